@@ -35,8 +35,9 @@ type Slice struct {
 }
 
 type WellKnownTypes struct {
-	Size scw.Size
-	Time time.Time
+	Size     scw.Size
+	Time     time.Time
+	Duration time.Duration
 }
 
 type Nested struct {
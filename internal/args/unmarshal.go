@@ -96,7 +96,7 @@ var unmarshalFuncs = map[reflect.Type]UnmarshalFunc{
 	},
 
 	reflect.TypeOf((*time.Duration)(nil)).Elem(): func(value string, dest interface{}) error {
-		duration, err := time.ParseDuration(value)
+		duration, err := parseDuration(value)
 		if err != nil {
 			return fmt.Errorf("failed to parse duration: %w", err)
 		}
@@ -116,7 +116,7 @@ var unmarshalFuncs = map[reflect.Type]UnmarshalFunc{
 		return nil
 	},
 	reflect.TypeOf((*scw.Duration)(nil)).Elem(): func(value string, dest interface{}) error {
-		duration, err := time.ParseDuration(value)
+		duration, err := parseDuration(value)
 		if err != nil {
 			return fmt.Errorf("failed to parse duration: %w", err)
 		}
@@ -125,6 +125,17 @@ var unmarshalFuncs = map[reflect.Type]UnmarshalFunc{
 	},
 }
 
+// parseDuration extends time.ParseDuration with support for a day unit (e.g. 30d).
+func parseDuration(value string) (time.Duration, error) {
+	if days, isDays := strings.CutSuffix(value, "d"); isDays {
+		count, err := strconv.ParseUint(days, 10, 32)
+		if err == nil {
+			return time.Duration(count) * 24 * time.Hour, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
+
 // UnmarshalStruct parses args like ["arg1=1", "arg2=2"] to a Go structure using reflection.
 //
 // args: slice of args passed through the command line
@@ -277,6 +277,24 @@ func TestUnmarshalStruct(t *testing.T) {
 		error: `cannot unmarshal arg 'time=-1R': cannot set nested field for unmarshalable type time.Time`,
 	}))
 
+	t.Run("Duration", run(TestCase{
+		args: []string{
+			"duration=1h30m",
+		},
+		expected: &WellKnownTypes{
+			Duration: 90 * time.Minute,
+		},
+	}))
+
+	t.Run("Duration in days", run(TestCase{
+		args: []string{
+			"duration=30d",
+		},
+		expected: &WellKnownTypes{
+			Duration: 30 * 24 * time.Hour,
+		},
+	}))
+
 	t.Run("nested-basic", run(TestCase{
 		args: []string{
 			"basic.string=test",
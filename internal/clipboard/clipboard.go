@@ -0,0 +1,62 @@
+// Package clipboard writes secrets to the clipboard of the OS with its copy tool:
+// pbcopy on macOS, clip on Windows and wl-copy, xclip or xsel on Linux.
+package clipboard
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+type provider interface {
+	Write(text string) error
+}
+
+var clipboardProvider provider = osProvider{}
+
+// Write replaces the content of the clipboard with text.
+func Write(text string) error {
+	return clipboardProvider.Write(text)
+}
+
+// runCopyTool runs a copy tool with text on its stdin, so that it does not appear in the process list.
+func runCopyTool(text string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(text)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return fmt.Errorf("%s not found, it is needed to copy to the clipboard", name)
+	case err != nil:
+		return fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// MockInit replaces the clipboard of the OS by an in-memory clipboard, to be used in tests.
+func MockInit() {
+	clipboardProvider = &mockProvider{}
+}
+
+// MockContent returns the content of the in-memory clipboard set up by MockInit.
+func MockContent() string {
+	mock, ok := clipboardProvider.(*mockProvider)
+	if !ok {
+		return ""
+	}
+	return mock.content
+}
+
+type mockProvider struct {
+	content string
+}
+
+func (m *mockProvider) Write(text string) error {
+	m.content = text
+	return nil
+}
@@ -0,0 +1,8 @@
+package clipboard
+
+// osProvider writes to the pasteboard with pbcopy.
+type osProvider struct{}
+
+func (osProvider) Write(text string) error {
+	return runCopyTool(text, "pbcopy")
+}
@@ -0,0 +1,27 @@
+package clipboard
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// osProvider writes to the clipboard with wl-copy on Wayland, or with xclip or xsel on X11.
+type osProvider struct{}
+
+func (osProvider) Write(text string) error {
+	tools := [][]string{
+		{"xclip", "-selection", "clipboard"},
+		{"xsel", "--clipboard", "--input"},
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		tools = append([][]string{{"wl-copy"}}, tools...)
+	}
+
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool[0]); err == nil {
+			return runCopyTool(text, tool[0], tool[1:]...)
+		}
+	}
+	return fmt.Errorf("no clipboard tool found, install wl-clipboard, xclip or xsel to copy to the clipboard")
+}
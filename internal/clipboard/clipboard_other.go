@@ -0,0 +1,15 @@
+//go:build !darwin && !linux && !windows
+
+package clipboard
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// osProvider is used on systems without a supported clipboard.
+type osProvider struct{}
+
+func (osProvider) Write(string) error {
+	return fmt.Errorf("copying to the clipboard is not supported on %s", runtime.GOOS)
+}
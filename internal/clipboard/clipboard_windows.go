@@ -0,0 +1,8 @@
+package clipboard
+
+// osProvider writes to the clipboard with clip.
+type osProvider struct{}
+
+func (osProvider) Write(text string) error {
+	return runCopyTool(text, "clip")
+}
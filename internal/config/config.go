@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"text/template"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -44,16 +45,167 @@ alias:
 #             - server
 #             - list
 {{- end }}
+
+# Binaries sets the path of the external binaries run by some commands, such as psql or ssh
+{{- if .Binaries }}
+binaries:
+    {{- range $binary, $path := .Binaries }}
+    {{ $binary }}: {{ $path }}
+    {{- end }}
+{{- else }}
+# binaries:
+#     psql: /usr/local/opt/libpq/bin/psql
+{{- end }}
+
+# Profile groups define named lists of profiles to use with --profiles
+{{- if .ProfileGroups }}
+profile_groups:
+    {{- range $group, $profiles := .ProfileGroups }}
+    {{ $group }}:
+    {{- range $index, $profile := $profiles }}
+        - {{ $profile }}
+    {{- end }}
+    {{- end }}
+{{- else }}
+# profile_groups:
+#     prod:
+#         - prod-fr
+#         - prod-nl
+{{- end }}
+
+# Confirm deletions lists the resources deleted by a command and asks for a confirmation before deleting them.
+# Confirmations are skipped with --yes or when SCW_AUTO_APPROVE is true
+{{ if .ConfirmDeletions }}confirm_deletions: true{{ else }}# confirm_deletions: true{{ end }}
+
+# Retry sets how requests failing with a 429 or 5xx error are retried, with an exponential backoff.
+# Settings can be overridden per profile and by the --max-retries and --retry-backoff flags
+{{- if .Retry }}
+retry:
+    {{- if .Retry.MaxRetries }}
+    max_retries: {{ .Retry.MaxRetries }}
+    {{- end }}
+    {{- if .Retry.Backoff }}
+    backoff: {{ .Retry.Backoff }}
+    {{- end }}
+    {{- if .Retry.Profiles }}
+    profiles:
+        {{- range $profile, $retry := .Retry.Profiles }}
+        {{ $profile }}:
+            {{- if $retry.MaxRetries }}
+            max_retries: {{ $retry.MaxRetries }}
+            {{- end }}
+            {{- if $retry.Backoff }}
+            backoff: {{ $retry.Backoff }}
+            {{- end }}
+        {{- end }}
+    {{- end }}
+{{- else }}
+# retry:
+#     max_retries: 3
+#     backoff: 1s
+#     profiles:
+#         prod:
+#             max_retries: 5
+{{- end }}
+
+# Budgets sets the monthly budget of projects in Euro, by project ID.
+# Create commands warn when the consumption of the month plus the estimated cost of the new resource exceeds the budget,
+# and fail with --enforce-budget
+{{- if .Budgets }}
+budgets:
+    {{- range $projectID, $budget := .Budgets }}
+    {{ $projectID }}: {{ $budget }}
+    {{- end }}
+{{- else }}
+# budgets:
+#     11111111-1111-1111-1111-111111111111: 100
+{{- end }}
+
+# Batch sets how commands operating on many resources, such as bulk deletions, run their requests.
+# Concurrency is the number of requests run at the same time, rate_limit the maximum number of requests started per second
+{{- if .Batch }}
+batch:
+    {{- if .Batch.Concurrency }}
+    concurrency: {{ .Batch.Concurrency }}
+    {{- end }}
+    {{- if .Batch.RateLimit }}
+    rate_limit: {{ .Batch.RateLimit }}
+    {{- end }}
+{{- else }}
+# batch:
+#     concurrency: 5
+#     rate_limit: 10
+{{- end }}
+
+# Command defaults sets default arguments per command, by command path.
+# Arguments given on the command line take precedence over these defaults
+{{- if .CommandDefaults }}
+command_defaults:
+    {{- range $command, $defaults := .CommandDefaults }}
+    {{ $command }}:
+        {{- range $name, $value := $defaults }}
+        {{ $name }}: {{ printf "%q" $value }}
+        {{- end }}
+    {{- end }}
+{{- else }}
+# command_defaults:
+#     instance.server.create:
+#         type: DEV1-S
+#         zone: fr-par-2
+{{- end }}
 `
 )
 
 type Config struct {
-	Alias  *alias.Config `json:"alias"`
-	Output string        `json:"output"`
+	Alias            *alias.Config       `json:"alias"`
+	Output           string              `json:"output"`
+	Binaries         map[string]string   `json:"binaries"`
+	ProfileGroups    map[string][]string `json:"profile_groups" yaml:"profile_groups"`
+	ConfirmDeletions bool                `json:"confirm_deletions" yaml:"confirm_deletions"`
+	Retry            *RetryConfig        `json:"retry" yaml:"retry"`
+	Budgets          map[string]float64  `json:"budgets" yaml:"budgets"`
+	Batch            *BatchConfig        `json:"batch" yaml:"batch"`
+	// CommandDefaults sets default arguments by command path, e.g. instance.server.create
+	CommandDefaults map[string]map[string]string `json:"command_defaults" yaml:"command_defaults"`
 
 	path string
 }
 
+// RetryConfig sets how requests failing with a 429 or 5xx error are retried.
+// Unset values fallback on the CLI defaults.
+type RetryConfig struct {
+	MaxRetries *int           `json:"max_retries" yaml:"max_retries"`
+	Backoff    *time.Duration `json:"backoff" yaml:"backoff"`
+	// Profiles overrides the settings for some profiles
+	Profiles map[string]*RetryConfig `json:"profiles" yaml:"profiles"`
+}
+
+// BatchConfig sets the concurrency of commands operating on many resources.
+// Unset values fallback on the CLI defaults.
+type BatchConfig struct {
+	Concurrency *int `json:"concurrency" yaml:"concurrency"`
+	// RateLimit is the maximum number of requests started per second
+	RateLimit *float64 `json:"rate_limit" yaml:"rate_limit"`
+}
+
+// ProfileSettings returns the retry settings of a profile, merged with the global settings.
+// Returned values are nil when they are not set.
+func (c *RetryConfig) ProfileSettings(profileName string) (maxRetries *int, backoff *time.Duration) {
+	if c == nil {
+		return nil, nil
+	}
+	maxRetries, backoff = c.MaxRetries, c.Backoff
+	if profile := c.Profiles[profileName]; profile != nil {
+		if profile.MaxRetries != nil {
+			maxRetries = profile.MaxRetries
+		}
+		if profile.Backoff != nil {
+			backoff = profile.Backoff
+		}
+	}
+	return maxRetries, backoff
+}
+
 // LoadConfig tries to load config file
 // returns a new empty config if file doesn't exist
 // return error if fail to load config file
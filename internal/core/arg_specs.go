@@ -93,7 +93,7 @@ type ArgSpec struct {
 	// ValidateFunc validates an argument.
 	ValidateFunc ArgSpecValidateFunc
 
-	// Positional defines whether the argument is a positional argument. NB: a positional argument is required.
+	// Positional defines whether the argument is a positional argument. NB: a positional argument can only be omitted when it is not required.
 	Positional bool
 
 	// Only one argument of the same OneOfGroup could be specified
@@ -143,6 +143,7 @@ func ZoneArgSpec(zones ...scw.Zone) *ArgSpec {
 			return &CliError{
 				Err:  fmt.Errorf("invalid zone %s", value),
 				Hint: "Zone format should look like XX-XXX-X (e.g. fr-par-1)",
+				Code: ExitCodeUsage,
 			}
 		},
 		Default: func(ctx context.Context) (value string, doc string) {
@@ -174,6 +175,7 @@ func RegionArgSpec(regions ...scw.Region) *ArgSpec {
 			return &CliError{
 				Err:  fmt.Errorf("invalid region %s", value),
 				Hint: "Region format should look like XX-XXX (e.g. fr-par)",
+				Code: ExitCodeUsage,
 			}
 		},
 		Default: func(ctx context.Context) (value string, doc string) {
@@ -35,8 +35,17 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 		{Name: "--config"},
 		{Name: "-D"},
 		{Name: "--debug"},
+		{
+			Name:             "--debug-file",
+			HasVariableValue: true,
+		},
 		{Name: "-h"},
 		{Name: "--help"},
+		{
+			Name:             "--max-retries",
+			HasVariableValue: true,
+		},
+		{Name: "--no-truncate"},
 		{
 			Name:       "-o",
 			EnumValues: printerTypes,
@@ -55,6 +64,19 @@ func getGlobalFlags(ctx context.Context) []FlagSpec {
 			HasVariableValue: true,
 			EnumValues:       profiles,
 		},
+		{
+			Name:       "--progress",
+			EnumValues: []string{ProgressFormatJSON.String()},
+		},
+		{
+			Name:             "--retry-backoff",
+			HasVariableValue: true,
+		},
+		{Name: "--show-sensitive"},
+		{
+			Name:             "--wait-timeout",
+			HasVariableValue: true,
+		},
 	}
 }
 
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchConcurrency = 5
+	maxBatchConcurrency     = 50
+)
+
+// BatchTask is an operation of a batch, such as the deletion of a resource.
+type BatchTask struct {
+	// Name identifies the task in errors, such as the ID of the resource
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// BatchOptions sets how the tasks of a batch are run.
+type BatchOptions struct {
+	// Concurrency is the maximum number of tasks run at the same time
+	Concurrency int
+	// RateLimit is the maximum number of tasks started per second, unlimited when 0
+	RateLimit float64
+}
+
+// BatchTaskError is the error of a task of a batch.
+type BatchTaskError struct {
+	Name string
+	Err  error
+}
+
+func (e *BatchTaskError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Name, e.Err)
+}
+
+func (e *BatchTaskError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError is returned when some tasks of a batch failed, the other tasks are run anyway.
+type BatchError struct {
+	Total  int
+	Errors []*BatchTaskError
+}
+
+func (e *BatchError) Error() string {
+	lines := []string{fmt.Sprintf("%d of %d operations failed:", len(e.Errors), e.Total)}
+	for _, taskErr := range e.Errors {
+		lines = append(lines, "  - "+taskErr.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, taskErr := range e.Errors {
+		errs = append(errs, taskErr)
+	}
+	return errs
+}
+
+// ExtractBatchOptions returns the batch options set in the batch section of the CLI config, or the defaults.
+func ExtractBatchOptions(ctx context.Context) *BatchOptions {
+	options := &BatchOptions{Concurrency: defaultBatchConcurrency}
+
+	cliCfg := ExtractCliConfig(ctx)
+	if cliCfg == nil || cliCfg.Batch == nil {
+		return options
+	}
+	if cliCfg.Batch.Concurrency != nil {
+		options.Concurrency = *cliCfg.Batch.Concurrency
+	}
+	if cliCfg.Batch.RateLimit != nil {
+		options.RateLimit = *cliCfg.Batch.RateLimit
+	}
+	return options
+}
+
+// RunBatch runs tasks concurrently with the batch options of the CLI config.
+// A *BatchError listing the failed tasks in the order of tasks is returned when some tasks failed.
+func RunBatch(ctx context.Context, tasks []*BatchTask) error {
+	return RunBatchWithOptions(ctx, ExtractBatchOptions(ctx), tasks)
+}
+
+// RunBatchWithOptions runs tasks concurrently, at most options.Concurrency at the same time and options.RateLimit per second.
+// Tasks not started when the context is canceled fail with the error of the context.
+func RunBatchWithOptions(ctx context.Context, options *BatchOptions, tasks []*BatchTask) error {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	if concurrency > maxBatchConcurrency {
+		concurrency = maxBatchConcurrency
+	}
+	interval := time.Duration(0)
+	if options.RateLimit > 0 {
+		interval = time.Duration(float64(time.Second) / options.RateLimit)
+	}
+
+	errs := make([]error, len(tasks))
+	slots := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	nextStart := time.Now()
+	for i, task := range tasks {
+		if err := waitBatchSlot(ctx, slots, nextStart); err != nil {
+			for j := i; j < len(tasks); j++ {
+				errs[j] = err
+			}
+			break
+		}
+		nextStart = time.Now().Add(interval)
+
+		wg.Add(1)
+		go func(i int, task *BatchTask) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = task.Run(ctx)
+		}(i, task)
+	}
+	wg.Wait()
+
+	batchErr := &BatchError{Total: len(tasks)}
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Errors = append(batchErr.Errors, &BatchTaskError{Name: tasks[i].Name, Err: err})
+		}
+	}
+	if len(batchErr.Errors) > 0 {
+		return batchErr
+	}
+	return nil
+}
+
+// waitBatchSlot waits for a free slot and for the start time of the next task given by the rate limit.
+func waitBatchSlot(ctx context.Context, slots chan struct{}, start time.Time) error {
+	if delay := time.Until(start); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case slots <- struct{}{}:
+	}
+	// A slot and the cancellation can be ready at the same time, canceled batches must not start new tasks
+	if err := ctx.Err(); err != nil {
+		<-slots
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunBatchWithOptions(t *testing.T) {
+	t.Run("concurrency", func(t *testing.T) {
+		running, maxRunning := int32(0), int32(0)
+		tasks := []*BatchTask(nil)
+		for i := 0; i < 10; i++ {
+			tasks = append(tasks, &BatchTask{
+				Name: fmt.Sprintf("task-%d", i),
+				Run: func(_ context.Context) error {
+					current := atomic.AddInt32(&running, 1)
+					for {
+						previous := atomic.LoadInt32(&maxRunning)
+						if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt32(&running, -1)
+					return nil
+				},
+			})
+		}
+
+		err := RunBatchWithOptions(context.Background(), &BatchOptions{Concurrency: 3}, tasks)
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), maxRunning)
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+		done := int32(0)
+		tasks := []*BatchTask(nil)
+		for i := 0; i < 5; i++ {
+			i := i
+			tasks = append(tasks, &BatchTask{
+				Name: fmt.Sprintf("task-%d", i),
+				Run: func(_ context.Context) error {
+					atomic.AddInt32(&done, 1)
+					if i%2 == 1 {
+						return errNotFound
+					}
+					return nil
+				},
+			})
+		}
+
+		err := RunBatchWithOptions(context.Background(), &BatchOptions{Concurrency: 2}, tasks)
+		batchErr := (*BatchError)(nil)
+		assert.ErrorAs(t, err, &batchErr)
+		assert.ErrorIs(t, err, errNotFound)
+		assert.Equal(t, int32(5), done)
+		assert.Equal(t, "2 of 5 operations failed:\n  - task-1: not found\n  - task-3: not found", err.Error())
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		tasks := []*BatchTask(nil)
+		for i := 0; i < 4; i++ {
+			tasks = append(tasks, &BatchTask{Run: func(_ context.Context) error { return nil }})
+		}
+
+		start := time.Now()
+		err := RunBatchWithOptions(context.Background(), &BatchOptions{Concurrency: 4, RateLimit: 50}, tasks)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 60*time.Millisecond)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		started := int32(0)
+		tasks := []*BatchTask(nil)
+		for i := 0; i < 3; i++ {
+			tasks = append(tasks, &BatchTask{
+				Name: fmt.Sprintf("task-%d", i),
+				Run: func(_ context.Context) error {
+					atomic.AddInt32(&started, 1)
+					cancel()
+					return nil
+				},
+			})
+		}
+
+		err := RunBatchWithOptions(ctx, &BatchOptions{Concurrency: 1}, tasks)
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, int32(1), started)
+	})
+}
@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/account"
 	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
@@ -79,23 +81,39 @@ type BootstrapConfig struct {
 // BootstrapConfig.Commands is a list of command available in CLI.
 func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err error) {
 	// Handles Flags
-	var debug bool
+	var debug debugMode
+	var debugFileFlag string
 	var profileFlag string
 	var configPathFlag string
 	var outputFlag string
+	var progressFlag string
+	var noTruncateFlag bool
+	var showSensitiveFlag bool
+	var maxRetriesFlag int
+	var retryBackoffFlag time.Duration
+	var waitTimeoutFlag time.Duration
 
 	flags := pflag.NewFlagSet(config.Args[0], pflag.ContinueOnError)
 	flags.StringVarP(&profileFlag, "profile", "p", "", "The config profile to use")
 	flags.StringVarP(&configPathFlag, "config", "c", "", "The path to the config file")
 	flags.StringVarP(&outputFlag, "output", "o", cliConfig.DefaultOutput, "Output format: json or human")
-	flags.BoolVarP(&debug, "debug", "D", os.Getenv("SCW_DEBUG") == "true", "Enable debug mode")
+	_ = debug.Set(os.Getenv("SCW_DEBUG"))
+	flags.VarP(&debug, "debug", "D", debugFlagUsage)
+	flags.Lookup("debug").NoOptDefVal = string(debugModeEnabled)
+	flags.StringVar(&debugFileFlag, "debug-file", "", debugFileFlagUsage)
+	flags.StringVar(&progressFlag, "progress", "", progressFlagUsage)
+	flags.BoolVar(&noTruncateFlag, "no-truncate", false, noTruncateFlagUsage)
+	flags.BoolVar(&showSensitiveFlag, "show-sensitive", false, showSensitiveFlagUsage)
+	flags.IntVar(&maxRetriesFlag, "max-retries", defaultMaxRetries, maxRetriesFlagUsage)
+	flags.DurationVar(&retryBackoffFlag, "retry-backoff", defaultRetryBackoff, retryBackoffFlagUsage)
+	flags.DurationVar(&waitTimeoutFlag, "wait-timeout", 0, waitTimeoutFlagUsage)
 	// Ignore unknown flag
 	flags.ParseErrorsWhitelist.UnknownFlags = true
 	// Make sure usage is never print by the parse method. (It should only be print by cobra)
 	flags.Usage = func() {}
 
 	// We don't do any error validation as:
-	// - debug is a boolean, no possible error
+	// - debug is a boolean or http, invalid values disable it
 	// - profileName will return proper error when we try to load profile
 	// - printerType will return proper error when we create the printer
 	// Furthermore additional flag can be added on a per-command basis inside cobra
@@ -108,7 +126,7 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		logLevel = logger.LogLevelError
 	}
 
-	if debug {
+	if debug == debugModeEnabled {
 		logLevel = logger.LogLevelDebug // enable debug mode
 	}
 
@@ -121,14 +139,33 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		}
 	}
 	log.level = logLevel
+	if debugFileFlag != "" {
+		debugFile, err := os.OpenFile(debugFileFlag, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			_, _ = fmt.Fprintln(config.Stderr, err)
+			return 1, nil, err
+		}
+		defer debugFile.Close()
+		log.writer = debugFile
+	}
 	logger.SetLogger(log)
 	log.Debugf("running: %s\n", config.Args)
 
+	// COLUMNS overrides the width of the terminal, as for most command line tools
+	columns, exists := config.OverrideEnv[columnsEnv]
+	if !exists {
+		columns = os.Getenv(columnsEnv)
+	}
+	terminalWidth, _ := strconv.Atoi(columns)
+
 	// The printer must be the first thing set in order to print errors
 	printer, err := NewPrinter(&PrinterConfig{
-		OutputFlag: outputFlag,
-		Stdout:     config.Stdout,
-		Stderr:     config.Stderr,
+		OutputFlag:    outputFlag,
+		Stdout:        config.Stdout,
+		Stderr:        config.Stderr,
+		NoTruncate:    noTruncateFlag,
+		Width:         terminalWidth,
+		ShowSensitive: showSensitiveFlag,
 	})
 	if err != nil {
 		_, _ = fmt.Fprintln(config.Stderr, err)
@@ -136,10 +173,55 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	}
 	interactive.SetOutputWriter(config.Stderr) // set printer for interactive function (always stderr).
 
+	progressFormat, err := parseProgressFormat(progressFlag)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return errorExitCode(err), nil, err
+	}
+
+	if maxRetriesFlag < 0 {
+		err := &CliError{
+			Err:  fmt.Errorf("invalid max retries %d", maxRetriesFlag),
+			Hint: "--max-retries must be positive, use 0 to disable retries",
+			Code: ExitCodeUsage,
+		}
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return errorExitCode(err), nil, err
+	}
+
+	if waitTimeoutFlag < 0 {
+		err := &CliError{
+			Err:  fmt.Errorf("invalid wait timeout %s", waitTimeoutFlag),
+			Hint: "--wait-timeout must be positive, omit it to use the default timeout of the command",
+			Code: ExitCodeUsage,
+		}
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(config.Stderr, printErr)
+		}
+		return errorExitCode(err), nil, err
+	}
+
+	var retryTransport *retryableHTTPTransport
+	var waitState *waitStateTransport
 	httpClient := config.HTTPClient
 	if httpClient == nil {
+		var transport http.RoundTripper = &SocketPassthroughTransport{}
+		if debug == debugModeHTTP {
+			transport = &httpDebugTransport{transport: transport, logger: log}
+		}
+		retryTransport = newRetryableHTTPTransport(transport)
+		waitState = &waitStateTransport{transport: retryTransport}
 		httpClient = &http.Client{
-			Transport: &retryableHTTPTransport{transport: &SocketPassthroughTransport{}},
+			Transport: &paginationCursorTransport{
+				transport: waitState,
+			},
 		}
 	}
 
@@ -180,6 +262,9 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		httpClient:                  httpClient,
 		isClientFromBootstrapConfig: isClientFromBootstrapConfig,
 		betaMode:                    config.BetaMode,
+		progressFormat:              progressFormat,
+		waitTimeout:                 waitTimeoutFlag,
+		waitState:                   waitState,
 	}
 	// We make sure OverrideEnv is never nil in meta.
 	if meta.OverrideEnv == nil {
@@ -198,6 +283,17 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	ctx = account.InjectHTTPClient(ctx, httpClient)
 	ctx = injectMeta(ctx, meta)
 
+	// Debug logs are also kept in the cache directory to be attached to bug reports
+	if debug != debugModeDisabled && debugFileFlag == "" {
+		lastDebugLog, err := createLastDebugLog(ctx)
+		if err != nil {
+			log.Debugf("cannot keep debug logs: %s\n", err)
+		} else {
+			defer lastDebugLog.Close()
+			log.writer = io.MultiWriter(log.writer, lastDebugLog)
+		}
+	}
+
 	// Load CLI config
 	cliCfg, err := cliConfig.LoadConfig(ExtractCliConfigPath(ctx))
 	if err != nil {
@@ -211,9 +307,12 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	if cliCfg.Output != cliConfig.DefaultOutput {
 		outputFlag = cliCfg.Output
 		printer, err = NewPrinter(&PrinterConfig{
-			OutputFlag: outputFlag,
-			Stdout:     config.Stdout,
-			Stderr:     config.Stderr,
+			OutputFlag:    outputFlag,
+			Stdout:        config.Stdout,
+			Stderr:        config.Stderr,
+			NoTruncate:    noTruncateFlag,
+			Width:         terminalWidth,
+			ShowSensitive: showSensitiveFlag,
 		})
 		if err != nil {
 			_, _ = fmt.Fprintln(config.Stderr, err)
@@ -221,6 +320,25 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		}
 	}
 
+	// Retries are set by the flags first, then by the retry settings of the profile in the CLI config
+	if retryTransport != nil {
+		retryTransport.maxRetries, retryTransport.backoff = maxRetriesFlag, retryBackoffFlag
+		if cliCfg.Retry != nil {
+			maxRetries, backoff := cliCfg.Retry.ProfileSettings(ExtractProfileName(ctx))
+			if maxRetries != nil && !flags.Changed("max-retries") {
+				retryTransport.maxRetries = *maxRetries
+			}
+			if backoff != nil && !flags.Changed("retry-backoff") {
+				retryTransport.backoff = *backoff
+			}
+		}
+		defer func() {
+			if retries := retryTransport.Retries(); retries > 0 {
+				log.Debugf("%d failed requests were retried\n", retries)
+			}
+		}()
+	}
+
 	// Run checks after command has been executed
 	defer func() { // if we plan to remove defer, do not forget logger is not set until cobra pre init func
 		// Check CLI new version and api key expiration date
@@ -246,7 +364,38 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 		return 0, meta.result, nil
 	}
 
+	// Commands and aliases of the CLI take precedence over plugins
+	if plugin, pluginArgs := findPluginCommand(ctx, rootCmd, flags.Args()[1:], config.Args[1:]); plugin != nil {
+		exitCode, err := runPlugin(ctx, plugin, pluginArgs)
+		if err != nil {
+			printErr := printer.Print(err, nil)
+			if printErr != nil {
+				_, _ = fmt.Fprintln(config.Stderr, printErr)
+			}
+			return 1, nil, err
+		}
+		return exitCode, nil, nil
+	}
+
 	args := config.Args[1:]
+	// Wizard mode prompts the arguments of a command, then runs it as if they were given on the command line
+	if len(args) > 0 && args[0] == wizardCommandName && !isHelpArgs(args) {
+		args, err = runWizard(ctx, config.Commands, args[1:])
+		if err != nil {
+			if _, ok := err.(*interactive.InterruptError); ok {
+				return ExitCodeInterrupted, nil, err
+			}
+			printErr := printer.Print(err, nil)
+			if printErr != nil {
+				_, _ = fmt.Fprintln(config.Stderr, printErr)
+			}
+			return 1, nil, err
+		}
+		if args == nil {
+			return 0, nil, nil
+		}
+	}
+
 	// Do not resolve aliases if using a disabled namespace
 	if (len(config.Args) < 2 || !aliasDisabled(config.Args[1])) && !config.DisableAliases {
 		args = meta.CliConfig.Alias.ResolveAliases(args)
@@ -257,28 +406,32 @@ func Bootstrap(config *BootstrapConfig) (exitCode int, result interface{}, err e
 	rootCmd.PersistentFlags().StringVarP(&profileFlag, "profile", "p", "", "The config profile to use")
 	rootCmd.PersistentFlags().StringVarP(&configPathFlag, "config", "c", "", "The path to the config file")
 	rootCmd.PersistentFlags().StringVarP(&outputFlag, "output", "o", cliConfig.DefaultOutput, "Output format: json or human, see 'scw help output' for more info")
-	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "D", false, "Enable debug mode")
+	rootCmd.PersistentFlags().VarP(new(debugMode), "debug", "D", debugFlagUsage)
+	rootCmd.PersistentFlags().Lookup("debug").NoOptDefVal = string(debugModeEnabled)
+	rootCmd.PersistentFlags().StringVar(&debugFileFlag, "debug-file", "", debugFileFlagUsage)
+	rootCmd.PersistentFlags().StringVar(&progressFlag, "progress", "", progressFlagUsage)
+	rootCmd.PersistentFlags().BoolVar(&noTruncateFlag, "no-truncate", false, noTruncateFlagUsage)
+	rootCmd.PersistentFlags().IntVar(&maxRetriesFlag, "max-retries", defaultMaxRetries, maxRetriesFlagUsage)
+	rootCmd.PersistentFlags().DurationVar(&retryBackoffFlag, "retry-backoff", defaultRetryBackoff, retryBackoffFlagUsage)
+	rootCmd.PersistentFlags().BoolVar(&showSensitiveFlag, "show-sensitive", false, showSensitiveFlagUsage)
+	rootCmd.PersistentFlags().DurationVar(&waitTimeoutFlag, "wait-timeout", 0, waitTimeoutFlagUsage)
 	rootCmd.SetArgs(args)
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
 	err = rootCmd.Execute()
 
 	if err != nil {
 		if _, ok := err.(*interactive.InterruptError); ok {
-			return 130, nil, err
-		}
-		errorCode := 1
-		if cliErr, ok := err.(*CliError); ok && cliErr.Code != 0 {
-			errorCode = cliErr.Code
+			return ExitCodeInterrupted, nil, err
 		}
 		printErr := printer.Print(err, nil)
 		if printErr != nil {
 			_, _ = fmt.Fprintln(os.Stderr, err)
 		}
-		return errorCode, nil, err
+		return errorExitCode(err), nil, err
 	}
 
 	if meta.command != nil {
-		printErr := printer.Print(meta.result, meta.command.getHumanMarshalerOpt())
+		printErr := printer.printResult(meta.command, meta.result, meta.humanMarshalerOpt())
 		if printErr != nil {
 			_, _ = fmt.Fprintln(config.Stderr, printErr)
 		}
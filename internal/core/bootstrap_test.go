@@ -150,3 +150,41 @@ func TestInterruptError(t *testing.T) {
 		},
 	}))
 }
+
+func TestUsageErrorExitCode(t *testing.T) {
+	commands := NewCommands(
+		&Command{
+			Namespace: "test",
+			Resource:  "list",
+			Verb:      "list",
+			ArgsType:  reflect.TypeOf(args.RawArgs{}),
+			Run: func(_ context.Context, _ interface{}) (i interface{}, e error) {
+				return []*struct{ Name string }{{Name: "a"}, {Name: "b"}}, nil
+			},
+		},
+		&Command{
+			Namespace: "test",
+			Resource:  "object",
+			Verb:      "list",
+			ArgsType:  reflect.TypeOf(args.RawArgs{}),
+			Run: func(_ context.Context, _ interface{}) (i interface{}, e error) {
+				return &struct{ Name string }{Name: "a"}, nil
+			},
+		},
+	)
+
+	for name, cmd := range map[string]string{
+		"max-retries":       "scw --max-retries=-1 test list list",
+		"progress":          "scw --progress=bogus test list list",
+		"wait-timeout":      "scw --wait-timeout=-1s test list list",
+		"sort-by-malformed": "scw test list list --sort-by=name:up",
+		"sort-by-unknown":   "scw test list list --sort-by=nope",
+		"sort-by-not-list":  "scw test object list --sort-by=name",
+	} {
+		t.Run(name, Test(&TestConfig{
+			Commands: commands,
+			Cmd:      cmd,
+			Check:    TestCheckExitCode(ExitCodeUsage),
+		}))
+	}
+}
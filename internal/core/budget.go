@@ -0,0 +1,105 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	billing "github.com/scaleway/scaleway-sdk-go/api/billing/v2alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/spf13/cobra"
+)
+
+const enforceBudgetFlagUsage = "Fail instead of warning when the estimated cost exceeds the monthly budget of the project"
+
+// CostEstimate is the estimated cost of the resource created by a command.
+type CostEstimate struct {
+	// ProjectID is the project of the resource, the default project is used when empty
+	ProjectID string
+	// MonthlyCost is the estimated monthly cost of the resource in Euro
+	MonthlyCost float64
+	// Resource describes the resource, such as "server DEV1-S"
+	Resource string
+}
+
+// CommandCostEstimateFunc returns the estimated cost of the resource created by a command.
+type CommandCostEstimateFunc func(ctx context.Context, argsI interface{}) (*CostEstimate, error)
+
+// checkBudget warns when the consumption of the month plus the estimated cost of the created resource
+// exceeds the budget of the project set in the CLI config. With --enforce-budget, it returns an error instead.
+// Budget checks are best effort: they are skipped when the cost or the consumption cannot be fetched.
+func checkBudget(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, cmdArgs interface{}) error {
+	cliCfg := ExtractCliConfig(ctx)
+	if cliCfg == nil || len(cliCfg.Budgets) == 0 {
+		return nil
+	}
+
+	estimate, err := cmd.CostEstimate(ctx, cmdArgs)
+	if err != nil {
+		ExtractLogger(ctx).Debugf("cannot estimate the cost of the command: %s\n", err)
+		return nil
+	}
+	if estimate == nil {
+		return nil
+	}
+
+	projectID := estimate.ProjectID
+	if projectID == "" {
+		projectID, _ = ExtractClient(ctx).GetDefaultProjectID()
+	}
+	budget, exists := cliCfg.Budgets[projectID]
+	if !exists {
+		return nil
+	}
+
+	consumptions, err := MonthlyConsumptionByProject(ctx)
+	if err != nil {
+		ExtractLogger(ctx).Debugf("cannot get the consumption of project %s: %s\n", projectID, err)
+		return nil
+	}
+	consumption := consumptions[projectID]
+	if consumption+estimate.MonthlyCost <= budget {
+		return nil
+	}
+
+	message := formatBudgetExceeded(projectID, budget, consumption, estimate)
+	if enforce, err := cobraCmd.PersistentFlags().GetBool("enforce-budget"); err == nil && enforce {
+		return &CliError{
+			Err:  fmt.Errorf("%s", message),
+			Hint: "Increase the budget with 'scw billing budget set' or run the command without --enforce-budget",
+		}
+	}
+
+	ExtractLogger(ctx).Warningf("%s\n", message)
+	return nil
+}
+
+func formatBudgetExceeded(projectID string, budget float64, consumption float64, estimate *CostEstimate) string {
+	return fmt.Sprintf("monthly budget of project %s exceeded: %.2f€ consumed this month + %.2f€ estimated for %s > %.2f€ budget",
+		projectID, consumption, estimate.MonthlyCost, estimate.Resource, budget)
+}
+
+// MonthlyConsumptionByProject returns the consumption of the current month in Euro by project ID.
+func MonthlyConsumptionByProject(ctx context.Context) (map[string]float64, error) {
+	client := ExtractClient(ctx)
+	organizationID, _ := client.GetDefaultOrganizationID()
+
+	res, err := billing.NewAPI(client).GetConsumption(&billing.GetConsumptionRequest{
+		OrganizationID: organizationID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return sumConsumptionByProject(res.Consumptions), nil
+}
+
+func sumConsumptionByProject(consumptions []*billing.GetConsumptionResponseConsumption) map[string]float64 {
+	totals := map[string]float64{}
+	for _, consumption := range consumptions {
+		if consumption.Value != nil {
+			totals[consumption.ProjectID] += consumption.Value.ToFloat()
+		}
+	}
+
+	return totals
+}
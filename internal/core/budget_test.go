@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	billing "github.com/scaleway/scaleway-sdk-go/api/billing/v2alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sumConsumptionByProject(t *testing.T) {
+	consumptions := []*billing.GetConsumptionResponseConsumption{
+		{ProjectID: "project-a", Value: scw.NewMoneyFromFloat(12.5, "EUR", 2)},
+		{ProjectID: "project-a", Value: scw.NewMoneyFromFloat(7.25, "EUR", 2)},
+		{ProjectID: "project-b", Value: scw.NewMoneyFromFloat(3, "EUR", 2)},
+		{ProjectID: "project-b"},
+	}
+
+	assert.Equal(t, map[string]float64{
+		"project-a": 19.75,
+		"project-b": 3,
+	}, sumConsumptionByProject(consumptions))
+}
+
+func Test_formatBudgetExceeded(t *testing.T) {
+	estimate := &CostEstimate{MonthlyCost: 36.5, Resource: "server PRO2-XS"}
+
+	assert.Equal(t,
+		"monthly budget of project project-a exceeded: 80.00€ consumed this month + 36.50€ estimated for server PRO2-XS > 100.00€ budget",
+		formatBudgetExceeded("project-a", 100, 80, estimate))
+}
@@ -53,6 +53,11 @@ func (b *cobraBuilder) build() *cobra.Command {
 
 	rootCmd.SetOut(b.meta.stderr)
 
+	// Invalid flags are usage errors, like invalid arguments.
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &CliError{Err: err, Code: ExitCodeUsage}
+	})
+
 	for _, cmd := range commands {
 		// If namespace command has not yet been created. We create an empty cobra command to allow leaf to be attached.
 		if _, namespaceExist := index[cmd.Namespace]; !namespaceExist {
@@ -175,6 +180,29 @@ func (b *cobraBuilder) hydrateCobra(cobraCmd *cobra.Command, cmd *Command, group
 	if commandHasWeb(cmd) {
 		cobraCmd.PersistentFlags().Bool("web", false, "open console page for the current ressource")
 	}
+
+	if cmd.DeletePreview != nil {
+		cobraCmd.PersistentFlags().Bool("yes", false, yesFlagUsage)
+	}
+
+	if cmd.CostEstimate != nil {
+		cobraCmd.PersistentFlags().Bool("enforce-budget", false, enforceBudgetFlagUsage)
+	}
+
+	if cmd.PickArgs != nil {
+		cobraCmd.PersistentFlags().Bool("no-interactive", false, noInteractiveFlagUsage)
+	}
+
+	if cmd.Verb == "list" {
+		cobraCmd.PersistentFlags().String("sort-by", "", sortByFlagUsage)
+		cobraCmd.PersistentFlags().Bool("resume", false, resumeFlagUsage)
+	}
+
+	// Only read-only commands can run with several profiles.
+	if cmd.Verb == "list" || cmd.Verb == "get" {
+		cobraCmd.PersistentFlags().String("profiles", "", profilesFlagUsage)
+		cobraCmd.PersistentFlags().Bool("all-profiles", false, allProfilesFlagUsage)
+	}
 }
 
 const usageTemplate = `USAGE:
@@ -24,7 +24,7 @@ func Test_UnknownCommand(t *testing.T) {
 		Cmd:      "scw instance foobar",
 		Check: TestCheckCombine(
 			TestCheckGolden(),
-			TestCheckExitCode(1),
+			TestCheckExitCode(ExitCodeUsage),
 		),
 	}))
 
@@ -33,7 +33,7 @@ func Test_UnknownCommand(t *testing.T) {
 		Cmd:      "scw instance server foobar",
 		Check: TestCheckCombine(
 			TestCheckGolden(),
-			TestCheckExitCode(1),
+			TestCheckExitCode(ExitCodeUsage),
 		),
 	}))
 }
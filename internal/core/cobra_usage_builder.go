@@ -72,7 +72,7 @@ func _buildUsageArgs(ctx context.Context, w io.Writer, argSpecs ArgSpecs) error
 			_, doc := argSpec.Default(ctx)
 			argSpecUsageLeftPart = fmt.Sprintf("%s=%s", argSpecUsageLeftPart, doc)
 		}
-		if !argSpec.Required && !argSpec.Positional {
+		if !argSpec.Required {
 			argSpecUsageLeftPart = fmt.Sprintf("[%s]", argSpecUsageLeftPart)
 		}
 		if argSpec.CanLoadFile {
@@ -19,81 +19,121 @@ func cobraRun(ctx context.Context, cmd *Command) func(*cobra.Command, []string)
 
 		meta := extractMeta(ctx)
 		meta.command = cmd
+		meta.multiProfile = false
 
 		sentry.AddCommandContext(cmd.GetCommandLine("scw"))
 
-		// If command requires authentication and the client was not directly provided in the bootstrap config, we create a new client and overwrite the existing one
-		if !cmd.AllowAnonymousClient && !meta.isClientFromBootstrapConfig {
-			client, err := meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx))
-			if err != nil {
-				return createClientError(err)
+		profiles, err := getProfilesFlags(ctx, cobraCmd)
+		if err != nil {
+			return err
+		}
+
+		var result interface{}
+		if len(profiles) > 0 {
+			result, err = runOnProfiles(ctx, profiles, func() (interface{}, error) {
+				return runWithClient(ctx, cobraCmd, cmd, rawArgs)
+			})
+			// Each profile result is already sorted, the merged list is sorted as a whole.
+			sortBy, flagErr := cobraCmd.PersistentFlags().GetString("sort-by")
+			if err == nil && flagErr == nil && sortBy != "" {
+				result, err = sortResult(result, sortBy)
 			}
-			meta.Client = client
+		} else {
+			result, err = runWithClient(ctx, cobraCmd, cmd, rawArgs)
+		}
+		if err != nil {
+			return err
 		}
 
-		// If command has no Run method there is nothing to do.
-		if cmd.Run == nil {
-			return nil
+		meta.result = result
+		return nil
+	}
+}
+
+// runWithClient creates the client of the current profile when needed then runs the command once per positional argument.
+func runWithClient(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs args.RawArgs) (interface{}, error) {
+	meta := extractMeta(ctx)
+
+	// If command requires authentication and the client was not directly provided in the bootstrap config, we create a new client and overwrite the existing one
+	if !cmd.AllowAnonymousClient && !meta.isClientFromBootstrapConfig {
+		client, err := meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx))
+		if err != nil {
+			return nil, createClientError(err)
 		}
+		meta.Client = client
+	}
 
-		// Apply default values on missing args.
-		rawArgs = ApplyDefaultValues(ctx, cmd.ArgSpecs, rawArgs)
+	// If command has no Run method there is nothing to do.
+	if cmd.Run == nil {
+		return nil, nil
+	}
 
-		positionalArgSpec := cmd.ArgSpecs.GetPositionalArg()
+	if cmd.RawArgsRewriter != nil {
+		rawArgs = cmd.RawArgsRewriter(rawArgs)
+	}
 
-		// If this command has no positional argument we execute the run
-		if positionalArgSpec == nil {
-			data, err := run(ctx, cobraCmd, cmd, rawArgs)
-			if err != nil {
-				return err
-			}
+	// Apply the default arguments set in the CLI config before picking the missing ones.
+	rawArgs = applyCommandDefaults(ctx, cmd, rawArgs)
 
-			meta.result = data
-			return nil
-		}
+	rawArgs, err := pickArgs(ctx, cobraCmd, cmd, rawArgs)
+	if err != nil {
+		return nil, err
+	}
 
-		positionalArgs := rawArgs.GetPositionalArgs()
+	// Apply default values on missing args.
+	rawArgs = ApplyDefaultValues(ctx, cmd.ArgSpecs, rawArgs)
 
-		// Return an error if a positional argument was provide using `key=value` syntax.
-		value, exist := rawArgs.Get(positionalArgSpec.Name)
-		if exist {
-			otherArgs := rawArgs.Remove(positionalArgSpec.Name)
-			return &CliError{
-				Err:  fmt.Errorf("a positional argument is required for this command"),
-				Hint: positionalArgHint(meta.BinaryName, cmd, value, otherArgs, len(positionalArgs) > 0),
-			}
-		}
+	positionalArgSpec := cmd.ArgSpecs.GetPositionalArg()
 
-		// If no positional arguments were provided, return an error
-		if len(positionalArgs) == 0 {
-			return &CliError{
-				Err:  fmt.Errorf("a positional argument is required for this command"),
-				Hint: positionalArgHint(meta.BinaryName, cmd, "<"+positionalArgSpec.Name+">", rawArgs, false),
-			}
-		}
+	// If this command has no positional argument we execute the run
+	if positionalArgSpec == nil {
+		return run(ctx, cobraCmd, cmd, rawArgs)
+	}
 
-		results := MultiResults(nil)
-		rawArgs = rawArgs.RemoveAllPositional()
-		for _, positionalArg := range positionalArgs {
-			rawArgsWithPositional := rawArgs.Add(positionalArgSpec.Name, positionalArg)
+	positionalArgs := rawArgs.GetPositionalArgs()
 
-			result, err := run(ctx, cobraCmd, cmd, rawArgsWithPositional)
-			if err != nil {
-				return err
-			}
+	// Return an error if a positional argument was provide using `key=value` syntax.
+	value, exist := rawArgs.Get(positionalArgSpec.Name)
+	if exist {
+		otherArgs := rawArgs.Remove(positionalArgSpec.Name)
+		return nil, &CliError{
+			Err:  fmt.Errorf("a positional argument is required for this command"),
+			Hint: positionalArgHint(meta.BinaryName, cmd, value, otherArgs, len(positionalArgs) > 0),
+			Code: ExitCodeUsage,
+		}
+	}
 
-			results = append(results, result)
+	// If no positional arguments were provided, return an error unless the command allows it
+	if len(positionalArgs) == 0 && cmd.OptionalPositionalArg && !positionalArgSpec.Required {
+		return run(ctx, cobraCmd, cmd, rawArgs)
+	}
+	if len(positionalArgs) == 0 {
+		return nil, &CliError{
+			Err:  fmt.Errorf("a positional argument is required for this command"),
+			Hint: positionalArgHint(meta.BinaryName, cmd, "<"+positionalArgSpec.Name+">", rawArgs, false),
+			Code: ExitCodeUsage,
 		}
-		// If only one positional parameter was provided we return the result directly instead of
-		// an array of results
-		if len(results) == 1 {
-			meta.result = results[0]
-		} else {
-			meta.result = results
+	}
+
+	results := MultiResults(nil)
+	rawArgs = rawArgs.RemoveAllPositional()
+	for _, positionalArg := range positionalArgs {
+		rawArgsWithPositional := rawArgs.Add(positionalArgSpec.Name, positionalArg)
+
+		result, err := run(ctx, cobraCmd, cmd, rawArgsWithPositional)
+		if err != nil {
+			return nil, err
 		}
 
-		return nil
+		results = append(results, result)
 	}
+	// If only one positional parameter was provided we return the result directly instead of
+	// an array of results
+	if len(results) == 1 {
+		return results[0], nil
+	}
+
+	return results, nil
 }
 
 func run(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs []string) (interface{}, error) {
@@ -145,6 +185,23 @@ func run(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs []s
 		return runWeb(cmd, cmdArgs)
 	}
 
+	if cmd.DeletePreview != nil {
+		confirmed, err := confirmDeletion(ctx, cobraCmd, cmd, cmdArgs)
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &SuccessResult{Message: "Deletion canceled"}, nil
+		}
+	}
+
+	if cmd.CostEstimate != nil {
+		err = checkBudget(ctx, cobraCmd, cmd, cmdArgs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// execute the command
 	interceptor := combineCommandInterceptor(
 		sdkStdErrorInterceptor,
@@ -152,15 +209,27 @@ func run(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs []s
 		cmd.Interceptor,
 	)
 
+	stopPaginationCursor := startPaginationCursor(ctx, cobraCmd, cmd)
+	ReportProgressStep(ctx, "run", ProgressStateStarted)
 	data, err := interceptor(ctx, cmdArgs, func(ctx context.Context, argsI interface{}) (i interface{}, err error) {
 		return cmd.Run(ctx, argsI)
 	})
+	err = ReportProgressResult(ctx, "run", stopPaginationCursor(err))
 	if err != nil {
-		return nil, err
+		return nil, addErrorHint(ctx, cmd, cmdArgs, err)
 	}
 	waitFlag, err := cobraCmd.PersistentFlags().GetBool("wait")
 	if err == nil && cmd.WaitFunc != nil && waitFlag {
-		data, err = cmd.WaitFunc(ctx, cmdArgs, data)
+		ReportProgressStep(ctx, "wait", ProgressStateStarted)
+		data, err = runWaitFunc(ctx, cmd, cmdArgs, data)
+		err = ReportProgressResult(ctx, "wait", err)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sortBy, err := cobraCmd.PersistentFlags().GetString("sort-by")
+	if err == nil && sortBy != "" {
+		data, err = sortResult(data, sortBy)
 		if err != nil {
 			return nil, err
 		}
@@ -195,6 +264,7 @@ func handleUnmarshalErrors(cmd *Command, unmarshalErr *args.UnmarshalArgError) e
 				Err:     fmt.Errorf(""),
 				Message: fmt.Sprintf("invalid value for '%s' argument: invalid boolean value", unmarshalErr.ArgName),
 				Hint:    "Possible values: true, false",
+				Code:    ExitCodeUsage,
 			}
 		case *args.CannotParseDateError:
 			dateErr := e.Err.(*args.CannotParseDateError)
@@ -205,10 +275,12 @@ func handleUnmarshalErrors(cmd *Command, unmarshalErr *args.UnmarshalArgError) e
 Relative time error: %s
 `, dateErr.AbsoluteTimeParseError, dateErr.RelativeTimeParseError),
 				Hint: "Run `scw help date` to learn more about date parsing",
+				Code: ExitCodeUsage,
 			}
 		default:
 			return &CliError{
-				Err: fmt.Errorf("invalid value for '%s' argument: %s", unmarshalErr.ArgName, e.Err),
+				Err:  fmt.Errorf("invalid value for '%s' argument: %s", unmarshalErr.ArgName, e.Err),
+				Code: ExitCodeUsage,
 			}
 		}
 	case *args.InvalidArgNameError:
@@ -221,6 +293,7 @@ Relative time error: %s
 		return &CliError{
 			Err:  fmt.Errorf("invalid argument '%s': %s", unmarshalErr.ArgName, e.Error()),
 			Hint: fmt.Sprintf("Valid arguments are: %s", strings.Join(argNames, ", ")),
+			Code: ExitCodeUsage,
 		}
 	case *args.UnknownArgError:
 		argNames := []string(nil)
@@ -232,10 +305,11 @@ Relative time error: %s
 		return &CliError{
 			Err:  fmt.Errorf("unknown argument '%s'", unmarshalErr.ArgName),
 			Hint: fmt.Sprintf("Valid arguments are: %s", strings.Join(argNames, ", ")),
+			Code: ExitCodeUsage,
 		}
 
 	default:
-		return &CliError{Err: unmarshalErr}
+		return &CliError{Err: unmarshalErr, Code: ExitCodeUsage}
 	}
 }
 
@@ -255,6 +329,6 @@ func cobraRunHelp(cmd *Command) func(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
-		return &CliError{Empty: true, Code: 1}
+		return &CliError{Empty: true, Code: ExitCodeUsage}
 	}
 }
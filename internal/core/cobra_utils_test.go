@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/stretchr/testify/assert"
 )
 
 type testType struct {
@@ -40,6 +41,7 @@ func testGetCommands() *Commands {
 			ArgSpecs: ArgSpecs{
 				{
 					Name:       "name-id",
+					Required:   true,
 					Positional: true,
 				},
 				{
@@ -52,6 +54,37 @@ func testGetCommands() *Commands {
 				return argsI, nil
 			},
 		},
+		&Command{
+			Namespace: "test",
+			Resource:  "optional-positional",
+			ArgSpecs: ArgSpecs{
+				{
+					Name:       "name-id",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient:  true,
+			OptionalPositionalArg: true,
+			ArgsType:              reflect.TypeOf(testType{}),
+			Run: func(_ context.Context, argsI interface{}) (i interface{}, e error) {
+				return argsI, nil
+			},
+		},
+		&Command{
+			Namespace: "test",
+			Resource:  "not-required-positional",
+			ArgSpecs: ArgSpecs{
+				{
+					Name:       "name-id",
+					Positional: true,
+				},
+			},
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(testType{}),
+			Run: func(_ context.Context, argsI interface{}) (i interface{}, e error) {
+				return argsI, nil
+			},
+		},
 		&Command{
 			Namespace:            "test",
 			Resource:             "raw-args",
@@ -87,10 +120,11 @@ func Test_handleUnmarshalErrors(t *testing.T) {
 		Commands: testGetCommands(),
 		Cmd:      "scw test name_id",
 		Check: TestCheckCombine(
-			TestCheckExitCode(1),
+			TestCheckExitCode(ExitCodeUsage),
 			TestCheckError(&CliError{
 				Err:  fmt.Errorf("invalid argument 'name_id': arg name must only contain lowercase letters, numbers or dashes"),
 				Hint: "Valid arguments are: name-id",
+				Code: ExitCodeUsage,
 			}),
 		),
 	}))
@@ -99,10 +133,11 @@ func Test_handleUnmarshalErrors(t *testing.T) {
 		Commands: testGetCommands(),
 		Cmd:      "scw test ubuntu_focal",
 		Check: TestCheckCombine(
-			TestCheckExitCode(1),
+			TestCheckExitCode(ExitCodeUsage),
 			TestCheckError(&CliError{
 				Err:  fmt.Errorf("invalid argument 'ubuntu_focal': arg name must only contain lowercase letters, numbers or dashes"),
 				Hint: "Valid arguments are: name-id",
+				Code: ExitCodeUsage,
 			}),
 		),
 	}))
@@ -111,7 +146,7 @@ func Test_handleUnmarshalErrors(t *testing.T) {
 		Commands: testGetCommands(),
 		Cmd:      "scw test date date=+3R",
 		Check: TestCheckCombine(
-			TestCheckExitCode(1),
+			TestCheckExitCode(ExitCodeUsage),
 			TestCheckError(&CliError{
 				Message: "could not parse +3R as either an absolute time (RFC3339) nor a relative time (+/-)RFC3339",
 				Details: `Absolute time error: parsing time "+3R" as "2006-01-02T15:04:05Z07:00": cannot parse "+3R" as "2006"
@@ -119,6 +154,7 @@ Relative time error: unknown unit in duration: "R"
 `,
 				Err:  fmt.Errorf("date parsing error: +3R"),
 				Hint: "Run `scw help date` to learn more about date parsing",
+				Code: ExitCodeUsage,
 			}),
 		),
 	}))
@@ -149,10 +185,11 @@ func Test_PositionalArg(t *testing.T) {
 			Commands: testGetCommands(),
 			Cmd:      "scw test positional",
 			Check: TestCheckCombine(
-				TestCheckExitCode(1),
+				TestCheckExitCode(ExitCodeUsage),
 				TestCheckError(&CliError{
 					Err:  fmt.Errorf("a positional argument is required for this command"),
 					Hint: "Try running: scw test positional <name-id>",
+					Code: ExitCodeUsage,
 				}),
 			),
 		}))
@@ -161,10 +198,24 @@ func Test_PositionalArg(t *testing.T) {
 			Commands: testGetCommands(),
 			Cmd:      "scw test positional tag=world",
 			Check: TestCheckCombine(
-				TestCheckExitCode(1),
+				TestCheckExitCode(ExitCodeUsage),
 				TestCheckError(&CliError{
 					Err:  fmt.Errorf("a positional argument is required for this command"),
 					Hint: "Try running: scw test positional <name-id> tag=world",
+					Code: ExitCodeUsage,
+				}),
+			),
+		}))
+
+		t.Run("Missing not required", Test(&TestConfig{
+			Commands: testGetCommands(),
+			Cmd:      "scw test not-required-positional",
+			Check: TestCheckCombine(
+				TestCheckExitCode(ExitCodeUsage),
+				TestCheckError(&CliError{
+					Err:  fmt.Errorf("a positional argument is required for this command"),
+					Hint: "Try running: scw test not-required-positional <name-id>",
+					Code: ExitCodeUsage,
 				}),
 			),
 		}))
@@ -173,10 +224,11 @@ func Test_PositionalArg(t *testing.T) {
 			Commands: testGetCommands(),
 			Cmd:      "scw test positional name-id=plop tag=world",
 			Check: TestCheckCombine(
-				TestCheckExitCode(1),
+				TestCheckExitCode(ExitCodeUsage),
 				TestCheckError(&CliError{
 					Err:  fmt.Errorf("a positional argument is required for this command"),
 					Hint: "Try running: scw test positional plop tag=world",
+					Code: ExitCodeUsage,
 				}),
 			),
 		}))
@@ -185,10 +237,11 @@ func Test_PositionalArg(t *testing.T) {
 			Commands: testGetCommands(),
 			Cmd:      "scw test positional tag=world name-id=plop",
 			Check: TestCheckCombine(
-				TestCheckExitCode(1),
+				TestCheckExitCode(ExitCodeUsage),
 				TestCheckError(&CliError{
 					Err:  fmt.Errorf("a positional argument is required for this command"),
 					Hint: "Try running: scw test positional plop tag=world",
+					Code: ExitCodeUsage,
 				}),
 			),
 		}))
@@ -197,10 +250,11 @@ func Test_PositionalArg(t *testing.T) {
 			Commands: testGetCommands(),
 			Cmd:      "scw test positional plop name-id=plop",
 			Check: TestCheckCombine(
-				TestCheckExitCode(1),
+				TestCheckExitCode(ExitCodeUsage),
 				TestCheckError(&CliError{
 					Err:  fmt.Errorf("a positional argument is required for this command"),
 					Hint: "Try running: scw test positional plop",
+					Code: ExitCodeUsage,
 				}),
 			),
 		}))
@@ -212,6 +266,21 @@ func Test_PositionalArg(t *testing.T) {
 		Check:    TestCheckExitCode(0),
 	}))
 
+	t.Run("optional", Test(&TestConfig{
+		Commands: testGetCommands(),
+		Cmd:      "scw test optional-positional",
+		Check:    TestCheckExitCode(0),
+	}))
+
+	t.Run("optional given", Test(&TestConfig{
+		Commands: testGetCommands(),
+		Cmd:      "scw test optional-positional plop",
+		Check: func(t *testing.T, ctx *CheckFuncCtx) {
+			t.Helper()
+			assert.Equal(t, "plop", ctx.Result.(*testType).NameID)
+		},
+	}))
+
 	t.Run("full command", Test(&TestConfig{
 		Commands: testGetCommands(),
 		Cmd:      "scw test positional plop tag=world",
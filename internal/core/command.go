@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/alias"
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 )
 
@@ -47,6 +48,13 @@ type Command struct {
 	// ArgSpecs defines specifications for arguments.
 	ArgSpecs ArgSpecs
 
+	// ShowSensitiveResult prints the sensitive fields of the result without masking them,
+	// for results holding secrets that cannot be read again, such as the secret key of a created API key.
+	ShowSensitiveResult bool
+
+	// OptionalPositionalArg allows to run the command without its positional argument, which must not be required.
+	OptionalPositionalArg bool
+
 	// View defines the View for this command.
 	// It is used to create the different options for the different Marshalers.
 	View *View
@@ -57,6 +65,9 @@ type Command struct {
 	// SeeAlsos presents commands related to this command.
 	SeeAlsos []*SeeAlso
 
+	// RawArgsRewriter allows to rewrite raw args before they are parsed, e.g. to support a shorthand syntax
+	RawArgsRewriter CommandRawArgsRewriter
+
 	// PreValidateFunc allows to manipulate args before validation
 	PreValidateFunc CommandPreValidateFunc
 
@@ -72,6 +83,18 @@ type Command struct {
 	// Non-nil values returned by this method will be printed out.
 	Run CommandRunner
 
+	// DeletePreview returns the resources deleted by a destructive command.
+	// When set, the command accepts the --yes flag and lists these resources before asking for a confirmation if deletions must be confirmed.
+	DeletePreview CommandDeletePreviewFunc
+
+	// CostEstimate returns the estimated cost of the resource created by the command.
+	// When set, the command accepts the --enforce-budget flag and warns when the cost exceeds the budget of the project set in the CLI config.
+	CostEstimate CommandCostEstimateFunc
+
+	// PickArgs prompts the user to pick the values of missing arguments in interactive mode, before default values are applied.
+	// When set, the command accepts the --no-interactive flag to use the default values instead.
+	PickArgs CommandPickArgsFunc
+
 	// WaitFunc will be called if non-nil when the -w (--wait) flag is passed.
 	WaitFunc WaitFunc
 
@@ -91,6 +114,9 @@ type Command struct {
 	Groups []string
 }
 
+// CommandRawArgsRewriter allows to rewrite raw args before they are parsed.
+type CommandRawArgsRewriter func(rawArgs args.RawArgs) args.RawArgs
+
 // CommandPreValidateFunc allows to manipulate args before validation.
 type CommandPreValidateFunc func(ctx context.Context, argsI interface{}) error
 
@@ -386,9 +412,10 @@ func (c *Commands) applyAliases(config *alias.Config) {
 	}
 }
 
-// Copy returns a copy of a command
+// Copy returns a copy of a command, its path is computed again so that the copy can be moved to another namespace
 func (c *Command) Copy() *Command {
 	newCommand := *c
+	newCommand.path = ""
 	newCommand.Aliases = append([]string(nil), c.Aliases...)
 	newCommand.Examples = make([]*Example, len(c.Examples))
 	for i := range c.Examples {
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+)
+
+// CommandDefaultsKey returns the key of the default arguments of a command in the CLI config, e.g. instance.server.create.
+func CommandDefaultsKey(cmd *Command) string {
+	return cmd.getPath()
+}
+
+// applyCommandDefaults adds the default arguments of the command set in the CLI config to the raw args.
+// An argument given on the command line overrides all the defaults of the same argument,
+// including all the elements of a list or a map, e.g. tags.0=foo overrides the defaults tags.0 and tags.1.
+func applyCommandDefaults(ctx context.Context, cmd *Command, rawArgs args.RawArgs) args.RawArgs {
+	cliCfg := ExtractCliConfig(ctx)
+	if cliCfg == nil {
+		return rawArgs
+	}
+	defaults := cliCfg.CommandDefaults[CommandDefaultsKey(cmd)]
+	if len(defaults) == 0 {
+		return rawArgs
+	}
+
+	explicitArgs := map[string]bool{}
+	for _, arg := range args.SplitRaw(rawArgs.RemoveAllPositional()) {
+		explicitArgs[argRootName(arg[0])] = true
+	}
+	positionalArgSpec := cmd.ArgSpecs.GetPositionalArg()
+
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if explicitArgs[argRootName(name)] {
+			continue
+		}
+		if positionalArgSpec != nil && positionalArgSpec.Name == name {
+			continue
+		}
+		rawArgs = rawArgs.Add(name, defaults[name])
+	}
+	return rawArgs
+}
+
+// argRootName returns the name of an argument without the fields of its elements, e.g. volumes for volumes.0.size.
+func argRootName(name string) string {
+	return strings.SplitN(name, ".", 2)[0]
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_applyCommandDefaults(t *testing.T) {
+	cmd := &Command{
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "create",
+		ArgSpecs: ArgSpecs{
+			{Name: "server-id", Positional: true},
+			{Name: "type"},
+			{Name: "zone"},
+			{Name: "tags.{index}"},
+		},
+	}
+	ctx := injectMeta(context.Background(), &meta{
+		CliConfig: &cliConfig.Config{
+			CommandDefaults: map[string]map[string]string{
+				"instance.server.create": {
+					"type":      "DEV1-S",
+					"zone":      "fr-par-2",
+					"tags.0":    "default",
+					"server-id": "11111111-1111-1111-1111-111111111111",
+				},
+			},
+		},
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		rawArgs := applyCommandDefaults(ctx, cmd, args.RawArgs{})
+		assert.Equal(t, args.RawArgs{"tags.0=default", "type=DEV1-S", "zone=fr-par-2"}, rawArgs)
+	})
+
+	t.Run("Explicit args take precedence", func(t *testing.T) {
+		rawArgs := applyCommandDefaults(ctx, cmd, args.RawArgs{"my-server", "type=GP1-S", "tags.1=prod"})
+		assert.Equal(t, args.RawArgs{"my-server", "type=GP1-S", "tags.1=prod", "zone=fr-par-2"}, rawArgs)
+	})
+
+	t.Run("Other command", func(t *testing.T) {
+		rawArgs := applyCommandDefaults(ctx, &Command{Namespace: "instance", Resource: "server", Verb: "list"}, args.RawArgs{"zone=nl-ams-1"})
+		assert.Equal(t, args.RawArgs{"zone=nl-ams-1"}, rawArgs)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"strings"
 
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -40,13 +41,32 @@ func sdkStdErrorInterceptor(ctx context.Context, args interface{}, runner Comman
 	switch sdkError := err.(type) {
 	case *scw.ResourceNotFoundError:
 		return nil, &CliError{
-			Message: fmt.Sprintf("cannot find resource '%v' with ID '%v'", sdkError.Resource, sdkError.ResourceID),
-			Err:     err,
+			Message:  fmt.Sprintf("cannot find resource '%v' with ID '%v'", sdkError.Resource, sdkError.ResourceID),
+			Err:      err,
+			Code:     ExitCodeNotFound,
+			Resource: sdkError.Resource,
+		}
+	case *scw.DeniedAuthenticationError:
+		reason := "the API key is invalid"
+		switch sdkError.Reason {
+		case "expired":
+			reason = "the API key is expired"
+		case "not_found":
+			reason = "the API key does not exist or was revoked"
 		}
+		return nil, deniedAuthenticationError(ctx, err, reason)
 	case *scw.ResponseError:
+		if sdkError.StatusCode == http.StatusUnauthorized {
+			return nil, deniedAuthenticationError(ctx, err, "the API key is invalid, expired or revoked")
+		}
+		code := 0
+		if sdkError.StatusCode == http.StatusNotFound {
+			code = ExitCodeNotFound
+		}
 		return nil, &CliError{
 			Message: sdkError.Message,
 			Err:     sdkError,
+			Code:    code,
 		}
 	case *scw.InvalidArgumentsError:
 		reasonsMap := map[string]string{
@@ -70,21 +90,26 @@ func sdkStdErrorInterceptor(ctx context.Context, args interface{}, runner Comman
 			Err:     err,
 			Details: strings.Join(reasons, "\n"),
 			Hint:    strings.Join(hints, "\n"),
+			Code:    ExitCodeUsage,
 		}
 
 	case *scw.QuotasExceededError:
 		invalidArgs := make([]string, len(sdkError.Details))
 		resources := make([]string, len(sdkError.Details))
+		quotaResources := make([]string, len(sdkError.Details))
 		for i, d := range sdkError.Details {
 			invalidArgs[i] = fmt.Sprintf("- %s has reached its quota (%d/%d)", d.Resource, d.Current, d.Current)
 			resources[i] = fmt.Sprintf("'%v'", d.Resource)
+			quotaResources[i] = d.Resource
 		}
 
 		return nil, &CliError{
-			Message: fmt.Sprintf("quota exceeded for resources %v", strings.Join(resources, ", ")),
-			Err:     err,
-			Details: strings.Join(invalidArgs, "\n"),
-			Hint:    "Quotas are defined by organization. You should either delete unused resources or contact support to obtain bigger quotas.",
+			Message:  fmt.Sprintf("quota exceeded for resources %v", strings.Join(resources, ", ")),
+			Err:      err,
+			Details:  strings.Join(invalidArgs, "\n"),
+			Hint:     "Quotas are defined by organization. You should either delete unused resources or contact support to obtain bigger quotas.",
+			Code:     ExitCodeQuota,
+			Resource: strings.Join(quotaResources, ","),
 		}
 	case *scw.TransientStateError:
 		return nil, &CliError{
@@ -94,12 +119,14 @@ func sdkStdErrorInterceptor(ctx context.Context, args interface{}, runner Comman
 				sdkError.Resource,
 				sdkError.ResourceID,
 				sdkError.CurrentState),
+			Resource: sdkError.Resource,
 		}
 	case *scw.OutOfStockError:
 		return nil, &CliError{
-			Message: fmt.Sprintf("resource out of stock '%v'", sdkError.Resource),
-			Err:     err,
-			Hint:    "Try again later :-)",
+			Message:  fmt.Sprintf("resource out of stock '%v'", sdkError.Resource),
+			Err:      err,
+			Hint:     "Try again later :-)",
+			Resource: sdkError.Resource,
 		}
 	case *scw.ResourceExpiredError:
 		var hint string
@@ -109,15 +136,45 @@ func sdkStdErrorInterceptor(ctx context.Context, args interface{}, runner Comman
 		}
 
 		return nil, &CliError{
-			Message: fmt.Sprintf("resource %s with ID %s expired since %s", sdkError.Resource, sdkError.ResourceID, sdkError.ExpiredSince.String()),
-			Err:     err,
-			Hint:    hint,
+			Message:  fmt.Sprintf("resource %s with ID %s expired since %s", sdkError.Resource, sdkError.ResourceID, sdkError.ExpiredSince.String()),
+			Err:      err,
+			Hint:     hint,
+			Resource: sdkError.Resource,
 		}
 	}
 
 	return res, err
 }
 
+// deniedAuthenticationError returns an error telling which API key failed to authenticate and how to renew it.
+func deniedAuthenticationError(ctx context.Context, err error, reason string) *CliError {
+	accessKey := "unknown"
+	if client := ExtractClient(ctx); client != nil {
+		if clientAccessKey, exists := client.GetAccessKey(); exists {
+			accessKey = clientAccessKey
+		}
+	}
+
+	if ExtractEnv(ctx, scw.ScwAccessKeyEnv) != "" {
+		return &CliError{
+			Message: fmt.Sprintf("authentication denied for API key %s set in the environment: %s", accessKey, reason),
+			Err:     err,
+			Hint:    fmt.Sprintf("Set %s and %s to a valid API key, check it with: scw iam api-key check", scw.ScwAccessKeyEnv, scw.ScwSecretKeyEnv),
+		}
+	}
+
+	profileName := ExtractProfileName(ctx)
+	loginCommand := "scw login refresh=true"
+	if profileName != scw.DefaultProfileName {
+		loginCommand = fmt.Sprintf("scw -p %s login refresh=true", profileName)
+	}
+	return &CliError{
+		Message: fmt.Sprintf("authentication denied for API key %s of profile %s: %s", accessKey, profileName, reason),
+		Err:     err,
+		Hint:    "Renew the API key of the profile with: " + loginCommand,
+	}
+}
+
 // sdkStdErrorInterceptor is a command interceptor that will catch sdk standard error and return more friendly CLI error.
 func sdkStdTypeInterceptor(ctx context.Context, args interface{}, runner CommandRunner) (interface{}, error) {
 	res, err := runner(ctx, args)
@@ -2,9 +2,11 @@ package core
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/alecthomas/assert"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 func Test_CombineCommandInterceptor(t *testing.T) {
@@ -65,3 +67,54 @@ func Test_CombineCommandInterceptor(t *testing.T) {
 		Expected: []string{"A", "B", "C", "runner"},
 	}))
 }
+
+func Test_sdkStdErrorInterceptorDeniedAuthentication(t *testing.T) {
+	client, err := scw.NewClient(scw.WithAuth("SCW11111111111111111", "11111111-1111-1111-1111-111111111111"))
+	assert.NoError(t, err)
+
+	type TestCase struct {
+		Err             error
+		Env             map[string]string
+		ExpectedMessage string
+		ExpectedHint    string
+	}
+
+	run := func(tc *TestCase) func(t *testing.T) {
+		return func(t *testing.T) {
+			ctx := injectMeta(context.Background(), &meta{
+				ProfileFlag: "prod",
+				Client:      client,
+				OverrideEnv: tc.Env,
+			})
+			_, err := sdkStdErrorInterceptor(ctx, nil, func(context.Context, interface{}) (interface{}, error) {
+				return nil, tc.Err
+			})
+
+			cliErr, ok := err.(*CliError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.ExpectedMessage, cliErr.Message)
+			assert.Equal(t, tc.ExpectedHint, cliErr.Hint)
+		}
+	}
+
+	t.Run("expired", run(&TestCase{
+		Err:             &scw.DeniedAuthenticationError{Method: "api_key", Reason: "expired"},
+		Env:             map[string]string{scw.ScwAccessKeyEnv: ""},
+		ExpectedMessage: "authentication denied for API key SCW11111111111111111 of profile prod: the API key is expired",
+		ExpectedHint:    "Renew the API key of the profile with: scw -p prod login refresh=true",
+	}))
+
+	t.Run("unauthorized", run(&TestCase{
+		Err:             &scw.ResponseError{StatusCode: http.StatusUnauthorized},
+		Env:             map[string]string{scw.ScwAccessKeyEnv: ""},
+		ExpectedMessage: "authentication denied for API key SCW11111111111111111 of profile prod: the API key is invalid, expired or revoked",
+		ExpectedHint:    "Renew the API key of the profile with: scw -p prod login refresh=true",
+	}))
+
+	t.Run("environment", run(&TestCase{
+		Err:             &scw.DeniedAuthenticationError{Method: "api_key", Reason: "not_found"},
+		Env:             map[string]string{scw.ScwAccessKeyEnv: "SCW11111111111111111"},
+		ExpectedMessage: "authentication denied for API key SCW11111111111111111 set in the environment: the API key does not exist or was revoked",
+		ExpectedHint:    "Set SCW_ACCESS_KEY and SCW_SECRET_KEY to a valid API key, check it with: scw iam api-key check",
+	}))
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/spf13/cobra"
+)
+
+const (
+	yesFlagUsage = "Delete without asking for confirmation"
+
+	// autoApproveEnv disables the confirmation of deletions when set to true.
+	autoApproveEnv = "SCW_AUTO_APPROVE"
+)
+
+// DeletePreview lists the resources a destructive command will delete.
+type DeletePreview struct {
+	Resources []*DeletePreviewResource
+}
+
+// DeletePreviewResource is a resource deleted by a command, Dependents are the resources deleted along with it.
+type DeletePreviewResource struct {
+	Type       string
+	ID         string
+	Name       string
+	Dependents []*DeletePreviewResource
+}
+
+// CommandDeletePreviewFunc returns the resources a destructive command will delete.
+type CommandDeletePreviewFunc func(ctx context.Context, argsI interface{}) (*DeletePreview, error)
+
+// confirmDeletion displays the resources a command will delete and asks the user to confirm.
+// Deletions are only confirmed when enabled in the CLI config, --yes and SCW_AUTO_APPROVE skip the confirmation.
+func confirmDeletion(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, cmdArgs interface{}) (bool, error) {
+	if yes, err := cobraCmd.PersistentFlags().GetBool("yes"); err == nil && yes {
+		return true, nil
+	}
+	if autoApprove, err := strconv.ParseBool(ExtractEnv(ctx, autoApproveEnv)); err == nil && autoApprove {
+		return true, nil
+	}
+	if cliCfg := extractMeta(ctx).CliConfig; cliCfg == nil || !cliCfg.ConfirmDeletions {
+		return true, nil
+	}
+
+	preview, err := cmd.DeletePreview(ctx, cmdArgs)
+	if err != nil {
+		return false, err
+	}
+	if preview == nil || len(preview.Resources) == 0 {
+		return true, nil
+	}
+
+	if !interactive.IsInteractive {
+		return false, &CliError{
+			Err:     fmt.Errorf("deletion must be confirmed"),
+			Details: formatDeletePreview(preview),
+			Hint:    fmt.Sprintf("Use --yes or set %s=true to delete without confirmation", autoApproveEnv),
+		}
+	}
+
+	_, _ = interactive.Println(formatDeletePreview(preview))
+	return interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Ctx:          ctx,
+		Prompt:       "Do you want to delete these resources?",
+		DefaultValue: false,
+	})
+}
+
+// formatDeletePreview returns the itemized list of the resources to delete followed by their count by type.
+func formatDeletePreview(preview *DeletePreview) string {
+	lines := []string{"The following resources will be deleted:"}
+	counts := map[string]int{}
+	types := []string(nil)
+
+	var addResources func(resources []*DeletePreviewResource, indent string)
+	addResources = func(resources []*DeletePreviewResource, indent string) {
+		for _, resource := range resources {
+			line := indent + "- " + resource.Type
+			if resource.Name != "" {
+				line += " " + resource.Name
+			}
+			if resource.ID != "" {
+				line += " (" + resource.ID + ")"
+			}
+			lines = append(lines, line)
+
+			if counts[resource.Type] == 0 {
+				types = append(types, resource.Type)
+			}
+			counts[resource.Type]++
+			addResources(resource.Dependents, indent+"  ")
+		}
+	}
+	addResources(preview.Resources, "  ")
+
+	total := make([]string, 0, len(types))
+	for _, resourceType := range types {
+		total = append(total, fmt.Sprintf("%s=%d", resourceType, counts[resourceType]))
+	}
+	lines = append(lines, "Resources by type: "+strings.Join(total, ", "))
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_formatDeletePreview(t *testing.T) {
+	preview := &DeletePreview{
+		Resources: []*DeletePreviewResource{
+			{
+				Type: "server",
+				ID:   "11111111-1111-1111-1111-111111111111",
+				Name: "web",
+				Dependents: []*DeletePreviewResource{
+					{Type: "volume", ID: "22222222-2222-2222-2222-222222222222", Name: "web-root"},
+					{Type: "volume", ID: "33333333-3333-3333-3333-333333333333"},
+					{Type: "ip", ID: "44444444-4444-4444-4444-444444444444", Name: "51.15.1.1"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, `The following resources will be deleted:
+  - server web (11111111-1111-1111-1111-111111111111)
+    - volume web-root (22222222-2222-2222-2222-222222222222)
+    - volume (33333333-3333-3333-3333-333333333333)
+    - ip 51.15.1.1 (44444444-4444-4444-4444-444444444444)
+Resources by type: server=1, volume=2, ip=1`, formatDeletePreview(preview))
+}
+
+func testGetDeleteCommands() *Commands {
+	return NewCommands(
+		&Command{
+			Namespace:            "test",
+			Resource:             "delete",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(args.RawArgs{}),
+			DeletePreview: func(_ context.Context, _ interface{}) (*DeletePreview, error) {
+				return &DeletePreview{Resources: []*DeletePreviewResource{{Type: "test", Name: "resource"}}}, nil
+			},
+			Run: func(_ context.Context, _ interface{}) (i interface{}, e error) {
+				return &SuccessResult{Message: "deleted"}, nil
+			},
+		},
+	)
+}
+
+func beforeFuncConfirmDeletions() BeforeFunc {
+	return func(ctx *BeforeFuncCtx) error {
+		cliConfigPath := path.Join(ctx.OverrideEnv["HOME"], ".config", "scw", "cli.yaml")
+		err := os.MkdirAll(path.Dir(cliConfigPath), 0755)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(cliConfigPath, []byte("confirm_deletions: true\n"), 0600)
+	}
+}
+
+func Test_ConfirmDeletion(t *testing.T) {
+	t.Run("Disabled", Test(&TestConfig{
+		Commands: testGetDeleteCommands(),
+		Cmd:      "scw test delete",
+		Check: TestCheckCombine(
+			TestCheckExitCode(0),
+			func(t *testing.T, ctx *CheckFuncCtx) {
+				assert.Equal(t, "deleted", ctx.Result.(*SuccessResult).Message)
+			},
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Not confirmed", Test(&TestConfig{
+		Commands:   testGetDeleteCommands(),
+		BeforeFunc: beforeFuncConfirmDeletions(),
+		Cmd:        "scw test delete",
+		Check: TestCheckCombine(
+			TestCheckExitCode(1),
+			func(t *testing.T, ctx *CheckFuncCtx) {
+				cliErr, isCliErr := ctx.Err.(*CliError)
+				require.True(t, isCliErr)
+				assert.Equal(t, "deletion must be confirmed", cliErr.Err.Error())
+				assert.Contains(t, cliErr.Details, "- test resource")
+			},
+		),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Yes flag", Test(&TestConfig{
+		Commands:   testGetDeleteCommands(),
+		BeforeFunc: beforeFuncConfirmDeletions(),
+		Cmd:        "scw test delete --yes",
+		Check:      TestCheckExitCode(0),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Auto approve", Test(&TestConfig{
+		Commands:   testGetDeleteCommands(),
+		BeforeFunc: beforeFuncConfirmDeletions(),
+		Cmd:        "scw test delete",
+		OverrideEnv: map[string]string{
+			"SCW_AUTO_APPROVE": "true",
+		},
+		Check:      TestCheckExitCode(0),
+		TmpHomeDir: true,
+	}))
+}
@@ -6,9 +6,12 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"sync"
+	"time"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/alias"
 	cliConfig "github.com/scaleway/scaleway-cli/v2/internal/config"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
@@ -37,6 +40,13 @@ type meta struct {
 	httpClient                  *http.Client
 	isClientFromBootstrapConfig bool
 	betaMode                    bool
+	progressFormat              ProgressFormat
+	progressMu                  sync.Mutex
+	waitTimeout                 time.Duration
+	// waitState records the state of the waited resources, nil when the HTTP client comes from the bootstrap config.
+	waitState *waitStateTransport
+	// multiProfile is set when the result merges the results of several profiles.
+	multiProfile bool
 }
 
 type contextKey int
@@ -50,6 +60,14 @@ func injectMeta(ctx context.Context, meta *meta) context.Context {
 	return context.WithValue(ctx, metaContextKey, meta)
 }
 
+// humanMarshalerOpt returns the human marshaler options of the result of the command.
+func (m *meta) humanMarshalerOpt() *human.MarshalOpt {
+	if m.multiProfile {
+		return profilesMarshalerOpt(m.command.getHumanMarshalerOpt())
+	}
+	return m.command.getHumanMarshalerOpt()
+}
+
 // extractMeta extracts meta from a given context.
 func extractMeta(ctx context.Context) *meta {
 	return ctx.Value(metaContextKey).(*meta)
@@ -146,6 +164,10 @@ func ExtractStdin(ctx context.Context) io.Reader {
 	return extractMeta(ctx).stdin
 }
 
+func ExtractStdout(ctx context.Context) io.Writer {
+	return extractMeta(ctx).stdout
+}
+
 func ExtractProfileName(ctx context.Context) string {
 	// Handle profile flag -p
 	if extractMeta(ctx).ProfileFlag != "" {
@@ -25,8 +25,16 @@ type CliError struct {
 	Hint    string
 
 	// Code allows to return a sepcific error code from the main binary.
+	// Use one of the documented ExitCode constants so that scripts can branch on the type of failure.
 	Code int
 
+	// ExitCode overrides the exit code of the main binary without changing the code of the error,
+	// e.g. to exit with the exit code of a job run.
+	ExitCode int
+
+	// Resource is the type of the resource the error is about, e.g. server.
+	Resource string
+
 	// Empty tells the marshaler to not print any message for the error
 	Empty bool
 }
@@ -83,15 +91,19 @@ func (s *CliError) MarshalJSON() ([]byte, error) {
 	}
 
 	type tmpRes struct {
-		Message string `json:"message,omitempty"`
-		Error   error  `json:"error,omitempty"`
-		Details string `json:"details,omitempty"`
-		Hint    string `json:"hint,omitempty"`
+		Message  string `json:"message,omitempty"`
+		Error    error  `json:"error,omitempty"`
+		Details  string `json:"details,omitempty"`
+		Hint     string `json:"hint,omitempty"`
+		Code     string `json:"code,omitempty"`
+		Resource string `json:"resource,omitempty"`
 	}
 	return json.Marshal(&tmpRes{
-		Message: message,
-		Error:   s.Err,
-		Details: s.Details,
-		Hint:    s.Hint,
+		Message:  message,
+		Error:    s.Err,
+		Details:  s.Details,
+		Hint:     s.Hint,
+		Code:     exitCodeNames[s.Code],
+		Resource: s.Resource,
 	})
 }
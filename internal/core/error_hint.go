@@ -0,0 +1,47 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrorHintFunc returns a hint telling the user how to fix the error of a command,
+// or an empty string when it has no hint for this error.
+type ErrorHintFunc func(ctx context.Context, cmd *Command, args interface{}, err error) string
+
+var errorHintFuncs sync.Map
+
+// RegisterErrorHintFunc binds the given ErrorHintFunc to the commands of a namespace.
+// Namespaces should register it in their GetCommands function.
+func RegisterErrorHintFunc(namespace string, f ErrorHintFunc) {
+	errorHintFuncs.Store(namespace, f)
+}
+
+// addErrorHint adds the hint of the namespace of cmd to err, after the hint err may already have.
+func addErrorHint(ctx context.Context, cmd *Command, args interface{}, err error) error {
+	value, exists := errorHintFuncs.Load(cmd.Namespace)
+	if !exists {
+		return err
+	}
+
+	hint := value.(ErrorHintFunc)(ctx, cmd, args, err)
+	if hint == "" {
+		return err
+	}
+
+	cliErr := (*CliError)(nil)
+	if !errors.As(err, &cliErr) {
+		return &CliError{
+			Err:  err,
+			Hint: hint,
+		}
+	}
+
+	hintedErr := *cliErr
+	if hintedErr.Hint != "" {
+		hint = hintedErr.Hint + "\n" + hint
+	}
+	hintedErr.Hint = hint
+	return &hintedErr
+}
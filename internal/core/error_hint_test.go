@@ -0,0 +1,40 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_addErrorHint(t *testing.T) {
+	RegisterErrorHintFunc("test-hint", func(_ context.Context, _ *Command, _ interface{}, err error) string {
+		if IsQuotasExceededError(err) {
+			return "Check the quotas with: scw test quota list"
+		}
+		return ""
+	})
+	cmd := &Command{Namespace: "test-hint"}
+
+	t.Run("no hint", func(t *testing.T) {
+		err := errors.New("failure")
+		assert.Equal(t, err, addErrorHint(context.Background(), cmd, nil, err))
+	})
+
+	t.Run("namespace without hints", func(t *testing.T) {
+		err := &CliError{Err: errors.New("quota exceeded"), Code: ExitCodeQuota}
+		assert.Equal(t, err, addErrorHint(context.Background(), &Command{Namespace: "other"}, nil, err))
+	})
+
+	t.Run("hint appended", func(t *testing.T) {
+		err := &CliError{Err: errors.New("quota exceeded"), Hint: "Quotas are defined by organization.", Code: ExitCodeQuota}
+		hintedErr := addErrorHint(context.Background(), cmd, nil, err)
+		assert.Equal(t, &CliError{
+			Err:  err.Err,
+			Hint: "Quotas are defined by organization.\nCheck the quotas with: scw test quota list",
+			Code: ExitCodeQuota,
+		}, hintedErr)
+		assert.Equal(t, "Quotas are defined by organization.", err.Hint)
+	})
+}
@@ -6,7 +6,8 @@ import (
 
 func MissingRequiredArgumentError(argumentName string) *CliError {
 	return &CliError{
-		Err: fmt.Errorf("missing required argument '%v'", argumentName),
+		Err:  fmt.Errorf("missing required argument '%v'", argumentName),
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -14,6 +15,7 @@ func InvalidValueForEnumError(argSpecName string, argSpecEnumValues []string, va
 	return &CliError{
 		Err:  fmt.Errorf("invalid value '%v' for arg '%v'", value, argSpecName),
 		Hint: fmt.Sprintf("Accepted values for '%v' are %v", argSpecName, argSpecEnumValues),
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -21,12 +23,14 @@ func InvalidSecretKeyError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid secret_key '%v'", value),
 		Hint: "secret_key should be a valid UUID, formatted as: XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX.",
+		Code: ExitCodeUsage,
 	}
 }
 func InvalidAccessKeyError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid access_key '%v'", value),
 		Hint: "access_key should look like: SCWXXXXXXXXXXXXXXXXX.",
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -34,6 +38,7 @@ func InvalidOrganizationIDError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid organization-id '%v'", value),
 		Hint: "organization-id should be a valid UUID, formatted as: XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX.",
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -41,6 +46,7 @@ func InvalidProjectIDError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid project-id '%v'", value),
 		Hint: "project-id should be a valid UUID, formatted as: XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX.",
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -48,6 +54,7 @@ func InvalidRegionError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid region '%v'", value),
 		Hint: "region format should look like: XX-XXX (e.g. fr-par).",
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -55,6 +62,7 @@ func InvalidZoneError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid zone '%v'", value),
 		Hint: "zone format should look like XX-XXX-X: (e.g. fr-par-1).",
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -62,12 +70,14 @@ func InvalidAPIURLError(value string) *CliError {
 	return &CliError{
 		Err:  fmt.Errorf("invalid api_url '%v'", value),
 		Hint: "api_url should look like: https://www.example.com (e.g. https://api.scaleway.com).",
+		Code: ExitCodeUsage,
 	}
 }
 
 func ArgumentConflictError(arg1 string, arg2 string) *CliError {
 	return &CliError{
-		Err: fmt.Errorf("only one of those two arguments '%s' and '%s' can be specified in the same time", arg1, arg2),
+		Err:  fmt.Errorf("only one of those two arguments '%s' and '%s' can be specified in the same time", arg1, arg2),
+		Code: ExitCodeUsage,
 	}
 }
 
@@ -10,5 +10,16 @@ import (
 func IsNotFoundError(err error) bool {
 	notFoundError := &scw.ResourceNotFoundError{}
 	responseError := &scw.ResponseError{}
-	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound || errors.As(err, &notFoundError)
+	return errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound || errors.As(err, &notFoundError) || hasCliErrorCode(err, ExitCodeNotFound)
+}
+
+func IsQuotasExceededError(err error) bool {
+	quotasExceededError := &scw.QuotasExceededError{}
+	return errors.As(err, &quotasExceededError) || hasCliErrorCode(err, ExitCodeQuota)
+}
+
+// hasCliErrorCode returns true if err is a CliError with the given code, as returned by sdkStdErrorInterceptor.
+func hasCliErrorCode(err error, code int) bool {
+	cliErr := (*CliError)(nil)
+	return errors.As(err, &cliErr) && cliErr.Code == code
 }
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+)
+
+const (
+	defaultEventsPollInterval = 5 * time.Second
+
+	ResourceEventCreated = "created"
+	ResourceEventUpdated = "updated"
+	ResourceEventStatus  = "status"
+)
+
+// ResourceEvent is an event of the activity of a resource, built from the dates and the status of the resource.
+type ResourceEvent struct {
+	// Date is nil when the resource does not tell when its status changed
+	Date       *time.Time `json:"date"`
+	Resource   string     `json:"resource"`
+	ResourceID string     `json:"resource_id"`
+	Event      string     `json:"event"`
+	Status     string     `json:"status"`
+	Detail     string     `json:"detail"`
+}
+
+// EventsFunc returns the current events of a resource and of its sub-resources.
+type EventsFunc func(ctx context.Context) ([]*ResourceEvent, error)
+
+// NewResourceEvents returns the events of a resource: its creation, and its last update with its current status.
+// When updatedAt is nil, the current status is returned as an event without date.
+func NewResourceEvents(resource string, resourceID string, createdAt *time.Time, updatedAt *time.Time, status string, detail string) []*ResourceEvent {
+	events := []*ResourceEvent(nil)
+	if createdAt != nil {
+		events = append(events, &ResourceEvent{
+			Date:       createdAt,
+			Resource:   resource,
+			ResourceID: resourceID,
+			Event:      ResourceEventCreated,
+		})
+	}
+
+	current := &ResourceEvent{
+		Date:       updatedAt,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Event:      ResourceEventUpdated,
+		Status:     status,
+		Detail:     detail,
+	}
+	if updatedAt == nil || (createdAt != nil && updatedAt.Equal(*createdAt)) {
+		current.Date = nil
+		current.Event = ResourceEventStatus
+	}
+	return append(events, current)
+}
+
+// RunEvents returns the events of fetch sorted by date.
+// With follow, the events are printed as they come until the context is done.
+func RunEvents(ctx context.Context, fetch EventsFunc, follow bool) (interface{}, error) {
+	events, err := fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !follow {
+		sortResourceEvents(events)
+		return events, nil
+	}
+
+	interval := defaultEventsPollInterval
+	if DefaultRetryInterval != nil {
+		interval = *DefaultRetryInterval
+	}
+	stdout := ExtractStdout(ctx)
+	printedEvents := map[string]bool{}
+	for {
+		for _, event := range newResourceEvents(events, printedEvents, time.Now()) {
+			_, err := fmt.Fprintln(stdout, formatResourceEvent(event))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return &SuccessResult{Empty: true}, nil
+		case <-time.After(interval):
+		}
+
+		events, err = fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// newResourceEvents returns the events that have not been printed yet sorted by date, and marks them as printed.
+// Events without date are dated now.
+func newResourceEvents(events []*ResourceEvent, printedEvents map[string]bool, now time.Time) []*ResourceEvent {
+	newEvents := []*ResourceEvent(nil)
+	for _, event := range events {
+		key := resourceEventKey(event)
+		if printedEvents[key] {
+			continue
+		}
+		printedEvents[key] = true
+
+		if event.Date == nil {
+			dated := *event
+			dated.Date = &now
+			event = &dated
+		}
+		newEvents = append(newEvents, event)
+	}
+	sortResourceEvents(newEvents)
+	return newEvents
+}
+
+func resourceEventKey(event *ResourceEvent) string {
+	date := ""
+	if event.Date != nil {
+		date = event.Date.UTC().Format(time.RFC3339Nano)
+	}
+	return strings.Join([]string{event.Resource, event.ResourceID, event.Event, date, event.Status, event.Detail}, "|")
+}
+
+// sortResourceEvents sorts events by date, events without date last.
+func sortResourceEvents(events []*ResourceEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Date == nil || events[j].Date == nil {
+			return events[i].Date != nil
+		}
+		return events[i].Date.Before(*events[j].Date)
+	})
+}
+
+func formatResourceEvent(event *ResourceEvent) string {
+	parts := []string{
+		terminal.Style(event.Date.Format(time.RFC3339), color.Faint),
+		event.Resource,
+		event.ResourceID,
+		terminal.Style(event.Event, color.FgBlue),
+	}
+	if event.Status != "" {
+		parts = append(parts, event.Status)
+	}
+	if event.Detail != "" {
+		parts = append(parts, event.Detail)
+	}
+	return strings.Join(parts, " ")
+}
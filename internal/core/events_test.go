@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewResourceEvents(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	events := NewResourceEvents("server", "1", &createdAt, &updatedAt, "running", "booted")
+	require.Len(t, events, 2)
+	assert.Equal(t, ResourceEventCreated, events[0].Event)
+	assert.Equal(t, &createdAt, events[0].Date)
+	assert.Equal(t, ResourceEventUpdated, events[1].Event)
+	assert.Equal(t, "running", events[1].Status)
+
+	events = NewResourceEvents("instance", "1", &createdAt, &createdAt, "ready", "")
+	require.Len(t, events, 2)
+	assert.Equal(t, ResourceEventStatus, events[1].Event)
+	assert.Nil(t, events[1].Date)
+
+	events = NewResourceEvents("log", "1", nil, nil, "ready", "")
+	require.Len(t, events, 1)
+	assert.Equal(t, ResourceEventStatus, events[0].Event)
+}
+
+func Test_newResourceEvents(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	now := createdAt.Add(2 * time.Hour)
+	printed := map[string]bool{}
+
+	events := append(
+		NewResourceEvents("instance", "1", &createdAt, nil, "provisioning", ""),
+		NewResourceEvents("backup", "2", &updatedAt, nil, "creating", "")...,
+	)
+	newEvents := newResourceEvents(events, printed, now)
+	require.Len(t, newEvents, 4)
+	assert.Equal(t, "instance", newEvents[0].Resource)
+	assert.Equal(t, "backup", newEvents[1].Resource)
+	assert.Equal(t, &now, newEvents[2].Date, "events without date are dated now")
+	assert.Nil(t, events[1].Date, "the fetched events must not be modified")
+
+	assert.Empty(t, newResourceEvents(events, printed, now.Add(time.Minute)))
+
+	events = NewResourceEvents("instance", "1", &createdAt, nil, "ready", "")
+	newEvents = newResourceEvents(events, printed, now.Add(time.Minute))
+	require.Len(t, newEvents, 1)
+	assert.Equal(t, ResourceEventStatus, newEvents[0].Event)
+	assert.Equal(t, "ready", newEvents[0].Status)
+}
@@ -1,13 +1,37 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
 )
 
 type OverrideExecFunc func(cmd *exec.Cmd) (exitCode int, err error)
 
+// binaryInstallHints explains how to install the external binaries run by some commands.
+var binaryInstallHints = map[string]string{
+	"docker":    "Install Docker: https://docs.docker.com/get-docker/",
+	"kubectl":   "Install kubectl: https://kubernetes.io/docs/tasks/tools/",
+	"mysql":     "Install the MySQL client: https://dev.mysql.com/downloads/",
+	"podman":    "Install Podman: https://podman.io/docs/installation",
+	"psql":      "Install the PostgreSQL client: https://www.postgresql.org/download/",
+	"redis-cli": "Install the Redis CLI: https://redis.io/docs/install/",
+	"ssh":       "Install an OpenSSH client: https://www.openssh.com/, on Windows add the OpenSSH Client optional feature",
+}
+
+var (
+	shellSafeArgRegex   = regexp.MustCompile(`^[a-zA-Z0-9_@%+=:,./-]+$`)
+	windowsSafeArgRegex = regexp.MustCompile(`^[a-zA-Z0-9_@+=:,./\\-]+$`)
+)
+
 func defaultOverrideExec(cmd *exec.Cmd) (exitCode int, err error) {
 	err = cmd.Run()
 	if exitErr, isExitErr := err.(*exec.ExitError); isExitErr {
@@ -18,6 +42,7 @@ func defaultOverrideExec(cmd *exec.Cmd) (exitCode int, err error) {
 
 func ExecCmd(ctx context.Context, cmd *exec.Cmd) (exitCode int, err error) {
 	meta := extractMeta(ctx)
+	resolveBinary(ctx, cmd)
 
 	// We do not support override of stdin
 	if cmd.Stdin == nil {
@@ -26,5 +51,136 @@ func ExecCmd(ctx context.Context, cmd *exec.Cmd) (exitCode int, err error) {
 
 	cmd.Stdout = meta.stdout
 	cmd.Stderr = meta.stderr
-	return meta.OverrideExec(cmd)
+	exitCode, err = meta.OverrideExec(cmd)
+	return exitCode, binaryNotFoundError(ctx, cmd, err)
+}
+
+// ExecCmdOutput runs a command like ExecCmd but captures its standard output instead of printing it.
+func ExecCmdOutput(ctx context.Context, cmd *exec.Cmd) (output []byte, exitCode int, err error) {
+	meta := extractMeta(ctx)
+	resolveBinary(ctx, cmd)
+
+	if cmd.Stdin == nil {
+		cmd.Stdin = os.Stdin
+	}
+
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = meta.stderr
+	exitCode, err = meta.OverrideExec(cmd)
+	return stdout.Bytes(), exitCode, binaryNotFoundError(ctx, cmd, err)
+}
+
+// FormatCommandLine returns the command line of a command, quoted so that it can be pasted in a shell.
+// On Windows, arguments are quoted for cmd.exe instead of POSIX shells.
+func FormatCommandLine(ctx context.Context, cmd *exec.Cmd) string {
+	args := append([]string(nil), cmd.Args...)
+	if binaryPath := configuredBinaryPath(ctx, args[0]); binaryPath != "" {
+		args[0] = binaryPath
+	}
+
+	quoteArg := quoteShellArg
+	if runtime.GOOS == "windows" {
+		quoteArg = quoteWindowsArg
+	}
+	for i, arg := range args {
+		args[i] = quoteArg(arg)
+	}
+
+	return strings.Join(args, " ")
+}
+
+// quoteShellArg quotes an argument with single quotes when it contains characters interpreted by shells.
+func quoteShellArg(arg string) string {
+	if shellSafeArgRegex.MatchString(arg) {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// quoteWindowsArg quotes an argument with double quotes when it contains characters interpreted by cmd.exe.
+// Backslashes are escaped as expected by CommandLineToArgvW, which is used by most Windows programs to parse their arguments.
+func quoteWindowsArg(arg string) string {
+	if windowsSafeArgRegex.MatchString(arg) {
+		return arg
+	}
+
+	quoted := strings.Builder{}
+	quoted.WriteByte('"')
+	backslashes := 0
+	for _, c := range arg {
+		switch c {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			// Backslashes preceding a quote and the quote itself are escaped.
+			quoted.WriteString(strings.Repeat(`\`, 2*backslashes+1))
+		default:
+			quoted.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		quoted.WriteRune(c)
+	}
+	// Backslashes preceding the closing quote are escaped.
+	quoted.WriteString(strings.Repeat(`\`, 2*backslashes))
+	quoted.WriteByte('"')
+
+	return quoted.String()
+}
+
+// PrintCommandArgSpec returns an arg spec allowing to print the command line of an external binary instead of running it.
+func PrintCommandArgSpec() *ArgSpec {
+	return &ArgSpec{
+		Name:  "print-command",
+		Short: "Print the command line instead of running it",
+	}
+}
+
+func configuredBinaryPath(ctx context.Context, binary string) string {
+	cfg := extractMeta(ctx).CliConfig
+	if cfg == nil {
+		return ""
+	}
+	return cfg.Binaries[binary]
+}
+
+// resolveBinary uses the path set in the binaries section of the CLI config to run a command, if any.
+func resolveBinary(ctx context.Context, cmd *exec.Cmd) {
+	if len(cmd.Args) == 0 {
+		return
+	}
+
+	binaryPath := configuredBinaryPath(ctx, cmd.Args[0])
+	if binaryPath == "" {
+		return
+	}
+
+	cmd.Path = binaryPath
+	cmd.Err = nil
+	if filepath.Base(binaryPath) == binaryPath {
+		cmd.Path, cmd.Err = exec.LookPath(binaryPath)
+	}
+}
+
+// binaryNotFoundError returns an actionable error when the binary of a command cannot be found.
+func binaryNotFoundError(ctx context.Context, cmd *exec.Cmd, err error) error {
+	if err == nil || len(cmd.Args) == 0 || !(errors.Is(err, exec.ErrNotFound) || errors.Is(err, fs.ErrNotExist)) {
+		return err
+	}
+
+	binary := cmd.Args[0]
+	installHint, exists := binaryInstallHints[binary]
+	if !exists {
+		installHint = fmt.Sprintf("Install %s", binary)
+	}
+
+	return &CliError{
+		Err: fmt.Errorf("cannot run %s: %w", binary, err),
+		Hint: strings.Join([]string{
+			installHint,
+			fmt.Sprintf("If %s is installed in a custom location, set its path in the binaries section of %s", binary, ExtractCliConfigPath(ctx)),
+			"Commands with a print-command argument can also display the command line instead of running it",
+		}, "\n"),
+	}
 }
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_quoteShellArg(t *testing.T) {
+	assert.Equal(t, "--host", quoteShellArg("--host"))
+	assert.Equal(t, "51.159.25.206", quoteShellArg("51.159.25.206"))
+	assert.Equal(t, "''", quoteShellArg(""))
+	assert.Equal(t, "'ls -la'", quoteShellArg("ls -la"))
+	assert.Equal(t, `'echo '\''hello'\'''`, quoteShellArg("echo 'hello'"))
+}
+
+func Test_quoteWindowsArg(t *testing.T) {
+	assert.Equal(t, "--host", quoteWindowsArg("--host"))
+	assert.Equal(t, `C:\Program\psql.exe`, quoteWindowsArg(`C:\Program\psql.exe`))
+	assert.Equal(t, `""`, quoteWindowsArg(""))
+	assert.Equal(t, `"C:\Program Files\psql.exe"`, quoteWindowsArg(`C:\Program Files\psql.exe`))
+	assert.Equal(t, `"echo \"hello\""`, quoteWindowsArg(`echo "hello"`))
+	assert.Equal(t, `"a\\\"b"`, quoteWindowsArg(`a\"b`))
+	assert.Equal(t, `"C:\my dir\\"`, quoteWindowsArg(`C:\my dir\`))
+	assert.Equal(t, `"100%"`, quoteWindowsArg("100%"))
+}
@@ -0,0 +1,54 @@
+package core
+
+import "errors"
+
+// Exit codes of the CLI, they are documented in scw help errors so that scripts can branch on the type of failure.
+const (
+	// ExitCodeError is the exit code of failures which have no specific exit code.
+	ExitCodeError = 1
+	// ExitCodeUsage is the exit code of invalid commands, arguments or flags.
+	ExitCodeUsage = 2
+	// ExitCodeNotFound is the exit code of commands targeting a resource which does not exist.
+	ExitCodeNotFound = 3
+	// ExitCodeQuota is the exit code of commands exceeding the quotas of the organization.
+	ExitCodeQuota = 4
+	// ExitCodeTimeout is the exit code of commands whose wait timed out.
+	ExitCodeTimeout = 5
+	// ExitCodeCheckFailed is the exit code of check commands which ran successfully and found a problem, such as a DNS drift.
+	ExitCodeCheckFailed = 6
+	// ExitCodeJobFailed is the exit code of commands waiting for a job which did not succeed without an exit code of its own.
+	ExitCodeJobFailed = 7
+	// ExitCodeInterrupted is the exit code of commands interrupted by the user, the same as shells.
+	ExitCodeInterrupted = 130
+)
+
+// exitCodeNames are the codes of the errors in JSON output, failures without a specific exit code have no code.
+var exitCodeNames = map[int]string{
+	ExitCodeUsage:       "usage",
+	ExitCodeNotFound:    "not_found",
+	ExitCodeQuota:       "quota",
+	ExitCodeTimeout:     "timeout",
+	ExitCodeCheckFailed: "check_failed",
+	ExitCodeJobFailed:   "job_failed",
+}
+
+// errorExitCode returns the exit code of a failed command.
+func errorExitCode(err error) int {
+	cliErr := (*CliError)(nil)
+	if errors.As(err, &cliErr) {
+		if cliErr.ExitCode != 0 {
+			return cliErr.ExitCode
+		}
+		if cliErr.Code != 0 {
+			return cliErr.Code
+		}
+	}
+
+	switch {
+	case IsNotFoundError(err):
+		return ExitCodeNotFound
+	case IsQuotasExceededError(err):
+		return ExitCodeQuota
+	}
+	return ExitCodeError
+}
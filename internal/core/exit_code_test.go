@@ -0,0 +1,48 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_errorExitCode(t *testing.T) {
+	assert.Equal(t, ExitCodeError, errorExitCode(errors.New("failure")))
+	assert.Equal(t, ExitCodeError, errorExitCode(&CliError{Err: errors.New("failure")}))
+	assert.Equal(t, ExitCodeUsage, errorExitCode(MissingRequiredArgumentError("name")))
+	assert.Equal(t, ExitCodeNotFound, errorExitCode(&scw.ResourceNotFoundError{Resource: "instance_server"}))
+	assert.Equal(t, ExitCodeNotFound, errorExitCode(fmt.Errorf("get server: %w", &scw.ResponseError{StatusCode: http.StatusNotFound})))
+	assert.Equal(t, ExitCodeQuota, errorExitCode(&scw.QuotasExceededError{}))
+	assert.Equal(t, ExitCodeTimeout, errorExitCode(&CliError{Err: errors.New("timeout"), Code: WaitTimeoutExitCode}))
+	assert.Equal(t, ExitCodeCheckFailed, errorExitCode(&CliError{Err: errors.New("drift"), Code: ExitCodeCheckFailed}))
+	assert.Equal(t, 3, errorExitCode(&CliError{Err: errors.New("job failed"), Code: ExitCodeJobFailed, ExitCode: 3}))
+}
+
+func Test_CliErrorJSON(t *testing.T) {
+	raw, err := json.Marshal(&CliError{
+		Message:  "cannot find resource 'instance_server' with ID '11111111-1111-1111-1111-111111111111'",
+		Hint:     "List the servers with: scw instance server list",
+		Code:     ExitCodeNotFound,
+		Resource: "instance_server",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"message": "cannot find resource 'instance_server' with ID '11111111-1111-1111-1111-111111111111'",
+		"hint": "List the servers with: scw instance server list",
+		"code": "not_found",
+		"resource": "instance_server"
+	}`, string(raw))
+
+	raw, err = json.Marshal(&CliError{Err: errors.New("job failed"), Code: ExitCodeJobFailed, ExitCode: 3})
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"code":"job_failed"`)
+
+	raw, err = json.Marshal(&CliError{Err: errors.New("failure"), Code: ExitCodeError})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(raw), `"code"`)
+}
@@ -0,0 +1,206 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	debugFlagUsage     = "Enable debug mode, use --debug=http to log the HTTP requests and responses without secrets"
+	debugFileFlagUsage = "Write debug logs to a file instead of stderr"
+
+	// httpDebugMaxBodySize is the size above which bodies are not logged
+	httpDebugMaxBodySize = 64 * 1024
+	httpDebugRedacted    = "<redacted>"
+)
+
+// debugMode is the value of the --debug flag. It is a boolean flag with an additional http mode.
+type debugMode string
+
+const (
+	debugModeDisabled = debugMode("")
+	debugModeEnabled  = debugMode("true")
+	debugModeHTTP     = debugMode("http")
+)
+
+func (d *debugMode) String() string {
+	if *d == debugModeDisabled {
+		return "false"
+	}
+	return string(*d)
+}
+
+func (d *debugMode) Set(value string) error {
+	if value == string(debugModeHTTP) {
+		*d = debugModeHTTP
+		return nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return fmt.Errorf("invalid debug mode '%s', expected true, false or http", value)
+	}
+	*d = debugModeDisabled
+	if enabled {
+		*d = debugModeEnabled
+	}
+	return nil
+}
+
+func (d *debugMode) Type() string {
+	return "bool"
+}
+
+func (d *debugMode) IsBoolFlag() bool {
+	return true
+}
+
+// httpDebugSensitiveHeaders are the headers whose values are redacted
+var httpDebugSensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Proxy-Authorization": true,
+	"Set-Cookie":          true,
+	"X-Auth-Token":        true,
+	"X-Session-Token":     true,
+}
+
+// httpDebugSensitiveFields are the suffixes of the JSON fields whose values are redacted, such as {"secret_key": "..."}
+var httpDebugSensitiveFields = []string{"secret_key", "secret", "password", "token", "private_key", "credentials"}
+
+// httpDebugTransport logs HTTP requests and responses, with their secrets redacted and their JSON bodies pretty-printed.
+type httpDebugTransport struct {
+	transport http.RoundTripper
+	logger    *Logger
+
+	mu sync.Mutex
+	// requestNumber distinguishes concurrent requests in logs
+	requestNumber uint32
+}
+
+func (t *httpDebugTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	number := atomic.AddUint32(&t.requestNumber, 1)
+
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+	t.log(fmt.Sprintf("HTTP REQUEST %d", number), fmt.Sprintf("%s %s", request.Method, request.URL), request.Header, body)
+
+	start := time.Now()
+	response, err := t.transport.RoundTrip(request)
+	if err != nil {
+		t.log(fmt.Sprintf("HTTP RESPONSE %d", number), fmt.Sprintf("error after %s: %s", time.Since(start).Round(time.Millisecond), err), nil, nil)
+		return response, err
+	}
+
+	body, err = io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	t.log(fmt.Sprintf("HTTP RESPONSE %d", number), fmt.Sprintf("%s %s in %s", response.Proto, response.Status, time.Since(start).Round(time.Millisecond)), response.Header, body)
+
+	return response, nil
+}
+
+func (t *httpDebugTransport) log(title string, firstLine string, header http.Header, body []byte) {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "--------------- %s ---------------\n%s\n", title, firstLine)
+
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := header[name]
+		if httpDebugSensitiveHeaders[http.CanonicalHeaderKey(name)] {
+			values = []string{httpDebugRedacted}
+		}
+		fmt.Fprintf(buf, "%s: %s\n", name, strings.Join(values, ", "))
+	}
+
+	if len(body) > 0 {
+		fmt.Fprintf(buf, "\n%s\n", formatDebugBody(body))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.logger.writer.Write(buf.Bytes())
+}
+
+// readRequestBody reads the body of the request and replaces it so that it can be sent.
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(request.Body)
+	_ = request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// formatDebugBody pretty-prints JSON bodies with their secrets redacted, other bodies are printed as is
+// unless they are binary or too large.
+func formatDebugBody(body []byte) string {
+	if len(body) > httpDebugMaxBodySize {
+		return fmt.Sprintf("<%d bytes not logged>", len(body))
+	}
+
+	var content interface{}
+	if json.Unmarshal(body, &content) == nil {
+		formatted, err := json.MarshalIndent(redactDebugJSON(content), "", "  ")
+		if err == nil {
+			return string(formatted)
+		}
+	}
+
+	if !utf8.Valid(body) {
+		return fmt.Sprintf("<%d bytes of binary content not logged>", len(body))
+	}
+	return string(body)
+}
+
+func redactDebugJSON(content interface{}) interface{} {
+	switch value := content.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitiveDebugField(key) && field != nil {
+				value[key] = httpDebugRedacted
+				continue
+			}
+			value[key] = redactDebugJSON(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactDebugJSON(item)
+		}
+	}
+	return content
+}
+
+func isSensitiveDebugField(key string) bool {
+	key = strings.ToLower(key)
+	for _, field := range httpDebugSensitiveFields {
+		if strings.HasSuffix(key, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDebugMode(t *testing.T) {
+	mode := debugModeDisabled
+	assert.Equal(t, "false", mode.String())
+
+	require.NoError(t, mode.Set("http"))
+	assert.Equal(t, debugModeHTTP, mode)
+	require.NoError(t, mode.Set("true"))
+	assert.Equal(t, debugModeEnabled, mode)
+	require.NoError(t, mode.Set("false"))
+	assert.Equal(t, debugModeDisabled, mode)
+	assert.Error(t, mode.Set("verbose"))
+}
+
+type httpDebugTestTransport struct {
+	body string
+}
+
+func (t *httpDebugTestTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, err
+	}
+	t.body = string(body)
+	return &http.Response{
+		Proto:      "HTTP/1.1",
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewBufferString(`{"access_key":"SCWXXXXXXXXXXXXXXXXX","secret_key":"11111111-1111-1111-1111-111111111111"}`)),
+	}, nil
+}
+
+func TestHTTPDebugTransport(t *testing.T) {
+	logs := &bytes.Buffer{}
+	backend := &httpDebugTestTransport{}
+	transport := &httpDebugTransport{transport: backend, logger: &Logger{writer: logs}}
+
+	request, err := http.NewRequest(http.MethodPost, "https://api.scaleway.com/rdb/v1/regions/fr-par/instances", bytes.NewBufferString(`{"name":"foo","password":"p4ssw0rd","tags":[{"user_token":"t0k3n"}]}`))
+	require.NoError(t, err)
+	request.Header.Set("X-Auth-Token", "11111111-1111-1111-1111-111111111111")
+
+	res, err := transport.RoundTrip(request)
+	require.NoError(t, err)
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	// Requests and responses are not altered
+	assert.Equal(t, `{"name":"foo","password":"p4ssw0rd","tags":[{"user_token":"t0k3n"}]}`, backend.body)
+	assert.Contains(t, string(body), "11111111-1111-1111-1111-111111111111")
+
+	assert.Contains(t, logs.String(), "POST https://api.scaleway.com/rdb/v1/regions/fr-par/instances\n")
+	assert.Contains(t, logs.String(), "X-Auth-Token: <redacted>\n")
+	assert.Contains(t, logs.String(), "\"name\": \"foo\"")
+	assert.Contains(t, logs.String(), "\"access_key\": \"SCWXXXXXXXXXXXXXXXXX\"")
+	assert.Contains(t, logs.String(), "HTTP/1.1 200 OK in ")
+	assert.NotContains(t, logs.String(), "11111111-1111-1111-1111-111111111111")
+	assert.NotContains(t, logs.String(), "p4ssw0rd")
+	assert.NotContains(t, logs.String(), "t0k3n")
+}
@@ -1,21 +1,111 @@
 package core
 
 import (
+	"io"
+	"math/rand"
 	"net/http"
+	"strconv"
+	"sync/atomic"
 	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/logger"
 )
 
-const defaultRetryInterval = 1 * time.Second
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 1 * time.Second
+	maxRetryBackoff     = 30 * time.Second
+
+	maxRetriesFlagUsage   = "Maximum number of retries of requests failing with a 429 or 5xx error"
+	retryBackoffFlagUsage = "Delay before the first retry of a failed request, doubled at each retry"
+)
 
+// retryableHTTPTransport retries requests failing with a 429 error, and idempotent requests failing
+// with a 5xx error, with an exponential backoff and jitter.
 type retryableHTTPTransport struct {
-	transport http.RoundTripper
+	transport  http.RoundTripper
+	maxRetries int
+	backoff    time.Duration
+
+	// retries counts the retries made by the transport
+	retries int64
+}
+
+func newRetryableHTTPTransport(transport http.RoundTripper) *retryableHTTPTransport {
+	return &retryableHTTPTransport{
+		transport:  transport,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultRetryBackoff,
+	}
 }
 
 func (r *retryableHTTPTransport) RoundTrip(request *http.Request) (*http.Response, error) {
-	res, err := r.transport.RoundTrip(request)
-	if err == nil && res.StatusCode == http.StatusTooManyRequests {
-		time.Sleep(defaultRetryInterval)
-		return r.RoundTrip(request)
+	for attempt := 1; ; attempt++ {
+		res, err := r.transport.RoundTrip(request)
+		if err != nil || attempt > r.maxRetries || !isRetryableResponse(request, res) {
+			return res, err
+		}
+
+		// The body of the request was consumed, it must be rewound to send the request again.
+		if request.Body != nil && request.Body != http.NoBody {
+			if request.GetBody == nil {
+				return res, nil
+			}
+			body, err := request.GetBody()
+			if err != nil {
+				return res, nil
+			}
+			request = request.Clone(request.Context())
+			request.Body = body
+		}
+
+		delay := r.retryDelay(attempt, res)
+		logger.Debugf("%s %s failed with status %d, retry %d/%d in %s\n", request.Method, request.URL, res.StatusCode, attempt, r.maxRetries, delay)
+		_, _ = io.Copy(io.Discard, res.Body)
+		_ = res.Body.Close()
+		atomic.AddInt64(&r.retries, 1)
+
+		select {
+		case <-request.Context().Done():
+			return nil, request.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// Retries returns the number of retries made by the transport.
+func (r *retryableHTTPTransport) Retries() int64 {
+	return atomic.LoadInt64(&r.retries)
+}
+
+// retryDelay returns the delay before a retry, the Retry-After header is used when set.
+// Otherwise, the backoff is doubled at each attempt and a random jitter of up to half the delay is removed.
+func (r *retryableHTTPTransport) retryDelay(attempt int, res *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if r.backoff <= 0 {
+		return 0
+	}
+
+	delay := r.backoff << (attempt - 1)
+	if delay <= 0 || delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	return delay - time.Duration(rand.Int63n(int64(delay/2)+1)) //nolint:gosec
+}
+
+// isRetryableResponse returns true when a request failed with a transient error and can be sent again.
+// 5xx errors are retried only for idempotent methods as the request may have been processed.
+func isRetryableResponse(request *http.Request, res *http.Response) bool {
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests:
+		return true
+	case res.StatusCode >= http.StatusInternalServerError && res.StatusCode != http.StatusNotImplemented:
+		switch request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
 	}
-	return res, err
+	return false
 }
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// retryTestTransport answers with statusCodes in order, then with 200.
+type retryTestTransport struct {
+	statusCodes []int
+	bodies      []string
+}
+
+func (t *retryTestTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	body := ""
+	if request.Body != nil {
+		content, err := io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(content)
+	}
+	t.bodies = append(t.bodies, body)
+
+	statusCode := http.StatusOK
+	if len(t.statusCodes) > 0 {
+		statusCode, t.statusCodes = t.statusCodes[0], t.statusCodes[1:]
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{},
+		Body:       io.NopCloser(bytes.NewBufferString("")),
+	}, nil
+}
+
+func TestRetryableHTTPTransport(t *testing.T) {
+	newTransport := func(statusCodes ...int) (*retryableHTTPTransport, *retryTestTransport) {
+		backend := &retryTestTransport{statusCodes: statusCodes}
+		transport := newRetryableHTTPTransport(backend)
+		transport.backoff = 0
+		return transport, backend
+	}
+
+	t.Run("rewind body", func(t *testing.T) {
+		transport, backend := newTransport(http.StatusTooManyRequests, http.StatusTooManyRequests)
+		request, err := http.NewRequest(http.MethodPost, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", bytes.NewBufferString(`{"name":"foo"}`))
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, []string{`{"name":"foo"}`, `{"name":"foo"}`, `{"name":"foo"}`}, backend.bodies)
+		assert.Equal(t, int64(2), transport.Retries())
+	})
+
+	t.Run("max retries", func(t *testing.T) {
+		transport, backend := newTransport(http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		request, err := http.NewRequest(http.MethodGet, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert.Len(t, backend.bodies, defaultMaxRetries+1)
+	})
+
+	t.Run("no retry of non idempotent request", func(t *testing.T) {
+		transport, backend := newTransport(http.StatusBadGateway)
+		request, err := http.NewRequest(http.MethodPost, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", bytes.NewBufferString(`{}`))
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadGateway, res.StatusCode)
+		assert.Len(t, backend.bodies, 1)
+	})
+
+	t.Run("retries disabled", func(t *testing.T) {
+		transport, backend := newTransport(http.StatusTooManyRequests)
+		transport.maxRetries = 0
+		request, err := http.NewRequest(http.MethodGet, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers", nil)
+		require.NoError(t, err)
+
+		res, err := transport.RoundTrip(request)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusTooManyRequests, res.StatusCode)
+		assert.Len(t, backend.bodies, 1)
+	})
+}
+
+func TestRetryableHTTPTransport_retryDelay(t *testing.T) {
+	transport := newRetryableHTTPTransport(nil)
+	res := &http.Response{Header: http.Header{}}
+	for attempt, maxDelay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		delay := transport.retryDelay(attempt+1, res)
+		assert.LessOrEqual(t, delay, maxDelay)
+		assert.GreaterOrEqual(t, delay, maxDelay/2)
+	}
+	assert.GreaterOrEqual(t, transport.retryDelay(100, res), maxRetryBackoff/2)
+
+	res.Header.Set("Retry-After", "5")
+	assert.Equal(t, 5*time.Second, transport.retryDelay(1, res))
+}
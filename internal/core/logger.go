@@ -1,8 +1,11 @@
 package core
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 
 	"github.com/scaleway/scaleway-sdk-go/logger"
 )
@@ -63,3 +66,20 @@ func (l *Logger) Error(args ...interface{}) {
 func (l *Logger) ShouldLog(level logger.LogLevel) bool {
 	return l.level <= level
 }
+
+// lastDebugLogFileName is the file of the cache directory keeping the debug logs of the last command run in debug mode
+const lastDebugLogFileName = "last-debug.log"
+
+// LastDebugLogPath returns the path of the debug logs of the last command run in debug mode.
+func LastDebugLogPath(ctx context.Context) string {
+	return filepath.Join(ExtractCacheDir(ctx), lastDebugLogFileName)
+}
+
+func createLastDebugLog(ctx context.Context) (*os.File, error) {
+	err := os.MkdirAll(ExtractCacheDir(ctx), 0o700)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.OpenFile(LastDebugLogPath(ctx), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+}
@@ -0,0 +1,224 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/scaleway/scaleway-sdk-go/logger"
+	"github.com/spf13/cobra"
+)
+
+const resumeFlagUsage = "Resume an interrupted listing from the last page it fetched"
+
+// paginationCursorTransport records the pages fetched by list commands in the cache directory.
+// When a listing is interrupted the recorded pages are kept, running the command again with --resume
+// replays them and only fetches the missing pages.
+type paginationCursorTransport struct {
+	transport http.RoundTripper
+
+	mu sync.Mutex
+	// dir is the directory where pages are recorded, recording is disabled when empty.
+	dir    string
+	resume bool
+	// listings is the last page recorded for each listing of the running command.
+	listings map[string]int
+}
+
+// paginationPage is a recorded page response.
+type paginationPage struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+func (t *paginationCursorTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	dir, resume := t.dir, t.resume
+	t.mu.Unlock()
+
+	page := request.URL.Query().Get("page")
+	if dir == "" || request.Method != http.MethodGet || page == "" {
+		return t.transport.RoundTrip(request)
+	}
+
+	listing := paginationListingKey(request)
+	pagePath := filepath.Join(dir, listing, page+".json")
+
+	if resume {
+		res, err := readPaginationPage(pagePath, request)
+		if err == nil {
+			logger.Debugf("resuming listing from recorded page %s of %s", page, request.URL.Path)
+			t.record(listing, page)
+			return res, nil
+		}
+	} else if page == "1" {
+		// A new listing starts, pages recorded by a previous interrupted run are outdated.
+		_ = os.RemoveAll(filepath.Join(dir, listing))
+	}
+
+	res, err := t.transport.RoundTrip(request)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	err = writePaginationPage(pagePath, &paginationPage{
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       body,
+	})
+	if err != nil {
+		logger.Debugf("cannot record page %s of %s: %s", page, request.URL.Path, err)
+		return res, nil
+	}
+	t.record(listing, page)
+
+	return res, nil
+}
+
+// start enables the recording of the pages fetched by a list command.
+func (t *paginationCursorTransport) start(dir string, resume bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.dir = dir
+	t.resume = resume
+	t.listings = map[string]int{}
+}
+
+// stop disables the recording and returns the number of pages kept to resume the listing.
+// Recorded pages are removed when the command succeeded.
+func (t *paginationCursorTransport) stop(succeeded bool) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pages := 0
+	for listing, lastPage := range t.listings {
+		if succeeded {
+			_ = os.RemoveAll(filepath.Join(t.dir, listing))
+			continue
+		}
+		pages += lastPage
+	}
+
+	t.dir = ""
+	t.listings = nil
+
+	return pages
+}
+
+func (t *paginationCursorTransport) record(listing string, page string) {
+	pageNumber, err := strconv.Atoi(page)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.listings != nil && pageNumber > t.listings[listing] {
+		t.listings[listing] = pageNumber
+	}
+}
+
+// paginationListingKey identifies the pages of a listing: the request without its page number.
+func paginationListingKey(request *http.Request) string {
+	query := request.URL.Query()
+	query.Del("page")
+
+	sum := sha256.Sum256([]byte(request.Method + " " + request.URL.Host + request.URL.Path + "?" + query.Encode()))
+	return hex.EncodeToString(sum[:16])
+}
+
+func readPaginationPage(path string, request *http.Request) (*http.Response, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &paginationPage{}
+	err = json.Unmarshal(content, page)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", page.StatusCode, http.StatusText(page.StatusCode)),
+		StatusCode:    page.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        page.Header,
+		Body:          io.NopCloser(bytes.NewReader(page.Body)),
+		ContentLength: int64(len(page.Body)),
+		Request:       request,
+	}, nil
+}
+
+func writePaginationPage(path string, page *paginationPage) error {
+	content, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o600)
+}
+
+// startPaginationCursor records the pages fetched by a list command, using the default HTTP client.
+// The returned function must be called with the error of the command: when the listing was interrupted
+// the error is completed with a hint to resume it.
+func startPaginationCursor(ctx context.Context, cobraCmd *cobra.Command, cmd *Command) func(error) error {
+	cursor, isCursor := extractMeta(ctx).httpClient.Transport.(*paginationCursorTransport)
+	if !isCursor || cmd.Verb != "list" {
+		return func(err error) error { return err }
+	}
+
+	resume, _ := cobraCmd.PersistentFlags().GetBool("resume")
+	cursor.start(filepath.Join(ExtractCacheDir(ctx), "pagination"), resume)
+
+	return func(err error) error {
+		pages := cursor.stop(err == nil)
+		if err == nil || pages == 0 {
+			return err
+		}
+
+		details := fmt.Sprintf("The listing was interrupted after %d pages, they have been saved.", pages)
+		hint := "Run the same command with --resume to continue the listing from where it stopped."
+		if cliErr, isCliErr := err.(*CliError); isCliErr {
+			if cliErr.Details == "" {
+				cliErr.Details = details
+			}
+			if cliErr.Hint == "" {
+				cliErr.Hint = hint
+			}
+			return cliErr
+		}
+
+		return &CliError{
+			Err:     err,
+			Details: details,
+			Hint:    hint,
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paginationTestTransport serves pages until failAtPage is reached.
+type paginationTestTransport struct {
+	failAtPage string
+	requested  []string
+}
+
+func (t *paginationTestTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	page := request.URL.Query().Get("page")
+	t.requested = append(t.requested, page)
+	if page == t.failAtPage {
+		return nil, errors.New("connection reset by peer")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Total-Count": []string{"3"}},
+		Body:       io.NopCloser(bytes.NewBufferString("page " + page)),
+	}, nil
+}
+
+func TestPaginationCursorTransport(t *testing.T) {
+	dir := t.TempDir()
+	backend := &paginationTestTransport{failAtPage: "3"}
+	cursor := &paginationCursorTransport{transport: backend}
+
+	listPages := func() error {
+		for _, page := range []string{"1", "2", "3"} {
+			request, err := http.NewRequest(http.MethodGet, "https://api.scaleway.com/domain/v2beta1/dns-zones/example.com/records?page="+page+"&page_size=100", nil)
+			require.NoError(t, err)
+			res, err := cursor.RoundTrip(request)
+			if err != nil {
+				return err
+			}
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "page "+page, string(body))
+			assert.Equal(t, "3", res.Header.Get("X-Total-Count"))
+		}
+		return nil
+	}
+
+	cursor.start(dir, false)
+	require.Error(t, listPages())
+	assert.Equal(t, 2, cursor.stop(false))
+
+	// Recorded pages are replayed, only the missing page is fetched.
+	backend.failAtPage = ""
+	backend.requested = nil
+	cursor.start(dir, true)
+	require.NoError(t, listPages())
+	assert.Equal(t, []string{"3"}, backend.requested)
+	assert.Equal(t, 0, cursor.stop(true))
+
+	// Recorded pages are removed once the listing succeeded.
+	backend.requested = nil
+	cursor.start(dir, true)
+	require.NoError(t, listPages())
+	assert.Equal(t, []string{"1", "2", "3"}, backend.requested)
+	cursor.stop(true)
+}
+
+func TestPaginationCursorTransport_Disabled(t *testing.T) {
+	backend := &paginationTestTransport{}
+	cursor := &paginationCursorTransport{transport: backend}
+
+	request, err := http.NewRequest(http.MethodGet, "https://api.scaleway.com/instance/v1/zones/fr-par-1/servers?page=1", nil)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = cursor.RoundTrip(request)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"1", "1"}, backend.requested)
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/spf13/cobra"
+)
+
+const noInteractiveFlagUsage = "Use the default values of the missing arguments instead of prompting for them"
+
+// CommandPickArgsFunc prompts the user to pick the values of missing arguments and returns the raw args with these values.
+type CommandPickArgsFunc func(ctx context.Context, rawArgs args.RawArgs) (args.RawArgs, error)
+
+// pickArgs lets the user pick the values of missing arguments in interactive mode, before the default values are applied.
+// Arguments are not picked with --no-interactive, so that scripts run by a terminal get the default values.
+func pickArgs(ctx context.Context, cobraCmd *cobra.Command, cmd *Command, rawArgs args.RawArgs) (args.RawArgs, error) {
+	if cmd.PickArgs == nil || !interactive.IsInteractive {
+		return rawArgs, nil
+	}
+	if noInteractive, err := cobraCmd.PersistentFlags().GetBool("no-interactive"); err == nil && noInteractive {
+		return rawArgs, nil
+	}
+	return cmd.PickArgs(ctx, rawArgs)
+}
+
+// PickChoice prompts a searchable list of choices, with the default value selected, and returns the value of the chosen one.
+func PickChoice(ctx context.Context, prompt string, values []string, choices []string, defaultValue string) (string, error) {
+	if len(values) == 0 {
+		return defaultValue, nil
+	}
+
+	defaultIndex := 0
+	for i, value := range values {
+		if value == defaultValue {
+			defaultIndex = i
+		}
+	}
+
+	listPrompt := interactive.ListPrompt{
+		Prompt:       prompt,
+		Choices:      choices,
+		DefaultIndex: defaultIndex,
+		Searchable:   true,
+	}
+	index, err := listPrompt.Execute(ctx)
+	if err != nil {
+		return "", &CliError{
+			Err:  err,
+			Hint: "Give the value as an argument or use --no-interactive to use the default value",
+		}
+	}
+	return values[index], nil
+}
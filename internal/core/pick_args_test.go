@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_pickArgs(t *testing.T) {
+	isInteractive := interactive.IsInteractive
+	defer func() {
+		interactive.IsInteractive = isInteractive
+	}()
+
+	cmd := &Command{
+		PickArgs: func(_ context.Context, rawArgs args.RawArgs) (args.RawArgs, error) {
+			if !rawArgs.Has("type") {
+				rawArgs = rawArgs.Add("type", "picked")
+			}
+			return rawArgs, nil
+		},
+	}
+	newCobraCmd := func(noInteractive bool) *cobra.Command {
+		cobraCmd := &cobra.Command{}
+		cobraCmd.PersistentFlags().Bool("no-interactive", noInteractive, noInteractiveFlagUsage)
+		return cobraCmd
+	}
+
+	t.Run("Not interactive", func(t *testing.T) {
+		interactive.IsInteractive = false
+		rawArgs, err := pickArgs(context.Background(), newCobraCmd(false), cmd, args.RawArgs{})
+		require.NoError(t, err)
+		assert.Empty(t, rawArgs)
+	})
+
+	t.Run("Picked", func(t *testing.T) {
+		interactive.IsInteractive = true
+		rawArgs, err := pickArgs(context.Background(), newCobraCmd(false), cmd, args.RawArgs{})
+		require.NoError(t, err)
+		assert.Equal(t, args.RawArgs{"type=picked"}, rawArgs)
+	})
+
+	t.Run("Given", func(t *testing.T) {
+		interactive.IsInteractive = true
+		rawArgs, err := pickArgs(context.Background(), newCobraCmd(false), cmd, args.RawArgs{"type=given"})
+		require.NoError(t, err)
+		assert.Equal(t, args.RawArgs{"type=given"}, rawArgs)
+	})
+
+	t.Run("No interactive flag", func(t *testing.T) {
+		interactive.IsInteractive = true
+		rawArgs, err := pickArgs(context.Background(), newCobraCmd(true), cmd, args.RawArgs{})
+		require.NoError(t, err)
+		assert.Empty(t, rawArgs)
+	})
+}
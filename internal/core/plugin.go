@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/pkg/plugin"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/spf13/cobra"
+)
+
+// Plugin is an executable named scw-<name> found in the PATH, run as scw <name>.
+type Plugin struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// ListPlugins returns the plugins found in the PATH, sorted by name.
+// When several executables have the same name, the first one of the PATH is used as the shell would.
+func ListPlugins(ctx context.Context) []*Plugin {
+	plugins := []*Plugin(nil)
+	found := map[string]bool{}
+	for _, dir := range filepath.SplitList(ExtractEnv(ctx, "PATH")) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, isPlugin := pluginName(entry)
+			if !isPlugin || found[name] {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, &Plugin{
+				Name: name,
+				Path: filepath.Join(dir, entry.Name()),
+			})
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// pluginName returns the name of the plugin of an executable named scw-<name>.
+func pluginName(entry os.DirEntry) (string, bool) {
+	fileName := entry.Name()
+	if !strings.HasPrefix(fileName, plugin.ExecutablePrefix) || entry.IsDir() {
+		return "", false
+	}
+
+	info, err := entry.Info()
+	if err != nil {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(fileName), ".exe") {
+			return "", false
+		}
+		fileName = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	} else if info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(fileName, plugin.ExecutablePrefix)
+	return name, name != ""
+}
+
+// findPluginCommand returns the plugin to run for the given arguments, if their first positional argument is neither a command
+// nor an alias of the CLI. The arguments following the name of the plugin are returned as the arguments of the plugin.
+func findPluginCommand(ctx context.Context, rootCmd *cobra.Command, positionalArgs []string, args []string) (*Plugin, []string) {
+	if len(positionalArgs) == 0 {
+		return nil, nil
+	}
+	name := positionalArgs[0]
+	if cmd, _, err := rootCmd.Find([]string{name}); err == nil && cmd != rootCmd {
+		return nil, nil
+	}
+	if cliCfg := ExtractCliConfig(ctx); cliCfg != nil && cliCfg.Alias.GetAlias(name) != nil {
+		return nil, nil
+	}
+
+	for _, p := range ListPlugins(ctx) {
+		if p.Name != name {
+			continue
+		}
+		for i, arg := range args {
+			if arg == name {
+				return p, args[i+1:]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// runPlugin runs a plugin with the profile and the credentials of the CLI in its environment.
+func runPlugin(ctx context.Context, p *Plugin, args []string) (int, error) {
+	cmd := exec.Command(p.Path, args...) //nolint:gosec
+	cmd.Env = append(os.Environ(), pluginEnv(ctx, p)...)
+	return ExecCmd(ctx, cmd)
+}
+
+// pluginEnv returns the environment variables passing the profile and the credentials to a plugin.
+// Credentials are only passed when the client of the profile can be created, plugins can then authenticate by themselves.
+func pluginEnv(ctx context.Context, p *Plugin) []string {
+	env := []string{
+		plugin.NameEnv + "=" + p.Name,
+		plugin.CLIVersionEnv + "=" + ExtractBuildInfo(ctx).Version.String(),
+		scw.ScwConfigPathEnv + "=" + ExtractConfigPath(ctx),
+	}
+	if profileName := ExtractProfileName(ctx); profileName != "" {
+		env = append(env, scw.ScwActiveProfileEnv+"="+profileName)
+	}
+
+	meta := extractMeta(ctx)
+	client := meta.Client
+	if !meta.isClientFromBootstrapConfig {
+		var err error
+		client, err = meta.Platform.CreateClient(meta.httpClient, ExtractConfigPath(ctx), ExtractProfileName(ctx))
+		if err != nil {
+			ExtractLogger(ctx).Debugf("plugin %s runs without credentials: %s\n", p.Name, err)
+			return env
+		}
+	}
+
+	if accessKey, exists := client.GetAccessKey(); exists {
+		env = append(env, scw.ScwAccessKeyEnv+"="+accessKey)
+	}
+	if secretKey, exists := client.GetSecretKey(); exists {
+		env = append(env, scw.ScwSecretKeyEnv+"="+secretKey)
+	}
+	if organizationID, exists := client.GetDefaultOrganizationID(); exists {
+		env = append(env, scw.ScwDefaultOrganizationIDEnv+"="+organizationID)
+	}
+	if projectID, exists := client.GetDefaultProjectID(); exists {
+		env = append(env, scw.ScwDefaultProjectIDEnv+"="+projectID)
+	}
+	if region, exists := client.GetDefaultRegion(); exists {
+		env = append(env, scw.ScwDefaultRegionEnv+"="+region.String())
+	}
+	if zone, exists := client.GetDefaultZone(); exists {
+		env = append(env, scw.ScwDefaultZoneEnv+"="+zone.String())
+	}
+
+	return env
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginsPath creates the executables of plugins in two directories and returns them as a PATH.
+func testPluginsPath(t *testing.T) string {
+	t.Helper()
+	first, second := t.TempDir(), t.TempDir()
+	files := map[string]os.FileMode{
+		filepath.Join(first, "scw-hello"):       0o755,
+		filepath.Join(first, "scw-test"):        0o755,
+		filepath.Join(first, "scw-not-exec"):    0o644,
+		filepath.Join(second, "scw-hello"):      0o755,
+		filepath.Join(second, "scw-dns-export"): 0o755,
+		filepath.Join(second, "hello"):          0o755,
+	}
+	for path, mode := range files {
+		require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), mode))
+	}
+
+	return first + string(os.PathListSeparator) + second
+}
+
+func Test_ListPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are .exe files on windows")
+	}
+	path := testPluginsPath(t)
+	ctx := injectMeta(context.Background(), &meta{
+		OverrideEnv: map[string]string{"PATH": path},
+	})
+
+	plugins := ListPlugins(ctx)
+	names := []string(nil)
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name)
+	}
+	assert.Equal(t, []string{"dns-export", "hello", "test"}, names)
+	assert.Equal(t, filepath.Join(filepath.SplitList(path)[0], "scw-hello"), plugins[1].Path)
+}
+
+func testGetPluginCommands() *Commands {
+	return NewCommands(
+		&Command{
+			Namespace:            "test",
+			AllowAnonymousClient: true,
+			ArgsType:             reflect.TypeOf(args.RawArgs{}),
+			Run: func(_ context.Context, _ interface{}) (i interface{}, e error) {
+				return &SuccessResult{Message: "test command"}, nil
+			},
+		},
+	)
+}
+
+func Test_RunPlugin(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are .exe files on windows")
+	}
+	path := testPluginsPath(t)
+
+	t.Run("Plugin", Test(&TestConfig{
+		Commands: testGetPluginCommands(),
+		Cmd:      "scw -p default hello world --name=foo",
+		OverrideEnv: map[string]string{
+			"PATH": path,
+		},
+		OverrideExec: func(ctx *ExecFuncCtx, cmd *exec.Cmd) (int, error) {
+			assert.Equal(ctx.T, []string{"world", "--name=foo"}, cmd.Args[1:])
+			assert.Contains(ctx.T, cmd.Env, "SCW_PLUGIN_NAME=hello")
+			assert.Contains(ctx.T, cmd.Env, "SCW_PROFILE=default")
+			assert.Contains(ctx.T, cmd.Env, "SCW_DEFAULT_ZONE=fr-par-1")
+			return 3, nil
+		},
+		Check:      TestCheckExitCode(3),
+		TmpHomeDir: true,
+	}))
+
+	t.Run("Commands take precedence", Test(&TestConfig{
+		Commands: testGetPluginCommands(),
+		Cmd:      "scw test",
+		OverrideEnv: map[string]string{
+			"PATH": path,
+		},
+		Check: TestCheckCombine(
+			TestCheckExitCode(0),
+			func(t *testing.T, ctx *CheckFuncCtx) {
+				assert.Equal(t, "test command", ctx.Result.(*SuccessResult).Message)
+			},
+		),
+		TmpHomeDir: true,
+	}))
+}
@@ -39,19 +39,42 @@ const (
 
 	// Option to enable pretty output on json printer.
 	PrinterOptJSONPretty = "pretty"
+
+	// Option to display lists as records on human printer.
+	PrinterOptHumanVertical = "vertical"
+)
+
+const (
+	noTruncateFlagUsage    = "Do not truncate values nor hide columns of tables to fit the terminal width"
+	showSensitiveFlagUsage = "Show the values of sensitive fields such as passwords, secret keys and tokens instead of masking them"
+
+	// columnsEnv overrides the width of the terminal tables are truncated to.
+	columnsEnv = "COLUMNS"
 )
 
 type PrinterConfig struct {
 	OutputFlag string
 	Stdout     io.Writer
 	Stderr     io.Writer
+
+	// NoTruncate disables the truncation of tables to the terminal width
+	NoTruncate bool
+
+	// Width overrides the width of the terminal tables are truncated to, when positive
+	Width int
+
+	// ShowSensitive disables the masking of sensitive fields
+	ShowSensitive bool
 }
 
 // NewPrinter returns an initialized formatter corresponding to a given FormatterType.
 func NewPrinter(config *PrinterConfig) (*Printer, error) {
 	printer := &Printer{
-		stdout: config.Stdout,
-		stderr: config.Stderr,
+		stdout:        config.Stdout,
+		stderr:        config.Stderr,
+		noTruncate:    config.NoTruncate,
+		width:         config.Width,
+		showSensitive: config.ShowSensitive,
 	}
 
 	// First we parse OutputFlag to extract printerName and printerOpt (e.g json=pretty)
@@ -121,7 +144,11 @@ func setupTemplatePrinter(printer *Printer, opts string) error {
 
 func setupHumanPrinter(printer *Printer, opts string) {
 	printer.printerType = PrinterTypeHuman
-	if opts != "" {
+	switch opts {
+	case PrinterOptHumanVertical:
+		printer.humanVertical = true
+	case "":
+	default:
 		printer.humanFields = strings.Split(opts, ",")
 	}
 }
@@ -144,6 +171,18 @@ type Printer struct {
 
 	// Allow to select specifics column in a table with human printer
 	humanFields []string
+
+	// Display lists as records instead of tables with human printer
+	humanVertical bool
+
+	// Disable the truncation of tables to the terminal width with human printer
+	noTruncate bool
+
+	// Width tables are truncated to with human printer, the width of the terminal when 0
+	width int
+
+	// Print the values of sensitive fields instead of masking them
+	showSensitive bool
 }
 
 func (p *Printer) Print(data interface{}, opt *human.MarshalOpt) error {
@@ -153,6 +192,10 @@ func (p *Printer) Print(data interface{}, opt *human.MarshalOpt) error {
 		return err
 	}
 
+	if _, isError := data.(error); !isError && !p.showSensitive {
+		data = maskSensitive(data)
+	}
+
 	var err error
 	switch p.printerType {
 	case PrinterTypeHuman:
@@ -177,6 +220,16 @@ func (p *Printer) Print(data interface{}, opt *human.MarshalOpt) error {
 	return nil
 }
 
+// printResult prints the result of a command, with its sensitive fields when the command shows them.
+func (p *Printer) printResult(cmd *Command, result interface{}, opt *human.MarshalOpt) error {
+	if cmd.ShowSensitiveResult && !p.showSensitive {
+		printer := *p
+		printer.showSensitive = true
+		return printer.Print(result, opt)
+	}
+	return p.Print(result, opt)
+}
+
 func (p *Printer) printHuman(data interface{}, opt *human.MarshalOpt) error {
 	_, isError := data.(error)
 
@@ -185,6 +238,12 @@ func (p *Printer) printHuman(data interface{}, opt *human.MarshalOpt) error {
 			opt = &human.MarshalOpt{}
 		}
 
+		opt.Vertical = p.humanVertical
+		opt.Width = p.width
+		if p.noTruncate {
+			opt.DisableShrinking = true
+		}
+
 		if len(p.humanFields) > 0 && reflect.TypeOf(data).Kind() != reflect.Slice {
 			return p.printHuman(fmt.Errorf("list of fields for human output is only supported for commands that return a list"), nil)
 		}
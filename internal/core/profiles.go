@@ -0,0 +1,280 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/spf13/cobra"
+)
+
+const (
+	profilesFlagUsage    = "Run the command with each of the given profiles or profile groups, e.g. prod,staging"
+	allProfilesFlagUsage = "Run the command with each profile of the config"
+)
+
+// getProfilesFlags returns the profiles to run a command with, none when the command must run with the current profile only.
+func getProfilesFlags(ctx context.Context, cobraCmd *cobra.Command) ([]string, error) {
+	profilesFlag, err := cobraCmd.PersistentFlags().GetString("profiles")
+	if err != nil {
+		return nil, nil
+	}
+	allProfiles, _ := cobraCmd.PersistentFlags().GetBool("all-profiles")
+	if profilesFlag == "" && !allProfiles {
+		return nil, nil
+	}
+
+	switch {
+	case profilesFlag != "" && allProfiles:
+		return nil, &CliError{
+			Err: fmt.Errorf("--profiles and --all-profiles cannot be used together"),
+		}
+	case ExtractProfileFlag(ctx) != "":
+		return nil, &CliError{
+			Err: fmt.Errorf("--profile cannot be used with --profiles or --all-profiles"),
+		}
+	}
+
+	config, err := scw.LoadConfigFromPath(ExtractConfigPath(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	profileGroups := map[string][]string(nil)
+	if cliCfg := extractMeta(ctx).CliConfig; cliCfg != nil {
+		profileGroups = cliCfg.ProfileGroups
+	}
+
+	return resolveProfiles(config, profileGroups, profilesFlag, allProfiles)
+}
+
+// resolveProfiles expands profile groups and checks that every profile exists in the config.
+// With allProfiles, the default profile is only used when it holds credentials.
+func resolveProfiles(config *scw.Config, profileGroups map[string][]string, profilesFlag string, allProfiles bool) ([]string, error) {
+	names := []string(nil)
+	if allProfiles {
+		if config.Profile.AccessKey != nil || config.Profile.SecretKey != nil {
+			names = append(names, scw.DefaultProfileName)
+		}
+		profileNames := make([]string, 0, len(config.Profiles))
+		for name := range config.Profiles {
+			profileNames = append(profileNames, name)
+		}
+		sort.Strings(profileNames)
+		names = append(names, profileNames...)
+	} else {
+		for _, name := range strings.Split(profilesFlag, ",") {
+			name = strings.TrimSpace(name)
+			if group, isGroup := profileGroups[name]; isGroup {
+				names = append(names, group...)
+			} else if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	profiles := []string(nil)
+	seen := map[string]bool{}
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		_, err := config.GetProfile(name)
+		if err != nil {
+			return nil, &CliError{
+				Err:  err,
+				Hint: "Profiles are defined in the config file, profile groups in the profile_groups section of the CLI config",
+			}
+		}
+		profiles = append(profiles, name)
+	}
+
+	if len(profiles) == 0 {
+		return nil, &CliError{
+			Err: fmt.Errorf("no profile to run the command with"),
+		}
+	}
+
+	return profiles, nil
+}
+
+// runOnProfiles runs a command once per profile and merges the results.
+// The profile flag and the client of meta are set for each run and restored afterward.
+func runOnProfiles(ctx context.Context, profiles []string, run func() (interface{}, error)) (interface{}, error) {
+	meta := extractMeta(ctx)
+	profileFlag, client := meta.ProfileFlag, meta.Client
+	defer func() {
+		meta.ProfileFlag, meta.Client = profileFlag, client
+	}()
+
+	results := make([]interface{}, 0, len(profiles))
+	for _, profile := range profiles {
+		meta.ProfileFlag = profile
+		result, err := run()
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", profile, err)
+		}
+		results = append(results, result)
+	}
+
+	meta.multiProfile = true
+
+	return mergeProfileResults(profiles, results)
+}
+
+// mergeProfileResults merges the results of several profiles in a single list.
+// Items are copied in structs having the same fields preceded by a Profile field, so that the profile is displayed as a column.
+func mergeProfileResults(profiles []string, results []interface{}) (interface{}, error) {
+	itemsByProfile := make([][]reflect.Value, len(results))
+	itemType := reflect.Type(nil)
+	for i, result := range results {
+		for _, item := range profileResultItems(reflect.ValueOf(result)) {
+			for item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+				if item.IsNil() {
+					break
+				}
+				item = item.Elem()
+			}
+			if item.Kind() == reflect.Ptr || item.Kind() == reflect.Interface {
+				continue
+			}
+			if itemType == nil {
+				itemType = item.Type()
+			} else if item.Type() != itemType {
+				return nil, fmt.Errorf("cannot merge results of type %s and %s", itemType, item.Type())
+			}
+			itemsByProfile[i] = append(itemsByProfile[i], item)
+		}
+	}
+
+	if itemType == nil {
+		return []struct{}{}, nil
+	}
+	if itemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("results of type %s cannot be merged", itemType)
+	}
+
+	structFields := []reflect.StructField{{
+		Name: "Profile",
+		Type: reflect.TypeOf(""),
+		Tag:  `json:"profile"`,
+	}}
+	allFields := profileResultFields(itemType, nil)
+	// As in Go, a field shadows the fields with the same name of deeper embedded structs.
+	depths := map[string]int{}
+	for _, field := range allFields {
+		if depth, exists := depths[field.Name]; !exists || len(field.index) < depth {
+			depths[field.Name] = len(field.index)
+		}
+	}
+	fields := []*profileResultField(nil)
+	fieldNames := map[string]bool{"Profile": true}
+	for _, field := range allFields {
+		if fieldNames[field.Name] || len(field.index) != depths[field.Name] {
+			continue
+		}
+		fieldNames[field.Name] = true
+		fields = append(fields, field)
+		structFields = append(structFields, field.StructField)
+	}
+	mergedType := reflect.StructOf(structFields)
+
+	merged := reflect.MakeSlice(reflect.SliceOf(reflect.PtrTo(mergedType)), 0, len(results))
+	for i, items := range itemsByProfile {
+		for _, item := range items {
+			mergedItem := reflect.New(mergedType).Elem()
+			mergedItem.Field(0).SetString(profiles[i])
+			for j, field := range fields {
+				value, err := item.FieldByIndexErr(field.index)
+				if err != nil {
+					// Field of a nil embedded struct
+					continue
+				}
+				mergedItem.Field(j + 1).Set(value)
+			}
+			merged = reflect.Append(merged, mergedItem.Addr())
+		}
+	}
+
+	return merged.Interface(), nil
+}
+
+// profileResultItems returns the items of a result, a list result is flattened.
+func profileResultItems(result reflect.Value) []reflect.Value {
+	if !result.IsValid() {
+		return nil
+	}
+	if result.Kind() == reflect.Ptr && !result.IsNil() && result.Elem().Kind() == reflect.Slice {
+		result = result.Elem()
+	}
+	if result.Kind() != reflect.Slice {
+		return []reflect.Value{result}
+	}
+
+	items := []reflect.Value(nil)
+	for i := 0; i < result.Len(); i++ {
+		// MultiResults holds the results of a command run with several positional arguments.
+		items = append(items, profileResultItems(result.Index(i))...)
+	}
+
+	return items
+}
+
+type profileResultField struct {
+	reflect.StructField
+	index []int
+}
+
+// profileResultFields returns the exported fields of a struct, fields of embedded structs are promoted.
+func profileResultFields(t reflect.Type, index []int) []*profileResultField {
+	fields := []*profileResultField(nil)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct {
+			fields = append(fields, profileResultFields(fieldType, fieldIndex)...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		fields = append(fields, &profileResultField{
+			StructField: reflect.StructField{
+				Name: field.Name,
+				Type: field.Type,
+				Tag:  field.Tag,
+			},
+			index: fieldIndex,
+		})
+	}
+
+	return fields
+}
+
+// profilesMarshalerOpt adds the Profile column to the fields of a command view.
+// Sections are dropped as the merged result is always a list.
+func profilesMarshalerOpt(opt *human.MarshalOpt) *human.MarshalOpt {
+	if opt == nil || len(opt.Fields) == 0 {
+		return nil
+	}
+
+	return &human.MarshalOpt{
+		Title: opt.Title,
+		Fields: append([]*human.MarshalFieldOpt{{
+			FieldName: "Profile",
+			Label:     "Profile",
+		}}, opt.Fields...),
+	}
+}
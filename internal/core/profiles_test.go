@@ -0,0 +1,83 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type profilesTestBase struct {
+	ID   string `json:"id"`
+	Zone string `json:"zone"`
+}
+
+type profilesTestResource struct {
+	*profilesTestBase
+	Name   string `json:"name"`
+	Zone   string `json:"zone_override"`
+	secret string
+}
+
+func TestResolveProfiles(t *testing.T) {
+	config := &scw.Config{
+		Profile: scw.Profile{AccessKey: scw.StringPtr("SCWXXXXXXXXXXXXXXXXX")},
+		Profiles: map[string]*scw.Profile{
+			"prod-fr": {},
+			"prod-nl": {},
+			"staging": {},
+		},
+	}
+	groups := map[string][]string{
+		"prod": {"prod-fr", "prod-nl"},
+	}
+
+	profiles, err := resolveProfiles(config, groups, "prod, staging,prod-fr", false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod-fr", "prod-nl", "staging"}, profiles)
+
+	profiles, err = resolveProfiles(config, groups, "", true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "prod-fr", "prod-nl", "staging"}, profiles)
+
+	_, err = resolveProfiles(config, groups, "prod,dev", false)
+	assert.Error(t, err)
+}
+
+func TestMergeProfileResults(t *testing.T) {
+	results := []interface{}{
+		[]*profilesTestResource{
+			{profilesTestBase: &profilesTestBase{ID: "1", Zone: "fr-par-1"}, Name: "a", Zone: "nl-ams-1", secret: "s"},
+			{Name: "b"},
+		},
+		MultiResults{
+			&profilesTestResource{profilesTestBase: &profilesTestBase{ID: "3"}, Name: "c"},
+		},
+		[]*profilesTestResource(nil),
+	}
+
+	merged, err := mergeProfileResults([]string{"prod", "staging", "dev"}, results)
+	require.NoError(t, err)
+
+	jsonMerged, err := json.Marshal(merged)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"profile": "prod", "id": "1", "name": "a", "zone_override": "nl-ams-1"},
+		{"profile": "prod", "id": "", "name": "b", "zone_override": ""},
+		{"profile": "staging", "id": "3", "name": "c", "zone_override": ""}
+	]`, string(jsonMerged))
+
+	sorted, err := sortResult(merged, "profile:desc,name")
+	require.NoError(t, err)
+	jsonSorted, err := json.Marshal(sorted)
+	require.NoError(t, err)
+	assert.Contains(t, string(jsonSorted), `[{"profile":"staging"`)
+
+	_, err = mergeProfileResults([]string{"prod", "staging"}, []interface{}{
+		&profilesTestBase{},
+		&profilesTestResource{},
+	})
+	assert.Error(t, err)
+}
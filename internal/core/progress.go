@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const progressFlagUsage = "Progress events format: json prints progress events of long operations on stderr, one JSON object per line"
+
+// ProgressFormat is the format of the progress events, progress events are not printed when empty.
+type ProgressFormat string
+
+const (
+	ProgressFormatNone = ProgressFormat("")
+	ProgressFormatJSON = ProgressFormat("json")
+)
+
+// ProgressState is the state of the step reported by a progress event.
+type ProgressState string
+
+const (
+	ProgressStateStarted   = ProgressState("started")
+	ProgressStateRunning   = ProgressState("running")
+	ProgressStateSucceeded = ProgressState("succeeded")
+	ProgressStateFailed    = ProgressState("failed")
+)
+
+// ProgressEvent is a step of a long operation, printed on stderr with --progress=json
+// so that wrapping tools can display the progress of a command without parsing its human output.
+type ProgressEvent struct {
+	Time    time.Time     `json:"time"`
+	Command string        `json:"command"`
+	Step    string        `json:"step"`
+	State   ProgressState `json:"state"`
+	Percent *float64      `json:"percent,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+func (f ProgressFormat) String() string {
+	return string(f)
+}
+
+func parseProgressFormat(flag string) (ProgressFormat, error) {
+	switch format := ProgressFormat(flag); format {
+	case ProgressFormatNone, ProgressFormatJSON:
+		return format, nil
+	default:
+		return ProgressFormatNone, &CliError{
+			Err:  fmt.Errorf("invalid progress format '%s'", flag),
+			Hint: "Supported progress format is json",
+			Code: ExitCodeUsage,
+		}
+	}
+}
+
+// ReportProgress prints a progress event on stderr when progress events are enabled, it is a no-op otherwise
+// or when the context does not come from a command.
+// Time and Command are set from the context when they are empty.
+func ReportProgress(ctx context.Context, event *ProgressEvent) {
+	meta, ok := ctx.Value(metaContextKey).(*meta)
+	if !ok || meta.progressFormat != ProgressFormatJSON {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Command == "" && meta.command != nil {
+		event.Command = meta.command.GetCommandLine("")
+	}
+
+	meta.progressMu.Lock()
+	defer meta.progressMu.Unlock()
+	err := json.NewEncoder(meta.stderr).Encode(event)
+	if err != nil {
+		meta.Logger.Debugf("cannot print progress event: %s", err)
+	}
+}
+
+// ReportProgressStep reports the state of a step without a percentage.
+func ReportProgressStep(ctx context.Context, step string, state ProgressState) {
+	ReportProgress(ctx, &ProgressEvent{
+		Step:  step,
+		State: state,
+	})
+}
+
+// ReportProgressResult reports that a step succeeded or failed depending on err and returns err.
+func ReportProgressResult(ctx context.Context, step string, err error) error {
+	event := &ProgressEvent{
+		Step:  step,
+		State: ProgressStateSucceeded,
+	}
+	if err != nil {
+		event.State = ProgressStateFailed
+		// Empty errors, like a command exit code, have no message.
+		if cliErr, isCliErr := err.(*CliError); !isCliErr || cliErr.Err != nil {
+			event.Message = err.Error()
+		}
+	}
+	ReportProgress(ctx, event)
+
+	return err
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportProgress(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	ctx := injectMeta(context.Background(), &meta{
+		stderr:         stderr,
+		progressFormat: ProgressFormatJSON,
+		command:        &Command{Namespace: "instance", Resource: "server", Verb: "create"},
+	})
+
+	ReportProgressStep(ctx, "run", ProgressStateStarted)
+	err := ReportProgressResult(ctx, "wait", errors.New("timeout"))
+	assert.EqualError(t, err, "timeout")
+	err = ReportProgressResult(ctx, "run", &CliError{Empty: true, Code: 2})
+	assert.Error(t, err)
+
+	decoder := json.NewDecoder(stderr)
+	events := []*ProgressEvent(nil)
+	for decoder.More() {
+		event := &ProgressEvent{}
+		require.NoError(t, decoder.Decode(event))
+		assert.False(t, event.Time.IsZero())
+		events = append(events, event)
+	}
+	require.Len(t, events, 3)
+	assert.Equal(t, "instance server create", events[0].Command)
+	assert.Equal(t, "run", events[0].Step)
+	assert.Equal(t, ProgressStateStarted, events[0].State)
+	assert.Equal(t, ProgressStateFailed, events[1].State)
+	assert.Equal(t, "timeout", events[1].Message)
+	assert.Equal(t, ProgressStateFailed, events[2].State)
+	assert.Empty(t, events[2].Message)
+}
+
+func TestReportProgressDisabled(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	ctx := injectMeta(context.Background(), &meta{stderr: stderr})
+
+	ReportProgressStep(ctx, "run", ProgressStateStarted)
+	assert.Empty(t, stderr.String())
+}
+
+func TestParseProgressFormat(t *testing.T) {
+	format, err := parseProgressFormat("json")
+	assert.NoError(t, err)
+	assert.Equal(t, ProgressFormatJSON, format)
+
+	format, err = parseProgressFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, ProgressFormatNone, format)
+
+	_, err = parseProgressFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+)
+
+// SensitiveMask replaces the values of sensitive fields in the output of commands.
+const SensitiveMask = "********"
+
+// sensitiveFieldNames are the names of the fields masked in the output, compared without case, dashes and underscores.
+// Fields of the types of the CLI can also be tagged with `sensitive:"true"`.
+var sensitiveFieldNames = map[string]bool{
+	"password":     true,
+	"secretkey":    true,
+	"token":        true,
+	"accesstoken":  true,
+	"refreshtoken": true,
+	"privatekey":   true,
+}
+
+func normalizeSensitiveName(name string) string {
+	return strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(name))
+}
+
+func isSensitiveField(field reflect.StructField) bool {
+	if field.Tag.Get("sensitive") == "true" {
+		return true
+	}
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	return sensitiveFieldNames[normalizeSensitiveName(field.Name)] || sensitiveFieldNames[normalizeSensitiveName(jsonName)]
+}
+
+// maskSensitive returns a copy of data where the values of sensitive fields are replaced by SensitiveMask.
+// data is returned as is when it does not hold any sensitive value.
+func maskSensitive(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+	masked, changed := maskSensitiveValue(reflect.ValueOf(data))
+	if !changed {
+		return data
+	}
+	return masked.Interface()
+}
+
+// maskSensitiveValue masks the sensitive values held by value, the value is copied only when something has been masked.
+func maskSensitiveValue(value reflect.Value) (reflect.Value, bool) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value, false
+		}
+		elem, changed := maskSensitiveValue(value.Elem())
+		if !changed {
+			return value, false
+		}
+		masked := reflect.New(value.Type().Elem())
+		masked.Elem().Set(elem)
+		return masked, true
+
+	case reflect.Interface:
+		if value.IsNil() {
+			return value, false
+		}
+		elem, changed := maskSensitiveValue(value.Elem())
+		if !changed {
+			return value, false
+		}
+		masked := reflect.New(value.Type()).Elem()
+		masked.Set(elem)
+		return masked, true
+
+	case reflect.Struct:
+		masked := reflect.Value{}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			fieldValue, changed := value.Field(i), false
+			if isSensitiveField(field) {
+				fieldValue, changed = maskSensitiveString(fieldValue)
+			}
+			if !changed {
+				fieldValue, changed = maskSensitiveValue(fieldValue)
+			}
+			if !changed {
+				continue
+			}
+
+			// The struct is copied as a whole so that its unexported fields are kept
+			if !masked.IsValid() {
+				masked = reflect.New(value.Type()).Elem()
+				masked.Set(value)
+			}
+			masked.Field(i).Set(fieldValue)
+		}
+		if !masked.IsValid() {
+			return value, false
+		}
+		return masked, true
+
+	case reflect.Slice:
+		masked := reflect.Value{}
+		for i := 0; i < value.Len(); i++ {
+			elem, changed := maskSensitiveValue(value.Index(i))
+			if !changed {
+				continue
+			}
+			if !masked.IsValid() {
+				masked = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+				reflect.Copy(masked, value)
+			}
+			masked.Index(i).Set(elem)
+		}
+		if !masked.IsValid() {
+			return value, false
+		}
+		return masked, true
+
+	case reflect.Map:
+		masked := reflect.Value{}
+		iter := value.MapRange()
+		for iter.Next() {
+			elem, changed := reflect.Value{}, false
+			if iter.Key().Kind() == reflect.String && sensitiveFieldNames[normalizeSensitiveName(iter.Key().String())] {
+				elem, changed = maskSensitiveString(iter.Value())
+			}
+			if !changed {
+				elem, changed = maskSensitiveValue(iter.Value())
+			}
+			if !changed {
+				continue
+			}
+			if !masked.IsValid() {
+				masked = reflect.MakeMapWithSize(value.Type(), value.Len())
+				copyIter := value.MapRange()
+				for copyIter.Next() {
+					masked.SetMapIndex(copyIter.Key(), copyIter.Value())
+				}
+			}
+			masked.SetMapIndex(iter.Key(), elem)
+		}
+		if !masked.IsValid() {
+			return value, false
+		}
+		return masked, true
+	}
+
+	return value, false
+}
+
+// maskSensitiveString masks a string or a pointer to a string, empty values are not masked.
+func maskSensitiveString(value reflect.Value) (reflect.Value, bool) {
+	switch {
+	case value.Kind() == reflect.Interface && !value.IsNil():
+		elem, changed := maskSensitiveString(value.Elem())
+		if !changed {
+			return value, false
+		}
+		masked := reflect.New(value.Type()).Elem()
+		masked.Set(elem)
+		return masked, true
+	case value.Kind() == reflect.String && value.Len() > 0:
+		return reflect.ValueOf(SensitiveMask).Convert(value.Type()), true
+	case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.String && value.Elem().Len() > 0:
+		masked := reflect.New(value.Type().Elem())
+		masked.Elem().Set(reflect.ValueOf(SensitiveMask).Convert(value.Type().Elem()))
+		return masked, true
+	}
+	return value, false
+}
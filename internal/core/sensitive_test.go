@@ -0,0 +1,83 @@
+package core
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sensitiveUser struct {
+	Name       string  `json:"name"`
+	Password   string  `json:"password"`
+	APIKey     *string `json:"api_key" sensitive:"true"`
+	unexported string
+}
+
+type sensitiveResult struct {
+	Users    []*sensitiveUser       `json:"users"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+func Test_MaskSensitive(t *testing.T) {
+	apiKey := "secret"
+	result := &sensitiveResult{
+		Users: []*sensitiveUser{
+			{Name: "admin", Password: "P@sSw0Rd", APIKey: &apiKey, unexported: "kept"},
+			{Name: "anonymous"},
+		},
+		Settings: map[string]interface{}{
+			"secret-key": "11111111-1111-1111-1111-111111111111",
+			"region":     "fr-par",
+		},
+	}
+
+	masked := maskSensitive(result).(*sensitiveResult)
+	assert.Equal(t, "admin", masked.Users[0].Name)
+	assert.Equal(t, SensitiveMask, masked.Users[0].Password)
+	assert.Equal(t, SensitiveMask, *masked.Users[0].APIKey)
+	assert.Equal(t, "kept", masked.Users[0].unexported)
+	assert.Equal(t, "", masked.Users[1].Password)
+	assert.Same(t, result.Users[1], masked.Users[1])
+	assert.Equal(t, SensitiveMask, masked.Settings["secret-key"])
+	assert.Equal(t, "fr-par", masked.Settings["region"])
+
+	// The result of the command is left untouched
+	assert.Equal(t, "P@sSw0Rd", result.Users[0].Password)
+	assert.Equal(t, "secret", apiKey)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", result.Settings["secret-key"])
+
+	withoutSensitive := &sensitiveUser{Name: "anonymous"}
+	assert.Same(t, withoutSensitive, maskSensitive(withoutSensitive))
+}
+
+func Test_PrinterShowSensitive(t *testing.T) {
+	user := &sensitiveUser{Name: "admin", Password: "P@sSw0Rd"}
+
+	stdout := &bytes.Buffer{}
+	printer, err := NewPrinter(&PrinterConfig{OutputFlag: "json", Stdout: stdout, Stderr: stdout})
+	assert.NoError(t, err)
+	assert.NoError(t, printer.Print(user, nil))
+	assert.Equal(t, `{"name":"admin","password":"********","api_key":null}`+"\n", stdout.String())
+
+	stdout.Reset()
+	printer, err = NewPrinter(&PrinterConfig{OutputFlag: "json", Stdout: stdout, Stderr: stdout, ShowSensitive: true})
+	assert.NoError(t, err)
+	assert.NoError(t, printer.Print(user, nil))
+	assert.Equal(t, `{"name":"admin","password":"P@sSw0Rd","api_key":null}`+"\n", stdout.String())
+}
+
+func Test_PrinterShowSensitiveResult(t *testing.T) {
+	user := &sensitiveUser{Name: "admin", Password: "P@sSw0Rd"}
+
+	stdout := &bytes.Buffer{}
+	printer, err := NewPrinter(&PrinterConfig{OutputFlag: "json", Stdout: stdout, Stderr: stdout})
+	assert.NoError(t, err)
+	assert.NoError(t, printer.printResult(&Command{ShowSensitiveResult: true}, user, nil))
+	assert.Equal(t, `{"name":"admin","password":"P@sSw0Rd","api_key":null}`+"\n", stdout.String())
+
+	// The printer keeps masking the results of the other commands
+	stdout.Reset()
+	assert.NoError(t, printer.printResult(&Command{}, user, nil))
+	assert.Equal(t, `{"name":"admin","password":"********","api_key":null}`+"\n", stdout.String())
+}
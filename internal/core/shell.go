@@ -4,9 +4,11 @@
 package core
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,9 +19,16 @@ import (
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
 	"github.com/scaleway/scaleway-cli/v2/internal/sentry"
 	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
 	"github.com/spf13/cobra"
 )
 
+const (
+	shellHistoryFileName = "shell_history"
+	shellHistorySize     = 1000
+)
+
 type Completer struct {
 	ctx context.Context
 }
@@ -255,9 +264,12 @@ func NewShellCompleter(ctx context.Context) *Completer {
 }
 
 // shellExecutor returns the function that will execute command entered in shell
-func shellExecutor(rootCmd *cobra.Command, printer *Printer, meta *meta) func(s string) {
+func shellExecutor(rootCmd *cobra.Command, printer *Printer, meta *meta, historyPath string) func(s string) {
 	return func(s string) {
 		args := strings.Fields(s)
+		if len(args) > 0 {
+			appendShellHistory(historyPath, s)
+		}
 
 		sentry.AddCommandContext(strings.Join(removeOptions(args), " "))
 
@@ -285,7 +297,7 @@ func shellExecutor(rootCmd *cobra.Command, printer *Printer, meta *meta) func(s
 
 		autoCompleteCache.Update(meta.command.Namespace)
 
-		printErr := printer.Print(meta.result, meta.command.getHumanMarshalerOpt())
+		printErr := printer.printResult(meta.command, meta.result, meta.humanMarshalerOpt())
 		if printErr != nil {
 			_, _ = fmt.Fprintln(os.Stderr, printErr)
 		}
@@ -316,17 +328,39 @@ func RunShell(ctx context.Context, printer *Printer, meta *meta, rootCmd *cobra.
 		return
 	}
 
+	shellCtx, err := parseShellContext(args[2:])
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		}
+		return
+	}
+	err = applyShellContext(meta, shellCtx)
+	if err != nil {
+		printErr := printer.Print(err, nil)
+		if printErr != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+		}
+		return
+	}
+
 	// remove shell command so it cannot be called from shell
 	rootCmd.RemoveCommand(shellCobraCommand)
 	meta.Commands.Remove("shell", "")
 
-	executor := shellExecutor(rootCmd, printer, meta)
+	historyPath := filepath.Join(ExtractCacheDir(ctx), shellHistoryFileName)
+	executor := shellExecutor(rootCmd, printer, meta, historyPath)
 	quitMessage := terminal.Style("- Type Ctrl+d to quit.", color.Bold, color.FgCyan)
 	fmt.Println(quitMessage)
+	if description := shellCtx.description(); description != "" {
+		fmt.Println(terminal.Style("- Commands run with "+description+".", color.Bold, color.FgCyan))
+	}
 	p := prompt.New(
 		executor,
 		completer.Complete,
-		prompt.OptionPrefix(">>> "),
+		prompt.OptionPrefix(shellCtx.prefix()+">>> "),
+		prompt.OptionHistory(loadShellHistory(historyPath)),
 		prompt.OptionSuggestionBGColor(prompt.Purple),
 		prompt.OptionSelectedSuggestionBGColor(prompt.Fuchsia),
 		prompt.OptionSelectedSuggestionTextColor(prompt.White),
@@ -336,3 +370,153 @@ func RunShell(ctx context.Context, printer *Printer, meta *meta, rootCmd *cobra.
 	)
 	p.Run()
 }
+
+// shellContext holds the profile, project and locality that all the commands of a shell session use by default.
+type shellContext struct {
+	Profile   string
+	ProjectID string
+	Zone      string
+	Region    string
+}
+
+// parseShellContext parses the key=value arguments given to the shell command, flags are ignored.
+func parseShellContext(args []string) (*shellContext, error) {
+	shellCtx := &shellContext{}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(arg, "=")
+		switch key {
+		case "profile":
+			shellCtx.Profile = value
+		case "project-id":
+			if !validation.IsProjectID(value) {
+				return nil, InvalidProjectIDError(value)
+			}
+			shellCtx.ProjectID = value
+		case "zone":
+			if !validation.IsZone(value) {
+				return nil, &CliError{Err: fmt.Errorf("invalid zone '%s'", value)}
+			}
+			shellCtx.Zone = value
+		case "region":
+			if !validation.IsRegion(value) {
+				return nil, &CliError{Err: fmt.Errorf("invalid region '%s'", value)}
+			}
+			shellCtx.Region = value
+		default:
+			return nil, &CliError{
+				Err:  fmt.Errorf("unknown shell argument '%s'", arg),
+				Hint: "Valid arguments are profile, project-id, zone and region",
+			}
+		}
+	}
+
+	// Commands would otherwise mix the zone of the shell with the region of the profile.
+	if shellCtx.Zone != "" && shellCtx.Region == "" {
+		region, err := scw.Zone(shellCtx.Zone).Region()
+		if err == nil {
+			shellCtx.Region = region.String()
+		}
+	}
+
+	return shellCtx, nil
+}
+
+// applyShellContext makes the clients created for each command of the shell use the shell context.
+// Environment variables are used as they take precedence over the profile when clients are created.
+func applyShellContext(meta *meta, shellCtx *shellContext) error {
+	if shellCtx.Profile != "" {
+		meta.ProfileFlag = shellCtx.Profile
+	}
+
+	env := map[string]string{
+		scw.ScwDefaultProjectIDEnv: shellCtx.ProjectID,
+		scw.ScwDefaultZoneEnv:      shellCtx.Zone,
+		scw.ScwDefaultRegionEnv:    shellCtx.Region,
+	}
+	for key, value := range env {
+		if value == "" {
+			continue
+		}
+		err := os.Setenv(key, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *shellContext) description() string {
+	parts := []string(nil)
+	if c.Profile != "" {
+		parts = append(parts, "profile "+c.Profile)
+	}
+	if c.ProjectID != "" {
+		parts = append(parts, "project "+c.ProjectID)
+	}
+	if c.Zone != "" {
+		parts = append(parts, "zone "+c.Zone)
+	}
+	if c.Region != "" && c.Zone == "" {
+		parts = append(parts, "region "+c.Region)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// prefix returns the part of the prompt reminding the shell context.
+func (c *shellContext) prefix() string {
+	parts := []string(nil)
+	if c.Profile != "" {
+		parts = append(parts, c.Profile)
+	}
+	switch {
+	case c.Zone != "":
+		parts = append(parts, c.Zone)
+	case c.Region != "":
+		parts = append(parts, c.Region)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(parts, "/") + ") "
+}
+
+// loadShellHistory returns the latest commands typed in previous shell sessions.
+func loadShellHistory(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	history := []string(nil)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			history = append(history, line)
+		}
+	}
+	if len(history) > shellHistorySize {
+		history = history[len(history)-shellHistorySize:]
+	}
+
+	return history
+}
+
+// appendShellHistory saves a command typed in the shell, errors are ignored as history is a convenience.
+func appendShellHistory(path string, line string) {
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err != nil {
+		return
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, _ = fmt.Fprintln(file, strings.TrimSpace(line))
+}
@@ -47,3 +47,20 @@ func TestShell_isOption(t *testing.T) {
 		assert.Equal(t, argIsOption(test.Arg), test.IsOption, "%s option value is wrong", test.Arg)
 	}
 }
+
+func TestShell_parseShellContext(t *testing.T) {
+	shellCtx, err := parseShellContext([]string{"profile=dev", "zone=nl-ams-1", "--help"})
+	assert.NoError(t, err)
+	assert.Equal(t, &shellContext{Profile: "dev", Zone: "nl-ams-1", Region: "nl-ams"}, shellCtx)
+	assert.Equal(t, "(dev/nl-ams-1) ", shellCtx.prefix())
+
+	shellCtx, err = parseShellContext([]string{"region=pl-waw", "project-id=11111111-1111-1111-1111-111111111111"})
+	assert.NoError(t, err)
+	assert.Equal(t, "project 11111111-1111-1111-1111-111111111111, region pl-waw", shellCtx.description())
+
+	_, err = parseShellContext([]string{"zone=moon-1"})
+	assert.Error(t, err)
+
+	_, err = parseShellContext([]string{"organization-id=11111111-1111-1111-1111-111111111111"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/strcase"
+)
+
+const sortByFlagUsage = "Sort the list by the given fields, e.g. created-at:desc,name"
+
+// sortByKey is a field path of the list items and its sort order.
+type sortByKey struct {
+	field string
+	path  []string
+	desc  bool
+}
+
+// parseSortBy parses a sort specification like "created-at:desc,name".
+func parseSortBy(sortBy string) ([]*sortByKey, error) {
+	keys := []*sortByKey(nil)
+	for _, rawKey := range strings.Split(sortBy, ",") {
+		field, order, _ := strings.Cut(strings.TrimSpace(rawKey), ":")
+		if field == "" {
+			return nil, fmt.Errorf("invalid sort key %q: missing field name", rawKey)
+		}
+
+		key := &sortByKey{field: field}
+		for _, part := range strings.Split(field, ".") {
+			key.path = append(key.path, strcase.ToPublicGoName(part))
+		}
+
+		switch order {
+		case "", "asc":
+		case "desc":
+			key.desc = true
+		default:
+			return nil, fmt.Errorf("invalid sort order %q for field %s: must be asc or desc", order, field)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// sortResult returns a sorted copy of a list result, using the fields given with the --sort-by flag.
+func sortResult(result interface{}, sortBy string) (interface{}, error) {
+	keys, err := parseSortBy(sortBy)
+	if err != nil {
+		return nil, &CliError{
+			Err:  err,
+			Hint: "Use --sort-by=field[:asc|desc],... e.g. --sort-by=created-at:desc,name",
+			Code: ExitCodeUsage,
+		}
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	value := reflect.ValueOf(result)
+	for value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Slice {
+		return nil, &CliError{
+			Err:  fmt.Errorf("--sort-by is only supported for commands returning a list"),
+			Code: ExitCodeUsage,
+		}
+	}
+
+	for _, key := range keys {
+		err := checkSortByKey(value.Type().Elem(), key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items := make([]reflect.Value, value.Len())
+	for i := range items {
+		items[i] = value.Index(i)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, key := range keys {
+			cmp := compareSortByValues(sortByFieldValue(items[i], key.path), sortByFieldValue(items[j], key.path))
+			if cmp == 0 {
+				continue
+			}
+			if key.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	sorted := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+	for i, item := range items {
+		sorted.Index(i).Set(item)
+	}
+
+	return sorted.Interface(), nil
+}
+
+// checkSortByKey makes sure the field path of a key exists in the list items type.
+func checkSortByKey(t reflect.Type, key *sortByKey) error {
+	for _, part := range key.path {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		var field reflect.StructField
+		exists := false
+		if t.Kind() == reflect.Struct {
+			field, exists = t.FieldByName(part)
+		}
+		if !exists {
+			return &CliError{
+				Err:  fmt.Errorf("cannot sort by %s: unknown field", key.field),
+				Hint: "Use -o json to see the available fields",
+				Code: ExitCodeUsage,
+			}
+		}
+		t = field.Type
+	}
+
+	return nil
+}
+
+// sortByFieldValue returns the value of a field path, or an invalid value if a nil pointer is met.
+func sortByFieldValue(value reflect.Value, path []string) reflect.Value {
+	for _, part := range path {
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return reflect.Value{}
+			}
+			value = value.Elem()
+		}
+		value = value.FieldByName(part)
+	}
+
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+
+	return value
+}
+
+// compareSortByValues returns -1, 0 or 1, missing values are sorted first.
+func compareSortByValues(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	case !a.CanInterface() || !b.CanInterface():
+		return 0
+	}
+
+	if timeA, isTime := a.Interface().(time.Time); isTime {
+		timeB := b.Interface().(time.Time)
+		switch {
+		case timeA.Before(timeB):
+			return -1
+		case timeA.After(timeB):
+			return 1
+		}
+		return 0
+	}
+
+	switch a.Kind() {
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareOrdered(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareOrdered(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareOrdered(a.Float(), b.Float())
+	case reflect.Bool:
+		return compareOrdered(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	default:
+		return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64 | int](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
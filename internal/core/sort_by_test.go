@@ -0,0 +1,80 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sortByTestResource struct {
+	Name      string
+	Size      uint64
+	CreatedAt *time.Time
+	Location  *sortByTestLocation
+}
+
+type sortByTestLocation struct {
+	Zone string
+}
+
+func TestSortResult(t *testing.T) {
+	date := func(day int) *time.Time {
+		d := time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	names := func(result interface{}) []string {
+		names := []string(nil)
+		for _, resource := range result.([]*sortByTestResource) {
+			names = append(names, resource.Name)
+		}
+		return names
+	}
+
+	resources := []*sortByTestResource{
+		{Name: "b", Size: 10, CreatedAt: date(2), Location: &sortByTestLocation{Zone: "nl-ams-1"}},
+		{Name: "a", Size: 20, CreatedAt: date(3), Location: &sortByTestLocation{Zone: "fr-par-1"}},
+		{Name: "c", Size: 10, CreatedAt: nil},
+	}
+
+	t.Run("by name", func(t *testing.T) {
+		result, err := sortResult(resources, "name")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, names(result))
+		assert.Equal(t, "b", resources[0].Name, "command result should not be modified")
+	})
+
+	t.Run("by date descending", func(t *testing.T) {
+		result, err := sortResult(resources, "created-at:desc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c"}, names(result))
+	})
+
+	t.Run("by several fields", func(t *testing.T) {
+		result, err := sortResult(resources, "size:desc,name:desc")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "c", "b"}, names(result))
+	})
+
+	t.Run("by nested field", func(t *testing.T) {
+		result, err := sortResult(resources, "location.zone")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c", "a", "b"}, names(result))
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := sortResult(resources, "color")
+		assert.EqualError(t, err, "cannot sort by color: unknown field")
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := sortResult(resources, "name:up")
+		assert.EqualError(t, err, `invalid sort order "up" for field name: must be asc or desc`)
+	})
+
+	t.Run("not a list", func(t *testing.T) {
+		_, err := sortResult(resources[0], "name")
+		assert.Error(t, err)
+	})
+}
@@ -224,7 +224,7 @@ func createTestClient(t *testing.T, testConfig *TestConfig, httpClient *http.Cli
 		scw.WithDefaultProjectID("11111111-1111-1111-1111-111111111111"),
 		scw.WithUserAgent("cli-e2e-test"),
 		scw.WithHTTPClient(&http.Client{
-			Transport: &retryableHTTPTransport{transport: &SocketPassthroughTransport{}},
+			Transport: newRetryableHTTPTransport(&SocketPassthroughTransport{}),
 		}),
 	}
 
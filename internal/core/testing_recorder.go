@@ -89,7 +89,7 @@ func getHTTPRecoder(t *testing.T, update bool) (client *http.Client, cleanup fun
 
 	r.SetMatcher(cassetteMatcher)
 
-	return &http.Client{Transport: &retryableHTTPTransport{transport: r}}, func() {
+	return &http.Client{Transport: newRetryableHTTPTransport(r)}, func() {
 		assert.NoError(t, r.Stop()) // Make sure recorder is stopped once done with it
 	}, nil
 }
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+)
+
+const uploadManifestDirName = "uploads"
+
+// UploadConfig returns the configuration of an upload run by a command:
+// interrupted uploads are resumed from manifests stored in the cache directory
+// and the progress of the upload is reported as the given step with --progress=json.
+func UploadConfig(ctx context.Context, step string) *upload.Config {
+	return &upload.Config{
+		ManifestDir: filepath.Join(ExtractCacheDir(ctx), uploadManifestDirName),
+		Progress: func(uploaded int64, total int64) {
+			percent := float64(100)
+			if total > 0 {
+				percent = float64(uploaded) * 100 / float64(total)
+			}
+			ReportProgress(ctx, &ProgressEvent{
+				Step:    step,
+				State:   ProgressStateRunning,
+				Percent: &percent,
+				Message: fmt.Sprintf("%s / %s", humanize.Bytes(uint64(uploaded)), humanize.Bytes(uint64(total))),
+			})
+		},
+	}
+}
@@ -18,6 +18,12 @@ type ViewField struct {
 
 	// FieldName is the key used to retrieve the value from a field (or nested field) of a structure.
 	FieldName string
+
+	// Priority of the field when the table does not fit in the terminal, fields with the lowest priority are hidden first.
+	Priority int
+
+	// MaxWidth is the width above which values are truncated when the table does not fit in the terminal.
+	MaxWidth int
 }
 
 type ViewSection struct {
@@ -35,6 +41,8 @@ func (v *View) getHumanMarshalerOpt() *human.MarshalOpt {
 		opt.Fields = append(opt.Fields, &human.MarshalFieldOpt{
 			FieldName: field.FieldName,
 			Label:     field.Label,
+			Priority:  field.Priority,
+			MaxWidth:  field.MaxWidth,
 		})
 	}
 	for _, section := range v.Sections {
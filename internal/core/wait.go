@@ -0,0 +1,252 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	waitTimeoutFlagUsage = "Maximum duration of --wait, overrides the default timeout of the command"
+
+	// WaitTimeoutExitCode is the exit code of commands whose wait timed out.
+	WaitTimeoutExitCode = ExitCodeTimeout
+
+	waitProgressInterval = 30 * time.Second
+)
+
+// WaitTimeout returns the timeout of a waiter: the --wait-timeout flag when it is set, defaultTimeout otherwise.
+func WaitTimeout(ctx context.Context, defaultTimeout time.Duration) *time.Duration {
+	meta, ok := ctx.Value(metaContextKey).(*meta)
+	if ok && meta.waitTimeout > 0 {
+		return scw.TimeDurationPtr(meta.waitTimeout)
+	}
+	return scw.TimeDurationPtr(defaultTimeout)
+}
+
+// waitStateTransport records the state of the resources fetched while a command waits,
+// so that the progress of the wait can be reported whatever the waiter of the resource.
+type waitStateTransport struct {
+	transport http.RoundTripper
+
+	mu      sync.Mutex
+	waiting bool
+	state   string
+}
+
+func (t *waitStateTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	waiting := t.waiting
+	t.mu.Unlock()
+
+	res, err := t.transport.RoundTrip(request)
+	if !waiting || err != nil || request.Method != http.MethodGet || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	_ = res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if state := resourceState(body); state != "" {
+		t.mu.Lock()
+		t.state = state
+		t.mu.Unlock()
+	}
+
+	return res, nil
+}
+
+// start starts recording the state of the fetched resources.
+func (t *waitStateTransport) start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.waiting = true
+	t.state = ""
+}
+
+func (t *waitStateTransport) stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.waiting = false
+}
+
+// lastState returns the state of the last resource fetched, empty when unknown.
+func (t *waitStateTransport) lastState() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.state
+}
+
+// resourceState returns the status or state field of a JSON resource, or of the resource nested in the response
+// such as {"server": {"state": "starting"}}.
+func resourceState(body []byte) string {
+	resource := map[string]interface{}(nil)
+	if err := json.Unmarshal(body, &resource); err != nil {
+		return ""
+	}
+
+	if state := stateField(resource); state != "" {
+		return state
+	}
+
+	keys := make([]string, 0, len(resource))
+	for key := range resource {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if nested, isObject := resource[key].(map[string]interface{}); isObject {
+			if state := stateField(nested); state != "" {
+				return state
+			}
+		}
+	}
+	return ""
+}
+
+func stateField(resource map[string]interface{}) string {
+	for _, field := range []string{"status", "state"} {
+		if state, isString := resource[field].(string); isString && state != "" {
+			return state
+		}
+	}
+	return ""
+}
+
+// runWaitFunc runs the waiter of a command within the --wait-timeout and reports its progress periodically.
+// Timeouts are returned as an error with the WaitTimeoutExitCode exit code.
+func runWaitFunc(ctx context.Context, cmd *Command, cmdArgs interface{}, data interface{}) (interface{}, error) {
+	meta := extractMeta(ctx)
+	waitCtx := ctx
+	if meta.waitTimeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, meta.waitTimeout)
+		defer cancel()
+	}
+
+	stopProgress := startWaitProgress(ctx, meta)
+	result, err := cmd.WaitFunc(waitCtx, cmdArgs, data)
+	stopProgress()
+
+	if isWaitTimeoutError(err) {
+		hint := "Increase the timeout of the wait with --wait-timeout"
+		if meta.waitTimeout > 0 {
+			hint = fmt.Sprintf("The %s is still being processed, increase --wait-timeout above %s to wait longer", cmd.Resource, meta.waitTimeout)
+		}
+		return nil, &CliError{
+			Err:      fmt.Errorf("timeout while waiting for the %s: %s", cmd.Resource, err),
+			Hint:     hint,
+			Code:     WaitTimeoutExitCode,
+			Resource: cmd.Resource,
+		}
+	}
+	return result, err
+}
+
+// isWaitTimeoutError returns whether a waiter failed because of its timeout or the deadline of its context.
+func isWaitTimeoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if cliErr, isCliErr := err.(*CliError); isCliErr && cliErr.Code != 0 {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "timeout after") || strings.Contains(message, context.DeadlineExceeded.Error())
+}
+
+// startWaitProgress reports the state of the waited resource and the elapsed time every waitProgressInterval,
+// as a progress event with --progress=json or as a line on stderr in interactive mode.
+// The returned function stops the reports.
+func startWaitProgress(ctx context.Context, meta *meta) func() {
+	if meta.waitState != nil {
+		meta.waitState.start()
+	}
+
+	start := time.Now()
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(waitProgressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				state := ""
+				if meta.waitState != nil {
+					state = meta.waitState.lastState()
+				}
+				reportWaitProgress(ctx, meta, formatWaitProgress(state, time.Since(start)), nil)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+		if meta.waitState != nil {
+			meta.waitState.stop()
+		}
+	}
+}
+
+// ReportWaitProgress reports the progress of a wait run by a command, with its percentage when it is known,
+// as a progress event with --progress=json or as a line on stderr in interactive mode.
+func ReportWaitProgress(ctx context.Context, message string, percent *float64) {
+	meta, ok := ctx.Value(metaContextKey).(*meta)
+	if !ok {
+		return
+	}
+	reportWaitProgress(ctx, meta, message, percent)
+}
+
+func reportWaitProgress(ctx context.Context, meta *meta, message string, percent *float64) {
+	if meta.progressFormat != ProgressFormatNone {
+		ReportProgress(ctx, &ProgressEvent{
+			Step:    "wait",
+			State:   ProgressStateRunning,
+			Percent: percent,
+			Message: message,
+		})
+		return
+	}
+	if interactive.IsInteractive {
+		_, _ = interactive.Println(message)
+	}
+}
+
+// formatWaitProgress formats the progress of a wait, such as "provisioning… 3m".
+func formatWaitProgress(state string, elapsed time.Duration) string {
+	if state == "" {
+		state = "waiting"
+	}
+	switch {
+	case elapsed < time.Minute:
+		return fmt.Sprintf("%s… %ds", state, int(elapsed.Seconds()))
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%s… %dm", state, int(elapsed.Minutes()))
+	default:
+		return fmt.Sprintf("%s… %dh%dm", state, int(elapsed.Hours()), int(elapsed.Minutes())%60)
+	}
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+const defaultWaitCommandInterval = 5 * time.Second
+
+// WaitCommandConfig describes the statuses of a resource waited by a command built with NewWaitCommand.
+type WaitCommandConfig struct {
+	// TerminalStatuses are the values of the status or state field of the resource where the wait stops
+	TerminalStatuses []string
+	// ErrorStatuses are the terminal statuses returned as an error
+	ErrorStatuses []string
+	// DefaultTimeout is the default value of the timeout argument
+	DefaultTimeout time.Duration
+}
+
+// NewWaitCommand returns a wait command for the resource of a get command: the resource is fetched with the get command,
+// with the same arguments, until its status or state field reaches a terminal status.
+// getCmd must return a resource with a Status or State string field.
+func NewWaitCommand(getCmd *Command, config *WaitCommandConfig) *Command {
+	if getCmd.Run == nil || getCmd.ArgsType == nil {
+		panic(fmt.Errorf("cannot build a wait command from %s, it must be runnable", getCmd.GetCommandLine("scw")))
+	}
+
+	getArgsType := getCmd.ArgsType
+	argsType := reflect.StructOf([]reflect.StructField{
+		{Name: "GetRequest", Type: getArgsType, Anonymous: true},
+		{Name: "Timeout", Type: reflect.TypeOf(time.Duration(0))},
+	})
+
+	argSpecs := make(ArgSpecs, 0, len(getCmd.ArgSpecs)+1)
+	argSpecs = append(argSpecs, getCmd.ArgSpecs...)
+	argSpecs = append(argSpecs, WaitTimeoutArgSpec(config.DefaultTimeout))
+
+	return &Command{
+		Short:     fmt.Sprintf("Wait for a %s to reach a stable state", getCmd.Resource),
+		Long:      fmt.Sprintf("Wait for a %s to reach a stable state. This is similar to using --wait flag on other action commands, but without requiring a new action on the %s.", getCmd.Resource, getCmd.Resource),
+		Namespace: getCmd.Namespace,
+		Resource:  getCmd.Resource,
+		Verb:      "wait",
+		Groups:    []string{"workflow"},
+		ArgsType:  argsType,
+		ArgSpecs:  argSpecs,
+		View:      getCmd.View,
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := reflect.ValueOf(argsI).Elem()
+			getArgs := args.Field(0).Addr().Interface()
+			timeout := time.Duration(args.Field(1).Int())
+			return waitForResource(ctx, getCmd, getArgs, config, timeout)
+		},
+	}
+}
+
+// waitForResource runs a get command until the status of its resource is terminal.
+func waitForResource(ctx context.Context, getCmd *Command, getArgs interface{}, config *WaitCommandConfig, timeout time.Duration) (interface{}, error) {
+	interval := defaultWaitCommandInterval
+	if DefaultRetryInterval != nil {
+		interval = *DefaultRetryInterval
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resource, err := getCmd.Run(ctx, getArgs)
+		if err != nil {
+			return nil, err
+		}
+
+		status := resourceStatus(resource)
+		if containsStatus(config.ErrorStatuses, status) {
+			return nil, &CliError{
+				Err:     fmt.Errorf("%s reached %s status", getCmd.Resource, status),
+				Details: fmt.Sprintf("Get the details of the %s with: %s", getCmd.Resource, getCmd.GetCommandLine(ExtractBinaryName(ctx))),
+			}
+		}
+		if containsStatus(config.TerminalStatuses, status) {
+			return resource, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, &CliError{
+				Err:      fmt.Errorf("timeout after %s waiting for the %s, its status is %s", timeout, getCmd.Resource, status),
+				Hint:     "Increase the timeout with timeout=<duration>",
+				Code:     WaitTimeoutExitCode,
+				Resource: getCmd.Resource,
+			}
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// resourceStatus returns the value of the Status or State field of a resource, empty when it has none.
+func resourceStatus(resource interface{}) string {
+	value := reflect.Indirect(reflect.ValueOf(resource))
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	for _, name := range []string{"Status", "State"} {
+		field := value.FieldByName(name)
+		if field.IsValid() && field.Kind() == reflect.String {
+			return field.String()
+		}
+	}
+	return ""
+}
+
+func containsStatus(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
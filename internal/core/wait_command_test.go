@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testWaitFlower struct {
+	Name   string
+	Status string
+}
+
+type testWaitFlowerGetRequest struct {
+	Name string
+}
+
+// testGetWaitCommands returns a get command whose flower goes through the given statuses, and its wait command.
+func testGetWaitCommands(statuses ...string) *Commands {
+	mu := sync.Mutex{}
+	calls := 0
+	getCmd := &Command{
+		Namespace:            "test",
+		Resource:             "flower",
+		Verb:                 "get",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(testWaitFlowerGetRequest{}),
+		ArgSpecs: ArgSpecs{
+			{Name: "name", Required: true, Positional: true},
+		},
+		Run: func(_ context.Context, argsI interface{}) (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			status := statuses[calls]
+			if calls < len(statuses)-1 {
+				calls++
+			}
+			return &testWaitFlower{Name: argsI.(*testWaitFlowerGetRequest).Name, Status: status}, nil
+		},
+	}
+
+	return NewCommands(getCmd, NewWaitCommand(getCmd, &WaitCommandConfig{
+		TerminalStatuses: []string{"ready", "error"},
+		ErrorStatuses:    []string{"error"},
+		DefaultTimeout:   time.Minute,
+	}))
+}
+
+func Test_NewWaitCommand(t *testing.T) {
+	t.Run("Ready", Test(&TestConfig{
+		Commands: testGetWaitCommands("pending", "growing", "ready"),
+		Cmd:      "scw test flower wait rose",
+		Check: TestCheckCombine(
+			TestCheckExitCode(0),
+			func(t *testing.T, ctx *CheckFuncCtx) {
+				assert.Equal(t, &testWaitFlower{Name: "rose", Status: "ready"}, ctx.Result)
+			},
+		),
+	}))
+
+	t.Run("Error", Test(&TestConfig{
+		Commands: testGetWaitCommands("growing", "error"),
+		Cmd:      "scw test flower wait rose",
+		Check: TestCheckCombine(
+			TestCheckExitCode(1),
+			func(t *testing.T, ctx *CheckFuncCtx) {
+				assert.EqualError(t, ctx.Err, "flower reached error status")
+			},
+		),
+	}))
+
+	t.Run("Timeout", Test(&TestConfig{
+		Commands: testGetWaitCommands("growing"),
+		Cmd:      "scw test flower wait rose timeout=0s",
+		Check:    TestCheckExitCode(WaitTimeoutExitCode),
+	}))
+}
+
+func Test_resourceStatus(t *testing.T) {
+	assert.Equal(t, "ready", resourceStatus(&testWaitFlower{Status: "ready"}))
+	assert.Equal(t, "running", resourceStatus(&struct{ State string }{State: "running"}))
+	assert.Equal(t, "", resourceStatus(&struct{ Status int }{Status: 1}))
+	assert.Equal(t, "", resourceStatus([]string{"ready"}))
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitTimeout(t *testing.T) {
+	ctx := injectMeta(context.Background(), &meta{})
+	assert.Equal(t, 10*time.Minute, *WaitTimeout(ctx, 10*time.Minute))
+
+	ctx = injectMeta(context.Background(), &meta{waitTimeout: time.Hour})
+	assert.Equal(t, time.Hour, *WaitTimeout(ctx, 10*time.Minute))
+
+	assert.Equal(t, 10*time.Minute, *WaitTimeout(context.Background(), 10*time.Minute))
+}
+
+func TestResourceState(t *testing.T) {
+	assert.Equal(t, "provisioning", resourceState([]byte(`{"id": "1", "status": "provisioning"}`)))
+	assert.Equal(t, "starting", resourceState([]byte(`{"server": {"id": "1", "state": "starting"}}`)))
+	assert.Equal(t, "", resourceState([]byte(`{"servers": [{"state": "starting"}]}`)))
+	assert.Equal(t, "", resourceState([]byte(`not json`)))
+}
+
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func TestWaitStateTransport(t *testing.T) {
+	transport := &waitStateTransport{
+		transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"status": "provisioning"}`)),
+			}, nil
+		}),
+	}
+	get := func() {
+		request, err := http.NewRequest(http.MethodGet, "https://api.scaleway.com/baremetal/v1/zones/fr-par-2/servers/1", nil)
+		require.NoError(t, err)
+		res, err := transport.RoundTrip(request)
+		require.NoError(t, err)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"status": "provisioning"}`, string(body))
+	}
+
+	get()
+	assert.Empty(t, transport.lastState(), "states must only be recorded while waiting")
+
+	transport.start()
+	get()
+	transport.stop()
+	assert.Equal(t, "provisioning", transport.lastState())
+}
+
+func TestFormatWaitProgress(t *testing.T) {
+	assert.Equal(t, "waiting… 30s", formatWaitProgress("", 30*time.Second))
+	assert.Equal(t, "provisioning… 3m", formatWaitProgress("provisioning", 3*time.Minute+20*time.Second))
+	assert.Equal(t, "provisioning… 1h5m", formatWaitProgress("provisioning", 65*time.Minute))
+}
+
+func TestRunWaitFunc(t *testing.T) {
+	cmd := &Command{
+		Resource: "server",
+		WaitFunc: func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+			<-ctx.Done()
+			return nil, fmt.Errorf("cannot get server: %w", ctx.Err())
+		},
+	}
+
+	ctx := injectMeta(context.Background(), &meta{waitTimeout: 10 * time.Millisecond})
+	_, err := runWaitFunc(ctx, cmd, nil, nil)
+	cliErr := (*CliError)(nil)
+	require.True(t, errors.As(err, &cliErr))
+	assert.Equal(t, WaitTimeoutExitCode, cliErr.Code)
+	assert.Equal(t, "timeout while waiting for the server: cannot get server: context deadline exceeded", cliErr.Err.Error())
+
+	cmd.WaitFunc = func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+		return nil, errors.New("server is in error state")
+	}
+	_, err = runWaitFunc(ctx, cmd, nil, nil)
+	assert.EqualError(t, err, "server is in error state")
+}
+
+func TestReportWaitProgress(t *testing.T) {
+	stderr := &bytes.Buffer{}
+	m := &meta{stderr: stderr, progressFormat: ProgressFormatJSON}
+	ctx := injectMeta(context.Background(), m)
+
+	percent := 42.0
+	ReportWaitProgress(ctx, "provisioning… 3m", &percent)
+
+	event := &ProgressEvent{}
+	require.NoError(t, json.Unmarshal(stderr.Bytes(), event))
+	assert.Equal(t, "wait", event.Step)
+	assert.Equal(t, ProgressStateRunning, event.State)
+	assert.Equal(t, "provisioning… 3m", event.Message)
+	assert.Equal(t, &percent, event.Percent)
+}
@@ -0,0 +1,216 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/strcase"
+)
+
+const wizardCommandName = "wizard"
+
+// runWizard prompts the arguments of the command given to scw wizard and returns the arguments to run the command with.
+// Arguments given on the command line are kept and not prompted. Nil is returned when the user does not want to run the command.
+func runWizard(ctx context.Context, commands *Commands, wizardArgs []string) ([]string, error) {
+	if !interactive.IsInteractive {
+		return nil, &CliError{
+			Err:  fmt.Errorf("the wizard requires an interactive terminal"),
+			Hint: "Give the arguments on the command line instead",
+		}
+	}
+
+	cmd, pathLength := findWizardCommand(commands, wizardArgs)
+	if cmd == nil {
+		return nil, &CliError{
+			Err:  fmt.Errorf("no command to build in %q", strings.Join(wizardArgs, " ")),
+			Hint: "Give the full path of a command, such as: scw wizard instance server create",
+		}
+	}
+
+	cmdArgs := append([]string(nil), wizardArgs...)
+	rawArgs := args.RawArgs(nil)
+	for _, arg := range wizardArgs[pathLength:] {
+		if !strings.HasPrefix(arg, "-") {
+			rawArgs = append(rawArgs, arg)
+		}
+	}
+
+	_, _ = interactive.Println(cmd.Short + "\n")
+	prompted, err := promptWizardArgs(ctx, cmd, rawArgs, true)
+	if err != nil {
+		return nil, err
+	}
+	cmdArgs = append(cmdArgs, prompted...)
+
+	setOptional, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Ctx:    ctx,
+		Prompt: "Set optional arguments?",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if setOptional {
+		prompted, err = promptWizardArgs(ctx, cmd, rawArgs, false)
+		if err != nil {
+			return nil, err
+		}
+		cmdArgs = append(cmdArgs, prompted...)
+	}
+
+	commandLine := FormatCommandLine(ctx, exec.Command(ExtractBinaryName(ctx), cmdArgs...))
+	_, _ = interactive.Println("\n" + commandLine + "\n")
+	run, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Ctx:          ctx,
+		Prompt:       "Run this command?",
+		DefaultValue: true,
+	})
+	if err != nil || !run {
+		return nil, err
+	}
+
+	return cmdArgs, nil
+}
+
+// findWizardCommand returns the runnable command of the longest path made of the first arguments, with the length of this path.
+func findWizardCommand(commands *Commands, wizardArgs []string) (*Command, int) {
+	path := []string(nil)
+	for _, arg := range wizardArgs {
+		if strings.HasPrefix(arg, "-") || strings.Contains(arg, "=") || len(path) == 3 {
+			break
+		}
+		path = append(path, arg)
+	}
+
+	for length := len(path); length > 0; length-- {
+		if cmd := commands.Find(path[:length]...); cmd != nil && cmd.Run != nil {
+			return cmd, length
+		}
+	}
+	return nil, 0
+}
+
+// promptWizardArgs prompts the required or the optional arguments of a command missing from rawArgs.
+// Arguments of lists are prompted until an empty value is given, maps cannot be built by the wizard.
+func promptWizardArgs(ctx context.Context, cmd *Command, rawArgs args.RawArgs, required bool) ([]string, error) {
+	prompted := []string(nil)
+	for _, argSpec := range cmd.ArgSpecs {
+		if argSpec.Required != required || argSpec.Deprecated || strings.Contains(argSpec.Name, mapSchema) {
+			continue
+		}
+
+		if strings.Count(argSpec.Name, sliceSchema) == 1 {
+			prefix := argSpec.Name[:strings.Index(argSpec.Name, sliceSchema)]
+			if len(rawArgs.GetSliceOrMapKeys(strings.TrimSuffix(prefix, "."))) > 0 {
+				continue
+			}
+			for index := 0; ; index++ {
+				name := strings.Replace(argSpec.Name, sliceSchema, strconv.Itoa(index), 1)
+				value, err := promptWizardArg(ctx, cmd, argSpec, name, false)
+				if err != nil {
+					return nil, err
+				}
+				if value == "" {
+					break
+				}
+				prompted = append(prompted, name+"="+value)
+			}
+			continue
+		}
+		if argSpec.IsPartOfMapOrSlice() || rawArgs.Has(argSpec.Name) || (argSpec.Positional && len(rawArgs.GetPositionalArgs()) > 0) {
+			continue
+		}
+
+		value, err := promptWizardArg(ctx, cmd, argSpec, argSpec.Name, argSpec.Required)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case value == "":
+		case argSpec.Positional:
+			prompted = append(prompted, value)
+		default:
+			prompted = append(prompted, argSpec.Name+"="+value)
+		}
+	}
+	return prompted, nil
+}
+
+// promptWizardArg prompts the value of an argument, validated as it would be on the command line.
+// Default values are not returned, the command sets them itself.
+func promptWizardArg(ctx context.Context, cmd *Command, argSpec *ArgSpec, name string, required bool) (string, error) {
+	prompt := name
+	if argSpec.Short != "" {
+		prompt += " - " + argSpec.Short
+	}
+	if len(argSpec.EnumValues) > 0 {
+		prompt += " (" + strings.Join(argSpec.EnumValues, " | ") + ")"
+	}
+	defaultValue, defaultDoc := "", ""
+	if argSpec.Default != nil {
+		defaultValue, defaultDoc = argSpec.Default(ctx)
+	}
+
+	value, err := interactive.PromptStringWithConfig(&interactive.PromptStringConfig{
+		Ctx:             ctx,
+		Prompt:          prompt,
+		DefaultValue:    defaultValue,
+		DefaultValueDoc: defaultDoc,
+		ValidateFunc: func(value string) error {
+			if value == "" {
+				if required && defaultValue == "" {
+					return fmt.Errorf("%s is required", name)
+				}
+				return nil
+			}
+			return validateWizardArg(cmd, argSpec, name, value)
+		},
+	})
+	if err != nil || value == defaultValue {
+		return "", err
+	}
+	return value, nil
+}
+
+// validateWizardArg parses a value in the arguments of a command and validates it with the validation function of its argument.
+func validateWizardArg(cmd *Command, argSpec *ArgSpec, name string, value string) error {
+	if cmd.ArgsType == nil {
+		return nil
+	}
+	cmdArgs := reflect.New(cmd.ArgsType).Interface()
+	err := args.UnmarshalStruct([]string{name + "=" + value}, cmdArgs)
+	if err != nil {
+		return err
+	}
+
+	validateFunc := DefaultArgSpecValidateFunc()
+	if argSpec.ValidateFunc != nil {
+		validateFunc = argSpec.ValidateFunc
+	}
+	fieldValues, err := getValuesForFieldByName(reflect.ValueOf(cmdArgs), strings.Split(strcase.ToPublicGoName(argSpec.Name), "."))
+	if err != nil {
+		return nil
+	}
+	for _, fieldValue := range fieldValues {
+		err := validateFunc(argSpec, fieldValue.Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isHelpArgs returns whether the arguments ask for the usage of the command.
+func isHelpArgs(args []string) bool {
+	for _, arg := range args {
+		if arg == "-h" || arg == "--help" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wizardTestArgs struct {
+	ServerID string
+	Type     string
+	Size     int
+	Tags     []string
+	Labels   map[string]string
+}
+
+func wizardTestCommands() *Commands {
+	return NewCommands(
+		&Command{
+			Namespace: "test",
+			Resource:  "server",
+		},
+		&Command{
+			Namespace: "test",
+			Resource:  "server",
+			Verb:      "create",
+			ArgsType:  reflect.TypeOf(wizardTestArgs{}),
+			ArgSpecs: ArgSpecs{
+				{Name: "server-id", Required: true, Positional: true},
+				{Name: "type", Required: true, EnumValues: []string{"small", "large"}},
+				{Name: "size", Default: DefaultValueSetter("10")},
+				{Name: "tags.{index}"},
+				{Name: "labels.{key}"},
+			},
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+				return nil, nil
+			},
+		},
+	)
+}
+
+func Test_findWizardCommand(t *testing.T) {
+	commands := wizardTestCommands()
+
+	cmd, length := findWizardCommand(commands, []string{"test", "server", "create", "type=small", "-p", "prod"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "create", cmd.Verb)
+	assert.Equal(t, 3, length)
+
+	cmd, _ = findWizardCommand(commands, []string{"test", "server"})
+	assert.Nil(t, cmd)
+}
+
+func Test_validateWizardArg(t *testing.T) {
+	cmd := wizardTestCommands().Find("test", "server", "create")
+
+	assert.NoError(t, validateWizardArg(cmd, cmd.ArgSpecs.GetByName("type"), "type", "large"))
+	assert.Error(t, validateWizardArg(cmd, cmd.ArgSpecs.GetByName("type"), "type", "medium"))
+	assert.NoError(t, validateWizardArg(cmd, cmd.ArgSpecs.GetByName("size"), "size", "20"))
+	assert.Error(t, validateWizardArg(cmd, cmd.ArgSpecs.GetByName("size"), "size", "big"))
+	assert.NoError(t, validateWizardArg(cmd, cmd.ArgSpecs.GetByName("tags.{index}"), "tags.0", "web"))
+}
+
+func Test_promptWizardArgs(t *testing.T) {
+	isInteractive := interactive.IsInteractive
+	interactive.IsInteractive = true
+	interactive.SetOutputWriter(&bytes.Buffer{})
+	defer func() {
+		interactive.IsInteractive = isInteractive
+	}()
+	cmd := wizardTestCommands().Find("test", "server", "create")
+
+	t.Run("required given on the command line", func(t *testing.T) {
+		ctx := interactive.InjectMockResponseToContext(context.Background(), []string{})
+		prompted, err := promptWizardArgs(ctx, cmd, args.RawArgs{"11111111-1111-1111-1111-111111111111", "type=small"}, true)
+		require.NoError(t, err)
+		assert.Empty(t, prompted)
+	})
+
+	t.Run("invalid value prompted again", func(t *testing.T) {
+		ctx := interactive.InjectMockResponseToContext(context.Background(), []string{"medium", "large"})
+		prompted, err := promptWizardArgs(ctx, cmd, args.RawArgs{"11111111-1111-1111-1111-111111111111"}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"type=large"}, prompted)
+	})
+}
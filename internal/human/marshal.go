@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,6 +23,13 @@ import (
 // Padding between column
 const colPadding = 2
 
+const ellipsis = "…"
+
+var (
+	uuidRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	ansiRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
 // Marshaler allow custom display for some type when printed using HumanPrinter
 type Marshaler interface {
 	MarshalHuman() (string, error)
@@ -92,7 +100,10 @@ func Marshal(data interface{}, opt *MarshalOpt) (string, error) {
 
 func marshalStruct(value reflect.Value, opt *MarshalOpt) (string, error) {
 	// subOpts that will be passed down to sub marshaler (field, sub struct, slice item ...)
-	subOpts := &MarshalOpt{}
+	subOpts := &MarshalOpt{
+		DisableShrinking: opt.DisableShrinking,
+		Vertical:         opt.Vertical,
+	}
 
 	// Marshal sections
 	sectionsStrs := []string(nil)
@@ -334,7 +345,11 @@ func marshalSlice(slice reflect.Value, opt *MarshalOpt) (string, error) {
 		}
 		grid = append(grid, row)
 	}
-	return formatGrid(grid, !opt.DisableShrinking)
+
+	if opt.Vertical {
+		return formatRecords(grid), nil
+	}
+	return formatGrid(grid, opt.Fields, !opt.DisableShrinking, opt.Width)
 }
 
 // marshalInlineSlice transforms nested scalar slices in an inline string representation
@@ -399,43 +414,136 @@ func marshalSection(section *MarshalSection, value reflect.Value, opt *MarshalOp
 	return Marshal(field, &subOpt)
 }
 
-func formatGrid(grid [][]string, shrinkColumns bool) (string, error) {
+func formatGrid(grid [][]string, fields []*MarshalFieldOpt, shrinkColumns bool, width int) (string, error) {
+	// If we are not writing to Stdout or through a tty Stdout, the table is not shrunk unless a width is given
+	if width <= 0 && terminal.IsTerm() {
+		width = terminal.GetWidth()
+	}
+	if shrinkColumns && width > 0 {
+		grid = fitGrid(grid, fields, width)
+	}
+
 	buffer := bytes.Buffer{}
-	maxCols := computeMaxCols(grid)
 	w := tabwriter.NewWriter(&buffer, 5, 1, colPadding, ' ', tabwriter.ANSIGraphicsRendition)
 	for _, line := range grid {
-		if shrinkColumns {
-			line = line[:maxCols]
-		}
 		fmt.Fprintln(w, strings.Join(line, "\t"))
 	}
 	w.Flush()
 	return strings.TrimSpace(buffer.String()), nil
 }
 
-// computeMaxCols calculates how many row we can fit in terminal width.
-func computeMaxCols(grid [][]string) int {
-	maxCols := len(grid[0])
-	width := terminal.GetWidth()
-	// If we are not writing to Stdout or through a tty Stdout, returns max length
-	if !terminal.IsTerm() || width == 0 {
-		return maxCols
-	}
-	colMaxSize := make([]int, len(grid[0]))
-	for i := 0; i < len(grid); i++ {
-		lineSize := 0
-		for j := 0; j < maxCols; j++ {
-			size := len(grid[i][j]) + colPadding
-			if size >= colMaxSize[j] {
-				colMaxSize[j] = size
+// formatRecords formats each row of a grid as a record of keys and values, the first row holding the keys.
+func formatRecords(grid [][]string) string {
+	records := []string(nil)
+	for _, row := range grid[1:] {
+		buffer := bytes.Buffer{}
+		w := tabwriter.NewWriter(&buffer, 5, 1, colPadding, ' ', tabwriter.ANSIGraphicsRendition)
+		for i, value := range row {
+			fmt.Fprintln(w, grid[0][i]+"\t"+value)
+		}
+		w.Flush()
+		records = append(records, strings.TrimSpace(buffer.String()))
+	}
+
+	return strings.Join(records, "\n\n")
+}
+
+// fitGrid shrinks a grid so that it fits in width.
+// Values are truncated first: UUIDs with an ellipsis in the middle and other values to the MaxWidth of their field.
+// Then columns are hidden by ascending priority, from the right for a same priority.
+func fitGrid(grid [][]string, fields []*MarshalFieldOpt, width int) [][]string {
+	if gridWidth(grid) <= width {
+		return grid
+	}
+
+	truncated := make([][]string, len(grid))
+	for i, row := range grid {
+		truncated[i] = make([]string, len(row))
+		for j, value := range row {
+			if i == 0 {
+				truncated[i][j] = value
+				continue
+			}
+			maxWidth := 0
+			if j < len(fields) {
+				maxWidth = fields[j].MaxWidth
+			}
+			truncated[i][j] = truncateValue(value, maxWidth)
+		}
+	}
+
+	columns := make([]int, len(grid[0]))
+	for i := range columns {
+		columns[i] = i
+	}
+	for len(columns) > 1 && gridWidth(selectColumns(truncated, columns)) > width {
+		hidden := len(columns) - 1
+		for i := len(columns) - 1; i >= 0; i-- {
+			if columnPriority(fields, columns[i]) < columnPriority(fields, columns[hidden]) {
+				hidden = i
+			}
+		}
+		columns = append(columns[:hidden], columns[hidden+1:]...)
+	}
+
+	return selectColumns(truncated, columns)
+}
+
+func columnPriority(fields []*MarshalFieldOpt, column int) int {
+	if column < len(fields) {
+		return fields[column].Priority
+	}
+	return 0
+}
+
+func selectColumns(grid [][]string, columns []int) [][]string {
+	selected := make([][]string, len(grid))
+	for i, row := range grid {
+		for _, column := range columns {
+			if column < len(row) {
+				selected[i] = append(selected[i], row[column])
 			}
-			lineSize += colMaxSize[j]
-			if lineSize > width {
-				maxCols = j
+		}
+	}
+	return selected
+}
+
+// gridWidth returns the width of a grid once formatted in columns.
+func gridWidth(grid [][]string) int {
+	columnWidths := []int(nil)
+	for _, row := range grid {
+		for j, value := range row {
+			if j >= len(columnWidths) {
+				columnWidths = append(columnWidths, 0)
+			}
+			if valueWidth := displayWidth(value); valueWidth > columnWidths[j] {
+				columnWidths[j] = valueWidth
 			}
 		}
 	}
-	return maxCols
+
+	width := 0
+	for _, columnWidth := range columnWidths {
+		width += columnWidth + colPadding
+	}
+	return width - colPadding
+}
+
+// truncateValue shortens UUIDs to their first and last characters and values longer than maxWidth.
+// Styled values are not truncated to keep their escape sequences.
+func truncateValue(value string, maxWidth int) string {
+	if strings.Contains(value, "\x1b") {
+		return value
+	}
+	if uuidRegex.MatchString(value) {
+		return value[:8] + ellipsis + value[len(value)-4:]
+	}
+
+	runes := []rune(value)
+	if maxWidth > 0 && len(runes) > maxWidth {
+		return string(runes[:maxWidth-1]) + ellipsis
+	}
+	return value
 }
 
 // Generate default []*MarshalFieldOpt using reflect
@@ -305,6 +305,80 @@ func TestMarshal(t *testing.T) {
 			MapPtr.String  MyString
 `,
 	}))
+
+	t.Run("vertical", run(&testCase{
+		data: []*Acquaintance{
+			{Name: "Paul", Link: "Friend"},
+			{Name: "Pierre", Link: "Brother"},
+		},
+		opt: &MarshalOpt{Vertical: true},
+		result: `
+			NAME  Paul
+			LINK  Friend
+
+			NAME  Pierre
+			LINK  Brother
+`,
+	}))
+}
+
+func Test_fitGrid(t *testing.T) {
+	grid := [][]string{
+		{"ID", "NAME", "DESCRIPTION"},
+		{"088b01da-9ba7-40d2-bc55-eb3170f42185", "scw-cool-franklin", "A server with a long description"},
+	}
+
+	tests := []struct {
+		name   string
+		fields []*MarshalFieldOpt
+		width  int
+		want   [][]string
+	}{
+		{
+			name:  "fits",
+			width: 100,
+			want:  grid,
+		},
+		{
+			name: "truncate",
+			fields: []*MarshalFieldOpt{
+				{FieldName: "ID"},
+				{FieldName: "Name"},
+				{FieldName: "Description", MaxWidth: 12},
+			},
+			width: 50,
+			want: [][]string{
+				{"ID", "NAME", "DESCRIPTION"},
+				{"088b01da…2185", "scw-cool-franklin", "A server wi…"},
+			},
+		},
+		{
+			name:  "hide the rightmost column",
+			width: 40,
+			want: [][]string{
+				{"ID", "NAME"},
+				{"088b01da…2185", "scw-cool-franklin"},
+			},
+		},
+		{
+			name: "hide by priority",
+			fields: []*MarshalFieldOpt{
+				{FieldName: "ID"},
+				{FieldName: "Name", Priority: -1},
+				{FieldName: "Description", Priority: 1},
+			},
+			width: 50,
+			want: [][]string{
+				{"ID", "DESCRIPTION"},
+				{"088b01da…2185", "A server with a long description"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fitGrid(grid, tt.fields, tt.width))
+		})
+	}
 }
 
 func Test_getStructFieldsIndex(t *testing.T) {
@@ -0,0 +1,49 @@
+package human
+
+import (
+	"strings"
+)
+
+var sparklineBars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of bars of at most width characters, from the lowest to the highest value.
+// Consecutive values are averaged when there are more values than characters.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+
+	if len(values) > width {
+		buckets := make([]float64, width)
+		for i := range buckets {
+			from := i * len(values) / width
+			to := (i + 1) * len(values) / width
+			sum := 0.0
+			for _, value := range values[from:to] {
+				sum += value
+			}
+			buckets[i] = sum / float64(to-from)
+		}
+		values = buckets
+	}
+
+	lowest, highest := values[0], values[0]
+	for _, value := range values {
+		if value < lowest {
+			lowest = value
+		}
+		if value > highest {
+			highest = value
+		}
+	}
+
+	line := strings.Builder{}
+	for _, value := range values {
+		bar := 0
+		if highest > lowest {
+			bar = int((value - lowest) / (highest - lowest) * float64(len(sparklineBars)-1))
+		}
+		line.WriteRune(sparklineBars[bar])
+	}
+	return line.String()
+}
@@ -0,0 +1,15 @@
+package human
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "", Sparkline(nil, 10))
+	assert.Equal(t, "▁▂▃▄▅▆▇█", Sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 10))
+	assert.Equal(t, "▁▁▁", Sparkline([]float64{42, 42, 42}, 10))
+	// Values are averaged by pairs: 0, 2 and 14.
+	assert.Equal(t, "▁▂█", Sparkline([]float64{0, 0, 1, 3, 14, 14}, 3))
+}
@@ -17,11 +17,24 @@ type MarshalOpt struct {
 
 	// DisableShrinking will disable columns shrinking based on terminal size
 	DisableShrinking bool
+
+	// Width is the width tables are shrunk to, the width of the terminal when 0
+	Width int
+
+	// Vertical displays lists as records of keys and values instead of tables
+	Vertical bool
 }
 
 type MarshalFieldOpt struct {
 	FieldName string
 	Label     string
+
+	// Priority of the column when the table does not fit in the terminal:
+	// columns with the lowest priority are hidden first, from the right for a same priority.
+	Priority int
+
+	// MaxWidth truncates the values of the column when the table does not fit in the terminal, 0 means no limit.
+	MaxWidth int
 }
 
 // MarshalSection describes a section to build from a given struct.
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 )
 
 // Capitalize returns the given string with a first character in uppercase.
@@ -34,3 +35,8 @@ func isInterfaceNil(data interface{}) bool {
 		return false
 	}
 }
+
+// displayWidth returns the number of characters displayed for a string, escape sequences excluded.
+func displayWidth(s string) int {
+	return utf8.RuneCountInString(ansiRegex.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,33 @@
+package interactive
+
+import (
+	"strings"
+)
+
+// fuzzyMatch returns whether each word of the filter appears in the choice as a subsequence, ignoring the case.
+// For instance "gp s" matches "GP1-S" and "ubu jam" matches "ubuntu_jammy".
+func fuzzyMatch(filter string, choice string) bool {
+	choice = strings.ToLower(choice)
+	for _, word := range strings.Fields(strings.ToLower(filter)) {
+		remaining := choice
+		for _, r := range word {
+			i := strings.IndexRune(remaining, r)
+			if i < 0 {
+				return false
+			}
+			remaining = remaining[i+len(string(r)):]
+		}
+	}
+	return true
+}
+
+// filterChoices returns the indexes of the choices matching the filter.
+func filterChoices(choices []string, filter string) []int {
+	matches := make([]int, 0, len(choices))
+	for i, choice := range choices {
+		if fuzzyMatch(filter, choice) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
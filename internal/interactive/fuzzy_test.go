@@ -0,0 +1,23 @@
+package interactive
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	assert.True(t, fuzzyMatch("", "GP1-S"))
+	assert.True(t, fuzzyMatch("gp s", "GP1-S"))
+	assert.True(t, fuzzyMatch("ubu jam", "ubuntu_jammy   Ubuntu 22.04 Jammy Jellyfish"))
+	assert.True(t, fuzzyMatch("dev1s", "DEV1-S"))
+	assert.False(t, fuzzyMatch("gp2", "GP1-S"))
+	assert.False(t, fuzzyMatch("sdev", "DEV1-S"))
+}
+
+func TestFilterChoices(t *testing.T) {
+	choices := []string{"DEV1-S", "DEV1-M", "GP1-S", "PRO2-S"}
+	assert.Equal(t, []int{0, 1, 2, 3}, filterChoices(choices, ""))
+	assert.Equal(t, []int{0, 2, 3}, filterChoices(choices, "-s"))
+	assert.Equal(t, []int{}, filterChoices(choices, "xl"))
+}
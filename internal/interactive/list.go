@@ -16,16 +16,28 @@ type ListPrompt struct {
 	Choices []string
 	// DefaultIndex is the element that will be selected when starting prompt
 	DefaultIndex int
+	// Searchable filters the choices with the text typed by the user
+	Searchable bool
 
 	cursor    int
 	cancelled bool
+	// filter and matches are the text typed by the user and the indexes of the choices matching it, used when Searchable is set
+	filter  string
+	matches []int
 }
 
+// searchableListHeight is the maximum number of choices shown at once by a searchable prompt.
+const searchableListHeight = 15
+
 func (m *ListPrompt) Init() tea.Cmd {
 	return nil
 }
 
 func (m *ListPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.Searchable {
+		return m.updateSearch(msg)
+	}
+
 	switch msg := msg.(type) {
 	// Key is pressed
 	case tea.KeyMsg:
@@ -49,7 +61,52 @@ func (m *ListPrompt) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateSearch handles the keys of a searchable prompt, where letters are typed in the filter instead of moving the cursor.
+func (m *ListPrompt) updateSearch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, isKey := msg.(tea.KeyMsg)
+	if !isKey {
+		return m, nil
+	}
+
+	switch keyMsg.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case tea.KeyDown:
+		if m.cursor < len(m.matches)-1 {
+			m.cursor++
+		}
+	case tea.KeyEnter:
+		if len(m.matches) > 0 {
+			return m, tea.Quit
+		}
+	case tea.KeyBackspace:
+		if m.filter != "" {
+			filter := []rune(m.filter)
+			m.setFilter(string(filter[:len(filter)-1]))
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.setFilter(m.filter + string(keyMsg.Runes))
+	}
+
+	return m, nil
+}
+
+func (m *ListPrompt) setFilter(filter string) {
+	m.filter = filter
+	m.matches = filterChoices(m.Choices, filter)
+	m.cursor = 0
+}
+
 func (m *ListPrompt) View() string {
+	if m.Searchable {
+		return m.viewSearch()
+	}
+
 	s := m.Prompt + "\n\n"
 
 	for i, choice := range m.Choices {
@@ -65,9 +122,42 @@ func (m *ListPrompt) View() string {
 	return s
 }
 
+// viewSearch shows the filter and a window of the matching choices around the cursor.
+func (m *ListPrompt) viewSearch() string {
+	s := m.Prompt + "\n\n"
+	s += fmt.Sprintf("Search: %s\n\n", m.filter)
+
+	start := 0
+	if m.cursor >= searchableListHeight {
+		start = m.cursor - searchableListHeight + 1
+	}
+	end := start + searchableListHeight
+	if end > len(m.matches) {
+		end = len(m.matches)
+	}
+	for i := start; i < end; i++ {
+		if m.cursor == i {
+			s += fmt.Sprintf("> %s\n", m.Choices[m.matches[i]])
+		} else {
+			s += fmt.Sprintf("  %s\n", m.Choices[m.matches[i]])
+		}
+	}
+	if len(m.matches) == 0 {
+		s += "No match.\n"
+	}
+
+	s += fmt.Sprintf("\n%d/%d choices. Type to search, use up and down to move and press enter to select.\n", len(m.matches), len(m.Choices))
+
+	return s
+}
+
 // Execute start the prompt and return the selected index
 func (m *ListPrompt) Execute(ctx context.Context) (int, error) {
 	m.cursor = m.DefaultIndex
+	if m.Searchable {
+		m.filter = ""
+		m.matches = filterChoices(m.Choices, "")
+	}
 
 	opts := []tea.ProgramOption{
 		tea.WithContext(ctx),
@@ -90,5 +180,8 @@ func (m *ListPrompt) Execute(ctx context.Context) (int, error) {
 		return -1, fmt.Errorf("prompt cancelled")
 	}
 
+	if m.Searchable {
+		return m.matches[m.cursor], nil
+	}
 	return m.cursor, nil
 }
@@ -0,0 +1,100 @@
+// Package keyring stores the secret keys of the config in the keyring of the OS:
+// the Keychain on macOS, the Credential Manager on Windows and the Secret Service (libsecret) on Linux.
+//
+// A profile whose secret key is stored in the keyring holds SecretKeyRef instead of the secret key,
+// the secret key is stored under the access key of the profile.
+package keyring
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// SecretKeyRef is the value of the secret_key of a profile whose secret key is stored in the keyring.
+	SecretKeyRef = "keyring"
+
+	service = "scaleway-cli"
+)
+
+// ErrNotFound is returned when no secret key is stored in the keyring for an access key.
+var ErrNotFound = errors.New("secret key not found in the keyring")
+
+type provider interface {
+	Set(service string, user string, secret string) error
+	Get(service string, user string) (string, error)
+	Delete(service string, user string) error
+}
+
+var keyringProvider provider = osProvider{}
+
+// Set stores the secret key of an access key in the keyring.
+func Set(accessKey string, secretKey string) error {
+	return keyringProvider.Set(service, accessKey, secretKey)
+}
+
+// Get returns the secret key of an access key stored in the keyring.
+func Get(accessKey string) (string, error) {
+	return keyringProvider.Get(service, accessKey)
+}
+
+// Delete removes the secret key of an access key from the keyring.
+func Delete(accessKey string) error {
+	return keyringProvider.Delete(service, accessKey)
+}
+
+// IsSecretKeyRef returns whether a secret key is stored in the keyring.
+func IsSecretKeyRef(secretKey *string) bool {
+	return secretKey != nil && *secretKey == SecretKeyRef
+}
+
+// ResolveSecretKey replaces the secret key of a profile stored in the keyring by its value.
+// Profiles whose secret key is not stored in the keyring are left untouched.
+func ResolveSecretKey(profile *scw.Profile) error {
+	if !IsSecretKeyRef(profile.SecretKey) {
+		return nil
+	}
+	if profile.AccessKey == nil || *profile.AccessKey == "" {
+		return fmt.Errorf("the secret key is stored in the keyring but the profile has no access key")
+	}
+
+	secretKey, err := Get(*profile.AccessKey)
+	if err != nil {
+		return fmt.Errorf("cannot read the secret key of %s from the keyring: %w", *profile.AccessKey, err)
+	}
+	profile.SecretKey = &secretKey
+
+	return nil
+}
+
+// MockInit replaces the keyring of the OS by an in-memory keyring, to be used in tests.
+func MockInit() {
+	keyringProvider = &mockProvider{secrets: map[string]string{}}
+}
+
+type mockProvider struct {
+	secrets map[string]string
+}
+
+func (m *mockProvider) Set(service string, user string, secret string) error {
+	m.secrets[service+"/"+user] = secret
+	return nil
+}
+
+func (m *mockProvider) Get(service string, user string) (string, error) {
+	secret, exists := m.secrets[service+"/"+user]
+	if !exists {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (m *mockProvider) Delete(service string, user string) error {
+	if _, exists := m.secrets[service+"/"+user]; !exists {
+		return ErrNotFound
+	}
+	delete(m.secrets, service+"/"+user)
+	return nil
+}
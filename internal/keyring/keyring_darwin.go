@@ -0,0 +1,53 @@
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// securityItemNotFound is the exit code of the security tool when no item matches.
+const securityItemNotFound = 44
+
+// osProvider stores secrets in the login Keychain with the security tool.
+type osProvider struct{}
+
+func (osProvider) Set(service string, user string, secret string) error {
+	// The command is given on stdin so that the secret does not appear in the process list
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n", strconv.Quote(service), strconv.Quote(user), strconv.Quote(secret)))
+	return runSecurity(cmd)
+}
+
+func (osProvider) Get(service string, user string) (string, error) {
+	cmd := exec.Command("security", "find-generic-password", "-s", service, "-a", user, "-w")
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	if err := runSecurity(cmd); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func (osProvider) Delete(service string, user string) error {
+	return runSecurity(exec.Command("security", "delete-generic-password", "-s", service, "-a", user))
+}
+
+func runSecurity(cmd *exec.Cmd) error {
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	exitErr := &exec.ExitError{}
+	switch {
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound:
+		return ErrNotFound
+	case err != nil:
+		return fmt.Errorf("security: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
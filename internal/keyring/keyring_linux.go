@@ -0,0 +1,61 @@
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// osProvider stores secrets in the Secret Service (GNOME Keyring, KWallet) with the secret-tool of libsecret.
+type osProvider struct{}
+
+func (osProvider) Set(service string, user string, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label=Scaleway CLI "+user, "service", service, "username", user)
+	// The secret is given on stdin so that it does not appear in the process list
+	cmd.Stdin = strings.NewReader(secret)
+	_, err := runSecretTool(cmd)
+	return err
+}
+
+func (osProvider) Get(service string, user string) (string, error) {
+	secret, err := runSecretTool(exec.Command("secret-tool", "lookup", "service", service, "username", user))
+	if err != nil {
+		return "", err
+	}
+	if secret == "" {
+		return "", ErrNotFound
+	}
+
+	return secret, nil
+}
+
+func (p osProvider) Delete(service string, user string) error {
+	// secret-tool clear succeeds when nothing matches
+	if _, err := p.Get(service, user); err != nil {
+		return err
+	}
+	_, err := runSecretTool(exec.Command("secret-tool", "clear", "service", service, "username", user))
+	return err
+}
+
+func runSecretTool(cmd *exec.Cmd) (string, error) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	err := cmd.Run()
+	exitErr := &exec.ExitError{}
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return "", fmt.Errorf("secret-tool not found, install libsecret-tools (or libsecret) to use the keyring")
+	case errors.As(err, &exitErr) && stderr.Len() == 0:
+		// secret-tool lookup exits with an error and no message when nothing matches
+		return "", ErrNotFound
+	case err != nil:
+		return "", fmt.Errorf("secret-tool: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
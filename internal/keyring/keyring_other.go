@@ -0,0 +1,27 @@
+//go:build !darwin && !linux && !windows
+
+package keyring
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// osProvider is used on systems without a supported keyring.
+type osProvider struct{}
+
+func (osProvider) Set(string, string, string) error {
+	return errUnsupported()
+}
+
+func (osProvider) Get(string, string) (string, error) {
+	return "", errUnsupported()
+}
+
+func (osProvider) Delete(string, string) error {
+	return errUnsupported()
+}
+
+func errUnsupported() error {
+	return fmt.Errorf("the keyring is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,47 @@
+package keyring
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecretKey(t *testing.T) {
+	MockInit()
+	require.NoError(t, Set("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"))
+
+	t.Run("stored in the keyring", func(t *testing.T) {
+		profile := &scw.Profile{
+			AccessKey: scw.StringPtr("SCWXXXXXXXXXXXXXXXXX"),
+			SecretKey: scw.StringPtr(SecretKeyRef),
+		}
+		require.NoError(t, ResolveSecretKey(profile))
+		assert.Equal(t, "11111111-1111-1111-1111-111111111111", *profile.SecretKey)
+	})
+
+	t.Run("stored in the config", func(t *testing.T) {
+		profile := &scw.Profile{
+			AccessKey: scw.StringPtr("SCWXXXXXXXXXXXXXXXXX"),
+			SecretKey: scw.StringPtr("22222222-2222-2222-2222-222222222222"),
+		}
+		require.NoError(t, ResolveSecretKey(profile))
+		assert.Equal(t, "22222222-2222-2222-2222-222222222222", *profile.SecretKey)
+	})
+
+	t.Run("missing in the keyring", func(t *testing.T) {
+		profile := &scw.Profile{
+			AccessKey: scw.StringPtr("SCWYYYYYYYYYYYYYYYYY"),
+			SecretKey: scw.StringPtr(SecretKeyRef),
+		}
+		assert.ErrorIs(t, ResolveSecretKey(profile), ErrNotFound)
+	})
+
+	t.Run("without access key", func(t *testing.T) {
+		profile := &scw.Profile{
+			SecretKey: scw.StringPtr(SecretKeyRef),
+		}
+		assert.Error(t, ResolveSecretKey(profile))
+	})
+}
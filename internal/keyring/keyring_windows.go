@@ -0,0 +1,107 @@
+package keyring
+
+import (
+	"errors"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+var (
+	advapi32       = syscall.NewLazyDLL("advapi32.dll")
+	procCredWrite  = advapi32.NewProc("CredWriteW")
+	procCredRead   = advapi32.NewProc("CredReadW")
+	procCredDelete = advapi32.NewProc("CredDeleteW")
+	procCredFree   = advapi32.NewProc("CredFree")
+)
+
+// credential is the CREDENTIALW structure of wincred.h.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// osProvider stores secrets as generic credentials of the Windows Credential Manager.
+type osProvider struct{}
+
+func (osProvider) Set(service string, user string, secret string) error {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + user)
+	if err != nil {
+		return err
+	}
+	userName, err := syscall.UTF16PtrFromString(user)
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := &credential{
+		Type:               credTypeGeneric,
+		TargetName:         targetName,
+		UserName:           userName,
+		Persist:            credPersistLocalMachine,
+		CredentialBlobSize: uint32(len(blob)),
+	}
+	if len(blob) > 0 {
+		cred.CredentialBlob = &blob[0]
+	}
+
+	ret, _, err := procCredWrite.Call(uintptr(unsafe.Pointer(cred)), 0)
+	if ret == 0 {
+		return credError(err)
+	}
+
+	return nil
+}
+
+func (osProvider) Get(service string, user string) (string, error) {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + user)
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	ret, _, err := procCredRead.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		return "", credError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) //nolint:errcheck
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (osProvider) Delete(service string, user string) error {
+	targetName, err := syscall.UTF16PtrFromString(service + ":" + user)
+	if err != nil {
+		return err
+	}
+
+	ret, _, err := procCredDelete.Call(uintptr(unsafe.Pointer(targetName)), credTypeGeneric, 0)
+	if ret == 0 {
+		return credError(err)
+	}
+
+	return nil
+}
+
+func credError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
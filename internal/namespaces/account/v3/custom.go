@@ -2,10 +2,34 @@ package account
 
 import (
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
 )
 
 func GetCommands() *core.Commands {
 	commands := GetGeneratedCommands()
 
+	human.RegisterMarshalerFunc(quotaStatus(""), human.EnumMarshalFunc(quotaStatusMarshalSpecs))
+
+	commands.MustFind("account", "project", "delete").Override(projectDeleteBuilder)
+
+	commands.Merge(core.NewCommands(
+		securityReportCommand(),
+		organizationCommand(),
+		organizationListCommand(),
+		organizationSwitchCommand(),
+		projectSwitchCommand(),
+		quotaCommand(),
+		quotaListCommand(),
+	))
+
+	commands.Merge(core.NewCommands(
+		projectShortcutRoot(),
+		shortcutCommand(commands.MustFind("account", "project", "switch"), "project"),
+		shortcutCommand(commands.MustFind("account", "project", "create"), "project"),
+		shortcutCommand(commands.MustFind("account", "project", "delete"), "project"),
+		organizationShortcutRoot(),
+		shortcutCommand(commands.MustFind("account", "organization", "switch"), "org"),
+	))
+
 	return commands
 }
@@ -0,0 +1,401 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	organizationCacheFileName = "organizations.json"
+	defaultProjectName        = "default"
+
+	organizationAccessGranted = "granted"
+	organizationAccessDenied  = "denied"
+	organizationAccessUnknown = "unknown"
+)
+
+// organizationCacheEntry is the last known access of the API key to an organization.
+type organizationCacheEntry struct {
+	ID        string    `json:"id"`
+	Access    string    `json:"access"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+type organizationSummary struct {
+	ID       string   `json:"id"`
+	Current  bool     `json:"current"`
+	Profiles []string `json:"profiles"`
+	// Access tells whether the API key of the current profile can access the organization
+	Access    string     `json:"access"`
+	CheckedAt *time.Time `json:"checked_at"`
+}
+
+func organizationCommand() *core.Command {
+	return &core.Command{
+		Short:     `Organization management commands`,
+		Long:      `Organizations group the projects, members and API keys of a company. A user can belong to several organizations.`,
+		Namespace: "account",
+		Resource:  "organization",
+	}
+}
+
+func organizationListCommand() *core.Command {
+	type organizationListArgs struct {
+		Refresh bool
+	}
+
+	return &core.Command{
+		Short: `List the organizations you can switch to`,
+		Long: `List the organizations known by the CLI: the default organization of each profile of the config file and the organizations previously used with scw account organization switch.
+Whether the API key of the current profile can access each organization is read from a cache, use refresh=true to check it again.`,
+		Namespace: "account",
+		Resource:  "organization",
+		Verb:      "list",
+		ArgsType:  reflect.TypeOf(organizationListArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "refresh",
+				Short: `Check the access of the API key to each organization instead of using the cache`,
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*organizationListArgs)
+			client := core.ExtractClient(ctx)
+
+			config, err := loadOrganizationConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			cachePath := organizationCachePath(ctx)
+			cache := readOrganizationCache(cachePath)
+			currentOrganizationID, _ := client.GetDefaultOrganizationID()
+
+			summaries := listOrganizations(config, cache, currentOrganizationID)
+			if !args.Refresh {
+				return summaries, nil
+			}
+
+			api := account.NewProjectAPI(client)
+			for _, summary := range summaries {
+				_, err := api.ListProjects(&account.ProjectAPIListProjectsRequest{
+					OrganizationID: summary.ID,
+					PageSize:       scw.Uint32Ptr(1),
+				}, scw.WithContext(ctx))
+				switch {
+				case err == nil:
+					cache = setOrganizationAccess(cache, summary.ID, organizationAccessGranted)
+				case isOrganizationAccessDenied(err):
+					cache = setOrganizationAccess(cache, summary.ID, organizationAccessDenied)
+				default:
+					return nil, err
+				}
+			}
+			if err := writeOrganizationCache(cachePath, cache); err != nil {
+				return nil, err
+			}
+
+			return listOrganizations(config, cache, currentOrganizationID), nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the organizations",
+				Raw:   "scw account organization list",
+			},
+			{
+				Short: "Check again which organizations the API key can access",
+				Raw:   "scw account organization list refresh=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Switch to another organization",
+				Command: "scw account organization switch",
+			},
+		},
+	}
+}
+
+func organizationSwitchCommand() *core.Command {
+	type organizationSwitchArgs struct {
+		OrganizationID string
+		ProjectID      *string
+	}
+
+	return &core.Command{
+		Short: `Switch the current profile to another organization`,
+		Long: `Set the default organization and the default project of the current profile together.
+The API key of the profile is checked against the organization before the config file is updated.
+Without project-id, the project named default of the organization is used, or its oldest project when there is none.
+In interactive mode, the organization is picked from the organizations known by the CLI when it is not given.`,
+		Namespace: "account",
+		Resource:  "organization",
+		Verb:      "switch",
+		ArgsType:  reflect.TypeOf(organizationSwitchArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "organization-id",
+				Short:      `ID of the organization to switch to`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "project-id",
+				Short: `ID of the project to use as default project, it must belong to the organization`,
+			},
+		},
+		PickArgs: organizationSwitchPickArgs,
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*organizationSwitchArgs)
+			profileName := core.ExtractProfileName(ctx)
+
+			config, err := loadOrganizationConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			cachePath := organizationCachePath(ctx)
+			cache := readOrganizationCache(cachePath)
+
+			projects, err := account.NewProjectAPI(core.ExtractClient(ctx)).ListProjects(&account.ProjectAPIListProjectsRequest{
+				OrganizationID: args.OrganizationID,
+				OrderBy:        account.ListProjectsRequestOrderByCreatedAtAsc,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				if !isOrganizationAccessDenied(err) {
+					return nil, err
+				}
+				_ = writeOrganizationCache(cachePath, setOrganizationAccess(cache, args.OrganizationID, organizationAccessDenied))
+				return nil, organizationAccessDeniedError(ctx, config, profileName, args.OrganizationID)
+			}
+
+			project, err := selectOrganizationProject(projects.Projects, args.ProjectID)
+			if err != nil {
+				return nil, err
+			}
+
+			profile := getConfigProfile(config, profileName)
+			profile.DefaultOrganizationID = scw.StringPtr(args.OrganizationID)
+			profile.DefaultProjectID = scw.StringPtr(project.ID)
+
+			err = config.SaveTo(core.ExtractConfigPath(ctx))
+			if err != nil {
+				return nil, err
+			}
+			if err := writeOrganizationCache(cachePath, setOrganizationAccess(cache, args.OrganizationID, organizationAccessGranted)); err != nil {
+				return nil, err
+			}
+
+			return &core.SuccessResult{
+				Message: fmt.Sprintf("profile %s switched to organization %s with project %s (%s)", profileName, args.OrganizationID, project.Name, project.ID),
+			}, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Switch to an organization and its default project",
+				Raw:   "scw account organization switch 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Switch the profile 'prod' to an organization and one of its projects",
+				Raw:   "scw -p prod account organization switch 11111111-1111-1111-1111-111111111111 project-id=22222222-2222-2222-2222-222222222222",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the organizations",
+				Command: "scw account organization list",
+			},
+		},
+	}
+}
+
+// listOrganizations merges the default organizations of the profiles with the cached organizations, sorted by ID.
+func listOrganizations(config *scw.Config, cache []*organizationCacheEntry, currentOrganizationID string) []*organizationSummary {
+	summaries := map[string]*organizationSummary{}
+	summaryOf := func(organizationID string) *organizationSummary {
+		if _, exists := summaries[organizationID]; !exists {
+			summaries[organizationID] = &organizationSummary{
+				ID:       organizationID,
+				Access:   organizationAccessUnknown,
+				Profiles: []string{},
+			}
+		}
+		return summaries[organizationID]
+	}
+
+	addProfile := func(profileName string, profile *scw.Profile) {
+		if profile == nil || profile.DefaultOrganizationID == nil || *profile.DefaultOrganizationID == "" {
+			return
+		}
+		summary := summaryOf(*profile.DefaultOrganizationID)
+		summary.Profiles = append(summary.Profiles, profileName)
+	}
+	addProfile(scw.DefaultProfileName, &config.Profile)
+	for profileName, profile := range config.Profiles {
+		addProfile(profileName, profile)
+	}
+
+	for _, entry := range cache {
+		summary := summaryOf(entry.ID)
+		summary.Access = entry.Access
+		checkedAt := entry.CheckedAt
+		summary.CheckedAt = &checkedAt
+	}
+
+	if currentOrganizationID != "" {
+		summaryOf(currentOrganizationID).Current = true
+	}
+
+	result := make([]*organizationSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		sort.Strings(summary.Profiles)
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// selectOrganizationProject returns the requested project, or the project named default, or the oldest project.
+func selectOrganizationProject(projects []*account.Project, projectID *string) (*account.Project, error) {
+	if projectID != nil {
+		for _, project := range projects {
+			if project.ID == *projectID {
+				return project, nil
+			}
+		}
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("project %s does not belong to the organization", *projectID),
+			Hint: "List the projects of the organization with: scw account project list organization-id=<organization-id>",
+		}
+	}
+
+	if len(projects) == 0 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("the organization has no project"),
+			Hint: "Create a project with: scw account project create organization-id=<organization-id>",
+		}
+	}
+	for _, project := range projects {
+		if project.Name == defaultProjectName {
+			return project, nil
+		}
+	}
+	return projects[0], nil
+}
+
+func isOrganizationAccessDenied(err error) bool {
+	responseError := (*scw.ResponseError)(nil)
+	if errors.As(err, &responseError) {
+		return responseError.StatusCode == http.StatusUnauthorized || responseError.StatusCode == http.StatusForbidden
+	}
+	return errors.As(err, new(*scw.PermissionsDeniedError)) || errors.As(err, new(*scw.DeniedAuthenticationError))
+}
+
+// organizationAccessDeniedError explains how to get an API key for an organization the current key cannot access.
+func organizationAccessDeniedError(ctx context.Context, config *scw.Config, profileName string, organizationID string) *core.CliError {
+	binaryName := core.ExtractBinaryName(ctx)
+	hint := []string{
+		"To get an API key for this organization:",
+		"- accept the invitation to the organization sent by one of its owners",
+		"- if the organization uses identity federation (SSO), log in to the console through your identity provider first",
+		"- create an API key in the organization from the console: https://console.scaleway.com/iam/api-keys",
+		fmt.Sprintf("- store it in a new profile with: %s init -p <profile-name>", binaryName),
+	}
+
+	for _, summary := range listOrganizations(config, nil, "") {
+		if summary.ID != organizationID {
+			continue
+		}
+		for _, otherProfile := range summary.Profiles {
+			if otherProfile != profileName {
+				hint = append(hint, fmt.Sprintf("The profile %s already uses this organization: %s -p %s <command>", otherProfile, binaryName, otherProfile))
+			}
+		}
+	}
+
+	return &core.CliError{
+		Err:  fmt.Errorf("the API key of profile %s cannot access organization %s", profileName, organizationID),
+		Hint: strings.Join(hint, "\n"),
+	}
+}
+
+// loadOrganizationConfig loads the config file, a missing config file is an empty config.
+func loadOrganizationConfig(ctx context.Context) (*scw.Config, error) {
+	config, err := scw.LoadConfigFromPath(core.ExtractConfigPath(ctx))
+	if errors.As(err, new(*scw.ConfigFileNotFoundError)) {
+		return &scw.Config{}, nil
+	}
+	return config, err
+}
+
+// getConfigProfile returns the profile of the config to modify, it is created when missing.
+func getConfigProfile(config *scw.Config, profileName string) *scw.Profile {
+	if profileName == scw.DefaultProfileName {
+		return &config.Profile
+	}
+	if config.Profiles == nil {
+		config.Profiles = map[string]*scw.Profile{}
+	}
+	profile, exists := config.Profiles[profileName]
+	if !exists {
+		profile = &scw.Profile{}
+		config.Profiles[profileName] = profile
+	}
+	return profile
+}
+
+func organizationCachePath(ctx context.Context) string {
+	return filepath.Join(core.ExtractCacheDir(ctx), organizationCacheFileName)
+}
+
+// readOrganizationCache returns the cached organizations, an unreadable cache is empty.
+func readOrganizationCache(path string) []*organizationCacheEntry {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	cache := []*organizationCacheEntry(nil)
+	if err := json.Unmarshal(content, &cache); err != nil {
+		return nil
+	}
+	return cache
+}
+
+func writeOrganizationCache(path string, cache []*organizationCacheEntry) error {
+	content, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+func setOrganizationAccess(cache []*organizationCacheEntry, organizationID string, access string) []*organizationCacheEntry {
+	for _, entry := range cache {
+		if entry.ID == organizationID {
+			entry.Access = access
+			entry.CheckedAt = time.Now()
+			return cache
+		}
+	}
+	return append(cache, &organizationCacheEntry{
+		ID:        organizationID,
+		Access:    access,
+		CheckedAt: time.Now(),
+	})
+}
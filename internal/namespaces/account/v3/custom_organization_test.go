@@ -0,0 +1,90 @@
+package account
+
+import (
+	"path/filepath"
+	"testing"
+
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listOrganizations(t *testing.T) {
+	config := &scw.Config{
+		Profile: scw.Profile{DefaultOrganizationID: scw.StringPtr("org-b")},
+		Profiles: map[string]*scw.Profile{
+			"prod":    {DefaultOrganizationID: scw.StringPtr("org-a")},
+			"staging": {DefaultOrganizationID: scw.StringPtr("org-b")},
+			"empty":   {},
+		},
+	}
+	cache := setOrganizationAccess(nil, "org-c", organizationAccessDenied)
+	cache = setOrganizationAccess(cache, "org-a", organizationAccessGranted)
+
+	summaries := listOrganizations(config, cache, "org-b")
+	require.Len(t, summaries, 3)
+
+	assert.Equal(t, "org-a", summaries[0].ID)
+	assert.Equal(t, []string{"prod"}, summaries[0].Profiles)
+	assert.Equal(t, organizationAccessGranted, summaries[0].Access)
+	assert.False(t, summaries[0].Current)
+
+	assert.Equal(t, "org-b", summaries[1].ID)
+	assert.Equal(t, []string{"default", "staging"}, summaries[1].Profiles)
+	assert.Equal(t, organizationAccessUnknown, summaries[1].Access)
+	assert.Nil(t, summaries[1].CheckedAt)
+	assert.True(t, summaries[1].Current)
+
+	assert.Equal(t, "org-c", summaries[2].ID)
+	assert.Empty(t, summaries[2].Profiles)
+	assert.Equal(t, organizationAccessDenied, summaries[2].Access)
+}
+
+func Test_selectOrganizationProject(t *testing.T) {
+	projects := []*account.Project{
+		{ID: "p1", Name: "first"},
+		{ID: "p2", Name: "default"},
+		{ID: "p3", Name: "other"},
+	}
+
+	project, err := selectOrganizationProject(projects, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "p2", project.ID)
+
+	project, err = selectOrganizationProject(projects[2:], nil)
+	require.NoError(t, err)
+	assert.Equal(t, "p3", project.ID)
+
+	project, err = selectOrganizationProject(projects, scw.StringPtr("p3"))
+	require.NoError(t, err)
+	assert.Equal(t, "p3", project.ID)
+
+	_, err = selectOrganizationProject(projects, scw.StringPtr("p4"))
+	assert.Error(t, err)
+
+	_, err = selectOrganizationProject(nil, nil)
+	assert.Error(t, err)
+}
+
+func Test_organizationCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", organizationCacheFileName)
+	assert.Empty(t, readOrganizationCache(path))
+
+	cache := setOrganizationAccess(nil, "org-a", organizationAccessDenied)
+	cache = setOrganizationAccess(cache, "org-a", organizationAccessGranted)
+	require.Len(t, cache, 1)
+	require.NoError(t, writeOrganizationCache(path, cache))
+
+	cache = readOrganizationCache(path)
+	require.Len(t, cache, 1)
+	assert.Equal(t, "org-a", cache[0].ID)
+	assert.Equal(t, organizationAccessGranted, cache[0].Access)
+}
+
+func Test_isOrganizationAccessDenied(t *testing.T) {
+	assert.True(t, isOrganizationAccessDenied(&scw.ResponseError{StatusCode: 403}))
+	assert.True(t, isOrganizationAccessDenied(&scw.PermissionsDeniedError{}))
+	assert.False(t, isOrganizationAccessDenied(&scw.ResponseError{StatusCode: 500}))
+	assert.False(t, isOrganizationAccessDenied(&scw.ResourceNotFoundError{}))
+}
@@ -0,0 +1,223 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func projectDeleteBuilder(c *core.Command) *core.Command {
+	c.DeletePreview = projectDeletePreview
+	return c
+}
+
+// projectDeletePreview shows the project deleted, and warns when it is the default project of the profile.
+func projectDeletePreview(ctx context.Context, argsI interface{}) (*core.DeletePreview, error) {
+	request := argsI.(*account.ProjectAPIDeleteProjectRequest)
+	client := core.ExtractClient(ctx)
+
+	project, err := account.NewProjectAPI(client).GetProject(&account.ProjectAPIGetProjectRequest{
+		ProjectID: request.ProjectID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &core.DeletePreviewResource{
+		Type: "project",
+		ID:   project.ID,
+		Name: project.Name,
+	}
+	if defaultProjectID, _ := client.GetDefaultProjectID(); defaultProjectID == project.ID {
+		resource.Type = "default project of the profile"
+	}
+	return &core.DeletePreview{Resources: []*core.DeletePreviewResource{resource}}, nil
+}
+
+func projectSwitchCommand() *core.Command {
+	type projectSwitchArgs struct {
+		ProjectID string
+	}
+
+	return &core.Command{
+		Short: `Switch the current profile to another project`,
+		Long: `Set the default project of the current profile, and its default organization to the organization of the project.
+In interactive mode, the project is picked from the projects of the current organization when it is not given.`,
+		Namespace: "account",
+		Resource:  "project",
+		Verb:      "switch",
+		ArgsType:  reflect.TypeOf(projectSwitchArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "project-id",
+				Short:      `ID of the project to switch to`,
+				Required:   true,
+				Positional: true,
+			},
+		},
+		PickArgs: projectSwitchPickArgs,
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*projectSwitchArgs)
+			profileName := core.ExtractProfileName(ctx)
+
+			project, err := account.NewProjectAPI(core.ExtractClient(ctx)).GetProject(&account.ProjectAPIGetProjectRequest{
+				ProjectID: args.ProjectID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			config, err := loadOrganizationConfig(ctx)
+			if err != nil {
+				return nil, err
+			}
+			profile := getConfigProfile(config, profileName)
+			profile.DefaultOrganizationID = scw.StringPtr(project.OrganizationID)
+			profile.DefaultProjectID = scw.StringPtr(project.ID)
+
+			err = config.SaveTo(core.ExtractConfigPath(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return &core.SuccessResult{
+				Message: fmt.Sprintf("profile %s switched to project %s (%s) of organization %s", profileName, project.Name, project.ID, project.OrganizationID),
+			}, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Pick the project to switch to",
+				Raw:   "scw project switch",
+			},
+			{
+				Short: "Switch to a project",
+				Raw:   "scw account project switch 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Switch to another organization",
+				Command: "scw account organization switch",
+			},
+		},
+	}
+}
+
+// projectSwitchPickArgs lets the user pick a project of the current organization, the current project is selected by default.
+func projectSwitchPickArgs(ctx context.Context, rawArgs args.RawArgs) (args.RawArgs, error) {
+	if rawArgs.Has("project-id") {
+		return rawArgs, nil
+	}
+	client := core.ExtractClient(ctx)
+	organizationID, _ := client.GetDefaultOrganizationID()
+	currentProjectID, _ := client.GetDefaultProjectID()
+
+	projects, err := account.NewProjectAPI(client).ListProjects(&account.ProjectAPIListProjectsRequest{
+		OrganizationID: organizationID,
+		OrderBy:        account.ListProjectsRequestOrderByNameAsc,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ids, choices := projectChoices(projects.Projects, currentProjectID)
+	projectID, err := core.PickChoice(ctx, fmt.Sprintf("Choose the project of organization %s", organizationID), ids, choices, currentProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if projectID == "" {
+		return rawArgs, nil
+	}
+	return rawArgs.Add("project-id", projectID), nil
+}
+
+// projectChoices returns the IDs of the projects, with their choices showing their names and the current project.
+func projectChoices(projects []*account.Project, currentProjectID string) ([]string, []string) {
+	ids := make([]string, 0, len(projects))
+	choices := make([]string, 0, len(projects))
+	for _, project := range projects {
+		choice := fmt.Sprintf("%s  %s", project.ID, project.Name)
+		if project.ID == currentProjectID {
+			choice += " (current)"
+		}
+		ids = append(ids, project.ID)
+		choices = append(choices, choice)
+	}
+	return ids, choices
+}
+
+// organizationSwitchPickArgs lets the user pick one of the organizations known by the CLI, the current organization is selected by default.
+func organizationSwitchPickArgs(ctx context.Context, rawArgs args.RawArgs) (args.RawArgs, error) {
+	if rawArgs.Has("organization-id") {
+		return rawArgs, nil
+	}
+	config, err := loadOrganizationConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currentOrganizationID, _ := core.ExtractClient(ctx).GetDefaultOrganizationID()
+	summaries := listOrganizations(config, readOrganizationCache(organizationCachePath(ctx)), currentOrganizationID)
+
+	ids, choices := organizationChoices(summaries)
+	organizationID, err := core.PickChoice(ctx, "Choose the organization", ids, choices, currentOrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	if organizationID == "" {
+		return rawArgs, nil
+	}
+	return rawArgs.Add("organization-id", organizationID), nil
+}
+
+// organizationChoices returns the IDs of the organizations, with their choices showing their profiles and access.
+func organizationChoices(summaries []*organizationSummary) ([]string, []string) {
+	ids := make([]string, 0, len(summaries))
+	choices := make([]string, 0, len(summaries))
+	for _, summary := range summaries {
+		choice := fmt.Sprintf("%s  access=%s", summary.ID, summary.Access)
+		if len(summary.Profiles) > 0 {
+			choice += "  profiles=" + strings.Join(summary.Profiles, ",")
+		}
+		if summary.Current {
+			choice += " (current)"
+		}
+		ids = append(ids, summary.ID)
+		choices = append(choices, choice)
+	}
+	return ids, choices
+}
+
+func projectShortcutRoot() *core.Command {
+	return &core.Command{
+		Short:     `Shortcuts to manage the projects of the current profile`,
+		Long:      `Shortcuts to the scw account project commands to switch, create and delete projects.`,
+		Namespace: "project",
+		Groups:    []string{"config"},
+	}
+}
+
+func organizationShortcutRoot() *core.Command {
+	return &core.Command{
+		Short:     `Shortcuts to manage the organization of the current profile`,
+		Long:      `Shortcuts to the scw account organization commands to switch organizations.`,
+		Namespace: "org",
+		Groups:    []string{"config"},
+	}
+}
+
+// shortcutCommand returns a copy of an account command reachable from a shorter path,
+// e.g. scw project switch for scw account project switch.
+func shortcutCommand(cmd *core.Command, namespace string) *core.Command {
+	shortcut := cmd.Copy()
+	shortcut.Namespace = namespace
+	shortcut.Resource = cmd.Verb
+	shortcut.Verb = ""
+	shortcut.Groups = []string{"config"}
+	return shortcut
+}
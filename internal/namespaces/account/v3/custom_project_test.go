@@ -0,0 +1,43 @@
+package account
+
+import (
+	"testing"
+
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_projectChoices(t *testing.T) {
+	ids, choices := projectChoices([]*account.Project{
+		{ID: "project-a", Name: "default"},
+		{ID: "project-b", Name: "staging"},
+	}, "project-b")
+
+	assert.Equal(t, []string{"project-a", "project-b"}, ids)
+	assert.Equal(t, []string{"project-a  default", "project-b  staging (current)"}, choices)
+}
+
+func Test_organizationChoices(t *testing.T) {
+	ids, choices := organizationChoices([]*organizationSummary{
+		{ID: "org-a", Access: organizationAccessGranted, Profiles: []string{"default", "prod"}, Current: true},
+		{ID: "org-b", Access: organizationAccessUnknown, Profiles: []string{}},
+	})
+
+	assert.Equal(t, []string{"org-a", "org-b"}, ids)
+	assert.Equal(t, []string{"org-a  access=granted  profiles=default,prod (current)", "org-b  access=unknown"}, choices)
+}
+
+func Test_shortcutCommands(t *testing.T) {
+	commands := GetCommands()
+
+	projectSwitch := commands.MustFind("project", "switch")
+	assert.Equal(t, "project", projectSwitch.Namespace)
+	assert.Equal(t, "switch", projectSwitch.Resource)
+	assert.Empty(t, projectSwitch.Verb)
+	assert.NotNil(t, projectSwitch.PickArgs)
+	assert.Equal(t, []string{"config"}, projectSwitch.Groups)
+
+	assert.NotNil(t, commands.MustFind("project", "delete").DeletePreview)
+	assert.NotNil(t, commands.MustFind("org", "switch").PickArgs)
+	assert.Equal(t, "organization", commands.MustFind("account", "organization", "switch").Resource)
+}
@@ -0,0 +1,262 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	instancecommands "github.com/scaleway/scaleway-cli/v2/internal/namespaces/instance/v1"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type quotaStatus string
+
+const (
+	quotaStatusOK        = quotaStatus("ok")
+	quotaStatusWarning   = quotaStatus("warning")
+	quotaStatusExhausted = quotaStatus("exhausted")
+	quotaStatusUnlimited = quotaStatus("unlimited")
+	// quotaStatusUnknown is the status of the quotas whose usage cannot be computed by the CLI
+	quotaStatusUnknown = quotaStatus("unknown")
+)
+
+var quotaStatusMarshalSpecs = human.EnumMarshalSpecs{
+	quotaStatusOK:        &human.EnumMarshalSpec{Attribute: color.FgGreen},
+	quotaStatusWarning:   &human.EnumMarshalSpec{Attribute: color.FgYellow},
+	quotaStatusExhausted: &human.EnumMarshalSpec{Attribute: color.FgRed},
+	quotaStatusUnlimited: &human.EnumMarshalSpec{Attribute: color.Faint},
+	quotaStatusUnknown:   &human.EnumMarshalSpec{Attribute: color.Faint},
+}
+
+type quotaListArgs struct {
+	OrganizationID *string
+	WarnThreshold  uint32
+}
+
+// quotaUsage is a quota of the organization and its current usage.
+type quotaUsage struct {
+	Product string `json:"product"`
+	Name    string `json:"name"`
+	// Limit is nil when the quota is unlimited
+	Limit *uint64 `json:"limit"`
+	// Used and UsedPercent are nil when the usage is unknown, UsedPercent is also nil when the quota is unlimited
+	Used        *uint64     `json:"used"`
+	UsedPercent *uint64     `json:"used_percent"`
+	Status      quotaStatus `json:"status"`
+}
+
+func quotaCommand() *core.Command {
+	return &core.Command{
+		Short:     `Quota management commands`,
+		Long:      `Quotas limit the number of resources an organization can create for each product.`,
+		Namespace: "account",
+		Resource:  "quota",
+	}
+}
+
+func quotaListCommand() *core.Command {
+	return &core.Command{
+		Short: `List the quotas of the organization and their usage`,
+		Long: `List the quotas of the organization for each product, with their current usage when the CLI can compute it.
+The usage is computed for the quotas of Instance server types, the usage of the other quotas is unknown.
+
+With warn-threshold, the quotas used at this percentage or more are highlighted and the command fails, to monitor the quotas from a CI pipeline.`,
+		Namespace: "account",
+		Resource:  "quota",
+		Verb:      "list",
+		ArgsType:  reflect.TypeOf(quotaListArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "warn-threshold",
+				Short: `Fail when a quota is used at this percentage or more`,
+			},
+			core.OrganizationIDArgSpec(),
+		},
+		Run: quotaListRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Product", FieldName: "Product"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Used", FieldName: "Used"},
+				{Label: "Limit", FieldName: "Limit"},
+				{Label: "Used Percent", FieldName: "UsedPercent"},
+				{Label: "Status", FieldName: "Status"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the quotas of the organization",
+				Raw:   "scw account quota list",
+			},
+			{
+				Short: "Fail a CI pipeline when a quota is used at 80% or more",
+				Raw:   "scw account quota list warn-threshold=80",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Check the stock and the quota of a server type",
+				Command: "scw instance server-type availability",
+			},
+		},
+	}
+}
+
+func quotaListRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*quotaListArgs)
+	client := core.ExtractClient(ctx)
+
+	if args.WarnThreshold > 100 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("invalid warn-threshold %d", args.WarnThreshold),
+			Hint: "The threshold is a percentage between 1 and 100",
+			Code: core.ExitCodeUsage,
+		}
+	}
+
+	organizationID := ""
+	if args.OrganizationID != nil {
+		organizationID = *args.OrganizationID
+	} else if defaultOrganizationID, exists := client.GetDefaultOrganizationID(); exists {
+		organizationID = defaultOrganizationID
+	}
+	if organizationID == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no organization ID found"),
+			Hint: "Use organization-id=xxx or set a default organization in your configuration",
+		}
+	}
+
+	quota, err := iam.NewAPI(client).ListQuota(&iam.ListQuotaRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The usage is best effort: it stays unknown when the servers of a zone cannot be counted.
+	serversByTypes, err := countServersByTypes(ctx, instance.NewAPI(client), organizationID)
+	if err != nil {
+		core.ExtractLogger(ctx).Debugf("cannot count the servers of organization %s: %s\n", organizationID, err)
+		serversByTypes = nil
+	}
+
+	usages := quotaUsages(quota.Quota, serversByTypes, args.WarnThreshold)
+	if args.WarnThreshold > 0 {
+		if err := quotaWarningError(usages, args.WarnThreshold); err != nil {
+			return nil, err
+		}
+	}
+	return usages, nil
+}
+
+// countServersByTypes counts the servers of the organization by commercial type in all the zones of the Instance API.
+func countServersByTypes(ctx context.Context, api *instance.API, organizationID string) (map[string]uint32, error) {
+	serversByTypes := map[string]uint32{}
+	for _, zone := range api.Zones() {
+		dashboard, err := api.GetDashboard(&instance.GetDashboardRequest{
+			Zone:         zone,
+			Organization: &organizationID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for serverType, count := range dashboard.Dashboard.ServersByTypes {
+			serversByTypes[serverType] += count
+		}
+	}
+	return serversByTypes, nil
+}
+
+// quotaUsages returns the usage of the quotas sorted by product, serversByTypes is nil when the servers could not be counted.
+func quotaUsages(quota []*iam.Quotum, serversByTypes map[string]uint32, warnThreshold uint32) []*quotaUsage {
+	usages := make([]*quotaUsage, 0, len(quota))
+	for _, quotum := range quota {
+		usage := &quotaUsage{
+			Product: strings.SplitN(quotum.Name, "_", 2)[0],
+			Name:    quotum.Name,
+			Status:  quotaStatusUnknown,
+		}
+		if quotum.Limit != nil && (quotum.Unlimited == nil || !*quotum.Unlimited) {
+			usage.Limit = quotum.Limit
+		}
+
+		if serversByTypes != nil {
+			used, isServerQuota := serverQuotaUsage(quotum.Name, serversByTypes)
+			if isServerQuota {
+				usage.Used = &used
+			}
+		}
+
+		switch {
+		case usage.Limit == nil:
+			usage.Status = quotaStatusUnlimited
+		case usage.Used != nil:
+			usage.Status = quotaStatusOf(*usage.Used, *usage.Limit, warnThreshold)
+			usedPercent := uint64(100)
+			if *usage.Limit > 0 {
+				usedPercent = *usage.Used * 100 / *usage.Limit
+			}
+			usage.UsedPercent = &usedPercent
+		}
+		usages = append(usages, usage)
+	}
+
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].Product != usages[j].Product {
+			return usages[i].Product < usages[j].Product
+		}
+		return usages[i].Name < usages[j].Name
+	})
+	return usages
+}
+
+// serverQuotaUsage returns the number of servers limited by a quota, and whether it is a quota of Instance server types.
+func serverQuotaUsage(quotumName string, serversByTypes map[string]uint32) (uint64, bool) {
+	used := uint64(0)
+	isServerQuota := false
+	for serverType, count := range serversByTypes {
+		if instancecommands.QuotumMatchesServerType(quotumName, serverType) {
+			used += uint64(count)
+			isServerQuota = true
+		}
+	}
+	return used, isServerQuota
+}
+
+func quotaStatusOf(used uint64, limit uint64, warnThreshold uint32) quotaStatus {
+	switch {
+	case used >= limit:
+		return quotaStatusExhausted
+	case warnThreshold > 0 && used*100 >= limit*uint64(warnThreshold):
+		return quotaStatusWarning
+	}
+	return quotaStatusOK
+}
+
+// quotaWarningError returns an error listing the quotas used at warnThreshold percent or more, or nil when there is none.
+func quotaWarningError(usages []*quotaUsage, warnThreshold uint32) error {
+	details := []string(nil)
+	for _, usage := range usages {
+		if usage.Status == quotaStatusWarning || usage.Status == quotaStatusExhausted {
+			details = append(details, fmt.Sprintf("%s: %d of %d used (%d%%)", usage.Name, *usage.Used, *usage.Limit, *usage.UsedPercent))
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return &core.CliError{
+		Err:     fmt.Errorf("%d quotas used at %d%% or more", len(details), warnThreshold),
+		Details: strings.Join(details, "\n"),
+		Hint:    "Delete unused resources or contact the support to raise the quotas",
+		Code:    core.ExitCodeQuota,
+	}
+}
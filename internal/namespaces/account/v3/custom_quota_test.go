@@ -0,0 +1,56 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_quotaUsages(t *testing.T) {
+	quota := []*iam.Quotum{
+		{Name: "instances_gp1_s_servers", Limit: scw.Uint64Ptr(10)},
+		{Name: "instances_dev1_s_servers", Limit: scw.Uint64Ptr(4)},
+		{Name: "instances_dev1_m_servers", Limit: scw.Uint64Ptr(2)},
+		{Name: "block_volumes", Limit: scw.Uint64Ptr(20)},
+		{Name: "instances_pro2_xxs_servers", Unlimited: scw.BoolPtr(true)},
+	}
+	serversByTypes := map[string]uint32{
+		"GP1-S":  9,
+		"DEV1-S": 1,
+		"DEV1-M": 2,
+	}
+
+	usages := quotaUsages(quota, serversByTypes, 80)
+
+	type result struct {
+		Name   string
+		Used   *uint64
+		Status quotaStatus
+	}
+	results := []result(nil)
+	for _, usage := range usages {
+		results = append(results, result{usage.Name, usage.Used, usage.Status})
+	}
+	assert.Equal(t, []result{
+		{"block_volumes", nil, quotaStatusUnknown},
+		{"instances_dev1_m_servers", scw.Uint64Ptr(2), quotaStatusExhausted},
+		{"instances_dev1_s_servers", scw.Uint64Ptr(1), quotaStatusOK},
+		{"instances_gp1_s_servers", scw.Uint64Ptr(9), quotaStatusWarning},
+		{"instances_pro2_xxs_servers", nil, quotaStatusUnlimited},
+	}, results)
+	assert.Equal(t, "instances", usages[1].Product)
+	assert.Equal(t, scw.Uint64Ptr(90), usages[3].UsedPercent)
+
+	err := quotaWarningError(usages, 80)
+	require.Error(t, err)
+	cliErr := err.(*core.CliError)
+	assert.Equal(t, "2 quotas used at 80% or more", cliErr.Error())
+	assert.Equal(t, "instances_dev1_m_servers: 2 of 2 used (100%)\ninstances_gp1_s_servers: 9 of 10 used (90%)", cliErr.Details)
+	assert.Equal(t, core.ExitCodeQuota, cliErr.Code)
+
+	assert.NoError(t, quotaWarningError(quotaUsages(quota, serversByTypes, 0)[2:3], 80))
+}
@@ -0,0 +1,360 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	securityReportSectionUsers          = "users"
+	securityReportSectionAPIKeys        = "api-keys"
+	securityReportSectionSSHKeys        = "ssh-keys"
+	securityReportSectionPolicies       = "policies"
+	securityReportSectionSecurityGroups = "security-groups"
+)
+
+type securityReportArgs struct {
+	OrganizationID *string
+	APIKeyMaxAge   time.Duration
+	Format         string
+}
+
+type securityReportEntry struct {
+	Section string `json:"section"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Detail  string `json:"detail"`
+	Flagged bool   `json:"flagged"`
+}
+
+type securityReport struct {
+	OrganizationID string                 `json:"organization_id"`
+	GeneratedAt    time.Time              `json:"generated_at"`
+	Entries        []*securityReportEntry `json:"entries"`
+}
+
+func securityReportCommand() *core.Command {
+	return &core.Command{
+		Short: `Generate a security report of the organization`,
+		Long: `Generate a compliance oriented report of the organization security settings:
+- members and their MFA status
+- API keys and their age
+- SSH keys
+- IAM policies and their principal
+- Instance security groups accepting inbound traffic from anywhere
+
+Entries needing attention are flagged. The report can be exported as CSV or markdown to be archived, for example from a scheduled job.`,
+		Namespace: "account",
+		Resource:  "security-report",
+		ArgsType:  reflect.TypeOf(securityReportArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:    "api-key-max-age",
+				Short:   `API keys older than this age are flagged`,
+				Default: core.DefaultValueSetter("90d"),
+			},
+			{
+				Name:       "format",
+				Short:      `Export the report as CSV or markdown instead of the regular output`,
+				EnumValues: []string{"csv", "markdown"},
+			},
+			core.OrganizationIDArgSpec(),
+		},
+		Run: securityReportRun,
+		View: &core.View{
+			Sections: []*core.ViewSection{
+				{FieldName: "Entries"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Export the security report as markdown",
+				Raw:   "scw account security-report format=markdown > security-report.md",
+			},
+			{
+				Short: "Export the security report as CSV, flagging API keys older than 30 days",
+				Raw:   "scw account security-report format=csv api-key-max-age=30d > security-report.csv",
+			},
+		},
+	}
+}
+
+func securityReportRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*securityReportArgs)
+	client := core.ExtractClient(ctx)
+
+	organizationID := ""
+	if args.OrganizationID != nil {
+		organizationID = *args.OrganizationID
+	} else if defaultOrganizationID, exists := client.GetDefaultOrganizationID(); exists {
+		organizationID = defaultOrganizationID
+	}
+	if organizationID == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no organization ID found"),
+			Hint: "Use organization-id=xxx or set a default organization in your configuration",
+		}
+	}
+
+	now := time.Now()
+	report := &securityReport{
+		OrganizationID: organizationID,
+		GeneratedAt:    now,
+	}
+
+	iamAPI := iam.NewAPI(client)
+
+	users, err := iamAPI.ListUsers(&iam.ListUsersRequest{
+		OrganizationID: &organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	report.Entries = append(report.Entries, userReportEntries(users.Users)...)
+
+	apiKeys, err := iamAPI.ListAPIKeys(&iam.ListAPIKeysRequest{
+		OrganizationID: &organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	report.Entries = append(report.Entries, apiKeyReportEntries(apiKeys.APIKeys, args.APIKeyMaxAge, now)...)
+
+	sshKeys, err := iamAPI.ListSSHKeys(&iam.ListSSHKeysRequest{
+		OrganizationID: &organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, sshKey := range sshKeys.SSHKeys {
+		report.Entries = append(report.Entries, &securityReportEntry{
+			Section: securityReportSectionSSHKeys,
+			ID:      sshKey.ID,
+			Name:    sshKey.Name,
+			Detail:  fmt.Sprintf("fingerprint=%s project=%s created=%s disabled=%t", sshKey.Fingerprint, sshKey.ProjectID, formatReportDate(sshKey.CreatedAt), sshKey.Disabled),
+		})
+	}
+
+	policies, err := iamAPI.ListPolicies(&iam.ListPoliciesRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	report.Entries = append(report.Entries, policyReportEntries(policies.Policies)...)
+
+	instanceAPI := instance.NewAPI(client)
+	for _, zone := range instanceAPI.Zones() {
+		securityGroups, err := instanceAPI.ListSecurityGroups(&instance.ListSecurityGroupsRequest{
+			Zone:         zone,
+			Organization: &organizationID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, securityGroup := range securityGroups.SecurityGroups {
+			rules, err := instanceAPI.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+				Zone:            zone,
+				SecurityGroupID: securityGroup.ID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			report.Entries = append(report.Entries, securityGroupReportEntries(securityGroup, rules.Rules)...)
+		}
+	}
+
+	switch args.Format {
+	case "csv":
+		csvReport, err := formatSecurityReportCSV(report)
+		if err != nil {
+			return nil, err
+		}
+		return core.RawResult(csvReport), nil
+	case "markdown":
+		return core.RawResult(formatSecurityReportMarkdown(report)), nil
+	}
+
+	return report, nil
+}
+
+func formatReportDate(date *time.Time) string {
+	if date == nil {
+		return "never"
+	}
+	return date.Format(time.RFC3339)
+}
+
+func userReportEntries(users []*iam.User) []*securityReportEntry {
+	entries := []*securityReportEntry(nil)
+	for _, user := range users {
+		entries = append(entries, &securityReportEntry{
+			Section: securityReportSectionUsers,
+			ID:      user.ID,
+			Name:    user.Email,
+			Detail:  fmt.Sprintf("type=%s status=%s mfa=%t last_login=%s", user.Type, user.Status, user.Mfa, formatReportDate(user.LastLoginAt)),
+			Flagged: !user.Mfa,
+		})
+	}
+	return entries
+}
+
+// apiKeyReportEntries flags API keys older than maxAge, a zero maxAge disables the check.
+func apiKeyReportEntries(apiKeys []*iam.APIKey, maxAge time.Duration, now time.Time) []*securityReportEntry {
+	entries := []*securityReportEntry(nil)
+	for _, apiKey := range apiKeys {
+		owner := ""
+		switch {
+		case apiKey.UserID != nil:
+			owner = "user:" + *apiKey.UserID
+		case apiKey.ApplicationID != nil:
+			owner = "application:" + *apiKey.ApplicationID
+		}
+
+		age := time.Duration(0)
+		if apiKey.CreatedAt != nil {
+			age = now.Sub(*apiKey.CreatedAt)
+		}
+
+		entries = append(entries, &securityReportEntry{
+			Section: securityReportSectionAPIKeys,
+			ID:      apiKey.AccessKey,
+			Name:    apiKey.Description,
+			Detail:  fmt.Sprintf("owner=%s created=%s expires=%s age=%dd", owner, formatReportDate(apiKey.CreatedAt), formatReportDate(apiKey.ExpiresAt), int(age.Hours()/24)),
+			Flagged: maxAge > 0 && age > maxAge,
+		})
+	}
+	return entries
+}
+
+// policyReportEntries flags policies without principal.
+func policyReportEntries(policies []*iam.Policy) []*securityReportEntry {
+	entries := []*securityReportEntry(nil)
+	for _, policy := range policies {
+		principal := ""
+		switch {
+		case policy.UserID != nil:
+			principal = "user:" + *policy.UserID
+		case policy.GroupID != nil:
+			principal = "group:" + *policy.GroupID
+		case policy.ApplicationID != nil:
+			principal = "application:" + *policy.ApplicationID
+		}
+
+		entries = append(entries, &securityReportEntry{
+			Section: securityReportSectionPolicies,
+			ID:      policy.ID,
+			Name:    policy.Name,
+			Detail:  fmt.Sprintf("principal=%s rules=%d editable=%t", principal, policy.NbRules, policy.Editable),
+			Flagged: principal == "",
+		})
+	}
+	return entries
+}
+
+// securityGroupReportEntries reports security groups accepting inbound traffic from anywhere,
+// either with their default policy or with rules.
+func securityGroupReportEntries(securityGroup *instance.SecurityGroup, rules []*instance.SecurityGroupRule) []*securityReportEntry {
+	entries := []*securityReportEntry(nil)
+	if securityGroup.InboundDefaultPolicy == instance.SecurityGroupPolicyAccept {
+		entries = append(entries, &securityReportEntry{
+			Section: securityReportSectionSecurityGroups,
+			ID:      securityGroup.ID,
+			Name:    securityGroup.Name,
+			Detail:  fmt.Sprintf("zone=%s inbound default policy is accept", securityGroup.Zone),
+			Flagged: true,
+		})
+	}
+
+	for _, rule := range rules {
+		if rule.Direction != instance.SecurityGroupRuleDirectionInbound || rule.Action != instance.SecurityGroupRuleActionAccept {
+			continue
+		}
+		if ones, _ := rule.IPRange.Mask.Size(); ones != 0 {
+			continue
+		}
+
+		ports := "all"
+		if rule.DestPortFrom != nil {
+			ports = strconv.FormatUint(uint64(*rule.DestPortFrom), 10)
+			if rule.DestPortTo != nil && *rule.DestPortTo != *rule.DestPortFrom {
+				ports += "-" + strconv.FormatUint(uint64(*rule.DestPortTo), 10)
+			}
+		}
+
+		entries = append(entries, &securityReportEntry{
+			Section: securityReportSectionSecurityGroups,
+			ID:      rule.ID,
+			Name:    securityGroup.Name,
+			Detail:  fmt.Sprintf("zone=%s security_group=%s protocol=%s ports=%s ip_range=%s", securityGroup.Zone, securityGroup.ID, rule.Protocol, ports, rule.IPRange.String()),
+			Flagged: true,
+		})
+	}
+
+	return entries
+}
+
+func formatSecurityReportCSV(report *securityReport) (string, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	err := w.Write([]string{"section", "id", "name", "detail", "flagged"})
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range report.Entries {
+		err := w.Write([]string{entry.Section, entry.ID, entry.Name, entry.Detail, strconv.FormatBool(entry.Flagged)})
+		if err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+
+	return buf.String(), w.Error()
+}
+
+func formatSecurityReportMarkdown(report *securityReport) string {
+	escape := strings.NewReplacer("|", `\|`, "\n", " ").Replace
+
+	buf := strings.Builder{}
+	buf.WriteString("# Security report\n\n")
+	buf.WriteString(fmt.Sprintf("- Organization: %s\n", report.OrganizationID))
+	buf.WriteString(fmt.Sprintf("- Generated at: %s\n", report.GeneratedAt.Format(time.RFC3339)))
+
+	sections := []string{
+		securityReportSectionUsers,
+		securityReportSectionAPIKeys,
+		securityReportSectionSSHKeys,
+		securityReportSectionPolicies,
+		securityReportSectionSecurityGroups,
+	}
+	for _, section := range sections {
+		buf.WriteString(fmt.Sprintf("\n## %s\n\n", section))
+		buf.WriteString("| ID | Name | Detail | Flagged |\n")
+		buf.WriteString("|----|------|--------|---------|\n")
+		for _, entry := range report.Entries {
+			if entry.Section != section {
+				continue
+			}
+			flagged := ""
+			if entry.Flagged {
+				flagged = "yes"
+			}
+			buf.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", escape(entry.ID), escape(entry.Name), escape(entry.Detail), flagged))
+		}
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,90 @@
+package account
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeyReportEntries(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	oldKey := now.Add(-120 * 24 * time.Hour)
+	recentKey := now.Add(-10 * 24 * time.Hour)
+
+	entries := apiKeyReportEntries([]*iam.APIKey{
+		{AccessKey: "SCWOLD", UserID: scw.StringPtr("user-id"), CreatedAt: &oldKey},
+		{AccessKey: "SCWRECENT", ApplicationID: scw.StringPtr("app-id"), CreatedAt: &recentKey},
+	}, 90*24*time.Hour, now)
+
+	require.Len(t, entries, 2)
+	assert.True(t, entries[0].Flagged)
+	assert.Contains(t, entries[0].Detail, "owner=user:user-id")
+	assert.Contains(t, entries[0].Detail, "age=120d")
+	assert.False(t, entries[1].Flagged)
+	assert.Contains(t, entries[1].Detail, "owner=application:app-id")
+}
+
+func Test_securityGroupReportEntries(t *testing.T) {
+	_, anyIPv4, _ := net.ParseCIDR("0.0.0.0/0")
+	_, privateRange, _ := net.ParseCIDR("10.0.0.0/8")
+
+	entries := securityGroupReportEntries(&instance.SecurityGroup{
+		ID:                   "sg-id",
+		Name:                 "web",
+		Zone:                 scw.ZoneFrPar1,
+		InboundDefaultPolicy: instance.SecurityGroupPolicyDrop,
+	}, []*instance.SecurityGroupRule{
+		{
+			ID:           "open-ssh",
+			Direction:    instance.SecurityGroupRuleDirectionInbound,
+			Action:       instance.SecurityGroupRuleActionAccept,
+			Protocol:     instance.SecurityGroupRuleProtocolTCP,
+			IPRange:      scw.IPNet{IPNet: *anyIPv4},
+			DestPortFrom: scw.Uint32Ptr(22),
+		},
+		{
+			ID:        "private",
+			Direction: instance.SecurityGroupRuleDirectionInbound,
+			Action:    instance.SecurityGroupRuleActionAccept,
+			Protocol:  instance.SecurityGroupRuleProtocolTCP,
+			IPRange:   scw.IPNet{IPNet: *privateRange},
+		},
+		{
+			ID:        "outbound",
+			Direction: instance.SecurityGroupRuleDirectionOutbound,
+			Action:    instance.SecurityGroupRuleActionAccept,
+			Protocol:  instance.SecurityGroupRuleProtocolANY,
+			IPRange:   scw.IPNet{IPNet: *anyIPv4},
+		},
+	})
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, "open-ssh", entries[0].ID)
+	assert.Equal(t, "zone=fr-par-1 security_group=sg-id protocol=TCP ports=22 ip_range=0.0.0.0/0", entries[0].Detail)
+}
+
+func Test_formatSecurityReport(t *testing.T) {
+	report := &securityReport{
+		OrganizationID: "org-id",
+		GeneratedAt:    time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Entries: []*securityReportEntry{
+			{Section: securityReportSectionUsers, ID: "user-id", Name: "jane@example.com", Detail: "mfa=false", Flagged: true},
+			{Section: securityReportSectionPolicies, ID: "policy-id", Name: "admins | ops", Detail: "rules=1"},
+		},
+	}
+
+	csvReport, err := formatSecurityReportCSV(report)
+	require.NoError(t, err)
+	assert.Equal(t, "section,id,name,detail,flagged\nusers,user-id,jane@example.com,mfa=false,true\npolicies,policy-id,admins | ops,rules=1,false\n", csvReport)
+
+	markdownReport := formatSecurityReportMarkdown(report)
+	assert.Contains(t, markdownReport, "- Organization: org-id\n")
+	assert.Contains(t, markdownReport, "| user-id | jane@example.com | mfa=false | yes |\n")
+	assert.Contains(t, markdownReport, `| policy-id | admins \| ops | rules=1 |  |`)
+}
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/pkg/shlex"
 )
@@ -62,7 +63,8 @@ func aliasCreateCommand() *core.Command {
 		Short:     "Create a new alias for a command",
 		Namespace: "alias",
 		Resource:  "create",
-		Long:      `This command help you create aliases and save it to your config`,
+		Long: `This command help you create aliases and save it to your config
+Aliases can also be created with the shorthand syntax <alias>="<command>"`,
 		Examples: []*core.Example{
 			{
 				Short: "Create a custom alias 'isl' for 'instance server list'",
@@ -72,6 +74,10 @@ func aliasCreateCommand() *core.Command {
 				Short: "Add an alias to a verb",
 				Raw:   `scw alias create c command=create`,
 			},
+			{
+				Short: "Create an alias to connect to a database, with its arguments",
+				Raw:   `scw alias create psql-prod="rdb instance connect 11111111-1111-1111-1111-111111111111 username=admin"`,
+			},
 		},
 		AllowAnonymousClient: true,
 		ArgSpecs: core.ArgSpecs{
@@ -87,7 +93,8 @@ func aliasCreateCommand() *core.Command {
 				Short:      "Command to create an alias for",
 			},
 		},
-		ArgsType: reflect.TypeOf(CreateRequest{}),
+		ArgsType:        reflect.TypeOf(CreateRequest{}),
+		RawArgsRewriter: aliasShorthandRawArgs,
 		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
 			args := argsI.(*CreateRequest)
 			cfg := core.ExtractCliConfig(ctx)
@@ -116,6 +123,20 @@ func aliasCreateCommand() *core.Command {
 	}
 }
 
+// aliasShorthandRawArgs rewrites the shorthand syntax <alias>="<command>" into the alias name and the command argument.
+func aliasShorthandRawArgs(rawArgs args.RawArgs) args.RawArgs {
+	rewrittenArgs := args.RawArgs(nil)
+	for _, arg := range rawArgs {
+		name, command, isKeyValue := strings.Cut(arg, "=")
+		if isKeyValue && name != "alias" && name != "command" {
+			rewrittenArgs = append(rewrittenArgs, name, "command="+command)
+			continue
+		}
+		rewrittenArgs = append(rewrittenArgs, arg)
+	}
+	return rewrittenArgs
+}
+
 type ListRequest struct {
 	Alias   string `json:"alias"`
 	OrderBy string `json:"order-by"`
@@ -228,6 +249,7 @@ func aliasDeleteCommand() *core.Command {
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "alias",
+				Required:   true,
 				Positional: true,
 				Short:      "alias name",
 			},
@@ -47,6 +47,22 @@ func Test_Alias(t *testing.T) {
 		TmpHomeDir: true,
 	}))
 
+	t.Run("shorthand syntax", core.Test(&core.TestConfig{
+		BeforeFunc: core.BeforeFuncCombine(
+			core.ExecBeforeCmdArgs([]string{"scw", "alias", "create", "isl=instance server list"}),
+		),
+		Commands:      namespaces.GetCommands(),
+		Cmd:           "scw isl -h",
+		EnableAliases: true,
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				assert.Contains(t, string(ctx.Stderr), "instance server list")
+			},
+		),
+		TmpHomeDir: true,
+	}))
+
 	t.Run("list aliases", core.Test(&core.TestConfig{
 		BeforeFunc: core.BeforeFuncCombine(
 			core.ExecBeforeCmd("scw alias create myalias command=iam"),
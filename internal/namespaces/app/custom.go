@@ -0,0 +1,21 @@
+package app
+
+import (
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+// GetCommands returns app commands.
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		appRoot(),
+		appDeployCommand(),
+	)
+}
+
+func appRoot() *core.Command {
+	return &core.Command{
+		Short:     `Deploy applications built from several products (experimental)`,
+		Long:      `Deploy applications on Serverless Containers or Instances from a minimal app spec, wiring Load Balancers and DNS records when needed.`,
+		Namespace: "app",
+	}
+}
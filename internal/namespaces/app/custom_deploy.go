@@ -0,0 +1,507 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	container "github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	appInstanceImageLabel = "ubuntu_jammy"
+	appLBType             = "LB-S"
+	appDNSRecordTTL       = 300
+)
+
+type appDeployArgs struct {
+	File      string
+	Service   string
+	ProjectID string
+	Zone      scw.Zone
+}
+
+type appDeployResult struct {
+	Name        string
+	Target      appTarget
+	URL         string
+	NamespaceID string
+	ContainerID string
+	ServerID    string
+	LBID        string
+}
+
+func appDeployCommand() *core.Command {
+	return &core.Command{
+		Short: `Deploy an application from an app spec or a compose file`,
+		Long: `Deploy a container image as a Serverless Container or on a new Instance, optionally behind a Load Balancer and a DNS record, and display its public URL.
+This command is experimental.
+
+The app spec is a YAML document with the following fields: name, image, port (default to 8080), environment, target (container or instance, default to container),
+domain.zone and domain.name to create a DNS record in a Scaleway DNS zone, min_scale, max_scale and memory_limit for the container target, commercial_type and lb for the instance target.
+
+A compose file with a single service, or the service given with the service argument, can be used instead: its image, first port and environment are used
+and the other fields of the app spec can be set in an x-scaleway extension of the service. Building images is not supported.
+
+Deploying a container again updates it, an Instance is only created once.`,
+		Namespace: "app",
+		Resource:  "deploy",
+		ArgsType:  reflect.TypeOf(appDeployArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:        "file",
+				Short:       `App spec or compose file`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "service",
+				Short: `Service of the compose file to deploy`,
+			},
+			core.ProjectIDArgSpec(),
+			core.ZoneArgSpec(),
+		},
+		Run: appDeployRun,
+		Examples: []*core.Example{
+			{
+				Short: "Deploy an app spec",
+				Raw: `cat app.yaml
+name: hello
+image: rg.fr-par.scw.cloud/my-namespace/hello:latest
+port: 8080
+environment:
+  LOG_LEVEL: info
+domain:
+  zone: example.com
+  name: hello
+scw app deploy file=@app.yaml`,
+			},
+			{
+				Short: "Deploy the web service of a compose file on an Instance behind a Load Balancer",
+				Raw: `cat compose.yaml
+services:
+  web:
+    image: nginx:latest
+    ports:
+      - "80:80"
+    x-scaleway:
+      target: instance
+      lb: true
+scw app deploy file=@compose.yaml service=web`,
+			},
+		},
+	}
+}
+
+func appDeployRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*appDeployArgs)
+
+	spec, err := parseAppSpec([]byte(args.File), args.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	// The project is used to look for existing resources, it cannot be left to the API default.
+	if args.ProjectID == "" {
+		projectID, exists := core.ExtractClient(ctx).GetDefaultProjectID()
+		if !exists {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("no project to deploy the application in"),
+				Hint: "Use project-id or set a default project in your configuration",
+			}
+		}
+		args.ProjectID = projectID
+	}
+
+	var result *appDeployResult
+	var address string
+	switch spec.Target {
+	case appTargetContainer:
+		result, address, err = deployAppContainer(ctx, spec, args)
+	case appTargetInstance:
+		result, address, err = deployAppInstance(ctx, spec, args)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Domain != nil {
+		recordType, data := domain.RecordTypeA, address
+		if spec.Target == appTargetContainer {
+			recordType, data = domain.RecordTypeCNAME, address+"."
+		}
+
+		_, err = domain.NewAPI(core.ExtractClient(ctx)).UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+			DNSZone: spec.Domain.Zone,
+			Changes: []*domain.RecordChange{
+				{
+					Set: &domain.RecordChangeSet{
+						IDFields: &domain.RecordIdentifier{
+							Name: spec.Domain.Name,
+							Type: recordType,
+						},
+						Records: []*domain.Record{
+							{
+								Name: spec.Domain.Name,
+								Type: recordType,
+								Data: data,
+								TTL:  appDNSRecordTTL,
+							},
+						},
+					},
+				},
+			},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("cannot set the DNS record of %s: %w", spec.Domain.Hostname(), err)
+		}
+		_, _ = interactive.Printf("DNS record %s set\n", spec.Domain.Hostname())
+
+		if spec.Target == appTargetContainer {
+			err = setAppContainerDomain(ctx, args, result.ContainerID, spec.Domain.Hostname())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		result.URL = strings.Replace(result.URL, address, spec.Domain.Hostname(), 1)
+	}
+
+	return result, nil
+}
+
+func deployAppContainer(ctx context.Context, spec *appSpec, args *appDeployArgs) (*appDeployResult, string, error) {
+	region, err := args.Zone.Region()
+	if err != nil {
+		return nil, "", err
+	}
+	api := container.NewAPI(core.ExtractClient(ctx))
+
+	namespaces, err := api.ListNamespaces(&container.ListNamespacesRequest{
+		Region:    region,
+		Name:      &spec.Name,
+		ProjectID: &args.ProjectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var namespace *container.Namespace
+	for _, ns := range namespaces.Namespaces {
+		if ns.Name == spec.Name {
+			namespace = ns
+		}
+	}
+	if namespace == nil {
+		namespace, err = api.CreateNamespace(&container.CreateNamespaceRequest{
+			Region:    region,
+			Name:      spec.Name,
+			ProjectID: args.ProjectID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+		_, _ = interactive.Printf("Container namespace %s created\n", namespace.ID)
+	}
+
+	_, err = api.WaitForNamespace(&container.WaitForNamespaceRequest{
+		NamespaceID:   namespace.ID,
+		Region:        region,
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	containers, err := api.ListContainers(&container.ListContainersRequest{
+		Region:      region,
+		NamespaceID: namespace.ID,
+		Name:        &spec.Name,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	var appContainer *container.Container
+	for _, c := range containers.Containers {
+		if c.Name == spec.Name {
+			appContainer = c
+		}
+	}
+
+	if appContainer == nil {
+		appContainer, err = api.CreateContainer(&container.CreateContainerRequest{
+			Region:               region,
+			NamespaceID:          namespace.ID,
+			Name:                 spec.Name,
+			EnvironmentVariables: &spec.Environment,
+			MinScale:             spec.MinScale,
+			MaxScale:             spec.MaxScale,
+			MemoryLimit:          spec.MemoryLimit,
+			Privacy:              container.ContainerPrivacyPublic,
+			RegistryImage:        &spec.Image,
+			Protocol:             container.ContainerProtocolHTTP1,
+			Port:                 &spec.Port,
+			HTTPOption:           container.ContainerHTTPOptionEnabled,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+
+		_, err = api.DeployContainer(&container.DeployContainerRequest{
+			Region:      region,
+			ContainerID: appContainer.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+		_, _ = interactive.Printf("Container %s created\n", appContainer.ID)
+	} else {
+		_, err = api.UpdateContainer(&container.UpdateContainerRequest{
+			Region:               region,
+			ContainerID:          appContainer.ID,
+			EnvironmentVariables: &spec.Environment,
+			MinScale:             spec.MinScale,
+			MaxScale:             spec.MaxScale,
+			MemoryLimit:          spec.MemoryLimit,
+			Redeploy:             scw.BoolPtr(true),
+			Privacy:              container.ContainerPrivacyPublic,
+			RegistryImage:        &spec.Image,
+			Protocol:             container.ContainerProtocolHTTP1,
+			Port:                 &spec.Port,
+			HTTPOption:           container.ContainerHTTPOptionEnabled,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, "", err
+		}
+		_, _ = interactive.Printf("Container %s updated\n", appContainer.ID)
+	}
+
+	appContainer, err = api.WaitForContainer(&container.WaitForContainerRequest{
+		ContainerID:   appContainer.ID,
+		Region:        region,
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	if appContainer.Status == container.ContainerStatusError {
+		errorMessage := ""
+		if appContainer.ErrorMessage != nil {
+			errorMessage = *appContainer.ErrorMessage
+		}
+		return nil, "", &core.CliError{
+			Err:     fmt.Errorf("container %s failed to deploy", appContainer.ID),
+			Details: errorMessage,
+			Hint:    "Make sure the image exists and listens on the port of the app spec",
+		}
+	}
+
+	return &appDeployResult{
+		Name:        spec.Name,
+		Target:      spec.Target,
+		URL:         "https://" + appContainer.DomainName,
+		NamespaceID: namespace.ID,
+		ContainerID: appContainer.ID,
+	}, appContainer.DomainName, nil
+}
+
+// setAppContainerDomain binds a hostname to a container unless it is already bound.
+func setAppContainerDomain(ctx context.Context, args *appDeployArgs, containerID string, hostname string) error {
+	region, err := args.Zone.Region()
+	if err != nil {
+		return err
+	}
+	api := container.NewAPI(core.ExtractClient(ctx))
+
+	domains, err := api.ListDomains(&container.ListDomainsRequest{
+		Region:      region,
+		ContainerID: containerID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	for _, d := range domains.Domains {
+		if d.Hostname == hostname {
+			return nil
+		}
+	}
+
+	_, err = api.CreateDomain(&container.CreateDomainRequest{
+		Region:      region,
+		Hostname:    hostname,
+		ContainerID: containerID,
+	}, scw.WithContext(ctx))
+	return err
+}
+
+func deployAppInstance(ctx context.Context, spec *appSpec, args *appDeployArgs) (*appDeployResult, string, error) {
+	client := core.ExtractClient(ctx)
+	api := instance.NewAPI(client)
+
+	servers, err := api.ListServers(&instance.ListServersRequest{
+		Zone:    args.Zone,
+		Project: &args.ProjectID,
+		Name:    &spec.Name,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	for _, server := range servers.Servers {
+		if server.Name == spec.Name {
+			return nil, "", &core.CliError{
+				Err:  fmt.Errorf("an instance named %s already exists", spec.Name),
+				Hint: "Instances are only created once, delete it or use another name to deploy the application again",
+			}
+		}
+	}
+
+	cloudInit, err := appCloudInit(spec)
+	if err != nil {
+		return nil, "", err
+	}
+
+	image, err := marketplace.NewAPI(client).GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+		ImageLabel:     appInstanceImageLabel,
+		Zone:           args.Zone,
+		CommercialType: spec.CommercialType,
+		Type:           marketplace.LocalImageTypeInstanceLocal,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	ip, err := api.CreateIP(&instance.CreateIPRequest{
+		Zone:    args.Zone,
+		Project: &args.ProjectID,
+		Tags:    []string{spec.Name},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	server, err := api.CreateServer(&instance.CreateServerRequest{
+		Zone:           args.Zone,
+		Name:           spec.Name,
+		CommercialType: spec.CommercialType,
+		Image:          image.ID,
+		PublicIP:       &ip.IP.ID,
+		Project:        &args.ProjectID,
+		Tags:           []string{spec.Name},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	_, _ = interactive.Printf("Instance %s created\n", server.Server.ID)
+
+	err = api.SetServerUserData(&instance.SetServerUserDataRequest{
+		Zone:     args.Zone,
+		ServerID: server.Server.ID,
+		Key:      "cloud-init",
+		Content:  strings.NewReader(cloudInit),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = api.ServerActionAndWait(&instance.ServerActionAndWaitRequest{
+		ServerID:      server.Server.ID,
+		Zone:          args.Zone,
+		Action:        instance.ServerActionPoweron,
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	result := &appDeployResult{
+		Name:     spec.Name,
+		Target:   spec.Target,
+		ServerID: server.Server.ID,
+	}
+	address := ip.IP.Address.String()
+
+	if spec.LB {
+		appLB, err := deployAppLB(ctx, spec, args, address)
+		if err != nil {
+			return nil, "", err
+		}
+		result.LBID = appLB.ID
+		address = appLB.IP[0].IPAddress
+	}
+
+	result.URL = "http://" + address
+	_, _ = interactive.Println("The application is available once docker has been installed by cloud-init, which can take a few minutes")
+
+	return result, address, nil
+}
+
+// deployAppLB creates a Load Balancer forwarding HTTP traffic to the port 80 of a server.
+func deployAppLB(ctx context.Context, spec *appSpec, args *appDeployArgs, serverIP string) (*lb.LB, error) {
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+
+	appLB, err := api.CreateLB(&lb.ZonedAPICreateLBRequest{
+		Zone:      args.Zone,
+		ProjectID: &args.ProjectID,
+		Name:      spec.Name,
+		Type:      appLBType,
+		Tags:      []string{spec.Name},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	_, _ = interactive.Printf("Load balancer %s created\n", appLB.ID)
+
+	appLB, err = api.WaitForLb(&lb.ZonedAPIWaitForLBRequest{
+		LBID:          appLB.ID,
+		Zone:          args.Zone,
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	backend, err := api.CreateBackend(&lb.ZonedAPICreateBackendRequest{
+		Zone:                 args.Zone,
+		LBID:                 appLB.ID,
+		Name:                 spec.Name,
+		ForwardProtocol:      lb.ProtocolHTTP,
+		ForwardPort:          80,
+		ForwardPortAlgorithm: lb.ForwardPortAlgorithmRoundrobin,
+		StickySessions:       lb.StickySessionsTypeNone,
+		HealthCheck: &lb.HealthCheck{
+			Port:            80,
+			CheckMaxRetries: 3,
+			TCPConfig:       &lb.HealthCheckTCPConfig{},
+		},
+		ServerIP: []string{serverIP},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = api.CreateFrontend(&lb.ZonedAPICreateFrontendRequest{
+		Zone:        args.Zone,
+		LBID:        appLB.ID,
+		Name:        spec.Name,
+		InboundPort: 80,
+		BackendID:   backend.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(appLB.IP) == 0 {
+		return nil, fmt.Errorf("load balancer %s has no IP", appLB.ID)
+	}
+
+	return appLB, nil
+}
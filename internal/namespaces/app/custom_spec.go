@@ -0,0 +1,233 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+type appTarget string
+
+const (
+	appTargetContainer = appTarget("container")
+	appTargetInstance  = appTarget("instance")
+
+	defaultAppPort           = 8080
+	defaultAppCommercialType = "DEV1-S"
+)
+
+// appSpec is the minimal description of an application deployed by app deploy.
+type appSpec struct {
+	Name        string            `json:"name"`
+	Image       string            `json:"image"`
+	Port        uint32            `json:"port"`
+	Environment map[string]string `json:"environment"`
+	Target      appTarget         `json:"target"`
+	Domain      *appSpecDomain    `json:"domain"`
+
+	// Container target only
+	MinScale    *uint32 `json:"min_scale"`
+	MaxScale    *uint32 `json:"max_scale"`
+	MemoryLimit *uint32 `json:"memory_limit"`
+
+	// Instance target only
+	CommercialType string `json:"commercial_type"`
+	LB             bool   `json:"lb"`
+}
+
+// appSpecDomain is the DNS record pointing to the application, name is relative to the zone.
+type appSpecDomain struct {
+	Zone string `json:"zone"`
+	Name string `json:"name"`
+}
+
+// Hostname returns the fully qualified name of the record.
+func (d *appSpecDomain) Hostname() string {
+	if d.Name == "" {
+		return d.Zone
+	}
+	return d.Name + "." + d.Zone
+}
+
+// composeFile is the subset of the compose file format supported by app deploy.
+type composeFile struct {
+	Services map[string]*composeService `json:"services"`
+}
+
+type composeService struct {
+	Image       string        `json:"image"`
+	Build       interface{}   `json:"build"`
+	Ports       []interface{} `json:"ports"`
+	Environment interface{}   `json:"environment"`
+	// Scaleway holds the fields of the app spec that cannot be expressed in a compose file.
+	Scaleway *appSpec `json:"x-scaleway"`
+}
+
+// parseAppSpec parses an app spec, or the given service of a compose file.
+func parseAppSpec(content []byte, service string) (*appSpec, error) {
+	compose := &composeFile{}
+	err := yaml.Unmarshal(content, compose)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse app spec: %w", err)
+	}
+
+	spec := &appSpec{}
+	if len(compose.Services) == 0 {
+		err = yaml.Unmarshal(content, spec)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse app spec: %w", err)
+		}
+	} else {
+		spec, err = appSpecFromCompose(compose, service)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Name == "" {
+		return nil, fmt.Errorf("app spec must have a name")
+	}
+	if spec.Image == "" {
+		return nil, fmt.Errorf("app spec must have an image")
+	}
+	if spec.Port == 0 {
+		spec.Port = defaultAppPort
+	}
+	if spec.Target == "" {
+		spec.Target = appTargetContainer
+	}
+	if spec.Target != appTargetContainer && spec.Target != appTargetInstance {
+		return nil, fmt.Errorf("unknown target %q, must be %s or %s", spec.Target, appTargetContainer, appTargetInstance)
+	}
+	if spec.LB && spec.Target != appTargetInstance {
+		return nil, fmt.Errorf("lb can only be used with the %s target", appTargetInstance)
+	}
+	if spec.Domain != nil && spec.Domain.Zone == "" {
+		return nil, fmt.Errorf("domain must have a zone")
+	}
+	if spec.CommercialType == "" {
+		spec.CommercialType = defaultAppCommercialType
+	}
+
+	return spec, nil
+}
+
+func appSpecFromCompose(compose *composeFile, service string) (*appSpec, error) {
+	if service == "" {
+		if len(compose.Services) > 1 {
+			names := make([]string, 0, len(compose.Services))
+			for name := range compose.Services {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("compose file has several services, choose one with service=%s", strings.Join(names, "|"))
+		}
+		for name := range compose.Services {
+			service = name
+		}
+	}
+
+	composeService, exists := compose.Services[service]
+	if !exists || composeService == nil {
+		return nil, fmt.Errorf("service %s not found in compose file", service)
+	}
+	if composeService.Image == "" {
+		return nil, fmt.Errorf("service %s must have an image, build is not supported", service)
+	}
+
+	spec := composeService.Scaleway
+	if spec == nil {
+		spec = &appSpec{}
+	}
+	if spec.Name == "" {
+		spec.Name = service
+	}
+	spec.Image = composeService.Image
+
+	if len(composeService.Ports) > 0 {
+		port, err := parseComposePort(composeService.Ports[0])
+		if err != nil {
+			return nil, fmt.Errorf("service %s: %w", service, err)
+		}
+		spec.Port = port
+	}
+
+	environment, err := parseComposeEnvironment(composeService.Environment)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", service, err)
+	}
+	if len(environment) > 0 {
+		spec.Environment = environment
+	}
+
+	return spec, nil
+}
+
+// parseComposePort returns the container port of a compose port mapping such as 8080, "80:8080" or "127.0.0.1:80:8080/tcp".
+func parseComposePort(rawPort interface{}) (uint32, error) {
+	port := strings.TrimSuffix(fmt.Sprint(rawPort), "/tcp")
+	if index := strings.LastIndex(port, ":"); index >= 0 {
+		port = port[index+1:]
+	}
+
+	value, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %v", rawPort)
+	}
+
+	return uint32(value), nil
+}
+
+// parseComposeEnvironment accepts both the map and the list syntax of compose environments.
+func parseComposeEnvironment(rawEnvironment interface{}) (map[string]string, error) {
+	environment := map[string]string{}
+
+	switch rawEnvironment := rawEnvironment.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, value := range rawEnvironment {
+			if value == nil {
+				value = ""
+			}
+			environment[key] = fmt.Sprint(value)
+		}
+	case []interface{}:
+		for _, variable := range rawEnvironment {
+			key, value, _ := strings.Cut(fmt.Sprint(variable), "=")
+			environment[key] = value
+		}
+	default:
+		return nil, fmt.Errorf("invalid environment")
+	}
+
+	return environment, nil
+}
+
+// appCloudInit returns the cloud-init configuration installing docker and running the application on port 80.
+func appCloudInit(spec *appSpec) (string, error) {
+	runArgs := []string{"docker", "run", "--detach", "--restart", "always", "--name", spec.Name, "--publish", fmt.Sprintf("80:%d", spec.Port)}
+
+	keys := make([]string, 0, len(spec.Environment))
+	for key := range spec.Environment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		runArgs = append(runArgs, "--env", key+"="+spec.Environment[key])
+	}
+	runArgs = append(runArgs, spec.Image)
+
+	cloudInit, err := yaml.Marshal(map[string]interface{}{
+		"package_update": true,
+		"packages":       []string{"docker.io"},
+		"runcmd":         [][]string{runArgs},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return "#cloud-config\n" + string(cloudInit), nil
+}
@@ -0,0 +1,96 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseAppSpec(t *testing.T) {
+	t.Run("App spec", func(t *testing.T) {
+		spec, err := parseAppSpec([]byte(`
+name: hello
+image: rg.fr-par.scw.cloud/ns/hello:latest
+environment:
+  LOG_LEVEL: info
+domain:
+  zone: example.com
+  name: hello
+`), "")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", spec.Name)
+		assert.Equal(t, uint32(defaultAppPort), spec.Port)
+		assert.Equal(t, appTargetContainer, spec.Target)
+		assert.Equal(t, map[string]string{"LOG_LEVEL": "info"}, spec.Environment)
+		assert.Equal(t, "hello.example.com", spec.Domain.Hostname())
+	})
+
+	t.Run("Compose file", func(t *testing.T) {
+		compose := []byte(`
+services:
+  web:
+    image: nginx:latest
+    ports:
+      - "8000:80"
+    environment:
+      - DEBUG=1
+    x-scaleway:
+      target: instance
+      lb: true
+  db:
+    image: postgres
+`)
+		_, err := parseAppSpec(compose, "")
+		assert.ErrorContains(t, err, "service=db|web")
+
+		spec, err := parseAppSpec(compose, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", spec.Name)
+		assert.Equal(t, "nginx:latest", spec.Image)
+		assert.Equal(t, uint32(80), spec.Port)
+		assert.Equal(t, appTargetInstance, spec.Target)
+		assert.True(t, spec.LB)
+		assert.Equal(t, defaultAppCommercialType, spec.CommercialType)
+		assert.Equal(t, map[string]string{"DEBUG": "1"}, spec.Environment)
+	})
+
+	t.Run("Invalid specs", func(t *testing.T) {
+		_, err := parseAppSpec([]byte(`image: nginx`), "")
+		assert.ErrorContains(t, err, "must have a name")
+
+		_, err = parseAppSpec([]byte("name: web\nimage: nginx\nlb: true"), "")
+		assert.ErrorContains(t, err, "lb can only be used")
+
+		_, err = parseAppSpec([]byte("services:\n  web:\n    build: .\n"), "")
+		assert.ErrorContains(t, err, "build is not supported")
+	})
+}
+
+func Test_parseComposePort(t *testing.T) {
+	for rawPort, expected := range map[interface{}]uint32{
+		float64(8080):           8080,
+		"80:8080":               8080,
+		"127.0.0.1:80:8080/tcp": 8080,
+	} {
+		port, err := parseComposePort(rawPort)
+		require.NoError(t, err)
+		assert.Equal(t, expected, port)
+	}
+
+	_, err := parseComposePort("80:http")
+	assert.Error(t, err)
+}
+
+func Test_appCloudInit(t *testing.T) {
+	cloudInit, err := appCloudInit(&appSpec{
+		Name:        "web",
+		Image:       "nginx:latest",
+		Port:        80,
+		Environment: map[string]string{"B": "2", "A": "1"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, cloudInit, "#cloud-config\n")
+	assert.Contains(t, cloudInit, "- docker.io")
+	assert.Contains(t, cloudInit, "- 80:80\n  - --env\n  - A=1\n  - --env\n  - B=2\n  - nginx:latest")
+}
@@ -11,6 +11,7 @@ func GetCommands() *core.Commands {
 
 	cmds.Merge(core.NewCommands(
 		serverSSHCommand(),
+		serverVNCCommand(),
 		serverWaitCommand(),
 	))
 
@@ -24,6 +25,7 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("apple-silicon", "server", "create").Override(serverCreateBuilder)
 	cmds.MustFind("apple-silicon", "server", "reboot").Override(serverRebootBuilder)
 	cmds.MustFind("apple-silicon", "server", "delete").Override(serverDeleteBuilder)
+	cmds.MustFind("apple-silicon", "server", "reinstall").Override(serverReinstallBuilder)
 
 	cmds.MustFind("apple-silicon", "server-type", "list").Override(serverTypeBuilder)
 
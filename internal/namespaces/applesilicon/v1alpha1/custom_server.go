@@ -23,6 +23,7 @@ const (
 	serverActionCreate = iota
 	serverActionDelete
 	serverActionReboot
+	serverActionReinstall
 )
 
 var (
@@ -50,19 +51,24 @@ func serverRebootBuilder(c *core.Command) *core.Command {
 	return c
 }
 
+func serverReinstallBuilder(c *core.Command) *core.Command {
+	c.WaitFunc = waitForServerFunc(serverActionReinstall)
+	return c
+}
+
 func waitForServerFunc(action int) core.WaitFunc {
 	return func(ctx context.Context, _, respI interface{}) (interface{}, error) {
 		server, err := applesilicon.NewAPI(core.ExtractClient(ctx)).WaitForServer(&applesilicon.WaitForServerRequest{
 			Zone:          respI.(*applesilicon.Server).Zone,
 			ServerID:      respI.(*applesilicon.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 
 		switch action {
 		case serverActionCreate:
 			return server, err
-		case serverActionReboot:
+		case serverActionReboot, serverActionReinstall:
 			return server, err
 		case serverActionDelete:
 			if err != nil {
@@ -12,11 +12,12 @@ import (
 )
 
 type serverSSHConnectRequest struct {
-	Zone     scw.Zone
-	ServerID string
-	Username string
-	Port     uint
-	Command  string
+	Zone         scw.Zone
+	ServerID     string
+	Username     string
+	Port         uint
+	Command      string
+	PrintCommand bool
 }
 
 func serverSSHCommand() *core.Command {
@@ -49,6 +50,7 @@ func serverSSHCommand() *core.Command {
 				Name:  "command",
 				Short: "Command to execute on the remote server",
 			},
+			core.PrintCommandArgSpec(),
 			core.ZoneArgSpec(),
 		},
 		Run: serverSSHRun,
@@ -86,6 +88,9 @@ func serverSSHRun(ctx context.Context, argsI interface{}) (i interface{}, e erro
 	}
 
 	sshCmd := exec.Command("ssh", sshArgs...)
+	if args.PrintCommand {
+		return core.RawResult(core.FormatCommandLine(ctx, sshCmd) + "\n"), nil
+	}
 
 	exitCode, err := core.ExecCmd(ctx, sshCmd)
 	if err != nil {
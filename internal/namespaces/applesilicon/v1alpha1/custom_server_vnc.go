@@ -0,0 +1,110 @@
+package applesilicon
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"runtime"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	applesilicon "github.com/scaleway/scaleway-sdk-go/api/applesilicon/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type serverVNCRequest struct {
+	Zone         scw.Zone
+	ServerID     string
+	PrintCommand bool
+}
+
+func serverVNCCommand() *core.Command {
+	return &core.Command{
+		Short: `Open the VNC remote desktop of a server`,
+		Long: `Get the VNC URL of a server and open it with the VNC client of this machine, Screen Sharing on macOS.
+The URL contains the credentials of the server, it is opened with the application handling vnc:// URLs: xdg-open on Linux, open on macOS and the URL protocol handler on Windows.`,
+		Namespace: "apple-silicon",
+		Resource:  "server",
+		Verb:      "vnc",
+		Groups:    []string{"utility"},
+		ArgsType:  reflect.TypeOf(serverVNCRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      "Server ID to connect to",
+				Required:   true,
+				Positional: true,
+			},
+			core.PrintCommandArgSpec(),
+			core.ZoneArgSpec(),
+		},
+		Run: serverVNCRun,
+		Examples: []*core.Example{
+			{
+				Short: "Open the remote desktop of a server",
+				Raw:   "scw apple-silicon server vnc 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "SSH into a server",
+				Command: "scw apple-silicon server ssh",
+			},
+		},
+	}
+}
+
+func serverVNCRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverVNCRequest)
+
+	asAPI := applesilicon.NewAPI(core.ExtractClient(ctx))
+	server, err := asAPI.GetServer(&applesilicon.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if server.Status != applesilicon.ServerStatusReady {
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("server is not ready"),
+			Details: fmt.Sprintf("Server %s currently in %s", server.Name, server.Status),
+		}
+	}
+	if server.VncURL == "" {
+		return nil, fmt.Errorf("server %s has no VNC URL", server.ID)
+	}
+
+	vncCmd, err := vncOpenCommand(runtime.GOOS, server.VncURL)
+	if err != nil {
+		return nil, err
+	}
+	if args.PrintCommand {
+		return core.RawResult(core.FormatCommandLine(ctx, vncCmd) + "\n"), nil
+	}
+
+	exitCode, err := core.ExecCmd(ctx, vncCmd)
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, &core.CliError{Empty: true, Code: exitCode}
+	}
+
+	return &core.SuccessResult{Empty: true}, nil
+}
+
+// vncOpenCommand returns the command opening a VNC URL with the default VNC client of an OS.
+func vncOpenCommand(goos string, url string) (*exec.Cmd, error) {
+	switch goos {
+	case "linux":
+		return exec.Command("xdg-open", url), nil //nolint:gosec
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url), nil //nolint:gosec
+	case "darwin":
+		return exec.Command("open", url), nil //nolint:gosec
+	default:
+		return nil, fmt.Errorf("unsupported platform %s, open %s with a VNC client", goos, url)
+	}
+}
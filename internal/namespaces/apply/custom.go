@@ -0,0 +1,15 @@
+package apply
+
+import (
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+)
+
+func GetCommands() *core.Commands {
+	human.RegisterMarshalerFunc(applyOperation(""), human.EnumMarshalFunc(applyOperationMarshalSpecs))
+
+	return core.NewCommands(
+		applyCommand(),
+		exportCommand(),
+	)
+}
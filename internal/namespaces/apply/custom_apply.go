@@ -0,0 +1,465 @@
+package apply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"gopkg.in/yaml.v3"
+)
+
+type applyOperation string
+
+const (
+	applyOperationCreate = applyOperation("create")
+	applyOperationUpdate = applyOperation("update")
+	applyOperationNoop   = applyOperation("no-op")
+)
+
+var applyOperationMarshalSpecs = human.EnumMarshalSpecs{
+	applyOperationCreate: &human.EnumMarshalSpec{Attribute: color.FgGreen},
+	applyOperationUpdate: &human.EnumMarshalSpec{Attribute: color.FgYellow},
+	applyOperationNoop:   &human.EnumMarshalSpec{Attribute: color.Faint},
+}
+
+type applyRequest struct {
+	File   string
+	DryRun bool
+	Yes    bool
+}
+
+// manifestResource is a resource of a manifest, the resource of a kind identified by its name in its project and locality.
+type manifestResource struct {
+	Kind      string     `yaml:"kind"`
+	Name      string     `yaml:"name"`
+	ProjectID string     `yaml:"project_id"`
+	Zone      scw.Zone   `yaml:"zone"`
+	Region    scw.Region `yaml:"region"`
+	// Tags are not compared when they are omitted, an empty list removes the tags of the resource
+	Tags []string  `yaml:"tags"`
+	Spec yaml.Node `yaml:"spec"`
+
+	// spec is the Spec decoded with the spec type of the kind
+	spec interface{}
+}
+
+// locality returns the zone or the region of the resource.
+func (r *manifestResource) locality() string {
+	if r.Zone != "" {
+		return r.Zone.String()
+	}
+	return r.Region.String()
+}
+
+// applyChange is the change needed to reconcile a live resource with a resource of the manifest.
+type applyChange struct {
+	Kind      string         `json:"kind"`
+	Name      string         `json:"name"`
+	Locality  string         `json:"locality"`
+	Operation applyOperation `json:"operation"`
+	// ID is empty for the resources created in dry-run
+	ID string `json:"id,omitempty"`
+	// Changes lists the fields updated, as "field: current -> desired"
+	Changes []string `json:"changes,omitempty"`
+
+	resource *manifestResource
+	kind     *resourceKind
+	// changedFields are the desired values of the fields to update
+	changedFields map[string]string
+}
+
+func applyCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Create or update resources from a manifest`,
+		Long: `Reconcile resources with a YAML manifest of one or more documents separated by ---, each describing a resource with its kind, name and spec.
+A document can also be a list of resources, such as the manifests exported with scw export -o yaml.
+Resources are matched by kind, name, project and locality. Missing resources are created, resources whose tags or updatable spec fields differ are updated, the others are left untouched.
+Resources missing from the manifest are never deleted, and fields that cannot be updated are only used to create resources.
+The changes are displayed and must be confirmed before being applied.
+
+Supported kinds and their spec:
+  instance.server        type, image (zone)
+  vpc.private-network    subnets (region)
+  lb                     type, description (zone)
+  rdb.instance           engine, node_type, user_name, password, is_ha_cluster (region)
+
+Updatable spec fields are description for lb and node_type for rdb.instance, which can only be upgraded.
+
+Manifest of a private network and a server:
+
+	kind: vpc.private-network
+	name: app
+	region: fr-par
+	tags: [app]
+	---
+	kind: instance.server
+	name: web
+	zone: fr-par-1
+	tags: [app, web]
+	spec:
+	  type: DEV1-S
+	  image: ubuntu_jammy`,
+		Namespace: "apply",
+		ArgsType:  reflect.TypeOf(applyRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:        "file",
+				Short:       `YAML manifest of the resources`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the changes`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*applyRequest)
+			client := core.ExtractClient(ctx)
+			kinds := resourceKinds()
+
+			resources, err := parseManifest([]byte(args.File), kinds, client)
+			if err != nil {
+				return nil, err
+			}
+			changes, err := planChanges(ctx, client, kinds, resources)
+			if err != nil {
+				return nil, err
+			}
+			if args.DryRun {
+				return changes, nil
+			}
+			if !hasChanges(changes) {
+				return &core.SuccessResult{Message: "Resources are already up to date"}, nil
+			}
+
+			if !args.Yes {
+				confirmed, err := confirmChanges(ctx, changes)
+				if err != nil {
+					return nil, err
+				}
+				if !confirmed {
+					return &core.SuccessResult{Message: "Changes canceled"}, nil
+				}
+			}
+
+			err = applyChanges(ctx, client, changes)
+			if err != nil {
+				return nil, err
+			}
+			return changes, nil
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Kind", FieldName: "Kind"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Locality", FieldName: "Locality"},
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "ID", FieldName: "ID"},
+				{Label: "Changes", FieldName: "Changes"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a manifest",
+				Raw:   "scw apply file=@resources.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a manifest without confirmation",
+				Raw:   "scw apply file=@resources.yaml yes=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Find a resource from its ID",
+				Command: "scw resource get",
+			},
+			{
+				Short:   "Export the resources of a project as a manifest",
+				Command: "scw export",
+			},
+		},
+	}
+}
+
+// parseManifest parses the resources of a manifest, with the default locality of the client for the resources without one.
+// The resources are the documents of the manifest, or the items of the documents which are lists, as exported by scw export.
+func parseManifest(manifest []byte, kinds map[string]*resourceKind, client *scw.Client) ([]*manifestResource, error) {
+	nodes, err := manifestNodes(manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []*manifestResource(nil)
+	identities := map[string]bool{}
+	for i, node := range nodes {
+		resource := &manifestResource{}
+		if err := decodeStrict(node, resource); err != nil {
+			return nil, fmt.Errorf("cannot parse resource %d of the manifest: %w", i+1, err)
+		}
+
+		kind, exists := kinds[resource.Kind]
+		if !exists {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("unknown kind '%s' in resource %d of the manifest", resource.Kind, i+1),
+				Hint: "Supported kinds are: " + strings.Join(kindNames(kinds), ", "),
+				Code: core.ExitCodeUsage,
+			}
+		}
+		if resource.Name == "" {
+			return nil, fmt.Errorf("resource %d of the manifest must have a name", i+1)
+		}
+		if err := setDefaultLocality(resource, kind, client); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", resource.Kind, resource.Name, err)
+		}
+		if resource.ProjectID == "" && client != nil {
+			resource.ProjectID, _ = client.GetDefaultProjectID()
+		}
+		if resource.ProjectID == "" {
+			return nil, fmt.Errorf("%s %s: no project ID found, set a project_id in the manifest or a default project in your configuration", resource.Kind, resource.Name)
+		}
+
+		resource.spec = kind.NewSpec()
+		if !resource.Spec.IsZero() {
+			if err := decodeStrict(&resource.Spec, resource.spec); err != nil {
+				return nil, fmt.Errorf("cannot parse the spec of %s %s: %w", resource.Kind, resource.Name, err)
+			}
+		}
+
+		identity := strings.Join([]string{resource.Kind, resource.ProjectID, resource.locality(), resource.Name}, "/")
+		if identities[identity] {
+			return nil, fmt.Errorf("%s %s is defined twice in %s", resource.Kind, resource.Name, resource.locality())
+		}
+		identities[identity] = true
+		resources = append(resources, resource)
+	}
+
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("the manifest has no resource")
+	}
+	return resources, nil
+}
+
+// manifestNodes returns the nodes of the resources of a manifest, in their order.
+func manifestNodes(manifest []byte) ([]*yaml.Node, error) {
+	nodes := []*yaml.Node(nil)
+	decoder := yaml.NewDecoder(bytes.NewReader(manifest))
+	for i := 1; ; i++ {
+		document := &yaml.Node{}
+		err := decoder.Decode(document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse document %d of the manifest: %w", i, err)
+		}
+
+		root := document
+		if document.Kind == yaml.DocumentNode && len(document.Content) == 1 {
+			root = document.Content[0]
+		}
+		if root.Kind == yaml.SequenceNode {
+			nodes = append(nodes, root.Content...)
+			continue
+		}
+		nodes = append(nodes, root)
+	}
+	return nodes, nil
+}
+
+// setDefaultLocality checks that the resource has a locality of its kind, and sets the default one of the client when it has none.
+func setDefaultLocality(resource *manifestResource, kind *resourceKind, client *scw.Client) error {
+	if len(kind.Zones) > 0 {
+		if resource.Region != "" {
+			return fmt.Errorf("resources of kind %s have a zone, not a region", resource.Kind)
+		}
+		if resource.Zone == "" && client != nil {
+			resource.Zone, _ = client.GetDefaultZone()
+		}
+		if resource.Zone == "" {
+			return fmt.Errorf("no zone found, set a zone in the manifest or a default zone in your configuration")
+		}
+		return nil
+	}
+
+	if resource.Zone != "" {
+		return fmt.Errorf("resources of kind %s have a region, not a zone", resource.Kind)
+	}
+	if resource.Region == "" && client != nil {
+		resource.Region, _ = client.GetDefaultRegion()
+	}
+	if resource.Region == "" {
+		return fmt.Errorf("no region found, set a region in the manifest or a default region in your configuration")
+	}
+	return nil
+}
+
+// decodeStrict decodes a node, failing on the fields unknown by the decoded type.
+func decodeStrict(node *yaml.Node, out interface{}) error {
+	raw, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
+
+// planChanges compares the resources of the manifest with the live resources, in the order of the manifest.
+func planChanges(ctx context.Context, client *scw.Client, kinds map[string]*resourceKind, resources []*manifestResource) ([]*applyChange, error) {
+	changes := make([]*applyChange, 0, len(resources))
+	for _, resource := range resources {
+		kind := kinds[resource.Kind]
+		change := &applyChange{
+			Kind:     resource.Kind,
+			Name:     resource.Name,
+			Locality: resource.locality(),
+			resource: resource,
+			kind:     kind,
+		}
+
+		live, err := kind.Find(ctx, client, resource)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %s %s: %w", resource.Kind, resource.Name, err)
+		}
+		if live == nil {
+			if err := kind.ValidateCreate(resource.spec); err != nil {
+				return nil, fmt.Errorf("cannot create %s %s: %w", resource.Kind, resource.Name, err)
+			}
+			change.Operation = applyOperationCreate
+			changes = append(changes, change)
+			continue
+		}
+
+		change.ID = live.ID
+		change.changedFields = changedFields(live.Fields, desiredFields(kind, resource))
+		change.Operation = applyOperationNoop
+		if len(change.changedFields) > 0 {
+			change.Operation = applyOperationUpdate
+			change.Changes = formatChangedFields(live.Fields, change.changedFields)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// desiredFields returns the updatable fields set by the resource of the manifest.
+func desiredFields(kind *resourceKind, resource *manifestResource) map[string]string {
+	fields := map[string]string{}
+	if resource.Tags != nil {
+		fields["tags"] = formatTags(resource.Tags)
+	}
+	if kind.Fields != nil {
+		for name, value := range kind.Fields(resource.spec) {
+			fields[name] = value
+		}
+	}
+	return fields
+}
+
+// changedFields returns the desired fields whose value differs from the live one.
+func changedFields(live map[string]string, desired map[string]string) map[string]string {
+	changed := map[string]string{}
+	for name, desiredValue := range desired {
+		if live[name] != desiredValue {
+			changed[name] = desiredValue
+		}
+	}
+	return changed
+}
+
+// formatChangedFields formats the changed fields as "field: current -> desired", sorted by name.
+func formatChangedFields(live map[string]string, changed map[string]string) []string {
+	formatted := make([]string, 0, len(changed))
+	for name, desiredValue := range changed {
+		formatted = append(formatted, fmt.Sprintf("%s: %s -> %s", name, live[name], desiredValue))
+	}
+	sort.Strings(formatted)
+	return formatted
+}
+
+// formatTags formats tags regardless of their order, so that they can be compared.
+func formatTags(tags []string) string {
+	sortedTags := append([]string(nil), tags...)
+	sort.Strings(sortedTags)
+	return strings.Join(sortedTags, ",")
+}
+
+func hasChanges(changes []*applyChange) bool {
+	for _, change := range changes {
+		if change.Operation != applyOperationNoop {
+			return true
+		}
+	}
+	return false
+}
+
+// applyChangeDiff formats a change as the lines of a diff, such as "~ instance.server web (fr-par-1)".
+func applyChangeDiff(change *applyChange) string {
+	resource := fmt.Sprintf("%s %s (%s)", change.Kind, change.Name, change.Locality)
+	switch change.Operation {
+	case applyOperationCreate:
+		return "+ " + resource
+	case applyOperationUpdate:
+		lines := []string{"~ " + resource}
+		for _, fieldChange := range change.Changes {
+			lines = append(lines, "    "+fieldChange)
+		}
+		return strings.Join(lines, "\n")
+	default:
+		return "  " + resource
+	}
+}
+
+func confirmChanges(ctx context.Context, changes []*applyChange) (bool, error) {
+	if !interactive.IsInteractive {
+		return false, &core.CliError{
+			Err:  fmt.Errorf("applying changes must be confirmed"),
+			Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+		}
+	}
+
+	for _, change := range changes {
+		_, _ = interactive.Println(applyChangeDiff(change))
+	}
+	return interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Prompt:       "Do you want to apply these changes?",
+		DefaultValue: false,
+		Ctx:          ctx,
+	})
+}
+
+// applyChanges creates and updates the resources in the order of the manifest, and stops at the first error.
+func applyChanges(ctx context.Context, client *scw.Client, changes []*applyChange) error {
+	for _, change := range changes {
+		switch change.Operation {
+		case applyOperationCreate:
+			id, err := change.kind.Create(ctx, client, change.resource)
+			if err != nil {
+				return fmt.Errorf("cannot create %s %s: %w", change.Kind, change.Name, err)
+			}
+			change.ID = id
+		case applyOperationUpdate:
+			err := change.kind.Update(ctx, client, change.resource, change.ID, change.changedFields)
+			if err != nil {
+				return fmt.Errorf("cannot update %s %s: %w", change.Kind, change.Name, err)
+			}
+		}
+	}
+	return nil
+}
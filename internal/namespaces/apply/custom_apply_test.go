@@ -0,0 +1,169 @@
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProjectID = "11111111-1111-1111-1111-111111111111"
+
+func testClient(t *testing.T) *scw.Client {
+	client, err := scw.NewClient(
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+		scw.WithDefaultRegion(scw.RegionFrPar),
+		scw.WithDefaultProjectID(testProjectID),
+	)
+	require.NoError(t, err)
+	return client
+}
+
+func Test_parseManifest(t *testing.T) {
+	client := testClient(t)
+	kinds := resourceKinds()
+
+	t.Run("Documents", func(t *testing.T) {
+		resources, err := parseManifest([]byte(`kind: vpc.private-network
+name: app
+tags: [app]
+spec:
+  subnets: [172.16.0.0/22]
+---
+kind: instance.server
+name: web
+zone: nl-ams-1
+spec:
+  type: DEV1-S
+  image: ubuntu_jammy
+`), kinds, client)
+		require.NoError(t, err)
+		require.Len(t, resources, 2)
+
+		assert.Equal(t, scw.RegionFrPar, resources[0].Region)
+		assert.Equal(t, testProjectID, resources[0].ProjectID)
+		assert.Equal(t, []string{"app"}, resources[0].Tags)
+		assert.Equal(t, &privateNetworkSpec{Subnets: []string{"172.16.0.0/22"}}, resources[0].spec)
+
+		assert.Equal(t, scw.ZoneNlAms1, resources[1].Zone)
+		assert.Nil(t, resources[1].Tags)
+		assert.Equal(t, &instanceServerSpec{Type: "DEV1-S", Image: "ubuntu_jammy"}, resources[1].spec)
+	})
+
+	t.Run("List of resources", func(t *testing.T) {
+		resources, err := parseManifest([]byte(`- kind: vpc.private-network
+  name: app
+  region: nl-ams
+  tags: []
+- kind: lb
+  name: front
+  zone: fr-par-2
+  tags: [app]
+  spec:
+    type: LB-S
+---
+kind: rdb.instance
+name: db
+`), kinds, client)
+		require.NoError(t, err)
+		require.Len(t, resources, 3)
+
+		assert.Equal(t, scw.RegionNlAms, resources[0].Region)
+		assert.Equal(t, []string{}, resources[0].Tags)
+		assert.Equal(t, "lb", resources[1].Kind)
+		assert.Equal(t, &lbSpec{Type: "LB-S"}, resources[1].spec)
+		assert.Equal(t, "db", resources[2].Name)
+	})
+
+	for name, manifest := range map[string]string{
+		"Unknown kind":       "kind: instance.volume\nname: data\n",
+		"Missing name":       "kind: lb\n",
+		"Unknown field":      "kind: lb\nname: front\nspec:\n  size: 3\n",
+		"Zone of a region":   "kind: rdb.instance\nname: db\nzone: fr-par-1\n",
+		"Defined twice":      "kind: lb\nname: front\n---\nkind: lb\nname: front\n",
+		"Empty manifest":     "",
+		"Invalid document":   "kind: [lb]\n",
+		"Unknown root field": "kind: lb\nname: front\ntype: LB-S\n",
+		"Invalid list item":  "- kind: lb\n  name: front\n- name: web\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseManifest([]byte(manifest), kinds, client)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_planChanges(t *testing.T) {
+	client := testClient(t)
+	live := map[string]*liveResource{
+		"up-to-date": {ID: "1", Fields: map[string]string{"tags": "a,b", "description": "front"}},
+		"outdated":   {ID: "2", Fields: map[string]string{"tags": "a", "description": "front"}},
+	}
+	updated := map[string]map[string]string{}
+	kind := lbKind()
+	kind.Find = func(_ context.Context, _ *scw.Client, resource *manifestResource) (*liveResource, error) {
+		return live[resource.Name], nil
+	}
+	kind.Create = func(_ context.Context, _ *scw.Client, resource *manifestResource) (string, error) {
+		return "new-" + resource.Name, nil
+	}
+	kind.Update = func(_ context.Context, _ *scw.Client, _ *manifestResource, id string, changedFields map[string]string) error {
+		updated[id] = changedFields
+		return nil
+	}
+	kinds := map[string]*resourceKind{"lb": kind}
+
+	resources, err := parseManifest([]byte(`kind: lb
+name: up-to-date
+tags: [b, a]
+---
+kind: lb
+name: outdated
+tags: [a, b]
+spec:
+  description: public front
+---
+kind: lb
+name: missing
+spec:
+  type: LB-S
+`), kinds, client)
+	require.NoError(t, err)
+
+	changes, err := planChanges(context.Background(), client, kinds, resources)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	assert.Equal(t, applyOperationNoop, changes[0].Operation)
+	assert.Equal(t, applyOperationUpdate, changes[1].Operation)
+	assert.Equal(t, []string{"description: front -> public front", "tags: a -> a,b"}, changes[1].Changes)
+	assert.Equal(t, applyOperationCreate, changes[2].Operation)
+	assert.Empty(t, changes[2].ID)
+	assert.Equal(t, "~ lb outdated (fr-par-1)\n    description: front -> public front\n    tags: a -> a,b", applyChangeDiff(changes[1]))
+
+	require.NoError(t, applyChanges(context.Background(), client, changes))
+	assert.Equal(t, map[string]map[string]string{"2": {"tags": "a,b", "description": "public front"}}, updated)
+	assert.Equal(t, "new-missing", changes[2].ID)
+
+	t.Run("Missing spec fields of a created resource", func(t *testing.T) {
+		resources, err := parseManifest([]byte("kind: lb\nname: missing\n"), kinds, client)
+		require.NoError(t, err)
+		_, err = planChanges(context.Background(), client, kinds, resources)
+		assert.Error(t, err)
+	})
+}
+
+func Test_findByName(t *testing.T) {
+	index, err := findByName("lb", "front", []string{"front-2", "front"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	index, err = findByName("lb", "front", []string{"front-2"})
+	require.NoError(t, err)
+	assert.Equal(t, -1, index)
+
+	_, err = findByName("lb", "front", []string{"front", "front"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,224 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// exportKindOrder is the order of the kinds in an exported manifest, the resources other resources may depend on come first.
+var exportKindOrder = []string{
+	"vpc.private-network",
+	"instance.server",
+	"lb",
+	"rdb.instance",
+}
+
+type exportRequest struct {
+	ProjectID string
+	Kinds     []string
+}
+
+// exportedResource is a live resource in its manifest representation.
+// The project is not exported so that the manifest can be applied to another project.
+type exportedResource struct {
+	Kind   string     `json:"kind" yaml:"kind"`
+	Name   string     `json:"name" yaml:"name"`
+	Zone   scw.Zone   `json:"zone,omitempty" yaml:"zone,omitempty"`
+	Region scw.Region `json:"region,omitempty" yaml:"region,omitempty"`
+	// Tags are always exported so that applying the manifest removes the tags added since the export
+	Tags []string    `json:"tags" yaml:"tags"`
+	Spec interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
+}
+
+func newExportedResource(kind string, name string, locality string, tags []string, spec interface{}) *exportedResource {
+	resource := &exportedResource{
+		Kind: kind,
+		Name: name,
+		Tags: append([]string{}, tags...),
+		Spec: spec,
+	}
+	if zone := scw.Zone(locality); zone.Exists() {
+		resource.Zone = zone
+	} else {
+		resource.Region = scw.Region(locality)
+	}
+	return resource
+}
+
+func (r *exportedResource) locality() string {
+	if r.Zone != "" {
+		return r.Zone.String()
+	}
+	return r.Region.String()
+}
+
+func exportCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Export the resources of a project as a manifest`,
+		Long: `List the resources of a project in all their localities, in the representation of the manifests of scw apply.
+Use -o yaml to get a manifest that can be applied with scw apply, for instance to duplicate the resources of a project in another one.
+The project of the resources is not exported, they are applied to the default project or the one given with -p.
+
+Exported resources have their kind, name, locality, tags and the spec fields used to create them, except the user name and password of the rdb.instance resources which must be added to the manifest before creating them.
+Resources are exported in the order they must be created: vpc.private-network, instance.server, lb and rdb.instance.`,
+		Namespace: "export",
+		ArgsType:  reflect.TypeOf(exportRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			{
+				Name:  "kinds.{index}",
+				Short: `Kinds of the exported resources, all the supported kinds by default`,
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*exportRequest)
+			client := core.ExtractClient(ctx)
+
+			projectID := args.ProjectID
+			if projectID == "" {
+				projectID, _ = client.GetDefaultProjectID()
+			}
+			if projectID == "" {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("no project ID found"),
+					Hint: "Set the project-id argument or a default project in your configuration",
+					Code: core.ExitCodeUsage,
+				}
+			}
+
+			kinds, err := exportedKinds(resourceKinds(), args.Kinds)
+			if err != nil {
+				return nil, err
+			}
+			return exportResources(ctx, client, kinds, projectID)
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Kind", FieldName: "Kind"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Zone", FieldName: "Zone"},
+				{Label: "Region", FieldName: "Region"},
+				{Label: "Tags", FieldName: "Tags"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Export the resources of a project as a manifest",
+				Raw:   "scw export project-id=11111111-1111-1111-1111-111111111111 -o yaml > resources.yaml",
+			},
+			{
+				Short: "Export the servers and the private networks of the default project",
+				Raw:   "scw export kinds.0=instance.server kinds.1=vpc.private-network -o yaml",
+			},
+			{
+				Short: "Duplicate the resources of a project in the project of the staging profile",
+				Raw:   "scw export project-id=11111111-1111-1111-1111-111111111111 -o yaml > resources.yaml && scw -p staging apply file=@resources.yaml",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create or update resources from a manifest",
+				Command: "scw apply",
+			},
+		},
+	}
+}
+
+// exportedKinds returns the kinds to export by name, all the kinds when names is empty.
+func exportedKinds(kinds map[string]*resourceKind, names []string) (map[string]*resourceKind, error) {
+	if len(names) == 0 {
+		return kinds, nil
+	}
+
+	exported := map[string]*resourceKind{}
+	for _, name := range names {
+		kind, exists := kinds[name]
+		if !exists {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("unknown kind '%s'", name),
+				Hint: "Supported kinds are: " + strings.Join(kindNames(kinds), ", "),
+				Code: core.ExitCodeUsage,
+			}
+		}
+		exported[name] = kind
+	}
+	return exported, nil
+}
+
+// exportResources exports the resources of the kinds in all their localities.
+// The localities are listed in a batch so that the concurrency and rate limit of the batch config are used.
+// The export fails when a locality cannot be listed, as the manifest would miss its resources.
+func exportResources(ctx context.Context, client *scw.Client, kinds map[string]*resourceKind, projectID string) ([]*exportedResource, error) {
+	tasks := []*core.BatchTask(nil)
+	exported := [][]*exportedResource(nil)
+	addListing := func(name string, kind *resourceKind, locality string) {
+		i := len(exported)
+		exported = append(exported, nil)
+		tasks = append(tasks, &core.BatchTask{
+			Name: fmt.Sprintf("%s in %s", name, locality),
+			Run: func(ctx context.Context) error {
+				resources, err := kind.Export(ctx, client, projectID, locality)
+				exported[i] = resources
+				return err
+			},
+		})
+	}
+	for _, name := range kindNames(kinds) {
+		kind := kinds[name]
+		for _, zone := range kind.Zones {
+			addListing(name, kind, zone.String())
+		}
+		for _, region := range kind.Regions {
+			addListing(name, kind, region.String())
+		}
+	}
+
+	err := core.RunBatch(ctx, tasks)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	batchErr := (*core.BatchError)(nil)
+	if errors.As(err, &batchErr) {
+		failures := make([]string, 0, len(batchErr.Errors))
+		for _, taskErr := range batchErr.Errors {
+			failures = append(failures, taskErr.Error())
+		}
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("cannot list the resources of project %s", projectID),
+			Details: strings.Join(failures, "\n"),
+			Hint:    "Export only the kinds of resources the current credentials can read with the kinds argument",
+		}
+	}
+
+	resources := []*exportedResource(nil)
+	for _, localityResources := range exported {
+		resources = append(resources, localityResources...)
+	}
+	sortExportedResources(resources)
+	return resources, nil
+}
+
+// sortExportedResources sorts the resources by kind in the order of creation, then by locality and name.
+func sortExportedResources(resources []*exportedResource) {
+	kindRanks := map[string]int{}
+	for i, kind := range exportKindOrder {
+		kindRanks[kind] = i
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].Kind != resources[j].Kind {
+			return kindRanks[resources[i].Kind] < kindRanks[resources[j].Kind]
+		}
+		if resources[i].locality() != resources[j].locality() {
+			return resources[i].locality() < resources[j].locality()
+		}
+		return resources[i].Name < resources[j].Name
+	})
+}
@@ -0,0 +1,115 @@
+package apply
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// runExportResources exports the resources from a command, as the options of the batch listing the localities come from its context.
+func runExportResources(t *testing.T, client *scw.Client, kinds map[string]*resourceKind) (resources []*exportedResource, err error) {
+	core.Test(&core.TestConfig{
+		Commands: core.NewCommands(&core.Command{
+			Namespace: "test",
+			Resource:  "export",
+			ArgsType:  reflect.TypeOf(args.RawArgs{}),
+			Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+				resources, err = exportResources(ctx, client, kinds, testProjectID)
+				return nil, nil
+			},
+		}),
+		Cmd:             "scw test export",
+		Client:          client,
+		DisableParallel: true,
+		Check:           core.TestCheckExitCode(0),
+	})(t)
+	return resources, err
+}
+
+func Test_exportResources(t *testing.T) {
+	client := testClient(t)
+	kinds := resourceKinds()
+	kinds["lb"].Zones = []scw.Zone{scw.ZoneFrPar1, scw.ZoneFrPar2}
+	kinds["lb"].Export = func(_ context.Context, _ *scw.Client, projectID string, locality string) ([]*exportedResource, error) {
+		assert.Equal(t, testProjectID, projectID)
+		if locality != scw.ZoneFrPar1.String() {
+			return nil, nil
+		}
+		return []*exportedResource{
+			newExportedResource("lb", "front-2", locality, nil, &lbSpec{Type: "LB-S"}),
+			newExportedResource("lb", "front-1", locality, []string{"app"}, &lbSpec{Type: "LB-S", Description: scw.StringPtr("public front")}),
+		}, nil
+	}
+	kinds["vpc.private-network"].Regions = []scw.Region{scw.RegionNlAms}
+	kinds["vpc.private-network"].Export = func(_ context.Context, _ *scw.Client, _ string, locality string) ([]*exportedResource, error) {
+		return []*exportedResource{
+			newExportedResource("vpc.private-network", "app", locality, []string{"app"}, &privateNetworkSpec{Subnets: []string{"172.16.0.0/22"}}),
+		}, nil
+	}
+	exported, err := exportedKinds(kinds, []string{"lb", "vpc.private-network"})
+	require.NoError(t, err)
+
+	resources, err := runExportResources(t, client, exported)
+	require.NoError(t, err)
+	require.Len(t, resources, 3)
+	assert.Equal(t, "app", resources[0].Name)
+	assert.Equal(t, scw.RegionNlAms, resources[0].Region)
+	assert.Equal(t, "front-1", resources[1].Name)
+	assert.Equal(t, scw.ZoneFrPar1, resources[1].Zone)
+	assert.Equal(t, []string{}, resources[2].Tags)
+
+	manifest, err := yaml.Marshal(resources)
+	require.NoError(t, err)
+	assert.Equal(t, `- kind: vpc.private-network
+  name: app
+  region: nl-ams
+  tags:
+    - app
+  spec:
+    subnets:
+        - 172.16.0.0/22
+- kind: lb
+  name: front-1
+  zone: fr-par-1
+  tags:
+    - app
+  spec:
+    type: LB-S
+    description: public front
+- kind: lb
+  name: front-2
+  zone: fr-par-1
+  tags: []
+  spec:
+    type: LB-S
+`, string(manifest))
+
+	manifestResources, err := parseManifest(manifest, kinds, client)
+	require.NoError(t, err)
+	require.Len(t, manifestResources, 3)
+	assert.Equal(t, testProjectID, manifestResources[0].ProjectID)
+	assert.Equal(t, &lbSpec{Type: "LB-S", Description: scw.StringPtr("public front")}, manifestResources[1].spec)
+
+	t.Run("Failed listing", func(t *testing.T) {
+		kinds["lb"].Export = func(_ context.Context, _ *scw.Client, _ string, _ string) ([]*exportedResource, error) {
+			return nil, errors.New("permission denied")
+		}
+		_, err := runExportResources(t, client, map[string]*resourceKind{"lb": kinds["lb"]})
+		cliErr := (*core.CliError)(nil)
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, "lb in fr-par-1: permission denied\nlb in fr-par-2: permission denied", cliErr.Details)
+	})
+
+	t.Run("Unknown kind", func(t *testing.T) {
+		_, err := exportedKinds(kinds, []string{"instance.volume"})
+		assert.Error(t, err)
+	})
+}
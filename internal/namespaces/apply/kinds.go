@@ -0,0 +1,507 @@
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	marketplace "github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// liveResource is a resource matching a resource of the manifest, with the values of its updatable fields.
+type liveResource struct {
+	ID     string
+	Fields map[string]string
+}
+
+// resourceKind finds, creates and updates the resources of a kind of the manifest.
+type resourceKind struct {
+	// Zones are the zones of the resources of a zoned kind, Regions the regions of the resources of a regional kind
+	Zones   []scw.Zone
+	Regions []scw.Region
+	// NewSpec returns a pointer to the spec of the kind, decoded from the spec of the manifest
+	NewSpec func() interface{}
+	// ValidateCreate checks that the spec has the fields needed to create a resource
+	ValidateCreate func(spec interface{}) error
+	// Fields returns the updatable fields set by the spec, tags are handled for all the kinds
+	Fields func(spec interface{}) map[string]string
+	// Find returns the resource with the name of the manifest resource in its project and locality, or nil when there is none
+	Find func(ctx context.Context, client *scw.Client, resource *manifestResource) (*liveResource, error)
+	// Create creates the resource and returns its ID
+	Create func(ctx context.Context, client *scw.Client, resource *manifestResource) (string, error)
+	// Update sets the desired values of the changed fields
+	Update func(ctx context.Context, client *scw.Client, resource *manifestResource, id string, changedFields map[string]string) error
+	// Export lists the resources of a project in a zone or a region, in their manifest representation
+	Export func(ctx context.Context, client *scw.Client, projectID string, locality string) ([]*exportedResource, error)
+}
+
+// resourceKinds returns the kinds of resources supported in manifests, by name.
+func resourceKinds() map[string]*resourceKind {
+	return map[string]*resourceKind{
+		"instance.server":     instanceServerKind(),
+		"vpc.private-network": privateNetworkKind(),
+		"lb":                  lbKind(),
+		"rdb.instance":        rdbInstanceKind(),
+	}
+}
+
+func kindNames(kinds map[string]*resourceKind) []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// findByName returns the index of the only resource named name, or -1 when there is none.
+func findByName(kind string, name string, names []string) (int, error) {
+	index := -1
+	for i := range names {
+		if names[i] != name {
+			continue
+		}
+		if index != -1 {
+			return -1, fmt.Errorf("several resources of kind %s are named %s, rename them to apply the manifest", kind, name)
+		}
+		index = i
+	}
+	return index, nil
+}
+
+// updatedTags returns the desired tags when they changed, nil otherwise.
+func updatedTags(resource *manifestResource, changedFields map[string]string) *[]string {
+	if _, changed := changedFields["tags"]; !changed {
+		return nil
+	}
+	tags := append([]string{}, resource.Tags...)
+	return &tags
+}
+
+type instanceServerSpec struct {
+	Type  string `json:"type" yaml:"type"`
+	Image string `json:"image" yaml:"image"`
+}
+
+func instanceServerKind() *resourceKind {
+	return &resourceKind{
+		Zones:   (*instance.API)(nil).Zones(),
+		NewSpec: func() interface{} { return &instanceServerSpec{} },
+		ValidateCreate: func(specI interface{}) error {
+			spec := specI.(*instanceServerSpec)
+			if spec.Type == "" || spec.Image == "" {
+				return fmt.Errorf("spec must have a type and an image")
+			}
+			return nil
+		},
+		Find: func(ctx context.Context, client *scw.Client, resource *manifestResource) (*liveResource, error) {
+			resp, err := instance.NewAPI(client).ListServers(&instance.ListServersRequest{
+				Zone:    resource.Zone,
+				Name:    &resource.Name,
+				Project: &resource.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(resp.Servers))
+			for _, server := range resp.Servers {
+				names = append(names, server.Name)
+			}
+			index, err := findByName(resource.Kind, resource.Name, names)
+			if err != nil || index == -1 {
+				return nil, err
+			}
+			server := resp.Servers[index]
+			return &liveResource{
+				ID:     server.ID,
+				Fields: map[string]string{"tags": formatTags(server.Tags)},
+			}, nil
+		},
+		Create: func(ctx context.Context, client *scw.Client, resource *manifestResource) (string, error) {
+			spec := resource.spec.(*instanceServerSpec)
+			imageID := spec.Image
+			if !validation.IsUUID(imageID) {
+				localImage, err := marketplace.NewAPI(client).GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+					ImageLabel:     strings.ReplaceAll(spec.Image, "-", "_"),
+					Zone:           resource.Zone,
+					CommercialType: spec.Type,
+					Type:           marketplace.LocalImageTypeInstanceLocal,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return "", err
+				}
+				imageID = localImage.ID
+			}
+
+			resp, err := instance.NewAPI(client).CreateServer(&instance.CreateServerRequest{
+				Zone:           resource.Zone,
+				Name:           resource.Name,
+				CommercialType: strings.ToUpper(spec.Type),
+				Image:          imageID,
+				Project:        &resource.ProjectID,
+				Tags:           resource.Tags,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+			return resp.Server.ID, nil
+		},
+		Update: func(ctx context.Context, client *scw.Client, resource *manifestResource, id string, changedFields map[string]string) error {
+			_, err := instance.NewAPI(client).UpdateServer(&instance.UpdateServerRequest{
+				Zone:     resource.Zone,
+				ServerID: id,
+				Tags:     updatedTags(resource, changedFields),
+			}, scw.WithContext(ctx))
+			return err
+		},
+		Export: func(ctx context.Context, client *scw.Client, projectID string, locality string) ([]*exportedResource, error) {
+			resp, err := instance.NewAPI(client).ListServers(&instance.ListServersRequest{
+				Zone:    scw.Zone(locality),
+				Project: &projectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			resources := make([]*exportedResource, 0, len(resp.Servers))
+			for _, server := range resp.Servers {
+				spec := &instanceServerSpec{Type: server.CommercialType}
+				if server.Image != nil {
+					spec.Image = server.Image.ID
+				}
+				resources = append(resources, newExportedResource("instance.server", server.Name, locality, server.Tags, spec))
+			}
+			return resources, nil
+		},
+	}
+}
+
+type privateNetworkSpec struct {
+	Subnets []string `json:"subnets,omitempty" yaml:"subnets,omitempty"`
+}
+
+func privateNetworkKind() *resourceKind {
+	return &resourceKind{
+		Regions: (*vpc.API)(nil).Regions(),
+		NewSpec: func() interface{} { return &privateNetworkSpec{} },
+		ValidateCreate: func(specI interface{}) error {
+			_, err := parseSubnets(specI.(*privateNetworkSpec).Subnets)
+			return err
+		},
+		Find: func(ctx context.Context, client *scw.Client, resource *manifestResource) (*liveResource, error) {
+			resp, err := vpc.NewAPI(client).ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+				Region:    resource.Region,
+				Name:      &resource.Name,
+				ProjectID: &resource.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(resp.PrivateNetworks))
+			for _, privateNetwork := range resp.PrivateNetworks {
+				names = append(names, privateNetwork.Name)
+			}
+			index, err := findByName(resource.Kind, resource.Name, names)
+			if err != nil || index == -1 {
+				return nil, err
+			}
+			privateNetwork := resp.PrivateNetworks[index]
+			return &liveResource{
+				ID:     privateNetwork.ID,
+				Fields: map[string]string{"tags": formatTags(privateNetwork.Tags)},
+			}, nil
+		},
+		Create: func(ctx context.Context, client *scw.Client, resource *manifestResource) (string, error) {
+			subnets, err := parseSubnets(resource.spec.(*privateNetworkSpec).Subnets)
+			if err != nil {
+				return "", err
+			}
+			privateNetwork, err := vpc.NewAPI(client).CreatePrivateNetwork(&vpc.CreatePrivateNetworkRequest{
+				Region:    resource.Region,
+				Name:      resource.Name,
+				ProjectID: resource.ProjectID,
+				Tags:      resource.Tags,
+				Subnets:   subnets,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+			return privateNetwork.ID, nil
+		},
+		Update: func(ctx context.Context, client *scw.Client, resource *manifestResource, id string, changedFields map[string]string) error {
+			_, err := vpc.NewAPI(client).UpdatePrivateNetwork(&vpc.UpdatePrivateNetworkRequest{
+				Region:           resource.Region,
+				PrivateNetworkID: id,
+				Tags:             updatedTags(resource, changedFields),
+			}, scw.WithContext(ctx))
+			return err
+		},
+		Export: func(ctx context.Context, client *scw.Client, projectID string, locality string) ([]*exportedResource, error) {
+			resp, err := vpc.NewAPI(client).ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+				Region:    scw.Region(locality),
+				ProjectID: &projectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			resources := make([]*exportedResource, 0, len(resp.PrivateNetworks))
+			for _, privateNetwork := range resp.PrivateNetworks {
+				spec := &privateNetworkSpec{}
+				for _, subnet := range privateNetwork.Subnets {
+					spec.Subnets = append(spec.Subnets, subnet.Subnet.String())
+				}
+				resources = append(resources, newExportedResource("vpc.private-network", privateNetwork.Name, locality, privateNetwork.Tags, spec))
+			}
+			return resources, nil
+		},
+	}
+}
+
+func parseSubnets(subnets []string) ([]scw.IPNet, error) {
+	ipNets := make([]scw.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		ipNet := scw.IPNet{}
+		if err := ipNet.UnmarshalJSON([]byte(`"` + subnet + `"`)); err != nil {
+			return nil, fmt.Errorf("invalid subnet %s", subnet)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets, nil
+}
+
+type lbSpec struct {
+	Type        string  `json:"type" yaml:"type"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+func lbKind() *resourceKind {
+	return &resourceKind{
+		Zones:   (*lb.ZonedAPI)(nil).Zones(),
+		NewSpec: func() interface{} { return &lbSpec{} },
+		ValidateCreate: func(specI interface{}) error {
+			if specI.(*lbSpec).Type == "" {
+				return fmt.Errorf("spec must have a type")
+			}
+			return nil
+		},
+		Fields: func(specI interface{}) map[string]string {
+			spec := specI.(*lbSpec)
+			if spec.Description == nil {
+				return nil
+			}
+			return map[string]string{"description": *spec.Description}
+		},
+		Find: func(ctx context.Context, client *scw.Client, resource *manifestResource) (*liveResource, error) {
+			resp, err := lb.NewZonedAPI(client).ListLBs(&lb.ZonedAPIListLBsRequest{
+				Zone:      resource.Zone,
+				Name:      &resource.Name,
+				ProjectID: &resource.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(resp.LBs))
+			for _, loadBalancer := range resp.LBs {
+				names = append(names, loadBalancer.Name)
+			}
+			index, err := findByName(resource.Kind, resource.Name, names)
+			if err != nil || index == -1 {
+				return nil, err
+			}
+			loadBalancer := resp.LBs[index]
+			return &liveResource{
+				ID: loadBalancer.ID,
+				Fields: map[string]string{
+					"tags":        formatTags(loadBalancer.Tags),
+					"description": loadBalancer.Description,
+				},
+			}, nil
+		},
+		Create: func(ctx context.Context, client *scw.Client, resource *manifestResource) (string, error) {
+			spec := resource.spec.(*lbSpec)
+			request := &lb.ZonedAPICreateLBRequest{
+				Zone:      resource.Zone,
+				ProjectID: &resource.ProjectID,
+				Name:      resource.Name,
+				Type:      spec.Type,
+				Tags:      resource.Tags,
+			}
+			if spec.Description != nil {
+				request.Description = *spec.Description
+			}
+			loadBalancer, err := lb.NewZonedAPI(client).CreateLB(request, scw.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+			return loadBalancer.ID, nil
+		},
+		Update: func(ctx context.Context, client *scw.Client, resource *manifestResource, id string, changedFields map[string]string) error {
+			api := lb.NewZonedAPI(client)
+			// UpdateLB replaces the name, the description and the tags, the unchanged ones are kept.
+			loadBalancer, err := api.GetLB(&lb.ZonedAPIGetLBRequest{
+				Zone: resource.Zone,
+				LBID: id,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			request := &lb.ZonedAPIUpdateLBRequest{
+				Zone:                  resource.Zone,
+				LBID:                  id,
+				Name:                  loadBalancer.Name,
+				Description:           loadBalancer.Description,
+				Tags:                  loadBalancer.Tags,
+				SslCompatibilityLevel: loadBalancer.SslCompatibilityLevel,
+			}
+			if description, changed := changedFields["description"]; changed {
+				request.Description = description
+			}
+			if tags := updatedTags(resource, changedFields); tags != nil {
+				request.Tags = *tags
+			}
+			_, err = api.UpdateLB(request, scw.WithContext(ctx))
+			return err
+		},
+		Export: func(ctx context.Context, client *scw.Client, projectID string, locality string) ([]*exportedResource, error) {
+			resp, err := lb.NewZonedAPI(client).ListLBs(&lb.ZonedAPIListLBsRequest{
+				Zone:      scw.Zone(locality),
+				ProjectID: &projectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			resources := make([]*exportedResource, 0, len(resp.LBs))
+			for _, loadBalancer := range resp.LBs {
+				spec := &lbSpec{Type: loadBalancer.Type}
+				if loadBalancer.Description != "" {
+					spec.Description = scw.StringPtr(loadBalancer.Description)
+				}
+				resources = append(resources, newExportedResource("lb", loadBalancer.Name, locality, loadBalancer.Tags, spec))
+			}
+			return resources, nil
+		},
+	}
+}
+
+// rdbInstanceSpec is the spec of a Database Instance, the user name and the password are not exported.
+type rdbInstanceSpec struct {
+	Engine      string `json:"engine" yaml:"engine"`
+	NodeType    string `json:"node_type" yaml:"node_type"`
+	UserName    string `json:"user_name,omitempty" yaml:"user_name,omitempty"`
+	Password    string `json:"password,omitempty" yaml:"password,omitempty"`
+	IsHaCluster bool   `json:"is_ha_cluster" yaml:"is_ha_cluster"`
+}
+
+func rdbInstanceKind() *resourceKind {
+	return &resourceKind{
+		Regions: (*rdb.API)(nil).Regions(),
+		NewSpec: func() interface{} { return &rdbInstanceSpec{} },
+		ValidateCreate: func(specI interface{}) error {
+			spec := specI.(*rdbInstanceSpec)
+			if spec.Engine == "" || spec.NodeType == "" || spec.UserName == "" || spec.Password == "" {
+				return fmt.Errorf("spec must have an engine, a node_type, a user_name and a password")
+			}
+			return nil
+		},
+		Fields: func(specI interface{}) map[string]string {
+			spec := specI.(*rdbInstanceSpec)
+			if spec.NodeType == "" {
+				return nil
+			}
+			return map[string]string{"node_type": strings.ToLower(spec.NodeType)}
+		},
+		Find: func(ctx context.Context, client *scw.Client, resource *manifestResource) (*liveResource, error) {
+			resp, err := rdb.NewAPI(client).ListInstances(&rdb.ListInstancesRequest{
+				Region:    resource.Region,
+				Name:      &resource.Name,
+				ProjectID: &resource.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(resp.Instances))
+			for _, rdbInstance := range resp.Instances {
+				names = append(names, rdbInstance.Name)
+			}
+			index, err := findByName(resource.Kind, resource.Name, names)
+			if err != nil || index == -1 {
+				return nil, err
+			}
+			rdbInstance := resp.Instances[index]
+			return &liveResource{
+				ID: rdbInstance.ID,
+				Fields: map[string]string{
+					"tags":      formatTags(rdbInstance.Tags),
+					"node_type": strings.ToLower(rdbInstance.NodeType),
+				},
+			}, nil
+		},
+		Create: func(ctx context.Context, client *scw.Client, resource *manifestResource) (string, error) {
+			spec := resource.spec.(*rdbInstanceSpec)
+			rdbInstance, err := rdb.NewAPI(client).CreateInstance(&rdb.CreateInstanceRequest{
+				Region:      resource.Region,
+				ProjectID:   &resource.ProjectID,
+				Name:        resource.Name,
+				Engine:      spec.Engine,
+				UserName:    spec.UserName,
+				Password:    spec.Password,
+				NodeType:    spec.NodeType,
+				IsHaCluster: spec.IsHaCluster,
+				Tags:        resource.Tags,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return "", err
+			}
+			return rdbInstance.ID, nil
+		},
+		Update: func(ctx context.Context, client *scw.Client, resource *manifestResource, id string, changedFields map[string]string) error {
+			api := rdb.NewAPI(client)
+			if tags := updatedTags(resource, changedFields); tags != nil {
+				_, err := api.UpdateInstance(&rdb.UpdateInstanceRequest{
+					Region:     resource.Region,
+					InstanceID: id,
+					Tags:       tags,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+			}
+			if nodeType, changed := changedFields["node_type"]; changed {
+				_, err := api.UpgradeInstance(&rdb.UpgradeInstanceRequest{
+					Region:     resource.Region,
+					InstanceID: id,
+					NodeType:   &nodeType,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Export: func(ctx context.Context, client *scw.Client, projectID string, locality string) ([]*exportedResource, error) {
+			resp, err := rdb.NewAPI(client).ListInstances(&rdb.ListInstancesRequest{
+				Region:    scw.Region(locality),
+				ProjectID: &projectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			resources := make([]*exportedResource, 0, len(resp.Instances))
+			for _, rdbInstance := range resp.Instances {
+				spec := &rdbInstanceSpec{
+					Engine:      rdbInstance.Engine,
+					NodeType:    rdbInstance.NodeType,
+					IsHaCluster: rdbInstance.IsHaCluster,
+				}
+				resources = append(resources, newExportedResource("rdb.instance", rdbInstance.Name, locality, rdbInstance.Tags, spec))
+			}
+			return resources, nil
+		},
+	}
+}
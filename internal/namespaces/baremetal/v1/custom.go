@@ -11,6 +11,12 @@ func GetCommands() *core.Commands {
 
 	cmds.Merge(core.NewCommands(
 		serverWaitCommand(),
+		serverReinstallCommand(),
+		serverRescueEnableCommand(),
+		serverRescueDisableCommand(),
+		serverKVMCommand(),
+		partitioningSchemaCommand(),
+		partitioningSchemaBuildCommand(),
 	))
 
 	human.RegisterMarshalerFunc(baremetal.ServerPingStatus(""), human.EnumMarshalFunc(serverPingStatusMarshalSpecs))
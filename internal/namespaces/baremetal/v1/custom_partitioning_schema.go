@@ -0,0 +1,498 @@
+package baremetal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	partitioningUEFISize = scw.Size(512 * 1024 * 1024)
+
+	partitionLabelUEFI = "uefi"
+	partitionLabelSwap = "swap"
+	partitionLabelBoot = "boot"
+	partitionLabelRoot = "root"
+	partitionLabelData = "data"
+
+	raidLevel1 = "raid_level_1"
+)
+
+// partitioningSchema is the partitioning of the disks of a server given to its installation.
+type partitioningSchema struct {
+	Disks       []*partitioningSchemaDisk       `json:"disks"`
+	Raids       []*partitioningSchemaRaid       `json:"raids"`
+	Filesystems []*partitioningSchemaFilesystem `json:"filesystems"`
+}
+
+type partitioningSchemaDisk struct {
+	Device     string                         `json:"device"`
+	Partitions []*partitioningSchemaPartition `json:"partitions"`
+}
+
+type partitioningSchemaPartition struct {
+	Label                string   `json:"label"`
+	Number               uint32   `json:"number"`
+	Size                 scw.Size `json:"size"`
+	UseAllAvailableSpace bool     `json:"use_all_available_space"`
+}
+
+type partitioningSchemaRaid struct {
+	Name    string   `json:"name"`
+	Level   string   `json:"level"`
+	Devices []string `json:"devices"`
+}
+
+type partitioningSchemaFilesystem struct {
+	Device     string `json:"device"`
+	Format     string `json:"format"`
+	Mountpoint string `json:"mountpoint"`
+}
+
+// partitioningDisk is a disk of an offer, with the device name it gets on the server.
+type partitioningDisk struct {
+	Device   string
+	Capacity scw.Size
+}
+
+// partitioningMount is a filesystem to create, parsed from <mountpoint>:<size>[:<format>].
+type partitioningMount struct {
+	Mountpoint string
+	// Size is 0 when the filesystem uses the remaining space of the disks
+	Size   scw.Size
+	Format string
+}
+
+type partitioningSchemaBuildRequest struct {
+	Zone     scw.Zone
+	OfferID  string
+	ServerID string
+	Disks    []string
+	Raid1    bool
+	SwapSize scw.Size
+	Mounts   []string
+}
+
+func partitioningSchemaCommand() *core.Command {
+	return &core.Command{
+		Short: `Partitioning schema management commands`,
+		Long: `A partitioning schema describes the partitions, the RAID arrays and the filesystems created on the disks of a server during its installation.
+It is given to scw baremetal server install with partitioning-schema=@schema.json.`,
+		Namespace: "baremetal",
+		Resource:  "partitioning-schema",
+	}
+}
+
+func partitioningSchemaBuildCommand() *core.Command {
+	return &core.Command{
+		Short: `Build a partitioning schema`,
+		Long: `Build the partitioning schema of a server from its disks and the filesystems to create, and validate it against the disks of its offer.
+Each disk gets an UEFI partition, a swap partition and a partition per filesystem. With raid1=true, the partitions are mirrored on all the disks with RAID 1.
+A filesystem is given as <mountpoint>:<size>[:<format>], where size is the size of its partition or rest for the remaining space of the disks, and format is ext4 (default) or xfs.`,
+		Namespace: "baremetal",
+		Resource:  "partitioning-schema",
+		Verb:      "build",
+		ArgsType:  reflect.TypeOf(partitioningSchemaBuildRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "offer-id",
+				Short:      `ID of the offer whose disks are partitioned`,
+				OneOfGroup: "offer",
+			},
+			{
+				Name:       "server-id",
+				Short:      `ID of the server whose disks are partitioned`,
+				OneOfGroup: "offer",
+			},
+			{
+				Name:  "disks.{index}",
+				Short: `Devices of the disks to partition, such as /dev/nvme0n1 or /dev/sda, default to the first disk or to all the disks with raid1`,
+			},
+			{
+				Name:  "raid1",
+				Short: `Mirror the partitions on all the disks with RAID 1`,
+			},
+			{
+				Name:    "swap-size",
+				Short:   `Size of the swap partition, 0GB to disable it`,
+				Default: core.DefaultValueSetter("4GB"),
+			},
+			{
+				Name:  "mounts.{index}",
+				Short: `Filesystems to create as <mountpoint>:<size>[:<format>], default to /:rest`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2),
+		},
+		Run: partitioningSchemaBuildRun,
+		Examples: []*core.Example{
+			{
+				Short: "Build the partitioning schema of a server with its root filesystem on the first disk",
+				Raw:   "scw baremetal partitioning-schema build server-id=11111111-1111-1111-1111-111111111111 -o json > schema.json",
+			},
+			{
+				Short: "Build a partitioning schema mirroring a 100GB root filesystem and a data filesystem on two disks",
+				Raw:   "scw baremetal partitioning-schema build offer-id=11111111-1111-1111-1111-111111111111 raid1=true mounts.0=/:100GB mounts.1=/data:rest:xfs -o json > schema.json",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Install an OS on a server with a partitioning schema",
+				Command: "scw baremetal server install 11111111-1111-1111-1111-111111111111 os-id=11111111-1111-1111-1111-111111111111 partitioning-schema=@schema.json",
+			},
+			{
+				Short:   "Get the disks of an offer",
+				Command: "scw baremetal offer get",
+			},
+		},
+	}
+}
+
+func partitioningSchemaBuildRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*partitioningSchemaBuildRequest)
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+
+	offerID := args.OfferID
+	if args.ServerID != "" {
+		server, err := api.GetServer(&baremetal.GetServerRequest{
+			Zone:     args.Zone,
+			ServerID: args.ServerID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		offerID = server.OfferID
+	}
+	if offerID == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("the disks to partition are unknown"),
+			Hint: "Give the server to install with server-id=<server-id> or its offer with offer-id=<offer-id>",
+		}
+	}
+
+	offer, err := api.GetOffer(&baremetal.GetOfferRequest{
+		Zone:    args.Zone,
+		OfferID: offerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := []*partitioningMount(nil)
+	for _, mount := range args.Mounts {
+		m, err := parsePartitioningMount(mount)
+		if err != nil {
+			return nil, err
+		}
+		mounts = append(mounts, m)
+	}
+
+	return buildPartitioningSchema(offerDisks(offer), args.Disks, args.Raid1, args.SwapSize, mounts)
+}
+
+// offerDisks returns the disks of an offer with their device name, NVMe disks are named /dev/nvme<n>n1
+// and the other ones /dev/sd<letter>, in the order of the offer.
+func offerDisks(offer *baremetal.Offer) []*partitioningDisk {
+	disks := []*partitioningDisk(nil)
+	nvmeDisks, sdDisks := 0, 0
+	for _, disk := range offer.Disks {
+		device := ""
+		if strings.EqualFold(disk.Type, "nvme") {
+			device = fmt.Sprintf("/dev/nvme%dn1", nvmeDisks)
+			nvmeDisks++
+		} else {
+			device = fmt.Sprintf("/dev/sd%c", 'a'+sdDisks)
+			sdDisks++
+		}
+		disks = append(disks, &partitioningDisk{
+			Device:   device,
+			Capacity: disk.Capacity,
+		})
+	}
+	return disks
+}
+
+// parsePartitioningMount parses a filesystem given as <mountpoint>:<size>[:<format>].
+func parsePartitioningMount(value string) (*partitioningMount, error) {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("invalid filesystem %s", value),
+			Hint: "Give the filesystems as <mountpoint>:<size>[:<format>], for example /:50GB or /data:rest:xfs",
+		}
+	}
+
+	mount := &partitioningMount{
+		Mountpoint: parts[0],
+		Format:     "ext4",
+	}
+	if !path.IsAbs(mount.Mountpoint) || path.Clean(mount.Mountpoint) != mount.Mountpoint {
+		return nil, fmt.Errorf("invalid mountpoint %s of filesystem %s, it must be an absolute path", mount.Mountpoint, value)
+	}
+	if parts[1] != "rest" {
+		size, err := humanize.ParseBytes(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %s of filesystem %s: %w", parts[1], value, err)
+		}
+		if size == 0 {
+			return nil, fmt.Errorf("invalid size %s of filesystem %s, use rest for the remaining space", parts[1], value)
+		}
+		mount.Size = scw.Size(size)
+	}
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "ext4", "xfs":
+			mount.Format = parts[2]
+		default:
+			return nil, fmt.Errorf("unsupported format %s of filesystem %s, use ext4 or xfs", parts[2], value)
+		}
+	}
+	return mount, nil
+}
+
+// buildPartitioningSchema builds the partitioning of disks among the disks of an offer and checks that it fits on them.
+func buildPartitioningSchema(offerDisks []*partitioningDisk, devices []string, raid1 bool, swapSize scw.Size, mounts []*partitioningMount) (*partitioningSchema, error) {
+	if len(offerDisks) == 0 {
+		return nil, fmt.Errorf("the offer has no disk")
+	}
+	if len(mounts) == 0 {
+		mounts = []*partitioningMount{{Mountpoint: "/", Format: "ext4"}}
+	}
+
+	disks, err := selectPartitioningDisks(offerDisks, devices, raid1)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePartitioningMounts(mounts); err != nil {
+		return nil, err
+	}
+
+	// The partitions are the same on all the disks, they must fit on the smallest one.
+	requiredSize := partitioningUEFISize + swapSize
+	for _, mount := range mounts {
+		requiredSize += mount.Size
+	}
+	for _, disk := range disks {
+		if requiredSize > disk.Capacity {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("the partitions do not fit on disk %s", disk.Device),
+				Details: fmt.Sprintf("the partitions need %s and the disk has %s", humanize.IBytes(uint64(requiredSize)), humanize.IBytes(uint64(disk.Capacity))),
+			}
+		}
+	}
+
+	schema := &partitioningSchema{}
+	for _, disk := range disks {
+		schemaDisk := &partitioningSchemaDisk{Device: disk.Device}
+		addPartition := func(label string, size scw.Size) {
+			schemaDisk.Partitions = append(schemaDisk.Partitions, &partitioningSchemaPartition{
+				Label:                label,
+				Number:               uint32(len(schemaDisk.Partitions) + 1),
+				Size:                 size,
+				UseAllAvailableSpace: size == 0,
+			})
+		}
+
+		addPartition(partitionLabelUEFI, partitioningUEFISize)
+		if swapSize > 0 {
+			addPartition(partitionLabelSwap, swapSize)
+		}
+		for _, mount := range mounts {
+			addPartition(partitioningMountLabel(mount.Mountpoint), mount.Size)
+		}
+		schema.Disks = append(schema.Disks, schemaDisk)
+	}
+
+	// The UEFI partition of the first disk is the one the server boots on.
+	schema.Filesystems = append(schema.Filesystems, &partitioningSchemaFilesystem{
+		Device:     partitionDevice(disks[0].Device, 1),
+		Format:     "fat32",
+		Mountpoint: "/boot/efi",
+	})
+
+	firstMountPartition := uint32(2)
+	if swapSize > 0 {
+		firstMountPartition = 3
+		if raid1 {
+			schema.Raids = append(schema.Raids, newPartitioningRaid(len(schema.Raids), disks, 2))
+		}
+	}
+	for i, mount := range mounts {
+		partition := firstMountPartition + uint32(i)
+		device := partitionDevice(disks[0].Device, partition)
+		if raid1 {
+			raid := newPartitioningRaid(len(schema.Raids), disks, partition)
+			schema.Raids = append(schema.Raids, raid)
+			device = raid.Name
+		}
+		schema.Filesystems = append(schema.Filesystems, &partitioningSchemaFilesystem{
+			Device:     device,
+			Format:     mount.Format,
+			Mountpoint: mount.Mountpoint,
+		})
+	}
+
+	return schema, nil
+}
+
+// selectPartitioningDisks returns the disks to partition, checking that they belong to the offer.
+func selectPartitioningDisks(offerDisks []*partitioningDisk, devices []string, raid1 bool) ([]*partitioningDisk, error) {
+	if len(devices) == 0 {
+		if raid1 {
+			devices = []string{}
+			for _, disk := range offerDisks {
+				devices = append(devices, disk.Device)
+			}
+		} else {
+			devices = []string{offerDisks[0].Device}
+		}
+	}
+
+	availableDevices := []string(nil)
+	disksByDevice := map[string]*partitioningDisk{}
+	for _, disk := range offerDisks {
+		availableDevices = append(availableDevices, disk.Device)
+		disksByDevice[disk.Device] = disk
+	}
+
+	disks := []*partitioningDisk(nil)
+	selected := map[string]bool{}
+	for _, device := range devices {
+		disk, exists := disksByDevice[device]
+		if !exists {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("the offer has no disk %s", device),
+				Hint: "The disks of the offer are " + strings.Join(availableDevices, ", "),
+			}
+		}
+		if selected[device] {
+			return nil, fmt.Errorf("disk %s is given twice", device)
+		}
+		selected[device] = true
+		disks = append(disks, disk)
+	}
+
+	if raid1 && len(disks) < 2 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("RAID 1 needs at least 2 disks"),
+			Hint: "The disks of the offer are " + strings.Join(availableDevices, ", "),
+		}
+	}
+	if !raid1 && len(disks) > 1 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("several disks can only be partitioned with RAID 1"),
+			Hint: "Use raid1=true to mirror the partitions on the disks, or give a single disk",
+		}
+	}
+	return disks, nil
+}
+
+func validatePartitioningMounts(mounts []*partitioningMount) error {
+	hasRoot := false
+	mountpoints := map[string]bool{}
+	for i, mount := range mounts {
+		if mountpoints[mount.Mountpoint] {
+			return fmt.Errorf("mountpoint %s is given twice", mount.Mountpoint)
+		}
+		mountpoints[mount.Mountpoint] = true
+
+		switch mount.Mountpoint {
+		case "/":
+			hasRoot = true
+		case "/boot/efi":
+			return fmt.Errorf("mountpoint /boot/efi is reserved to the UEFI partition")
+		}
+		if mount.Size == 0 && i != len(mounts)-1 {
+			return fmt.Errorf("only the last filesystem can use the remaining space, %s is not the last one", mount.Mountpoint)
+		}
+	}
+	if !hasRoot {
+		return &core.CliError{
+			Err:  fmt.Errorf("no filesystem is mounted on /"),
+			Hint: "Add a root filesystem, for example mounts.0=/:rest",
+		}
+	}
+	return nil
+}
+
+func partitioningMountLabel(mountpoint string) string {
+	switch mountpoint {
+	case "/":
+		return partitionLabelRoot
+	case "/boot":
+		return partitionLabelBoot
+	default:
+		return partitionLabelData
+	}
+}
+
+// partitionDevice returns the device of a partition of a disk: /dev/nvme0n1p1 or /dev/sda1.
+func partitionDevice(disk string, number uint32) string {
+	if strings.HasPrefix(disk, "/dev/nvme") {
+		return fmt.Sprintf("%sp%d", disk, number)
+	}
+	return fmt.Sprintf("%s%d", disk, number)
+}
+
+// newPartitioningRaid returns the RAID 1 array /dev/md<index> of a partition of all the disks.
+func newPartitioningRaid(index int, disks []*partitioningDisk, partition uint32) *partitioningSchemaRaid {
+	raid := &partitioningSchemaRaid{
+		Name:  fmt.Sprintf("/dev/md%d", index),
+		Level: raidLevel1,
+	}
+	for _, disk := range disks {
+		raid.Devices = append(raid.Devices, partitionDevice(disk.Device, partition))
+	}
+	return raid
+}
+
+// parsePartitioningSchema parses the JSON partitioning schema given to an installation.
+func parsePartitioningSchema(content string) (*partitioningSchema, error) {
+	schema := &partitioningSchema{}
+	if err := json.Unmarshal([]byte(content), schema); err != nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("invalid partitioning schema: %w", err),
+			Hint: "Build a partitioning schema with: scw baremetal partitioning-schema build -o json",
+		}
+	}
+	if len(schema.Disks) == 0 {
+		return nil, fmt.Errorf("invalid partitioning schema: no disk is partitioned")
+	}
+	return schema, nil
+}
+
+// installServerWithPartitioningSchema installs a server with a custom partitioning, which is not part of the install request of the SDK.
+func installServerWithPartitioningSchema(ctx context.Context, request *baremetal.InstallServerRequest, schema *partitioningSchema) (*baremetal.Server, error) {
+	client := core.ExtractClient(ctx)
+	if request.Zone == "" {
+		request.Zone, _ = client.GetDefaultZone()
+	}
+
+	scwReq := &scw.ScalewayRequest{
+		Method: "POST",
+		Path:   "/baremetal/v1/zones/" + request.Zone.String() + "/servers/" + request.ServerID + "/install",
+	}
+	err := scwReq.SetBody(struct {
+		*baremetal.InstallServerRequest
+		PartitioningSchema *partitioningSchema `json:"partitioning_schema"`
+	}{
+		InstallServerRequest: request,
+		PartitioningSchema:   schema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	server := &baremetal.Server{}
+	if err := client.Do(scwReq, server, scw.WithContext(ctx)); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
@@ -0,0 +1,97 @@
+package baremetal
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_offerDisks(t *testing.T) {
+	disks := offerDisks(&baremetal.Offer{
+		Disks: []*baremetal.Disk{
+			{Type: "NVMe", Capacity: 1 * scw.TB},
+			{Type: "NVMe", Capacity: 1 * scw.TB},
+			{Type: "HDD", Capacity: 4 * scw.TB},
+		},
+	})
+	require.Len(t, disks, 3)
+	assert.Equal(t, "/dev/nvme0n1", disks[0].Device)
+	assert.Equal(t, "/dev/nvme1n1", disks[1].Device)
+	assert.Equal(t, "/dev/sda", disks[2].Device)
+}
+
+func Test_parsePartitioningMount(t *testing.T) {
+	mount, err := parsePartitioningMount("/:50GB")
+	require.NoError(t, err)
+	assert.Equal(t, &partitioningMount{Mountpoint: "/", Size: 50 * scw.GB, Format: "ext4"}, mount)
+
+	mount, err = parsePartitioningMount("/data:rest:xfs")
+	require.NoError(t, err)
+	assert.Equal(t, &partitioningMount{Mountpoint: "/data", Format: "xfs"}, mount)
+
+	for _, value := range []string{"/", "data:10GB", "/data:0GB", "/data:10GB:ntfs", "/data:ten"} {
+		_, err := parsePartitioningMount(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func Test_buildPartitioningSchema(t *testing.T) {
+	disks := []*partitioningDisk{
+		{Device: "/dev/nvme0n1", Capacity: 500 * scw.GB},
+		{Device: "/dev/nvme1n1", Capacity: 500 * scw.GB},
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		schema, err := buildPartitioningSchema(disks, nil, false, 4*scw.GB, nil)
+		require.NoError(t, err)
+		require.Len(t, schema.Disks, 1)
+		assert.Equal(t, "/dev/nvme0n1", schema.Disks[0].Device)
+		assert.Equal(t, []*partitioningSchemaPartition{
+			{Label: partitionLabelUEFI, Number: 1, Size: partitioningUEFISize},
+			{Label: partitionLabelSwap, Number: 2, Size: 4 * scw.GB},
+			{Label: partitionLabelRoot, Number: 3, UseAllAvailableSpace: true},
+		}, schema.Disks[0].Partitions)
+		assert.Empty(t, schema.Raids)
+		assert.Equal(t, []*partitioningSchemaFilesystem{
+			{Device: "/dev/nvme0n1p1", Format: "fat32", Mountpoint: "/boot/efi"},
+			{Device: "/dev/nvme0n1p3", Format: "ext4", Mountpoint: "/"},
+		}, schema.Filesystems)
+	})
+
+	t.Run("RAID 1", func(t *testing.T) {
+		schema, err := buildPartitioningSchema(disks, nil, true, 0, []*partitioningMount{
+			{Mountpoint: "/", Size: 100 * scw.GB, Format: "ext4"},
+			{Mountpoint: "/data", Format: "xfs"},
+		})
+		require.NoError(t, err)
+		require.Len(t, schema.Disks, 2)
+		assert.Len(t, schema.Disks[1].Partitions, 3)
+		assert.Equal(t, []*partitioningSchemaRaid{
+			{Name: "/dev/md0", Level: raidLevel1, Devices: []string{"/dev/nvme0n1p2", "/dev/nvme1n1p2"}},
+			{Name: "/dev/md1", Level: raidLevel1, Devices: []string{"/dev/nvme0n1p3", "/dev/nvme1n1p3"}},
+		}, schema.Raids)
+		assert.Equal(t, []*partitioningSchemaFilesystem{
+			{Device: "/dev/nvme0n1p1", Format: "fat32", Mountpoint: "/boot/efi"},
+			{Device: "/dev/md0", Format: "ext4", Mountpoint: "/"},
+			{Device: "/dev/md1", Format: "xfs", Mountpoint: "/data"},
+		}, schema.Filesystems)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := buildPartitioningSchema(disks, []string{"/dev/sda"}, false, 0, nil)
+		assert.Error(t, err, "unknown disk")
+		_, err = buildPartitioningSchema(disks, []string{"/dev/nvme0n1", "/dev/nvme1n1"}, false, 0, nil)
+		assert.Error(t, err, "several disks without RAID")
+		_, err = buildPartitioningSchema(disks[:1], nil, true, 0, nil)
+		assert.Error(t, err, "RAID 1 on a single disk")
+		_, err = buildPartitioningSchema(disks, nil, false, 0, []*partitioningMount{{Mountpoint: "/data", Format: "ext4"}})
+		assert.Error(t, err, "no root filesystem")
+		_, err = buildPartitioningSchema(disks, nil, false, 0, []*partitioningMount{{Mountpoint: "/", Format: "ext4"}, {Mountpoint: "/data", Size: scw.GB, Format: "ext4"}})
+		assert.Error(t, err, "remaining space before the last filesystem")
+		_, err = buildPartitioningSchema(disks, nil, false, 0, []*partitioningMount{{Mountpoint: "/", Size: scw.TB, Format: "ext4"}})
+		assert.Error(t, err, "partitions larger than the disk")
+	})
+}
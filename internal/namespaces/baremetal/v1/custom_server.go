@@ -110,7 +110,7 @@ func serverStartBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.StartServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -125,7 +125,7 @@ func serverStopBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.StopServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -142,7 +142,7 @@ func serverRebootBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetal.RebootServerRequest).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
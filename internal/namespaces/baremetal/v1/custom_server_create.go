@@ -100,7 +100,7 @@ func serverCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForServer(&baremetal.WaitForServerRequest{
 			Zone:          argsI.(*baremetalCreateServerRequestCustom).Zone,
 			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -13,7 +13,8 @@ import (
 func serverInstallBuilder(c *core.Command) *core.Command {
 	type baremetalInstallServerRequestCustom struct {
 		baremetal.InstallServerRequest
-		AllSSHKeys *bool
+		AllSSHKeys         *bool
+		PartitioningSchema string
 	}
 
 	c.ArgsType = reflect.TypeOf(baremetalInstallServerRequestCustom{})
@@ -24,6 +25,17 @@ func serverInstallBuilder(c *core.Command) *core.Command {
 		OneOfGroup: "ssh",
 	})
 
+	c.ArgSpecs.AddBefore("zone", &core.ArgSpec{
+		Name:        "partitioning-schema",
+		Short:       "Partitioning schema of the disks in JSON, built with scw baremetal partitioning-schema build",
+		CanLoadFile: true,
+	})
+
+	c.Examples = append(c.Examples, &core.Example{
+		Short: "Install an OS on a server with a custom partitioning",
+		Raw:   "scw baremetal server install 11111111-1111-1111-1111-111111111111 os-id=11111111-1111-1111-1111-111111111111 all-ssh-keys=true partitioning-schema=@schema.json",
+	})
+
 	c.ArgSpecs.GetByName("ssh-key-ids.{index}").OneOfGroup = "ssh"
 	c.ArgSpecs.GetByName("ssh-key-ids.{index}").Short = "SSH key IDs authorized on the server (cannot be used with all-ssh-keys)"
 
@@ -48,17 +60,19 @@ func serverInstallBuilder(c *core.Command) *core.Command {
 			tmpRequest.SSHKeyIDs = keyIDs
 		}
 
+		if tmpRequest.PartitioningSchema != "" {
+			schema, err := parsePartitioningSchema(tmpRequest.PartitioningSchema)
+			if err != nil {
+				return nil, err
+			}
+			return installServerWithPartitioningSchema(ctx, &tmpRequest.InstallServerRequest, schema)
+		}
+
 		return runner(ctx, &tmpRequest.InstallServerRequest)
 	}
 
 	c.WaitFunc = func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
-		api := baremetal.NewAPI(core.ExtractClient(ctx))
-		return api.WaitForServerInstall(&baremetal.WaitForServerInstallRequest{
-			Zone:          argsI.(*baremetalInstallServerRequestCustom).Zone,
-			ServerID:      respI.(*baremetal.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
-			RetryInterval: core.DefaultRetryInterval,
-		})
+		return waitForServerInstallProgress(ctx, argsI.(*baremetalInstallServerRequestCustom).Zone, respI.(*baremetal.Server).ID, core.WaitTimeout(ctx, serverActionTimeout))
 	}
 
 	return c
@@ -0,0 +1,189 @@
+package baremetal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	bmcAccessTimeout      = 5 * time.Minute
+	bmcAccessPollInterval = 5 * time.Second
+)
+
+type serverKVMRequest struct {
+	Zone     scw.Zone
+	ServerID string
+	IP       net.IP
+	Open     bool
+}
+
+func serverKVMCommand() *core.Command {
+	return &core.Command{
+		Short: `Get a remote access to the console of a server`,
+		Long: `Get the URL and the credentials of the KVM-over-IP console of a server, served by its BMC (Baseboard Management Controller).
+The current access is returned when it has not expired, otherwise a new access is started for the given IP and the command waits until it is available.
+The Remote Access option of the server must be enabled.`,
+		Namespace: "baremetal",
+		Resource:  "server",
+		Verb:      "kvm",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverKVMRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      `ID of the server`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "ip",
+				Short: `IP authorized to connect to the console, usually your public IP, required to start a new access`,
+			},
+			{
+				Name:  "open",
+				Short: `Open the console in the browser`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2),
+		},
+		Run: serverKVMRun,
+		Examples: []*core.Example{
+			{
+				Short: "Get a remote access to the console of a server from an IP and open it in the browser",
+				Raw:   "scw baremetal server kvm 11111111-1111-1111-1111-111111111111 ip=203.0.113.1 open=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the options of the servers, including Remote Access",
+				Command: "scw baremetal options list",
+			},
+			{
+				Short:   "Stop the remote access to a server",
+				Command: "scw baremetal bmc stop",
+			},
+		},
+	}
+}
+
+func serverKVMRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverKVMRequest)
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+
+	access, err := api.GetBMCAccess(&baremetal.GetBMCAccessRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if !bmcAccessAvailable(access, time.Now()) {
+		if args.IP == nil {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("server %s has no remote access", args.ServerID),
+				Hint: "Give the IP authorized to connect to the console with ip=<your-public-ip>",
+			}
+		}
+		_, err := api.StartBMCAccess(&baremetal.StartBMCAccessRequest{
+			Zone:     args.Zone,
+			ServerID: args.ServerID,
+			IP:       args.IP,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("failed to start the remote access of server %s: %w", args.ServerID, err),
+				Hint: "Check that the Remote Access option is enabled on the server with: scw baremetal server get " + args.ServerID,
+			}
+		}
+
+		access, err = waitForBMCAccess(ctx, args.Zone, args.ServerID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if args.Open {
+		if err := openBrowser(ctx, access.URL); err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", access.URL, err)
+		}
+	}
+
+	return access, nil
+}
+
+// bmcAccessAvailable returns true when an access has an URL and has not expired.
+func bmcAccessAvailable(access *baremetal.BMCAccess, now time.Time) bool {
+	if access == nil || access.URL == "" {
+		return false
+	}
+	return access.ExpiresAt == nil || access.ExpiresAt.After(now)
+}
+
+// waitForBMCAccess waits until a started access has its URL and its credentials.
+func waitForBMCAccess(ctx context.Context, zone scw.Zone, serverID string) (*baremetal.BMCAccess, error) {
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+	interval := bmcAccessPollInterval
+	if core.DefaultRetryInterval != nil {
+		interval = *core.DefaultRetryInterval
+	}
+	deadline := time.Now().Add(bmcAccessTimeout)
+
+	for {
+		access, err := api.GetBMCAccess(&baremetal.GetBMCAccessRequest{
+			Zone:     zone,
+			ServerID: serverID,
+		}, scw.WithContext(ctx))
+		if err != nil && !isNotFoundError(err) {
+			return nil, err
+		}
+		if bmcAccessAvailable(access, time.Now()) {
+			return access, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timeout after %s waiting for the remote access of server %s", bmcAccessTimeout, serverID)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func isNotFoundError(err error) bool {
+	notFoundError := &scw.ResourceNotFoundError{}
+	responseError := &scw.ResponseError{}
+	return errors.As(err, &notFoundError) || (errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound)
+}
+
+func openBrowser(ctx context.Context, url string) error {
+	var openCmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		openCmd = exec.Command("xdg-open", url) //nolint:gosec
+	case "windows":
+		openCmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url) //nolint:gosec
+	case "darwin":
+		openCmd = exec.Command("open", url) //nolint:gosec
+	default:
+		return fmt.Errorf("unsupported platform")
+	}
+
+	exitCode, err := core.ExecCmd(ctx, openCmd)
+	if exitCode != 0 {
+		return &core.CliError{Empty: true, Code: exitCode}
+	}
+	return err
+}
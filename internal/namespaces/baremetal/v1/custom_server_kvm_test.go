@@ -0,0 +1,19 @@
+package baremetal
+
+import (
+	"testing"
+	"time"
+
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_bmcAccessAvailable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, bmcAccessAvailable(nil, now))
+	assert.False(t, bmcAccessAvailable(&baremetal.BMCAccess{}, now), "access is starting")
+	assert.True(t, bmcAccessAvailable(&baremetal.BMCAccess{URL: "https://bmc.example.com", ExpiresAt: scw.TimePtr(now.Add(time.Hour))}, now))
+	assert.False(t, bmcAccessAvailable(&baremetal.BMCAccess{URL: "https://bmc.example.com", ExpiresAt: scw.TimePtr(now.Add(-time.Minute))}, now), "access expired")
+}
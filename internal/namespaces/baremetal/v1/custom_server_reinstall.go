@@ -0,0 +1,254 @@
+package baremetal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// serverInstallExpectedDuration is the usual duration of an installation, used to estimate its progress
+	// as the API only gives its status.
+	serverInstallExpectedDuration = 15 * time.Minute
+	serverInstallPollInterval     = 15 * time.Second
+	// serverInstallProgressStep is the minimum progress between two progress reports, in percent
+	serverInstallProgressStep = 5
+)
+
+type serverReinstallRequest struct {
+	Zone      scw.Zone
+	ServerID  string
+	OsID      string
+	Hostname  string
+	SSHKeyIDs []string
+	Yes       bool
+}
+
+func serverReinstallCommand() *core.Command {
+	return &core.Command{
+		Short: `Reinstall the OS of a server`,
+		Long: `Reinstall the OS of an Elastic Metal server. All the data of the server is erased.
+The OS, the hostname and the SSH keys of the current installation are kept unless new ones are given.
+With --wait, the progress of the installation is reported until it is completed, the percentage is estimated from the usual duration of an installation.`,
+		Namespace: "baremetal",
+		Resource:  "server",
+		Verb:      "reinstall",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverReinstallRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      `ID of the server to reinstall`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "os-id",
+				Short: `ID of the OS to install, default to the current OS`,
+			},
+			{
+				Name:  "hostname",
+				Short: `Hostname of the server, default to the current hostname`,
+			},
+			{
+				Name:  "ssh-key-ids.{index}",
+				Short: `SSH key IDs authorized on the server, default to the current SSH keys`,
+			},
+			{
+				Name:  "yes",
+				Short: `Reinstall the server without asking for confirmation`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2),
+		},
+		Run: serverReinstallRun,
+		WaitFunc: func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+			server, isServer := respI.(*baremetal.Server)
+			if !isServer {
+				return respI, nil
+			}
+			return waitForServerInstallProgress(ctx, argsI.(*serverReinstallRequest).Zone, server.ID, core.WaitTimeout(ctx, serverActionTimeout))
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Reinstall a server with its current OS and wait for the installation",
+				Raw:   "scw baremetal server reinstall 11111111-1111-1111-1111-111111111111 --wait",
+			},
+			{
+				Short: "Reinstall a server with another OS and SSH key",
+				Raw:   "scw baremetal server reinstall 11111111-1111-1111-1111-111111111111 os-id=22222222-2222-2222-2222-222222222222 ssh-key-ids.0=33333333-3333-3333-3333-333333333333 yes=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the available OS",
+				Command: "scw baremetal os list",
+			},
+			{
+				Short:   "Install an OS on a server",
+				Command: "scw baremetal server install",
+			},
+		},
+	}
+}
+
+func serverReinstallRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverReinstallRequest)
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+
+	server, err := api.GetServer(&baremetal.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := buildServerReinstallRequest(args, server)
+	if err != nil {
+		return nil, err
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("the reinstallation of server %s must be confirmed, all its data will be erased", server.Name),
+				Hint: "Use yes=true to reinstall the server without confirmation",
+			}
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       fmt.Sprintf("All the data of server %s will be erased, do you want to proceed?", server.Name),
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Reinstallation canceled"}, nil
+		}
+	}
+
+	return api.InstallServer(request, scw.WithContext(ctx))
+}
+
+// buildServerReinstallRequest returns the install request of a server, completed with its current installation.
+func buildServerReinstallRequest(args *serverReinstallRequest, server *baremetal.Server) (*baremetal.InstallServerRequest, error) {
+	request := &baremetal.InstallServerRequest{
+		Zone:      args.Zone,
+		ServerID:  args.ServerID,
+		OsID:      args.OsID,
+		Hostname:  args.Hostname,
+		SSHKeyIDs: args.SSHKeyIDs,
+	}
+
+	if server.Install != nil {
+		if request.OsID == "" {
+			request.OsID = server.Install.OsID
+		}
+		if request.Hostname == "" {
+			request.Hostname = server.Install.Hostname
+		}
+		if len(request.SSHKeyIDs) == 0 {
+			request.SSHKeyIDs = server.Install.SSHKeyIDs
+		}
+	}
+	if request.Hostname == "" {
+		request.Hostname = server.Name
+	}
+
+	if request.OsID == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("server %s has no OS installed", server.ID),
+			Hint: "Give the OS to install with os-id=<os-id>, list the available OS with: scw baremetal os list",
+		}
+	}
+	if len(request.SSHKeyIDs) == 0 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("server %s has no SSH key", server.ID),
+			Hint: "Give the SSH keys authorized on the server with ssh-key-ids.0=<ssh-key-id>",
+		}
+	}
+
+	return request, nil
+}
+
+// waitForServerInstallProgress waits for the installation of a server and reports its estimated progress.
+func waitForServerInstallProgress(ctx context.Context, zone scw.Zone, serverID string, timeout *time.Duration) (*baremetal.Server, error) {
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+	interval := serverInstallPollInterval
+	if core.DefaultRetryInterval != nil {
+		interval = *core.DefaultRetryInterval
+	}
+	timeoutDuration := serverActionTimeout
+	if timeout != nil {
+		timeoutDuration = *timeout
+	}
+	deadline := time.Now().Add(timeoutDuration)
+
+	installingSince := time.Time{}
+	lastPercent := -1.0
+	for {
+		server, err := api.GetServer(&baremetal.GetServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if server.Install == nil {
+			return nil, fmt.Errorf("no installation is running on server %s", serverID)
+		}
+
+		status := server.Install.Status
+		if status == baremetal.ServerInstallStatusInstalling && installingSince.IsZero() {
+			installingSince = time.Now()
+		}
+		percent := serverInstallProgress(status, time.Since(installingSince))
+		if percent >= lastPercent+serverInstallProgressStep || (percent == 100 && lastPercent != 100) {
+			lastPercent = percent
+			core.ReportWaitProgress(ctx, fmt.Sprintf("%s… %d%%", status, int(percent)), &percent)
+		}
+
+		switch status {
+		case baremetal.ServerInstallStatusCompleted:
+			return server, nil
+		case baremetal.ServerInstallStatusError, baremetal.ServerInstallStatusUnknown:
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("installation of server %s failed", serverID),
+				Details: fmt.Sprintf("installation status is %s", status),
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timeout after %s waiting for the installation of server %s, its status is %s", timeoutDuration, serverID, status)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// serverInstallProgress estimates the progress of an installation in percent from its status
+// and the time elapsed since it started installing.
+func serverInstallProgress(status baremetal.ServerInstallStatus, installing time.Duration) float64 {
+	switch status {
+	case baremetal.ServerInstallStatusCompleted:
+		return 100
+	case baremetal.ServerInstallStatusInstalling:
+		percent := 5 + 90*float64(installing)/float64(serverInstallExpectedDuration)
+		if percent > 95 {
+			percent = 95
+		}
+		return percent
+	default:
+		return 0
+	}
+}
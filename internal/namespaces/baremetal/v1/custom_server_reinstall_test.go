@@ -0,0 +1,48 @@
+package baremetal
+
+import (
+	"testing"
+	"time"
+
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildServerReinstallRequest(t *testing.T) {
+	server := &baremetal.Server{
+		ID:   "11111111-1111-1111-1111-111111111111",
+		Name: "my-server",
+		Install: &baremetal.ServerInstall{
+			OsID:      "os-1",
+			Hostname:  "my-host",
+			SSHKeyIDs: []string{"key-1"},
+		},
+	}
+
+	request, err := buildServerReinstallRequest(&serverReinstallRequest{ServerID: server.ID}, server)
+	require.NoError(t, err)
+	assert.Equal(t, "os-1", request.OsID)
+	assert.Equal(t, "my-host", request.Hostname)
+	assert.Equal(t, []string{"key-1"}, request.SSHKeyIDs)
+
+	request, err = buildServerReinstallRequest(&serverReinstallRequest{ServerID: server.ID, OsID: "os-2", SSHKeyIDs: []string{"key-2"}}, server)
+	require.NoError(t, err)
+	assert.Equal(t, "os-2", request.OsID)
+	assert.Equal(t, []string{"key-2"}, request.SSHKeyIDs)
+
+	_, err = buildServerReinstallRequest(&serverReinstallRequest{ServerID: server.ID}, &baremetal.Server{ID: server.ID})
+	assert.Error(t, err, "a server without installation needs an OS")
+
+	request, err = buildServerReinstallRequest(&serverReinstallRequest{ServerID: server.ID, OsID: "os-2", SSHKeyIDs: []string{"key-2"}}, &baremetal.Server{ID: server.ID, Name: "my-server"})
+	require.NoError(t, err)
+	assert.Equal(t, "my-server", request.Hostname)
+}
+
+func Test_serverInstallProgress(t *testing.T) {
+	assert.Equal(t, float64(0), serverInstallProgress(baremetal.ServerInstallStatusToInstall, 0))
+	assert.Equal(t, float64(5), serverInstallProgress(baremetal.ServerInstallStatusInstalling, 0))
+	assert.Equal(t, float64(50), serverInstallProgress(baremetal.ServerInstallStatusInstalling, serverInstallExpectedDuration/2))
+	assert.Equal(t, float64(95), serverInstallProgress(baremetal.ServerInstallStatusInstalling, time.Hour))
+	assert.Equal(t, float64(100), serverInstallProgress(baremetal.ServerInstallStatusCompleted, time.Hour))
+}
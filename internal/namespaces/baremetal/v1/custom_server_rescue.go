@@ -0,0 +1,218 @@
+package baremetal
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const serverRescuePollInterval = 10 * time.Second
+
+type serverRescueRequest struct {
+	Zone     scw.Zone
+	ServerID string
+}
+
+type serverRescueResult struct {
+	ServerID   string `json:"server_id"`
+	IP         string `json:"ip"`
+	User       string `json:"user"`
+	Password   string `json:"password"`
+	SSHCommand string `json:"ssh_command"`
+}
+
+func serverRescueArgSpecs() core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:       "server-id",
+			Short:      `ID of the server`,
+			Required:   true,
+			Positional: true,
+		},
+		core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2),
+	}
+}
+
+func serverRescueEnableCommand() *core.Command {
+	return &core.Command{
+		Short: `Boot a server in rescue mode`,
+		Long: `Reboot a server on the rescue system, or start it when it is stopped, wait until it is reachable and print the credentials of the rescue system.
+The disks of the server are left untouched, use scw baremetal server rescue-disable to boot on them again.`,
+		Namespace: "baremetal",
+		Resource:  "server",
+		Verb:      "rescue-enable",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverRescueRequest{}),
+		ArgSpecs:  serverRescueArgSpecs(),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*serverRescueRequest)
+			server, err := bootServer(ctx, args.Zone, args.ServerID, baremetal.ServerBootTypeRescue)
+			if err != nil {
+				return nil, err
+			}
+			return newServerRescueResult(server), nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Boot a server in rescue mode and get the credentials of the rescue system",
+				Raw:   "scw baremetal server rescue-enable 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Boot a server on its disks again",
+				Command: "scw baremetal server rescue-disable",
+			},
+		},
+	}
+}
+
+func serverRescueDisableCommand() *core.Command {
+	return &core.Command{
+		Short:     `Boot a server out of rescue mode`,
+		Long:      `Reboot a server on its disks after a rescue, and wait until it is reachable.`,
+		Namespace: "baremetal",
+		Resource:  "server",
+		Verb:      "rescue-disable",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverRescueRequest{}),
+		ArgSpecs:  serverRescueArgSpecs(),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*serverRescueRequest)
+			return bootServer(ctx, args.Zone, args.ServerID, baremetal.ServerBootTypeNormal)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Boot a server on its disks after a rescue",
+				Raw:   "scw baremetal server rescue-disable 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Boot a server in rescue mode",
+				Command: "scw baremetal server rescue-enable",
+			},
+		},
+	}
+}
+
+// bootServer reboots a server with a boot type, or starts it when it is stopped,
+// and waits until it is reachable with this boot type.
+func bootServer(ctx context.Context, zone scw.Zone, serverID string, bootType baremetal.ServerBootType) (*baremetal.Server, error) {
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+
+	server, err := api.GetServer(&baremetal.GetServerRequest{
+		Zone:     zone,
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	switch server.Status {
+	case baremetal.ServerStatusStopped:
+		_, err = api.StartServer(&baremetal.StartServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+			BootType: bootType,
+		}, scw.WithContext(ctx))
+	case baremetal.ServerStatusReady:
+		_, err = api.RebootServer(&baremetal.RebootServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+			BootType: bootType,
+		}, scw.WithContext(ctx))
+	default:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("server %s cannot be rebooted in %s status", serverID, server.Status),
+			Hint: "Wait for the server to be ready with: scw baremetal server wait " + serverID,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return waitForServerBoot(ctx, zone, serverID, bootType, core.WaitTimeout(ctx, serverActionTimeout))
+}
+
+// waitForServerBoot waits until a server is ready with a boot type and answers to ping.
+// The credentials of the rescue system are also awaited for a rescue boot.
+func waitForServerBoot(ctx context.Context, zone scw.Zone, serverID string, bootType baremetal.ServerBootType, timeout *time.Duration) (*baremetal.Server, error) {
+	api := baremetal.NewAPI(core.ExtractClient(ctx))
+	interval := serverRescuePollInterval
+	if core.DefaultRetryInterval != nil {
+		interval = *core.DefaultRetryInterval
+	}
+	timeoutDuration := serverActionTimeout
+	if timeout != nil {
+		timeoutDuration = *timeout
+	}
+	deadline := time.Now().Add(timeoutDuration)
+
+	lastStatus := ""
+	for {
+		server, err := api.GetServer(&baremetal.GetServerRequest{
+			Zone:     zone,
+			ServerID: serverID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if serverBooted(server, bootType) {
+			return server, nil
+		}
+		if server.Status == baremetal.ServerStatusError {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("server %s failed to boot", serverID),
+				Details: fmt.Sprintf("server %s is in %s status", serverID, server.Status),
+			}
+		}
+
+		status := fmt.Sprintf("%s, ping %s", server.Status, server.PingStatus)
+		if status != lastStatus {
+			lastStatus = status
+			core.ReportWaitProgress(ctx, status, nil)
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timeout after %s waiting for server %s to boot in %s mode, its status is %s", timeoutDuration, serverID, bootType, status)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+func serverBooted(server *baremetal.Server, bootType baremetal.ServerBootType) bool {
+	if server.Status != baremetal.ServerStatusReady || server.BootType != bootType || server.PingStatus != baremetal.ServerPingStatusPingStatusUp {
+		return false
+	}
+	return bootType != baremetal.ServerBootTypeRescue || server.RescueServer != nil
+}
+
+func newServerRescueResult(server *baremetal.Server) *serverRescueResult {
+	result := &serverRescueResult{
+		ServerID: server.ID,
+	}
+	for _, ip := range server.IPs {
+		if ip.Version == baremetal.IPVersionIPv4 {
+			result.IP = ip.Address.String()
+			break
+		}
+	}
+	if server.RescueServer != nil {
+		result.User = server.RescueServer.User
+		result.Password = server.RescueServer.Password
+	}
+	if result.IP != "" && result.User != "" {
+		result.SSHCommand = fmt.Sprintf("ssh %s@%s", result.User, result.IP)
+	}
+	return result
+}
@@ -0,0 +1,43 @@
+package baremetal
+
+import (
+	"net"
+	"testing"
+
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serverBooted(t *testing.T) {
+	server := &baremetal.Server{
+		Status:     baremetal.ServerStatusReady,
+		BootType:   baremetal.ServerBootTypeRescue,
+		PingStatus: baremetal.ServerPingStatusPingStatusUp,
+	}
+	assert.False(t, serverBooted(server, baremetal.ServerBootTypeRescue), "rescue credentials are missing")
+	assert.False(t, serverBooted(server, baremetal.ServerBootTypeNormal))
+
+	server.RescueServer = &baremetal.ServerRescueServer{User: "rescue", Password: "secret"}
+	assert.True(t, serverBooted(server, baremetal.ServerBootTypeRescue))
+
+	server.PingStatus = baremetal.ServerPingStatusPingStatusDown
+	assert.False(t, serverBooted(server, baremetal.ServerBootTypeRescue))
+}
+
+func Test_newServerRescueResult(t *testing.T) {
+	result := newServerRescueResult(&baremetal.Server{
+		ID: "11111111-1111-1111-1111-111111111111",
+		IPs: []*baremetal.IP{
+			{Address: net.ParseIP("2001:db8::1"), Version: baremetal.IPVersionIPv6},
+			{Address: net.ParseIP("192.0.2.1"), Version: baremetal.IPVersionIPv4},
+		},
+		RescueServer: &baremetal.ServerRescueServer{User: "rescue", Password: "secret"},
+	})
+	assert.Equal(t, &serverRescueResult{
+		ServerID:   "11111111-1111-1111-1111-111111111111",
+		IP:         "192.0.2.1",
+		User:       "rescue",
+		Password:   "secret",
+		SSHCommand: "ssh rescue@192.0.2.1",
+	}, result)
+}
@@ -67,7 +67,7 @@ func Test_RebootServerErrors(t *testing.T) {
 		Cmd:        "scw baremetal server reboot zone-nl-ams-1 {{ .Server.ID }}",
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeNotFound),
 		),
 		AfterFunc: core.AfterFuncCombine(
 			func(ctx *core.AfterFuncCtx) error {
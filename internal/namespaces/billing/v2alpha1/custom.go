@@ -18,5 +18,10 @@ func GetCommands() *core.Commands {
 
 	human.RegisterMarshalerFunc(billing.DownloadInvoiceRequestFileType("pdf"), human.EnumMarshalFunc(invoiceTypeMarshalSpecs))
 	cmds.MustFind("billing", "invoice", "download").Override(buildDownloadCommand)
+	cmds.Merge(core.NewCommands(
+		budgetRoot(),
+		budgetSetCommand(),
+		budgetListCommand(),
+	))
 	return cmds
 }
@@ -0,0 +1,156 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+type budgetSetRequest struct {
+	ProjectID *string
+	Amount    float64
+}
+
+type budgetListRequest struct{}
+
+// budgetStatus is the budget of a project with its consumption of the month.
+type budgetStatus struct {
+	ProjectID   string  `json:"project_id"`
+	Budget      float64 `json:"budget"`
+	Consumption float64 `json:"consumption"`
+	Remaining   float64 `json:"remaining"`
+}
+
+func budgetRoot() *core.Command {
+	return &core.Command{
+		Short: `Monthly budgets of projects`,
+		Long: `Monthly budgets of projects, stored in the CLI config.
+Create commands warn when the consumption of the month plus the estimated cost of the new resource exceeds the budget of the project, and fail with --enforce-budget.`,
+		Namespace: "billing",
+		Resource:  "budget",
+	}
+}
+
+func budgetSetCommand() *core.Command {
+	return &core.Command{
+		Short:     `Set the monthly budget of a project`,
+		Long:      `Set the monthly budget of a project in Euro in the CLI config, an amount of 0 removes the budget.`,
+		Namespace: "billing",
+		Resource:  "budget",
+		Verb:      "set",
+		ArgsType:  reflect.TypeOf(budgetSetRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			{
+				Name:     "amount",
+				Short:    `Monthly budget in Euro`,
+				Required: true,
+			},
+		},
+		Run: budgetSetRun,
+		Examples: []*core.Example{
+			{
+				Short:    "Set a budget of 100€ per month for the default project",
+				ArgsJSON: `{"amount":100}`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the budgets with the consumption of the month",
+				Command: "scw billing budget list",
+			},
+		},
+	}
+}
+
+func budgetSetRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*budgetSetRequest)
+	if args.Amount < 0 {
+		return nil, fmt.Errorf("amount must be positive")
+	}
+
+	projectID, exists := core.ExtractClient(ctx).GetDefaultProjectID()
+	if args.ProjectID != nil {
+		projectID, exists = *args.ProjectID, true
+	}
+	if !exists {
+		return nil, fmt.Errorf("no project-id given and no default project in the config")
+	}
+
+	cfg := core.ExtractCliConfig(ctx)
+	message := fmt.Sprintf("Budget of project %s set to %.2f€ per month", projectID, args.Amount)
+	if args.Amount == 0 {
+		delete(cfg.Budgets, projectID)
+		message = fmt.Sprintf("Budget of project %s removed", projectID)
+	} else {
+		if cfg.Budgets == nil {
+			cfg.Budgets = map[string]float64{}
+		}
+		cfg.Budgets[projectID] = args.Amount
+	}
+
+	err := cfg.Save()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return &core.SuccessResult{Message: message}, nil
+}
+
+func budgetListCommand() *core.Command {
+	return &core.Command{
+		Short:     `List the budgets with the consumption of the month`,
+		Long:      `List the budgets of the CLI config with the consumption of the current month of their project.`,
+		Namespace: "billing",
+		Resource:  "budget",
+		Verb:      "list",
+		ArgsType:  reflect.TypeOf(budgetListRequest{}),
+		ArgSpecs:  core.ArgSpecs{},
+		Run:       budgetListRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Project ID", FieldName: "ProjectID"},
+				{Label: "Budget", FieldName: "Budget"},
+				{Label: "Consumption", FieldName: "Consumption"},
+				{Label: "Remaining", FieldName: "Remaining"},
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Set the monthly budget of a project",
+				Command: "scw billing budget set",
+			},
+		},
+	}
+}
+
+func budgetListRun(ctx context.Context, _ interface{}) (interface{}, error) {
+	budgets := core.ExtractCliConfig(ctx).Budgets
+	if len(budgets) == 0 {
+		return []*budgetStatus{}, nil
+	}
+
+	consumptions, err := core.MonthlyConsumptionByProject(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*budgetStatus, 0, len(budgets))
+	for projectID, budget := range budgets {
+		consumption := consumptions[projectID]
+		statuses = append(statuses, &budgetStatus{
+			ProjectID:   projectID,
+			Budget:      budget,
+			Consumption: consumption,
+			Remaining:   budget - consumption,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ProjectID < statuses[j].ProjectID
+	})
+
+	return statuses, nil
+}
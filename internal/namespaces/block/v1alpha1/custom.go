@@ -49,5 +49,16 @@ func GetCommands() *core.Commands {
 	human.RegisterMarshalerFunc(block.SnapshotStatus(""), human.EnumMarshalFunc(snapshotStatusMarshalSpecs))
 	human.RegisterMarshalerFunc(block.ReferenceStatus(""), human.EnumMarshalFunc(referenceStatusMarshalSpecs))
 
+	cmds.MustFind("block", "volume", "list").Override(volumeListBuilder)
+	cmds.MustFind("block", "volume", "create").Override(volumeCreateBuilder)
+	cmds.MustFind("block", "volume", "update").Override(volumeUpdateBuilder)
+	cmds.MustFind("block", "snapshot", "list").Override(snapshotListBuilder)
+	cmds.MustFind("block", "snapshot", "create").Override(snapshotCreateBuilder)
+
+	cmds.Merge(core.NewCommands(
+		volumeAttachCommand(),
+		volumeDetachCommand(),
+	))
+
 	return cmds
 }
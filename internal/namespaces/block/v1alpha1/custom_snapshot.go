@@ -0,0 +1,42 @@
+package block
+
+import (
+	"context"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const snapshotActionTimeout = 60 * time.Minute
+
+func snapshotListBuilder(c *core.Command) *core.Command {
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{Label: "ID", FieldName: "ID"},
+			{Label: "Name", FieldName: "Name"},
+			{Label: "Size", FieldName: "Size"},
+			{Label: "Class", FieldName: "Class"},
+			{Label: "Volume ID", FieldName: "ParentVolume.ID"},
+			{Label: "Status", FieldName: "Status"},
+			{Label: "Tags", FieldName: "Tags"},
+			{Label: "Zone", FieldName: "Zone"},
+			{Label: "Created At", FieldName: "CreatedAt"},
+		},
+	}
+	return c
+}
+
+func snapshotCreateBuilder(c *core.Command) *core.Command {
+	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+		snapshot := respI.(*block.Snapshot)
+		return block.NewAPI(core.ExtractClient(ctx)).WaitForSnapshot(&block.WaitForSnapshotRequest{
+			Zone:          snapshot.Zone,
+			SnapshotID:    snapshot.ID,
+			Timeout:       core.WaitTimeout(ctx, snapshotActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+	}
+	return c
+}
@@ -0,0 +1,250 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	volumeActionTimeout = 5 * time.Minute
+
+	// referenceTypeInstanceServer is the product resource type of the references of volumes attached to Instances
+	referenceTypeInstanceServer = "instance_server"
+)
+
+const sizeArgShort = `Volume size, in bytes or with a unit like 20GB, with a granularity of 1 GB (10^9 bytes)`
+
+type volumeAttachRequest struct {
+	Zone     scw.Zone
+	VolumeID string
+	ServerID string
+}
+
+type volumeDetachRequest struct {
+	Zone     scw.Zone
+	VolumeID string
+	ServerID string
+}
+
+func volumeListBuilder(c *core.Command) *core.Command {
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{Label: "ID", FieldName: "ID"},
+			{Label: "Name", FieldName: "Name"},
+			{Label: "Type", FieldName: "Type"},
+			{Label: "Size", FieldName: "Size"},
+			{Label: "IOPS", FieldName: "Specs.PerfIops"},
+			{Label: "Status", FieldName: "Status"},
+			{Label: "Tags", FieldName: "Tags"},
+			{Label: "Zone", FieldName: "Zone"},
+			{Label: "Created At", FieldName: "CreatedAt"},
+		},
+	}
+	return c
+}
+
+func volumeCreateBuilder(c *core.Command) *core.Command {
+	c.ArgSpecs.GetByName("from-empty.size").Short = sizeArgShort
+	c.ArgSpecs.GetByName("from-snapshot.size").Short = sizeArgShort
+	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+		volume := respI.(*block.Volume)
+		return waitForVolume(ctx, volume.Zone, volume.ID)
+	}
+	c.Examples = []*core.Example{
+		{
+			Short: "Create a 20GB volume",
+			Raw:   "scw block volume create name=data perf-iops=5000 from-empty.size=20GB --wait",
+		},
+		{
+			Short: "Create a volume from a snapshot",
+			Raw:   "scw block volume create name=restored perf-iops=5000 from-snapshot.snapshot-id=11111111-1111-1111-1111-111111111111",
+		},
+	}
+	return c
+}
+
+func volumeUpdateBuilder(c *core.Command) *core.Command {
+	c.ArgSpecs.GetByName("size").Short = `New size of the volume, in bytes or with a unit like 50GB, it must be larger than the current one`
+	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+		volume := respI.(*block.Volume)
+		return waitForVolume(ctx, volume.Zone, volume.ID)
+	}
+	c.Examples = []*core.Example{
+		{
+			Short: "Resize a volume to 50GB",
+			Raw:   "scw block volume update 11111111-1111-1111-1111-111111111111 size=50GB --wait",
+		},
+	}
+	return c
+}
+
+func volumeAttachCommand() *core.Command {
+	return &core.Command{
+		Short:     `Attach a volume to an Instance`,
+		Long:      `Attach a volume to an Instance with the Instance API. The volume and the Instance must be in the same zone.`,
+		Namespace: "block",
+		Resource:  "volume",
+		Verb:      "attach",
+		ArgsType:  reflect.TypeOf(volumeAttachRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "volume-id",
+				Short:      `ID of the volume to attach`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "server-id",
+				Short:    `ID of the Instance to attach the volume to`,
+				Required: true,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms3, scw.ZonePlWaw3),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*volumeAttachRequest)
+			api := instance.NewAPI(core.ExtractClient(ctx))
+
+			_, err := api.AttachServerVolume(&instance.AttachServerVolumeRequest{
+				Zone:       args.Zone,
+				ServerID:   args.ServerID,
+				VolumeID:   args.VolumeID,
+				VolumeType: instance.AttachServerVolumeRequestVolumeTypeSbsVolume,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return block.NewAPI(core.ExtractClient(ctx)).GetVolume(&block.GetVolumeRequest{
+				Zone:     args.Zone,
+				VolumeID: args.VolumeID,
+			}, scw.WithContext(ctx))
+		},
+		WaitFunc: func(ctx context.Context, argsI, _ interface{}) (interface{}, error) {
+			args := argsI.(*volumeAttachRequest)
+			return waitForVolumeReferences(ctx, args.Zone, args.VolumeID, block.VolumeStatusInUse)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Attach a volume to an Instance",
+				Raw:   "scw block volume attach 11111111-1111-1111-1111-111111111111 server-id=22222222-2222-2222-2222-222222222222 --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Detach a volume from its Instance",
+				Command: "scw block volume detach",
+			},
+		},
+	}
+}
+
+func volumeDetachCommand() *core.Command {
+	return &core.Command{
+		Short:     `Detach a volume from its Instance`,
+		Long:      `Detach a volume from the Instance it is attached to with the Instance API. The Instance is found from the references of the volume when server-id is not set.`,
+		Namespace: "block",
+		Resource:  "volume",
+		Verb:      "detach",
+		ArgsType:  reflect.TypeOf(volumeDetachRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "volume-id",
+				Short:      `ID of the volume to detach`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "server-id",
+				Short: `ID of the Instance to detach the volume from, the Instance the volume is attached to by default`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms3, scw.ZonePlWaw3),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*volumeDetachRequest)
+			client := core.ExtractClient(ctx)
+			blockAPI := block.NewAPI(client)
+
+			serverID := args.ServerID
+			if serverID == "" {
+				volume, err := blockAPI.GetVolume(&block.GetVolumeRequest{
+					Zone:     args.Zone,
+					VolumeID: args.VolumeID,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return nil, err
+				}
+				serverID, err = volumeServerID(volume)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			_, err := instance.NewAPI(client).DetachServerVolume(&instance.DetachServerVolumeRequest{
+				Zone:     args.Zone,
+				ServerID: serverID,
+				VolumeID: args.VolumeID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return blockAPI.GetVolume(&block.GetVolumeRequest{
+				Zone:     args.Zone,
+				VolumeID: args.VolumeID,
+			}, scw.WithContext(ctx))
+		},
+		WaitFunc: func(ctx context.Context, argsI, _ interface{}) (interface{}, error) {
+			args := argsI.(*volumeDetachRequest)
+			return waitForVolumeReferences(ctx, args.Zone, args.VolumeID, block.VolumeStatusAvailable)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Detach a volume from its Instance",
+				Raw:   "scw block volume detach 11111111-1111-1111-1111-111111111111 --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Attach a volume to an Instance",
+				Command: "scw block volume attach",
+			},
+		},
+	}
+}
+
+// volumeServerID returns the ID of the Instance a volume is attached to.
+func volumeServerID(volume *block.Volume) (string, error) {
+	for _, reference := range volume.References {
+		if reference.ProductResourceType == referenceTypeInstanceServer && reference.Status != block.ReferenceStatusDetached {
+			return reference.ProductResourceID, nil
+		}
+	}
+	return "", fmt.Errorf("volume %s is not attached to an Instance", volume.ID)
+}
+
+func waitForVolume(ctx context.Context, zone scw.Zone, volumeID string) (*block.Volume, error) {
+	return block.NewAPI(core.ExtractClient(ctx)).WaitForVolume(&block.WaitForVolumeRequest{
+		Zone:          zone,
+		VolumeID:      volumeID,
+		Timeout:       core.WaitTimeout(ctx, volumeActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+}
+
+// waitForVolumeReferences waits for a volume to reach a status and for its references to be attached or detached.
+func waitForVolumeReferences(ctx context.Context, zone scw.Zone, volumeID string, status block.VolumeStatus) (*block.Volume, error) {
+	return block.NewAPI(core.ExtractClient(ctx)).WaitForVolumeAndReferences(&block.WaitForVolumeAndReferencesRequest{
+		Zone:                 zone,
+		VolumeID:             volumeID,
+		Timeout:              core.WaitTimeout(ctx, volumeActionTimeout),
+		RetryInterval:        core.DefaultRetryInterval,
+		VolumeTerminalStatus: &status,
+	}, scw.WithContext(ctx))
+}
@@ -0,0 +1,27 @@
+package block
+
+import (
+	"testing"
+
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_volumeServerID(t *testing.T) {
+	t.Run("Attached volume", func(t *testing.T) {
+		serverID, err := volumeServerID(&block.Volume{References: []*block.Reference{
+			{ProductResourceType: "instance_server", ProductResourceID: "old", Status: block.ReferenceStatusDetached},
+			{ProductResourceType: "instance_server", ProductResourceID: "web", Status: block.ReferenceStatusAttached},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, "web", serverID)
+	})
+
+	t.Run("Detached volume", func(t *testing.T) {
+		_, err := volumeServerID(&block.Volume{ID: "data", References: []*block.Reference{
+			{ProductResourceType: "instance_server", ProductResourceID: "old", Status: block.ReferenceStatusDetached},
+		}})
+		assert.EqualError(t, err, "volume data is not attached to an Instance")
+	})
+}
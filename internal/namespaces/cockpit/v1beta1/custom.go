@@ -11,6 +11,8 @@ func GetCommands() *core.Commands {
 
 	cmds.Merge(core.NewCommands(
 		cockpitWaitCommand(),
+		cockpitDatasourceListCommand(),
+		cockpitPushMetricCommand(),
 	))
 
 	human.RegisterMarshalerFunc(cockpit.CockpitStatus(""), human.EnumMarshalFunc(cockpitStatusMarshalSpecs))
@@ -19,6 +21,9 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("cockpit", "cockpit", "deactivate").Override(cockpitCockpitDeactivateBuilder)
 	cmds.MustFind("cockpit", "cockpit", "get").Override(cockpitCockpitGetBuilder)
 	cmds.MustFind("cockpit", "token", "get").Override(cockpitTokenGetBuilder)
+	cmds.MustFind("cockpit", "token", "create").Override(cockpitTokenCreateBuilder)
+	cmds.MustFind("cockpit", "grafana-user", "create").Override(cockpitGrafanaUserCreateBuilder)
+	cmds.MustFind("cockpit", "grafana-user", "reset-password").Override(cockpitGrafanaUserResetPasswordBuilder)
 
 	return cmds
 }
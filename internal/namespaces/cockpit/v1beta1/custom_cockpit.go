@@ -91,7 +91,7 @@ func cockpitCockpitActivateBuilder(command *core.Command) *core.Command {
 		api := cockpit.NewAPI(client)
 		return api.WaitForCockpit(&cockpit.WaitForCockpitRequest{
 			ProjectID:     req.ProjectID,
-			Timeout:       scw.TimeDurationPtr(cockpitActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, cockpitActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -106,7 +106,7 @@ func cockpitCockpitDeactivateBuilder(command *core.Command) *core.Command {
 		api := cockpit.NewAPI(client)
 		_, err := api.WaitForCockpit(&cockpit.WaitForCockpitRequest{
 			ProjectID:     req.ProjectID,
-			Timeout:       scw.TimeDurationPtr(cockpitActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, cockpitActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
@@ -150,3 +150,10 @@ func cockpitTokenGetBuilder(c *core.Command) *core.Command {
 
 	return c
 }
+
+func cockpitTokenCreateBuilder(c *core.Command) *core.Command {
+	// The secret key of the token is only returned when it is created
+	c.ShowSensitiveResult = true
+
+	return c
+}
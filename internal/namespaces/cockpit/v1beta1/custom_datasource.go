@@ -0,0 +1,55 @@
+package cockpit
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func cockpitDatasourceListCommand() *core.Command {
+	return &core.Command{
+		Short:     `List the datasources of a Cockpit`,
+		Long:      `List the datasources of the Cockpit of a Project, including the ones managed by Scaleway, with their URL.`,
+		Namespace: "cockpit",
+		Resource:  "datasource",
+		Verb:      "list",
+		ArgsType:  reflect.TypeOf(cockpit.ListDatasourcesRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "order-by",
+				Short:      `How the response is ordered`,
+				EnumValues: []string{"created_at_asc", "created_at_desc", "name_asc", "name_desc"},
+			},
+			core.ProjectIDArgSpec(),
+			{
+				Name:       "types.{index}",
+				Short:      `Filter by datasource types`,
+				EnumValues: []string{"metrics", "logs", "traces", "alerts"},
+			},
+			{
+				Name:  "is-managed-by-scaleway",
+				Short: `Filter by datasources managed by Scaleway`,
+			},
+		},
+		Run: func(ctx context.Context, args interface{}) (interface{}, error) {
+			request := args.(*cockpit.ListDatasourcesRequest)
+
+			api := cockpit.NewAPI(core.ExtractClient(ctx))
+			resp, err := api.ListDatasources(request, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return resp.Datasources, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the metrics datasources of the default Project",
+				Raw:   "scw cockpit datasource list types.0=metrics",
+			},
+		},
+	}
+}
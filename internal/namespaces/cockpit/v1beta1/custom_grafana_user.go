@@ -0,0 +1,64 @@
+package cockpit
+
+import (
+	"context"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// grafanaUserCredentials is a Grafana user with the URL to log in with its password.
+type grafanaUserCredentials struct {
+	*cockpit.GrafanaUser
+	GrafanaURL string `json:"grafana_url"`
+}
+
+func cockpitGrafanaUserCreateBuilder(c *core.Command) *core.Command {
+	// The password of the user is only returned by this command
+	c.ShowSensitiveResult = true
+	c.AddInterceptors(func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+		respI, err := runner(ctx, argsI)
+		if err != nil {
+			return nil, err
+		}
+
+		return newGrafanaUserCredentials(ctx, argsI.(*cockpit.CreateGrafanaUserRequest).ProjectID, respI.(*cockpit.GrafanaUser))
+	})
+
+	return c
+}
+
+func cockpitGrafanaUserResetPasswordBuilder(c *core.Command) *core.Command {
+	// The password of the user is only returned by this command
+	c.ShowSensitiveResult = true
+	c.AddInterceptors(func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+		respI, err := runner(ctx, argsI)
+		if err != nil {
+			return nil, err
+		}
+
+		return newGrafanaUserCredentials(ctx, argsI.(*cockpit.ResetGrafanaUserPasswordRequest).ProjectID, respI.(*cockpit.GrafanaUser))
+	})
+
+	return c
+}
+
+// newGrafanaUserCredentials adds the Grafana URL of the Cockpit of a Project to a Grafana user.
+func newGrafanaUserCredentials(ctx context.Context, projectID string, user *cockpit.GrafanaUser) (*grafanaUserCredentials, error) {
+	projectCockpit, err := cockpit.NewAPI(core.ExtractClient(ctx)).GetCockpit(&cockpit.GetCockpitRequest{
+		ProjectID: projectID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := &grafanaUserCredentials{
+		GrafanaUser: user,
+	}
+	if projectCockpit.Endpoints != nil {
+		credentials.GrafanaURL = projectCockpit.Endpoints.GrafanaURL
+	}
+
+	return credentials, nil
+}
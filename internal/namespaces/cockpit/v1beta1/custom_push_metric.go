@@ -0,0 +1,187 @@
+package cockpit
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type cockpitPushMetricArgs struct {
+	Name      string
+	Value     float64
+	Labels    map[string]string
+	Timestamp *time.Time
+	Token     string
+	ProjectID string
+}
+
+func cockpitPushMetricCommand() *core.Command {
+	return &core.Command{
+		Short: `Push a sample of a custom metric`,
+		Long: `Push a single sample of a custom metric to the metrics endpoint of a Cockpit using the Prometheus remote write protocol.
+This is useful to check that a token can write metrics and that custom metrics are displayed in Grafana.`,
+		Namespace: "cockpit",
+		Resource:  "push-metric",
+		ArgsType:  reflect.TypeOf(cockpitPushMetricArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:     "name",
+				Short:    `Name of the metric`,
+				Required: true,
+			},
+			{
+				Name:     "value",
+				Short:    `Value of the sample`,
+				Required: true,
+			},
+			{
+				Name:  "labels.{key}",
+				Short: `Labels of the sample`,
+			},
+			{
+				Name:  "timestamp",
+				Short: `Timestamp of the sample, default to now`,
+			},
+			{
+				Name:     "token",
+				Short:    `Secret key of a Cockpit token with the write_metrics scope`,
+				Required: true,
+			},
+			core.ProjectIDArgSpec(),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*cockpitPushMetricArgs)
+
+			projectCockpit, err := cockpit.NewAPI(core.ExtractClient(ctx)).GetCockpit(&cockpit.GetCockpitRequest{
+				ProjectID: args.ProjectID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			if projectCockpit.Endpoints == nil || projectCockpit.Endpoints.MetricsURL == "" {
+				return nil, fmt.Errorf("cockpit of project %s has no metrics endpoint", args.ProjectID)
+			}
+
+			timestamp := time.Now()
+			if args.Timestamp != nil {
+				timestamp = *args.Timestamp
+			}
+
+			labels := map[string]string{}
+			for key, value := range args.Labels {
+				labels[key] = value
+			}
+			labels["__name__"] = args.Name
+
+			body := encodeSnappyLiteral(encodeRemoteWriteRequest(labels, args.Value, timestamp))
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(projectCockpit.Endpoints.MetricsURL, "/")+"/api/v1/push", bytes.NewReader(body))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Encoding", "snappy")
+			req.Header.Set("Content-Type", "application/x-protobuf")
+			req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+			req.Header.Set("X-Token", args.Token)
+
+			resp, err := core.ExtractHTTPClient(ctx).Do(req)
+			if err != nil {
+				return nil, err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode >= http.StatusBadRequest {
+				respBody, _ := io.ReadAll(resp.Body)
+				return nil, &core.CliError{
+					Err:     fmt.Errorf("failed to push metric: %s", resp.Status),
+					Details: strings.TrimSpace(string(respBody)),
+					Hint:    "Make sure the token has the write_metrics scope",
+				}
+			}
+
+			return &core.SuccessResult{
+				Message: fmt.Sprintf("Metric %s pushed", args.Name),
+			}, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Push a sample with labels",
+				Raw:   "scw cockpit push-metric name=deploy_duration_seconds value=42.5 labels.app=api labels.env=prod token=11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create a token with the write_metrics scope",
+				Command: "scw cockpit token create scopes.write-metrics=true",
+			},
+		},
+	}
+}
+
+// encodeRemoteWriteRequest encodes a Prometheus remote write request holding a single sample.
+// Labels are sorted by name as required by the protocol.
+func encodeRemoteWriteRequest(labels map[string]string, value float64, timestamp time.Time) []byte {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	timeSeries := []byte(nil)
+	for _, name := range names {
+		label := appendProtoBytes(nil, 1, []byte(name))
+		label = appendProtoBytes(label, 2, []byte(labels[name]))
+		timeSeries = appendProtoBytes(timeSeries, 1, label)
+	}
+
+	sample := binary.AppendUvarint(nil, 1<<3|1)
+	sample = binary.LittleEndian.AppendUint64(sample, math.Float64bits(value))
+	sample = binary.AppendUvarint(sample, 2<<3)
+	sample = binary.AppendUvarint(sample, uint64(timestamp.UnixMilli()))
+	timeSeries = appendProtoBytes(timeSeries, 2, sample)
+
+	return appendProtoBytes(nil, 1, timeSeries)
+}
+
+// appendProtoBytes appends a length-delimited protobuf field.
+func appendProtoBytes(b []byte, field uint64, value []byte) []byte {
+	b = binary.AppendUvarint(b, field<<3|2)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+// encodeSnappyLiteral encodes data in the snappy block format without compressing it,
+// which is enough for the small payloads pushed by the CLI.
+func encodeSnappyLiteral(data []byte) []byte {
+	b := binary.AppendUvarint(nil, uint64(len(data)))
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > math.MaxUint16+1 {
+			chunk = chunk[:math.MaxUint16+1]
+		}
+		data = data[len(chunk):]
+
+		n := len(chunk) - 1
+		if n < 60 {
+			b = append(b, byte(n)<<2)
+		} else if n < 1<<8 {
+			b = append(b, 60<<2, byte(n))
+		} else {
+			b = append(b, 61<<2, byte(n), byte(n>>8))
+		}
+		b = append(b, chunk...)
+	}
+
+	return b
+}
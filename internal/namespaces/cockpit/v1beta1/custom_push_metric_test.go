@@ -0,0 +1,32 @@
+package cockpit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_encodeRemoteWriteRequest(t *testing.T) {
+	request := encodeRemoteWriteRequest(map[string]string{"__name__": "up"}, 1, time.UnixMilli(1000))
+
+	expected := []byte{
+		0x0a, 0x1e, // timeseries
+		0x0a, 0x0e, // label
+		0x0a, 0x08, '_', '_', 'n', 'a', 'm', 'e', '_', '_',
+		0x12, 0x02, 'u', 'p',
+		0x12, 0x0c, // sample
+		0x09, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xf0, 0x3f,
+		0x10, 0xe8, 0x07,
+	}
+	assert.Equal(t, expected, request)
+}
+
+func Test_encodeSnappyLiteral(t *testing.T) {
+	assert.Equal(t, []byte{0x03, 0x08, 'a', 'b', 'c'}, encodeSnappyLiteral([]byte("abc")))
+
+	data := make([]byte, 100)
+	encoded := encodeSnappyLiteral(data)
+	assert.Equal(t, []byte{100, 60 << 2, 99}, encoded[:3])
+	assert.Len(t, encoded, 103)
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+type configSetDefaultArgs struct {
+	Command string
+	Args    []string
+}
+
+func configSetDefaultCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"config"},
+		Short:  `Set default arguments of a command`,
+		Long: `Persist default arguments of a command in the CLI config file, they are used when the command is run without these arguments.
+Arguments given on the command line take precedence over the defaults.
+The command is given by its path, e.g. instance.server.create, and the arguments as key=value. An empty value removes the default of an argument.`,
+		Namespace:            "config",
+		Resource:             "set-default",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(configSetDefaultArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "command",
+				Short:      "Path of the command, e.g. instance.server.create",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "args.{index}",
+				Short: "Default arguments of the command as key=value",
+			},
+		},
+		RawArgsRewriter: setDefaultRawArgs,
+		Run:             configSetDefaultRun,
+		Examples: []*core.Example{
+			{
+				Short: "Create the servers in fr-par-2 with the DEV1-S type by default",
+				Raw:   "scw config set-default instance.server.create type=DEV1-S zone=fr-par-2",
+			},
+			{
+				Short: "Remove the default type of the created servers",
+				Raw:   "scw config set-default instance.server.create type=",
+			},
+		},
+	}
+}
+
+// setDefaultRawArgs rewrites the key=value arguments of the command into args.{index}=key=value.
+func setDefaultRawArgs(rawArgs args.RawArgs) args.RawArgs {
+	rewrittenArgs := args.RawArgs(nil)
+	index := 0
+	for _, arg := range rawArgs {
+		if !strings.Contains(arg, "=") || strings.HasPrefix(arg, "args.") {
+			rewrittenArgs = append(rewrittenArgs, arg)
+			continue
+		}
+		rewrittenArgs = rewrittenArgs.Add(fmt.Sprintf("args.%d", index), arg)
+		index++
+	}
+	return rewrittenArgs
+}
+
+func configSetDefaultRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	request := argsI.(*configSetDefaultArgs)
+	cfg := core.ExtractCliConfig(ctx)
+
+	path := strings.Fields(strings.ReplaceAll(request.Command, ".", " "))
+	cmd := core.ExtractCommands(ctx).Find(path...)
+	if cmd == nil || cmd.Run == nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("unknown command '%s'", request.Command),
+			Hint: "Give the path of the command with its namespace, resource and verb, e.g. instance.server.create",
+			Code: core.ExitCodeUsage,
+		}
+	}
+	key := core.CommandDefaultsKey(cmd)
+
+	defaults := map[string]string{}
+	for name, value := range cfg.CommandDefaults[key] {
+		defaults[name] = value
+	}
+	for _, arg := range args.SplitRaw(request.Args) {
+		name, value := arg[0], arg[1]
+		if value == "" {
+			delete(defaults, name)
+			continue
+		}
+		if err := validateDefaultArg(cmd, name, value); err != nil {
+			return nil, err
+		}
+		defaults[name] = value
+	}
+
+	if cfg.CommandDefaults == nil {
+		cfg.CommandDefaults = map[string]map[string]string{}
+	}
+	if len(defaults) == 0 {
+		delete(cfg.CommandDefaults, key)
+	} else {
+		cfg.CommandDefaults[key] = defaults
+	}
+
+	err := cfg.Save()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save command defaults: %w", err)
+	}
+
+	commandPath := strings.ReplaceAll(key, ".", " ")
+	if len(defaults) == 0 {
+		return &core.SuccessResult{
+			Message: fmt.Sprintf("removed the default arguments of %s", commandPath),
+		}, nil
+	}
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("default arguments of %s: %s", commandPath, formatDefaults(defaults)),
+	}, nil
+}
+
+// validateDefaultArg checks that the argument is a non-positional argument of the command with a valid value.
+func validateDefaultArg(cmd *core.Command, name string, value string) error {
+	if positionalArgSpec := cmd.ArgSpecs.GetPositionalArg(); positionalArgSpec != nil && positionalArgSpec.Name == name {
+		return &core.CliError{
+			Err:  fmt.Errorf("cannot set a default value to the positional argument '%s'", name),
+			Code: core.ExitCodeUsage,
+		}
+	}
+	if cmd.ArgsType == nil {
+		return &core.CliError{
+			Err:  fmt.Errorf("command %s has no arguments", strings.ReplaceAll(core.CommandDefaultsKey(cmd), ".", " ")),
+			Code: core.ExitCodeUsage,
+		}
+	}
+
+	err := args.UnmarshalStruct([]string{name + "=" + value}, reflect.New(cmd.ArgsType).Interface())
+	if err != nil {
+		argNames := []string(nil)
+		for _, argSpec := range cmd.ArgSpecs.GetDeprecated(false) {
+			if !argSpec.Positional {
+				argNames = append(argNames, argSpec.Name)
+			}
+		}
+		return &core.CliError{
+			Err:  fmt.Errorf("invalid default argument: %w", err),
+			Hint: fmt.Sprintf("Valid arguments are: %s", strings.Join(argNames, ", ")),
+			Code: core.ExitCodeUsage,
+		}
+	}
+	return nil
+}
+
+// formatDefaults returns the default arguments as key=value sorted by key.
+func formatDefaults(defaults map[string]string) string {
+	names := make([]string, 0, len(defaults))
+	for name := range defaults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	formatted := make([]string, 0, len(names))
+	for _, name := range names {
+		formatted = append(formatted, name+"="+defaults[name])
+	}
+	return strings.Join(formatted, " ")
+}
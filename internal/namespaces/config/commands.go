@@ -3,6 +3,7 @@ package config
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
@@ -13,6 +14,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-cli/v2/internal/keyring"
 	"github.com/scaleway/scaleway-cli/v2/internal/tabwriter"
 	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -24,6 +26,7 @@ func GetCommands() *core.Commands {
 		configRoot(),
 		configGetCommand(),
 		configSetCommand(),
+		configSetSecretCommand(),
 		configUnsetCommand(),
 		configDumpCommand(),
 		configProfileCommand(),
@@ -34,6 +37,8 @@ func GetCommands() *core.Commands {
 		configInfoCommand(),
 		configImportCommand(),
 		configValidateCommand(),
+		configDoctorCommand(),
+		configSetDefaultCommand(),
 	)
 }
 
@@ -311,6 +316,139 @@ The only allowed attributes are access_key, secret_key, default_organization_id,
 	}
 }
 
+// configSetSecretCommand sets the secret key of a profile, in the config file or in the keyring of the OS
+func configSetSecretCommand() *core.Command {
+	type configSetSecretArgs struct {
+		SecretKey string
+		Keyring   bool
+	}
+
+	return &core.Command{
+		Groups: []string{"config"},
+		Short:  `Set the secret key of the profile`,
+		Long: `Set the secret key of the profile, in the config file or in the keyring of the OS with keyring=true.
+
+The keyring is the Keychain on macOS, the Credential Manager on Windows and the Secret Service (GNOME Keyring, KWallet) on Linux, where secret-tool must be installed.
+The secret key is stored under the access key of the profile and the config file only holds secret_key: keyring. It is read from the keyring when the client is created, unless SCW_SECRET_KEY is set.
+Tools other than this CLI cannot read secret keys stored in the keyring.
+
+When the secret key is not given, it is prompted.`,
+		Namespace:            "config",
+		Resource:             "set-secret",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(configSetSecretArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "secret-key",
+				Short: "A Scaleway secret key, prompted when not given",
+				ValidateFunc: func(_ *core.ArgSpec, value interface{}) error {
+					if secretKey := value.(string); secretKey != "" && !validation.IsSecretKey(secretKey) {
+						return core.InvalidSecretKeyError(secretKey)
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "keyring",
+				Short: "Store the secret key in the keyring of the OS instead of the config file",
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Move the secret key of the profile 'prod' to the keyring",
+				Raw:   "scw -p prod config set-secret keyring=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Set a line from the config file",
+				Command: "scw config set",
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (i interface{}, err error) {
+			args := argsI.(*configSetSecretArgs)
+
+			configPath := core.ExtractConfigPath(ctx)
+			config, err := scw.LoadConfigFromPath(configPath)
+			if err != nil {
+				return nil, err
+			}
+
+			profileName := core.ExtractProfileName(ctx)
+			profile, err := getProfile(config, profileName)
+			if err != nil {
+				return nil, err
+			}
+			// use config.GetProfile as the access key may be inherited from the default profile
+			mergedProfile, err := config.GetProfile(profileName)
+			if err != nil {
+				return nil, err
+			}
+
+			secretKey := args.SecretKey
+			if secretKey == "" {
+				if !interactive.IsInteractive {
+					return nil, &core.CliError{
+						Err:  fmt.Errorf("missing secret key"),
+						Hint: "Give the secret key with secret-key=xxx",
+					}
+				}
+				secretKey, err = interactive.PromptPasswordWithConfig(&interactive.PromptPasswordConfig{
+					Ctx:    ctx,
+					Prompt: "Secret key",
+				})
+				if err != nil {
+					return nil, err
+				}
+				if !validation.IsSecretKey(secretKey) {
+					return nil, core.InvalidSecretKeyError(secretKey)
+				}
+			}
+
+			if !args.Keyring {
+				// A secret key stored in the keyring is removed from it when it is set back in the config file
+				if keyring.IsSecretKeyRef(profile.SecretKey) && mergedProfile.AccessKey != nil {
+					err := keyring.Delete(*mergedProfile.AccessKey)
+					if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+						return nil, err
+					}
+				}
+				profile.SecretKey = &secretKey
+
+				err = config.SaveTo(configPath)
+				if err != nil {
+					return nil, err
+				}
+
+				return &core.SuccessResult{
+					Message: "successfully update config",
+				}, nil
+			}
+
+			if mergedProfile.AccessKey == nil || *mergedProfile.AccessKey == "" {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("the profile has no access key"),
+					Hint: "The secret key is stored under the access key of the profile, set it with scw config set access-key=xxx",
+				}
+			}
+			err = keyring.Set(*mergedProfile.AccessKey, secretKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to store the secret key in the keyring: %w", err)
+			}
+			profile.SecretKey = scw.StringPtr(keyring.SecretKeyRef)
+
+			err = config.SaveTo(configPath)
+			if err != nil {
+				return nil, err
+			}
+
+			return &core.SuccessResult{
+				Message: "successfully store the secret key in the keyring",
+			}, nil
+		},
+	}
+}
+
 // configDumpCommand unsets a value for the scaleway config
 func configUnsetCommand() *core.Command {
 	type configUnsetArgs struct {
@@ -634,22 +772,56 @@ func configInfoCommand() *core.Command {
 func configImportCommand() *core.Command {
 	type configImportArgs struct {
 		File string
+		From string
 	}
 
 	return &core.Command{
-		Groups:               []string{"config"},
-		Short:                "Import configurations from another file",
-		Namespace:            "config",
-		Resource:             "import",
-		AllowAnonymousClient: true,
-		ArgsType:             reflect.TypeOf(configImportArgs{}),
+		Groups: []string{"config"},
+		Short:  "Import configurations from another file",
+		Long: `Import configurations from another Scaleway config file, from the environment or from the configuration of other tools.
+
+Sources are:
+- file: a Scaleway config file, its profiles are merged with the profiles of the same name
+- env: the SCW_* environment variables, imported in the current profile
+- terraform: the string attributes of the scaleway provider blocks of a Terraform file, or of the .tf files of a directory (default to the current directory), imported in the current profile
+- aws-style-credentials-file: the Scaleway keys of an AWS style credentials file used by S3 tools (default to ~/.aws/credentials), its default section is imported in the current profile and other sections in profiles of the same name`,
+		Namespace:             "config",
+		Resource:              "import",
+		AllowAnonymousClient:  true,
+		ArgsType:              reflect.TypeOf(configImportArgs{}),
+		OptionalPositionalArg: true,
 		ArgSpecs: core.ArgSpecs{
 			{
 				Name:       "file",
 				Short:      "Path to the configuration file to import",
-				Required:   true,
 				Positional: true,
 			},
+			{
+				Name:       "from",
+				Short:      "Source of the configuration to import",
+				Default:    core.DefaultValueSetter(importFromFile),
+				EnumValues: []string{importFromFile, importFromEnv, importFromTerraform, importFromAWS},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Import a config file",
+				Raw:   "scw config import ./config.yaml",
+			},
+			{
+				Short: "Save the SCW_* environment variables in the profile 'prod'",
+				Raw:   "scw -p prod config import from=env",
+			},
+			{
+				Short: "Import the Scaleway keys used by S3 tools",
+				Raw:   "scw config import from=aws-style-credentials-file",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Check the config",
+				Command: "scw config doctor",
+			},
 		},
 		Run: func(ctx context.Context, argsI interface{}) (i interface{}, e error) {
 			args := argsI.(*configImportArgs)
@@ -657,23 +829,32 @@ func configImportCommand() *core.Command {
 
 			currentConfig, err := scw.LoadConfigFromPath(configPath)
 			if err != nil {
-				return nil, err
+				// We allow to import a config when no config file exists
+				if _, isNotFoundError := err.(*scw.ConfigFileNotFoundError); !isNotFoundError {
+					return nil, err
+				}
+				currentConfig = &scw.Config{}
+			}
+			if currentConfig.Profiles == nil {
+				currentConfig.Profiles = map[string]*scw.Profile{}
 			}
 			currentProfileName := core.ExtractProfileName(ctx)
-			currentProfile, err := currentConfig.GetProfile(currentProfileName)
+			currentProfile, err := getProfile(currentConfig, currentProfileName)
 			if err != nil {
-				return nil, err
+				// The profile is created when it does not exist
+				currentProfile = &scw.Profile{}
+				currentConfig.Profiles[currentProfileName] = currentProfile
 			}
 
-			// Read the content of the file to import
-			importedConfig, err := scw.LoadConfigFromPath(args.File)
+			// Read the configuration to import
+			importedConfig, err := loadImportedConfig(ctx, args.From, args.File)
 			if err != nil {
 				return nil, err
 			}
 			importedProfile := importedConfig.Profile
 
 			// Merge the imported configurations into the existing configuration
-			currentConfig.Profile = *scw.MergeProfiles(currentProfile, &importedProfile)
+			*currentProfile = *scw.MergeProfiles(currentProfile, &importedProfile)
 
 			for profileName, profile := range importedConfig.Profiles {
 				existingProfile, exists := currentConfig.Profiles[profileName]
@@ -842,7 +1023,7 @@ func validateSecretKey(profile *scw.Profile) error {
 			}
 		}
 
-		if !validation.IsSecretKey(*profile.SecretKey) {
+		if !validation.IsSecretKey(*profile.SecretKey) && !keyring.IsSecretKeyRef(profile.SecretKey) {
 			return core.InvalidSecretKeyError(*profile.SecretKey)
 		}
 	}
@@ -0,0 +1,261 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/keyring"
+	accountv3 "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+const (
+	doctorCheckOK      = "ok"
+	doctorCheckWarning = "warning"
+	doctorCheckError   = "error"
+)
+
+// doctorCheck is the result of a check of the current profile.
+type doctorCheck struct {
+	Check   string
+	Status  string
+	Details string
+}
+
+// configDoctorCommand checks that the current profile can be used
+func configDoctorCommand() *core.Command {
+	type configDoctorArgs struct{}
+
+	return &core.Command{
+		Groups: []string{"config"},
+		Short:  `Check the config of the current profile`,
+		Long: `Check the config of the current profile, merged with the environment, and report what is wrong:
+- the keys are set and the API key is valid and not expired
+- the default organization is set
+- the default project exists and belongs to the default organization
+- the default region and zone are set and the zone belongs to the region`,
+		Namespace:            "config",
+		Resource:             "doctor",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(configDoctorArgs{}),
+		Examples: []*core.Example{
+			{
+				Short: "Check the config of the profile 'prod'",
+				Raw:   "scw -p prod config doctor",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Validate the format of all profiles",
+				Command: "scw config validate",
+			},
+			{
+				Short:   "Import keys from the environment or other tools",
+				Command: "scw config import",
+			},
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Check", FieldName: "Check"},
+				{Label: "Status", FieldName: "Status"},
+				{Label: "Details", FieldName: "Details"},
+			},
+		},
+		Run: configDoctorRun,
+	}
+}
+
+func configDoctorRun(ctx context.Context, _ interface{}) (interface{}, error) {
+	checks := []*doctorCheck(nil)
+	profile := &scw.Profile{}
+
+	configPath := core.ExtractConfigPath(ctx)
+	config, err := scw.LoadConfigFromPath(configPath)
+	switch {
+	case err == nil:
+		// use config.GetProfile instead of getProfile as we want the profile merged with the default
+		profile, err = config.GetProfile(core.ExtractProfileName(ctx))
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, &doctorCheck{Check: "Config file", Status: doctorCheckOK, Details: configPath})
+	case errors.As(err, new(*scw.ConfigFileNotFoundError)):
+		checks = append(checks, &doctorCheck{Check: "Config file", Status: doctorCheckWarning, Details: "no config file, only the environment is used"})
+	default:
+		checks = append(checks, &doctorCheck{Check: "Config file", Status: doctorCheckError, Details: err.Error()})
+	}
+
+	profileEnv := scw.LoadEnvProfile()
+	overriddenVariables := []string(nil)
+	for _, key := range getProfileKeys() {
+		value, err := getProfileField(profileEnv, key)
+		if err == nil && !value.IsZero() {
+			overriddenVariables = append(overriddenVariables, key)
+		}
+	}
+	if len(overriddenVariables) > 0 {
+		checks = append(checks, &doctorCheck{
+			Check:   "Environment",
+			Status:  doctorCheckWarning,
+			Details: "overridden by the environment: " + strings.Join(overriddenVariables, ", "),
+		})
+	}
+	profile = scw.MergeProfiles(profile, profileEnv)
+	if keyring.IsSecretKeyRef(profile.SecretKey) {
+		err := keyring.ResolveSecretKey(profile)
+		if err != nil {
+			checks = append(checks, &doctorCheck{Check: "Keyring", Status: doctorCheckError, Details: err.Error()})
+			profile.SecretKey = nil
+		} else {
+			checks = append(checks, &doctorCheck{Check: "Keyring", Status: doctorCheckOK, Details: "the secret key is read from the keyring"})
+		}
+	}
+
+	client := core.ExtractClient(ctx)
+	checks = append(checks, apiKeyDoctorCheck(ctx, client, profile))
+	checks = append(checks, organizationDoctorCheck(profile))
+	checks = append(checks, projectDoctorCheck(ctx, client, profile))
+	checks = append(checks, localityDoctorChecks(profile)...)
+
+	return checks, nil
+}
+
+// apiKeyDoctorCheck checks that the keys of the profile are set and that the API key is valid.
+func apiKeyDoctorCheck(ctx context.Context, client *scw.Client, profile *scw.Profile) *doctorCheck {
+	check := &doctorCheck{Check: "API key", Status: doctorCheckError}
+	switch {
+	case profile.AccessKey == nil || profile.SecretKey == nil:
+		check.Details = "access key and secret key must be set, run scw init to set them"
+		return check
+	case !validation.IsAccessKey(*profile.AccessKey):
+		check.Details = fmt.Sprintf("invalid access key format '%s'", *profile.AccessKey)
+		return check
+	case !validation.IsSecretKey(*profile.SecretKey):
+		check.Details = "invalid secret key format, expected a UUID"
+		return check
+	}
+
+	apiKey, err := iam.NewAPI(client).GetAPIKey(&iam.GetAPIKeyRequest{
+		AccessKey: *profile.AccessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		responseError := &scw.ResponseError{}
+		switch {
+		case errors.As(err, &responseError) && responseError.StatusCode == http.StatusUnauthorized:
+			check.Details = "the API key is invalid, expired or revoked"
+		case errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden, errors.As(err, new(*scw.PermissionsDeniedError)):
+			check.Status = doctorCheckWarning
+			check.Details = "the API key is valid but cannot read itself, its expiration is not checked"
+		default:
+			check.Details = err.Error()
+		}
+		return check
+	}
+
+	check.Status = doctorCheckOK
+	check.Details = *profile.AccessKey
+	if apiKey.ExpiresAt != nil {
+		if apiKey.ExpiresAt.Before(time.Now()) {
+			check.Status = doctorCheckError
+			check.Details = fmt.Sprintf("the API key expired on %s", apiKey.ExpiresAt.Format(time.RFC3339))
+		} else {
+			check.Details = fmt.Sprintf("%s, expires on %s", *profile.AccessKey, apiKey.ExpiresAt.Format(time.RFC3339))
+		}
+	}
+
+	return check
+}
+
+func organizationDoctorCheck(profile *scw.Profile) *doctorCheck {
+	check := &doctorCheck{Check: "Default organization", Status: doctorCheckOK}
+	switch {
+	case profile.DefaultOrganizationID == nil:
+		check.Status = doctorCheckError
+		check.Details = "not set, commands creating resources need it"
+	case !validation.IsOrganizationID(*profile.DefaultOrganizationID):
+		check.Status = doctorCheckError
+		check.Details = fmt.Sprintf("invalid organization ID '%s'", *profile.DefaultOrganizationID)
+	default:
+		check.Details = *profile.DefaultOrganizationID
+	}
+
+	return check
+}
+
+// projectDoctorCheck checks that the default project exists and belongs to the default organization.
+func projectDoctorCheck(ctx context.Context, client *scw.Client, profile *scw.Profile) *doctorCheck {
+	check := &doctorCheck{Check: "Default project", Status: doctorCheckOK}
+	switch {
+	case profile.DefaultProjectID == nil:
+		check.Status = doctorCheckWarning
+		check.Details = "not set, the default project of the organization is used"
+		return check
+	case !validation.IsProjectID(*profile.DefaultProjectID):
+		check.Status = doctorCheckError
+		check.Details = fmt.Sprintf("invalid project ID '%s'", *profile.DefaultProjectID)
+		return check
+	case profile.AccessKey == nil || profile.SecretKey == nil:
+		check.Status = doctorCheckWarning
+		check.Details = fmt.Sprintf("%s, cannot be checked without keys", *profile.DefaultProjectID)
+		return check
+	}
+
+	project, err := accountv3.NewProjectAPI(client).GetProject(&accountv3.ProjectAPIGetProjectRequest{
+		ProjectID: *profile.DefaultProjectID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		check.Status = doctorCheckError
+		check.Details = fmt.Sprintf("%s cannot be read: %s", *profile.DefaultProjectID, err)
+		return check
+	}
+
+	check.Details = fmt.Sprintf("%s (%s)", project.Name, project.ID)
+	if profile.DefaultOrganizationID != nil && project.OrganizationID != *profile.DefaultOrganizationID {
+		check.Status = doctorCheckError
+		check.Details = fmt.Sprintf("%s belongs to organization %s instead of the default organization", project.ID, project.OrganizationID)
+	}
+
+	return check
+}
+
+// localityDoctorChecks checks that the default region and zone are valid and consistent.
+func localityDoctorChecks(profile *scw.Profile) []*doctorCheck {
+	region := &doctorCheck{Check: "Default region", Status: doctorCheckOK}
+	switch {
+	case profile.DefaultRegion == nil:
+		region.Status = doctorCheckWarning
+		region.Details = fmt.Sprintf("not set, %s is used", scw.RegionFrPar)
+	case !validation.IsRegion(*profile.DefaultRegion):
+		region.Status = doctorCheckError
+		region.Details = fmt.Sprintf("invalid region '%s'", *profile.DefaultRegion)
+	default:
+		region.Details = *profile.DefaultRegion
+	}
+
+	zone := &doctorCheck{Check: "Default zone", Status: doctorCheckOK}
+	switch {
+	case profile.DefaultZone == nil:
+		zone.Status = doctorCheckWarning
+		zone.Details = fmt.Sprintf("not set, %s is used", scw.ZoneFrPar1)
+	case !validation.IsZone(*profile.DefaultZone):
+		zone.Status = doctorCheckError
+		zone.Details = fmt.Sprintf("invalid zone '%s'", *profile.DefaultZone)
+	default:
+		zone.Details = *profile.DefaultZone
+		zoneRegion, err := scw.Zone(*profile.DefaultZone).Region()
+		if err == nil && profile.DefaultRegion != nil && zoneRegion.String() != *profile.DefaultRegion {
+			zone.Status = doctorCheckError
+			zone.Details = fmt.Sprintf("%s is not in the default region %s", *profile.DefaultZone, *profile.DefaultRegion)
+		}
+	}
+
+	return []*doctorCheck{region, zone}
+}
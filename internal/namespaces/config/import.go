@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+const (
+	importFromFile      = "file"
+	importFromEnv       = "env"
+	importFromTerraform = "terraform"
+	importFromAWS       = "aws-style-credentials-file"
+)
+
+var (
+	terraformProviderRegex  = regexp.MustCompile(`provider\s+"scaleway"\s*{`)
+	terraformAttributeRegex = regexp.MustCompile(`^\s*(\w+)\s*=\s*"([^"]*)"\s*(?:#.*|//.*)?$`)
+)
+
+// loadImportedConfig loads the config to import from the given source.
+func loadImportedConfig(ctx context.Context, from string, file string) (*scw.Config, error) {
+	switch from {
+	case importFromEnv:
+		return loadEnvConfig(ctx)
+	case importFromTerraform:
+		if file == "" {
+			file = "."
+		}
+		return loadTerraformConfig(file)
+	case importFromAWS:
+		if file == "" {
+			file = filepath.Join(core.ExtractUserHomeDir(ctx), ".aws", "credentials")
+		}
+		return loadAWSCredentialsConfig(file)
+	default:
+		if file == "" {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("missing file to import"),
+				Hint: "Give the path of the configuration file to import, or import from another source with from=env",
+			}
+		}
+		return scw.LoadConfigFromPath(file)
+	}
+}
+
+// loadEnvConfig returns a config whose default profile holds the values of the SCW_* environment variables.
+func loadEnvConfig(ctx context.Context) (*scw.Config, error) {
+	profile := scw.Profile{}
+	found := false
+	for envKey, value := range map[string]**string{
+		scw.ScwAccessKeyEnv:             &profile.AccessKey,
+		scw.ScwSecretKeyEnv:             &profile.SecretKey,
+		scw.ScwAPIURLEnv:                &profile.APIURL,
+		scw.ScwDefaultOrganizationIDEnv: &profile.DefaultOrganizationID,
+		scw.ScwDefaultProjectIDEnv:      &profile.DefaultProjectID,
+		scw.ScwDefaultRegionEnv:         &profile.DefaultRegion,
+		scw.ScwDefaultZoneEnv:           &profile.DefaultZone,
+	} {
+		if envValue := core.ExtractEnv(ctx, envKey); envValue != "" {
+			*value = scw.StringPtr(envValue)
+			found = true
+		}
+	}
+	if insecure := core.ExtractEnv(ctx, scw.ScwInsecureEnv); insecure != "" {
+		value, err := strconv.ParseBool(insecure)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", scw.ScwInsecureEnv, insecure)
+		}
+		profile.Insecure = &value
+		found = true
+	}
+
+	if !found {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no SCW_* environment variable found"),
+			Hint: fmt.Sprintf("Set at least %s and %s", scw.ScwAccessKeyEnv, scw.ScwSecretKeyEnv),
+		}
+	}
+
+	return &scw.Config{Profile: profile}, nil
+}
+
+// loadTerraformConfig returns a config whose default profile holds the attributes of the scaleway provider blocks.
+// path is a Terraform file or a directory whose .tf files are read. Only string literals are imported, attributes set from variables are ignored.
+func loadTerraformConfig(path string) (*scw.Config, error) {
+	files := []string{path}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.tf"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	profile := scw.Profile{}
+	found := false
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		for attribute, value := range terraformProviderAttributes(content) {
+			switch attribute {
+			case "access_key":
+				profile.AccessKey = scw.StringPtr(value)
+			case "secret_key":
+				profile.SecretKey = scw.StringPtr(value)
+			case "organization_id":
+				profile.DefaultOrganizationID = scw.StringPtr(value)
+			case "project_id":
+				profile.DefaultProjectID = scw.StringPtr(value)
+			case "region":
+				profile.DefaultRegion = scw.StringPtr(value)
+			case "zone":
+				profile.DefaultZone = scw.StringPtr(value)
+			case "api_url":
+				profile.APIURL = scw.StringPtr(value)
+			default:
+				continue
+			}
+			found = true
+		}
+	}
+
+	if !found {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no scaleway provider configuration found in %s", path),
+			Hint: "The scaleway provider may read its configuration from the environment or from the Scaleway config file, which can be imported with from=env or from=file",
+		}
+	}
+
+	return &scw.Config{Profile: profile}, nil
+}
+
+// terraformProviderAttributes returns the string attributes of the scaleway provider blocks of a Terraform file.
+func terraformProviderAttributes(content []byte) map[string]string {
+	attributes := map[string]string{}
+	for _, loc := range terraformProviderRegex.FindAllIndex(content, -1) {
+		depth := 1
+		end := loc[1]
+		for ; end < len(content) && depth > 0; end++ {
+			switch content[end] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+
+		for _, line := range strings.Split(string(content[loc[1]:end]), "\n") {
+			if match := terraformAttributeRegex.FindStringSubmatch(line); match != nil {
+				attributes[match[1]] = match[2]
+			}
+		}
+	}
+
+	return attributes
+}
+
+// loadAWSCredentialsConfig returns a config holding the Scaleway credentials of an AWS style credentials file, as used by S3 tools.
+// The default section is imported in the default profile, other sections in profiles with the same name.
+// Sections whose access key is not a Scaleway access key are ignored.
+func loadAWSCredentialsConfig(path string) (*scw.Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &scw.Config{Profiles: map[string]*scw.Profile{}}
+	sections := map[string]map[string]string{}
+	sectionNames := []string(nil)
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(strings.TrimPrefix(strings.Trim(line, "[]"), "profile "))
+			if _, exists := sections[section]; !exists {
+				sections[section] = map[string]string{}
+				sectionNames = append(sectionNames, section)
+			}
+		default:
+			key, value, found := strings.Cut(line, "=")
+			if found && section != "" {
+				sections[section][strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, name := range sectionNames {
+		values := sections[name]
+		if !validation.IsAccessKey(values["aws_access_key_id"]) {
+			continue
+		}
+
+		profile := &scw.Profile{
+			AccessKey: scw.StringPtr(values["aws_access_key_id"]),
+		}
+		if secretKey := values["aws_secret_access_key"]; secretKey != "" {
+			profile.SecretKey = scw.StringPtr(secretKey)
+		}
+		if region := values["region"]; validation.IsRegion(region) {
+			profile.DefaultRegion = scw.StringPtr(region)
+		}
+
+		if name == "default" {
+			config.Profile = *profile
+		} else {
+			config.Profiles[name] = profile
+		}
+		found = true
+	}
+
+	if !found {
+		return nil, &core.CliError{
+			Err: fmt.Errorf("no Scaleway credentials found in %s", path),
+		}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,305 @@
+//go:build !wasm
+
+package console
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+)
+
+// resourcesLoadedMsg is sent when the resources of a namespace are listed.
+type resourcesLoadedMsg struct {
+	namespace int
+	resources []*consoleResource
+	err       error
+}
+
+// actionDoneMsg is sent when an action on a resource is done.
+type actionDoneMsg struct {
+	action   *consoleAction
+	resource *consoleResource
+	err      error
+}
+
+type consoleModel struct {
+	ctx        context.Context
+	namespaces []*consoleNamespace
+
+	// resources are the resources of each namespace, nil until the namespace is loaded
+	resources [][]*consoleResource
+	loading   []bool
+	current   int
+	cursor    int
+
+	search    string
+	searching bool
+	details   bool
+	// confirming is the action waiting for a confirmation
+	confirming *consoleAction
+	message    string
+}
+
+func newConsoleModel(ctx context.Context, namespaces []*consoleNamespace) *consoleModel {
+	return &consoleModel{
+		ctx:        ctx,
+		namespaces: namespaces,
+		resources:  make([][]*consoleResource, len(namespaces)),
+		loading:    make([]bool, len(namespaces)),
+	}
+}
+
+func runConsole(ctx context.Context, namespaces []*consoleNamespace) error {
+	p := tea.NewProgram(newConsoleModel(ctx, namespaces), tea.WithContext(ctx), tea.WithAltScreen())
+	_, err := p.Run()
+	if err != nil {
+		return fmt.Errorf("error running console: %w", err)
+	}
+	return nil
+}
+
+func (m *consoleModel) Init() tea.Cmd {
+	return m.load(m.current)
+}
+
+// load lists the resources of a namespace in the background.
+func (m *consoleModel) load(namespace int) tea.Cmd {
+	m.loading[namespace] = true
+	list := m.namespaces[namespace].List
+	return func() tea.Msg {
+		resources, err := list(m.ctx)
+		return resourcesLoadedMsg{namespace: namespace, resources: resources, err: err}
+	}
+}
+
+// visibleResources returns the resources of the current namespace matching the search.
+func (m *consoleModel) visibleResources() []*consoleResource {
+	return filterResources(m.resources[m.current], m.search)
+}
+
+func (m *consoleModel) selectedResource() *consoleResource {
+	resources := m.visibleResources()
+	if m.cursor < 0 || m.cursor >= len(resources) {
+		return nil
+	}
+	return resources[m.cursor]
+}
+
+func (m *consoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case resourcesLoadedMsg:
+		m.loading[msg.namespace] = false
+		if msg.err != nil {
+			m.message = fmt.Sprintf("cannot list %s: %s", m.namespaces[msg.namespace].Name, msg.err)
+			m.resources[msg.namespace] = []*consoleResource{}
+			return m, nil
+		}
+		m.resources[msg.namespace] = msg.resources
+		if resources := m.visibleResources(); m.cursor >= len(resources) && len(resources) > 0 {
+			m.cursor = len(resources) - 1
+		}
+		return m, nil
+	case actionDoneMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("%s %s failed: %s", msg.action.Name, msg.resource.Name, msg.err)
+		} else {
+			m.message = fmt.Sprintf("%s %s done", msg.action.Name, msg.resource.Name)
+		}
+		return m, m.load(m.current)
+	case tea.KeyMsg:
+		return m.updateKey(msg)
+	}
+
+	return m, nil
+}
+
+func (m *consoleModel) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.confirming != nil {
+		action := m.confirming
+		m.confirming = nil
+		if msg.String() != "y" {
+			m.message = action.Name + " canceled"
+			return m, nil
+		}
+		return m, m.run(action)
+	}
+
+	if m.searching {
+		switch msg.Type {
+		case tea.KeyEsc:
+			m.search = ""
+			m.searching = false
+		case tea.KeyEnter:
+			m.searching = false
+		case tea.KeyBackspace:
+			if m.search != "" {
+				m.search = m.search[:len(m.search)-1]
+			}
+		case tea.KeyRunes, tea.KeySpace:
+			m.search += string(msg.Runes)
+		}
+		m.cursor = 0
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		m.details = false
+	case "enter":
+		m.details = !m.details && m.selectedResource() != nil
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.visibleResources())-1 {
+			m.cursor++
+		}
+	case "tab", "shift+tab":
+		step := 1
+		if msg.String() == "shift+tab" {
+			step = len(m.namespaces) - 1
+		}
+		m.current = (m.current + step) % len(m.namespaces)
+		m.cursor, m.search, m.details = 0, "", false
+		if m.resources[m.current] == nil && !m.loading[m.current] {
+			return m, m.load(m.current)
+		}
+	case "/":
+		m.searching = true
+		m.details = false
+	case "r":
+		return m, m.load(m.current)
+	default:
+		for _, action := range m.namespaces[m.current].Actions {
+			if msg.String() != action.Key || m.selectedResource() == nil {
+				continue
+			}
+			if action.Confirm {
+				m.confirming = action
+				return m, nil
+			}
+			return m, m.run(action)
+		}
+	}
+
+	return m, nil
+}
+
+// run runs an action on the selected resource, commands taking over the terminal suspend the console until they exit.
+func (m *consoleModel) run(action *consoleAction) tea.Cmd {
+	resource := m.selectedResource()
+	if resource == nil {
+		return nil
+	}
+
+	if action.Exec != nil {
+		cmd, err := action.Exec(m.ctx, resource)
+		if err != nil {
+			m.message = fmt.Sprintf("%s %s failed: %s", action.Name, resource.Name, err)
+			return nil
+		}
+		return tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return actionDoneMsg{action: action, resource: resource, err: err}
+		})
+	}
+
+	m.message = fmt.Sprintf("%s %s...", action.Name, resource.Name)
+	return func() tea.Msg {
+		err := action.Run(m.ctx, resource)
+		return actionDoneMsg{action: action, resource: resource, err: err}
+	}
+}
+
+func (m *consoleModel) View() string {
+	s := &strings.Builder{}
+	for i, namespace := range m.namespaces {
+		if i == m.current {
+			fmt.Fprintf(s, "[%s] ", namespace.Name)
+		} else {
+			fmt.Fprintf(s, " %s  ", namespace.Name)
+		}
+	}
+	s.WriteString("\n\n")
+
+	switch {
+	case m.resources[m.current] == nil:
+		s.WriteString("Loading...\n")
+	case m.details:
+		s.WriteString(m.detailsView())
+	default:
+		s.WriteString(m.listView())
+	}
+
+	s.WriteString("\n")
+	if m.searching || m.search != "" {
+		fmt.Fprintf(s, "/%s\n", m.search)
+	}
+	if m.confirming != nil {
+		fmt.Fprintf(s, "%s %s? (y/N)\n", m.confirming.Name, m.selectedResource().Name)
+	} else if m.message != "" {
+		s.WriteString(m.message + "\n")
+	}
+	s.WriteString(m.helpView())
+
+	return s.String()
+}
+
+func (m *consoleModel) listView() string {
+	resources := m.visibleResources()
+	if len(resources) == 0 {
+		return "No resources\n"
+	}
+
+	nameWidth, statusWidth := len("NAME"), len("STATUS")
+	for _, resource := range resources {
+		if len(resource.Name) > nameWidth {
+			nameWidth = len(resource.Name)
+		}
+		if len(resource.Status) > statusWidth {
+			statusWidth = len(resource.Status)
+		}
+	}
+
+	s := &strings.Builder{}
+	fmt.Fprintf(s, "  %-*s  %-*s  %-8s  %s\n", nameWidth, "NAME", statusWidth, "STATUS", "LOCALITY", "ID")
+	for i, resource := range resources {
+		cursor := " "
+		if i == m.cursor {
+			cursor = ">"
+		}
+		fmt.Fprintf(s, "%s %-*s  %-*s  %-8s  %s\n", cursor, nameWidth, resource.Name, statusWidth, resource.Status, resource.Locality, resource.ID)
+	}
+	return s.String()
+}
+
+func (m *consoleModel) detailsView() string {
+	resource := m.selectedResource()
+	if resource == nil {
+		return "No resource selected\n"
+	}
+
+	details, err := human.Marshal(resource.Details, nil)
+	if err != nil {
+		return fmt.Sprintf("cannot show %s: %s\n", resource.Name, err)
+	}
+	return details + "\n"
+}
+
+func (m *consoleModel) helpView() string {
+	help := []string{"tab: namespace", "/: search", "enter: details", "r: refresh"}
+	for _, action := range m.namespaces[m.current].Actions {
+		help = append(help, action.Key+": "+action.Name)
+	}
+	help = append(help, "q: quit")
+	return strings.Join(help, " • ") + "\n"
+}
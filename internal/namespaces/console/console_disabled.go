@@ -0,0 +1,12 @@
+//go:build wasm
+
+package console
+
+import (
+	"context"
+	"fmt"
+)
+
+func runConsole(_ context.Context, _ []*consoleNamespace) error {
+	return fmt.Errorf("console is not implemented for current platform")
+}
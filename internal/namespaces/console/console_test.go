@@ -0,0 +1,103 @@
+//go:build !wasm
+
+package console
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func Test_fuzzyMatch(t *testing.T) {
+	assert.True(t, fuzzyMatch("", "web-server"))
+	assert.True(t, fuzzyMatch("wbsrv", "web-server"))
+	assert.True(t, fuzzyMatch("WEB", "web-server"))
+	assert.False(t, fuzzyMatch("srvweb", "web-server"))
+	assert.False(t, fuzzyMatch("db", "web-server"))
+}
+
+func Test_consoleModel(t *testing.T) {
+	deleted := []string(nil)
+	namespace := &consoleNamespace{
+		Name: "servers",
+		List: func(_ context.Context) ([]*consoleResource, error) {
+			return []*consoleResource{
+				{ID: "11111111-1111-1111-1111-111111111111", Name: "web-server", Status: "running"},
+				{ID: "22222222-2222-2222-2222-222222222222", Name: "database", Status: "stopped"},
+			}, nil
+		},
+		Actions: []*consoleAction{
+			{
+				Key:     "d",
+				Name:    "delete",
+				Confirm: true,
+				Run: func(_ context.Context, resource *consoleResource) error {
+					deleted = append(deleted, resource.ID)
+					return nil
+				},
+			},
+		},
+	}
+	keys := func(m *consoleModel, keys ...string) tea.Cmd {
+		cmd := tea.Cmd(nil)
+		for _, key := range keys {
+			msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+			switch key {
+			case "enter":
+				msg = tea.KeyMsg{Type: tea.KeyEnter}
+			case "esc":
+				msg = tea.KeyMsg{Type: tea.KeyEsc}
+			}
+			_, cmd = m.Update(msg)
+		}
+		return cmd
+	}
+	newModel := func() *consoleModel {
+		m := newConsoleModel(context.Background(), []*consoleNamespace{namespace})
+		m.Update(m.Init()())
+		return m
+	}
+
+	t.Run("search", func(t *testing.T) {
+		m := newModel()
+		keys(m, "/", "d", "b", "enter")
+		assert.Equal(t, "db", m.search)
+		assert.Equal(t, "database", m.selectedResource().Name)
+		assert.Contains(t, m.View(), "database")
+		assert.NotContains(t, m.View(), "web-server")
+
+		keys(m, "/", "esc")
+		assert.Len(t, m.visibleResources(), 2)
+	})
+
+	t.Run("delete confirmed", func(t *testing.T) {
+		deleted = nil
+		m := newModel()
+		keys(m, "j", "d")
+		assert.Contains(t, m.View(), "delete database? (y/N)")
+
+		cmd := keys(m, "y")
+		assert.NotZero(t, cmd)
+		m.Update(cmd())
+		assert.Equal(t, []string{"22222222-2222-2222-2222-222222222222"}, deleted)
+		assert.Equal(t, "delete database done", m.message)
+	})
+
+	t.Run("delete canceled", func(t *testing.T) {
+		deleted = nil
+		m := newModel()
+		keys(m, "d", "n")
+		assert.Zero(t, deleted)
+		assert.Equal(t, "delete canceled", m.message)
+	})
+
+	t.Run("details", func(t *testing.T) {
+		m := newModel()
+		keys(m, "enter")
+		assert.True(t, m.details)
+		keys(m, "esc")
+		assert.False(t, m.details)
+	})
+}
@@ -0,0 +1,81 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		consoleCommand(),
+	)
+}
+
+type consoleArgs struct {
+	ProjectID *string
+	Zone      scw.Zone
+	Region    scw.Region
+}
+
+func consoleCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Browse resources in a terminal UI`,
+		Long: `Start a terminal UI listing the resources of a project by namespace, a lightweight alternative to the web console for quick operations.
+Resources are filtered with a fuzzy search, their details are shown on selection and common actions are available:
+  - instance servers: start, stop, delete and ssh.
+  - k8s clusters and rdb instances: delete.
+
+Keys:
+  tab/shift+tab   switch namespace
+  up/down         move in the list
+  /               search, enter or esc to leave the search
+  enter           show the details of a resource, esc to go back
+  r               refresh the list
+  q, ctrl+c       quit
+
+The actions of the selected resource are shown at the bottom of the screen, deletions must be confirmed.`,
+		Namespace: "console",
+		ArgsType:  reflect.TypeOf(consoleArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			core.ZoneArgSpec(),
+			core.RegionArgSpec(),
+		},
+		Run: consoleRun,
+		Examples: []*core.Example{
+			{
+				Short: "Browse the resources of a project in Amsterdam",
+				Raw:   "scw console project-id=11111111-1111-1111-1111-111111111111 zone=nl-ams-1 region=nl-ams",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Start shell mode",
+				Command: "scw shell",
+			},
+		},
+	}
+}
+
+func consoleRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*consoleArgs)
+	if !interactive.IsInteractive {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("the console requires an interactive terminal"),
+			Hint: "Use the list commands of the namespaces instead, such as 'scw instance server list'",
+		}
+	}
+
+	err := runConsole(ctx, consoleNamespaces(ctx, args))
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.SuccessResult{Empty: true}, nil
+}
@@ -0,0 +1,234 @@
+package console
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	rdb "github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// consoleNamespace lists the resources of a namespace shown in a tab of the console.
+type consoleNamespace struct {
+	Name    string
+	List    func(ctx context.Context) ([]*consoleResource, error)
+	Actions []*consoleAction
+}
+
+// consoleResource is a line of the list of a namespace.
+type consoleResource struct {
+	ID       string
+	Name     string
+	Status   string
+	Locality string
+	// Details is the resource returned by the API, shown with the human marshaler
+	Details interface{}
+}
+
+// consoleAction is an action run on the selected resource when its key is pressed.
+// Exactly one of Run and Exec is set: Exec returns a command taking over the terminal, such as ssh.
+type consoleAction struct {
+	Key     string
+	Name    string
+	Confirm bool
+	Run     func(ctx context.Context, resource *consoleResource) error
+	Exec    func(ctx context.Context, resource *consoleResource) (*exec.Cmd, error)
+}
+
+func consoleNamespaces(ctx context.Context, args *consoleArgs) []*consoleNamespace {
+	client := core.ExtractClient(ctx)
+	return []*consoleNamespace{
+		instanceServerNamespace(client, args),
+		k8sClusterNamespace(client, args),
+		rdbInstanceNamespace(client, args),
+	}
+}
+
+func instanceServerNamespace(client *scw.Client, args *consoleArgs) *consoleNamespace {
+	api := instance.NewAPI(client)
+	serverAction := func(action instance.ServerAction) func(ctx context.Context, resource *consoleResource) error {
+		return func(ctx context.Context, resource *consoleResource) error {
+			_, err := api.ServerAction(&instance.ServerActionRequest{
+				Zone:     args.Zone,
+				ServerID: resource.ID,
+				Action:   action,
+			}, scw.WithContext(ctx))
+			return err
+		}
+	}
+
+	return &consoleNamespace{
+		Name: "instance servers",
+		List: func(ctx context.Context) ([]*consoleResource, error) {
+			res, err := api.ListServers(&instance.ListServersRequest{
+				Zone:    args.Zone,
+				Project: args.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			resources := make([]*consoleResource, 0, len(res.Servers))
+			for _, server := range res.Servers {
+				resources = append(resources, &consoleResource{
+					ID:       server.ID,
+					Name:     server.Name,
+					Status:   server.State.String(),
+					Locality: server.Zone.String(),
+					Details:  server,
+				})
+			}
+			return resources, nil
+		},
+		Actions: []*consoleAction{
+			{Key: "s", Name: "start", Run: serverAction(instance.ServerActionPoweron)},
+			{Key: "o", Name: "stop", Run: serverAction(instance.ServerActionPoweroff)},
+			{
+				Key:     "d",
+				Name:    "delete",
+				Confirm: true,
+				Run: func(ctx context.Context, resource *consoleResource) error {
+					server := resource.Details.(*instance.Server)
+					if server.State != instance.ServerStateStopped {
+						return fmt.Errorf("server %s must be stopped before being deleted", server.Name)
+					}
+					return api.DeleteServer(&instance.DeleteServerRequest{
+						Zone:     args.Zone,
+						ServerID: resource.ID,
+					}, scw.WithContext(ctx))
+				},
+			},
+			{
+				Key:  "x",
+				Name: "ssh",
+				Exec: func(_ context.Context, resource *consoleResource) (*exec.Cmd, error) {
+					server := resource.Details.(*instance.Server)
+					if server.State != instance.ServerStateRunning {
+						return nil, fmt.Errorf("server %s is not running", server.Name)
+					}
+					if server.PublicIP == nil {
+						return nil, fmt.Errorf("server %s does not have a public IP to connect to", server.Name)
+					}
+					return exec.Command("ssh", server.PublicIP.Address.String(), "-l", "root", "-t"), nil
+				},
+			},
+		},
+	}
+}
+
+func k8sClusterNamespace(client *scw.Client, args *consoleArgs) *consoleNamespace {
+	api := k8s.NewAPI(client)
+	return &consoleNamespace{
+		Name: "k8s clusters",
+		List: func(ctx context.Context) ([]*consoleResource, error) {
+			res, err := api.ListClusters(&k8s.ListClustersRequest{
+				Region:    args.Region,
+				ProjectID: args.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			resources := make([]*consoleResource, 0, len(res.Clusters))
+			for _, cluster := range res.Clusters {
+				resources = append(resources, &consoleResource{
+					ID:       cluster.ID,
+					Name:     cluster.Name,
+					Status:   cluster.Status.String(),
+					Locality: cluster.Region.String(),
+					Details:  cluster,
+				})
+			}
+			return resources, nil
+		},
+		Actions: []*consoleAction{
+			{
+				Key:     "d",
+				Name:    "delete",
+				Confirm: true,
+				Run: func(ctx context.Context, resource *consoleResource) error {
+					_, err := api.DeleteCluster(&k8s.DeleteClusterRequest{
+						Region:    args.Region,
+						ClusterID: resource.ID,
+					}, scw.WithContext(ctx))
+					return err
+				},
+			},
+		},
+	}
+}
+
+func rdbInstanceNamespace(client *scw.Client, args *consoleArgs) *consoleNamespace {
+	api := rdb.NewAPI(client)
+	return &consoleNamespace{
+		Name: "rdb instances",
+		List: func(ctx context.Context) ([]*consoleResource, error) {
+			res, err := api.ListInstances(&rdb.ListInstancesRequest{
+				Region:    args.Region,
+				ProjectID: args.ProjectID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			resources := make([]*consoleResource, 0, len(res.Instances))
+			for _, rdbInstance := range res.Instances {
+				resources = append(resources, &consoleResource{
+					ID:       rdbInstance.ID,
+					Name:     rdbInstance.Name,
+					Status:   rdbInstance.Status.String(),
+					Locality: rdbInstance.Region.String(),
+					Details:  rdbInstance,
+				})
+			}
+			return resources, nil
+		},
+		Actions: []*consoleAction{
+			{
+				Key:     "d",
+				Name:    "delete",
+				Confirm: true,
+				Run: func(ctx context.Context, resource *consoleResource) error {
+					_, err := api.DeleteInstance(&rdb.DeleteInstanceRequest{
+						Region:     args.Region,
+						InstanceID: resource.ID,
+					}, scw.WithContext(ctx))
+					return err
+				},
+			},
+		},
+	}
+}
+
+// fuzzyMatch reports whether the characters of the pattern appear in order in the text, ignoring the case.
+func fuzzyMatch(pattern string, text string) bool {
+	text = strings.ToLower(text)
+	for _, r := range strings.ToLower(pattern) {
+		index := strings.IndexRune(text, r)
+		if index < 0 {
+			return false
+		}
+		text = text[index+len(string(r)):]
+	}
+	return true
+}
+
+// filterResources returns the resources whose name, ID or status fuzzy matches the search.
+func filterResources(resources []*consoleResource, search string) []*consoleResource {
+	if search == "" {
+		return resources
+	}
+
+	filtered := []*consoleResource(nil)
+	for _, resource := range resources {
+		if fuzzyMatch(search, resource.Name) || fuzzyMatch(search, resource.ID) || fuzzyMatch(search, resource.Status) {
+			filtered = append(filtered, resource)
+		}
+	}
+	return filtered
+}
@@ -19,10 +19,24 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("container", "namespace", "create").Override(containerNamespaceCreateBuilder)
 	cmds.MustFind("container", "namespace", "update").Override(containerNamespaceUpdateBuilder)
 	cmds.MustFind("container", "namespace", "delete").Override(containerNamespaceDeleteBuilder)
+	cmds.MustFind("container", "token", "create").Override(containerTokenCreateBuilder)
 
 	if cmdDeploy := containerDeployCommand(); cmdDeploy != nil {
 		cmds.Add(cmdDeploy)
 	}
 
+	cmds.Add(containerLogs())
+
+	cmds.Add(core.NewWaitCommand(cmds.MustFind("container", "container", "get"), &core.WaitCommandConfig{
+		TerminalStatuses: []string{container.ContainerStatusReady.String(), container.ContainerStatusCreated.String(), container.ContainerStatusLocked.String(), container.ContainerStatusError.String()},
+		ErrorStatuses:    []string{container.ContainerStatusError.String()},
+		DefaultTimeout:   containerDeployTimeout,
+	}))
+	cmds.Add(core.NewWaitCommand(cmds.MustFind("container", "namespace", "get"), &core.WaitCommandConfig{
+		TerminalStatuses: []string{container.NamespaceStatusReady.String(), container.NamespaceStatusLocked.String(), container.NamespaceStatusError.String()},
+		ErrorStatuses:    []string{container.NamespaceStatusError.String()},
+		DefaultTimeout:   containerNamespaceActionTimeout,
+	}))
+
 	return cmds
 }
@@ -35,7 +35,7 @@ func waitForContainer(ctx context.Context, _, respI interface{}) (interface{}, e
 	return api.WaitForContainer(&container.WaitForContainerRequest{
 		ContainerID:   c.ID,
 		Region:        c.Region,
-		Timeout:       scw.TimeDurationPtr(containerDeployTimeout),
+		Timeout:       core.WaitTimeout(ctx, containerDeployTimeout),
 		RetryInterval: core.DefaultRetryInterval,
 	})
 }
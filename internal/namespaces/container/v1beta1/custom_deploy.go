@@ -12,7 +12,6 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
-	"time"
 
 	pack "github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -405,7 +404,7 @@ func DeployStepCreateContainer(t *tasks.Task, data *DeployStepPushImageResponse)
 	targetContainer, err = data.API.WaitForContainer(&container.WaitForContainerRequest{
 		Region:        data.Args.Region,
 		ContainerID:   targetContainer.ID,
-		Timeout:       scw.TimeDurationPtr(12*time.Minute + 30*time.Second),
+		Timeout:       core.WaitTimeout(t.Ctx, containerDeployTimeout),
 		RetryInterval: core.DefaultRetryInterval,
 	}, scw.WithContext(t.Ctx))
 	if err != nil {
@@ -435,7 +434,7 @@ func DeployStepDeployContainer(t *tasks.Task, data *DeployStepCreateContainerRes
 	targetContainer, err = data.API.WaitForContainer(&container.WaitForContainerRequest{
 		Region:        data.Args.Region,
 		ContainerID:   targetContainer.ID,
-		Timeout:       scw.TimeDurationPtr(12*time.Minute + 30*time.Second),
+		Timeout:       core.WaitTimeout(t.Ctx, containerDeployTimeout),
 		RetryInterval: core.DefaultRetryInterval,
 	}, scw.WithContext(t.Ctx))
 	if err != nil {
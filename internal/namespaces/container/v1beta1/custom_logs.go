@@ -0,0 +1,144 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	container "github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const containerLogsPollInterval = 2 * time.Second
+
+type containerLogsArgs struct {
+	ContainerID string
+	Follow      bool
+	Tail        uint32
+	Region      scw.Region
+}
+
+func containerLogs() *core.Command {
+	return &core.Command{
+		Short:     `Print the logs of a container`,
+		Long:      `Print the latest logs of a container, colored by level. With follow, new logs are printed as they come until the command is interrupted.`,
+		Namespace: "container",
+		Resource:  "logs",
+		ArgsType:  reflect.TypeOf(containerLogsArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "container-id",
+				Short:      `UUID of the container to print the logs of`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "follow",
+				Short: `Keep printing new logs`,
+			},
+			{
+				Name:    "tail",
+				Short:   `Number of latest logs to print`,
+				Default: core.DefaultValueSetter("100"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: containerLogsRun,
+		Examples: []*core.Example{
+			{
+				Short: "Follow the logs of a container",
+				Raw:   "scw container logs 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List your container logs",
+				Command: "scw container container get-logs",
+			},
+		},
+	}
+}
+
+func containerLogsRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*containerLogsArgs)
+
+	api := container.NewAPI(core.ExtractClient(ctx))
+	stdout := core.ExtractStdout(ctx)
+
+	pageSize := args.Tail
+	printedIDs := map[string]bool(nil)
+	for {
+		resp, err := api.ListLogs(&container.ListLogsRequest{
+			Region:      args.Region,
+			ContainerID: args.ContainerID,
+			OrderBy:     container.ListLogsRequestOrderByTimestampDesc,
+			PageSize:    &pageSize,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		var logs []*container.Log
+		logs, printedIDs = newContainerLogs(resp.Logs, printedIDs)
+		for _, log := range logs {
+			_, err := fmt.Fprintln(stdout, formatContainerLog(log))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !args.Follow {
+			return &core.SuccessResult{Empty: true}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &core.SuccessResult{Empty: true}, nil
+		case <-time.After(containerLogsPollInterval):
+		}
+
+		// Following polls only need the latest logs
+		pageSize = 100
+	}
+}
+
+// newContainerLogs returns the logs that have not been printed yet, oldest first, and the IDs of the given logs.
+// logs are expected to be ordered by descending timestamp.
+func newContainerLogs(logs []*container.Log, printedIDs map[string]bool) ([]*container.Log, map[string]bool) {
+	newLogs := []*container.Log(nil)
+	ids := make(map[string]bool, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		ids[logs[i].ID] = true
+		if !printedIDs[logs[i].ID] {
+			newLogs = append(newLogs, logs[i])
+		}
+	}
+
+	return newLogs, ids
+}
+
+func formatContainerLog(log *container.Log) string {
+	timestamp := ""
+	if log.Timestamp != nil {
+		timestamp = log.Timestamp.Format(time.RFC3339)
+	}
+
+	level := strings.ToUpper(log.Level)
+	switch level {
+	case "ERROR", "FATAL", "CRITICAL":
+		level = terminal.Style(level, color.FgRed)
+	case "WARN", "WARNING":
+		level = terminal.Style(level, color.FgYellow)
+	case "DEBUG", "TRACE":
+		level = terminal.Style(level, color.Faint)
+	default:
+		level = terminal.Style(level, color.FgBlue)
+	}
+
+	return fmt.Sprintf("%s %s %s", terminal.Style(timestamp, color.Faint), level, strings.TrimSuffix(log.Message, "\n"))
+}
@@ -0,0 +1,24 @@
+package diagnostic
+
+import (
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		diagnosticRoot(),
+		diagnosticBundleCommand(),
+	)
+}
+
+func diagnosticRoot() *core.Command {
+	return &core.Command{
+		Groups:    []string{"utility"},
+		Short:     `Diagnostic tools to report issues`,
+		Namespace: "diagnostic",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
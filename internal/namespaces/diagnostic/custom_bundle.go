@@ -0,0 +1,249 @@
+package diagnostic
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	statusPageURL     = "https://status.scaleway.com/api/v2/summary.json"
+	statusPageTimeout = 5 * time.Second
+	redacted          = "<redacted>"
+)
+
+// sensitiveLogRegexps match the secrets that can be found in debug logs, the first group is kept.
+var sensitiveLogRegexps = []*regexp.Regexp{
+	regexp.MustCompile(`("[a-z_]*(?:secret_key|secret|password|token|private_key|credentials)"\s*:\s*)"[^"]*"`),
+	regexp.MustCompile(`(?i)((?:X-Auth-Token|X-Session-Token|Authorization|Cookie|Set-Cookie):\s*)\S.*`),
+}
+
+type diagnosticBundleArgs struct {
+	Path string
+}
+
+type diagnosticBundleResult struct {
+	Path     string   `json:"path"`
+	Files    []string `json:"files"`
+	Warnings []string `json:"warnings"`
+}
+
+// bundleFile is a file added to the diagnostic bundle.
+type bundleFile struct {
+	Name    string
+	Content []byte
+}
+
+func diagnosticBundleCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Create a diagnostic bundle to attach to bug reports`,
+		Long: `Create a tarball to attach to bug reports, containing:
+  - the version of the CLI and the OS.
+  - the config file, with secret keys hidden, and the CLI config file.
+  - the SCW_* environment variables, with secret keys hidden.
+  - the debug logs of the last command run with --debug, with secrets redacted.
+  - the health of the Scaleway services reported by the status page.
+
+Review the content of the bundle before sharing it.`,
+		Namespace:            "diagnostic",
+		Resource:             "bundle",
+		ArgsType:             reflect.TypeOf(diagnosticBundleArgs{}),
+		AllowAnonymousClient: true,
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "path",
+				Short: `Path of the tarball, scw-diagnostic-<date>.tar.gz in the current directory by default`,
+			},
+		},
+		Run: diagnosticBundleRun,
+		Examples: []*core.Example{
+			{
+				Short: "Reproduce an issue in debug mode then create a diagnostic bundle",
+				Raw:   "scw instance server list -D\nscw diagnostic bundle",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Send a bug-report",
+				Command: "scw feedback bug",
+			},
+		},
+	}
+}
+
+func diagnosticBundleRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*diagnosticBundleArgs)
+	if args.Path == "" {
+		args.Path = fmt.Sprintf("scw-diagnostic-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	result := &diagnosticBundleResult{Path: args.Path}
+	files := []*bundleFile(nil)
+	addFile := func(name string, content []byte, err error) {
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s", name, err))
+			return
+		}
+		files = append(files, &bundleFile{Name: name, Content: content})
+		result.Files = append(result.Files, name)
+	}
+
+	addFile(versionBundleFile(ctx))
+	addFile(configBundleFile(ctx))
+	addFile(cliConfigBundleFile(ctx))
+	addFile(environmentBundleFile(ctx))
+	addFile(debugLogBundleFile(ctx))
+	addFile(statusBundleFile(ctx))
+
+	err := writeBundle(args.Path, files)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func versionBundleFile(ctx context.Context) (string, []byte, error) {
+	content, err := json.MarshalIndent(struct {
+		BuildInfo *core.BuildInfo `json:"build_info"`
+		OS        string          `json:"os"`
+		Arch      string          `json:"arch"`
+		NumCPU    int             `json:"num_cpu"`
+	}{
+		BuildInfo: core.ExtractBuildInfo(ctx),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		NumCPU:    runtime.NumCPU(),
+	}, "", "  ")
+
+	return "version.json", content, err
+}
+
+func configBundleFile(ctx context.Context) (string, []byte, error) {
+	config, err := scw.LoadConfigFromPath(core.ExtractConfigPath(ctx))
+	if err != nil {
+		return "config.yaml", nil, err
+	}
+
+	// String hides the secret keys
+	return "config.yaml", []byte(config.String()), nil
+}
+
+func cliConfigBundleFile(ctx context.Context) (string, []byte, error) {
+	content, err := os.ReadFile(core.ExtractCliConfigPath(ctx))
+	return "cli.yaml", content, err
+}
+
+func environmentBundleFile(ctx context.Context) (string, []byte, error) {
+	lines := []string(nil)
+	for _, variable := range os.Environ() {
+		name, _, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, "SCW_") {
+			continue
+		}
+		value := core.ExtractEnv(ctx, name)
+		if name == scw.ScwSecretKeyEnv {
+			value = redacted
+		}
+		lines = append(lines, name+"="+value)
+	}
+	sort.Strings(lines)
+
+	return "environment.txt", []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+func debugLogBundleFile(ctx context.Context) (string, []byte, error) {
+	content, err := os.ReadFile(core.LastDebugLogPath(ctx))
+	if errors.Is(err, os.ErrNotExist) {
+		err = fmt.Errorf("no debug logs, run the command to report with --debug first")
+	}
+	if err != nil {
+		return "last-debug.log", nil, err
+	}
+
+	return "last-debug.log", redactDebugLog(content), nil
+}
+
+// statusBundleFile fetches the health of the Scaleway services from the status page.
+func statusBundleFile(ctx context.Context) (string, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, statusPageTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, statusPageURL, nil)
+	if err != nil {
+		return "status.json", nil, err
+	}
+	response, err := core.ExtractHTTPClient(ctx).Do(request)
+	if err != nil {
+		return "status.json", nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "status.json", nil, fmt.Errorf("status page returned %s", response.Status)
+	}
+
+	content, err := io.ReadAll(response.Body)
+	return "status.json", content, err
+}
+
+// redactDebugLog redacts the secrets of debug logs, such as secret keys in response bodies.
+func redactDebugLog(content []byte) []byte {
+	for _, sensitiveRegexp := range sensitiveLogRegexps {
+		content = sensitiveRegexp.ReplaceAll(content, []byte(`${1}"`+redacted+`"`))
+	}
+
+	return content
+}
+
+func writeBundle(path string, files []*bundleFile) error {
+	bundle, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer bundle.Close()
+
+	gzipWriter := gzip.NewWriter(bundle)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		err := tarWriter.WriteHeader(&tar.Header{
+			Name:    file.Name,
+			Mode:    0o600,
+			Size:    int64(len(file.Content)),
+			ModTime: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tarWriter.Write(file.Content)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tarWriter.Close()
+	if err != nil {
+		return err
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return err
+	}
+
+	return bundle.Close()
+}
@@ -0,0 +1,60 @@
+package diagnostic
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_redactDebugLog(t *testing.T) {
+	logs := `X-Auth-Token: 11111111-1111-1111-1111-111111111111
+{
+  "access_key": "SCWXXXXXXXXXXXXXXXXX",
+  "secret_key": "11111111-1111-1111-1111-111111111111",
+  "admin_password": "p4ssw0rd"
+}`
+
+	redactedLogs := string(redactDebugLog([]byte(logs)))
+	assert.Contains(t, redactedLogs, `"access_key": "SCWXXXXXXXXXXXXXXXXX"`)
+	assert.Contains(t, redactedLogs, `"admin_password": "<redacted>"`)
+	assert.NotContains(t, redactedLogs, "11111111-1111-1111-1111-111111111111")
+	assert.NotContains(t, redactedLogs, "p4ssw0rd")
+}
+
+func Test_writeBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	err := writeBundle(path, []*bundleFile{
+		{Name: "version.json", Content: []byte(`{"os":"linux"}`)},
+		{Name: "environment.txt", Content: []byte("SCW_DEFAULT_ZONE=fr-par-1\n")},
+	})
+	require.NoError(t, err)
+
+	bundle, err := os.Open(path)
+	require.NoError(t, err)
+	defer bundle.Close()
+	gzipReader, err := gzip.NewReader(bundle)
+	require.NoError(t, err)
+	tarReader := tar.NewReader(gzipReader)
+
+	files := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tarReader)
+		require.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{
+		"version.json":    `{"os":"linux"}`,
+		"environment.txt": "SCW_DEFAULT_ZONE=fr-par-1\n",
+	}, files)
+}
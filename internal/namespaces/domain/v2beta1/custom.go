@@ -24,6 +24,8 @@ func GetCommands() *core.Commands {
 	cmds.Merge(core.NewCommands(
 		dnsRecordAddCommand(),
 		dnsRecordSetCommand(),
+		dnsRecordBatchCommand(),
+		dnsRecordMonitorCommand(),
 		dnsRecordDeleteCommand(),
 	))
 
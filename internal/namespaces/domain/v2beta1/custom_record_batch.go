@@ -0,0 +1,373 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type dnsRecordBatchRequest struct {
+	DNSZone string
+	File    string
+	DryRun  bool
+	Yes     bool
+}
+
+type dnsRecordBatchAction string
+
+const (
+	dnsRecordBatchActionAdd    = dnsRecordBatchAction("add")
+	dnsRecordBatchActionSet    = dnsRecordBatchAction("set")
+	dnsRecordBatchActionDelete = dnsRecordBatchAction("delete")
+)
+
+// dnsRecordBatchChange is a change described in the file given to dns record batch.
+type dnsRecordBatchChange struct {
+	Action   dnsRecordBatchAction `json:"action"`
+	Name     string               `json:"name"`
+	Type     domain.RecordType    `json:"type"`
+	Values   []string             `json:"values"`
+	TTL      *uint32              `json:"ttl"`
+	Priority uint32               `json:"priority"`
+	Comment  *string              `json:"comment"`
+}
+
+// dnsRecordBatchDiff is a record added (+) or removed (-) by a batch.
+type dnsRecordBatchDiff struct {
+	Operation string
+	Name      string
+	TTL       uint32
+	Type      domain.RecordType
+	Data      string
+}
+
+func formatDNSRecordBatchDiff(d *dnsRecordBatchDiff) string {
+	name := d.Name
+	if name == "" {
+		name = "@"
+	}
+	return fmt.Sprintf("%s %s %d %s %s", d.Operation, name, d.TTL, d.Type, d.Data)
+}
+
+func dnsRecordBatchCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply several changes to the records of a DNS zone`,
+		Long: `Apply the add, set and delete changes described in a YAML or JSON file in a single API call, so that all of them are applied or none of them.
+The file must contain a list of changes with the following fields: action (add | set | delete), name, type, values, ttl, priority and comment.
+A set change replaces all the records with the same name and type, a delete change without values deletes all the records with the same name and type.
+The records added and removed are displayed and must be confirmed before the changes are applied.`,
+		Namespace: "dns",
+		Verb:      "batch",
+		Resource:  "record",
+		ArgsType:  reflect.TypeOf(dnsRecordBatchRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "dns-zone",
+				Short:      "DNS zone in which to apply the changes",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "file",
+				Short:       "YAML or JSON list of changes to apply",
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: "Only display the records that would be added and removed",
+			},
+			{
+				Name:  "yes",
+				Short: "Do not ask for confirmation",
+			},
+		},
+		Run: dnsRecordBatchRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "TTL", FieldName: "TTL"},
+				{Label: "Type", FieldName: "Type"},
+				{Label: "Data", FieldName: "Data"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Preview the changes described in a file",
+				Raw:   "scw dns record batch my-domain.tld file=@changes.yaml dry-run=true",
+			},
+			{
+				Short: "Apply the changes described in a file",
+				Raw: `cat changes.yaml
+- {action: set, name: www, type: A, ttl: 300, values: [1.2.3.4, 1.2.3.5]}
+- {action: add, name: www2, type: CNAME, values: [www]}
+- {action: delete, name: old, type: CNAME}
+scw dns record batch my-domain.tld file=@changes.yaml`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Add or replace a single record",
+				Command: "scw dns record set",
+			},
+		},
+	}
+}
+
+func dnsRecordBatchRun(ctx context.Context, argsI interface{}) (i interface{}, e error) {
+	request := argsI.(*dnsRecordBatchRequest)
+
+	changes := []*dnsRecordBatchChange(nil)
+	err := yaml.Unmarshal([]byte(request.File), &changes)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the changes: %s", err)
+	}
+
+	recordChanges, err := buildDNSRecordBatchChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+
+	apiDomain := domain.NewAPI(core.ExtractClient(ctx))
+
+	records, err := apiDomain.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+		DNSZone: request.DNSZone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := diffDNSRecordBatch(records.Records, changes)
+	if request.DryRun {
+		return diff, nil
+	}
+	if len(diff) == 0 {
+		return &core.SuccessResult{Message: "No record to change"}, nil
+	}
+
+	if !request.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying changes to records must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		lines := make([]string, 0, len(diff))
+		for _, d := range diff {
+			lines = append(lines, formatDNSRecordBatchDiff(d))
+		}
+		_, _ = interactive.Println(strings.Join(lines, "\n"))
+
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	resp, err := apiDomain.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone: request.DNSZone,
+		Changes: recordChanges,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cannot apply the changes: %s", err)
+	}
+
+	return resp.Records, nil
+}
+
+// buildDNSRecordBatchChanges converts the changes of a batch file to API record changes.
+func buildDNSRecordBatchChanges(changes []*dnsRecordBatchChange) ([]*domain.RecordChange, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("no change to apply")
+	}
+
+	recordChanges := []*domain.RecordChange(nil)
+	for i, change := range changes {
+		if change.Type == "" {
+			return nil, fmt.Errorf("change %d: type is required", i)
+		}
+
+		switch change.Action {
+		case dnsRecordBatchActionAdd:
+			if len(change.Values) == 0 {
+				return nil, fmt.Errorf("change %d: at least one value is required to add a record", i)
+			}
+			recordChanges = append(recordChanges, &domain.RecordChange{
+				Add: &domain.RecordChangeAdd{
+					Records: dnsRecordBatchRecords(change),
+				},
+			})
+		case dnsRecordBatchActionSet:
+			if len(change.Values) == 0 {
+				return nil, fmt.Errorf("change %d: at least one value is required to set a record", i)
+			}
+			recordChanges = append(recordChanges, &domain.RecordChange{
+				Set: &domain.RecordChangeSet{
+					IDFields: &domain.RecordIdentifier{
+						Name: change.Name,
+						Type: change.Type,
+					},
+					Records: dnsRecordBatchRecords(change),
+				},
+			})
+		case dnsRecordBatchActionDelete:
+			if len(change.Values) == 0 {
+				recordChanges = append(recordChanges, &domain.RecordChange{
+					Delete: &domain.RecordChangeDelete{
+						IDFields: &domain.RecordIdentifier{
+							Name: change.Name,
+							Type: change.Type,
+							TTL:  change.TTL,
+						},
+					},
+				})
+			}
+			for _, value := range change.Values {
+				data := value
+				recordChanges = append(recordChanges, &domain.RecordChange{
+					Delete: &domain.RecordChangeDelete{
+						IDFields: &domain.RecordIdentifier{
+							Name: change.Name,
+							Type: change.Type,
+							Data: &data,
+							TTL:  change.TTL,
+						},
+					},
+				})
+			}
+		default:
+			return nil, fmt.Errorf("change %d: unknown action %q, must be add, set or delete", i, change.Action)
+		}
+	}
+
+	return recordChanges, nil
+}
+
+func dnsRecordBatchTTL(change *dnsRecordBatchChange) uint32 {
+	if change.TTL != nil {
+		return *change.TTL
+	}
+	ttl, _ := strconv.ParseUint(defaultTTL, 10, 32)
+	return uint32(ttl)
+}
+
+func dnsRecordBatchRecords(change *dnsRecordBatchChange) []*domain.Record {
+	records := make([]*domain.Record, 0, len(change.Values))
+	for _, value := range change.Values {
+		records = append(records, &domain.Record{
+			Name:     change.Name,
+			Type:     change.Type,
+			Data:     value,
+			TTL:      dnsRecordBatchTTL(change),
+			Priority: change.Priority,
+			Comment:  change.Comment,
+		})
+	}
+	return records
+}
+
+// diffDNSRecordBatch returns the records removed and added by applying the changes, in order, to the current records.
+func diffDNSRecordBatch(current []*domain.Record, changes []*dnsRecordBatchChange) []*dnsRecordBatchDiff {
+	records := make([]*domain.Record, len(current))
+	copy(records, current)
+
+	diff := []*dnsRecordBatchDiff(nil)
+	remove := func(match func(record *domain.Record) bool) {
+		kept := records[:0:0]
+		for _, record := range records {
+			if match(record) {
+				diff = append(diff, newDNSRecordBatchDiff("-", record))
+				continue
+			}
+			kept = append(kept, record)
+		}
+		records = kept
+	}
+	add := func(newRecords []*domain.Record) {
+		for _, record := range newRecords {
+			diff = append(diff, newDNSRecordBatchDiff("+", record))
+		}
+		records = append(records, newRecords...)
+	}
+
+	for _, change := range changes {
+		sameID := func(record *domain.Record) bool {
+			return record.Name == change.Name && record.Type == change.Type
+		}
+
+		switch change.Action {
+		case dnsRecordBatchActionAdd:
+			add(dnsRecordBatchRecords(change))
+		case dnsRecordBatchActionSet:
+			// Records that are left unchanged by the set are not part of the diff.
+			newRecords := dnsRecordBatchRecords(change)
+			unchanged := map[string]bool{}
+			for _, record := range records {
+				if sameID(record) {
+					unchanged[dnsRecordBatchKey(record)] = true
+				}
+			}
+			wanted := map[string]bool{}
+			toAdd := []*domain.Record(nil)
+			for _, record := range newRecords {
+				key := dnsRecordBatchKey(record)
+				wanted[key] = true
+				if !unchanged[key] {
+					toAdd = append(toAdd, record)
+				}
+			}
+			remove(func(record *domain.Record) bool {
+				return sameID(record) && !wanted[dnsRecordBatchKey(record)]
+			})
+			add(toAdd)
+		case dnsRecordBatchActionDelete:
+			values := map[string]bool{}
+			for _, value := range change.Values {
+				values[value] = true
+			}
+			remove(func(record *domain.Record) bool {
+				if !sameID(record) {
+					return false
+				}
+				if change.TTL != nil && record.TTL != *change.TTL {
+					return false
+				}
+				return len(values) == 0 || values[record.Data]
+			})
+		}
+	}
+
+	return diff
+}
+
+// dnsRecordBatchKey identifies a record among the records with the same name and type.
+func dnsRecordBatchKey(record *domain.Record) string {
+	return fmt.Sprintf("%s/%d", record.Data, record.TTL)
+}
+
+func newDNSRecordBatchDiff(operation string, record *domain.Record) *dnsRecordBatchDiff {
+	return &dnsRecordBatchDiff{
+		Operation: operation,
+		Name:      record.Name,
+		TTL:       record.TTL,
+		Type:      record.Type,
+		Data:      record.Data,
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildDNSRecordBatchChanges(t *testing.T) {
+	changes, err := buildDNSRecordBatchChanges([]*dnsRecordBatchChange{
+		{Action: dnsRecordBatchActionSet, Name: "www", Type: domain.RecordTypeA, TTL: scw.Uint32Ptr(300), Values: []string{"1.2.3.4", "1.2.3.5"}},
+		{Action: dnsRecordBatchActionAdd, Name: "www2", Type: domain.RecordTypeCNAME, Values: []string{"www"}},
+		{Action: dnsRecordBatchActionDelete, Name: "old", Type: domain.RecordTypeCNAME},
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Len(t, changes[0].Set.Records, 2)
+	assert.Equal(t, uint32(300), changes[0].Set.Records[0].TTL)
+	assert.Equal(t, uint32(3600), changes[1].Add.Records[0].TTL)
+	assert.Nil(t, changes[2].Delete.IDFields.Data)
+
+	_, err = buildDNSRecordBatchChanges([]*dnsRecordBatchChange{
+		{Action: "update", Name: "www", Type: domain.RecordTypeA, Values: []string{"1.2.3.4"}},
+	})
+	assert.EqualError(t, err, `change 0: unknown action "update", must be add, set or delete`)
+}
+
+func Test_diffDNSRecordBatch(t *testing.T) {
+	current := []*domain.Record{
+		{Name: "www", Type: domain.RecordTypeA, TTL: 300, Data: "1.2.3.4"},
+		{Name: "www", Type: domain.RecordTypeA, TTL: 300, Data: "1.2.3.6"},
+		{Name: "old", Type: domain.RecordTypeCNAME, TTL: 3600, Data: "www"},
+	}
+
+	diff := diffDNSRecordBatch(current, []*dnsRecordBatchChange{
+		{Action: dnsRecordBatchActionSet, Name: "www", Type: domain.RecordTypeA, TTL: scw.Uint32Ptr(300), Values: []string{"1.2.3.4", "1.2.3.5"}},
+		{Action: dnsRecordBatchActionDelete, Name: "old", Type: domain.RecordTypeCNAME},
+	})
+
+	lines := []string(nil)
+	for _, d := range diff {
+		lines = append(lines, formatDNSRecordBatchDiff(d))
+	}
+	assert.Equal(t, []string{
+		"- www 300 A 1.2.3.6",
+		"+ www 300 A 1.2.3.5",
+		"- old 3600 CNAME www",
+	}, lines)
+	assert.Len(t, current, 3, "current records should not be modified")
+}
@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const dnsRecordDriftExitCode = core.ExitCodeCheckFailed
+
+type dnsRecordMonitorRequest struct {
+	DNSZone     string
+	File        string
+	IgnoreTypes []domain.RecordType
+	Revert      bool
+	Yes         bool
+}
+
+func dnsRecordMonitorCommand() *core.Command {
+	return &core.Command{
+		Short: `Detect changes of the records of a DNS zone made outside of a reference file`,
+		Long: `Compare the records of a DNS zone with the records described in a YAML or JSON file, such as a file committed in a repository.
+The file must contain a list of records with the following fields: name, type, values, ttl and priority.
+When records differ, the records added (+) or removed (-) compared to the file are displayed and the command exits with code 6, which makes it suitable for a cron job.
+With revert=true, the records of the zone are replaced by the records of the file, after a confirmation unless yes=true is set.`,
+		Namespace: "dns",
+		Verb:      "monitor",
+		Resource:  "record",
+		ArgsType:  reflect.TypeOf(dnsRecordMonitorRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "dns-zone",
+				Short:      "DNS zone to monitor",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "file",
+				Short:       "YAML or JSON list of the expected records",
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:       "ignore-types.{index}",
+				Short:      "Types of records to ignore, such as the NS records managed by Scaleway",
+				EnumValues: domainTypes,
+			},
+			{
+				Name:  "revert",
+				Short: "Replace the records that changed by the records of the file",
+			},
+			{
+				Name:  "yes",
+				Short: "Revert the changes without asking for confirmation",
+			},
+		},
+		Run: dnsRecordMonitorRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "TTL", FieldName: "TTL"},
+				{Label: "Type", FieldName: "Type"},
+				{Label: "Data", FieldName: "Data"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Check that the records of a zone match a file",
+				Raw: `cat zone.yaml
+- {name: "", type: A, ttl: 3600, values: [1.2.3.4]}
+- {name: www, type: CNAME, values: [my-domain.tld.]}
+scw dns record monitor my-domain.tld file=@zone.yaml ignore-types.0=NS`,
+			},
+			{
+				Short: "Revert the changes made outside of a file from a cron job",
+				Raw:   "scw dns record monitor my-domain.tld file=@zone.yaml ignore-types.0=NS revert=true yes=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply several changes to the records of a DNS zone",
+				Command: "scw dns record batch",
+			},
+		},
+	}
+}
+
+func dnsRecordMonitorRun(ctx context.Context, argsI interface{}) (i interface{}, e error) {
+	request := argsI.(*dnsRecordMonitorRequest)
+
+	recordSets := []*dnsRecordBatchChange(nil)
+	err := yaml.Unmarshal([]byte(request.File), &recordSets)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the records: %s", err)
+	}
+	expected, err := dnsRecordMonitorExpectedRecords(recordSets)
+	if err != nil {
+		return nil, err
+	}
+
+	apiDomain := domain.NewAPI(core.ExtractClient(ctx))
+
+	records, err := apiDomain.ListDNSZoneRecords(&domain.ListDNSZoneRecordsRequest{
+		DNSZone: request.DNSZone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	ignored := map[domain.RecordType]bool{}
+	for _, recordType := range request.IgnoreTypes {
+		ignored[recordType] = true
+	}
+	current := []*domain.Record(nil)
+	for _, record := range records.Records {
+		if !ignored[record.Type] {
+			current = append(current, record)
+		}
+	}
+	wanted := []*domain.Record(nil)
+	for _, record := range expected {
+		if !ignored[record.Type] {
+			wanted = append(wanted, record)
+		}
+	}
+
+	diff := diffDNSRecordMonitor(current, wanted)
+	if len(diff) == 0 {
+		return &core.SuccessResult{Message: fmt.Sprintf("Records of %s match the file", request.DNSZone)}, nil
+	}
+
+	lines := make([]string, 0, len(diff))
+	for _, d := range diff {
+		lines = append(lines, formatDNSRecordBatchDiff(d))
+	}
+	details := strings.Join(lines, "\n")
+
+	if !request.Revert {
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("records of %s do not match the file", request.DNSZone),
+			Details: details,
+			Hint:    "Use revert=true to replace the records that changed by the records of the file",
+			Code:    dnsRecordDriftExitCode,
+		}
+	}
+
+	if !request.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("reverting the records must be confirmed"),
+				Details: details,
+				Hint:    "Use yes=true to revert the records without confirmation",
+				Code:    dnsRecordDriftExitCode,
+			}
+		}
+
+		_, _ = interactive.Println(details)
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to revert these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Revert canceled"}, nil
+		}
+	}
+
+	_, err = apiDomain.UpdateDNSZoneRecords(&domain.UpdateDNSZoneRecordsRequest{
+		DNSZone: request.DNSZone,
+		Changes: buildDNSRecordMonitorChanges(diff, wanted),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cannot revert the records: %s", err)
+	}
+
+	// The drift is still reported so that the job running the command notices it.
+	return nil, &core.CliError{
+		Err:     fmt.Errorf("records of %s did not match the file and were reverted", request.DNSZone),
+		Details: details,
+		Code:    dnsRecordDriftExitCode,
+	}
+}
+
+// dnsRecordMonitorExpectedRecords returns the records described in a reference file.
+func dnsRecordMonitorExpectedRecords(recordSets []*dnsRecordBatchChange) ([]*domain.Record, error) {
+	records := []*domain.Record(nil)
+	for i, recordSet := range recordSets {
+		if recordSet.Type == "" {
+			return nil, fmt.Errorf("record %d: type is required", i)
+		}
+		if len(recordSet.Values) == 0 {
+			return nil, fmt.Errorf("record %d: at least one value is required", i)
+		}
+		if recordSet.Name == "@" {
+			recordSet.Name = ""
+		}
+		records = append(records, dnsRecordBatchRecords(recordSet)...)
+	}
+	return records, nil
+}
+
+// dnsRecordMonitorKey identifies a record in a zone, comments are ignored.
+func dnsRecordMonitorKey(record *domain.Record) string {
+	return fmt.Sprintf("%s/%s/%s/%d/%d", record.Name, record.Type, record.Data, record.TTL, record.Priority)
+}
+
+// diffDNSRecordMonitor returns the records of the zone missing from the file (-) and the records of the file missing from the zone (+),
+// sorted by name and type.
+func diffDNSRecordMonitor(current []*domain.Record, expected []*domain.Record) []*dnsRecordBatchDiff {
+	currentKeys := map[string]bool{}
+	for _, record := range current {
+		currentKeys[dnsRecordMonitorKey(record)] = true
+	}
+	expectedKeys := map[string]bool{}
+	for _, record := range expected {
+		expectedKeys[dnsRecordMonitorKey(record)] = true
+	}
+
+	diff := []*dnsRecordBatchDiff(nil)
+	for _, record := range current {
+		if !expectedKeys[dnsRecordMonitorKey(record)] {
+			diff = append(diff, newDNSRecordBatchDiff("-", record))
+		}
+	}
+	for _, record := range expected {
+		if !currentKeys[dnsRecordMonitorKey(record)] {
+			diff = append(diff, newDNSRecordBatchDiff("+", record))
+		}
+	}
+
+	sort.SliceStable(diff, func(i, j int) bool {
+		if diff[i].Name != diff[j].Name {
+			return diff[i].Name < diff[j].Name
+		}
+		return diff[i].Type < diff[j].Type
+	})
+	return diff
+}
+
+// buildDNSRecordMonitorChanges returns the changes replacing the records of each name and type that differ from the file.
+func buildDNSRecordMonitorChanges(diff []*dnsRecordBatchDiff, expected []*domain.Record) []*domain.RecordChange {
+	changes := []*domain.RecordChange(nil)
+	done := map[string]bool{}
+	for _, d := range diff {
+		id := d.Name + "/" + string(d.Type)
+		if done[id] {
+			continue
+		}
+		done[id] = true
+
+		records := []*domain.Record(nil)
+		for _, record := range expected {
+			if record.Name == d.Name && record.Type == d.Type {
+				records = append(records, record)
+			}
+		}
+
+		if len(records) == 0 {
+			changes = append(changes, &domain.RecordChange{
+				Delete: &domain.RecordChangeDelete{
+					IDFields: &domain.RecordIdentifier{
+						Name: d.Name,
+						Type: d.Type,
+					},
+				},
+			})
+			continue
+		}
+		changes = append(changes, &domain.RecordChange{
+			Set: &domain.RecordChangeSet{
+				IDFields: &domain.RecordIdentifier{
+					Name: d.Name,
+					Type: d.Type,
+				},
+				Records: records,
+			},
+		})
+	}
+	return changes
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	domain "github.com/scaleway/scaleway-sdk-go/api/domain/v2beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_diffDNSRecordMonitor(t *testing.T) {
+	expected, err := dnsRecordMonitorExpectedRecords([]*dnsRecordBatchChange{
+		{Name: "@", Type: domain.RecordTypeA, TTL: scw.Uint32Ptr(300), Values: []string{"1.2.3.4"}},
+		{Name: "www", Type: domain.RecordTypeCNAME, Values: []string{"my-domain.tld."}},
+	})
+	require.NoError(t, err)
+
+	current := []*domain.Record{
+		{Name: "", Type: domain.RecordTypeA, TTL: 300, Data: "1.2.3.4"},
+		{Name: "www", Type: domain.RecordTypeCNAME, TTL: 3600, Data: "evil.tld."},
+		{Name: "new", Type: domain.RecordTypeTXT, TTL: 3600, Data: "unauthorized"},
+	}
+
+	diff := diffDNSRecordMonitor(current, expected)
+	lines := []string(nil)
+	for _, d := range diff {
+		lines = append(lines, formatDNSRecordBatchDiff(d))
+	}
+	assert.Equal(t, []string{
+		"- new 3600 TXT unauthorized",
+		"- www 3600 CNAME evil.tld.",
+		"+ www 3600 CNAME my-domain.tld.",
+	}, lines)
+
+	changes := buildDNSRecordMonitorChanges(diff, expected)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "new", changes[0].Delete.IDFields.Name)
+	assert.Nil(t, changes[0].Delete.IDFields.Data)
+	assert.Equal(t, "www", changes[1].Set.IDFields.Name)
+	require.Len(t, changes[1].Set.Records, 1)
+	assert.Equal(t, "my-domain.tld.", changes[1].Set.Records[0].Data)
+
+	assert.Empty(t, diffDNSRecordMonitor(expected, expected))
+}
+
+func Test_dnsRecordMonitorExpectedRecords(t *testing.T) {
+	_, err := dnsRecordMonitorExpectedRecords([]*dnsRecordBatchChange{
+		{Name: "www", Type: domain.RecordTypeA},
+	})
+	assert.EqualError(t, err, "record 0: at least one value is required")
+}
@@ -1,9 +1,16 @@
 package flexibleip
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+
 	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	flexibleip "github.com/scaleway/scaleway-sdk-go/api/flexibleip/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/logger"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 var (
@@ -17,3 +24,137 @@ var (
 		flexibleip.FlexibleIPStatusUpdating:  &human.EnumMarshalSpec{Attribute: color.FgBlue},
 	}
 )
+
+type fipMoveRequest struct {
+	FipID    string
+	ServerID string
+	Zone     scw.Zone
+}
+
+func fipMoveCommand() *core.Command {
+	return &core.Command{
+		Short: `Move a flexible IP to another server`,
+		Long: `Detach a flexible IP from its current Elastic Metal server and attach it to another one in one operation.
+If the flexible IP cannot be attached to the target server, it is attached back to its previous server.`,
+		Namespace: "fip",
+		Resource:  "ip",
+		Verb:      "move",
+		ArgsType:  reflect.TypeOf(fipMoveRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "fip-id",
+				Short:      `ID of the flexible IP to move`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "server-id",
+				Short:    `ID of the server on which to attach the flexible IP`,
+				Required: true,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1),
+		},
+		Run: fipMoveRun,
+		Examples: []*core.Example{
+			{
+				Short: "Move a flexible IP to another server",
+				Raw:   "scw fip ip move 11111111-1111-1111-1111-111111111111 server-id=22222222-2222-2222-2222-222222222222",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw fip ip attach",
+				Short:   "Attach an existing flexible IP to a server",
+			},
+			{
+				Command: "scw fip ip detach",
+				Short:   "Detach an existing flexible IP from a server",
+			},
+		},
+	}
+}
+
+func fipMoveRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*fipMoveRequest)
+	api := flexibleip.NewAPI(core.ExtractClient(ctx))
+
+	fip, err := api.GetFlexibleIP(&flexibleip.GetFlexibleIPRequest{
+		Zone:  args.Zone,
+		FipID: args.FipID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	previousServerID := ""
+	if fip.ServerID != nil {
+		previousServerID = *fip.ServerID
+	}
+	if previousServerID == args.ServerID {
+		return fip, nil
+	}
+
+	if previousServerID != "" {
+		_, err = api.DetachFlexibleIP(&flexibleip.DetachFlexibleIPRequest{
+			Zone:    args.Zone,
+			FipsIDs: []string{fip.ID},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		_, err = api.WaitForFlexibleIP(&flexibleip.WaitForFlexibleIPRequest{
+			Zone:  args.Zone,
+			FipID: fip.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	moved, err := attachFlexibleIP(ctx, api, args.Zone, fip.ID, args.ServerID)
+	if err == nil {
+		return moved, nil
+	}
+	if previousServerID == "" {
+		return nil, err
+	}
+
+	logger.Debugf("failed to move flexible IP %s, attaching it back to server %s", fip.ID, previousServerID)
+	if _, rollbackErr := attachFlexibleIP(ctx, api, args.Zone, fip.ID, previousServerID); rollbackErr != nil {
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("failed to move flexible IP %s to server %s: %w", fip.ID, args.ServerID, err),
+			Details: fmt.Sprintf("The flexible IP could not be attached back to server %s: %s", previousServerID, rollbackErr),
+			Hint:    fmt.Sprintf("Attach the flexible IP manually with: scw fip ip attach fips-ids.0=%s server-id=%s zone=%s", fip.ID, previousServerID, args.Zone),
+		}
+	}
+
+	return nil, &core.CliError{
+		Err:     fmt.Errorf("failed to move flexible IP %s to server %s: %w", fip.ID, args.ServerID, err),
+		Details: fmt.Sprintf("The flexible IP has been attached back to server %s", previousServerID),
+	}
+}
+
+// attachFlexibleIP attaches a flexible IP to a server and waits for the attachment to be done.
+func attachFlexibleIP(ctx context.Context, api *flexibleip.API, zone scw.Zone, fipID string, serverID string) (*flexibleip.FlexibleIP, error) {
+	_, err := api.AttachFlexibleIP(&flexibleip.AttachFlexibleIPRequest{
+		Zone:     zone,
+		FipsIDs:  []string{fipID},
+		ServerID: serverID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	fip, err := api.WaitForFlexibleIP(&flexibleip.WaitForFlexibleIPRequest{
+		Zone:  zone,
+		FipID: fipID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if fip.Status != flexibleip.FlexibleIPStatusAttached {
+		return nil, fmt.Errorf("flexible IP %s is %s after attachment", fipID, fip.Status)
+	}
+
+	return fip, nil
+}
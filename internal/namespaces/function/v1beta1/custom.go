@@ -1,11 +1,18 @@
 package function
 
 import (
+	"time"
+
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 )
 
+const (
+	functionDeployTimeout          = 12*time.Minute + 30*time.Second
+	functionNamespaceActionTimeout = 5 * time.Minute
+)
+
 func GetCommands() *core.Commands {
 	cmds := GetGeneratedCommands()
 
@@ -13,9 +20,24 @@ func GetCommands() *core.Commands {
 	human.RegisterMarshalerFunc(function.FunctionStatus(""), human.EnumMarshalFunc(functionStatusMarshalSpecs))
 	human.RegisterMarshalerFunc(function.CronStatus(""), human.EnumMarshalFunc(cronStatusMarshalSpecs))
 
+	cmds.MustFind("function", "token", "create").Override(functionTokenCreateBuilder)
+
 	if cmdDeploy := functionDeploy(); cmdDeploy != nil {
 		cmds.Add(cmdDeploy)
 	}
 
+	cmds.Add(functionLogs())
+
+	cmds.Add(core.NewWaitCommand(cmds.MustFind("function", "function", "get"), &core.WaitCommandConfig{
+		TerminalStatuses: []string{function.FunctionStatusReady.String(), function.FunctionStatusCreated.String(), function.FunctionStatusLocked.String(), function.FunctionStatusError.String()},
+		ErrorStatuses:    []string{function.FunctionStatusError.String()},
+		DefaultTimeout:   functionDeployTimeout,
+	}))
+	cmds.Add(core.NewWaitCommand(cmds.MustFind("function", "namespace", "get"), &core.WaitCommandConfig{
+		TerminalStatuses: []string{function.NamespaceStatusReady.String(), function.NamespaceStatusLocked.String(), function.NamespaceStatusError.String()},
+		ErrorStatuses:    []string{function.NamespaceStatusError.String()},
+		DefaultTimeout:   functionNamespaceActionTimeout,
+	}))
+
 	return cmds
 }
@@ -3,14 +3,19 @@
 package function
 
 import (
+	"archive/zip"
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/tasks"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
@@ -18,6 +23,7 @@ import (
 type functionDeployRequest struct {
 	NamespaceID string                   `json:"namespace_id"`
 	ZipFile     string                   `json:"zip_file"`
+	Src         string                   `json:"src"`
 	Runtime     function.FunctionRuntime `json:"runtime"`
 	Name        string                   `json:"name"`
 	Region      scw.Region               `json:"region"`
@@ -48,12 +54,27 @@ func functionDeploy() *core.Command {
 				Required:   true,
 			},
 			{
-				Name:     "zip-file",
-				Short:    "Path of the zip file that contains your code",
-				Required: true,
+				Name:       "zip-file",
+				Short:      "Path of the zip file that contains your code",
+				OneOfGroup: "source",
+			},
+			{
+				Name:       "src",
+				Short:      "Path of the directory that contains your code, it will be zipped before upload",
+				OneOfGroup: "source",
 			},
 			core.RegionArgSpec((&function.API{}).Regions()...),
 		},
+		Examples: []*core.Example{
+			{
+				Short: "Deploy a function from a zip file",
+				Raw:   "scw function deploy name=my-fn runtime=go120 zip-file=./handler.zip",
+			},
+			{
+				Short: "Deploy a function from a source directory",
+				Raw:   "scw function deploy name=my-fn runtime=go120 src=./handler",
+			},
+		},
 		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
 			args := argsI.(*functionDeployRequest)
 			scwClient := core.ExtractClient(ctx)
@@ -64,6 +85,19 @@ func functionDeploy() *core.Command {
 				return nil, err
 			}
 
+			if args.ZipFile == "" && args.Src == "" {
+				return nil, fmt.Errorf("one of zip-file or src must be provided")
+			}
+
+			if args.Src != "" {
+				zipFile, err := zipSource(args.Src)
+				if err != nil {
+					return nil, fmt.Errorf("failed to zip src: %w", err)
+				}
+				defer os.Remove(zipFile)
+				args.ZipFile = zipFile
+			}
+
 			zipFileStat, err := os.Stat(args.ZipFile)
 			if err != nil {
 				return nil, fmt.Errorf("failed to stat zip-file: %w", err)
@@ -89,6 +123,76 @@ func functionDeploy() *core.Command {
 	}
 }
 
+// zipSource archives the content of a directory (or a single file) in a temporary zip file and returns its path.
+func zipSource(src string) (string, error) {
+	zipFile, err := os.CreateTemp("", "scw-function-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer zipFile.Close()
+
+	writer := zip.NewWriter(zipFile)
+
+	srcStat, err := os.Stat(src)
+	if err != nil {
+		return "", err
+	}
+	root := src
+	if !srcStat.IsDir() {
+		root = filepath.Dir(src)
+	}
+
+	err = filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relativePath)
+		header.Method = zip.Deflate
+
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(w, file)
+		return err
+	})
+	if err != nil {
+		_ = os.Remove(zipFile.Name())
+		return "", err
+	}
+
+	if err := writer.Close(); err != nil {
+		_ = os.Remove(zipFile.Name())
+		return "", err
+	}
+
+	return zipFile.Name(), nil
+}
+
 func validateRuntime(api *function.API, region scw.Region, runtime function.FunctionRuntime) error {
 	runtimeName := string(runtime)
 
@@ -214,36 +318,26 @@ func DeployStepFunctionUpload(httpClient *http.Client, scwClient *scw.Client, ap
 			return nil, err
 		}
 
-		zip, err := os.Open(zipPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read zip file: %w", err)
-		}
-		defer zip.Close()
-
-		req, err := http.NewRequest(http.MethodPut, uploadURL.URL, zip)
-		if err != nil {
-			return nil, fmt.Errorf("failed to init request: %w", err)
-		}
-		req = req.WithContext(t.Ctx)
-		req.ContentLength = zipSize
-
+		header := http.Header{}
 		for headerName, headerList := range uploadURL.Headers {
-			for _, header := range *headerList {
-				req.Header.Add(headerName, header)
+			for _, value := range *headerList {
+				header.Add(headerName, value)
 			}
 		}
-
 		secretKey, _ := scwClient.GetSecretKey()
-		req.Header.Add("X-Auth-Token", secretKey)
-
-		resp, err := httpClient.Do(req)
+		header.Add("X-Auth-Token", secretKey)
+
+		// The upload URL only accepts the whole zip file in a single request, the upload cannot be resumed.
+		config := core.UploadConfig(t.Ctx, "upload")
+		config.PartSize = -1
+		config.ManifestDir = ""
+		_, err = upload.File(t.Ctx, zipPath, &upload.PutTarget{
+			HTTPClient: httpClient,
+			URL:        uploadURL.URL,
+			Header:     header,
+		}, config)
 		if err != nil {
-			return nil, fmt.Errorf("failed to send upload request: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("failed to upload function (Status: %d)", resp.StatusCode)
+			return nil, fmt.Errorf("failed to upload function: %w", err)
 		}
 
 		return fc, nil
@@ -1,13 +1,17 @@
 package function
 
 import (
+	"archive/zip"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_Deploy(t *testing.T) {
@@ -34,6 +38,27 @@ func Test_Deploy(t *testing.T) {
 	}))
 }
 
+func Test_zipSource(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "lib"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "handler.go"), []byte("package handler"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "lib", "lib.go"), []byte("package lib"), 0600))
+
+	zipFile, err := zipSource(src)
+	require.NoError(t, err)
+	defer os.Remove(zipFile)
+
+	reader, err := zip.OpenReader(zipFile)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	names := []string(nil)
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+	}
+	assert.ElementsMatch(t, []string{"handler.go", "lib/lib.go"}, names)
+}
+
 func testDeleteFunctionNamespaceAfter(functionName string) func(*core.AfterFuncCtx) error {
 	return func(ctx *core.AfterFuncCtx) error {
 		api := function.NewAPI(ctx.Client)
@@ -0,0 +1,144 @@
+package function
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const functionLogsPollInterval = 2 * time.Second
+
+type functionLogsArgs struct {
+	FunctionID string
+	Follow     bool
+	Tail       uint32
+	Region     scw.Region
+}
+
+func functionLogs() *core.Command {
+	return &core.Command{
+		Short:     `Print the logs of a function`,
+		Long:      `Print the latest logs of a function, colored by level. With follow, new logs are printed as they come until the command is interrupted.`,
+		Namespace: "function",
+		Resource:  "logs",
+		ArgsType:  reflect.TypeOf(functionLogsArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "function-id",
+				Short:      `UUID of the function to print the logs of`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "follow",
+				Short: `Keep printing new logs`,
+			},
+			{
+				Name:    "tail",
+				Short:   `Number of latest logs to print`,
+				Default: core.DefaultValueSetter("100"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: functionLogsRun,
+		Examples: []*core.Example{
+			{
+				Short: "Follow the logs of a function",
+				Raw:   "scw function logs 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List application logs",
+				Command: "scw function function get-logs",
+			},
+		},
+	}
+}
+
+func functionLogsRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*functionLogsArgs)
+
+	api := function.NewAPI(core.ExtractClient(ctx))
+	stdout := core.ExtractStdout(ctx)
+
+	pageSize := args.Tail
+	printedIDs := map[string]bool(nil)
+	for {
+		resp, err := api.ListLogs(&function.ListLogsRequest{
+			Region:     args.Region,
+			FunctionID: args.FunctionID,
+			OrderBy:    function.ListLogsRequestOrderByTimestampDesc,
+			PageSize:   &pageSize,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		var logs []*function.Log
+		logs, printedIDs = newFunctionLogs(resp.Logs, printedIDs)
+		for _, log := range logs {
+			_, err := fmt.Fprintln(stdout, formatFunctionLog(log))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !args.Follow {
+			return &core.SuccessResult{Empty: true}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &core.SuccessResult{Empty: true}, nil
+		case <-time.After(functionLogsPollInterval):
+		}
+
+		// Following polls only need the latest logs
+		pageSize = 100
+	}
+}
+
+// newFunctionLogs returns the logs that have not been printed yet, oldest first, and the IDs of the given logs.
+// logs are expected to be ordered by descending timestamp.
+func newFunctionLogs(logs []*function.Log, printedIDs map[string]bool) ([]*function.Log, map[string]bool) {
+	newLogs := []*function.Log(nil)
+	ids := make(map[string]bool, len(logs))
+	for i := len(logs) - 1; i >= 0; i-- {
+		ids[logs[i].ID] = true
+		if !printedIDs[logs[i].ID] {
+			newLogs = append(newLogs, logs[i])
+		}
+	}
+
+	return newLogs, ids
+}
+
+func formatFunctionLog(log *function.Log) string {
+	timestamp := ""
+	if log.Timestamp != nil {
+		timestamp = log.Timestamp.Format(time.RFC3339)
+	}
+
+	level := strings.ToUpper(log.Level)
+	switch level {
+	case "ERROR", "FATAL", "CRITICAL":
+		level = terminal.Style(level, color.FgRed)
+	case "WARN", "WARNING":
+		level = terminal.Style(level, color.FgYellow)
+	case "DEBUG", "TRACE":
+		level = terminal.Style(level, color.Faint)
+	default:
+		level = terminal.Style(level, color.FgBlue)
+	}
+
+	return fmt.Sprintf("%s %s %s", terminal.Style(timestamp, color.Faint), level, strings.TrimSuffix(log.Message, "\n"))
+}
@@ -0,0 +1,27 @@
+package function
+
+import (
+	"testing"
+
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newFunctionLogs(t *testing.T) {
+	logIDs := func(logs []*function.Log) []string {
+		ids := []string(nil)
+		for _, log := range logs {
+			ids = append(ids, log.ID)
+		}
+		return ids
+	}
+
+	logs, printedIDs := newFunctionLogs([]*function.Log{{ID: "3"}, {ID: "2"}, {ID: "1"}}, nil)
+	assert.Equal(t, []string{"1", "2", "3"}, logIDs(logs))
+
+	logs, printedIDs = newFunctionLogs([]*function.Log{{ID: "5"}, {ID: "4"}, {ID: "3"}}, printedIDs)
+	assert.Equal(t, []string{"4", "5"}, logIDs(logs))
+
+	logs, _ = newFunctionLogs([]*function.Log{{ID: "5"}, {ID: "4"}, {ID: "3"}}, printedIDs)
+	assert.Empty(t, logs)
+}
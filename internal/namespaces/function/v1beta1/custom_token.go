@@ -0,0 +1,12 @@
+package function
+
+import (
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func functionTokenCreateBuilder(c *core.Command) *core.Command {
+	// The token is only returned when it is created
+	c.ShowSensitiveResult = true
+
+	return c
+}
@@ -1,17 +1,23 @@
 package namespaces
 
 import (
+	"os"
+
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	accountv3 "github.com/scaleway/scaleway-cli/v2/internal/namespaces/account/v3"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/alias"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/app"
 	applesilicon "github.com/scaleway/scaleway-cli/v2/internal/namespaces/applesilicon/v1alpha1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/apply"
 	autocompleteNamespace "github.com/scaleway/scaleway-cli/v2/internal/namespaces/autocomplete"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/baremetal/v1"
 	billing "github.com/scaleway/scaleway-cli/v2/internal/namespaces/billing/v2alpha1"
 	block "github.com/scaleway/scaleway-cli/v2/internal/namespaces/block/v1alpha1"
 	cockpit "github.com/scaleway/scaleway-cli/v2/internal/namespaces/cockpit/v1beta1"
 	configNamespace "github.com/scaleway/scaleway-cli/v2/internal/namespaces/config"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/console"
 	container "github.com/scaleway/scaleway-cli/v2/internal/namespaces/container/v1beta1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/diagnostic"
 	documentdb "github.com/scaleway/scaleway-cli/v2/internal/namespaces/documentdb/v1beta1"
 	domain "github.com/scaleway/scaleway-cli/v2/internal/namespaces/domain/v2beta1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/feedback"
@@ -28,12 +34,17 @@ import (
 	jobs "github.com/scaleway/scaleway-cli/v2/internal/namespaces/jobs/v1alpha1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/k8s/v1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/lb/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/login"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/maintenance"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/marketplace/v2"
 	mnq "github.com/scaleway/scaleway-cli/v2/internal/namespaces/mnq/v1beta1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/network"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/object/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/plugin"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/rdb/v1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/redis/v1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/registry/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/resource"
 	secret "github.com/scaleway/scaleway-cli/v2/internal/namespaces/secret/v1alpha1"
 	serverless_sqldb "github.com/scaleway/scaleway-cli/v2/internal/namespaces/serverless_sqldb/v1alpha1"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/shell"
@@ -41,11 +52,14 @@ import (
 	versionNamespace "github.com/scaleway/scaleway-cli/v2/internal/namespaces/version"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/vpc/v2"
 	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/vpcgw/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/watcher"
 	webhosting "github.com/scaleway/scaleway-cli/v2/internal/namespaces/webhosting/v1alpha1"
+	"github.com/scaleway/scaleway-cli/v2/internal/namespaces/wizard"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 // Enable beta in the code when products are in beta
-//var beta = os.Getenv(scw.ScwEnableBeta) == "true"
+var beta = os.Getenv(scw.ScwEnableBeta) == "true"
 
 // GetCommands returns a list of all commands in the CLI.
 // It is used by both scw and scw-qa.
@@ -61,6 +75,7 @@ func GetCommands() *core.Commands {
 		k8s.GetCommands(),
 		marketplace.GetCommands(),
 		initNamespace.GetCommands(),
+		login.GetCommands(),
 		configNamespace.GetCommands(),
 		accountv3.GetCommands(),
 		autocompleteNamespace.GetCommands(),
@@ -68,6 +83,12 @@ func GetCommands() *core.Commands {
 		versionNamespace.GetCommands(),
 		registry.GetCommands(),
 		feedback.GetCommands(),
+		diagnostic.GetCommands(),
+		plugin.GetCommands(),
+		network.GetCommands(),
+		maintenance.GetCommands(),
+		resource.GetCommands(),
+		watcher.GetCommands(),
 		info.GetCommands(),
 		rdb.GetCommands(),
 		lb.GetCommands(),
@@ -83,6 +104,8 @@ func GetCommands() *core.Commands {
 		redis.GetCommands(),
 		secret.GetCommands(),
 		shell.GetCommands(),
+		console.GetCommands(),
+		wizard.GetCommands(),
 		tem.GetCommands(),
 		alias.GetCommands(),
 		webhosting.GetCommands(),
@@ -94,9 +117,12 @@ func GetCommands() *core.Commands {
 		ipam.GetCommands(),
 		jobs.GetCommands(),
 		serverless_sqldb.GetCommands(),
+		apply.GetCommands(),
 	)
 
-	//if beta {}
+	if beta {
+		commands.Merge(app.GetCommands())
+	}
 
 	return commands
 }
@@ -12,6 +12,7 @@ func GetCommands() *core.Commands {
 		helpRoot(),
 		newHelpCommand("output", shortOutput, longOutput),
 		newHelpCommand("date", shortDate, longDate),
+		newHelpCommand("errors", shortErrors, longErrors),
 	)
 }
 
@@ -0,0 +1,48 @@
+package help
+
+const (
+	shortErrors = "Get help about how errors and exit codes work in the CLI"
+	longErrors  = `Errors and exit codes
+
+Errors are printed on stderr. With a JSON output they are printed as a JSON object so that scripts can parse them.
+
+	scw instance server get 11111111-1111-1111-1111-111111111111 -o json=pretty
+
+	{
+	  "message": "cannot find resource 'instance_server' with ID '11111111-1111-1111-1111-111111111111'",
+	  "error": {
+	    "resource": "instance_server",
+	    "resource_id": "11111111-1111-1111-1111-111111111111"
+	  },
+	  "code": "not_found",
+	  "resource": "instance_server"
+	}
+
+- Fields of a JSON error
+
+	message: the error message
+	error: the original error returned by the API
+	details: more details about the error, if any
+	hint: how to fix the error, if any
+	code: the code of the error, one of usage, not_found, quota, timeout, check_failed or job_failed
+	resource: the type of the resource the error is about, if any
+
+- Exit codes
+
+	0: the command succeeded
+	1: the command failed, no specific exit code applies
+	2: usage error, the command, its arguments or its flags are invalid
+	3: the resource targeted by the command does not exist
+	4: the quotas of the organization are exceeded
+	5: the --wait of the command timed out
+	6: the check run by the command failed, such as a storage threshold exceeded or a DNS drift
+	7: the job waited for by the command did not succeed without an exit code between 1 and 255
+	130: the command was interrupted
+
+	scw instance server get 11111111-1111-1111-1111-111111111111
+	if [ $? -eq 3 ]; then echo "the server does not exist"; fi
+
+Commands waiting for a job, such as scw jobs run wait, exit with the exit code of the job when it fails with one.
+Their JSON error has the job_failed code whatever the exit code.
+`
+)
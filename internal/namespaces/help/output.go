@@ -36,6 +36,34 @@ You can select the columns that you want to print with commands that return a li
 	NAME                                            PUBLIC IP
 	scw-cool-franklin                               51.15.251.251
 
+Vertical output
+
+Lists can be displayed as records of keys and values, which is easier to read for lists with many columns
+
+	scw instance server list -o human=vertical
+
+	ID         088b01da-9ba7-40d2-bc55-eb3170f42185
+	NAME       scw-cool-franklin
+	TYPE       DEV1-S
+	STATE      running
+	ZONE       fr-par-1
+	PUBLIC IP  51.15.251.251
+
+Tables in a terminal
+
+When a table is wider than the terminal, UUIDs are shortened (088b01da…2185), long values are truncated
+and the less useful columns are hidden. Use --no-truncate or the wide output to print the full table.
+The COLUMNS environment variable sets the width tables are fitted to, even when the output is not a terminal.
+
+	scw instance server list --no-truncate
+
+Sensitive values
+
+Passwords, secret keys and tokens are masked in every output format. Use --show-sensitive to print them.
+They are not masked in the results of the commands returning secrets that cannot be read again, such as scw iam api-key create.
+
+	scw rdb user create instance-id=11111111-1111-1111-1111-111111111111 name=app --show-sensitive
+
 
 Standard JSON output
 
@@ -32,6 +32,13 @@ func GetCommands() *core.Commands {
 
 	cmds.Merge(core.NewCommands(
 		initWithSSHCommand(),
+		apiKeyRotateCommand(),
+		apiKeyCheckCommand(),
+		policyApplyCommand(),
+		policyDiffCommand(),
+		policyDriftCheckCommand(),
+		auditLogCommand(),
+		auditLogExportCommand(),
 	))
 
 	// These commands have an "optional" organization-id that is required for now.
@@ -46,6 +53,12 @@ func GetCommands() *core.Commands {
 		cmds.MustFind(commandPath...).Override(setOrganizationDefaultValue)
 	}
 
+	// The secret key of an API key is only returned when it is created.
+	cmds.MustFind("iam", "api-key", "create").Override(func(c *core.Command) *core.Command {
+		c.ShowSensitiveResult = true
+		return c
+	})
+
 	// Autocomplete permission set names using IAM API.
 	cmds.MustFind("iam", "policy", "create").Override(func(c *core.Command) *core.Command {
 		c.ArgSpecs.GetByName("rules.{index}.permission-set-names.{index}").AutoCompleteFunc = func(ctx context.Context, _ string) core.AutocompleteSuggestions {
@@ -0,0 +1,235 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type apiKeyRotateRequest struct {
+	AccessKey     string
+	ExpiresAt     *time.Time
+	UpdateProfile bool
+	SecretID      string
+	DeleteOld     bool
+	GracePeriod   time.Duration
+	Region        scw.Region
+}
+
+type apiKeyRotateResult struct {
+	AccessKey      string `json:"access_key"`
+	SecretKey      string `json:"secret_key"`
+	OldAccessKey   string `json:"old_access_key"`
+	ProfileUpdated string `json:"profile_updated,omitempty"`
+	SecretVersion  uint32 `json:"secret_version,omitempty"`
+	OldKeyDeleted  bool   `json:"old_key_deleted"`
+}
+
+// apiKeySecretData is the content of the secret version holding a rotated API key.
+type apiKeySecretData struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+func apiKeyRotateCommand() *core.Command {
+	return &core.Command{
+		Short: `Rotate an API key`,
+		Long: `Create a new API key for the same bearer, default project and description as an existing one, then optionally propagate it and delete the old one.
+
+The new key can be written in the active profile of the CLI config, when this profile uses the old key, and in a new version of a Secret Manager secret, as a JSON object with access_key and secret_key.
+With delete-old, the old key is deleted after the grace period, the command waits until then. Otherwise the old key is kept.`,
+		Namespace: "iam",
+		Resource:  "api-key",
+		Verb:      "rotate",
+		Groups:    []string{"workflow"},
+		// The secret key of the new API key cannot be read again
+		ShowSensitiveResult: true,
+		ArgsType:            reflect.TypeOf(apiKeyRotateRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "access-key",
+				Short:      `Access key of the API key to rotate`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "expires-at",
+				Short: `Expiration date of the new API key`,
+			},
+			{
+				Name:  "update-profile",
+				Short: `Write the new API key in the active profile of the CLI config`,
+			},
+			{
+				Name:  "secret-id",
+				Short: `ID of a Secret Manager secret in which to add a version holding the new API key`,
+			},
+			{
+				Name:  "delete-old",
+				Short: `Delete the old API key after the grace period`,
+			},
+			{
+				Name:    "grace-period",
+				Short:   `Time to wait before deleting the old API key`,
+				Default: core.DefaultValueSetter("0s"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: apiKeyRotateRun,
+		Examples: []*core.Example{
+			{
+				Short: "Rotate the API key of the active profile and delete the old one",
+				Raw:   "scw iam api-key rotate SCW11111111111111111 update-profile=true delete-old=true",
+			},
+			{
+				Short: "Rotate an API key stored in a secret and delete the old one after one hour",
+				Raw:   "scw iam api-key rotate SCW11111111111111111 secret-id=11111111-1111-1111-1111-111111111111 delete-old=true grace-period=1h",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create an API key",
+				Command: "scw iam api-key create",
+			},
+			{
+				Short:   "Delete an API key",
+				Command: "scw iam api-key delete",
+			},
+		},
+	}
+}
+
+func apiKeyRotateRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*apiKeyRotateRequest)
+
+	client := core.ExtractClient(ctx)
+	api := iam.NewAPI(client)
+
+	oldKey, err := api.GetAPIKey(&iam.GetAPIKeyRequest{
+		AccessKey: args.AccessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// The profile is checked before creating the new key so that a mismatch does not leave an unused key.
+	configPath := core.ExtractConfigPath(ctx)
+	profileName := core.ExtractProfileName(ctx)
+	config := (*scw.Config)(nil)
+	if args.UpdateProfile {
+		config, err = scw.LoadConfigFromPath(configPath)
+		if err != nil {
+			return nil, err
+		}
+		profile, err := config.GetProfile(profileName)
+		if err != nil {
+			return nil, err
+		}
+		if profile.AccessKey == nil || *profile.AccessKey != oldKey.AccessKey {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("profile %s does not use API key %s", profileName, oldKey.AccessKey),
+				Hint: "Select the profile using this API key with --profile",
+			}
+		}
+	}
+
+	newKey, err := api.CreateAPIKey(&iam.CreateAPIKeyRequest{
+		ApplicationID:    oldKey.ApplicationID,
+		UserID:           oldKey.UserID,
+		ExpiresAt:        args.ExpiresAt,
+		DefaultProjectID: &oldKey.DefaultProjectID,
+		Description:      oldKey.Description,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if newKey.SecretKey == nil {
+		return nil, fmt.Errorf("no secret key returned for API key %s", newKey.AccessKey)
+	}
+	_, _ = interactive.Printf("API key %s created\n", newKey.AccessKey)
+
+	result := &apiKeyRotateResult{
+		AccessKey:    newKey.AccessKey,
+		SecretKey:    *newKey.SecretKey,
+		OldAccessKey: oldKey.AccessKey,
+	}
+
+	if args.SecretID != "" {
+		data, err := json.Marshal(&apiKeySecretData{
+			AccessKey: newKey.AccessKey,
+			SecretKey: *newKey.SecretKey,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		version, err := secret.NewAPI(client).CreateSecretVersion(&secret.CreateSecretVersionRequest{
+			Region:      args.Region,
+			SecretID:    args.SecretID,
+			Data:        data,
+			Description: scw.StringPtr("API key " + newKey.AccessKey),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, apiKeyRotatePartialError(result, err)
+		}
+		result.SecretVersion = version.Revision
+		_, _ = interactive.Printf("secret %s updated to revision %d\n", args.SecretID, version.Revision)
+	}
+
+	if args.UpdateProfile {
+		// GetProfile returns a merged copy for named profiles, the keys are written in the profile itself.
+		profile := &config.Profile
+		if profileName != scw.DefaultProfileName {
+			profile = config.Profiles[profileName]
+		}
+		profile.AccessKey = &result.AccessKey
+		profile.SecretKey = &result.SecretKey
+		err = config.SaveTo(configPath)
+		if err != nil {
+			return nil, apiKeyRotatePartialError(result, err)
+		}
+		result.ProfileUpdated = profileName
+		_, _ = interactive.Printf("profile %s updated\n", profileName)
+	}
+
+	if !args.DeleteOld {
+		return result, nil
+	}
+
+	if args.GracePeriod > 0 {
+		_, _ = interactive.Printf("waiting %s before deleting API key %s\n", args.GracePeriod, oldKey.AccessKey)
+		select {
+		case <-ctx.Done():
+			return nil, apiKeyRotatePartialError(result, ctx.Err())
+		case <-time.After(args.GracePeriod):
+		}
+	}
+
+	err = api.DeleteAPIKey(&iam.DeleteAPIKeyRequest{
+		AccessKey: oldKey.AccessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, apiKeyRotatePartialError(result, err)
+	}
+	result.OldKeyDeleted = true
+
+	return result, nil
+}
+
+// apiKeyRotatePartialError returns an error for a rotation that stopped once the new key was created,
+// the new key is given in the details as its secret key cannot be retrieved again.
+func apiKeyRotatePartialError(result *apiKeyRotateResult, err error) error {
+	return &core.CliError{
+		Err:     fmt.Errorf("API key rotation did not complete: %w", err),
+		Details: fmt.Sprintf("New API key: access key %s, secret key %s", result.AccessKey, result.SecretKey),
+		Hint:    fmt.Sprintf("Store the new API key then delete the old one with: scw iam api-key delete %s", result.OldAccessKey),
+	}
+}
@@ -0,0 +1,104 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// apiKeyExpirationWarningDelay is the time before expiration from which the check warns about it.
+const apiKeyExpirationWarningDelay = 7 * 24 * time.Hour
+
+type apiKeyCheckResult struct {
+	AccessKey        string     `json:"access_key"`
+	Profile          string     `json:"profile"`
+	Valid            bool       `json:"valid"`
+	ExpiresAt        *time.Time `json:"expires_at"`
+	DefaultProjectID string     `json:"default_project_id,omitempty"`
+	Warning          string     `json:"warning,omitempty"`
+}
+
+func apiKeyCheckCommand() *core.Command {
+	return &core.Command{
+		Short: `Check the API key of the current credentials`,
+		Long: `Check that the API key used by the CLI, from the active profile or the environment, is valid and report when it expires.
+
+An expired, revoked or unknown API key makes the command fail. An API key without permission to read itself is valid but its expiration is not known.`,
+		Namespace: "iam",
+		Resource:  "api-key",
+		Verb:      "check",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+		Run:       apiKeyCheckRun,
+		Examples: []*core.Example{
+			{
+				Short: "Check the API key of the profile 'prod'",
+				Raw:   "scw -p prod iam api-key check",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Renew the API key of the profile",
+				Command: "scw login refresh=true",
+			},
+			{
+				Short:   "Check the config of the profile",
+				Command: "scw config doctor",
+			},
+		},
+	}
+}
+
+func apiKeyCheckRun(ctx context.Context, _ interface{}) (interface{}, error) {
+	client := core.ExtractClient(ctx)
+	accessKey, exists := client.GetAccessKey()
+	if !exists {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no API key to check"),
+			Hint: "Set an API key with scw login or scw init",
+		}
+	}
+
+	result := &apiKeyCheckResult{
+		AccessKey: accessKey,
+		Profile:   core.ExtractProfileName(ctx),
+	}
+
+	apiKey, err := iam.NewAPI(client).GetAPIKey(&iam.GetAPIKeyRequest{
+		AccessKey: accessKey,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		// The API key authenticated the request but cannot read itself.
+		responseError := &scw.ResponseError{}
+		if errors.As(err, new(*scw.PermissionsDeniedError)) || (errors.As(err, &responseError) && responseError.StatusCode == http.StatusForbidden) {
+			result.Valid = true
+			result.Warning = "the API key cannot read itself, its expiration is unknown"
+			return result, nil
+		}
+		return nil, err
+	}
+
+	result.Valid = true
+	result.ExpiresAt = apiKey.ExpiresAt
+	result.DefaultProjectID = apiKey.DefaultProjectID
+	result.Warning = apiKeyExpirationWarning(apiKey.ExpiresAt, time.Now())
+
+	return result, nil
+}
+
+// apiKeyExpirationWarning returns a warning when an API key expires soon.
+func apiKeyExpirationWarning(expiresAt *time.Time, now time.Time) string {
+	if expiresAt == nil || expiresAt.Sub(now) > apiKeyExpirationWarningDelay {
+		return ""
+	}
+
+	return fmt.Sprintf("the API key expires in %s, renew it with: scw login refresh=true", expiresAt.Sub(now).Round(time.Hour))
+}
@@ -0,0 +1,82 @@
+package iam
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_apiKeyRotatePartialError(t *testing.T) {
+	err := apiKeyRotatePartialError(&apiKeyRotateResult{
+		AccessKey:    "SCW22222222222222222",
+		SecretKey:    "22222222-2222-2222-2222-222222222222",
+		OldAccessKey: "SCW11111111111111111",
+	}, errors.New("timeout"))
+
+	cliErr, ok := err.(*core.CliError)
+	assert.True(t, ok)
+	assert.Equal(t, "API key rotation did not complete: timeout", cliErr.Err.Error())
+	assert.Contains(t, cliErr.Details, "SCW22222222222222222")
+	assert.Contains(t, cliErr.Details, "22222222-2222-2222-2222-222222222222")
+	assert.Contains(t, cliErr.Hint, "scw iam api-key delete SCW11111111111111111")
+}
+
+func Test_apiKeyExpirationWarning(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, "", apiKeyExpirationWarning(nil, now))
+	assert.Equal(t, "", apiKeyExpirationWarning(scw.TimePtr(now.Add(30*24*time.Hour)), now))
+	assert.Equal(t, "the API key expires in 48h0m0s, renew it with: scw login refresh=true", apiKeyExpirationWarning(scw.TimePtr(now.Add(48*time.Hour)), now))
+}
+
+func Test_apiKeyCreateShowsSecretKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/iam/v1alpha1/api-keys", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_key": "SCW22222222222222222",
+			"secret_key": "22222222-2222-2222-2222-222222222222",
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultOrganizationID("11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultProjectID("11111111-1111-1111-1111-111111111111"),
+	)
+	require.NoError(t, err)
+
+	t.Run("json", core.Test(&core.TestConfig{
+		Commands: GetCommands(),
+		Cmd:      "scw iam api-key create -o json",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				assert.Contains(t, string(ctx.Stdout), `"secret_key":"22222222-2222-2222-2222-222222222222"`)
+			},
+		),
+		Client: client,
+	}))
+
+	t.Run("human", core.Test(&core.TestConfig{
+		Commands: GetCommands(),
+		Cmd:      "scw iam api-key create",
+		Check: core.TestCheckCombine(
+			core.TestCheckExitCode(0),
+			func(t *testing.T, ctx *core.CheckFuncCtx) {
+				assert.Contains(t, string(ctx.Stdout), "22222222-2222-2222-2222-222222222222")
+			},
+		),
+		Client: client,
+	}))
+}
@@ -0,0 +1,191 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type auditLogExportRequest struct {
+	Since          time.Duration
+	Principal      string
+	Action         iam.LogAction
+	ResourceType   iam.LogResourceType
+	Format         string
+	OrganizationID *string
+}
+
+func auditLogCommand() *core.Command {
+	return &core.Command{
+		Short:     `Audit log commands`,
+		Long:      `Audit log commands.`,
+		Namespace: "iam",
+		Resource:  "audit-log",
+	}
+}
+
+func auditLogExportCommand() *core.Command {
+	return &core.Command{
+		Short: `Export the audit logs of an organization`,
+		Long: `Export the IAM logs of an organization, recording who created, updated or deleted API keys, users, applications, groups and policies, to build compliance reports.
+
+Logs can be filtered by principal, the ID of the user or application at the origin of the log, by action and by resource type. They are ordered by creation date.
+With format, logs are exported as a JSON array or as CSV instead of the regular output.`,
+		Namespace: "iam",
+		Resource:  "audit-log",
+		Verb:      "export",
+		ArgsType:  reflect.TypeOf(auditLogExportRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:    "since",
+				Short:   `Age of the oldest logs to export`,
+				Default: core.DefaultValueSetter("7d"),
+			},
+			{
+				Name:  "principal",
+				Short: `Only export the logs of this user or application ID`,
+			},
+			{
+				Name:       "action",
+				Short:      `Only export the logs of this action`,
+				EnumValues: []string{"created", "updated", "deleted"},
+			},
+			{
+				Name:       "resource-type",
+				Short:      `Only export the logs of this type of resource`,
+				EnumValues: []string{"api_key", "user", "application", "group", "policy"},
+			},
+			{
+				Name:       "format",
+				Short:      `Export the logs as JSON or CSV instead of the regular output`,
+				EnumValues: []string{"json", "csv"},
+			},
+			core.OrganizationIDArgSpec(),
+		},
+		Run: auditLogExportRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Created At", FieldName: "CreatedAt"},
+				{Label: "Principal", FieldName: "BearerID"},
+				{Label: "Action", FieldName: "Action"},
+				{Label: "Resource Type", FieldName: "ResourceType"},
+				{Label: "Resource ID", FieldName: "ResourceID"},
+				{Label: "IP", FieldName: "IP"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Export the logs of the last 30 days as CSV",
+				Raw:   "scw iam audit-log export since=30d format=csv > audit.csv",
+			},
+			{
+				Short: "Export the policies deleted by an application",
+				Raw:   "scw iam audit-log export principal=11111111-1111-1111-1111-111111111111 action=deleted resource-type=policy format=json",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List logs",
+				Command: "scw iam log list",
+			},
+		},
+	}
+}
+
+func auditLogExportRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*auditLogExportRequest)
+	client := core.ExtractClient(ctx)
+
+	organizationID, err := iamOrganizationID(ctx, args.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &iam.ListLogsRequest{
+		OrderBy:        iam.ListLogsRequestOrderByCreatedAtAsc,
+		OrganizationID: organizationID,
+		CreatedAfter:   scw.TimePtr(time.Now().Add(-args.Since)),
+		Action:         args.Action,
+		ResourceType:   args.ResourceType,
+	}
+	// Search matches both bearer and resource IDs, logs are then filtered on the bearer.
+	if args.Principal != "" {
+		req.Search = &args.Principal
+	}
+
+	resp, err := iam.NewAPI(client).ListLogs(req, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	logs := filterAuditLogs(resp.Logs, args.Principal)
+
+	switch args.Format {
+	case "json":
+		if logs == nil {
+			logs = []*iam.Log{}
+		}
+		jsonLogs, err := json.MarshalIndent(logs, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return core.RawResult(string(jsonLogs) + "\n"), nil
+	case "csv":
+		csvLogs, err := formatAuditLogsCSV(logs)
+		if err != nil {
+			return nil, err
+		}
+		return core.RawResult(csvLogs), nil
+	}
+
+	return logs, nil
+}
+
+// filterAuditLogs returns the logs whose bearer is principal, all logs are returned when principal is empty.
+func filterAuditLogs(logs []*iam.Log, principal string) []*iam.Log {
+	if principal == "" {
+		return logs
+	}
+
+	filtered := []*iam.Log(nil)
+	for _, log := range logs {
+		if log.BearerID == principal {
+			filtered = append(filtered, log)
+		}
+	}
+
+	return filtered
+}
+
+func formatAuditLogsCSV(logs []*iam.Log) (string, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	err := w.Write([]string{"id", "created_at", "principal", "action", "resource_type", "resource_id", "ip", "user_agent"})
+	if err != nil {
+		return "", err
+	}
+	for _, log := range logs {
+		createdAt := ""
+		if log.CreatedAt != nil {
+			createdAt = log.CreatedAt.Format(time.RFC3339)
+		}
+		ip := ""
+		if log.IP != nil {
+			ip = log.IP.String()
+		}
+		err := w.Write([]string{log.ID, createdAt, log.BearerID, log.Action.String(), log.ResourceType.String(), log.ResourceID, ip, log.UserAgent})
+		if err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+
+	return buf.String(), w.Error()
+}
@@ -0,0 +1,46 @@
+package iam
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_auditLogsExport(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	logs := []*iam.Log{
+		{
+			ID:           "log-1",
+			CreatedAt:    scw.TimePtr(createdAt),
+			IP:           net.ParseIP("1.2.3.4"),
+			UserAgent:    "scaleway-cli",
+			Action:       iam.LogActionDeleted,
+			BearerID:     "bearer-1",
+			ResourceType: iam.LogResourceTypePolicy,
+			ResourceID:   "policy-1",
+		},
+		{
+			ID:           "log-2",
+			Action:       iam.LogActionCreated,
+			BearerID:     "bearer-2",
+			ResourceType: iam.LogResourceTypeAPIKey,
+			ResourceID:   "bearer-1",
+		},
+	}
+
+	assert.Len(t, filterAuditLogs(logs, ""), 2)
+	filtered := filterAuditLogs(logs, "bearer-1")
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "log-1", filtered[0].ID)
+
+	csvLogs, err := formatAuditLogsCSV(logs)
+	assert.NoError(t, err)
+	assert.Equal(t, `id,created_at,principal,action,resource_type,resource_id,ip,user_agent
+log-1,2024-01-02T03:04:05Z,bearer-1,deleted,policy,policy-1,1.2.3.4,scaleway-cli
+log-2,,bearer-2,created,api_key,bearer-1,,
+`, csvLogs)
+}
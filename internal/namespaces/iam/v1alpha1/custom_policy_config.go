@@ -0,0 +1,896 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	iamConfigOperationCreate = "create"
+	iamConfigOperationUpdate = "update"
+	iamConfigOperationDelete = "delete"
+
+	iamConfigResourceApplication = "application"
+	iamConfigResourceGroup       = "group"
+	iamConfigResourcePolicy      = "policy"
+)
+
+// iamConfig is the declarative configuration of the applications, groups and policies of an organization.
+// Resources are identified by their name and users by their email.
+type iamConfig struct {
+	Applications []*iamConfigApplication `json:"applications,omitempty"`
+	Groups       []*iamConfigGroup       `json:"groups,omitempty"`
+	Policies     []*iamConfigPolicy      `json:"policies,omitempty"`
+}
+
+type iamConfigApplication struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type iamConfigGroup struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Users        []string `json:"users,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+}
+
+// iamConfigPolicy is attributed to at most one of Group, Application and User.
+type iamConfigPolicy struct {
+	Name        string           `json:"name"`
+	Description string           `json:"description,omitempty"`
+	Group       string           `json:"group,omitempty"`
+	Application string           `json:"application,omitempty"`
+	User        string           `json:"user,omitempty"`
+	Rules       []*iamConfigRule `json:"rules"`
+}
+
+// iamConfigRule applies to either the given projects or the whole organization.
+type iamConfigRule struct {
+	PermissionSets []string `json:"permission_sets"`
+	ProjectIDs     []string `json:"project_ids,omitempty"`
+	Organization   bool     `json:"organization,omitempty"`
+}
+
+// iamConfigChange is a change needed to reconcile an organization with a configuration.
+type iamConfigChange struct {
+	Operation string
+	Resource  string
+	Name      string
+}
+
+// iamConfigPlan holds the changes needed to reconcile an organization with a configuration and what is needed to apply them.
+type iamConfigPlan struct {
+	organizationID string
+	state          *iamConfigState
+	desired        *iamConfig
+	changes        []*iamConfigChange
+}
+
+// iamConfigState is the live configuration of an organization and the IDs of its resources.
+type iamConfigState struct {
+	config         *iamConfig
+	applicationIDs map[string]string
+	groupIDs       map[string]string
+	policyIDs      map[string]string
+	userIDs        map[string]string
+}
+
+type iamConfigRequest struct {
+	Path           string
+	Prune          bool
+	OrganizationID *string
+}
+
+type iamConfigApplyRequest struct {
+	Path           string
+	Prune          bool
+	Yes            bool
+	OrganizationID *string
+}
+
+// iamConfigArgSpecs returns the arg specs shared by the commands handling IAM configuration files, extra arg specs are added before the organization ID.
+func iamConfigArgSpecs(extra ...*core.ArgSpec) core.ArgSpecs {
+	argSpecs := core.ArgSpecs{
+		{
+			Name:       "path",
+			Short:      `YAML or JSON file, or directory of such files, holding the configuration`,
+			Required:   true,
+			Positional: true,
+		},
+		{
+			Name:  "prune",
+			Short: `Delete the applications, groups and policies of the organization missing from the configuration`,
+		},
+	}
+	argSpecs = append(argSpecs, extra...)
+	return append(argSpecs, core.OrganizationIDArgSpec())
+}
+
+func iamConfigView() *core.View {
+	return &core.View{
+		Fields: []*core.ViewField{
+			{Label: "Operation", FieldName: "Operation"},
+			{Label: "Resource", FieldName: "Resource"},
+			{Label: "Name", FieldName: "Name"},
+		},
+	}
+}
+
+func policyApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply IAM configuration files to an organization`,
+		Long: `Reconcile the applications, groups and policies of an organization with configuration files, to manage access control as code.
+
+The configuration holds lists of applications, groups and policies, which are matched by name. Group members are users, given by email, and applications. A policy is attributed to a group, an application or a user and its rules apply to projects or to the whole organization.
+Files of a directory are merged. Resources missing from the organization are created and resources that differ are updated. With prune, resources missing from the configuration are deleted, except the applications and policies that cannot be edited.
+The changes are displayed and must be confirmed before being applied.`,
+		Namespace: "iam",
+		Resource:  "policy",
+		Verb:      "apply",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(iamConfigApplyRequest{}),
+		ArgSpecs: iamConfigArgSpecs(&core.ArgSpec{
+			Name:  "yes",
+			Short: `Do not ask for confirmation`,
+		}),
+		Run:  policyApplyRun,
+		View: iamConfigView(),
+		Examples: []*core.Example{
+			{
+				Short: "Apply the configuration files of a directory",
+				Raw:   "scw iam policy apply ./iam",
+			},
+			{
+				Short: "Apply a configuration file and delete the resources it does not hold",
+				Raw:   "scw iam policy apply ./iam.yaml prune=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Display the changes needed to apply IAM configuration files",
+				Command: "scw iam policy diff",
+			},
+		},
+	}
+}
+
+func policyDiffCommand() *core.Command {
+	return &core.Command{
+		Short:     `Display the changes needed to apply IAM configuration files`,
+		Long:      `Display the changes that scw iam policy apply would make to reconcile an organization with configuration files, without applying them.`,
+		Namespace: "iam",
+		Resource:  "policy",
+		Verb:      "diff",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(iamConfigRequest{}),
+		ArgSpecs:  iamConfigArgSpecs(),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			plan, err := planIAMConfig(ctx, argsI.(*iamConfigRequest))
+			if err != nil {
+				return nil, err
+			}
+			return plan.changes, nil
+		},
+		View: iamConfigView(),
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply the configuration files of a directory",
+				Raw:   "scw iam policy diff ./iam",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply IAM configuration files to an organization",
+				Command: "scw iam policy apply",
+			},
+		},
+	}
+}
+
+func policyDriftCheckCommand() *core.Command {
+	return &core.Command{
+		Short: `Check that an organization matches IAM configuration files`,
+		Long: `Check that the applications, groups and policies of an organization match configuration files, to detect changes made outside of them.
+
+The command fails and lists the changes needed to apply the configuration when the organization drifted.`,
+		Namespace: "iam",
+		Resource:  "policy",
+		Verb:      "drift-check",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(iamConfigRequest{}),
+		ArgSpecs:  iamConfigArgSpecs(),
+		Run:       policyDriftCheckRun,
+		Examples: []*core.Example{
+			{
+				Short: "Check that no resource was changed or added outside of the configuration files of a directory",
+				Raw:   "scw iam policy drift-check ./iam prune=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply IAM configuration files to an organization",
+				Command: "scw iam policy apply",
+			},
+		},
+	}
+}
+
+func policyApplyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*iamConfigApplyRequest)
+
+	plan, err := planIAMConfig(ctx, &iamConfigRequest{
+		Path:           args.Path,
+		Prune:          args.Prune,
+		OrganizationID: args.OrganizationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.changes) == 0 {
+		return &core.SuccessResult{Message: "Organization is already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying a configuration must be confirmed"),
+				Hint: "Review the changes with scw iam policy diff then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range plan.changes {
+			_, _ = interactive.Printf("%s %s %s\n", change.Operation, change.Resource, change.Name)
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	api := iam.NewAPI(core.ExtractClient(ctx))
+	for _, change := range plan.changes {
+		err := applyIAMConfigChange(ctx, api, plan, change)
+		if err != nil {
+			return nil, fmt.Errorf("cannot %s %s %s: %w", change.Operation, change.Resource, change.Name, err)
+		}
+		_, _ = interactive.Printf("%s %s %s done\n", change.Operation, change.Resource, change.Name)
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("%d changes applied to organization %s", len(plan.changes), plan.organizationID),
+	}, nil
+}
+
+func policyDriftCheckRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	plan, err := planIAMConfig(ctx, argsI.(*iamConfigRequest))
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.changes) == 0 {
+		return &core.SuccessResult{Message: "Organization matches the configuration"}, nil
+	}
+
+	details := make([]string, 0, len(plan.changes))
+	for _, change := range plan.changes {
+		details = append(details, fmt.Sprintf("- %s %s %s", change.Operation, change.Resource, change.Name))
+	}
+
+	return nil, &core.CliError{
+		Err:     fmt.Errorf("organization drifted from the configuration, %d changes are needed", len(plan.changes)),
+		Details: strings.Join(details, "\n"),
+		Hint:    "Apply the configuration with scw iam policy apply",
+	}
+}
+
+// iamOrganizationID returns the organization ID argument or the default organization ID of the client.
+func iamOrganizationID(ctx context.Context, organizationID *string) (string, error) {
+	if organizationID != nil {
+		return *organizationID, nil
+	}
+	if defaultOrganizationID, exists := core.ExtractClient(ctx).GetDefaultOrganizationID(); exists {
+		return defaultOrganizationID, nil
+	}
+
+	return "", &core.CliError{
+		Err:  fmt.Errorf("no organization ID found"),
+		Hint: "Use organization-id=xxx or set a default organization in your configuration",
+	}
+}
+
+// planIAMConfig loads the configuration and the organization and returns the changes needed to reconcile them.
+func planIAMConfig(ctx context.Context, args *iamConfigRequest) (*iamConfigPlan, error) {
+	desired, err := loadIAMConfig(args.Path)
+	if err != nil {
+		return nil, err
+	}
+	organizationID, err := iamOrganizationID(ctx, args.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := fetchIAMConfigState(ctx, iam.NewAPI(core.ExtractClient(ctx)), organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := planIAMConfigChanges(state, desired, args.Prune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &iamConfigPlan{
+		organizationID: organizationID,
+		state:          state,
+		desired:        desired,
+		changes:        changes,
+	}, nil
+}
+
+// loadIAMConfig reads a configuration file, or merges the YAML and JSON files of a directory in alphabetical order.
+func loadIAMConfig(path string) (*iamConfig, error) {
+	files := []string{path}
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if stat.IsDir() {
+		files = nil
+		for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, matches...)
+		}
+		sort.Strings(files)
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no YAML or JSON file found in %s", path)
+		}
+	}
+
+	config := &iamConfig{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileConfig := &iamConfig{}
+		err = yaml.Unmarshal(content, fileConfig)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse %s: %s", file, err)
+		}
+		config.Applications = append(config.Applications, fileConfig.Applications...)
+		config.Groups = append(config.Groups, fileConfig.Groups...)
+		config.Policies = append(config.Policies, fileConfig.Policies...)
+	}
+
+	return config, nil
+}
+
+func fetchIAMConfigState(ctx context.Context, api *iam.API, organizationID string) (*iamConfigState, error) {
+	applications, err := api.ListApplications(&iam.ListApplicationsRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := api.ListGroups(&iam.ListGroupsRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	policies, err := api.ListPolicies(&iam.ListPoliciesRequest{
+		OrganizationID: organizationID,
+		Editable:       scw.BoolPtr(true),
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := api.ListUsers(&iam.ListUsersRequest{
+		OrganizationID: &organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string][]*iam.Rule{}
+	for _, policy := range policies.Policies {
+		policyRules, err := api.ListRules(&iam.ListRulesRequest{
+			PolicyID: policy.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		rules[policy.ID] = policyRules.Rules
+	}
+
+	return newIAMConfigState(applications.Applications, groups.Groups, policies.Policies, rules, users.Users), nil
+}
+
+// newIAMConfigState builds the configuration of an organization from its resources, rules are indexed by policy ID.
+// Applications and policies that cannot be edited are ignored.
+func newIAMConfigState(applications []*iam.Application, groups []*iam.Group, policies []*iam.Policy, rules map[string][]*iam.Rule, users []*iam.User) *iamConfigState {
+	state := &iamConfigState{
+		config:         &iamConfig{},
+		applicationIDs: map[string]string{},
+		groupIDs:       map[string]string{},
+		policyIDs:      map[string]string{},
+		userIDs:        map[string]string{},
+	}
+
+	userEmails := map[string]string{}
+	for _, user := range users {
+		userEmails[user.ID] = user.Email
+		state.userIDs[user.Email] = user.ID
+	}
+
+	applicationNames := map[string]string{}
+	for _, application := range applications {
+		applicationNames[application.ID] = application.Name
+		if !application.Editable {
+			continue
+		}
+		state.applicationIDs[application.Name] = application.ID
+		state.config.Applications = append(state.config.Applications, &iamConfigApplication{
+			Name:        application.Name,
+			Description: application.Description,
+		})
+	}
+
+	groupNames := map[string]string{}
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+		state.groupIDs[group.Name] = group.ID
+
+		configGroup := &iamConfigGroup{
+			Name:        group.Name,
+			Description: group.Description,
+		}
+		for _, userID := range group.UserIDs {
+			configGroup.Users = append(configGroup.Users, userEmails[userID])
+		}
+		for _, applicationID := range group.ApplicationIDs {
+			configGroup.Applications = append(configGroup.Applications, applicationNames[applicationID])
+		}
+		state.config.Groups = append(state.config.Groups, configGroup)
+	}
+
+	for _, policy := range policies {
+		if !policy.Editable {
+			continue
+		}
+		state.policyIDs[policy.Name] = policy.ID
+
+		configPolicy := &iamConfigPolicy{
+			Name:        policy.Name,
+			Description: policy.Description,
+		}
+		switch {
+		case policy.GroupID != nil:
+			configPolicy.Group = groupNames[*policy.GroupID]
+		case policy.ApplicationID != nil:
+			configPolicy.Application = applicationNames[*policy.ApplicationID]
+		case policy.UserID != nil:
+			configPolicy.User = userEmails[*policy.UserID]
+		}
+		for _, rule := range rules[policy.ID] {
+			configRule := &iamConfigRule{
+				Organization: rule.OrganizationID != nil,
+			}
+			if rule.PermissionSetNames != nil {
+				configRule.PermissionSets = *rule.PermissionSetNames
+			}
+			if rule.ProjectIDs != nil {
+				configRule.ProjectIDs = *rule.ProjectIDs
+			}
+			configPolicy.Rules = append(configPolicy.Rules, configRule)
+		}
+		state.config.Policies = append(state.config.Policies, configPolicy)
+	}
+
+	return state
+}
+
+// iamConfigEqual compares two resources of a configuration using their JSON representation.
+func iamConfigEqual(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
+
+// normalizeIAMConfigGroup returns a group whose members are sorted so that their order is ignored.
+func normalizeIAMConfigGroup(group *iamConfigGroup) *iamConfigGroup {
+	normalized := *group
+	normalized.Users = sortedStrings(group.Users)
+	normalized.Applications = sortedStrings(group.Applications)
+	return &normalized
+}
+
+// normalizeIAMConfigPolicy returns a policy whose rules are sorted so that their order is ignored.
+func normalizeIAMConfigPolicy(policy *iamConfigPolicy) *iamConfigPolicy {
+	normalized := *policy
+	normalized.Rules = make([]*iamConfigRule, 0, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		normalized.Rules = append(normalized.Rules, &iamConfigRule{
+			PermissionSets: sortedStrings(rule.PermissionSets),
+			ProjectIDs:     sortedStrings(rule.ProjectIDs),
+			Organization:   rule.Organization,
+		})
+	}
+	sort.Slice(normalized.Rules, func(i, j int) bool {
+		rawI, _ := json.Marshal(normalized.Rules[i])
+		rawJ, _ := json.Marshal(normalized.Rules[j])
+		return string(rawI) < string(rawJ)
+	})
+	return &normalized
+}
+
+func sortedStrings(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// validateIAMConfig checks that names are unique and that references between resources and to users are valid.
+func validateIAMConfig(state *iamConfigState, config *iamConfig) error {
+	applications := map[string]bool{}
+	for _, application := range config.Applications {
+		if applications[application.Name] {
+			return fmt.Errorf("application %s is defined more than once", application.Name)
+		}
+		applications[application.Name] = true
+	}
+	applicationExists := func(name string) bool {
+		_, exists := state.applicationIDs[name]
+		return exists || applications[name]
+	}
+
+	groups := map[string]bool{}
+	for _, group := range config.Groups {
+		if groups[group.Name] {
+			return fmt.Errorf("group %s is defined more than once", group.Name)
+		}
+		groups[group.Name] = true
+
+		for _, user := range group.Users {
+			if _, exists := state.userIDs[user]; !exists {
+				return fmt.Errorf("group %s uses unknown user %q", group.Name, user)
+			}
+		}
+		for _, application := range group.Applications {
+			if !applicationExists(application) {
+				return fmt.Errorf("group %s uses unknown application %q", group.Name, application)
+			}
+		}
+	}
+
+	policies := map[string]bool{}
+	for _, policy := range config.Policies {
+		if policies[policy.Name] {
+			return fmt.Errorf("policy %s is defined more than once", policy.Name)
+		}
+		policies[policy.Name] = true
+
+		principals := 0
+		for _, principal := range []string{policy.Group, policy.Application, policy.User} {
+			if principal != "" {
+				principals++
+			}
+		}
+		_, groupExists := state.groupIDs[policy.Group]
+		_, userExists := state.userIDs[policy.User]
+		switch {
+		case principals > 1:
+			return fmt.Errorf("policy %s must be attributed to only one of a group, an application or a user", policy.Name)
+		case policy.Group != "" && !groupExists && !groups[policy.Group]:
+			return fmt.Errorf("policy %s uses unknown group %q", policy.Name, policy.Group)
+		case policy.Application != "" && !applicationExists(policy.Application):
+			return fmt.Errorf("policy %s uses unknown application %q", policy.Name, policy.Application)
+		case policy.User != "" && !userExists:
+			return fmt.Errorf("policy %s uses unknown user %q", policy.Name, policy.User)
+		}
+
+		for _, rule := range policy.Rules {
+			if len(rule.PermissionSets) == 0 {
+				return fmt.Errorf("a rule of policy %s has no permission set", policy.Name)
+			}
+			if rule.Organization == (len(rule.ProjectIDs) > 0) {
+				return fmt.Errorf("a rule of policy %s must apply to either project IDs or the organization", policy.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planIAMConfigChanges returns the ordered changes needed to reconcile the organization with the desired configuration.
+// Resources are created and updated before being deleted so that references are never broken, deletions require prune.
+func planIAMConfigChanges(state *iamConfigState, desired *iamConfig, prune bool) ([]*iamConfigChange, error) {
+	err := validateIAMConfig(state, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	current := state.config
+	changes := []*iamConfigChange(nil)
+	addChange := func(operation, resource, name string) {
+		changes = append(changes, &iamConfigChange{Operation: operation, Resource: resource, Name: name})
+	}
+
+	currentApplications := map[string]*iamConfigApplication{}
+	for _, application := range current.Applications {
+		currentApplications[application.Name] = application
+	}
+	for _, application := range desired.Applications {
+		currentApplication, exists := currentApplications[application.Name]
+		switch {
+		case !exists:
+			addChange(iamConfigOperationCreate, iamConfigResourceApplication, application.Name)
+		case !iamConfigEqual(currentApplication, application):
+			addChange(iamConfigOperationUpdate, iamConfigResourceApplication, application.Name)
+		}
+	}
+
+	currentGroups := map[string]*iamConfigGroup{}
+	for _, group := range current.Groups {
+		currentGroups[group.Name] = group
+	}
+	for _, group := range desired.Groups {
+		currentGroup, exists := currentGroups[group.Name]
+		switch {
+		case !exists:
+			addChange(iamConfigOperationCreate, iamConfigResourceGroup, group.Name)
+		case !iamConfigEqual(normalizeIAMConfigGroup(currentGroup), normalizeIAMConfigGroup(group)):
+			addChange(iamConfigOperationUpdate, iamConfigResourceGroup, group.Name)
+		}
+	}
+
+	currentPolicies := map[string]*iamConfigPolicy{}
+	for _, policy := range current.Policies {
+		currentPolicies[policy.Name] = policy
+	}
+	for _, policy := range desired.Policies {
+		currentPolicy, exists := currentPolicies[policy.Name]
+		switch {
+		case !exists:
+			addChange(iamConfigOperationCreate, iamConfigResourcePolicy, policy.Name)
+		case !iamConfigEqual(normalizeIAMConfigPolicy(currentPolicy), normalizeIAMConfigPolicy(policy)):
+			addChange(iamConfigOperationUpdate, iamConfigResourcePolicy, policy.Name)
+		}
+	}
+
+	if !prune {
+		return changes, nil
+	}
+
+	desiredPolicies := map[string]bool{}
+	for _, policy := range desired.Policies {
+		desiredPolicies[policy.Name] = true
+	}
+	for _, policy := range current.Policies {
+		if !desiredPolicies[policy.Name] {
+			addChange(iamConfigOperationDelete, iamConfigResourcePolicy, policy.Name)
+		}
+	}
+
+	desiredGroups := map[string]bool{}
+	for _, group := range desired.Groups {
+		desiredGroups[group.Name] = true
+	}
+	for _, group := range current.Groups {
+		if !desiredGroups[group.Name] {
+			addChange(iamConfigOperationDelete, iamConfigResourceGroup, group.Name)
+		}
+	}
+
+	desiredApplications := map[string]bool{}
+	for _, application := range desired.Applications {
+		desiredApplications[application.Name] = true
+	}
+	for _, application := range current.Applications {
+		if !desiredApplications[application.Name] {
+			addChange(iamConfigOperationDelete, iamConfigResourceApplication, application.Name)
+		}
+	}
+
+	return changes, nil
+}
+
+func applyIAMConfigChange(ctx context.Context, api *iam.API, plan *iamConfigPlan, change *iamConfigChange) error {
+	organizationID, state, desired := plan.organizationID, plan.state, plan.desired
+	switch change.Resource + "/" + change.Operation {
+	case iamConfigResourceApplication + "/" + iamConfigOperationCreate:
+		application := findIAMConfigApplication(desired, change.Name)
+		created, err := api.CreateApplication(&iam.CreateApplicationRequest{
+			Name:           application.Name,
+			OrganizationID: organizationID,
+			Description:    application.Description,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		state.applicationIDs[change.Name] = created.ID
+
+	case iamConfigResourceApplication + "/" + iamConfigOperationUpdate:
+		application := findIAMConfigApplication(desired, change.Name)
+		_, err := api.UpdateApplication(&iam.UpdateApplicationRequest{
+			ApplicationID: state.applicationIDs[change.Name],
+			Description:   &application.Description,
+		}, scw.WithContext(ctx))
+		return err
+
+	case iamConfigResourceApplication + "/" + iamConfigOperationDelete:
+		return api.DeleteApplication(&iam.DeleteApplicationRequest{
+			ApplicationID: state.applicationIDs[change.Name],
+		}, scw.WithContext(ctx))
+
+	case iamConfigResourceGroup + "/" + iamConfigOperationCreate:
+		group := findIAMConfigGroup(desired, change.Name)
+		created, err := api.CreateGroup(&iam.CreateGroupRequest{
+			Name:           group.Name,
+			OrganizationID: organizationID,
+			Description:    group.Description,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		state.groupIDs[change.Name] = created.ID
+		return setIAMConfigGroupMembers(ctx, api, state, group)
+
+	case iamConfigResourceGroup + "/" + iamConfigOperationUpdate:
+		group := findIAMConfigGroup(desired, change.Name)
+		_, err := api.UpdateGroup(&iam.UpdateGroupRequest{
+			GroupID:     state.groupIDs[change.Name],
+			Description: &group.Description,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		return setIAMConfigGroupMembers(ctx, api, state, group)
+
+	case iamConfigResourceGroup + "/" + iamConfigOperationDelete:
+		return api.DeleteGroup(&iam.DeleteGroupRequest{
+			GroupID: state.groupIDs[change.Name],
+		}, scw.WithContext(ctx))
+
+	case iamConfigResourcePolicy + "/" + iamConfigOperationCreate:
+		policy := findIAMConfigPolicy(desired, change.Name)
+		request := &iam.CreatePolicyRequest{
+			Name:           policy.Name,
+			Description:    policy.Description,
+			OrganizationID: organizationID,
+			Rules:          iamConfigRuleSpecs(organizationID, policy),
+		}
+		request.UserID, request.GroupID, request.ApplicationID, request.NoPrincipal = iamConfigPrincipal(state, policy)
+		_, err := api.CreatePolicy(request, scw.WithContext(ctx))
+		return err
+
+	case iamConfigResourcePolicy + "/" + iamConfigOperationUpdate:
+		policy := findIAMConfigPolicy(desired, change.Name)
+		request := &iam.UpdatePolicyRequest{
+			PolicyID:    state.policyIDs[change.Name],
+			Description: &policy.Description,
+		}
+		request.UserID, request.GroupID, request.ApplicationID, request.NoPrincipal = iamConfigPrincipal(state, policy)
+		_, err := api.UpdatePolicy(request, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		_, err = api.SetRules(&iam.SetRulesRequest{
+			PolicyID: state.policyIDs[change.Name],
+			Rules:    iamConfigRuleSpecs(organizationID, policy),
+		}, scw.WithContext(ctx))
+		return err
+
+	case iamConfigResourcePolicy + "/" + iamConfigOperationDelete:
+		return api.DeletePolicy(&iam.DeletePolicyRequest{
+			PolicyID: state.policyIDs[change.Name],
+		}, scw.WithContext(ctx))
+	}
+
+	return nil
+}
+
+func setIAMConfigGroupMembers(ctx context.Context, api *iam.API, state *iamConfigState, group *iamConfigGroup) error {
+	userIDs := []string{}
+	for _, user := range group.Users {
+		userIDs = append(userIDs, state.userIDs[user])
+	}
+	applicationIDs := []string{}
+	for _, application := range group.Applications {
+		applicationIDs = append(applicationIDs, state.applicationIDs[application])
+	}
+
+	_, err := api.SetGroupMembers(&iam.SetGroupMembersRequest{
+		GroupID:        state.groupIDs[group.Name],
+		UserIDs:        userIDs,
+		ApplicationIDs: applicationIDs,
+	}, scw.WithContext(ctx))
+	return err
+}
+
+// iamConfigPrincipal returns the principal of a policy as expected by the API, with NoPrincipal set when it has none.
+func iamConfigPrincipal(state *iamConfigState, policy *iamConfigPolicy) (userID, groupID, applicationID *string, noPrincipal *bool) {
+	switch {
+	case policy.Group != "":
+		return nil, scw.StringPtr(state.groupIDs[policy.Group]), nil, nil
+	case policy.Application != "":
+		return nil, nil, scw.StringPtr(state.applicationIDs[policy.Application]), nil
+	case policy.User != "":
+		return scw.StringPtr(state.userIDs[policy.User]), nil, nil, nil
+	}
+	return nil, nil, nil, scw.BoolPtr(true)
+}
+
+func iamConfigRuleSpecs(organizationID string, policy *iamConfigPolicy) []*iam.RuleSpecs {
+	rules := []*iam.RuleSpecs{}
+	for _, rule := range policy.Rules {
+		permissionSets := rule.PermissionSets
+		spec := &iam.RuleSpecs{
+			PermissionSetNames: &permissionSets,
+		}
+		if rule.Organization {
+			spec.OrganizationID = scw.StringPtr(organizationID)
+		} else {
+			projectIDs := rule.ProjectIDs
+			spec.ProjectIDs = &projectIDs
+		}
+		rules = append(rules, spec)
+	}
+	return rules
+}
+
+func findIAMConfigApplication(config *iamConfig, name string) *iamConfigApplication {
+	for _, application := range config.Applications {
+		if application.Name == name {
+			return application
+		}
+	}
+	return nil
+}
+
+func findIAMConfigGroup(config *iamConfig, name string) *iamConfigGroup {
+	for _, group := range config.Groups {
+		if group.Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+func findIAMConfigPolicy(config *iamConfig, name string) *iamConfigPolicy {
+	for _, policy := range config.Policies {
+		if policy.Name == name {
+			return policy
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package iam
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_loadIAMConfig(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "groups.yaml"), []byte(`
+groups:
+  - name: developers
+    users: [dev@example.com]
+`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "policies.yml"), []byte(`
+policies:
+  - name: developers-access
+    group: developers
+    rules:
+      - permission_sets: [InstancesFullAccess]
+        project_ids: [11111111-1111-1111-1111-111111111111]
+`), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a configuration"), 0o600))
+
+	config, err := loadIAMConfig(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(config.Groups))
+	assert.Equal(t, []string{"dev@example.com"}, config.Groups[0].Users)
+	assert.Equal(t, 1, len(config.Policies))
+	assert.Equal(t, "developers", config.Policies[0].Group)
+	assert.Equal(t, []string{"InstancesFullAccess"}, config.Policies[0].Rules[0].PermissionSets)
+
+	_, err = loadIAMConfig(t.TempDir())
+	assert.Error(t, err)
+}
+
+func Test_newIAMConfigState(t *testing.T) {
+	state := newIAMConfigState(
+		[]*iam.Application{
+			{ID: "application-id", Name: "ci", Editable: true},
+			{ID: "system-application-id", Name: "system", Editable: false},
+		},
+		[]*iam.Group{{ID: "group-id", Name: "developers", UserIDs: []string{"user-id"}, ApplicationIDs: []string{"application-id"}}},
+		[]*iam.Policy{{ID: "policy-id", Name: "developers-access", Editable: true, GroupID: scw.StringPtr("group-id")}},
+		map[string][]*iam.Rule{"policy-id": {{
+			PermissionSetNames: &[]string{"InstancesFullAccess"},
+			OrganizationID:     scw.StringPtr("organization-id"),
+		}}},
+		[]*iam.User{{ID: "user-id", Email: "dev@example.com"}},
+	)
+
+	assert.Equal(t, 1, len(state.config.Applications))
+	assert.Equal(t, "", state.applicationIDs["system"])
+	assert.Equal(t, []string{"dev@example.com"}, state.config.Groups[0].Users)
+	assert.Equal(t, []string{"ci"}, state.config.Groups[0].Applications)
+	assert.Equal(t, "developers", state.config.Policies[0].Group)
+	assert.True(t, state.config.Policies[0].Rules[0].Organization)
+	assert.Equal(t, "policy-id", state.policyIDs["developers-access"])
+	assert.Equal(t, "user-id", state.userIDs["dev@example.com"])
+}
+
+func Test_planIAMConfigChanges(t *testing.T) {
+	newState := func() *iamConfigState {
+		return &iamConfigState{
+			config: &iamConfig{
+				Applications: []*iamConfigApplication{{Name: "legacy"}},
+				Groups: []*iamConfigGroup{
+					{Name: "developers", Users: []string{"b@example.com", "a@example.com"}},
+					{Name: "ops", Description: "Operators"},
+				},
+				Policies: []*iamConfigPolicy{
+					{
+						Name:  "developers-access",
+						Group: "developers",
+						Rules: []*iamConfigRule{
+							{PermissionSets: []string{"ObjectStorageReadOnly"}, Organization: true},
+							{PermissionSets: []string{"InstancesFullAccess", "IPAMReadOnly"}, ProjectIDs: []string{"project-id"}},
+						},
+					},
+					{Name: "legacy-access", Application: "legacy"},
+				},
+			},
+			applicationIDs: map[string]string{"legacy": "legacy-id"},
+			groupIDs:       map[string]string{"developers": "developers-id", "ops": "ops-id"},
+			policyIDs:      map[string]string{"developers-access": "developers-access-id", "legacy-access": "legacy-access-id"},
+			userIDs:        map[string]string{"a@example.com": "a-id", "b@example.com": "b-id"},
+		}
+	}
+	desired := &iamConfig{
+		Applications: []*iamConfigApplication{{Name: "ci"}},
+		Groups: []*iamConfigGroup{
+			{Name: "developers", Users: []string{"a@example.com", "b@example.com"}, Applications: []string{"ci"}},
+			{Name: "ops", Description: "Operators"},
+		},
+		Policies: []*iamConfigPolicy{
+			{
+				Name:  "developers-access",
+				Group: "developers",
+				Rules: []*iamConfigRule{
+					{PermissionSets: []string{"IPAMReadOnly", "InstancesFullAccess"}, ProjectIDs: []string{"project-id"}},
+					{PermissionSets: []string{"ObjectStorageReadOnly"}, Organization: true},
+				},
+			},
+			{Name: "ci-access", Application: "ci", Rules: []*iamConfigRule{{PermissionSets: []string{"ContainerRegistryFullAccess"}, Organization: true}}},
+		},
+	}
+
+	changesString := func(changes []*iamConfigChange) []string {
+		actual := []string(nil)
+		for _, change := range changes {
+			actual = append(actual, change.Operation+" "+change.Resource+" "+change.Name)
+		}
+		return actual
+	}
+
+	changes, err := planIAMConfigChanges(newState(), desired, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"create application ci",
+		"update group developers",
+		"create policy ci-access",
+	}, changesString(changes))
+
+	changes, err = planIAMConfigChanges(newState(), desired, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"create application ci",
+		"update group developers",
+		"create policy ci-access",
+		"delete policy legacy-access",
+		"delete application legacy",
+	}, changesString(changes))
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := planIAMConfigChanges(newState(), &iamConfig{
+			Groups: []*iamConfigGroup{{Name: "ops", Users: []string{"unknown@example.com"}}},
+		}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("several principals", func(t *testing.T) {
+		_, err := planIAMConfigChanges(newState(), &iamConfig{
+			Policies: []*iamConfigPolicy{{Name: "access", Group: "ops", User: "a@example.com"}},
+		}, false)
+		assert.Error(t, err)
+	})
+
+	t.Run("rule scope", func(t *testing.T) {
+		_, err := planIAMConfigChanges(newState(), &iamConfig{
+			Policies: []*iamConfigPolicy{{Name: "access", Group: "ops", Rules: []*iamConfigRule{{PermissionSets: []string{"AllProductsFullAccess"}}}}},
+		}, false)
+		assert.Error(t, err)
+	})
+}
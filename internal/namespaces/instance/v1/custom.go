@@ -35,6 +35,8 @@ func renameProjectIDArgSpec(argSpecs core.ArgSpecs) {
 func GetCommands() *core.Commands {
 	cmds := GetGeneratedCommands()
 
+	core.RegisterErrorHintFunc("instance", instanceErrorHint)
+
 	//
 	// Server
 	//
@@ -51,6 +53,9 @@ func GetCommands() *core.Commands {
 	cmds.Merge(core.NewCommands(
 		serverAttachVolumeCommand(),
 		serverBackupCommand(),
+		serverMoveToProjectCommand(),
+		serverNetworkResetCommand(),
+		serverSnapshotAndPatchCommand(),
 		serverCreateCommand(),
 		serverDeleteCommand(),
 		serverTerminateCommand(),
@@ -65,6 +70,9 @@ func GetCommands() *core.Commands {
 		serverWaitCommand(),
 		serverAttachIPCommand(),
 		serverDetachIPCommand(),
+		serverMigrateBootCommand(),
+		serverFirewallTestCommand(),
+		serverEventsCommand(),
 	))
 
 	if cmdConsole := serverConsoleCommand(); cmdConsole != nil {
@@ -77,6 +85,9 @@ func GetCommands() *core.Commands {
 	human.RegisterMarshalerFunc(instance.ServerTypesAvailability(""), human.EnumMarshalFunc(serverTypesAvailabilityMarshalSpecs))
 
 	cmds.MustFind("instance", "server-type", "list").Override(serverTypeListBuilder)
+	cmds.Merge(core.NewCommands(
+		serverTypeAvailabilityCommand(),
+	))
 
 	//
 	// IP
@@ -88,6 +99,7 @@ func GetCommands() *core.Commands {
 	cmds.Merge(core.NewCommands(
 		ipAttachCommand(),
 		ipDetachCommand(),
+		ipMoveCommand(),
 	))
 
 	//
@@ -102,6 +114,9 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("instance", "image", "delete").Override(imageDeleteBuilder)
 	cmds.Merge(core.NewCommands(
 		imageWaitCommand(),
+		imageShareCommand(),
+		imageUnshareCommand(),
+		imageListSharedCommand(),
 	))
 
 	//
@@ -112,8 +127,10 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("instance", "snapshot", "create").Override(snapshotCreateBuilder)
 	cmds.MustFind("instance", "snapshot", "list").Override(snapshotListBuilder)
 	cmds.MustFind("instance", "snapshot", "update").Override(snapshotUpdateBuilder)
+	cmds.MustFind("instance", "snapshot", "export").Override(snapshotExportBuilder)
 	cmds.Merge(core.NewCommands(
 		snapshotWaitCommand(),
+		snapshotImportCommand(),
 	))
 
 	//
@@ -128,6 +145,9 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("instance", "volume", "list").Override(volumeListBuilder)
 	cmds.Merge(core.NewCommands(
 		volumeWaitCommand(),
+		volumeListAttachmentsCommand(),
+		volumeMigrateCommand(),
+		volumeResizeCommand(),
 	))
 
 	//
@@ -141,6 +161,7 @@ func GetCommands() *core.Commands {
 	human.RegisterMarshalerFunc(instance.CreateSecurityGroupResponse{}, marshallNestedField("SecurityGroup"))
 	human.RegisterMarshalerFunc(instance.SecurityGroupPolicy(""), human.EnumMarshalFunc(securityGroupPolicyMarshalSpecs))
 	human.RegisterMarshalerFunc(instance.SecurityGroupState(""), human.EnumMarshalFunc(securityGroupStateMarshalSpecs))
+	human.RegisterMarshalerFunc(securityGroupAuditSeverity(""), human.EnumMarshalFunc(securityGroupAuditSeverityMarshalSpecs))
 
 	cmds.MustFind("instance", "security-group", "create").Override(securityGroupCreateBuilder)
 	cmds.MustFind("instance", "security-group", "get").Override(securityGroupGetBuilder)
@@ -150,6 +171,9 @@ func GetCommands() *core.Commands {
 	cmds.Merge(core.NewCommands(
 		securityGroupClearCommand(),
 		securityGroupEditCommand(),
+		securityGroupImportCommand(),
+		securityGroupApplyCommand(),
+		securityGroupAuditCommand(),
 	))
 
 	//
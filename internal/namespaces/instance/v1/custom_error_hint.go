@@ -0,0 +1,68 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// localImageNotFoundMessage starts the error returned by marketplace GetLocalImageByLabel
+// when an image label has no local image compatible with the commercial type in the zone.
+const localImageNotFoundMessage = "couldn't find a local image"
+
+// instanceErrorHint suggests how to fix the errors of instance commands.
+func instanceErrorHint(ctx context.Context, _ *core.Command, argsI interface{}, err error) string {
+	args, isServerCreate := argsI.(*instanceCreateServerRequest)
+	if !isServerCreate {
+		return ""
+	}
+
+	switch {
+	case core.IsQuotasExceededError(err):
+		return serverCreateQuotaHint(args)
+	case core.IsNotFoundError(err), strings.HasPrefix(err.Error(), localImageNotFoundMessage):
+		if validation.IsUUID(args.Image) {
+			return ""
+		}
+		return serverCreateImageZonesHint(ctx, args)
+	}
+	return ""
+}
+
+func serverCreateQuotaHint(args *instanceCreateServerRequest) string {
+	return fmt.Sprintf("Check the quota of the organization for this server type with: scw instance server-type availability type=%s", args.Type)
+}
+
+// serverCreateImageZonesHint lists the other zones where the image label can be used with the server type.
+func serverCreateImageZonesHint(ctx context.Context, args *instanceCreateServerRequest) string {
+	client := core.ExtractClient(ctx)
+	apiMarketplace := marketplace.NewAPI(client)
+	imageLabel := strings.Replace(args.Image, "-", "_", -1)
+
+	zones := []string(nil)
+	for _, zone := range instance.NewAPI(client).Zones() {
+		if zone == args.Zone {
+			continue
+		}
+		_, err := apiMarketplace.GetLocalImageByLabel(&marketplace.GetLocalImageByLabelRequest{
+			ImageLabel:     imageLabel,
+			Zone:           zone,
+			CommercialType: args.Type,
+			Type:           marketplace.LocalImageTypeInstanceLocal,
+		})
+		if err == nil {
+			zones = append(zones, zone.String())
+		}
+	}
+
+	if len(zones) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Image %s is available for %s in zones %s, use zone=%s to create the server in one of them",
+		args.Image, args.Type, strings.Join(zones, ", "), zones[0])
+}
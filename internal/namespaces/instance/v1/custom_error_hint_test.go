@@ -0,0 +1,22 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_instanceErrorHint(t *testing.T) {
+	quotaErr := &core.CliError{Err: errors.New("quota exceeded"), Code: core.ExitCodeQuota}
+
+	assert.Equal(t, "Check the quota of the organization for this server type with: scw instance server-type availability type=GP1-XL",
+		instanceErrorHint(context.Background(), nil, &instanceCreateServerRequest{Type: "GP1-XL"}, quotaErr))
+	assert.Empty(t, instanceErrorHint(context.Background(), nil, &instanceCreateServerRequest{Type: "GP1-XL"}, errors.New("failure")))
+	assert.Empty(t, instanceErrorHint(context.Background(), nil, &struct{}{}, quotaErr))
+	assert.Empty(t, instanceErrorHint(context.Background(), nil, &instanceCreateServerRequest{
+		Image: "11111111-1111-1111-1111-111111111111",
+	}, &core.CliError{Err: errors.New("not found"), Code: core.ExitCodeNotFound}))
+}
@@ -0,0 +1,241 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// imageSharedWithEveryone is displayed for public images, the API cannot share an image with given projects or organizations.
+const imageSharedWithEveryone = "everyone"
+
+type imageShareRequest struct {
+	Zone    scw.Zone
+	ImageID string
+}
+
+// sharedImage is an image shared outside of its project.
+type sharedImage struct {
+	ID               string
+	Name             string
+	Zone             scw.Zone
+	SharedWith       string
+	RootSnapshotID   string
+	Arch             instance.Arch
+	OrganizationID   string
+	ProjectID        string
+	ModificationDate *time.Time
+}
+
+func imageShareCommand() *core.Command {
+	return &core.Command{
+		Short: `Share an image with all organizations`,
+		Long: `Make an image public, so that it can be used to create Instances in any project of any organization, to distribute golden images.
+
+The API cannot share an image with given projects or organizations: a shared image is visible to every Scaleway user.
+Its snapshots are not shared and stay billed to the project of the image.`,
+		Namespace: "instance",
+		Resource:  "image",
+		Verb:      "share",
+		ArgsType:  reflect.TypeOf(imageShareRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "image-id",
+				Short:      `UUID of the image to share`,
+				Required:   true,
+				Positional: true,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			return setImagePublic(ctx, argsI.(*imageShareRequest), true)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Share an image",
+				Raw:   "scw instance image share 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the shared images",
+				Command: "scw instance image list-shared",
+			},
+			{
+				Short:   "Stop sharing an image",
+				Command: "scw instance image unshare",
+			},
+		},
+	}
+}
+
+func imageUnshareCommand() *core.Command {
+	return &core.Command{
+		Short:     `Stop sharing an image`,
+		Long:      `Make a shared image private again. Instances already created from the image in other projects are not affected.`,
+		Namespace: "instance",
+		Resource:  "image",
+		Verb:      "unshare",
+		ArgsType:  reflect.TypeOf(imageShareRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "image-id",
+				Short:      `UUID of the image to stop sharing`,
+				Required:   true,
+				Positional: true,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			return setImagePublic(ctx, argsI.(*imageShareRequest), false)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Stop sharing an image",
+				Raw:   "scw instance image unshare 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the shared images",
+				Command: "scw instance image list-shared",
+			},
+		},
+	}
+}
+
+func setImagePublic(ctx context.Context, args *imageShareRequest, public bool) (*instance.Image, error) {
+	resp, err := instance.NewAPI(core.ExtractClient(ctx)).UpdateImage(&instance.UpdateImageRequest{
+		Zone:    args.Zone,
+		ImageID: args.ImageID,
+		Public:  &public,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Image, nil
+}
+
+type imageListSharedRequest struct {
+	Zone           scw.Zone
+	OrganizationID *string
+	ProjectID      *string
+}
+
+func imageListSharedCommand() *core.Command {
+	availableZones := ((*instance.API)(nil)).Zones()
+	availableZones = append(availableZones, scw.Zone(core.AllLocalities))
+
+	return &core.Command{
+		Short: `List the shared images of an organization`,
+		Long: `List the images of an organization, or of one of its projects, that are shared with other organizations, to audit the images distributed outside of their project.
+
+Shared images are public, they are shared with every Scaleway user.`,
+		Namespace: "instance",
+		Resource:  "image",
+		Verb:      "list-shared",
+		ArgsType:  reflect.TypeOf(imageListSharedRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			core.OrganizationIDArgSpec(),
+			core.ZoneArgSpec(availableZones...),
+		},
+		Run: imageListSharedRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "ID", FieldName: "ID"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Zone", FieldName: "Zone"},
+				{Label: "Shared With", FieldName: "SharedWith"},
+				{Label: "Root Snapshot ID", FieldName: "RootSnapshotID"},
+				{Label: "Arch", FieldName: "Arch"},
+				{Label: "Project ID", FieldName: "ProjectID"},
+				{Label: "Modification Date", FieldName: "ModificationDate"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the shared images of the default organization in all zones",
+				Raw:   "scw instance image list-shared zone=all",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Stop sharing an image",
+				Command: "scw instance image unshare",
+			},
+		},
+	}
+}
+
+func imageListSharedRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*imageListSharedRequest)
+	client := core.ExtractClient(ctx)
+
+	// Without an organization, public images of the marketplace would be listed
+	organizationID := args.OrganizationID
+	if organizationID == nil {
+		if defaultOrganizationID, exists := client.GetDefaultOrganizationID(); exists {
+			organizationID = &defaultOrganizationID
+		}
+	}
+	if organizationID == nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no organization ID found"),
+			Hint: "Use organization-id=xxx or set a default organization in your configuration",
+		}
+	}
+
+	api := instance.NewAPI(client)
+	reqOpts := []scw.RequestOption{scw.WithAllPages(), scw.WithContext(ctx)}
+	if args.Zone == scw.Zone(core.AllLocalities) {
+		reqOpts = append(reqOpts, scw.WithZones(api.Zones()...))
+		args.Zone = ""
+	}
+
+	resp, err := api.ListImages(&instance.ListImagesRequest{
+		Zone:         args.Zone,
+		Organization: organizationID,
+		Project:      args.ProjectID,
+		Public:       scw.BoolPtr(true),
+	}, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return sharedImages(resp.Images), nil
+}
+
+// sharedImages returns the shared images among images.
+func sharedImages(images []*instance.Image) []*sharedImage {
+	shared := []*sharedImage(nil)
+	for _, image := range images {
+		if !image.Public {
+			continue
+		}
+
+		rootSnapshotID := ""
+		if image.RootVolume != nil {
+			rootSnapshotID = image.RootVolume.ID
+		}
+		shared = append(shared, &sharedImage{
+			ID:               image.ID,
+			Name:             image.Name,
+			Zone:             image.Zone,
+			SharedWith:       imageSharedWithEveryone,
+			RootSnapshotID:   rootSnapshotID,
+			Arch:             image.Arch,
+			OrganizationID:   image.Organization,
+			ProjectID:        image.Project,
+			ModificationDate: image.ModificationDate,
+		})
+	}
+
+	return shared
+}
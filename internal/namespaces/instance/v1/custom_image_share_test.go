@@ -0,0 +1,30 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sharedImages(t *testing.T) {
+	images := []*instance.Image{
+		{
+			ID:         "11111111-1111-1111-1111-111111111111",
+			Name:       "golden",
+			Public:     true,
+			RootVolume: &instance.VolumeSummary{ID: "22222222-2222-2222-2222-222222222222"},
+		},
+		{
+			ID:     "33333333-3333-3333-3333-333333333333",
+			Name:   "private",
+			Public: false,
+		},
+	}
+
+	shared := sharedImages(images)
+	assert.Len(t, shared, 1)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", shared[0].ID)
+	assert.Equal(t, "22222222-2222-2222-2222-222222222222", shared[0].RootSnapshotID)
+	assert.Equal(t, imageSharedWithEveryone, shared[0].SharedWith)
+}
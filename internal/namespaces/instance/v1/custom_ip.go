@@ -190,3 +190,113 @@ func ipDetachCommand() *core.Command {
 		},
 	}
 }
+
+func ipMoveCommand() *core.Command {
+	type customIPMoveRequest struct {
+		IP       string   `json:"-"`
+		ServerID string   `json:"server,omitempty"`
+		Zone     scw.Zone `json:"zone"`
+	}
+
+	return &core.Command{
+		Short: `Move an IP to another server`,
+		Long: `Move an IP from its current server to another server in one operation.
+If the IP cannot be attached to the target server, it is attached back to its previous server.`,
+		Namespace: "instance",
+		Resource:  "ip",
+		Verb:      "move",
+		ArgsType:  reflect.TypeOf(customIPMoveRequest{}),
+		Run: func(ctx context.Context, argsI interface{}) (i interface{}, err error) {
+			args := argsI.(*customIPMoveRequest)
+			api := instance.NewAPI(core.ExtractClient(ctx))
+
+			if !validation.IsUUID(args.IP) && net.ParseIP(args.IP) == nil {
+				return nil, fmt.Errorf(`invalid IP "%s", should be either an IP address ID or a reserved flexible IP address`, args.IP)
+			}
+
+			res, err := api.GetIP(&instance.GetIPRequest{
+				Zone: args.Zone,
+				IP:   args.IP,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			ip := res.IP
+
+			var previousServerID string
+			if ip.Server != nil {
+				previousServerID = ip.Server.ID
+			}
+			if previousServerID == args.ServerID {
+				return ip, nil
+			}
+
+			moved, err := api.UpdateIP(&instance.UpdateIPRequest{
+				Zone: args.Zone,
+				IP:   ip.ID,
+				Server: &instance.NullableStringValue{
+					Value: args.ServerID,
+				},
+			}, scw.WithContext(ctx))
+			if err == nil {
+				return moved, nil
+			}
+
+			if previousServerID == "" {
+				return nil, err
+			}
+
+			// The move may have detached the IP before failing, make sure it is back on its previous server.
+			logger.Debugf("failed to move IP %s, attaching it back to server %s", ip.ID, previousServerID)
+			rollback := &instance.UpdateIPRequest{
+				Zone: args.Zone,
+				IP:   ip.ID,
+				Server: &instance.NullableStringValue{
+					Value: previousServerID,
+				},
+			}
+			if _, rollbackErr := api.UpdateIP(rollback, scw.WithContext(ctx)); rollbackErr != nil {
+				return nil, &core.CliError{
+					Err:     fmt.Errorf("failed to move IP %s to server %s: %w", ip.Address, args.ServerID, err),
+					Details: fmt.Sprintf("The IP could not be attached back to server %s: %s", previousServerID, rollbackErr),
+					Hint:    fmt.Sprintf("Attach the IP manually with: scw instance ip attach %s server-id=%s zone=%s", ip.ID, previousServerID, args.Zone),
+				}
+			}
+
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("failed to move IP %s to server %s: %w", ip.Address, args.ServerID, err),
+				Details: fmt.Sprintf("The IP has been attached back to server %s", previousServerID),
+			}
+		},
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "ip",
+				Short:      `IP or UUID of the IP.`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "server-id",
+				Short:    "UUID of the server to move the IP to",
+				Required: true,
+			},
+			core.ZoneArgSpec(),
+		},
+		Examples: []*core.Example{
+			{
+				Short:    "Move an IP to the given server",
+				ArgsJSON: `{"server_id": "11111111-1111-1111-1111-111111111111", "ip": "1.2.3.4"}`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw instance ip attach",
+				Short:   "Attach an IP to a given server",
+			},
+			{
+				Command: "scw instance ip detach",
+				Short:   "Detach an ip from its server",
+			},
+		},
+	}
+}
@@ -0,0 +1,361 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	securityGroupRuleChangeAdd    = "add"
+	securityGroupRuleChangeDelete = "delete"
+	securityGroupRuleChangeMove   = "move"
+)
+
+// securityGroupApplySpec is the declarative list of the editable rules of a security group, in the format of security-group edit.
+type securityGroupApplySpec struct {
+	Rules []*instance.SetSecurityGroupRulesRequestRule `json:"rules"`
+}
+
+// securityGroupRuleChange is a change needed to reconcile the rules of a security group with a spec.
+// Position is the position of the rule after the change, or its current position when it is deleted.
+type securityGroupRuleChange struct {
+	Operation    string                              `json:"operation"`
+	Position     uint32                              `json:"position"`
+	Direction    instance.SecurityGroupRuleDirection `json:"direction"`
+	Action       instance.SecurityGroupRuleAction    `json:"action"`
+	Protocol     instance.SecurityGroupRuleProtocol  `json:"protocol"`
+	IPRange      scw.IPNet                           `json:"ip_range"`
+	DestPortFrom *uint32                             `json:"dest_port_from,omitempty"`
+	DestPortTo   *uint32                             `json:"dest_port_to,omitempty"`
+}
+
+type instanceSecurityGroupApplyArgs struct {
+	Zone            scw.Zone
+	SecurityGroupID string
+	File            string
+	DryRun          bool
+	Yes             bool
+}
+
+func securityGroupApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a list of rules to a security group`,
+		Long: `Reconcile the editable rules of a security group with a YAML or JSON list of rules, in the format of scw instance security-group edit.
+Rules are matched by direction, action, protocol, IP range and ports. Missing rules are added, extra rules are deleted and rules are moved to the position they have in the list.
+The changes are displayed and must be confirmed before being applied in a single request.`,
+		Namespace: "instance",
+		Resource:  "security-group",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(instanceSecurityGroupApplyArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "security-group-id",
+				Short:      `ID of the security group to apply the rules to`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "file",
+				Short:       `YAML or JSON list of rules to apply`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the changes`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(),
+		},
+		Run: securityGroupApplyRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "Position", FieldName: "Position"},
+				{Label: "Direction", FieldName: "Direction"},
+				{Label: "Action", FieldName: "Action"},
+				{Label: "Protocol", FieldName: "Protocol"},
+				{Label: "IP Range", FieldName: "IPRange"},
+				{Label: "Dest Port From", FieldName: "DestPortFrom"},
+				{Label: "Dest Port To", FieldName: "DestPortTo"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a list of rules",
+				Raw:   "scw instance security-group apply 11111111-1111-1111-1111-111111111111 file=@sg.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a list of rules",
+				Raw:   "scw instance security-group apply 11111111-1111-1111-1111-111111111111 file=@sg.yaml",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Edit all rules of a security group",
+				Command: "scw instance security-group edit",
+			},
+		},
+	}
+}
+
+func securityGroupApplyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*instanceSecurityGroupApplyArgs)
+
+	spec := &securityGroupApplySpec{}
+	err := yaml.Unmarshal([]byte(args.File), spec)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the rules: %s", err)
+	}
+	err = validateSecurityGroupApplySpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	api := instance.NewAPI(core.ExtractClient(ctx))
+	rules, err := api.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+		Zone:            args.Zone,
+		SecurityGroupID: args.SecurityGroupID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list security-group rules: %w", err)
+	}
+
+	setRules, changes := planSecurityGroupRules(rules.Rules, spec.Rules)
+	if args.DryRun {
+		return changes, nil
+	}
+	if len(changes) == 0 {
+		return &core.SuccessResult{Message: "Security group rules are already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying rules must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range changes {
+			_, _ = interactive.Println(securityGroupRuleChangeDiff(change))
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	_, err = api.SetSecurityGroupRules(&instance.SetSecurityGroupRulesRequest{
+		Zone:            args.Zone,
+		SecurityGroupID: args.SecurityGroupID,
+		Rules:           setRules,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("%d changes applied to security group %s", len(changes), args.SecurityGroupID),
+	}, nil
+}
+
+func validateSecurityGroupApplySpec(spec *securityGroupApplySpec) error {
+	for i, rule := range spec.Rules {
+		if rule == nil {
+			return fmt.Errorf("rule %d is empty", i+1)
+		}
+		if rule.Direction == "" || rule.Action == "" || rule.Protocol == "" {
+			return fmt.Errorf("rule %d must have a direction, an action and a protocol", i+1)
+		}
+		if rule.IPRange.IP == nil {
+			return fmt.Errorf("rule %d must have an ip_range", i+1)
+		}
+	}
+	return nil
+}
+
+// planSecurityGroupRules matches the editable rules of a security group with the desired rules.
+// It returns the rules to set, which keep the IDs of the matched rules, and the changes they make.
+// Matched rules are moved only when they are not part of the longest sequence of rules already in the desired order.
+func planSecurityGroupRules(current []*instance.SecurityGroupRule, desired []*instance.SetSecurityGroupRulesRequestRule) ([]*instance.SetSecurityGroupRulesRequestRule, []*securityGroupRuleChange) {
+	editable := []*instance.SecurityGroupRule(nil)
+	for _, rule := range current {
+		if rule.Editable {
+			editable = append(editable, rule)
+		}
+	}
+	sort.SliceStable(editable, func(i, j int) bool {
+		return editable[i].Position < editable[j].Position
+	})
+
+	currentIndexes := map[string][]int{}
+	for i, rule := range editable {
+		key := securityGroupRuleKey(rule.Direction, rule.Action, rule.Protocol, rule.IPRange, rule.DestPortFrom, rule.DestPortTo)
+		currentIndexes[key] = append(currentIndexes[key], i)
+	}
+
+	// matches[i] is the index of the current rule matching the i-th desired rule, or -1
+	matches := make([]int, len(desired))
+	matched := make([]bool, len(editable))
+	for i, rule := range desired {
+		key := securityGroupRuleKey(rule.Direction, rule.Action, rule.Protocol, rule.IPRange, rule.DestPortFrom, rule.DestPortTo)
+		matches[i] = -1
+		if indexes := currentIndexes[key]; len(indexes) > 0 {
+			matches[i] = indexes[0]
+			matched[indexes[0]] = true
+			currentIndexes[key] = indexes[1:]
+		}
+	}
+	inPlace := longestIncreasingMatches(matches)
+
+	changes := []*securityGroupRuleChange(nil)
+	for i, rule := range editable {
+		if !matched[i] {
+			changes = append(changes, &securityGroupRuleChange{
+				Operation:    securityGroupRuleChangeDelete,
+				Position:     rule.Position,
+				Direction:    rule.Direction,
+				Action:       rule.Action,
+				Protocol:     rule.Protocol,
+				IPRange:      rule.IPRange,
+				DestPortFrom: rule.DestPortFrom,
+				DestPortTo:   rule.DestPortTo,
+			})
+		}
+	}
+
+	setRules := make([]*instance.SetSecurityGroupRulesRequestRule, 0, len(desired))
+	for i, rule := range desired {
+		setRule := &instance.SetSecurityGroupRulesRequestRule{
+			Action:       rule.Action,
+			Protocol:     rule.Protocol,
+			Direction:    rule.Direction,
+			IPRange:      rule.IPRange,
+			DestPortFrom: rule.DestPortFrom,
+			DestPortTo:   rule.DestPortTo,
+			Position:     uint32(i + 1),
+			Editable:     scw.BoolPtr(true),
+		}
+		if matches[i] >= 0 {
+			setRule.ID = scw.StringPtr(editable[matches[i]].ID)
+		}
+		setRules = append(setRules, setRule)
+
+		operation := ""
+		switch {
+		case matches[i] < 0:
+			operation = securityGroupRuleChangeAdd
+		case !inPlace[i]:
+			operation = securityGroupRuleChangeMove
+		default:
+			continue
+		}
+		changes = append(changes, &securityGroupRuleChange{
+			Operation:    operation,
+			Position:     setRule.Position,
+			Direction:    rule.Direction,
+			Action:       rule.Action,
+			Protocol:     rule.Protocol,
+			IPRange:      rule.IPRange,
+			DestPortFrom: rule.DestPortFrom,
+			DestPortTo:   rule.DestPortTo,
+		})
+	}
+
+	return setRules, changes
+}
+
+// longestIncreasingMatches returns which matches are part of the longest increasing sequence of matched indexes, ignoring -1.
+func longestIncreasingMatches(matches []int) []bool {
+	// length[i] is the length of the longest sequence ending with the i-th match, previous[i] the match before it
+	length := make([]int, len(matches))
+	previous := make([]int, len(matches))
+	last := -1
+	for i := range matches {
+		previous[i] = -1
+		if matches[i] < 0 {
+			continue
+		}
+		length[i] = 1
+		for j := 0; j < i; j++ {
+			if matches[j] >= 0 && matches[j] < matches[i] && length[j]+1 > length[i] {
+				length[i] = length[j] + 1
+				previous[i] = j
+			}
+		}
+		if last < 0 || length[i] > length[last] {
+			last = i
+		}
+	}
+
+	inPlace := make([]bool, len(matches))
+	for i := last; i >= 0; i = previous[i] {
+		inPlace[i] = true
+	}
+	return inPlace
+}
+
+// securityGroupRuleKey identifies a rule by what it matches. Ports are ignored for ICMP and ANY protocols and a range of one port is the port itself.
+func securityGroupRuleKey(direction instance.SecurityGroupRuleDirection, action instance.SecurityGroupRuleAction, protocol instance.SecurityGroupRuleProtocol, ipRange scw.IPNet, portFrom, portTo *uint32) string {
+	ipNet := ipRange.IPNet
+	if ipNet.IP != nil {
+		ipNet.IP = ipNet.IP.Mask(ipNet.Mask)
+	}
+	return strings.Join([]string{
+		direction.String(),
+		action.String(),
+		protocol.String(),
+		ipNet.String(),
+		securityGroupRulePorts(protocol, portFrom, portTo),
+	}, " ")
+}
+
+// securityGroupRulePorts formats the destination ports of a rule, such as "22" or "8000-8080", and is empty when the rule matches all the ports.
+func securityGroupRulePorts(protocol instance.SecurityGroupRuleProtocol, portFrom, portTo *uint32) string {
+	if protocol != instance.SecurityGroupRuleProtocolTCP && protocol != instance.SecurityGroupRuleProtocolUDP {
+		return ""
+	}
+	if portFrom == nil {
+		return ""
+	}
+	if portTo == nil || *portTo == *portFrom {
+		return fmt.Sprint(*portFrom)
+	}
+	return fmt.Sprintf("%d-%d", *portFrom, *portTo)
+}
+
+// securityGroupRuleChangeDiff formats a change as a line of a diff, such as "+ 1 inbound accept TCP 0.0.0.0/0 22".
+func securityGroupRuleChangeDiff(change *securityGroupRuleChange) string {
+	prefix := "~"
+	switch change.Operation {
+	case securityGroupRuleChangeAdd:
+		prefix = "+"
+	case securityGroupRuleChangeDelete:
+		prefix = "-"
+	}
+	line := fmt.Sprintf("%s %d %s %s %s %s", prefix, change.Position, change.Direction, change.Action, change.Protocol, change.IPRange.String())
+	if ports := securityGroupRulePorts(change.Protocol, change.DestPortFrom, change.DestPortTo); ports != "" {
+		line += " " + ports
+	}
+	return line
+}
@@ -0,0 +1,79 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_planSecurityGroupRules(t *testing.T) {
+	mustIPNet := func(ipRange string) scw.IPNet {
+		ipNet := scw.IPNet{}
+		require.NoError(t, ipNet.UnmarshalJSON([]byte(`"`+ipRange+`"`)))
+		return ipNet
+	}
+	current := []*instance.SecurityGroupRule{
+		{ID: "ssh", Position: 1, Editable: true, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(22)},
+		{ID: "http", Position: 2, Editable: true, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(80), DestPortTo: scw.Uint32Ptr(80)},
+		{ID: "icmp", Position: 3, Editable: true, Direction: "inbound", Action: "accept", Protocol: "ICMP", IPRange: mustIPNet("0.0.0.0/0")},
+		{ID: "smtp", Position: 4, Editable: false, Direction: "outbound", Action: "drop", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(25)},
+	}
+
+	spec := &securityGroupApplySpec{}
+	require.NoError(t, yaml.Unmarshal([]byte(`rules:
+- direction: inbound
+  action: accept
+  protocol: ICMP
+  ip_range: 0.0.0.0/0
+- direction: inbound
+  action: accept
+  protocol: TCP
+  ip_range: 0.0.0.0/0
+  dest_port_from: 80
+- direction: inbound
+  action: accept
+  protocol: TCP
+  ip_range: 10.0.0.1
+  dest_port_from: 8000
+  dest_port_to: 8080
+`), spec))
+	require.NoError(t, validateSecurityGroupApplySpec(spec))
+
+	setRules, changes := planSecurityGroupRules(current, spec.Rules)
+	require.Len(t, setRules, 3)
+	assert.Equal(t, "icmp", *setRules[0].ID)
+	assert.Equal(t, "http", *setRules[1].ID)
+	assert.Nil(t, setRules[2].ID)
+	assert.Equal(t, uint32(3), setRules[2].Position)
+
+	diff := []string(nil)
+	for _, change := range changes {
+		diff = append(diff, securityGroupRuleChangeDiff(change))
+	}
+	assert.Equal(t, []string{
+		"- 1 inbound accept TCP 0.0.0.0/0 22",
+		"~ 2 inbound accept TCP 0.0.0.0/0 80",
+		"+ 3 inbound accept TCP 10.0.0.1/32 8000-8080",
+	}, diff)
+
+	_, changes = planSecurityGroupRules(current, []*instance.SetSecurityGroupRulesRequestRule{
+		{Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(22), DestPortTo: scw.Uint32Ptr(22)},
+		{Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(80)},
+		{Direction: "inbound", Action: "accept", Protocol: "ICMP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(1)},
+	})
+	assert.Empty(t, changes)
+}
+
+func Test_validateSecurityGroupApplySpec(t *testing.T) {
+	spec := &securityGroupApplySpec{}
+	require.NoError(t, yaml.Unmarshal([]byte(`rules:
+- direction: inbound
+  protocol: TCP
+  ip_range: 0.0.0.0/0
+`), spec))
+	assert.EqualError(t, validateSecurityGroupApplySpec(spec), "rule 1 must have a direction, an action and a protocol")
+}
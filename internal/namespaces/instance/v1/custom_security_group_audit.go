@@ -0,0 +1,291 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type securityGroupAuditSeverity string
+
+const (
+	securityGroupAuditSeverityMedium   = securityGroupAuditSeverity("medium")
+	securityGroupAuditSeverityHigh     = securityGroupAuditSeverity("high")
+	securityGroupAuditSeverityCritical = securityGroupAuditSeverity("critical")
+)
+
+// securityGroupAuditSeverities are the severities from the least to the most severe.
+var securityGroupAuditSeverities = []securityGroupAuditSeverity{
+	securityGroupAuditSeverityMedium,
+	securityGroupAuditSeverityHigh,
+	securityGroupAuditSeverityCritical,
+}
+
+var securityGroupAuditSeverityMarshalSpecs = human.EnumMarshalSpecs{
+	securityGroupAuditSeverityMedium:   &human.EnumMarshalSpec{Attribute: color.FgYellow},
+	securityGroupAuditSeverityHigh:     &human.EnumMarshalSpec{Attribute: color.FgRed},
+	securityGroupAuditSeverityCritical: &human.EnumMarshalSpec{Attribute: color.FgHiRed},
+}
+
+// securityGroupAuditSensitivePort is a port that must not be reachable from the internet.
+type securityGroupAuditSensitivePort struct {
+	port     uint32
+	service  string
+	severity securityGroupAuditSeverity
+}
+
+var securityGroupAuditSensitivePorts = []*securityGroupAuditSensitivePort{
+	{port: 22, service: "SSH", severity: securityGroupAuditSeverityHigh},
+	{port: 3389, service: "RDP", severity: securityGroupAuditSeverityHigh},
+	{port: 1433, service: "SQL Server", severity: securityGroupAuditSeverityCritical},
+	{port: 3306, service: "MySQL", severity: securityGroupAuditSeverityCritical},
+	{port: 5432, service: "PostgreSQL", severity: securityGroupAuditSeverityCritical},
+	{port: 6379, service: "Redis", severity: securityGroupAuditSeverityCritical},
+	{port: 9200, service: "Elasticsearch", severity: securityGroupAuditSeverityCritical},
+	{port: 11211, service: "Memcached", severity: securityGroupAuditSeverityCritical},
+	{port: 27017, service: "MongoDB", severity: securityGroupAuditSeverityCritical},
+}
+
+// securityGroupAuditFinding is a risky setting of a security group, RuleID is empty when it is not about a rule.
+type securityGroupAuditFinding struct {
+	Severity          securityGroupAuditSeverity `json:"severity"`
+	SecurityGroupID   string                     `json:"security_group_id"`
+	SecurityGroupName string                     `json:"security_group_name"`
+	RuleID            string                     `json:"rule_id,omitempty"`
+	Position          uint32                     `json:"position,omitempty"`
+	Issue             string                     `json:"issue"`
+	Zone              scw.Zone                   `json:"zone"`
+}
+
+type instanceSecurityGroupAuditArgs struct {
+	Zone      scw.Zone
+	ProjectID *string
+	FailOn    securityGroupAuditSeverity
+}
+
+func securityGroupAuditCommand() *core.Command {
+	availableZones := ((*instance.API)(nil)).Zones()
+	availableZones = append(availableZones, scw.Zone(core.AllLocalities))
+
+	severities := make([]string, 0, len(securityGroupAuditSeverities))
+	for _, severity := range securityGroupAuditSeverities {
+		severities = append(severities, string(severity))
+	}
+
+	return &core.Command{
+		Short: `Flag the risky rules of the security groups of a project`,
+		Long: `Scan the security groups of a project and report their risky settings with a severity:
+- critical: a database port, or all the ports, accepting inbound traffic from the internet
+- high: an SSH or RDP port accepting inbound traffic from the internet, or an inbound policy accepting all traffic
+- medium: stateful filtering disabled, so that return traffic must be allowed explicitly
+
+With fail-on, the command fails when a finding is at least as severe, to gate CI pipelines.`,
+		Namespace: "instance",
+		Resource:  "security-group",
+		Verb:      "audit",
+		ArgsType:  reflect.TypeOf(instanceSecurityGroupAuditArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "fail-on",
+				Short:      `Fail when a finding has this severity or a higher one`,
+				EnumValues: severities,
+			},
+			core.ProjectIDArgSpec(),
+			core.ZoneArgSpec(availableZones...),
+		},
+		Run: securityGroupAuditRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Severity", FieldName: "Severity"},
+				{Label: "Security Group ID", FieldName: "SecurityGroupID"},
+				{Label: "Security Group Name", FieldName: "SecurityGroupName"},
+				{Label: "Position", FieldName: "Position"},
+				{Label: "Issue", FieldName: "Issue"},
+				{Label: "Zone", FieldName: "Zone"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Audit the security groups of all the zones",
+				Raw:   "scw instance security-group audit zone=all",
+			},
+			{
+				Short: "Fail a CI pipeline on high and critical findings",
+				Raw:   "scw instance security-group audit zone=all fail-on=high -o json",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply a list of rules to a security group",
+				Command: "scw instance security-group apply",
+			},
+		},
+	}
+}
+
+func securityGroupAuditRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*instanceSecurityGroupAuditArgs)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	reqOpts := []scw.RequestOption{scw.WithAllPages(), scw.WithContext(ctx)}
+	zone := args.Zone
+	if zone == scw.Zone(core.AllLocalities) {
+		reqOpts = append(reqOpts, scw.WithZones(api.Zones()...))
+		zone = ""
+	}
+
+	securityGroups, err := api.ListSecurityGroups(&instance.ListSecurityGroupsRequest{
+		Zone:    zone,
+		Project: args.ProjectID,
+	}, reqOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := []*securityGroupAuditFinding{}
+	for _, securityGroup := range securityGroups.SecurityGroups {
+		rules, err := api.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+			Zone:            securityGroup.Zone,
+			SecurityGroupID: securityGroup.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the rules of security group %s: %w", securityGroup.ID, err)
+		}
+		findings = append(findings, auditSecurityGroup(securityGroup, rules.Rules)...)
+	}
+	sortSecurityGroupAuditFindings(findings)
+
+	if args.FailOn != "" {
+		if err := securityGroupAuditError(findings, args.FailOn); err != nil {
+			return nil, err
+		}
+	}
+	return findings, nil
+}
+
+// auditSecurityGroup returns the risky settings of a security group and its rules.
+func auditSecurityGroup(securityGroup *instance.SecurityGroup, rules []*instance.SecurityGroupRule) []*securityGroupAuditFinding {
+	findings := []*securityGroupAuditFinding(nil)
+	newFinding := func(severity securityGroupAuditSeverity, issue string) *securityGroupAuditFinding {
+		finding := &securityGroupAuditFinding{
+			Severity:          severity,
+			SecurityGroupID:   securityGroup.ID,
+			SecurityGroupName: securityGroup.Name,
+			Issue:             issue,
+			Zone:              securityGroup.Zone,
+		}
+		findings = append(findings, finding)
+		return finding
+	}
+
+	if securityGroup.InboundDefaultPolicy == instance.SecurityGroupPolicyAccept {
+		newFinding(securityGroupAuditSeverityHigh, "inbound default policy accepts all traffic")
+	}
+	if !securityGroup.Stateful {
+		newFinding(securityGroupAuditSeverityMedium, "stateful filtering is disabled")
+	}
+
+	for _, rule := range rules {
+		severity, issue := auditSecurityGroupRule(rule)
+		if issue == "" {
+			continue
+		}
+		finding := newFinding(severity, issue)
+		finding.RuleID = rule.ID
+		finding.Position = rule.Position
+	}
+
+	return findings
+}
+
+// auditSecurityGroupRule returns the most severe issue of an inbound rule accepting traffic from the internet, the issue is empty when the rule is not risky.
+func auditSecurityGroupRule(rule *instance.SecurityGroupRule) (securityGroupAuditSeverity, string) {
+	if rule.Direction != instance.SecurityGroupRuleDirectionInbound || rule.Action != instance.SecurityGroupRuleActionAccept {
+		return "", ""
+	}
+	if ones, _ := rule.IPRange.Mask.Size(); rule.IPRange.IP == nil || ones != 0 {
+		return "", ""
+	}
+	source := rule.IPRange.String()
+
+	switch {
+	case rule.Protocol == instance.SecurityGroupRuleProtocolANY:
+		return securityGroupAuditSeverityCritical, fmt.Sprintf("all traffic open to %s", source)
+	case rule.Protocol == instance.SecurityGroupRuleProtocolICMP:
+		return "", ""
+	case rule.DestPortFrom == nil:
+		return securityGroupAuditSeverityCritical, fmt.Sprintf("all %s ports open to %s", rule.Protocol, source)
+	}
+
+	portFrom, portTo := *rule.DestPortFrom, *rule.DestPortFrom
+	if rule.DestPortTo != nil {
+		portTo = *rule.DestPortTo
+	}
+	var exposed *securityGroupAuditSensitivePort
+	for _, sensitivePort := range securityGroupAuditSensitivePorts {
+		if sensitivePort.port < portFrom || sensitivePort.port > portTo {
+			continue
+		}
+		if exposed == nil || securityGroupAuditSeverityRank(sensitivePort.severity) > securityGroupAuditSeverityRank(exposed.severity) {
+			exposed = sensitivePort
+		}
+	}
+	if exposed == nil {
+		return "", ""
+	}
+	return exposed.severity, fmt.Sprintf("%s port %d open to %s", exposed.service, exposed.port, source)
+}
+
+func securityGroupAuditSeverityRank(severity securityGroupAuditSeverity) int {
+	for rank, s := range securityGroupAuditSeverities {
+		if s == severity {
+			return rank
+		}
+	}
+	return -1
+}
+
+// sortSecurityGroupAuditFindings sorts findings from the most severe, then by security group and position.
+func sortSecurityGroupAuditFindings(findings []*securityGroupAuditFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		rankI, rankJ := securityGroupAuditSeverityRank(findings[i].Severity), securityGroupAuditSeverityRank(findings[j].Severity)
+		if rankI != rankJ {
+			return rankI > rankJ
+		}
+		if findings[i].SecurityGroupName != findings[j].SecurityGroupName {
+			return findings[i].SecurityGroupName < findings[j].SecurityGroupName
+		}
+		return findings[i].Position < findings[j].Position
+	})
+}
+
+// securityGroupAuditError returns an error listing the findings at least as severe as failOn, or nil when there is none.
+func securityGroupAuditError(findings []*securityGroupAuditFinding, failOn securityGroupAuditSeverity) error {
+	failing := []*securityGroupAuditFinding(nil)
+	for _, finding := range findings {
+		if securityGroupAuditSeverityRank(finding.Severity) >= securityGroupAuditSeverityRank(failOn) {
+			failing = append(failing, finding)
+		}
+	}
+	if len(failing) == 0 {
+		return nil
+	}
+
+	details := make([]string, 0, len(failing))
+	for _, finding := range failing {
+		details = append(details, fmt.Sprintf("%s: %s in security group %s", finding.Severity, finding.Issue, finding.SecurityGroupName))
+	}
+	return &core.CliError{
+		Err:     fmt.Errorf("%d findings of severity %s or higher", len(failing), failOn),
+		Details: strings.Join(details, "\n"),
+		Hint:    "Fix the rules with scw instance security-group apply or scw instance security-group update-rule",
+	}
+}
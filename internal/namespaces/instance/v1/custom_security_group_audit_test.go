@@ -0,0 +1,65 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_auditSecurityGroup(t *testing.T) {
+	mustIPNet := func(ipRange string) scw.IPNet {
+		ipNet := scw.IPNet{}
+		require.NoError(t, ipNet.UnmarshalJSON([]byte(`"`+ipRange+`"`)))
+		return ipNet
+	}
+	securityGroup := &instance.SecurityGroup{
+		ID:                   "11111111-1111-1111-1111-111111111111",
+		Name:                 "web",
+		InboundDefaultPolicy: instance.SecurityGroupPolicyDrop,
+		Stateful:             false,
+		Zone:                 scw.ZoneFrPar1,
+	}
+	rules := []*instance.SecurityGroupRule{
+		{ID: "https", Position: 1, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(443)},
+		{ID: "ssh", Position: 2, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("::/0"), DestPortFrom: scw.Uint32Ptr(22)},
+		{ID: "ssh-office", Position: 3, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("203.0.113.0/24"), DestPortFrom: scw.Uint32Ptr(22)},
+		{ID: "range", Position: 4, Direction: "inbound", Action: "accept", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(3000), DestPortTo: scw.Uint32Ptr(6000)},
+		{ID: "drop-db", Position: 5, Direction: "inbound", Action: "drop", Protocol: "TCP", IPRange: mustIPNet("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(5432)},
+		{ID: "udp", Position: 6, Direction: "inbound", Action: "accept", Protocol: "UDP", IPRange: mustIPNet("0.0.0.0/0")},
+		{ID: "icmp", Position: 7, Direction: "inbound", Action: "accept", Protocol: "ICMP", IPRange: mustIPNet("0.0.0.0/0")},
+		{ID: "outbound", Position: 8, Direction: "outbound", Action: "accept", Protocol: "ANY", IPRange: mustIPNet("0.0.0.0/0")},
+	}
+
+	findings := auditSecurityGroup(securityGroup, rules)
+	sortSecurityGroupAuditFindings(findings)
+
+	type result struct {
+		Severity securityGroupAuditSeverity
+		RuleID   string
+		Issue    string
+	}
+	results := []result(nil)
+	for _, finding := range findings {
+		results = append(results, result{finding.Severity, finding.RuleID, finding.Issue})
+	}
+	assert.Equal(t, []result{
+		{securityGroupAuditSeverityCritical, "range", "MySQL port 3306 open to 0.0.0.0/0"},
+		{securityGroupAuditSeverityCritical, "udp", "all UDP ports open to 0.0.0.0/0"},
+		{securityGroupAuditSeverityHigh, "ssh", "SSH port 22 open to ::/0"},
+		{securityGroupAuditSeverityMedium, "", "stateful filtering is disabled"},
+	}, results)
+}
+
+func Test_securityGroupAuditError(t *testing.T) {
+	findings := []*securityGroupAuditFinding{
+		{Severity: securityGroupAuditSeverityHigh, SecurityGroupName: "web", Issue: "SSH port 22 open to 0.0.0.0/0"},
+		{Severity: securityGroupAuditSeverityMedium, SecurityGroupName: "web", Issue: "stateful filtering is disabled"},
+	}
+
+	assert.NoError(t, securityGroupAuditError(findings, securityGroupAuditSeverityCritical))
+	assert.EqualError(t, securityGroupAuditError(findings, securityGroupAuditSeverityHigh), "1 findings of severity high or higher")
+	assert.EqualError(t, securityGroupAuditError(findings, securityGroupAuditSeverityMedium), "2 findings of severity medium or higher")
+}
@@ -0,0 +1,570 @@
+package instance
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type securityGroupImportFormat string
+
+const (
+	securityGroupImportFormatAWS      = securityGroupImportFormat("aws")
+	securityGroupImportFormatIptables = securityGroupImportFormat("iptables")
+	securityGroupImportFormatCSV      = securityGroupImportFormat("csv")
+)
+
+type instanceSecurityGroupImportArgs struct {
+	Zone            scw.Zone
+	SecurityGroupID string
+	Rules           string
+	Format          securityGroupImportFormat
+	Replace         bool
+	DryRun          bool
+}
+
+// securityGroupImportEntry is a line of the mapping report between a source rule and the imported Scaleway rule.
+type securityGroupImportEntry struct {
+	Source       string                              `json:"source"`
+	Imported     bool                                `json:"imported"`
+	Reason       string                              `json:"reason,omitempty"`
+	Direction    instance.SecurityGroupRuleDirection `json:"direction,omitempty"`
+	Action       instance.SecurityGroupRuleAction    `json:"action,omitempty"`
+	Protocol     instance.SecurityGroupRuleProtocol  `json:"protocol,omitempty"`
+	IPRange      string                              `json:"ip_range,omitempty"`
+	DestPortFrom *uint32                             `json:"dest_port_from,omitempty"`
+	DestPortTo   *uint32                             `json:"dest_port_to,omitempty"`
+}
+
+func securityGroupImportCommand() *core.Command {
+	return &core.Command{
+		Short: `Import rules in a security group from another firewall format`,
+		Long: `Convert the rules of another firewall format into security group rules and add them to a security group.
+Supported formats are:
+- aws: JSON output of "aws ec2 describe-security-groups" (or a single security group object)
+- iptables: filter table rules of iptables-save output (INPUT and OUTPUT chains)
+- csv: a CSV file with a "direction,action,protocol,ip_range,port_from,port_to" header
+
+Rules that cannot be converted are skipped and reported with the reason.`,
+		Namespace: "instance",
+		Resource:  "security-group",
+		Verb:      "import",
+		ArgsType:  reflect.TypeOf(instanceSecurityGroupImportArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "security-group-id",
+				Short:      `ID of the security group to import the rules into`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "rules",
+				Short:       `Rules to import`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:     "format",
+				Short:    `Format of the rules to import`,
+				Required: true,
+				EnumValues: []string{
+					string(securityGroupImportFormatAWS),
+					string(securityGroupImportFormatIptables),
+					string(securityGroupImportFormatCSV),
+				},
+			},
+			{
+				Name:  "replace",
+				Short: `Replace the existing rules of the security group instead of appending the imported rules`,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only print the mapping report without importing the rules`,
+			},
+			core.ZoneArgSpec(),
+		},
+		Run: securityGroupImportRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Source", FieldName: "Source"},
+				{Label: "Imported", FieldName: "Imported"},
+				{Label: "Direction", FieldName: "Direction"},
+				{Label: "Action", FieldName: "Action"},
+				{Label: "Protocol", FieldName: "Protocol"},
+				{Label: "IP Range", FieldName: "IPRange"},
+				{Label: "Dest Port From", FieldName: "DestPortFrom"},
+				{Label: "Dest Port To", FieldName: "DestPortTo"},
+				{Label: "Reason", FieldName: "Reason"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Preview the import of an AWS security group export",
+				Raw:   "scw instance security-group import 11111111-1111-1111-1111-111111111111 format=aws rules=@sg.json dry-run=true",
+			},
+			{
+				Short: "Replace the rules of a security group with iptables rules",
+				Raw:   "scw instance security-group import 11111111-1111-1111-1111-111111111111 format=iptables rules=@iptables.rules replace=true",
+			},
+		},
+	}
+}
+
+func securityGroupImportRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*instanceSecurityGroupImportArgs)
+
+	var entries []*securityGroupImportEntry
+	var err error
+	switch args.Format {
+	case securityGroupImportFormatAWS:
+		entries, err = parseAWSSecurityGroupRules(args.Rules)
+	case securityGroupImportFormatIptables:
+		entries, err = parseIptablesSecurityGroupRules(args.Rules)
+	case securityGroupImportFormatCSV:
+		entries, err = parseCSVSecurityGroupRules(args.Rules)
+	default:
+		err = fmt.Errorf("unknown format %q", args.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if args.DryRun {
+		return entries, nil
+	}
+
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	if args.Replace {
+		rules := []*instance.SetSecurityGroupRulesRequestRule(nil)
+		for _, entry := range entries {
+			if !entry.Imported {
+				continue
+			}
+			rule, err := entry.setRule()
+			if err != nil {
+				return nil, err
+			}
+			rule.Position = uint32(len(rules) + 1)
+			rules = append(rules, rule)
+		}
+
+		_, err = api.SetSecurityGroupRules(&instance.SetSecurityGroupRulesRequest{
+			Zone:            args.Zone,
+			SecurityGroupID: args.SecurityGroupID,
+			Rules:           rules,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		return entries, nil
+	}
+
+	for _, entry := range entries {
+		if !entry.Imported {
+			continue
+		}
+		rule, err := entry.setRule()
+		if err != nil {
+			return nil, err
+		}
+		_, err = api.CreateSecurityGroupRule(&instance.CreateSecurityGroupRuleRequest{
+			Zone:            args.Zone,
+			SecurityGroupID: args.SecurityGroupID,
+			Protocol:        rule.Protocol,
+			Direction:       rule.Direction,
+			Action:          rule.Action,
+			IPRange:         rule.IPRange,
+			DestPortFrom:    rule.DestPortFrom,
+			DestPortTo:      rule.DestPortTo,
+			Editable:        true,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to import rule %q: %w", entry.Source, err)
+		}
+	}
+
+	return entries, nil
+}
+
+func (e *securityGroupImportEntry) setRule() (*instance.SetSecurityGroupRulesRequestRule, error) {
+	ipRange := scw.IPNet{}
+	if err := ipRange.UnmarshalJSON([]byte(strconv.Quote(e.IPRange))); err != nil {
+		return nil, fmt.Errorf("invalid ip range %q: %w", e.IPRange, err)
+	}
+
+	return &instance.SetSecurityGroupRulesRequestRule{
+		Action:       e.Action,
+		Protocol:     e.Protocol,
+		Direction:    e.Direction,
+		IPRange:      ipRange,
+		DestPortFrom: e.DestPortFrom,
+		DestPortTo:   e.DestPortTo,
+		Editable:     scw.BoolPtr(true),
+	}, nil
+}
+
+func skippedImportEntry(source string, reason string) *securityGroupImportEntry {
+	return &securityGroupImportEntry{
+		Source: source,
+		Reason: reason,
+	}
+}
+
+// setImportEntryPorts sets the destination ports of an entry, ports are ignored for ICMP and ANY protocols.
+func setImportEntryPorts(entry *securityGroupImportEntry, from, to uint32) {
+	if entry.Protocol != instance.SecurityGroupRuleProtocolTCP && entry.Protocol != instance.SecurityGroupRuleProtocolUDP {
+		return
+	}
+	if from == 0 && (to == 0 || to == 65535) {
+		return
+	}
+	entry.DestPortFrom = scw.Uint32Ptr(from)
+	if to != from && to != 0 {
+		entry.DestPortTo = scw.Uint32Ptr(to)
+	}
+}
+
+func parseImportProtocol(protocol string) (instance.SecurityGroupRuleProtocol, bool) {
+	switch strings.ToLower(protocol) {
+	case "tcp", "6":
+		return instance.SecurityGroupRuleProtocolTCP, true
+	case "udp", "17":
+		return instance.SecurityGroupRuleProtocolUDP, true
+	case "icmp", "1", "icmpv6", "ipv6-icmp", "58":
+		return instance.SecurityGroupRuleProtocolICMP, true
+	case "-1", "all", "any", "":
+		return instance.SecurityGroupRuleProtocolANY, true
+	default:
+		return "", false
+	}
+}
+
+func parseImportIPRange(ipRange string) (string, bool) {
+	if !strings.Contains(ipRange, "/") {
+		ip := net.ParseIP(ipRange)
+		if ip == nil {
+			return "", false
+		}
+		if ip.To4() != nil {
+			return ipRange + "/32", true
+		}
+		return ipRange + "/128", true
+	}
+	_, network, err := net.ParseCIDR(ipRange)
+	if err != nil {
+		return "", false
+	}
+	return network.String(), true
+}
+
+type awsSecurityGroup struct {
+	GroupID             string              `json:"GroupId"`
+	IPPermissions       []*awsIPPermissions `json:"IpPermissions"`
+	IPPermissionsEgress []*awsIPPermissions `json:"IpPermissionsEgress"`
+}
+
+type awsIPPermissions struct {
+	IPProtocol string `json:"IpProtocol"`
+	FromPort   *int64 `json:"FromPort"`
+	ToPort     *int64 `json:"ToPort"`
+	IPRanges   []struct {
+		CidrIP string `json:"CidrIp"`
+	} `json:"IpRanges"`
+	IPv6Ranges []struct {
+		CidrIPv6 string `json:"CidrIpv6"`
+	} `json:"Ipv6Ranges"`
+	UserIDGroupPairs []json.RawMessage `json:"UserIdGroupPairs"`
+	PrefixListIDs    []json.RawMessage `json:"PrefixListIds"`
+}
+
+// parseAWSSecurityGroupRules converts the output of "aws ec2 describe-security-groups".
+// AWS rules are always accept rules.
+func parseAWSSecurityGroupRules(content string) ([]*securityGroupImportEntry, error) {
+	export := struct {
+		SecurityGroups []*awsSecurityGroup `json:"SecurityGroups"`
+	}{}
+	if err := json.Unmarshal([]byte(content), &export); err != nil {
+		return nil, fmt.Errorf("invalid aws security group export: %w", err)
+	}
+	if export.SecurityGroups == nil {
+		group := &awsSecurityGroup{}
+		if err := json.Unmarshal([]byte(content), group); err != nil {
+			return nil, fmt.Errorf("invalid aws security group export: %w", err)
+		}
+		export.SecurityGroups = []*awsSecurityGroup{group}
+	}
+
+	entries := []*securityGroupImportEntry(nil)
+	for _, group := range export.SecurityGroups {
+		directions := []struct {
+			direction   instance.SecurityGroupRuleDirection
+			permissions []*awsIPPermissions
+		}{
+			{instance.SecurityGroupRuleDirectionInbound, group.IPPermissions},
+			{instance.SecurityGroupRuleDirectionOutbound, group.IPPermissionsEgress},
+		}
+
+		for _, d := range directions {
+			for _, permission := range d.permissions {
+				source := fmt.Sprintf("%s %s %s", group.GroupID, d.direction, permission.IPProtocol)
+				if permission.FromPort != nil {
+					source += fmt.Sprintf(" %d-%d", *permission.FromPort, int64PtrValue(permission.ToPort))
+				}
+
+				protocol, ok := parseImportProtocol(permission.IPProtocol)
+				if !ok {
+					entries = append(entries, skippedImportEntry(source, "unsupported protocol "+permission.IPProtocol))
+					continue
+				}
+
+				if len(permission.UserIDGroupPairs) > 0 {
+					entries = append(entries, skippedImportEntry(source, "security group references are not supported"))
+				}
+				if len(permission.PrefixListIDs) > 0 {
+					entries = append(entries, skippedImportEntry(source, "prefix lists are not supported"))
+				}
+
+				ranges := []string(nil)
+				for _, ipRange := range permission.IPRanges {
+					ranges = append(ranges, ipRange.CidrIP)
+				}
+				for _, ipRange := range permission.IPv6Ranges {
+					ranges = append(ranges, ipRange.CidrIPv6)
+				}
+
+				for _, ipRange := range ranges {
+					entrySource := source + " " + ipRange
+					ipNet, ok := parseImportIPRange(ipRange)
+					if !ok {
+						entries = append(entries, skippedImportEntry(entrySource, "invalid ip range"))
+						continue
+					}
+
+					entry := &securityGroupImportEntry{
+						Source:    entrySource,
+						Imported:  true,
+						Direction: d.direction,
+						Action:    instance.SecurityGroupRuleActionAccept,
+						Protocol:  protocol,
+						IPRange:   ipNet,
+					}
+					if permission.FromPort != nil && *permission.FromPort >= 0 {
+						setImportEntryPorts(entry, uint32(*permission.FromPort), uint32(int64PtrValue(permission.ToPort)))
+					}
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func int64PtrValue(i *int64) int64 {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
+
+// parseIptablesSecurityGroupRules converts rules of the INPUT and OUTPUT chains of an iptables-save output.
+// Only source, destination, protocol, destination port and target options are supported.
+func parseIptablesSecurityGroupRules(content string) ([]*securityGroupImportEntry, error) {
+	entries := []*securityGroupImportEntry(nil)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "-A ") {
+			// Tables, chain policies, comments and COMMIT are not rules
+			continue
+		}
+
+		entries = append(entries, parseIptablesRule(line))
+	}
+
+	return entries, nil
+}
+
+func parseIptablesRule(line string) *securityGroupImportEntry {
+	fields := strings.Fields(line)
+	entry := &securityGroupImportEntry{
+		Source:   line,
+		Imported: true,
+		Protocol: instance.SecurityGroupRuleProtocolANY,
+		IPRange:  "0.0.0.0/0",
+	}
+
+	switch fields[1] {
+	case "INPUT":
+		entry.Direction = instance.SecurityGroupRuleDirectionInbound
+	case "OUTPUT":
+		entry.Direction = instance.SecurityGroupRuleDirectionOutbound
+	default:
+		return skippedImportEntry(line, "unsupported chain "+fields[1])
+	}
+
+	var portFrom, portTo uint32
+	for i := 2; i < len(fields); i++ {
+		option := fields[i]
+		if i+1 >= len(fields) {
+			return skippedImportEntry(line, "missing value for option "+option)
+		}
+		value := fields[i+1]
+		i++
+
+		switch option {
+		case "-s", "--source", "-d", "--destination":
+			isSource := option == "-s" || option == "--source"
+			if isSource != (entry.Direction == instance.SecurityGroupRuleDirectionInbound) {
+				return skippedImportEntry(line, "filtering on local address is not supported")
+			}
+			ipNet, ok := parseImportIPRange(value)
+			if !ok {
+				return skippedImportEntry(line, "invalid ip range "+value)
+			}
+			entry.IPRange = ipNet
+		case "-p", "--protocol":
+			protocol, ok := parseImportProtocol(value)
+			if !ok {
+				return skippedImportEntry(line, "unsupported protocol "+value)
+			}
+			entry.Protocol = protocol
+		case "-m", "--match":
+			if _, ok := parseImportProtocol(value); !ok {
+				return skippedImportEntry(line, "unsupported match "+value)
+			}
+		case "--dport", "--destination-port":
+			from, to, _ := strings.Cut(value, ":")
+			portFromInt, err := strconv.ParseUint(from, 10, 16)
+			if err != nil {
+				return skippedImportEntry(line, "invalid port "+value)
+			}
+			portFrom = uint32(portFromInt)
+			portTo = portFrom
+			if to != "" {
+				portToInt, err := strconv.ParseUint(to, 10, 16)
+				if err != nil {
+					return skippedImportEntry(line, "invalid port "+value)
+				}
+				portTo = uint32(portToInt)
+			}
+		case "-j", "--jump":
+			switch value {
+			case "ACCEPT":
+				entry.Action = instance.SecurityGroupRuleActionAccept
+			case "DROP", "REJECT":
+				entry.Action = instance.SecurityGroupRuleActionDrop
+			default:
+				return skippedImportEntry(line, "unsupported target "+value)
+			}
+		default:
+			return skippedImportEntry(line, "unsupported option "+option)
+		}
+	}
+
+	if entry.Action == "" {
+		return skippedImportEntry(line, "missing target")
+	}
+	setImportEntryPorts(entry, portFrom, portTo)
+
+	return entry
+}
+
+// parseCSVSecurityGroupRules converts a CSV file with a direction,action,protocol,ip_range,port_from,port_to header.
+// port_from and port_to columns are optional.
+func parseCSVSecurityGroupRules(content string) ([]*securityGroupImportEntry, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{}
+	for i, column := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, required := range []string{"direction", "action", "protocol", "ip_range"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("invalid csv: missing %s column", required)
+		}
+	}
+
+	get := func(record []string, column string) string {
+		i, exists := columns[column]
+		if !exists || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	entries := []*securityGroupImportEntry(nil)
+	for _, record := range records[1:] {
+		source := strings.Join(record, ",")
+		entry := &securityGroupImportEntry{
+			Source:    source,
+			Imported:  true,
+			Direction: instance.SecurityGroupRuleDirection(strings.ToLower(get(record, "direction"))),
+			Action:    instance.SecurityGroupRuleAction(strings.ToLower(get(record, "action"))),
+		}
+
+		if entry.Direction != instance.SecurityGroupRuleDirectionInbound && entry.Direction != instance.SecurityGroupRuleDirectionOutbound {
+			entries = append(entries, skippedImportEntry(source, "invalid direction "+get(record, "direction")))
+			continue
+		}
+		if entry.Action != instance.SecurityGroupRuleActionAccept && entry.Action != instance.SecurityGroupRuleActionDrop {
+			entries = append(entries, skippedImportEntry(source, "invalid action "+get(record, "action")))
+			continue
+		}
+
+		protocol, ok := parseImportProtocol(get(record, "protocol"))
+		if !ok {
+			entries = append(entries, skippedImportEntry(source, "unsupported protocol "+get(record, "protocol")))
+			continue
+		}
+		entry.Protocol = protocol
+
+		ipNet, ok := parseImportIPRange(get(record, "ip_range"))
+		if !ok {
+			entries = append(entries, skippedImportEntry(source, "invalid ip range "+get(record, "ip_range")))
+			continue
+		}
+		entry.IPRange = ipNet
+
+		var ports [2]uint32
+		invalidPort := ""
+		for i, column := range []string{"port_from", "port_to"} {
+			if value := get(record, column); value != "" {
+				port, err := strconv.ParseUint(value, 10, 16)
+				if err != nil {
+					invalidPort = value
+					break
+				}
+				ports[i] = uint32(port)
+			}
+		}
+		if invalidPort != "" {
+			entries = append(entries, skippedImportEntry(source, "invalid port "+invalidPort))
+			continue
+		}
+		setImportEntryPorts(entry, ports[0], ports[1])
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,113 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseAWSSecurityGroupRules(t *testing.T) {
+	entries, err := parseAWSSecurityGroupRules(`{
+  "SecurityGroups": [
+    {
+      "GroupId": "sg-1234",
+      "IpPermissions": [
+        {"IpProtocol": "tcp", "FromPort": 22, "ToPort": 22, "IpRanges": [{"CidrIp": "10.0.0.0/8"}], "Ipv6Ranges": [{"CidrIpv6": "::/0"}]},
+        {"IpProtocol": "tcp", "FromPort": 80, "ToPort": 80, "UserIdGroupPairs": [{"GroupId": "sg-5678"}]},
+        {"IpProtocol": "icmp", "FromPort": -1, "ToPort": -1, "IpRanges": [{"CidrIp": "0.0.0.0/0"}]}
+      ],
+      "IpPermissionsEgress": [
+        {"IpProtocol": "-1", "IpRanges": [{"CidrIp": "0.0.0.0/0"}]}
+      ]
+    }
+  ]
+}`)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+
+	assert.True(t, entries[0].Imported)
+	assert.Equal(t, instance.SecurityGroupRuleDirectionInbound, entries[0].Direction)
+	assert.Equal(t, instance.SecurityGroupRuleActionAccept, entries[0].Action)
+	assert.Equal(t, instance.SecurityGroupRuleProtocolTCP, entries[0].Protocol)
+	assert.Equal(t, "10.0.0.0/8", entries[0].IPRange)
+	assert.Equal(t, scw.Uint32Ptr(22), entries[0].DestPortFrom)
+	assert.Nil(t, entries[0].DestPortTo)
+
+	assert.True(t, entries[1].Imported)
+	assert.Equal(t, "::/0", entries[1].IPRange)
+
+	assert.False(t, entries[2].Imported)
+	assert.Equal(t, "security group references are not supported", entries[2].Reason)
+
+	assert.Equal(t, instance.SecurityGroupRuleProtocolICMP, entries[3].Protocol)
+	assert.Nil(t, entries[3].DestPortFrom)
+
+	assert.Equal(t, instance.SecurityGroupRuleDirectionOutbound, entries[4].Direction)
+	assert.Equal(t, instance.SecurityGroupRuleProtocolANY, entries[4].Protocol)
+}
+
+func Test_parseIptablesSecurityGroupRules(t *testing.T) {
+	entries, err := parseIptablesSecurityGroupRules(`*filter
+:INPUT DROP [0:0]
+:OUTPUT ACCEPT [0:0]
+-A INPUT -s 192.168.1.1 -p tcp -m tcp --dport 8000:8080 -j ACCEPT
+-A INPUT -p udp --dport 53 -j DROP
+-A INPUT -m state --state RELATED,ESTABLISHED -j ACCEPT
+-A FORWARD -j DROP
+-A OUTPUT -d 10.0.0.0/8 -j REJECT
+COMMIT
+`)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+
+	assert.True(t, entries[0].Imported)
+	assert.Equal(t, instance.SecurityGroupRuleDirectionInbound, entries[0].Direction)
+	assert.Equal(t, "192.168.1.1/32", entries[0].IPRange)
+	assert.Equal(t, scw.Uint32Ptr(8000), entries[0].DestPortFrom)
+	assert.Equal(t, scw.Uint32Ptr(8080), entries[0].DestPortTo)
+
+	assert.True(t, entries[1].Imported)
+	assert.Equal(t, instance.SecurityGroupRuleActionDrop, entries[1].Action)
+	assert.Equal(t, instance.SecurityGroupRuleProtocolUDP, entries[1].Protocol)
+	assert.Equal(t, "0.0.0.0/0", entries[1].IPRange)
+
+	assert.False(t, entries[2].Imported)
+	assert.Equal(t, "unsupported match state", entries[2].Reason)
+
+	assert.False(t, entries[3].Imported)
+	assert.Equal(t, "unsupported chain FORWARD", entries[3].Reason)
+
+	assert.True(t, entries[4].Imported)
+	assert.Equal(t, instance.SecurityGroupRuleDirectionOutbound, entries[4].Direction)
+	assert.Equal(t, instance.SecurityGroupRuleActionDrop, entries[4].Action)
+	assert.Equal(t, "10.0.0.0/8", entries[4].IPRange)
+}
+
+func Test_parseCSVSecurityGroupRules(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		entries, err := parseCSVSecurityGroupRules(`direction,action,protocol,ip_range,port_from,port_to
+inbound,accept,TCP,0.0.0.0/0,443,
+inbound,drop,ANY,10.0.0.0/8,,
+outbound,reject,TCP,0.0.0.0/0,,
+`)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		assert.True(t, entries[0].Imported)
+		assert.Equal(t, scw.Uint32Ptr(443), entries[0].DestPortFrom)
+
+		assert.True(t, entries[1].Imported)
+		assert.Equal(t, instance.SecurityGroupRuleProtocolANY, entries[1].Protocol)
+
+		assert.False(t, entries[2].Imported)
+		assert.Equal(t, "invalid action reject", entries[2].Reason)
+	})
+
+	t.Run("Missing column", func(t *testing.T) {
+		_, err := parseCSVSecurityGroupRules("direction,action,protocol\ninbound,accept,TCP\n")
+		assert.EqualError(t, err, "invalid csv: missing ip_range column")
+	})
+}
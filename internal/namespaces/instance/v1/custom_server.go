@@ -155,6 +155,87 @@ func serverListBuilder(c *core.Command) *core.Command {
 
 	c.ArgsType = reflect.TypeOf(customListServersRequest{})
 
+	// Secondary columns are hidden first and long values truncated when the list does not fit in the terminal
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{
+				FieldName: "ID",
+			},
+			{
+				FieldName: "Name",
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Type",
+			},
+			{
+				FieldName: "State",
+			},
+			{
+				FieldName: "Zone",
+			},
+			{
+				FieldName: "PublicIP",
+			},
+			{
+				FieldName: "PrivateIP",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Tags",
+				Priority:  -2,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "ImageName",
+				Priority:  -1,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "RoutedIPEnabled",
+				Priority:  -1,
+			},
+			{
+				FieldName: "ModificationDate",
+				Priority:  -2,
+			},
+			{
+				FieldName: "CreationDate",
+				Priority:  -2,
+			},
+			{
+				FieldName: "Volumes",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Protected",
+				Priority:  -1,
+			},
+			{
+				FieldName: "SecurityGroupName",
+				Priority:  -1,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "SecurityGroupID",
+				Priority:  -1,
+			},
+			{
+				FieldName: "StateDetail",
+				Priority:  -1,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Arch",
+				Priority:  -1,
+			},
+			{
+				FieldName: "ImageID",
+				Priority:  -1,
+			},
+		},
+	}
+
 	c.AddInterceptors(func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (i interface{}, err error) {
 		args := argsI.(*customListServersRequest)
 
@@ -743,6 +824,7 @@ func serverDeleteCommand() *core.Command {
 				Short:   "Stop a running server",
 			},
 		},
+		DeletePreview: serverDeletePreview,
 		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
 			deleteServerArgs := argsI.(*customDeleteServerRequest)
 
@@ -761,7 +843,7 @@ func serverDeleteCommand() *core.Command {
 				finalStateServer, err := api.WaitForServer(&instance.WaitForServerRequest{
 					Zone:          deleteServerArgs.Zone,
 					ServerID:      deleteServerArgs.ServerID,
-					Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+					Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 					RetryInterval: core.DefaultRetryInterval,
 				})
 				if err != nil {
@@ -773,7 +855,7 @@ func serverDeleteCommand() *core.Command {
 						Zone:          deleteServerArgs.Zone,
 						ServerID:      deleteServerArgs.ServerID,
 						Action:        instance.ServerActionPoweroff,
-						Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+						Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 						RetryInterval: core.DefaultRetryInterval,
 					})
 					if err != nil {
@@ -802,18 +884,8 @@ func serverDeleteCommand() *core.Command {
 			}
 
 			deletedVolumeMessages := [][2]string(nil)
-		volumeDelete:
 			for index, volume := range server.Server.Volumes {
-				switch {
-				case deleteServerArgs.WithVolumes == withVolumesNone:
-					break volumeDelete
-				case deleteServerArgs.WithVolumes == withVolumesRoot && index != "0":
-					continue
-				case deleteServerArgs.WithVolumes == withVolumesLocal && volume.VolumeType != instance.VolumeServerVolumeTypeLSSD:
-					continue
-				case deleteServerArgs.WithVolumes == withVolumesBlock && volume.VolumeType != instance.VolumeServerVolumeTypeBSSD && volume.VolumeType != instance.VolumeServerVolumeTypeSbsVolume:
-					continue
-				case volume.VolumeType == instance.VolumeServerVolumeTypeScratch:
+				if !shouldDeleteServerVolume(index, volume, deleteServerArgs.WithVolumes) {
 					continue
 				}
 				if volume.VolumeType == instance.VolumeServerVolumeTypeSbsVolume {
@@ -855,3 +927,62 @@ func serverDeleteCommand() *core.Command {
 		},
 	}
 }
+
+// shouldDeleteServerVolume returns whether a volume of a server is deleted with the server, scratch volumes are always deleted by the API.
+func shouldDeleteServerVolume(index string, volume *instance.VolumeServer, withVolumes withVolumes) bool {
+	switch {
+	case withVolumes == withVolumesNone:
+		return false
+	case withVolumes == withVolumesRoot && index != "0":
+		return false
+	case withVolumes == withVolumesLocal && volume.VolumeType != instance.VolumeServerVolumeTypeLSSD:
+		return false
+	case withVolumes == withVolumesBlock && volume.VolumeType != instance.VolumeServerVolumeTypeBSSD && volume.VolumeType != instance.VolumeServerVolumeTypeSbsVolume:
+		return false
+	case volume.VolumeType == instance.VolumeServerVolumeTypeScratch:
+		return false
+	}
+	return true
+}
+
+func serverDeletePreview(ctx context.Context, argsI interface{}) (*core.DeletePreview, error) {
+	args := argsI.(*customDeleteServerRequest)
+
+	server, err := instance.NewAPI(core.ExtractClient(ctx)).GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &core.DeletePreviewResource{
+		Type: "server",
+		ID:   server.Server.ID,
+		Name: server.Server.Name,
+	}
+	indexes := make([]string, 0, len(server.Server.Volumes))
+	for index := range server.Server.Volumes {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+	for _, index := range indexes {
+		volume := server.Server.Volumes[index]
+		if shouldDeleteServerVolume(index, volume, args.WithVolumes) {
+			resource.Dependents = append(resource.Dependents, &core.DeletePreviewResource{
+				Type: "volume",
+				ID:   volume.ID,
+				Name: volume.Name,
+			})
+		}
+	}
+	if args.WithIP && server.Server.PublicIP != nil && !server.Server.PublicIP.Dynamic {
+		resource.Dependents = append(resource.Dependents, &core.DeletePreviewResource{
+			Type: "ip",
+			ID:   server.Server.PublicIP.ID,
+			Name: server.Server.PublicIP.Address.String(),
+		})
+	}
+
+	return &core.DeletePreview{Resources: []*core.DeletePreviewResource{resource}}, nil
+}
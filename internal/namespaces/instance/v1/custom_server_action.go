@@ -15,14 +15,17 @@ import (
 
 func serverStartCommand() *core.Command {
 	return &core.Command{
-		Short:     `Power on server`,
-		Namespace: "instance",
-		Resource:  "server",
-		Verb:      "start",
-		ArgsType:  reflect.TypeOf(instanceUniqueActionRequest{}),
-		Run:       getRunServerAction(instance.ServerActionPoweron),
-		WaitFunc:  waitForServerFunc(),
-		ArgSpecs:  serverActionArgSpecs,
+		Short:                 `Power on server`,
+		Long:                  "Power on server.\n" + serverPowerActionLong,
+		Namespace:             "instance",
+		Resource:              "server",
+		Verb:                  "start",
+		ArgsType:              reflect.TypeOf(serverPowerActionRequest{}),
+		RawArgsRewriter:       serverIDsRawArgs,
+		Run:                   getRunServerPowerAction(instance.ServerActionPoweron),
+		WaitFunc:              waitForServerPowerActionFunc(),
+		ArgSpecs:              serverPowerActionArgSpecs,
+		OptionalPositionalArg: true,
 		Examples: []*core.Example{
 			{
 				Short:    "Start a server in the default zone with a given id",
@@ -32,20 +35,27 @@ func serverStartCommand() *core.Command {
 				Short:    "Start a server in fr-par-1 zone with a given id",
 				ArgsJSON: `{"zone":"fr-par-1", "server_id": "11111111-1111-1111-1111-111111111111"}`,
 			},
+			{
+				Short: "Start several servers",
+				Raw:   "scw instance server start 11111111-1111-1111-1111-111111111111 22222222-2222-2222-2222-222222222222",
+			},
 		},
 	}
 }
 
 func serverStopCommand() *core.Command {
 	return &core.Command{
-		Short:     `Power off server`,
-		Namespace: "instance",
-		Resource:  "server",
-		Verb:      "stop",
-		ArgsType:  reflect.TypeOf(instanceUniqueActionRequest{}),
-		Run:       getRunServerAction(instance.ServerActionPoweroff),
-		WaitFunc:  waitForServerFunc(),
-		ArgSpecs:  serverActionArgSpecs,
+		Short:                 `Power off server`,
+		Long:                  "Power off server.\n" + serverPowerActionLong,
+		Namespace:             "instance",
+		Resource:              "server",
+		Verb:                  "stop",
+		ArgsType:              reflect.TypeOf(serverPowerActionRequest{}),
+		RawArgsRewriter:       serverIDsRawArgs,
+		Run:                   getRunServerPowerAction(instance.ServerActionPoweroff),
+		WaitFunc:              waitForServerPowerActionFunc(),
+		ArgSpecs:              serverPowerActionArgSpecs,
+		OptionalPositionalArg: true,
 		Examples: []*core.Example{
 			{
 				Short:    "Stop a server in the default zone with a given id",
@@ -55,20 +65,27 @@ func serverStopCommand() *core.Command {
 				Short:    "Stop a server in fr-par-1 zone with a given id",
 				ArgsJSON: `{"zone":"fr-par-1", "server_id": "11111111-1111-1111-1111-111111111111"}`,
 			},
+			{
+				Short: "Stop all the servers having a tag and wait until they are stopped",
+				Raw:   "scw instance server stop tags.0=staging --wait",
+			},
 		},
 	}
 }
 
 func serverStandbyCommand() *core.Command {
 	return &core.Command{
-		Short:     `Put server in standby mode`,
-		Namespace: "instance",
-		Resource:  "server",
-		Verb:      "standby",
-		ArgsType:  reflect.TypeOf(instanceUniqueActionRequest{}),
-		Run:       getRunServerAction(instance.ServerActionStopInPlace),
-		WaitFunc:  waitForServerFunc(),
-		ArgSpecs:  serverActionArgSpecs,
+		Short:                 `Put server in standby mode`,
+		Long:                  "Put server in standby mode.\n" + serverPowerActionLong,
+		Namespace:             "instance",
+		Resource:              "server",
+		Verb:                  "standby",
+		ArgsType:              reflect.TypeOf(serverPowerActionRequest{}),
+		RawArgsRewriter:       serverIDsRawArgs,
+		Run:                   getRunServerPowerAction(instance.ServerActionStopInPlace),
+		WaitFunc:              waitForServerPowerActionFunc(),
+		ArgSpecs:              serverPowerActionArgSpecs,
+		OptionalPositionalArg: true,
 		Examples: []*core.Example{
 			{
 				Short:    "Put in standby a server in the default zone with a given id",
@@ -78,20 +95,27 @@ func serverStandbyCommand() *core.Command {
 				Short:    "Put in standby a server in fr-par-1 zone with a given id",
 				ArgsJSON: `{"zone":"fr-par-1", "server_id": "11111111-1111-1111-1111-111111111111"}`,
 			},
+			{
+				Short: "Put in standby all the servers having a tag",
+				Raw:   "scw instance server standby tags.0=staging",
+			},
 		},
 	}
 }
 
 func serverRebootCommand() *core.Command {
 	return &core.Command{
-		Short:     `Reboot server`,
-		Namespace: "instance",
-		Resource:  "server",
-		Verb:      "reboot",
-		ArgsType:  reflect.TypeOf(instanceUniqueActionRequest{}),
-		Run:       getRunServerAction(instance.ServerActionReboot),
-		WaitFunc:  waitForServerFunc(),
-		ArgSpecs:  serverActionArgSpecs,
+		Short:                 `Reboot server`,
+		Long:                  "Reboot server.\n" + serverPowerActionLong,
+		Namespace:             "instance",
+		Resource:              "server",
+		Verb:                  "reboot",
+		ArgsType:              reflect.TypeOf(serverPowerActionRequest{}),
+		RawArgsRewriter:       serverIDsRawArgs,
+		Run:                   getRunServerPowerAction(instance.ServerActionReboot),
+		WaitFunc:              waitForServerPowerActionFunc(),
+		ArgSpecs:              serverPowerActionArgSpecs,
+		OptionalPositionalArg: true,
 		Examples: []*core.Example{
 			{
 				Short:    "Reboot a server in the default zone with a given id",
@@ -101,6 +125,10 @@ func serverRebootCommand() *core.Command {
 				Short:    "Reboot a server in fr-par-1 zone with a given id",
 				ArgsJSON: `{"zone":"fr-par-1", "server_id": "11111111-1111-1111-1111-111111111111"}`,
 			},
+			{
+				Short: "Reboot several servers",
+				Raw:   "scw instance server reboot 11111111-1111-1111-1111-111111111111 22222222-2222-2222-2222-222222222222",
+			},
 		},
 	}
 }
@@ -200,7 +228,7 @@ Once your image is ready you will be able to create a new server based on this i
 			return api.WaitForImage(&instance.WaitForImageRequest{
 				ImageID:       resp.Image.ID,
 				Zone:          resp.Image.Zone,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+				Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 				RetryInterval: core.DefaultRetryInterval,
 			})
 		},
@@ -312,7 +340,7 @@ func serverTerminateCommand() *core.Command {
 			_, err := api.WaitForServer(&instance.WaitForServerRequest{
 				Zone:          server.Zone,
 				ServerID:      server.ID,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+				Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 				RetryInterval: core.DefaultRetryInterval,
 			})
 			if err != nil {
@@ -466,7 +494,7 @@ func waitForServerFunc() core.WaitFunc {
 		return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 			Zone:          argsI.(*instanceUniqueActionRequest).Zone,
 			ServerID:      argsI.(*instanceUniqueActionRequest).ServerID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -504,7 +532,7 @@ func serverActionCommand() *core.Command {
 			return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 				Zone:          argsI.(*instanceActionRequest).Zone,
 				ServerID:      argsI.(*instanceActionRequest).ServerID,
-				Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+				Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 				RetryInterval: core.DefaultRetryInterval,
 			})
 		},
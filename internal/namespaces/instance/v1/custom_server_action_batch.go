@@ -0,0 +1,199 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// serverPowerActionRequest targets one server, several servers given as positional arguments or the servers having some tags.
+type serverPowerActionRequest struct {
+	Zone     scw.Zone
+	ServerID string
+	// ServerIDs is set by serverIDsRawArgs when several servers are given
+	ServerIDs []string
+	Tags      []string
+}
+
+// serverPowerActionResult is the outcome of an action on a server of a batch.
+type serverPowerActionResult struct {
+	ServerID string               `json:"server_id"`
+	State    instance.ServerState `json:"state,omitempty"`
+	Status   string               `json:"status"`
+}
+
+var serverPowerActionArgSpecs = core.ArgSpecs{
+	{
+		Name:       "server-id",
+		Short:      `ID of the server affected by the action, several IDs can be given`,
+		Positional: true,
+	},
+	{
+		Name:  "tags.{index}",
+		Short: `Act on all the servers having these tags`,
+	},
+	core.ZoneArgSpec(),
+}
+
+const serverPowerActionLong = `Several servers can be given, as positional arguments or with the tags they have.
+The action is then performed on all the servers concurrently, as set in the batch section of the config, and the result of each server is listed.`
+
+// serverIDsRawArgs moves several positional server IDs to the server-ids argument, so that a single command acts on all of them.
+func serverIDsRawArgs(rawArgs args.RawArgs) args.RawArgs {
+	positionalArgs := rawArgs.GetPositionalArgs()
+	if len(positionalArgs) < 2 {
+		return rawArgs
+	}
+
+	rewrittenArgs := rawArgs.RemoveAllPositional()
+	for i, serverID := range positionalArgs {
+		rewrittenArgs = rewrittenArgs.Add(fmt.Sprintf("server-ids.%d", i), serverID)
+	}
+	return rewrittenArgs
+}
+
+func getRunServerPowerAction(action instance.ServerAction) core.CommandRunner {
+	return func(ctx context.Context, argsI interface{}) (interface{}, error) {
+		args := argsI.(*serverPowerActionRequest)
+		if len(args.ServerIDs) == 0 && len(args.Tags) == 0 {
+			if args.ServerID == "" {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("a server ID or tags are required"),
+					Hint: "Give the IDs of the servers as positional arguments or their tags with tags.0=<tag>",
+				}
+			}
+			return getRunServerAction(action)(ctx, &instanceUniqueActionRequest{
+				Zone:     args.Zone,
+				ServerID: args.ServerID,
+			})
+		}
+
+		api := instance.NewAPI(core.ExtractClient(ctx))
+		serverIDs, err := serverPowerActionTargets(ctx, api, args)
+		if err != nil {
+			return nil, err
+		}
+
+		results := make([]*serverPowerActionResult, 0, len(serverIDs))
+		tasks := make([]*core.BatchTask, 0, len(serverIDs))
+		for _, serverID := range serverIDs {
+			result := &serverPowerActionResult{ServerID: serverID}
+			results = append(results, result)
+			tasks = append(tasks, &core.BatchTask{
+				Name: fmt.Sprintf("server %s", serverID),
+				Run: func(ctx context.Context) error {
+					_, err := api.ServerAction(&instance.ServerActionRequest{
+						Zone:     args.Zone,
+						ServerID: result.ServerID,
+						Action:   action,
+					}, scw.WithContext(ctx))
+					if err != nil {
+						result.Status = fmt.Sprintf("failed: %s", err)
+						return err
+					}
+					result.Status = fmt.Sprintf("%s started", action)
+					return nil
+				},
+			})
+		}
+
+		return results, serverPowerActionBatchError(results, core.RunBatch(ctx, tasks))
+	}
+}
+
+// serverPowerActionTargets returns the IDs of the servers given as arguments and of the servers having the given tags, without duplicates.
+func serverPowerActionTargets(ctx context.Context, api *instance.API, args *serverPowerActionRequest) ([]string, error) {
+	serverIDs := []string(nil)
+	seen := map[string]bool{}
+	addServerID := func(serverID string) {
+		if serverID != "" && !seen[serverID] {
+			seen[serverID] = true
+			serverIDs = append(serverIDs, serverID)
+		}
+	}
+
+	addServerID(args.ServerID)
+	for _, serverID := range args.ServerIDs {
+		addServerID(serverID)
+	}
+
+	if len(args.Tags) > 0 {
+		resp, err := api.ListServers(&instance.ListServersRequest{
+			Zone: args.Zone,
+			Tags: args.Tags,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.Servers) == 0 && len(serverIDs) == 0 {
+			return nil, fmt.Errorf("no server found in zone %s with tags %v", args.Zone, args.Tags)
+		}
+		for _, server := range resp.Servers {
+			addServerID(server.ID)
+		}
+	}
+
+	return serverIDs, nil
+}
+
+// serverPowerActionBatchError adds the results of all the servers to the error of a batch, as the results are not printed on error.
+func serverPowerActionBatchError(results []*serverPowerActionResult, err error) error {
+	if err == nil {
+		return nil
+	}
+	details, marshalErr := human.Marshal(results, nil)
+	if marshalErr != nil {
+		return err
+	}
+	return &core.CliError{
+		Err:     err,
+		Details: details,
+	}
+}
+
+func waitForServerPowerActionFunc() core.WaitFunc {
+	return func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+		args := argsI.(*serverPowerActionRequest)
+		api := instance.NewAPI(core.ExtractClient(ctx))
+
+		results, isBatch := respI.([]*serverPowerActionResult)
+		if !isBatch {
+			return api.WaitForServer(&instance.WaitForServerRequest{
+				Zone:          args.Zone,
+				ServerID:      args.ServerID,
+				Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+				RetryInterval: core.DefaultRetryInterval,
+			})
+		}
+
+		tasks := make([]*core.BatchTask, 0, len(results))
+		for _, result := range results {
+			result := result
+			tasks = append(tasks, &core.BatchTask{
+				Name: fmt.Sprintf("server %s", result.ServerID),
+				Run: func(ctx context.Context) error {
+					server, err := api.WaitForServer(&instance.WaitForServerRequest{
+						Zone:          args.Zone,
+						ServerID:      result.ServerID,
+						Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+						RetryInterval: core.DefaultRetryInterval,
+					}, scw.WithContext(ctx))
+					if err != nil {
+						result.Status = fmt.Sprintf("failed: %s", err)
+						return err
+					}
+					result.State = server.State
+					result.Status = "done"
+					return nil
+				},
+			})
+		}
+
+		return results, serverPowerActionBatchError(results, core.RunBatch(ctx, tasks))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/alecthomas/assert"
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
@@ -18,7 +19,7 @@ func Test_ServerTerminate(t *testing.T) {
 
 	t.Run("without IP", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create image=ubuntu-jammy -w"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive image=ubuntu-jammy -w"),
 		Cmd:        `scw instance server terminate {{ .Server.ID }}`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -38,7 +39,7 @@ func Test_ServerTerminate(t *testing.T) {
 
 	t.Run("with IP", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create image=ubuntu-jammy -w"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive image=ubuntu-jammy -w"),
 		Cmd:        `scw instance server terminate {{ .Server.ID }} with-ip=true`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -58,7 +59,7 @@ func Test_ServerTerminate(t *testing.T) {
 
 	t.Run("without block", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create image=ubuntu-jammy additional-volumes.0=block:10G -w"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive image=ubuntu-jammy additional-volumes.0=block:10G -w"),
 		Cmd:        `scw instance server terminate {{ .Server.ID }} with-ip=true with-block=false`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -73,7 +74,7 @@ func Test_ServerTerminate(t *testing.T) {
 
 	t.Run("with block", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create image=ubuntu-jammy additional-volumes.0=block:10G -w"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive image=ubuntu-jammy additional-volumes.0=block:10G -w"),
 		Cmd:        `scw instance server terminate {{ .Server.ID }} with-ip=true with-block=true -w`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -167,3 +168,13 @@ func Test_ServerEnableRoutedIP(t *testing.T) {
 		),
 	}))
 }
+
+func Test_serverIDsRawArgs(t *testing.T) {
+	rawArgs := args.RawArgs{"11111111-1111-1111-1111-111111111111", "zone=fr-par-2"}
+	assert.Equal(t, rawArgs, serverIDsRawArgs(rawArgs))
+
+	assert.Equal(t,
+		args.RawArgs{"zone=fr-par-2", "server-ids.0=11111111-1111-1111-1111-111111111111", "server-ids.1=22222222-2222-2222-2222-222222222222"},
+		serverIDsRawArgs(args.RawArgs{"11111111-1111-1111-1111-111111111111", "zone=fr-par-2", "22222222-2222-2222-2222-222222222222"}),
+	)
+}
@@ -0,0 +1,262 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	bootMigrationStatusPending  = "to migrate"
+	bootMigrationStatusBlocked  = "cannot migrate"
+	bootMigrationStatusMigrated = "migrated"
+)
+
+type serverMigrateBootRequest struct {
+	Zone      scw.Zone
+	ProjectID *string
+	ServerIDs []string
+	Apply     bool
+	Yes       bool
+}
+
+// serverBootMigration lists the changes required to boot a server without bootscript.
+type serverBootMigration struct {
+	ServerID    string               `json:"server_id"`
+	ServerName  string               `json:"server_name"`
+	ServerState instance.ServerState `json:"server_state"`
+	BootType    instance.BootType    `json:"boot_type"`
+	Bootscript  string               `json:"bootscript"`
+	Changes     string               `json:"changes"`
+	Status      string               `json:"status"`
+
+	// bootVolumeIndex is the index of the volume to flag as boot volume, empty when no volume must be flagged
+	bootVolumeIndex string
+	server          *instance.Server
+}
+
+func serverMigrateBootCommand() *core.Command {
+	return &core.Command{
+		Short: `Migrate servers from bootscripts to local boot`,
+		Long: `Bootscripts are deprecated, servers must boot on the kernel of their root volume instead.
+List the servers of a zone still configured with legacy boot options and the changes required to migrate them:
+  - the boot type is switched from bootscript to local.
+  - the root volume is flagged as boot volume when no volume is.
+
+With apply=true, the changes are applied to the servers that can be migrated, after a confirmation unless yes=true is set.
+Running servers boot on their root volume at their next reboot.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "migrate-boot",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverMigrateBootRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			{
+				Name:  "server-ids.{index}",
+				Short: `IDs of the servers to migrate, all the servers with legacy boot options by default`,
+			},
+			{
+				Name:  "apply",
+				Short: `Apply the changes, only report them otherwise`,
+			},
+			{
+				Name:  "yes",
+				Short: `Apply the changes without asking for confirmation`,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: serverMigrateBootRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Server ID", FieldName: "ServerID"},
+				{Label: "Server Name", FieldName: "ServerName"},
+				{Label: "State", FieldName: "ServerState"},
+				{Label: "Boot Type", FieldName: "BootType"},
+				{Label: "Bootscript", FieldName: "Bootscript"},
+				{Label: "Changes", FieldName: "Changes"},
+				{Label: "Status", FieldName: "Status"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Report the changes required to migrate the servers of the default zone",
+				Raw:   "scw instance server migrate-boot",
+			},
+			{
+				Short: "Migrate all the servers of a zone",
+				Raw:   "scw instance server migrate-boot apply=true zone=nl-ams-1",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Update the boot type of a server",
+				Command: "scw instance server update",
+			},
+		},
+	}
+}
+
+func serverMigrateBootRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverMigrateBootRequest)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	servers, err := api.ListServers(&instance.ListServersRequest{
+		Zone:    args.Zone,
+		Project: args.ProjectID,
+		Servers: args.ServerIDs,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := planBootMigrations(servers.Servers)
+	pending := []*serverBootMigration(nil)
+	for _, migration := range migrations {
+		if migration.Status == bootMigrationStatusPending {
+			pending = append(pending, migration)
+		}
+	}
+	if !args.Apply || len(pending) == 0 {
+		return migrations, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("the migration of the servers must be confirmed"),
+				Details: formatBootMigrations(pending),
+				Hint:    "Use yes=true to migrate the servers without confirmation",
+			}
+		}
+
+		_, _ = interactive.Println(formatBootMigrations(pending))
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to proceed?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Migration canceled"}, nil
+		}
+	}
+
+	tasks := make([]*core.BatchTask, 0, len(pending))
+	for _, migration := range pending {
+		migration := migration
+		tasks = append(tasks, &core.BatchTask{
+			Name: fmt.Sprintf("server %s", migration.ServerID),
+			Run: func(ctx context.Context) error {
+				err := applyBootMigration(ctx, api, args.Zone, migration)
+				if err != nil {
+					migration.Status = fmt.Sprintf("failed: %s", err)
+					return err
+				}
+				migration.Status = bootMigrationStatusMigrated
+				if migration.ServerState == instance.ServerStateRunning {
+					migration.Status += ", reboot required"
+				}
+				return nil
+			},
+		})
+	}
+
+	return migrations, core.RunBatch(ctx, tasks)
+}
+
+// planBootMigrations returns the changes required to migrate the servers still configured with legacy boot options.
+// Servers booting on a local volume or in rescue mode are skipped, their bootscript is ignored.
+func planBootMigrations(servers []*instance.Server) []*serverBootMigration {
+	migrations := []*serverBootMigration(nil)
+	for _, server := range servers {
+		if server.BootType != instance.BootTypeBootscript {
+			continue
+		}
+
+		migration := &serverBootMigration{
+			ServerID:    server.ID,
+			ServerName:  server.Name,
+			ServerState: server.State,
+			BootType:    server.BootType,
+			Status:      bootMigrationStatusPending,
+			server:      server,
+		}
+		if server.Bootscript != nil {
+			migration.Bootscript = server.Bootscript.Title
+		}
+
+		changes := []string{"switch boot type from bootscript to local"}
+		switch rootVolume, hasRootVolume := server.Volumes["0"]; {
+		case !hasRootVolume:
+			migration.Status = bootMigrationStatusBlocked + ": no root volume to boot on"
+		case !hasBootVolume(server):
+			migration.bootVolumeIndex = "0"
+			changes = append(changes, fmt.Sprintf("flag root volume %s as boot volume", rootVolume.ID))
+		}
+		if server.State == instance.ServerStateRunning {
+			changes = append(changes, "reboot to boot on the root volume")
+		}
+		migration.Changes = strings.Join(changes, ", ")
+
+		migrations = append(migrations, migration)
+	}
+
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return migrations[i].ServerName < migrations[j].ServerName
+	})
+
+	return migrations
+}
+
+func hasBootVolume(server *instance.Server) bool {
+	for _, volume := range server.Volumes {
+		if volume.Boot {
+			return true
+		}
+	}
+	return false
+}
+
+func formatBootMigrations(migrations []*serverBootMigration) string {
+	lines := []string{"The following servers will be migrated:"}
+	for _, migration := range migrations {
+		lines = append(lines, fmt.Sprintf("  - server %s (%s): %s", migration.ServerName, migration.ServerID, migration.Changes))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// applyBootMigration switches the server to local boot. Volumes are only updated when a boot volume must be flagged,
+// as all the volumes of the server must then be sent.
+func applyBootMigration(ctx context.Context, api *instance.API, zone scw.Zone, migration *serverBootMigration) error {
+	bootType := instance.BootTypeLocal
+	request := &instance.UpdateServerRequest{
+		Zone:     zone,
+		ServerID: migration.ServerID,
+		BootType: &bootType,
+	}
+	if migration.bootVolumeIndex != "" {
+		volumes := make(map[string]*instance.VolumeServerTemplate, len(migration.server.Volumes))
+		for index, volume := range migration.server.Volumes {
+			volumes[index] = &instance.VolumeServerTemplate{
+				ID:   scw.StringPtr(volume.ID),
+				Name: scw.StringPtr(volume.Name),
+				Boot: scw.BoolPtr(index == migration.bootVolumeIndex),
+			}
+		}
+		request.Volumes = &volumes
+	}
+
+	_, err := api.UpdateServer(request, scw.WithContext(ctx))
+	return err
+}
@@ -0,0 +1,46 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_planBootMigrations(t *testing.T) {
+	servers := []*instance.Server{
+		{ID: "local", Name: "local", BootType: instance.BootTypeLocal, Volumes: map[string]*instance.VolumeServer{
+			"0": {ID: "local-root"},
+		}},
+		{ID: "legacy", Name: "legacy", BootType: instance.BootTypeBootscript, State: instance.ServerStateRunning,
+			Bootscript: &instance.Bootscript{Title: "x86_64 mainline 4.4.230 rev1"},
+			Volumes: map[string]*instance.VolumeServer{
+				"0": {ID: "legacy-root"},
+				"1": {ID: "legacy-data"},
+			}},
+		{ID: "flagged", Name: "flagged", BootType: instance.BootTypeBootscript, State: instance.ServerStateStopped,
+			Volumes: map[string]*instance.VolumeServer{
+				"0": {ID: "flagged-root", Boot: true},
+			}},
+		{ID: "diskless", Name: "diskless", BootType: instance.BootTypeBootscript},
+	}
+
+	migrations := planBootMigrations(servers)
+	assert.Len(t, migrations, 3)
+
+	byID := map[string]*serverBootMigration{}
+	for _, migration := range migrations {
+		byID[migration.ServerID] = migration
+	}
+
+	assert.Equal(t, bootMigrationStatusBlocked+": no root volume to boot on", byID["diskless"].Status)
+
+	assert.Equal(t, bootMigrationStatusPending, byID["legacy"].Status)
+	assert.Equal(t, "0", byID["legacy"].bootVolumeIndex)
+	assert.Equal(t, "x86_64 mainline 4.4.230 rev1", byID["legacy"].Bootscript)
+	assert.Equal(t, "switch boot type from bootscript to local, flag root volume legacy-root as boot volume, reboot to boot on the root volume", byID["legacy"].Changes)
+
+	assert.Equal(t, bootMigrationStatusPending, byID["flagged"].Status)
+	assert.Equal(t, "", byID["flagged"].bootVolumeIndex)
+	assert.Equal(t, "switch boot type from bootscript to local", byID["flagged"].Changes)
+}
@@ -24,6 +24,7 @@ type instanceCreateServerRequest struct {
 	Image             string
 	Type              string
 	Name              string
+	Count             uint32
 	RootVolume        string
 	AdditionalVolumes []string
 	IP                string
@@ -32,6 +33,8 @@ type instanceCreateServerRequest struct {
 	Stopped           bool
 	SecurityGroupID   string
 	PlacementGroupID  string
+	// PlacementGroupPolicyType is the policy of the placement group created with placement-group-id=new
+	PlacementGroupPolicyType instance.PlacementGroupPolicyType
 
 	// IP Mobility
 	RoutedIPEnabled *bool
@@ -47,8 +50,9 @@ type instanceCreateServerRequest struct {
 
 func serverCreateCommand() *core.Command {
 	return &core.Command{
-		Short:     `Create server`,
-		Long:      `Create an instance server.`,
+		Short: `Create server`,
+		Long: `Create an instance server.
+In interactive mode, the commercial type and the image are picked from searchable lists when they are not given. Use --no-interactive to use their default values instead.`,
 		Namespace: "instance",
 		Verb:      "create",
 		Resource:  "server",
@@ -57,14 +61,14 @@ func serverCreateCommand() *core.Command {
 			{
 				Name:             "image",
 				Short:            "Image ID or label of the server",
-				Default:          core.DefaultValueSetter("ubuntu_jammy"),
+				Default:          core.DefaultValueSetter(serverCreateDefaultImage),
 				Required:         true,
 				AutoCompleteFunc: instanceServerCreateImageAutoCompleteFunc,
 			},
 			{
 				Name:     "type",
 				Short:    "Server commercial type (help: https://www.scaleway.com/en/docs/compute/instances/reference-content/choosing-instance-type/)",
-				Default:  core.DefaultValueSetter("DEV1-S"),
+				Default:  core.DefaultValueSetter(serverCreateDefaultType),
 				Required: true,
 				ValidateFunc: func(_ *core.ArgSpec, _ interface{}) error {
 					// Allow all commercial types
@@ -74,9 +78,13 @@ func serverCreateCommand() *core.Command {
 			},
 			{
 				Name:    "name",
-				Short:   "Server name",
+				Short:   "Server name, {index} is replaced by the number of the server when several servers are created",
 				Default: core.RandomValueGenerator("srv"),
 			},
+			{
+				Name:  "count",
+				Short: "Number of servers to create in parallel, named after the name with their number",
+			},
 			{
 				Name:  "root-volume",
 				Short: "Local root volume of the server",
@@ -108,7 +116,12 @@ func serverCreateCommand() *core.Command {
 			},
 			{
 				Name:  "placement-group-id",
-				Short: "The placement group ID in which the server has to be created",
+				Short: "The placement group ID in which the server has to be created, new to create a placement group for the servers",
+			},
+			{
+				Name:       "placement-group-policy-type",
+				Short:      "Policy of the placement group created with placement-group-id=new, max_availability by default",
+				EnumValues: []string{instance.PlacementGroupPolicyTypeMaxAvailability.String(), instance.PlacementGroupPolicyTypeLowLatency.String()},
 			},
 			{
 				Name:  "bootscript-id",
@@ -133,8 +146,10 @@ func serverCreateCommand() *core.Command {
 			core.ZoneArgSpec(),
 			core.OrganizationIDArgSpec(),
 		},
-		Run:      instanceServerCreateRun,
-		WaitFunc: instanceWaitServerCreateRun(),
+		Run:          instanceServerCreateRun,
+		PickArgs:     serverCreatePickArgs,
+		WaitFunc:     instanceWaitServerCreateRun(),
+		CostEstimate: instanceServerCreateCostEstimate,
 		SeeAlsos: []*core.SeeAlso{{
 			Short:   "List marketplace label images",
 			Command: "scw marketplace image list",
@@ -165,16 +180,23 @@ func serverCreateCommand() *core.Command {
 				Raw: `ip=$(scw instance ip create | grep id | awk '{ print $2 }')
 scw instance server create image=ubuntu_focal ip=$ip`,
 			},
+			{
+				Short: "Create 5 servers named web-1 to web-5 spread in a new placement group",
+				Raw:   "scw instance server create image=ubuntu_jammy type=DEV1-S count=5 name=web-{index} placement-group-id=new --wait",
+			},
 		},
 	}
 }
 
 func instanceWaitServerCreateRun() core.WaitFunc {
 	return func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+		if servers, isBatch := respI.([]*instance.Server); isBatch {
+			return waitForServersCreated(ctx, argsI.(*instanceCreateServerRequest).Zone, servers)
+		}
 		return instance.NewAPI(core.ExtractClient(ctx)).WaitForServer(&instance.WaitForServerRequest{
 			Zone:          argsI.(*instanceCreateServerRequest).Zone,
 			ServerID:      respI.(*instance.Server).ID,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -182,14 +204,34 @@ func instanceWaitServerCreateRun() core.WaitFunc {
 
 func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interface{}, e error) {
 	args := argsI.(*instanceCreateServerRequest)
+	if args.Count > 1 {
+		return instanceServerCreateBatch(ctx, args)
+	}
+
+	if args.PlacementGroupID == placementGroupNew {
+		placementGroup, err := createServersPlacementGroup(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		args.PlacementGroupID = placementGroup.ID
+	}
+	args.Name = serverCreateName(args.Name, 1, 1)
+
+	serverReq, needIPCreation, err := buildServerCreateRequest(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return createServerFromRequest(ctx, args, serverReq, needIPCreation)
+}
 
+// buildServerCreateRequest validates the arguments and builds the request creating the server.
+// needIPCreation is true when a flexible IP must be created for the server.
+func buildServerCreateRequest(ctx context.Context, args *instanceCreateServerRequest) (serverReq *instance.CreateServerRequest, needIPCreation bool, err error) {
 	//
 	// STEP 1: Argument validation and API requests creation.
 	//
 
-	needIPCreation := false
-
-	serverReq := &instance.CreateServerRequest{
+	serverReq = &instance.CreateServerRequest{
 		Zone:            args.Zone,
 		Organization:    args.OrganizationID,
 		Project:         args.ProjectID,
@@ -224,7 +266,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 			Type:           marketplace.LocalImageTypeInstanceLocal,
 		})
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		serverReq.Image = localImage.ID
 	default:
@@ -243,7 +285,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 
 	if serverType != nil && getImageResponse != nil {
 		if err := validateImageServerTypeCompatibility(getImageResponse.Image, serverType, serverReq.CommercialType); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	} else {
 		logger.Warningf("skipping image server-type compatibility validation")
@@ -272,7 +314,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 			IP:   args.IP,
 		})
 		if err != nil { // FIXME: isNotFoundError
-			return nil, fmt.Errorf("%s does not belong to you", args.IP)
+			return nil, false, fmt.Errorf("%s does not belong to you", args.IP)
 		}
 		serverReq.PublicIP = scw.StringPtr(res.IP.ID)
 	case args.IP == "dynamic":
@@ -280,7 +322,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 	case args.IP == "none":
 		serverReq.DynamicIPRequired = scw.BoolPtr(false)
 	default:
-		return nil, fmt.Errorf(`invalid IP "%s", should be either 'new', 'dynamic', 'none', an IP address ID or a reserved flexible IP address`, args.IP)
+		return nil, false, fmt.Errorf(`invalid IP "%s", should be either 'new', 'dynamic', 'none', an IP address ID or a reserved flexible IP address`, args.IP)
 	}
 
 	//
@@ -292,13 +334,13 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 		// Create initial volume template map.
 		volumes, err := buildVolumes(apiInstance, args.Zone, serverReq.Name, args.RootVolume, args.AdditionalVolumes)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		// Validate root volume type and size.
 		if getImageResponse != nil {
 			if err := validateRootVolume(getImageResponse.Image.RootVolume.Size, volumes["0"]); err != nil {
-				return nil, err
+				return nil, false, err
 			}
 		} else {
 			logger.Warningf("skipping root volume validation")
@@ -307,7 +349,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 		// Validate total local volume sizes.
 		if serverType != nil && getImageResponse != nil {
 			if err := validateLocalVolumeSizes(volumes, serverType, serverReq.CommercialType, getImageResponse.Image.RootVolume.Size); err != nil {
-				return nil, err
+				return nil, false, err
 			}
 		} else {
 			logger.Warningf("skip local volume size validation")
@@ -333,7 +375,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 	//
 	if args.BootscriptID != "" {
 		if !validation.IsUUID(args.BootscriptID) {
-			return nil, fmt.Errorf("bootscript ID %s is not a valid UUID", args.BootscriptID)
+			return nil, false, fmt.Errorf("bootscript ID %s is not a valid UUID", args.BootscriptID)
 		}
 		//nolint: staticcheck // Bootscript is deprecated
 		_, err := apiInstance.GetBootscript(&instance.GetBootscriptRequest{
@@ -341,7 +383,7 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 			BootscriptID: args.BootscriptID,
 		})
 		if err != nil { // FIXME: isNotFoundError
-			return nil, fmt.Errorf("bootscript ID %s does not exist", args.BootscriptID)
+			return nil, false, fmt.Errorf("bootscript ID %s does not exist", args.BootscriptID)
 		}
 
 		//nolint: staticcheck // Bootscript is deprecated
@@ -364,6 +406,13 @@ func instanceServerCreateRun(ctx context.Context, argsI interface{}) (i interfac
 		serverReq.PlacementGroup = scw.StringPtr(args.PlacementGroupID)
 	}
 
+	return serverReq, needIPCreation, nil
+}
+
+// createServerFromRequest creates the server, with its flexible IP when needed, and starts it unless it must be stopped.
+func createServerFromRequest(ctx context.Context, args *instanceCreateServerRequest, serverReq *instance.CreateServerRequest, needIPCreation bool) (*instance.Server, error) {
+	apiInstance := instance.NewAPI(core.ExtractClient(ctx))
+
 	//
 	// STEP 2: Resource creations and modifications.
 	//
@@ -771,6 +820,40 @@ func getServerType(apiInstance *instance.API, zone scw.Zone, commercialType stri
 	return serverType
 }
 
+// hoursPerMonth is the number of hours used to estimate monthly prices
+const hoursPerMonth = 730
+
+// instanceServerCreateCostEstimate estimates the monthly cost of the servers of the type, volumes and IPs are not included.
+func instanceServerCreateCostEstimate(ctx context.Context, argsI interface{}) (*core.CostEstimate, error) {
+	args := argsI.(*instanceCreateServerRequest)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	serverTypes, err := api.ListServersTypes(&instance.ListServersTypesRequest{
+		Zone: args.Zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	serverType, exists := serverTypes.Servers[args.Type]
+	if !exists {
+		return nil, fmt.Errorf("unrecognized server type: %s", args.Type)
+	}
+
+	estimate := &core.CostEstimate{
+		MonthlyCost: float64(serverType.HourlyPrice) * hoursPerMonth,
+		Resource:    "server " + args.Type,
+	}
+	if args.Count > 1 {
+		estimate.MonthlyCost *= float64(args.Count)
+		estimate.Resource = fmt.Sprintf("%d servers %s", args.Count, args.Type)
+	}
+	if args.ProjectID != nil {
+		estimate.ProjectID = *args.ProjectID
+	}
+
+	return estimate, nil
+}
+
 func instanceServerCreateIPCreate(args *instanceCreateServerRequest, api *instance.API) (*instance.IP, error) {
 	req := &instance.CreateIPRequest{
 		Zone:         args.Zone,
@@ -0,0 +1,201 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// placementGroupNew is the value of placement-group-id creating a placement group for the created servers.
+const placementGroupNew = "new"
+
+// instanceServerCreateBatch creates count servers in parallel from the same arguments, with their number in their names.
+// The servers created are returned even when the creation of some servers failed.
+func instanceServerCreateBatch(ctx context.Context, args *instanceCreateServerRequest) ([]*instance.Server, error) {
+	err := validateServerCreateBatch(args)
+	if err != nil {
+		return nil, err
+	}
+
+	nameTemplate := args.Name
+	if args.PlacementGroupID == placementGroupNew {
+		placementGroup, err := createServersPlacementGroup(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		args.PlacementGroupID = placementGroup.ID
+	}
+
+	// The image, the type and the volumes are validated once for all the servers.
+	args.Name = serverCreateName(nameTemplate, 1, args.Count)
+	serverReq, needIPCreation, err := buildServerCreateRequest(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	servers := make([]*instance.Server, args.Count)
+	tasks := make([]*core.BatchTask, 0, args.Count)
+	for i := range servers {
+		index := i
+		name := serverCreateName(nameTemplate, i+1, args.Count)
+		request := serverCreateRequestWithName(serverReq, name)
+		tasks = append(tasks, &core.BatchTask{
+			Name: fmt.Sprintf("server %s", name),
+			Run: func(ctx context.Context) error {
+				server, err := createServerFromRequest(ctx, args, request, needIPCreation)
+				if err != nil {
+					return err
+				}
+				servers[index] = server
+				return nil
+			},
+		})
+	}
+
+	err = core.RunBatch(ctx, tasks)
+	created := compactServers(servers)
+	return created, serverCreateBatchError(created, err)
+}
+
+// validateServerCreateBatch rejects the arguments attaching an existing resource, which cannot be attached to several servers.
+func validateServerCreateBatch(args *instanceCreateServerRequest) error {
+	if validation.IsUUID(args.IP) || net.ParseIP(args.IP) != nil {
+		return &core.CliError{
+			Err:  fmt.Errorf("flexible IP %s cannot be attached to several servers", args.IP),
+			Hint: "Use ip=new to create a flexible IP for each server",
+		}
+	}
+
+	volumes := append([]string{args.RootVolume}, args.AdditionalVolumes...)
+	for _, volume := range volumes {
+		if validation.IsUUID(strings.TrimSpace(volume)) {
+			return &core.CliError{
+				Err:  fmt.Errorf("volume %s cannot be attached to several servers", volume),
+				Hint: "Create the volumes of each server from a snapshot, such as block:<snapshot-id>",
+			}
+		}
+	}
+	return nil
+}
+
+// serverCreateName returns the name of the index-th of count servers, counting from 1.
+// {index} is replaced by the number of the server, which is appended to the name when several servers are created without {index}.
+func serverCreateName(name string, index int, count uint32) string {
+	if strings.Contains(name, "{index}") {
+		return strings.ReplaceAll(name, "{index}", strconv.Itoa(index))
+	}
+	if count > 1 {
+		return fmt.Sprintf("%s-%d", name, index)
+	}
+	return name
+}
+
+// serverCreateRequestWithName returns a copy of the request creating a server with another name, as well as the names of its volumes.
+func serverCreateRequestWithName(serverReq *instance.CreateServerRequest, name string) *instance.CreateServerRequest {
+	request := *serverReq
+	request.Name = name
+	if serverReq.Volumes != nil {
+		request.Volumes = make(map[string]*instance.VolumeServerTemplate, len(serverReq.Volumes))
+		for index, volumeTemplate := range serverReq.Volumes {
+			volume := *volumeTemplate
+			if volume.Name != nil {
+				volume.Name = scw.StringPtr(name + "-" + index)
+			}
+			request.Volumes[index] = &volume
+		}
+	}
+	return &request
+}
+
+// createServersPlacementGroup creates the placement group of the created servers, named after them.
+// Its policy is optional, so that servers are created even when the policy cannot be respected.
+func createServersPlacementGroup(ctx context.Context, args *instanceCreateServerRequest) (*instance.PlacementGroup, error) {
+	policyType := instance.PlacementGroupPolicyTypeMaxAvailability
+	if args.PlacementGroupPolicyType != "" {
+		policyType = args.PlacementGroupPolicyType
+	}
+
+	name := strings.Trim(strings.ReplaceAll(args.Name, "{index}", ""), "-_. ")
+	if name == "" {
+		name = args.Name
+	}
+
+	resp, err := instance.NewAPI(core.ExtractClient(ctx)).CreatePlacementGroup(&instance.CreatePlacementGroupRequest{
+		Zone:         args.Zone,
+		Name:         name,
+		Organization: args.OrganizationID,
+		Project:      args.ProjectID,
+		Tags:         args.Tags,
+		PolicyMode:   instance.PlacementGroupPolicyModeOptional,
+		PolicyType:   policyType,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the placement group: %s", err)
+	}
+	return resp.PlacementGroup, nil
+}
+
+func compactServers(servers []*instance.Server) []*instance.Server {
+	compacted := make([]*instance.Server, 0, len(servers))
+	for _, server := range servers {
+		if server != nil {
+			compacted = append(compacted, server)
+		}
+	}
+	return compacted
+}
+
+// serverCreateBatchError adds the servers created to the error of a batch, as the results are not printed on error.
+func serverCreateBatchError(servers []*instance.Server, err error) error {
+	if err == nil {
+		return nil
+	}
+	if len(servers) == 0 {
+		return err
+	}
+	details, marshalErr := human.Marshal(servers, nil)
+	if marshalErr != nil {
+		return err
+	}
+	return &core.CliError{
+		Err:     err,
+		Details: "Created servers:\n" + details,
+		Hint:    "The servers created are kept, delete them with scw instance server delete or create the missing servers again",
+	}
+}
+
+// waitForServersCreated waits for all the servers created by a batch.
+func waitForServersCreated(ctx context.Context, zone scw.Zone, servers []*instance.Server) ([]*instance.Server, error) {
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	tasks := make([]*core.BatchTask, 0, len(servers))
+	for i := range servers {
+		index := i
+		tasks = append(tasks, &core.BatchTask{
+			Name: fmt.Sprintf("server %s", servers[index].Name),
+			Run: func(ctx context.Context) error {
+				server, err := api.WaitForServer(&instance.WaitForServerRequest{
+					Zone:          zone,
+					ServerID:      servers[index].ID,
+					Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+					RetryInterval: core.DefaultRetryInterval,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return err
+				}
+				servers[index] = server
+				return nil
+			},
+		})
+	}
+
+	return servers, core.RunBatch(ctx, tasks)
+}
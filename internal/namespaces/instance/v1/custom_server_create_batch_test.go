@@ -0,0 +1,52 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serverCreateName(t *testing.T) {
+	assert.Equal(t, "web-3", serverCreateName("web-{index}", 3, 5))
+	assert.Equal(t, "web-3", serverCreateName("web", 3, 5))
+	assert.Equal(t, "web", serverCreateName("web", 1, 1))
+	assert.Equal(t, "web-1", serverCreateName("web-{index}", 1, 1))
+}
+
+func Test_serverCreateRequestWithName(t *testing.T) {
+	request := &instance.CreateServerRequest{
+		Name: "web-1",
+		Volumes: map[string]*instance.VolumeServerTemplate{
+			"0": {Name: scw.StringPtr("web-1-0"), Size: scw.SizePtr(20 * scw.GB)},
+			"1": {ID: scw.StringPtr("11111111-1111-1111-1111-111111111111")},
+		},
+	}
+
+	renamed := serverCreateRequestWithName(request, "web-2")
+	assert.Equal(t, "web-2", renamed.Name)
+	assert.Equal(t, "web-2-0", *renamed.Volumes["0"].Name)
+	assert.Nil(t, renamed.Volumes["1"].Name)
+
+	// The original request is shared by all the servers and must not be modified.
+	assert.Equal(t, "web-1", request.Name)
+	assert.Equal(t, "web-1-0", *request.Volumes["0"].Name)
+}
+
+func Test_validateServerCreateBatch(t *testing.T) {
+	assert.NoError(t, validateServerCreateBatch(&instanceCreateServerRequest{
+		IP:                "new",
+		RootVolume:        "local:20GB",
+		AdditionalVolumes: []string{"block:11111111-1111-1111-1111-111111111111"},
+	}))
+	assert.Error(t, validateServerCreateBatch(&instanceCreateServerRequest{
+		IP: "11111111-1111-1111-1111-111111111111",
+	}))
+	assert.Error(t, validateServerCreateBatch(&instanceCreateServerRequest{
+		IP: "51.15.1.1",
+	}))
+	assert.Error(t, validateServerCreateBatch(&instanceCreateServerRequest{
+		AdditionalVolumes: []string{"11111111-1111-1111-1111-111111111111"},
+	}))
+}
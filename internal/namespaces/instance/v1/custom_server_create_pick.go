@@ -0,0 +1,110 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/args"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	serverCreateDefaultImage = "ubuntu_jammy"
+	serverCreateDefaultType  = "DEV1-S"
+)
+
+// serverCreatePickArgs lets the user pick the commercial type and the image of the server when they are not given.
+func serverCreatePickArgs(ctx context.Context, rawArgs args.RawArgs) (args.RawArgs, error) {
+	client := core.ExtractClient(ctx)
+	zone := scw.Zone("")
+	if value, exists := rawArgs.Get("zone"); exists {
+		zone = scw.Zone(value)
+	} else {
+		zone, _ = client.GetDefaultZone()
+	}
+
+	if !rawArgs.Has("type") {
+		resp, err := instance.NewAPI(client).ListServersTypes(&instance.ListServersTypesRequest{
+			Zone: zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		names, choices := serverTypeChoices(resp.Servers)
+		serverType, err := core.PickChoice(ctx, fmt.Sprintf("Choose the commercial type of the server in %s", zone), names, choices, serverCreateDefaultType)
+		if err != nil {
+			return nil, err
+		}
+		rawArgs = rawArgs.Add("type", serverType)
+	}
+
+	if !rawArgs.Has("image") {
+		resp, err := marketplace.NewAPI(client).ListImages(&marketplace.ListImagesRequest{}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		labels, choices := imageChoices(resp.Images)
+		image, err := core.PickChoice(ctx, "Choose the image of the server", labels, choices, serverCreateDefaultImage)
+		if err != nil {
+			return nil, err
+		}
+		rawArgs = rawArgs.Add("image", image)
+	}
+
+	return rawArgs, nil
+}
+
+// serverTypeChoices returns the names of the server types that are not deprecated, from the cheapest to the most expensive,
+// with their choices showing their vCPUs, RAM and price.
+func serverTypeChoices(serverTypes map[string]*instance.ServerType) ([]string, []string) {
+	names := make([]string, 0, len(serverTypes))
+	for name := range serverTypes {
+		if _, isDeprecated := deprecatedServerTypes[name]; !isDeprecated {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if serverTypes[names[i]].HourlyPrice != serverTypes[names[j]].HourlyPrice {
+			return serverTypes[names[i]].HourlyPrice < serverTypes[names[j]].HourlyPrice
+		}
+		return names[i] < names[j]
+	})
+
+	choices := make([]string, 0, len(names))
+	for _, name := range names {
+		serverType := serverTypes[name]
+		choices = append(choices, fmt.Sprintf("%-16s %3d vCPU  %8s RAM  %.4f €/hour",
+			name,
+			serverType.Ncpus,
+			humanize.IBytes(serverType.RAM),
+			serverType.HourlyPrice,
+		))
+	}
+	return names, choices
+}
+
+// imageChoices returns the labels of the marketplace images in alphabetical order, with their choices showing their names.
+func imageChoices(images []*marketplace.Image) ([]string, []string) {
+	sorted := make([]*marketplace.Image, 0, len(images))
+	for _, image := range images {
+		if image.Label != "" {
+			sorted = append(sorted, image)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Label < sorted[j].Label
+	})
+
+	labels := make([]string, 0, len(sorted))
+	choices := make([]string, 0, len(sorted))
+	for _, image := range sorted {
+		labels = append(labels, image.Label)
+		choices = append(choices, fmt.Sprintf("%-28s %s", image.Label, image.Name))
+	}
+	return labels, choices
+}
@@ -0,0 +1,37 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/marketplace/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_serverTypeChoices(t *testing.T) {
+	names, choices := serverTypeChoices(map[string]*instance.ServerType{
+		"GP1-XS":  {Ncpus: 4, RAM: 16 << 30, HourlyPrice: 0.091},
+		"DEV1-S":  {Ncpus: 2, RAM: 2 << 30, HourlyPrice: 0.0088},
+		"PLAY2-S": {Ncpus: 2, RAM: 4 << 30, HourlyPrice: 0.0088},
+		"VC1S":    {Ncpus: 2, RAM: 2 << 30, HourlyPrice: 0.006},
+	})
+	assert.Equal(t, []string{"DEV1-S", "PLAY2-S", "GP1-XS"}, names)
+	assert.Equal(t, []string{
+		"DEV1-S             2 vCPU   2.0 GiB RAM  0.0088 €/hour",
+		"PLAY2-S            2 vCPU   4.0 GiB RAM  0.0088 €/hour",
+		"GP1-XS             4 vCPU    16 GiB RAM  0.0910 €/hour",
+	}, choices)
+}
+
+func Test_imageChoices(t *testing.T) {
+	labels, choices := imageChoices([]*marketplace.Image{
+		{Label: "ubuntu_jammy", Name: "Ubuntu 22.04 Jammy Jellyfish"},
+		{Name: "Unlabeled"},
+		{Label: "debian_bookworm", Name: "Debian Bookworm"},
+	})
+	assert.Equal(t, []string{"debian_bookworm", "ubuntu_jammy"}, labels)
+	assert.Equal(t, []string{
+		"debian_bookworm              Debian Bookworm",
+		"ubuntu_jammy                 Ubuntu 22.04 Jammy Jellyfish",
+	}, choices)
+}
@@ -296,7 +296,7 @@ func Test_CreateServerErrors(t *testing.T) {
 		Cmd:      "scw instance server create image=macos",
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeNotFound),
 		),
 		DisableParallel: true,
 	}))
@@ -0,0 +1,91 @@
+package instance
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type serverEventsRequest struct {
+	Zone     scw.Zone
+	ServerID string
+	Follow   bool
+}
+
+func serverEventsCommand() *core.Command {
+	return &core.Command{
+		Short: `Show the recent activity of a server`,
+		Long: `Show the recent activity of a server and of its volumes: their creation, their last update and their current state.
+With follow, the state of the server is polled and new events are printed as they come until the command is interrupted, to see what the platform is doing during long operations.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "events",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverEventsRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      "ID of the server",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "follow",
+				Short: "Keep printing new events",
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*serverEventsRequest)
+			api := instance.NewAPI(core.ExtractClient(ctx))
+
+			return core.RunEvents(ctx, func(ctx context.Context) ([]*core.ResourceEvent, error) {
+				resp, err := api.GetServer(&instance.GetServerRequest{
+					Zone:     args.Zone,
+					ServerID: args.ServerID,
+				}, scw.WithContext(ctx))
+				if err != nil {
+					return nil, err
+				}
+				return serverEvents(resp.Server), nil
+			}, args.Follow)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Show the recent activity of a server",
+				Raw:   "scw instance server events 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Follow the activity of a server while it is rebooted",
+				Raw:   "scw instance server reboot 11111111-1111-1111-1111-111111111111 && scw instance server events 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Wait for a server to reach a stable state",
+				Command: "scw instance server wait",
+			},
+		},
+	}
+}
+
+// serverEvents returns the events of a server and of its volumes.
+func serverEvents(server *instance.Server) []*core.ResourceEvent {
+	events := core.NewResourceEvents("server", server.ID, server.CreationDate, server.ModificationDate, server.State.String(), server.StateDetail)
+
+	volumeKeys := make([]string, 0, len(server.Volumes))
+	for key := range server.Volumes {
+		volumeKeys = append(volumeKeys, key)
+	}
+	sort.Strings(volumeKeys)
+	for _, key := range volumeKeys {
+		volume := server.Volumes[key]
+		events = append(events, core.NewResourceEvents("volume", volume.ID, volume.CreationDate, volume.ModificationDate, volume.State.String(), "")...)
+	}
+
+	return events
+}
@@ -0,0 +1,180 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type serverFirewallTestRequest struct {
+	Zone     scw.Zone
+	ServerID string
+	SourceIP net.IP
+	Port     uint32
+	Protocol instance.SecurityGroupRuleProtocol
+}
+
+// serverFirewallTestResult is the decision of the security group of a server for an inbound packet.
+type serverFirewallTestResult struct {
+	Allowed           bool                             `json:"allowed"`
+	Action            instance.SecurityGroupRuleAction `json:"action"`
+	SecurityGroupID   string                           `json:"security_group_id"`
+	SecurityGroupName string                           `json:"security_group_name"`
+	// MatchedRule is the first rule matching the packet, nil when the default policy applies
+	MatchedRule *instance.SecurityGroupRule `json:"matched_rule"`
+	Reason      string                      `json:"reason"`
+}
+
+func serverFirewallTestCommand() *core.Command {
+	return &core.Command{
+		Short: `Test whether the security group of a server allows inbound traffic`,
+		Long: `Evaluate locally the rules of the security group attached to a server for a packet sent from an IP to a port of the server.
+The inbound rules are evaluated in the order of their position and the first rule matching the protocol, the source IP and the port decides whether the packet is accepted or dropped.
+When no rule matches, the inbound default policy of the security group applies.
+No traffic is sent to the server.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "firewall-test",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverFirewallTestRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      "ID of the server receiving the traffic",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "source-ip",
+				Short:    "IP sending the traffic",
+				Required: true,
+			},
+			{
+				Name:  "port",
+				Short: "Destination port on the server, ignored for ICMP",
+			},
+			{
+				Name:       "protocol",
+				Short:      "Protocol of the traffic",
+				Default:    core.DefaultValueSetter(instance.SecurityGroupRuleProtocolTCP.String()),
+				EnumValues: []string{"TCP", "UDP", "ICMP"},
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: serverFirewallTestRun,
+		Examples: []*core.Example{
+			{
+				Short: "Test whether SSH connections from an IP are allowed",
+				Raw:   "scw instance server firewall-test 11111111-1111-1111-1111-111111111111 source-ip=1.2.3.4 port=22",
+			},
+			{
+				Short: "Test whether DNS queries from an IP are allowed",
+				Raw:   "scw instance server firewall-test 11111111-1111-1111-1111-111111111111 source-ip=1.2.3.4 port=53 protocol=UDP",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the rules of a security group",
+				Command: "scw instance security-group list-rules",
+			},
+		},
+	}
+}
+
+func serverFirewallTestRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverFirewallTestRequest)
+	if args.Protocol != instance.SecurityGroupRuleProtocolICMP && args.Port == 0 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("port is required for %s traffic", args.Protocol),
+			Hint: "Give the destination port with port=<port>",
+		}
+	}
+
+	api := instance.NewAPI(core.ExtractClient(ctx))
+	serverResp, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if serverResp.Server.SecurityGroup == nil {
+		return nil, fmt.Errorf("server %s has no security group", args.ServerID)
+	}
+
+	securityGroupResp, err := api.GetSecurityGroup(&instance.GetSecurityGroupRequest{
+		Zone:            args.Zone,
+		SecurityGroupID: serverResp.Server.SecurityGroup.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	rulesResp, err := api.ListSecurityGroupRules(&instance.ListSecurityGroupRulesRequest{
+		Zone:            args.Zone,
+		SecurityGroupID: serverResp.Server.SecurityGroup.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return evaluateSecurityGroupRules(securityGroupResp.SecurityGroup, rulesResp.Rules, args.SourceIP, args.Protocol, args.Port), nil
+}
+
+// evaluateSecurityGroupRules returns whether an inbound packet is accepted by a security group:
+// the first inbound rule by position matching the packet decides, the inbound default policy applies otherwise.
+func evaluateSecurityGroupRules(securityGroup *instance.SecurityGroup, rules []*instance.SecurityGroupRule, sourceIP net.IP, protocol instance.SecurityGroupRuleProtocol, port uint32) *serverFirewallTestResult {
+	result := &serverFirewallTestResult{
+		SecurityGroupID:   securityGroup.ID,
+		SecurityGroupName: securityGroup.Name,
+	}
+
+	sortedRules := make([]*instance.SecurityGroupRule, len(rules))
+	copy(sortedRules, rules)
+	sort.SliceStable(sortedRules, func(i, j int) bool {
+		return sortedRules[i].Position < sortedRules[j].Position
+	})
+
+	for _, rule := range sortedRules {
+		if !securityGroupRuleMatches(rule, sourceIP, protocol, port) {
+			continue
+		}
+		result.MatchedRule = rule
+		result.Action = rule.Action
+		result.Reason = fmt.Sprintf("rule %s at position %d matches", rule.ID, rule.Position)
+		result.Allowed = rule.Action == instance.SecurityGroupRuleActionAccept
+		return result
+	}
+
+	result.Action = instance.SecurityGroupRuleAction(securityGroup.InboundDefaultPolicy)
+	result.Reason = "no rule matches, the inbound default policy applies"
+	result.Allowed = securityGroup.InboundDefaultPolicy == instance.SecurityGroupPolicyAccept
+	return result
+}
+
+func securityGroupRuleMatches(rule *instance.SecurityGroupRule, sourceIP net.IP, protocol instance.SecurityGroupRuleProtocol, port uint32) bool {
+	if rule.Direction != instance.SecurityGroupRuleDirectionInbound {
+		return false
+	}
+	if rule.Protocol != instance.SecurityGroupRuleProtocolANY && rule.Protocol != protocol {
+		return false
+	}
+	if !rule.IPRange.Contains(sourceIP) {
+		return false
+	}
+	if protocol == instance.SecurityGroupRuleProtocolICMP || rule.DestPortFrom == nil {
+		return true
+	}
+
+	portTo := *rule.DestPortFrom
+	if rule.DestPortTo != nil {
+		portTo = *rule.DestPortTo
+	}
+	return port >= *rule.DestPortFrom && port <= portTo
+}
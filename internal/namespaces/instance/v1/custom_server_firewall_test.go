@@ -0,0 +1,52 @@
+package instance
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_evaluateSecurityGroupRules(t *testing.T) {
+	ipRange := func(cidr string) scw.IPNet {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		return scw.IPNet{IPNet: *ipNet}
+	}
+
+	securityGroup := &instance.SecurityGroup{
+		ID:                   "11111111-1111-1111-1111-111111111111",
+		Name:                 "web",
+		InboundDefaultPolicy: instance.SecurityGroupPolicyDrop,
+	}
+	rules := []*instance.SecurityGroupRule{
+		{ID: "allow-http", Position: 3, Direction: instance.SecurityGroupRuleDirectionInbound, Protocol: instance.SecurityGroupRuleProtocolTCP, Action: instance.SecurityGroupRuleActionAccept, IPRange: ipRange("0.0.0.0/0"), DestPortFrom: scw.Uint32Ptr(80), DestPortTo: scw.Uint32Ptr(443)},
+		{ID: "drop-bad", Position: 1, Direction: instance.SecurityGroupRuleDirectionInbound, Protocol: instance.SecurityGroupRuleProtocolANY, Action: instance.SecurityGroupRuleActionDrop, IPRange: ipRange("10.0.0.0/8")},
+		{ID: "allow-ssh", Position: 2, Direction: instance.SecurityGroupRuleDirectionInbound, Protocol: instance.SecurityGroupRuleProtocolTCP, Action: instance.SecurityGroupRuleActionAccept, IPRange: ipRange("1.2.3.0/24"), DestPortFrom: scw.Uint32Ptr(22)},
+		{ID: "outbound", Position: 4, Direction: instance.SecurityGroupRuleDirectionOutbound, Protocol: instance.SecurityGroupRuleProtocolANY, Action: instance.SecurityGroupRuleActionAccept, IPRange: ipRange("0.0.0.0/0")},
+	}
+
+	result := evaluateSecurityGroupRules(securityGroup, rules, net.ParseIP("1.2.3.4"), instance.SecurityGroupRuleProtocolTCP, 22)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "allow-ssh", result.MatchedRule.ID)
+
+	result = evaluateSecurityGroupRules(securityGroup, rules, net.ParseIP("10.1.2.3"), instance.SecurityGroupRuleProtocolTCP, 443)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, "drop-bad", result.MatchedRule.ID, "rules must be evaluated by position")
+
+	result = evaluateSecurityGroupRules(securityGroup, rules, net.ParseIP("5.6.7.8"), instance.SecurityGroupRuleProtocolTCP, 443)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "allow-http", result.MatchedRule.ID)
+
+	result = evaluateSecurityGroupRules(securityGroup, rules, net.ParseIP("5.6.7.8"), instance.SecurityGroupRuleProtocolTCP, 22)
+	assert.False(t, result.Allowed)
+	assert.Nil(t, result.MatchedRule)
+	assert.Equal(t, instance.SecurityGroupRuleActionDrop, result.Action)
+
+	result = evaluateSecurityGroupRules(securityGroup, rules, net.ParseIP("2001:db8::1"), instance.SecurityGroupRuleProtocolUDP, 53)
+	assert.False(t, result.Allowed)
+	assert.Nil(t, result.MatchedRule)
+}
@@ -0,0 +1,398 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type serverMoveToProjectRequest struct {
+	Zone           scw.Zone
+	ServerID       string
+	ProjectID      string
+	Name           string
+	DeleteOriginal bool
+	DryRun         bool
+	Yes            bool
+}
+
+// serverMovePlan describes how a server is re-created in another project.
+type serverMovePlan struct {
+	Steps    []string `json:"steps"`
+	Warnings []string `json:"warnings"`
+}
+
+// serverMoveTargets are the resources of the target project matched with the ones of the server.
+type serverMoveTargets struct {
+	PrivateNetworkIDs []string
+	SecurityGroupID   *string
+}
+
+type serverMoveResult struct {
+	SourceServerID string           `json:"source_server_id"`
+	Server         *instance.Server `json:"server"`
+	ImageID        string           `json:"image_id"`
+	Warnings       []string         `json:"warnings"`
+}
+
+func serverMoveToProjectCommand() *core.Command {
+	return &core.Command{
+		Short: `Move a server to another project`,
+		Long: `Move a server to another project of the same organization by re-creating it.
+
+Servers cannot change project, so this command:
+  - creates an image of the server.
+  - creates a new server from this image in the target project, with the same commercial type, tags and boot type.
+  - attaches the private networks and the security group of the target project having the same name as the original ones.
+  - starts the new server and checks that it is running.
+  - deletes the original server and its volumes if delete-original is set.
+
+Flexible IPs cannot change project either: the new server gets a new public IP.
+The plan is displayed and must be confirmed before any change is made.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "move-to-project",
+		ArgsType:  reflect.TypeOf(serverMoveToProjectRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      `ID of the server to move`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "project-id",
+				Short:    `ID of the target project`,
+				Required: true,
+			},
+			{
+				Name:  "name",
+				Short: `Name of the new server, default to the name of the original server`,
+			},
+			{
+				Name:  "delete-original",
+				Short: `Terminate the original server and its volumes once the new server is running`,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the plan`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(),
+		},
+		Run: serverMoveToProjectRun,
+		Examples: []*core.Example{
+			{
+				Short: "Display the plan to move a server",
+				Raw:   "scw instance server move-to-project 11111111-1111-1111-1111-111111111111 project-id=22222222-2222-2222-2222-222222222222 dry-run=true",
+			},
+			{
+				Short: "Move a server and delete the original one",
+				Raw:   "scw instance server move-to-project 11111111-1111-1111-1111-111111111111 project-id=22222222-2222-2222-2222-222222222222 delete-original=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Backup a server",
+				Command: "scw instance server backup",
+			},
+		},
+	}
+}
+
+func serverMoveToProjectRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverMoveToProjectRequest)
+
+	client := core.ExtractClient(ctx)
+	api := instance.NewAPI(client)
+	vpcAPI := vpc.NewAPI(client)
+
+	getServerResp, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	server := getServerResp.Server
+
+	if server.Project == args.ProjectID {
+		return nil, fmt.Errorf("server %s is already in project %s", server.ID, args.ProjectID)
+	}
+
+	privateNetworkNames := map[string]string{}
+	for _, nic := range server.PrivateNics {
+		pn, err := vpcAPI.GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+			Zone:             args.Zone,
+			PrivateNetworkID: nic.PrivateNetworkID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		privateNetworkNames[pn.ID] = pn.Name
+	}
+
+	targetPrivateNetworks, err := vpcAPI.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Zone:      args.Zone,
+		ProjectID: &args.ProjectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	targetSecurityGroups, err := api.ListSecurityGroups(&instance.ListSecurityGroupsRequest{
+		Zone:    args.Zone,
+		Project: &args.ProjectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Name == "" {
+		args.Name = server.Name
+	}
+
+	plan, targets := buildServerMovePlan(server, args, privateNetworkNames, targetPrivateNetworks.PrivateNetworks, targetSecurityGroups.SecurityGroups)
+	if args.DryRun {
+		return plan, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("moving a server must be confirmed"),
+				Hint: "Review the plan with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		_, _ = interactive.Println(formatServerMovePlan(plan))
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to proceed?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Move canceled"}, nil
+		}
+	}
+
+	core.ReportProgressStep(ctx, "backup", core.ProgressStateStarted)
+	image, err := backupServerForMove(ctx, api, server)
+	if core.ReportProgressResult(ctx, "backup", err) != nil {
+		return nil, err
+	}
+	_, _ = interactive.Printf("image %s created\n", image.ID)
+
+	createServerRequest := &instance.CreateServerRequest{
+		Zone:              args.Zone,
+		Name:              args.Name,
+		CommercialType:    server.CommercialType,
+		Image:             image.ID,
+		Project:           &args.ProjectID,
+		Tags:              server.Tags,
+		BootType:          &server.BootType,
+		EnableIPv6:        server.EnableIPv6,
+		DynamicIPRequired: scw.BoolPtr(server.PublicIP != nil || len(server.PublicIPs) > 0),
+		SecurityGroup:     targets.SecurityGroupID,
+	}
+	if server.RoutedIPEnabled {
+		createServerRequest.RoutedIPEnabled = scw.BoolPtr(true)
+	}
+	core.ReportProgressStep(ctx, "create-server", core.ProgressStateStarted)
+	createServerResp, err := api.CreateServer(createServerRequest, scw.WithContext(ctx))
+	if core.ReportProgressResult(ctx, "create-server", err) != nil {
+		return nil, fmt.Errorf("failed to create server from image %s: %w", image.ID, err)
+	}
+	newServer := createServerResp.Server
+	_, _ = interactive.Printf("server %s created in project %s\n", newServer.ID, args.ProjectID)
+
+	for _, privateNetworkID := range targets.PrivateNetworkIDs {
+		_, err := api.CreatePrivateNIC(&instance.CreatePrivateNICRequest{
+			Zone:             args.Zone,
+			ServerID:         newServer.ID,
+			PrivateNetworkID: privateNetworkID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to attach private network %s to server %s: %w", privateNetworkID, newServer.ID, err)
+		}
+	}
+
+	core.ReportProgressStep(ctx, "start-server", core.ProgressStateStarted)
+	_, err = api.ServerAction(&instance.ServerActionRequest{
+		Zone:     args.Zone,
+		ServerID: newServer.ID,
+		Action:   instance.ServerActionPoweron,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, core.ReportProgressResult(ctx, "start-server", err)
+	}
+	newServer, err = api.WaitForServer(&instance.WaitForServerRequest{
+		Zone:          args.Zone,
+		ServerID:      newServer.ID,
+		Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if core.ReportProgressResult(ctx, "start-server", err) != nil {
+		return nil, err
+	}
+	if newServer.State != instance.ServerStateRunning {
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("server %s did not boot", newServer.ID),
+			Details: fmt.Sprintf("server is %s, the original server %s was kept", newServer.State, server.ID),
+		}
+	}
+	_, _ = interactive.Printf("server %s is running\n", newServer.ID)
+
+	if args.DeleteOriginal {
+		_, err = api.ServerAction(&instance.ServerActionRequest{
+			Zone:     args.Zone,
+			ServerID: server.ID,
+			Action:   instance.ServerActionTerminate,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("server moved but the original server could not be terminated: %w", err)
+		}
+		_, _ = interactive.Printf("server %s terminated\n", server.ID)
+	}
+
+	return &serverMoveResult{
+		SourceServerID: server.ID,
+		Server:         newServer,
+		ImageID:        image.ID,
+		Warnings:       plan.Warnings,
+	}, nil
+}
+
+// backupServerForMove creates an image of all the volumes of a server and waits for it.
+func backupServerForMove(ctx context.Context, api *instance.API, server *instance.Server) (*instance.Image, error) {
+	req := &instance.ServerActionRequest{
+		Zone:     server.Zone,
+		ServerID: server.ID,
+		Action:   instance.ServerActionBackup,
+		Name:     scw.StringPtr(server.Name + "-move"),
+		Volumes:  map[string]*instance.ServerActionRequestVolumeBackupTemplate{},
+	}
+	for _, volume := range server.Volumes {
+		req.Volumes[volume.ID] = &instance.ServerActionRequestVolumeBackupTemplate{
+			VolumeType: instance.SnapshotVolumeType(volume.VolumeType),
+		}
+	}
+
+	res, err := api.ServerAction(req, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	tmp := strings.Split(res.Task.HrefResult, "/")
+	if len(tmp) != 3 {
+		return nil, fmt.Errorf("cannot extract image id from task")
+	}
+
+	return api.WaitForImage(&instance.WaitForImageRequest{
+		Zone:          server.Zone,
+		ImageID:       tmp[2],
+		Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+}
+
+// buildServerMovePlan matches the private networks and security group of a server with the ones of the
+// target project by name, and lists the steps of the move.
+func buildServerMovePlan(server *instance.Server, args *serverMoveToProjectRequest, privateNetworkNames map[string]string, targetPrivateNetworks []*vpc.PrivateNetwork, targetSecurityGroups []*instance.SecurityGroup) (*serverMovePlan, *serverMoveTargets) {
+	plan := &serverMovePlan{}
+	targets := &serverMoveTargets{}
+
+	if !serverHasAllowedAction(server, instance.ServerActionBackup) {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("server is %s and may have to be stopped to be backed up", server.State))
+	}
+
+	plan.Steps = append(plan.Steps,
+		fmt.Sprintf("create an image of server %s (%s) with its %d volume(s)", server.Name, server.ID, len(server.Volumes)),
+		fmt.Sprintf("create server %s (%s) in project %s from this image", args.Name, server.CommercialType, args.ProjectID),
+	)
+
+	if server.SecurityGroup != nil {
+		for _, securityGroup := range targetSecurityGroups {
+			if securityGroup.Name == server.SecurityGroup.Name {
+				targets.SecurityGroupID = &securityGroup.ID
+				break
+			}
+		}
+		if targets.SecurityGroupID != nil {
+			plan.Steps = append(plan.Steps, fmt.Sprintf("use security group %s (%s)", server.SecurityGroup.Name, *targets.SecurityGroupID))
+		} else {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("no security group named %s in the target project, the default security group will be used", server.SecurityGroup.Name))
+		}
+	}
+
+	for _, nic := range server.PrivateNics {
+		name := privateNetworkNames[nic.PrivateNetworkID]
+		targetID := ""
+		for _, pn := range targetPrivateNetworks {
+			if pn.Name == name {
+				targetID = pn.ID
+				break
+			}
+		}
+		if targetID == "" {
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("no private network named %s in the target project, it will not be attached", name))
+			continue
+		}
+		targets.PrivateNetworkIDs = append(targets.PrivateNetworkIDs, targetID)
+		plan.Steps = append(plan.Steps, fmt.Sprintf("attach private network %s (%s)", name, targetID))
+	}
+
+	publicIPs := server.PublicIPs
+	if len(publicIPs) == 0 && server.PublicIP != nil {
+		publicIPs = []*instance.ServerIP{server.PublicIP}
+	}
+	for _, ip := range publicIPs {
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf("public IP %s cannot be moved, the new server gets a new public IP", ip.Address))
+	}
+
+	plan.Steps = append(plan.Steps, "start the new server and check that it is running")
+	if args.DeleteOriginal {
+		plan.Steps = append(plan.Steps, fmt.Sprintf("terminate server %s and its volumes", server.ID))
+	} else {
+		plan.Steps = append(plan.Steps, fmt.Sprintf("keep server %s", server.ID))
+	}
+
+	return plan, targets
+}
+
+func serverHasAllowedAction(server *instance.Server, action instance.ServerAction) bool {
+	for _, allowedAction := range server.AllowedActions {
+		if allowedAction == action {
+			return true
+		}
+	}
+	return false
+}
+
+func formatServerMovePlan(plan *serverMovePlan) string {
+	buf := strings.Builder{}
+	buf.WriteString("Plan:\n")
+	for i, step := range plan.Steps {
+		buf.WriteString(fmt.Sprintf("  %d. %s\n", i+1, step))
+	}
+	if len(plan.Warnings) > 0 {
+		buf.WriteString("Warnings:\n")
+		for _, warning := range plan.Warnings {
+			buf.WriteString(fmt.Sprintf("  - %s\n", warning))
+		}
+	}
+	return buf.String()
+}
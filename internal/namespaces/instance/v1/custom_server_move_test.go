@@ -0,0 +1,49 @@
+package instance
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildServerMovePlan(t *testing.T) {
+	server := &instance.Server{
+		ID:             "server-id",
+		Name:           "web",
+		CommercialType: "DEV1-S",
+		State:          instance.ServerStateRunning,
+		AllowedActions: []instance.ServerAction{instance.ServerActionPoweroff, instance.ServerActionBackup},
+		Volumes:        map[string]*instance.VolumeServer{"0": {ID: "volume-id"}},
+		SecurityGroup:  &instance.SecurityGroupSummary{ID: "sg-id", Name: "web-sg"},
+		PrivateNics: []*instance.PrivateNIC{
+			{PrivateNetworkID: "pn-backend"},
+			{PrivateNetworkID: "pn-admin"},
+		},
+		PublicIP: &instance.ServerIP{Address: net.ParseIP("51.15.0.1")},
+	}
+
+	plan, targets := buildServerMovePlan(server,
+		&serverMoveToProjectRequest{ProjectID: "project-id", Name: "web"},
+		map[string]string{"pn-backend": "backend", "pn-admin": "admin"},
+		[]*vpc.PrivateNetwork{{ID: "target-pn-backend", Name: "backend"}},
+		[]*instance.SecurityGroup{{ID: "target-sg-id", Name: "web-sg"}},
+	)
+
+	assert.Equal(t, []string{"target-pn-backend"}, targets.PrivateNetworkIDs)
+	assert.Equal(t, "target-sg-id", *targets.SecurityGroupID)
+	assert.Equal(t, []string{
+		"create an image of server web (server-id) with its 1 volume(s)",
+		"create server web (DEV1-S) in project project-id from this image",
+		"use security group web-sg (target-sg-id)",
+		"attach private network backend (target-pn-backend)",
+		"start the new server and check that it is running",
+		"keep server server-id",
+	}, plan.Steps)
+	assert.Equal(t, []string{
+		"no private network named admin in the target project, it will not be attached",
+		"public IP 51.15.0.1 cannot be moved, the new server gets a new public IP",
+	}, plan.Warnings)
+}
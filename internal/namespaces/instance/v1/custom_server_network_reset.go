@@ -0,0 +1,422 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type serverNetworkResetRequest struct {
+	Zone        scw.Zone
+	ServerID    string
+	IP          bool
+	PrivateNics bool
+	DHCP        bool
+	DryRun      bool
+	Yes         bool
+}
+
+// serverNetworkResetPlan describes the steps rebuilding the network configuration of a server.
+type serverNetworkResetPlan struct {
+	Steps    []string `json:"steps"`
+	Warnings []string `json:"warnings"`
+}
+
+type serverNetworkResetResult struct {
+	Server *instance.Server `json:"server"`
+	Steps  []string         `json:"steps"`
+}
+
+// networkResetStep is a step of a network reset. Rollback restores what the step removed and returns false
+// when a later step already restored it, it is nil when the step removes nothing.
+type networkResetStep struct {
+	Name        string
+	Description string
+	Run         func(ctx context.Context) error
+	Rollback    func(ctx context.Context) (bool, error)
+}
+
+// networkResetState tracks what was attached again, so that a rollback only restores what is still missing.
+type networkResetState struct {
+	attachedIPs map[string]bool
+	newNICs     map[string]*instance.PrivateNIC
+}
+
+func serverNetworkResetCommand() *core.Command {
+	return &core.Command{
+		Short: `Rebuild the network configuration of a server`,
+		Long: `Rebuild the network configuration of a server which lost its connectivity, after a migration for instance:
+  - detaches and attaches again its flexible IPs.
+  - deletes and re-creates its private NICs, in the same private networks and with the same tags.
+  - refreshes the DHCP entries of the Public Gateways of these private networks: the leases of the old NICs are deleted and their reservations are moved to the new NICs.
+
+Re-created private NICs have new MAC addresses. If a step fails, the flexible IPs and private networks detached by the previous steps are attached again.
+The plan is displayed and must be confirmed before any change is made.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "network-reset",
+		ArgsType:  reflect.TypeOf(serverNetworkResetRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      `ID of the server`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "ip",
+				Short:   `Detach and attach again the flexible IPs`,
+				Default: core.DefaultValueSetter("true"),
+			},
+			{
+				Name:    "private-nics",
+				Short:   `Re-create the private NICs`,
+				Default: core.DefaultValueSetter("true"),
+			},
+			{
+				Name:    "dhcp",
+				Short:   `Refresh the DHCP entries of the Public Gateways for the re-created private NICs`,
+				Default: core.DefaultValueSetter("true"),
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the plan`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(),
+		},
+		Run: serverNetworkResetRun,
+		Examples: []*core.Example{
+			{
+				Short: "Display the plan to reset the network of a server",
+				Raw:   "scw instance server network-reset 11111111-1111-1111-1111-111111111111 dry-run=true",
+			},
+			{
+				Short: "Re-create the private NICs of a server only",
+				Raw:   "scw instance server network-reset 11111111-1111-1111-1111-111111111111 ip=false",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List the private NICs of a server",
+				Command: "scw instance private-nic list",
+			},
+			{
+				Short:   "List the DHCP entries of a Public Gateway",
+				Command: "scw vpc-gw dhcp-entry list",
+			},
+		},
+	}
+}
+
+func serverNetworkResetRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverNetworkResetRequest)
+
+	client := core.ExtractClient(ctx)
+	api := instance.NewAPI(client)
+	gatewayAPI := vpcgw.NewAPI(client)
+
+	getServerResp, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	server := getServerResp.Server
+
+	gatewayNetworks := map[string][]*vpcgw.GatewayNetwork{}
+	if args.PrivateNics && args.DHCP {
+		for _, nic := range server.PrivateNics {
+			resp, err := gatewayAPI.ListGatewayNetworks(&vpcgw.ListGatewayNetworksRequest{
+				Zone:             args.Zone,
+				PrivateNetworkID: &nic.PrivateNetworkID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			gatewayNetworks[nic.PrivateNetworkID] = resp.GatewayNetworks
+		}
+	}
+
+	plan, steps := buildServerNetworkResetPlan(api, gatewayAPI, server, args, gatewayNetworks)
+	if len(steps) == 0 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("nothing to reset on server %s", server.ID),
+			Hint: "The server has no flexible IP nor private NIC to reset",
+		}
+	}
+	if args.DryRun {
+		return plan, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("resetting the network of a server must be confirmed"),
+				Hint: "Review the plan with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		_, _ = interactive.Println(formatServerMovePlan((*serverMovePlan)(plan)))
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to proceed?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Network reset canceled"}, nil
+		}
+	}
+
+	done, err := runNetworkResetSteps(ctx, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	getServerResp, err = api.GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverNetworkResetResult{
+		Server: getServerResp.Server,
+		Steps:  done,
+	}, nil
+}
+
+// buildServerNetworkResetPlan lists the steps resetting the network of a server, in the order they must run.
+func buildServerNetworkResetPlan(api *instance.API, gatewayAPI *vpcgw.API, server *instance.Server, args *serverNetworkResetRequest, gatewayNetworks map[string][]*vpcgw.GatewayNetwork) (*serverNetworkResetPlan, []*networkResetStep) {
+	plan := &serverNetworkResetPlan{}
+	steps := []*networkResetStep(nil)
+	state := &networkResetState{
+		attachedIPs: map[string]bool{},
+		newNICs:     map[string]*instance.PrivateNIC{},
+	}
+
+	attachIP := func(ctx context.Context, ipID string) error {
+		_, err := api.UpdateIP(&instance.UpdateIPRequest{
+			Zone:   server.Zone,
+			IP:     ipID,
+			Server: &instance.NullableStringValue{Value: server.ID},
+		}, scw.WithContext(ctx))
+		return err
+	}
+	attachPrivateNetwork := func(ctx context.Context, nic *instance.PrivateNIC) (*instance.PrivateNIC, error) {
+		resp, err := api.CreatePrivateNIC(&instance.CreatePrivateNICRequest{
+			Zone:             server.Zone,
+			ServerID:         server.ID,
+			PrivateNetworkID: nic.PrivateNetworkID,
+			Tags:             nic.Tags,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return api.WaitForPrivateNIC(&instance.WaitForPrivateNICRequest{
+			Zone:          server.Zone,
+			ServerID:      server.ID,
+			PrivateNicID:  resp.PrivateNic.ID,
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+	}
+
+	if args.IP {
+		publicIPs := server.PublicIPs
+		if len(publicIPs) == 0 && server.PublicIP != nil {
+			publicIPs = []*instance.ServerIP{server.PublicIP}
+		}
+		for _, ip := range publicIPs {
+			ip := ip
+			if ip.Dynamic {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("dynamic IP %s is kept, it would be released if it was detached", ip.Address))
+				continue
+			}
+			steps = append(steps,
+				&networkResetStep{
+					Name:        "detach-ip",
+					Description: fmt.Sprintf("detach flexible IP %s", ip.Address),
+					Run: func(ctx context.Context) error {
+						_, err := api.UpdateIP(&instance.UpdateIPRequest{
+							Zone:   server.Zone,
+							IP:     ip.ID,
+							Server: &instance.NullableStringValue{Null: true},
+						}, scw.WithContext(ctx))
+						return err
+					},
+					Rollback: func(ctx context.Context) (bool, error) {
+						if state.attachedIPs[ip.ID] {
+							return false, nil
+						}
+						return true, attachIP(ctx, ip.ID)
+					},
+				},
+				&networkResetStep{
+					Name:        "attach-ip",
+					Description: fmt.Sprintf("attach flexible IP %s", ip.Address),
+					Run: func(ctx context.Context) error {
+						if err := attachIP(ctx, ip.ID); err != nil {
+							return err
+						}
+						state.attachedIPs[ip.ID] = true
+						return nil
+					},
+				},
+			)
+		}
+	}
+
+	if args.PrivateNics {
+		for _, nic := range server.PrivateNics {
+			nic := nic
+			steps = append(steps,
+				&networkResetStep{
+					Name:        "detach-private-network",
+					Description: fmt.Sprintf("detach private network %s (private NIC %s)", nic.PrivateNetworkID, nic.ID),
+					Run: func(ctx context.Context) error {
+						return api.DeletePrivateNIC(&instance.DeletePrivateNICRequest{
+							Zone:         server.Zone,
+							ServerID:     server.ID,
+							PrivateNicID: nic.ID,
+						}, scw.WithContext(ctx))
+					},
+					Rollback: func(ctx context.Context) (bool, error) {
+						if state.newNICs[nic.PrivateNetworkID] != nil {
+							return false, nil
+						}
+						_, err := attachPrivateNetwork(ctx, nic)
+						return true, err
+					},
+				},
+				&networkResetStep{
+					Name:        "attach-private-network",
+					Description: fmt.Sprintf("attach private network %s with a new private NIC", nic.PrivateNetworkID),
+					Run: func(ctx context.Context) error {
+						newNIC, err := attachPrivateNetwork(ctx, nic)
+						if err != nil {
+							return err
+						}
+						state.newNICs[nic.PrivateNetworkID] = newNIC
+						return nil
+					},
+				},
+			)
+
+			if !args.DHCP {
+				continue
+			}
+			for _, gatewayNetwork := range gatewayNetworks[nic.PrivateNetworkID] {
+				gatewayNetwork := gatewayNetwork
+				if !gatewayNetwork.EnableDHCP {
+					continue
+				}
+				steps = append(steps, &networkResetStep{
+					Name:        "refresh-dhcp",
+					Description: fmt.Sprintf("refresh the DHCP entries of MAC address %s on Public Gateway %s", nic.MacAddress, gatewayNetwork.GatewayID),
+					Run: func(ctx context.Context) error {
+						return refreshDHCPEntries(ctx, gatewayAPI, gatewayNetwork, nic.MacAddress, state.newNICs[nic.PrivateNetworkID].MacAddress)
+					},
+				})
+			}
+		}
+	}
+
+	for _, step := range steps {
+		plan.Steps = append(plan.Steps, step.Description)
+	}
+
+	return plan, steps
+}
+
+// refreshDHCPEntries deletes the leases of an old MAC address on a gateway network and moves its reservations to a new MAC address.
+func refreshDHCPEntries(ctx context.Context, gatewayAPI *vpcgw.API, gatewayNetwork *vpcgw.GatewayNetwork, oldMacAddress string, newMacAddress string) error {
+	resp, err := gatewayAPI.ListDHCPEntries(&vpcgw.ListDHCPEntriesRequest{
+		Zone:             gatewayNetwork.Zone,
+		GatewayNetworkID: &gatewayNetwork.ID,
+		MacAddress:       &oldMacAddress,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range resp.DHCPEntries {
+		err := gatewayAPI.DeleteDHCPEntry(&vpcgw.DeleteDHCPEntryRequest{
+			Zone:        entry.Zone,
+			DHCPEntryID: entry.ID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		if entry.Type != vpcgw.DHCPEntryTypeReservation {
+			continue
+		}
+		_, err = gatewayAPI.CreateDHCPEntry(&vpcgw.CreateDHCPEntryRequest{
+			Zone:             entry.Zone,
+			GatewayNetworkID: entry.GatewayNetworkID,
+			MacAddress:       newMacAddress,
+			IPAddress:        entry.IPAddress,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return fmt.Errorf("failed to reserve %s for MAC address %s: %w", entry.IPAddress, newMacAddress, err)
+		}
+	}
+
+	return nil
+}
+
+// runNetworkResetSteps runs steps one after the other and returns the description of the steps done.
+// When a step fails, the steps already done are rolled back in reverse order.
+func runNetworkResetSteps(ctx context.Context, steps []*networkResetStep) ([]string, error) {
+	done := []string(nil)
+	for i, step := range steps {
+		core.ReportProgressStep(ctx, step.Name, core.ProgressStateStarted)
+		err := core.ReportProgressResult(ctx, step.Name, step.Run(ctx))
+		if err == nil {
+			done = append(done, step.Description)
+			_, _ = interactive.Printf("%s: done\n", step.Description)
+			continue
+		}
+
+		rollbackReport := []string(nil)
+		for j := i - 1; j >= 0; j-- {
+			if steps[j].Rollback == nil {
+				continue
+			}
+			rollbackStep := "rollback-" + steps[j].Name
+			core.ReportProgressStep(ctx, rollbackStep, core.ProgressStateStarted)
+			restored, rollbackErr := steps[j].Rollback(ctx)
+			switch {
+			case core.ReportProgressResult(ctx, rollbackStep, rollbackErr) != nil:
+				rollbackReport = append(rollbackReport, fmt.Sprintf("failed to undo %s: %s", steps[j].Description, rollbackErr))
+			case restored:
+				rollbackReport = append(rollbackReport, fmt.Sprintf("undid %s", steps[j].Description))
+			}
+		}
+
+		cliErr := &core.CliError{
+			Err: fmt.Errorf("failed to %s: %w", step.Description, err),
+		}
+		if len(rollbackReport) > 0 {
+			cliErr.Details = "Rollback:\n  " + strings.Join(rollbackReport, "\n  ")
+		}
+		return done, cliErr
+	}
+
+	return done, nil
+}
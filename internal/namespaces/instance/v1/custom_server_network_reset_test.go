@@ -0,0 +1,95 @@
+package instance
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildServerNetworkResetPlan(t *testing.T) {
+	server := &instance.Server{
+		ID: "server-id",
+		PublicIPs: []*instance.ServerIP{
+			{ID: "flexible-ip-id", Address: net.ParseIP("51.15.0.1")},
+			{ID: "dynamic-ip-id", Address: net.ParseIP("51.15.0.2"), Dynamic: true},
+		},
+		PrivateNics: []*instance.PrivateNIC{
+			{ID: "nic-id", PrivateNetworkID: "pn-id", MacAddress: "02:00:00:00:00:01"},
+		},
+	}
+	gatewayNetworks := map[string][]*vpcgw.GatewayNetwork{
+		"pn-id": {
+			{ID: "gwn-id", GatewayID: "gw-id", EnableDHCP: true},
+			{ID: "gwn-static-id", GatewayID: "gw-static-id"},
+		},
+	}
+
+	plan, steps := buildServerNetworkResetPlan(nil, nil, server, &serverNetworkResetRequest{IP: true, PrivateNics: true, DHCP: true}, gatewayNetworks)
+	assert.Len(t, steps, 5)
+	assert.Equal(t, []string{
+		"detach flexible IP 51.15.0.1",
+		"attach flexible IP 51.15.0.1",
+		"detach private network pn-id (private NIC nic-id)",
+		"attach private network pn-id with a new private NIC",
+		"refresh the DHCP entries of MAC address 02:00:00:00:00:01 on Public Gateway gw-id",
+	}, plan.Steps)
+	assert.Equal(t, []string{
+		"dynamic IP 51.15.0.2 is kept, it would be released if it was detached",
+	}, plan.Warnings)
+
+	plan, _ = buildServerNetworkResetPlan(nil, nil, server, &serverNetworkResetRequest{PrivateNics: true}, gatewayNetworks)
+	assert.Equal(t, []string{
+		"detach private network pn-id (private NIC nic-id)",
+		"attach private network pn-id with a new private NIC",
+	}, plan.Steps)
+}
+
+func Test_runNetworkResetSteps(t *testing.T) {
+	ctx := context.Background()
+	calls := []string(nil)
+	step := func(name string, runErr error, restored bool) *networkResetStep {
+		return &networkResetStep{
+			Name:        name,
+			Description: name,
+			Run: func(_ context.Context) error {
+				calls = append(calls, "run "+name)
+				return runErr
+			},
+			Rollback: func(_ context.Context) (bool, error) {
+				calls = append(calls, "rollback "+name)
+				return restored, nil
+			},
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		calls = nil
+		done, err := runNetworkResetSteps(ctx, []*networkResetStep{step("detach", nil, true), step("attach", nil, false)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"detach", "attach"}, done)
+		assert.Equal(t, []string{"run detach", "run attach"}, calls)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		calls = nil
+		done, err := runNetworkResetSteps(ctx, []*networkResetStep{
+			step("detach-ip", nil, false),
+			step("detach-nic", nil, true),
+			step("attach-nic", errors.New("quota exceeded"), false),
+		})
+		assert.Equal(t, []string{"detach-ip", "detach-nic"}, done)
+		assert.Equal(t, []string{"run detach-ip", "run detach-nic", "run attach-nic", "rollback detach-nic", "rollback detach-ip"}, calls)
+
+		cliErr := &core.CliError{}
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, "failed to attach-nic: quota exceeded", cliErr.Err.Error())
+		assert.Equal(t, "Rollback:\n  undid detach-nic", cliErr.Details)
+	})
+}
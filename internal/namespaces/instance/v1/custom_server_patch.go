@@ -0,0 +1,422 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const serverPatchHealthCheckInterval = 5 * time.Second
+
+type serverSnapshotAndPatchRequest struct {
+	Zone               scw.Zone
+	ServerID           string
+	Script             string
+	HealthCheckURL     string
+	HealthCheckCommand string
+	HealthCheckTimeout time.Duration
+	Username           string
+	Port               uint
+	DeleteSnapshots    bool
+}
+
+// serverPatchSnapshot is the snapshot of a volume taken before patching, indexed like the volumes of the server.
+type serverPatchSnapshot struct {
+	Index      string                          `json:"index"`
+	VolumeID   string                          `json:"volume_id"`
+	VolumeName string                          `json:"volume_name"`
+	VolumeType instance.VolumeServerVolumeType `json:"volume_type"`
+	SnapshotID string                          `json:"snapshot_id"`
+}
+
+type serverSnapshotAndPatchResult struct {
+	ServerID         string                 `json:"server_id"`
+	Snapshots        []*serverPatchSnapshot `json:"snapshots"`
+	SnapshotsDeleted bool                   `json:"snapshots_deleted"`
+}
+
+func serverSnapshotAndPatchCommand() *core.Command {
+	return &core.Command{
+		Short: `Patch a server and roll it back on failure`,
+		Long: `Patch a server with a script run over SSH and roll it back from snapshots when the patch or the health check fails.
+
+This command:
+  - snapshots every volume of the server.
+  - runs the script on the server over SSH.
+  - waits for the health check URL to answer with a 2xx status and for the health check command to succeed over SSH.
+  - on failure, stops the server, replaces its volumes with new volumes created from the snapshots and starts it again.
+
+The volumes replaced during a rollback are detached and kept for investigation. The snapshots are kept unless delete-snapshots is set.
+Only local and block SSD volumes can be snapshotted with this command.`,
+		Namespace: "instance",
+		Resource:  "server",
+		Verb:      "snapshot-and-patch",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverSnapshotAndPatchRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "server-id",
+				Short:      `ID of the server to patch`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "script",
+				Short:       `Shell script to run on the server`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "health-check-url",
+				Short: `URL that must answer with a 2xx status once the server is patched`,
+			},
+			{
+				Name:  "health-check-command",
+				Short: `Command that must succeed on the server once it is patched`,
+			},
+			{
+				Name:    "health-check-timeout",
+				Short:   `Time to wait for the health checks to succeed`,
+				Default: core.DefaultValueSetter("5m"),
+			},
+			{
+				Name:    "username",
+				Short:   "Username used for the SSH connection",
+				Default: core.DefaultValueSetter("root"),
+			},
+			{
+				Name:    "port",
+				Short:   "Port used for the SSH connection",
+				Default: core.DefaultValueSetter("22"),
+			},
+			{
+				Name:  "delete-snapshots",
+				Short: `Delete the snapshots once the server is patched`,
+			},
+			core.ZoneArgSpec(),
+		},
+		Run: serverSnapshotAndPatchRun,
+		Examples: []*core.Example{
+			{
+				Short: "Upgrade the packages of a server and check that its website is up",
+				Raw:   "scw instance server snapshot-and-patch 11111111-1111-1111-1111-111111111111 script=@upgrade.sh health-check-url=https://example.com/health",
+			},
+			{
+				Short: "Patch a server and check that a service is active",
+				Raw:   `scw instance server snapshot-and-patch 11111111-1111-1111-1111-111111111111 script="apt-get update && apt-get -y upgrade" health-check-command="systemctl is-active nginx"`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "SSH into a server",
+				Command: "scw instance server ssh",
+			},
+			{
+				Short:   "List snapshots",
+				Command: "scw instance snapshot list",
+			},
+		},
+	}
+}
+
+func serverSnapshotAndPatchRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverSnapshotAndPatchRequest)
+
+	api := instance.NewAPI(core.ExtractClient(ctx))
+	getServerResp, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     args.Zone,
+		ServerID: args.ServerID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	server := getServerResp.Server
+
+	if server.State != instance.ServerStateRunning {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("server is not running"),
+			Hint: fmt.Sprintf("Start the instance with: %s instance server start %s --wait", core.ExtractBinaryName(ctx), server.ID),
+		}
+	}
+	if server.PublicIP == nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("server does not have a public IP to connect to"),
+			Hint: fmt.Sprintf("Add a public IP to the instance with: %s instance server update %s ip=<ip_id>", core.ExtractBinaryName(ctx), server.ID),
+		}
+	}
+	for _, volume := range server.Volumes {
+		if volume.VolumeType != instance.VolumeServerVolumeTypeLSSD && volume.VolumeType != instance.VolumeServerVolumeTypeBSSD {
+			return nil, fmt.Errorf("volume %s is a %s volume which cannot be snapshotted with this command", volume.ID, volume.VolumeType)
+		}
+	}
+
+	core.ReportProgressStep(ctx, "snapshot", core.ProgressStateStarted)
+	snapshots, err := snapshotServerVolumes(ctx, api, server)
+	if core.ReportProgressResult(ctx, "snapshot", err) != nil {
+		return nil, err
+	}
+	_, _ = interactive.Printf("%d volume(s) of server %s snapshotted\n", len(snapshots), server.ID)
+
+	core.ReportProgressStep(ctx, "patch", core.ProgressStateStarted)
+	patchErr := core.ReportProgressResult(ctx, "patch", patchServer(ctx, args, server.PublicIP.Address.String()))
+	if patchErr == nil {
+		core.ReportProgressStep(ctx, "health-check", core.ProgressStateStarted)
+		patchErr = core.ReportProgressResult(ctx, "health-check", waitForServerHealth(ctx, args, server.PublicIP.Address.String()))
+	}
+	if patchErr != nil {
+		_, _ = interactive.Printf("patch failed, rolling back server %s\n", server.ID)
+		core.ReportProgressStep(ctx, "rollback", core.ProgressStateStarted)
+		replacedVolumeIDs, err := rollbackServerVolumes(ctx, api, server, snapshots)
+		if core.ReportProgressResult(ctx, "rollback", err) != nil {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("patch failed and the rollback failed: %w", err),
+				Details: fmt.Sprintf("patch error: %s\nsnapshots: %s", patchErr, formatServerPatchSnapshots(snapshots)),
+				Hint:    "Restore the server volumes manually from the snapshots",
+			}
+		}
+
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("patch failed, server %s was rolled back", server.ID),
+			Details: fmt.Sprintf("%s\nthe replaced volumes were kept: %s", patchErr, strings.Join(replacedVolumeIDs, ", ")),
+		}
+	}
+
+	result := &serverSnapshotAndPatchResult{
+		ServerID:  server.ID,
+		Snapshots: snapshots,
+	}
+	if args.DeleteSnapshots {
+		for _, snapshot := range snapshots {
+			err := api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+				Zone:       args.Zone,
+				SnapshotID: snapshot.SnapshotID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, fmt.Errorf("server patched but snapshot %s could not be deleted: %w", snapshot.SnapshotID, err)
+			}
+		}
+		result.SnapshotsDeleted = true
+	}
+
+	return result, nil
+}
+
+// snapshotServerVolumes creates a snapshot of every volume of a server and waits for them.
+func snapshotServerVolumes(ctx context.Context, api *instance.API, server *instance.Server) ([]*serverPatchSnapshot, error) {
+	snapshots := []*serverPatchSnapshot(nil)
+	for index, volume := range server.Volumes {
+		resp, err := api.CreateSnapshot(&instance.CreateSnapshotRequest{
+			Zone:       server.Zone,
+			Name:       fmt.Sprintf("%s-%s-patch", server.Name, index),
+			VolumeID:   &volume.ID,
+			VolumeType: instance.SnapshotVolumeType(volume.VolumeType),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot volume %s: %w", volume.ID, err)
+		}
+		snapshots = append(snapshots, &serverPatchSnapshot{
+			Index:      index,
+			VolumeID:   volume.ID,
+			VolumeName: volume.Name,
+			VolumeType: volume.VolumeType,
+			SnapshotID: resp.Snapshot.ID,
+		})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Index < snapshots[j].Index
+	})
+
+	for _, snapshot := range snapshots {
+		s, err := api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
+			Zone:          server.Zone,
+			SnapshotID:    snapshot.SnapshotID,
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if s.State != instance.SnapshotStateAvailable {
+			return nil, fmt.Errorf("snapshot %s of volume %s is %s", s.ID, snapshot.VolumeID, s.State)
+		}
+	}
+
+	return snapshots, nil
+}
+
+// patchServer runs the script on the server, it is sent on the standard input of a remote shell.
+func patchServer(ctx context.Context, args *serverSnapshotAndPatchRequest, address string) error {
+	sshCmd := exec.Command("ssh", serverPatchSSHArgs(args, address, "sh -s")...)
+	sshCmd.Stdin = strings.NewReader(args.Script)
+
+	exitCode, err := core.ExecCmd(ctx, sshCmd)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("script exited with code %d", exitCode)
+	}
+
+	return nil
+}
+
+// waitForServerHealth waits for the health check URL and command to succeed until the health check timeout.
+func waitForServerHealth(ctx context.Context, args *serverSnapshotAndPatchRequest, address string) error {
+	if args.HealthCheckURL == "" && args.HealthCheckCommand == "" {
+		return nil
+	}
+
+	deadline := time.Now().Add(args.HealthCheckTimeout)
+	for {
+		err := checkServerHealth(ctx, args, address)
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("health check failed after %s: %w", args.HealthCheckTimeout, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(serverPatchHealthCheckInterval):
+		}
+	}
+}
+
+func checkServerHealth(ctx context.Context, args *serverSnapshotAndPatchRequest, address string) error {
+	if args.HealthCheckURL != "" {
+		err := checkHealthURL(ctx, core.ExtractHTTPClient(ctx), args.HealthCheckURL)
+		if err != nil {
+			return err
+		}
+	}
+
+	if args.HealthCheckCommand != "" {
+		sshCmd := exec.Command("ssh", serverPatchSSHArgs(args, address, args.HealthCheckCommand)...)
+		exitCode, err := core.ExecCmd(ctx, sshCmd)
+		if err != nil {
+			return err
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("health check command exited with code %d", exitCode)
+		}
+	}
+
+	return nil
+}
+
+func checkHealthURL(ctx context.Context, httpClient *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("health check URL answered %s", resp.Status)
+	}
+
+	return nil
+}
+
+func serverPatchSSHArgs(args *serverSnapshotAndPatchRequest, address string, command string) []string {
+	return []string{
+		address,
+		"-p", strconv.FormatUint(uint64(args.Port), 10),
+		"-l", args.Username,
+		command,
+	}
+}
+
+// rollbackServerVolumes stops the server, replaces its volumes with volumes created from the snapshots and starts it.
+// It returns the IDs of the replaced volumes, which are detached but not deleted.
+func rollbackServerVolumes(ctx context.Context, api *instance.API, server *instance.Server, snapshots []*serverPatchSnapshot) ([]string, error) {
+	err := api.ServerActionAndWait(&instance.ServerActionAndWaitRequest{
+		Zone:          server.Zone,
+		ServerID:      server.ID,
+		Action:        instance.ServerActionPoweroff,
+		Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := make(map[string]*instance.VolumeServerTemplate, len(snapshots))
+	replacedVolumeIDs := []string(nil)
+	for _, snapshot := range snapshots {
+		resp, err := api.CreateVolume(&instance.CreateVolumeRequest{
+			Zone:         server.Zone,
+			Name:         snapshot.VolumeName,
+			Project:      &server.Project,
+			VolumeType:   instance.VolumeVolumeType(snapshot.VolumeType),
+			BaseSnapshot: &snapshot.SnapshotID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create a volume from snapshot %s: %w", snapshot.SnapshotID, err)
+		}
+		_, err = api.WaitForVolume(&instance.WaitForVolumeRequest{
+			Zone:          server.Zone,
+			VolumeID:      resp.Volume.ID,
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		volumes[snapshot.Index] = &instance.VolumeServerTemplate{
+			ID:   &resp.Volume.ID,
+			Name: scw.StringPtr(snapshot.VolumeName),
+		}
+		replacedVolumeIDs = append(replacedVolumeIDs, snapshot.VolumeID)
+	}
+
+	_, err = api.UpdateServer(&instance.UpdateServerRequest{
+		Zone:     server.Zone,
+		ServerID: server.ID,
+		Volumes:  &volumes,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	err = api.ServerActionAndWait(&instance.ServerActionAndWaitRequest{
+		Zone:          server.Zone,
+		ServerID:      server.ID,
+		Action:        instance.ServerActionPoweron,
+		Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return replacedVolumeIDs, nil
+}
+
+func formatServerPatchSnapshots(snapshots []*serverPatchSnapshot) string {
+	parts := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		parts = append(parts, fmt.Sprintf("volume %s (%s) -> snapshot %s", snapshot.Index, snapshot.VolumeID, snapshot.SnapshotID))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,29 @@
+package instance
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkHealthURL(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	assert.NoError(t, checkHealthURL(context.Background(), server.Client(), server.URL))
+
+	status = http.StatusBadGateway
+	assert.EqualError(t, checkHealthURL(context.Background(), server.Client(), server.URL), "health check URL answered 502 Bad Gateway")
+}
+
+func Test_serverPatchSSHArgs(t *testing.T) {
+	args := &serverSnapshotAndPatchRequest{Username: "admin", Port: 2222}
+
+	assert.Equal(t, []string{"51.15.0.1", "-p", "2222", "-l", "admin", "sh -s"}, serverPatchSSHArgs(args, "51.15.0.1", "sh -s"))
+}
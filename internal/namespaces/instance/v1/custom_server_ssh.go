@@ -12,11 +12,12 @@ import (
 )
 
 type instanceSSHServerRequest struct {
-	Zone     scw.Zone
-	ServerID string
-	Username string
-	Port     uint
-	Command  string
+	Zone         scw.Zone
+	ServerID     string
+	Username     string
+	Port         uint
+	Command      string
+	PrintCommand bool
 }
 
 func serverSSHCommand() *core.Command {
@@ -48,6 +49,7 @@ func serverSSHCommand() *core.Command {
 				Name:  "command",
 				Short: "Command to execute on the remote server",
 			},
+			core.PrintCommandArgSpec(),
 			core.ZoneArgSpec(),
 		},
 		Run: instanceServerSSHRun,
@@ -92,6 +94,9 @@ func instanceServerSSHRun(ctx context.Context, argsI interface{}) (i interface{}
 	}
 
 	sshCmd := exec.Command("ssh", sshArgs...)
+	if args.PrintCommand {
+		return core.RawResult(core.FormatCommandLine(ctx, sshCmd) + "\n"), nil
+	}
 
 	exitCode, err := core.ExecCmd(ctx, sshCmd)
 	if err != nil {
@@ -55,7 +55,7 @@ func Test_ServerVolumeUpdate(t *testing.T) {
 			Cmd:        "scw instance server attach-volume server-id={{ .Server.ID }} volume-id=11111111-1111-1111-1111-111111111111",
 			Check: core.TestCheckCombine(
 				core.TestCheckGolden(),
-				core.TestCheckExitCode(1),
+				core.TestCheckExitCode(core.ExitCodeNotFound),
 			),
 			AfterFunc:       deleteServer("Server"),
 			DisableParallel: true,
@@ -85,7 +85,7 @@ func Test_ServerVolumeUpdate(t *testing.T) {
 			Cmd:        "scw instance server detach-volume volume-id=11111111-1111-1111-1111-111111111111",
 			Check: core.TestCheckCombine(
 				core.TestCheckGolden(),
-				core.TestCheckExitCode(1),
+				core.TestCheckExitCode(core.ExitCodeNotFound),
 			),
 			AfterFunc:       deleteServer("Server"),
 			DisableParallel: true,
@@ -243,7 +243,7 @@ func Test_ServerDelete(t *testing.T) {
 
 	t.Run("with all volumes", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
 		Cmd:        `scw instance server delete {{ .Server.ID }} with-ip=true with-volumes=all`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -254,7 +254,7 @@ func Test_ServerDelete(t *testing.T) {
 
 	t.Run("only block volumes", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
 		Cmd:        `scw instance server delete {{ .Server.ID }} with-ip=true with-volumes=block`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -266,7 +266,7 @@ func Test_ServerDelete(t *testing.T) {
 
 	t.Run("only local volumes", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
 		Cmd:        `scw instance server delete {{ .Server.ID }} with-ip=true with-volumes=local`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -278,7 +278,7 @@ func Test_ServerDelete(t *testing.T) {
 
 	t.Run("with none volumes", core.Test(&core.TestConfig{
 		Commands:   GetCommands(),
-		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
+		BeforeFunc: core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive stopped=true image=ubuntu-bionic additional-volumes.0=block:10G"),
 		Cmd:        `scw instance server delete {{ .Server.ID }} with-ip=true with-volumes=none`,
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
@@ -303,7 +303,7 @@ func Test_ServerDelete(t *testing.T) {
 		),
 		BeforeFunc: core.BeforeFuncCombine(
 			core.ExecStoreBeforeCmd("BlockVolume", "scw block volume create perf-iops=5000 from-empty.size=10G name=cli-test-server-delete-with-sbs-volumes"),
-			core.ExecStoreBeforeCmd("Server", "scw instance server create stopped=true image=ubuntu-jammy"),
+			core.ExecStoreBeforeCmd("Server", "scw instance server create --no-interactive stopped=true image=ubuntu-jammy"),
 			core.ExecBeforeCmd("scw instance server attach-volume server-id={{ .Server.ID }} volume-id={{ .BlockVolume.ID }}"),
 		),
 		Cmd: `scw instance server delete {{ .Server.ID }} with-ip=true with-volumes=all`,
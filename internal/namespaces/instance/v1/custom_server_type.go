@@ -21,6 +21,7 @@ var (
 		instance.ServerTypesAvailabilityAvailable: &human.EnumMarshalSpec{Attribute: color.FgGreen},
 		instance.ServerTypesAvailabilityScarce:    &human.EnumMarshalSpec{Attribute: color.FgYellow, Value: "low stock"},
 		instance.ServerTypesAvailabilityShortage:  &human.EnumMarshalSpec{Attribute: color.FgRed, Value: "out of stock"},
+		serverTypeNotOffered:                      &human.EnumMarshalSpec{Attribute: color.Faint, Value: "not offered"},
 	}
 )
 
@@ -28,36 +29,37 @@ var (
 // Builders
 //
 
+// deprecatedServerTypes are hidden from the server types listed or proposed by the CLI.
+var deprecatedServerTypes = map[string]struct{}{
+	"START1-L":    {},
+	"START1-M":    {},
+	"START1-S":    {},
+	"START1-XS":   {},
+	"VC1L":        {},
+	"VC1M":        {},
+	"VC1S":        {},
+	"X64-120GB":   {},
+	"X64-15GB":    {},
+	"X64-30GB":    {},
+	"X64-60GB":    {},
+	"C1":          {},
+	"C2M":         {},
+	"C2L":         {},
+	"C2S":         {},
+	"ARM64-2GB":   {},
+	"ARM64-4GB":   {},
+	"ARM64-8GB":   {},
+	"ARM64-16GB":  {},
+	"ARM64-32GB":  {},
+	"ARM64-64GB":  {},
+	"ARM64-128GB": {},
+}
+
 // serverTypeListBuilder transforms the server map into a list to display a
 // table of server types instead of a flat key/value list.
 // We need it for:
 // - [APIGW-1932] hide deprecated instance for scw instance server-type list
 func serverTypeListBuilder(c *core.Command) *core.Command {
-	deprecatedNames := map[string]struct{}{
-		"START1-L":    {},
-		"START1-M":    {},
-		"START1-S":    {},
-		"START1-XS":   {},
-		"VC1L":        {},
-		"VC1M":        {},
-		"VC1S":        {},
-		"X64-120GB":   {},
-		"X64-15GB":    {},
-		"X64-30GB":    {},
-		"X64-60GB":    {},
-		"C1":          {},
-		"C2M":         {},
-		"C2L":         {},
-		"C2S":         {},
-		"ARM64-2GB":   {},
-		"ARM64-4GB":   {},
-		"ARM64-8GB":   {},
-		"ARM64-16GB":  {},
-		"ARM64-32GB":  {},
-		"ARM64-64GB":  {},
-		"ARM64-128GB": {},
-	}
-
 	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
 		type customServerType struct {
 			Name               string                           `json:"name"`
@@ -89,7 +91,7 @@ func serverTypeListBuilder(c *core.Command) *core.Command {
 		}
 
 		for name, serverType := range listServersTypesResponse.Servers {
-			_, isDeprecated := deprecatedNames[name]
+			_, isDeprecated := deprecatedServerTypes[name]
 			if isDeprecated {
 				continue
 			}
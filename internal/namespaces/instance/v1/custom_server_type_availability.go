@@ -0,0 +1,258 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// serverTypeNotOffered is the availability of a server type in a zone where it is not sold.
+const serverTypeNotOffered = instance.ServerTypesAvailability("not_offered")
+
+type serverTypeAvailabilityRequest struct {
+	Zone     scw.Zone
+	Type     string
+	AllZones bool
+}
+
+// serverTypeAvailabilityResult is the stock of a server type in some zones and the quota of the organization for this type.
+type serverTypeAvailabilityResult struct {
+	Type  string                        `json:"type"`
+	Zones []*serverTypeZoneAvailability `json:"zones"`
+	// Quota is nil when no quota of the organization applies to the server type
+	Quota *serverTypeQuota `json:"quota"`
+}
+
+type serverTypeZoneAvailability struct {
+	Zone         scw.Zone                         `json:"zone"`
+	Availability instance.ServerTypesAvailability `json:"availability"`
+	// Servers is the number of servers of the type of the organization in the zone
+	Servers uint32 `json:"servers"`
+	Error   string `json:"error,omitempty"`
+}
+
+type serverTypeQuota struct {
+	Name string `json:"name"`
+	// Limit and Remaining are nil when the quota is unlimited
+	Limit     *uint64 `json:"limit"`
+	Used      uint32  `json:"used"`
+	Remaining *uint64 `json:"remaining"`
+}
+
+func serverTypeAvailabilityCommand() *core.Command {
+	return &core.Command{
+		Short: `Check the stock and the quota of a server type`,
+		Long: `Report the stock of a server type in a zone, or in all the zones with all-zones=true, and the remaining quota of the organization for this type.
+The quota counts the servers of the type in all the zones, so that automation can pick a zone where a server of this type can actually be created.`,
+		Namespace: "instance",
+		Resource:  "server-type",
+		Verb:      "availability",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(serverTypeAvailabilityRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:             "type",
+				Short:            `Commercial type of the server`,
+				Required:         true,
+				AutoCompleteFunc: completeServerType,
+			},
+			{
+				Name:  "all-zones",
+				Short: `Report the stock of the server type in all the zones`,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: serverTypeAvailabilityRun,
+		View: &core.View{
+			Sections: []*core.ViewSection{
+				{
+					FieldName: "Zones",
+					Title:     "Zones",
+				},
+				{
+					FieldName:   "Quota",
+					Title:       "Quota",
+					HideIfEmpty: true,
+				},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Check the stock of a server type in all the zones",
+				Raw:   "scw instance server-type availability type=GP1-S all-zones=true",
+			},
+			{
+				Short: "List the zones where a server type is available",
+				Raw:   `scw instance server-type availability type=GP1-S all-zones=true -o json | jq -r '.zones[] | select(.availability == "available") | .zone'`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List Instance types",
+				Command: "scw instance server-type list",
+			},
+		},
+	}
+}
+
+func serverTypeAvailabilityRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*serverTypeAvailabilityRequest)
+	client := core.ExtractClient(ctx)
+	api := instance.NewAPI(client)
+
+	reportedZone := args.Zone
+	if reportedZone == "" {
+		reportedZone, _ = client.GetDefaultZone()
+	}
+	organizationID, _ := client.GetDefaultOrganizationID()
+
+	// The servers of all the zones are counted for the quota, whatever the zones reported.
+	allZones := api.Zones()
+	zones := make([]*serverTypeZoneAvailability, 0, len(allZones))
+	tasks := make([]*core.BatchTask, 0, len(allZones))
+	for _, zone := range allZones {
+		zoneAvailability := &serverTypeZoneAvailability{Zone: zone}
+		zones = append(zones, zoneAvailability)
+		isReported := args.AllZones || zone == reportedZone
+		tasks = append(tasks, &core.BatchTask{
+			Name: fmt.Sprintf("zone %s", zone),
+			Run: func(ctx context.Context) error {
+				err := fetchServerTypeZoneAvailability(ctx, api, zoneAvailability, args.Type, organizationID, isReported)
+				if err != nil {
+					zoneAvailability.Error = err.Error()
+				}
+				return err
+			},
+		})
+	}
+	batchErr := core.RunBatch(ctx, tasks)
+
+	result := &serverTypeAvailabilityResult{Type: args.Type}
+	used := uint32(0)
+	for _, zone := range zones {
+		used += zone.Servers
+		if args.AllZones || zone.Zone == reportedZone {
+			result.Zones = append(result.Zones, zone)
+		}
+	}
+	if len(result.Zones) == 0 {
+		return nil, fmt.Errorf("zone %s is not a zone of the Instance API", reportedZone)
+	}
+	if batchErr != nil && !serverTypeZonesReported(result.Zones) {
+		return nil, batchErr
+	}
+
+	// Quotas are best effort: they cannot be read without the permissions of the organization,
+	// and the usage is unknown when the servers of a zone cannot be counted.
+	if batchErr != nil {
+		core.ExtractLogger(ctx).Debugf("cannot count the servers of type %s in all the zones: %s\n", args.Type, batchErr)
+		return result, nil
+	}
+	quota, err := iam.NewAPI(client).ListQuota(&iam.ListQuotaRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		core.ExtractLogger(ctx).Debugf("cannot list the quotas of organization %s: %s\n", organizationID, err)
+		return result, nil
+	}
+	result.Quota = serverTypeQuotaOf(quota.Quota, args.Type, used)
+
+	return result, nil
+}
+
+// fetchServerTypeZoneAvailability counts the servers of a type of the organization in a zone, and gets the stock of the type when the zone is reported.
+func fetchServerTypeZoneAvailability(ctx context.Context, api *instance.API, zoneAvailability *serverTypeZoneAvailability, serverType string, organizationID string, isReported bool) error {
+	dashboard, err := api.GetDashboard(&instance.GetDashboardRequest{
+		Zone:         zoneAvailability.Zone,
+		Organization: &organizationID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	zoneAvailability.Servers = dashboard.Dashboard.ServersByTypes[serverType]
+
+	if !isReported {
+		return nil
+	}
+	availabilities, err := api.GetServerTypesAvailability(&instance.GetServerTypesAvailabilityRequest{
+		Zone: zoneAvailability.Zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	zoneAvailability.Availability = serverTypeNotOffered
+	if availability, exists := availabilities.Servers[serverType]; exists {
+		zoneAvailability.Availability = availability.Availability
+	}
+	return nil
+}
+
+// serverTypeZonesReported returns whether the stock of at least one of the zones could be fetched.
+func serverTypeZonesReported(zones []*serverTypeZoneAvailability) bool {
+	for _, zone := range zones {
+		if zone.Error == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// serverTypeQuotaOf returns the quota applying to a server type, or nil when there is none.
+func serverTypeQuotaOf(quota []*iam.Quotum, serverType string, used uint32) *serverTypeQuota {
+	for _, quotum := range quota {
+		if !QuotumMatchesServerType(quotum.Name, serverType) {
+			continue
+		}
+
+		result := &serverTypeQuota{
+			Name: quotum.Name,
+			Used: used,
+		}
+		if quotum.Limit != nil && (quotum.Unlimited == nil || !*quotum.Unlimited) {
+			remaining := uint64(0)
+			if *quotum.Limit > uint64(used) {
+				remaining = *quotum.Limit - uint64(used)
+			}
+			result.Limit = quotum.Limit
+			result.Remaining = &remaining
+		}
+		return result
+	}
+	return nil
+}
+
+// QuotumMatchesServerType returns whether a quota limits the servers of a type, such as "instances_gp1_s_servers" for GP1-S.
+// The words of the type must follow each other in the name of the quota, which must be about servers or instances.
+func QuotumMatchesServerType(quotumName string, serverType string) bool {
+	split := func(r rune) bool {
+		return r == '_' || r == '-'
+	}
+	quotumWords := strings.FieldsFunc(strings.ToLower(quotumName), split)
+	typeWords := strings.FieldsFunc(strings.ToLower(serverType), split)
+	if len(typeWords) == 0 {
+		return false
+	}
+
+	isServerQuota := false
+	for _, word := range quotumWords {
+		if strings.HasPrefix(word, "server") || strings.HasPrefix(word, "instance") {
+			isServerQuota = true
+		}
+	}
+	if !isServerQuota {
+		return false
+	}
+
+	for i := 0; i+len(typeWords) <= len(quotumWords); i++ {
+		if reflect.DeepEqual(quotumWords[i:i+len(typeWords)], typeWords) {
+			return true
+		}
+	}
+	return false
+}
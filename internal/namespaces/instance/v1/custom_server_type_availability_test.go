@@ -0,0 +1,52 @@
+package instance
+
+import (
+	"testing"
+
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotumMatchesServerType(t *testing.T) {
+	assert.True(t, QuotumMatchesServerType("instances_gp1_s_servers", "GP1-S"))
+	assert.True(t, QuotumMatchesServerType("servers_type_PRO2-XXS", "PRO2-XXS"))
+	assert.False(t, QuotumMatchesServerType("instances_gp1_xs_servers", "GP1-S"))
+	assert.False(t, QuotumMatchesServerType("instances_gp1_servers_s", "GP1-S"))
+	assert.False(t, QuotumMatchesServerType("gp1_s_volumes", "GP1-S"))
+}
+
+func Test_serverTypeQuotaOf(t *testing.T) {
+	quota := []*iam.Quotum{
+		{Name: "instances_dev1_s_servers", Unlimited: scw.BoolPtr(true)},
+		{Name: "instances_gp1_s_servers", Limit: scw.Uint64Ptr(10)},
+		{Name: "instances_gp1_xs_servers", Limit: scw.Uint64Ptr(2)},
+	}
+
+	t.Run("Limited", func(t *testing.T) {
+		result := serverTypeQuotaOf(quota, "GP1-S", 3)
+		require.NotNil(t, result)
+		assert.Equal(t, "instances_gp1_s_servers", result.Name)
+		assert.Equal(t, uint64(10), *result.Limit)
+		assert.Equal(t, uint64(7), *result.Remaining)
+	})
+
+	t.Run("Exceeded", func(t *testing.T) {
+		result := serverTypeQuotaOf(quota, "GP1-XS", 3)
+		require.NotNil(t, result)
+		assert.Equal(t, uint64(0), *result.Remaining)
+	})
+
+	t.Run("Unlimited", func(t *testing.T) {
+		result := serverTypeQuotaOf(quota, "DEV1-S", 3)
+		require.NotNil(t, result)
+		assert.Nil(t, result.Limit)
+		assert.Nil(t, result.Remaining)
+		assert.Equal(t, uint32(3), result.Used)
+	})
+
+	t.Run("No quota", func(t *testing.T) {
+		assert.Nil(t, serverTypeQuotaOf(quota, "PRO2-S", 3))
+	})
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const (
@@ -71,7 +70,7 @@ func snapshotCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
 			SnapshotID:    respI.(*instance.CreateSnapshotResponse).Snapshot.ID,
 			Zone:          argsI.(*customCreateSnapshotRequest).Zone,
-			Timeout:       scw.TimeDurationPtr(serverActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -151,7 +150,7 @@ func snapshotUpdateBuilder(c *core.Command) *core.Command {
 		return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
 			SnapshotID:    snapshot.ID,
 			Zone:          snapshot.Zone,
-			Timeout:       scw.TimeDurationPtr(snapshotActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, snapshotActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -0,0 +1,115 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const taskPollInterval = 5 * time.Second
+
+func snapshotExportBuilder(c *core.Command) *core.Command {
+	c.Long = `Export a snapshot as a QCOW2 file to a bucket of the region of the zone, to archive it or to import it in another region.
+With --wait, the progress of the transfer is reported until the file is available in the bucket.`
+	c.ArgSpecs.GetByName("snapshot-id").Positional = true
+	c.ArgSpecs.GetByName("bucket").Short = `Bucket the QCOW2 file is exported to, it must be in the region of the zone`
+	c.ArgSpecs.GetByName("bucket").Required = true
+	c.ArgSpecs.GetByName("key").Short = `Object key of the exported QCOW2 file`
+	c.ArgSpecs.GetByName("key").Required = true
+
+	c.WaitFunc = func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+		return waitForTask(ctx, argsI.(*instance.ExportSnapshotRequest).Zone, respI.(*instance.ExportSnapshotResponse).Task, "exporting")
+	}
+	c.Examples = []*core.Example{
+		{
+			Short: "Export a snapshot to a bucket and wait for the end of the transfer",
+			Raw:   "scw instance snapshot export 11111111-1111-1111-1111-111111111111 bucket=my-bucket key=disk.qcow2 --wait",
+		},
+	}
+	c.SeeAlsos = []*core.SeeAlso{
+		{
+			Short:   "Import a QCOW2 file of a bucket as a snapshot",
+			Command: "scw instance snapshot import",
+		},
+	}
+	return c
+}
+
+// getTask fetches a task of the Instance API, which is not part of the SDK.
+func getTask(ctx context.Context, zone scw.Zone, taskID string) (*instance.Task, error) {
+	client := core.ExtractClient(ctx)
+	if zone == "" {
+		zone, _ = client.GetDefaultZone()
+	}
+
+	scwReq := &scw.ScalewayRequest{
+		Method: "GET",
+		Path:   "/instance/v1/zones/" + zone.String() + "/tasks/" + taskID,
+	}
+	resp := &struct {
+		Task *instance.Task `json:"task"`
+	}{}
+	if err := client.Do(scwReq, resp, scw.WithContext(ctx)); err != nil {
+		return nil, err
+	}
+	return resp.Task, nil
+}
+
+// waitForTask waits for a task to succeed and reports its progress each time it changes.
+func waitForTask(ctx context.Context, zone scw.Zone, task *instance.Task, state string) (*instance.Task, error) {
+	if task == nil {
+		return nil, fmt.Errorf("no task to wait for")
+	}
+	interval := taskPollInterval
+	if core.DefaultRetryInterval != nil {
+		interval = *core.DefaultRetryInterval
+	}
+	timeoutDuration := *core.WaitTimeout(ctx, snapshotActionTimeout)
+	deadline := time.Now().Add(timeoutDuration)
+
+	lastProgress := int32(-1)
+	for {
+		if task.Progress != lastProgress {
+			lastProgress = task.Progress
+			percent := float64(task.Progress)
+			core.ReportWaitProgress(ctx, taskProgressMessage(state, task), &percent)
+		}
+
+		switch task.Status {
+		case instance.TaskStatusSuccess:
+			return task, nil
+		case instance.TaskStatusFailure:
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("task %s failed", task.ID),
+				Details: task.Description,
+			}
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("timeout after %s waiting for task %s, its status is %s", timeoutDuration, task.ID, task.Status)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var err error
+		task, err = getTask(ctx, zone, task.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// taskProgressMessage formats the progress of a task, such as "exporting… 42%".
+func taskProgressMessage(state string, task *instance.Task) string {
+	if task.Status == instance.TaskStatusSuccess {
+		return fmt.Sprintf("%s… done", state)
+	}
+	return fmt.Sprintf("%s… %d%%", state, task.Progress)
+}
@@ -0,0 +1,14 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_taskProgressMessage(t *testing.T) {
+	assert.Equal(t, "exporting… 42%", taskProgressMessage("exporting", &instance.Task{Status: instance.TaskStatusStarted, Progress: 42}))
+	assert.Equal(t, "importing… 0%", taskProgressMessage("importing", &instance.Task{Status: instance.TaskStatusPending}))
+	assert.Equal(t, "exporting… done", taskProgressMessage("exporting", &instance.Task{Status: instance.TaskStatusSuccess, Progress: 100}))
+}
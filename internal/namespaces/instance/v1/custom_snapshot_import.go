@@ -0,0 +1,167 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	object "github.com/scaleway/scaleway-cli/v2/internal/namespaces/object/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type snapshotImportRequest struct {
+	Zone      scw.Zone
+	File      string
+	Bucket    string
+	Key       string
+	Name      string
+	Unified   bool
+	ProjectID *string
+}
+
+func snapshotImportCommand() *core.Command {
+	return &core.Command{
+		Short: `Import a QCOW file as a snapshot`,
+		Long: `Upload a local QCOW file to a bucket of the region of the zone and import it as a snapshot.
+The file is uploaded in parts, in parallel, and the failed parts are retried.
+When the upload is interrupted, running the same command again resumes it instead of starting over.
+Without a file, the object key of the bucket is imported, such as a QCOW2 file exported from a snapshot of another region.
+With --wait, the progress of the import is reported until the snapshot is available.`,
+		Namespace:             "instance",
+		Resource:              "snapshot",
+		Verb:                  "import",
+		ArgsType:              reflect.TypeOf(snapshotImportRequest{}),
+		OptionalPositionalArg: true,
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "file",
+				Short:      "Path of the QCOW file to import, the object key of the bucket is imported when not set",
+				Positional: true,
+			},
+			{
+				Name:     "bucket",
+				Short:    "Bucket the file is uploaded to, it must be in the region of the zone",
+				Required: true,
+			},
+			{
+				Name:  "key",
+				Short: "Object key of the uploaded or imported file, default to the name of the file",
+			},
+			{
+				Name:  "name",
+				Short: "Name of the snapshot, default to the name of the file or of the key without its extension",
+			},
+			{
+				Name:  "unified",
+				Short: "Whether the snapshot is unified or not",
+			},
+			core.ProjectIDArgSpec(),
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: snapshotImportRun,
+		WaitFunc: func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+			zone := argsI.(*snapshotImportRequest).Zone
+			resp := respI.(*instance.CreateSnapshotResponse)
+			if resp.Task != nil {
+				_, err := waitForTask(ctx, zone, resp.Task, "importing")
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			api := instance.NewAPI(core.ExtractClient(ctx))
+			return api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
+				SnapshotID:    resp.Snapshot.ID,
+				Zone:          zone,
+				Timeout:       core.WaitTimeout(ctx, snapshotActionTimeout),
+				RetryInterval: core.DefaultRetryInterval,
+			}, scw.WithContext(ctx))
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Import a QCOW file as a snapshot and wait for it to be available",
+				Raw:   "scw instance snapshot import ./disk.qcow2 bucket=my-bucket --wait",
+			},
+			{
+				Short: "Import a QCOW2 file exported to a bucket",
+				Raw:   "scw instance snapshot import bucket=my-bucket key=disk.qcow2 zone=nl-ams-1 --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Export a snapshot as a QCOW2 file to a bucket",
+				Command: "scw instance snapshot export",
+			},
+		},
+	}
+}
+
+func snapshotImportRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*snapshotImportRequest)
+
+	key, name, err := snapshotImportObject(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.File != "" {
+		region, err := args.Zone.Region()
+		if err != nil {
+			return nil, err
+		}
+		target, err := object.NewUploadTarget(ctx, region, args.Bucket, key)
+		if err != nil {
+			return nil, err
+		}
+		_, err = upload.File(ctx, args.File, target, core.UploadConfig(ctx, "upload"))
+		if err != nil {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("failed to upload %s: %w", args.File, err),
+				Hint: "Run the same command again to resume the upload",
+			}
+		}
+	}
+
+	request := &instance.CreateSnapshotRequest{
+		Zone:    args.Zone,
+		Name:    name,
+		Bucket:  &args.Bucket,
+		Key:     &key,
+		Project: args.ProjectID,
+	}
+	if args.Unified {
+		request.VolumeType = instance.SnapshotVolumeTypeUnified
+	}
+
+	return instance.NewAPI(core.ExtractClient(ctx)).CreateSnapshot(request, scw.WithContext(ctx))
+}
+
+// snapshotImportObject returns the object key imported as a snapshot and the name of the snapshot, defaulting to the name of the file.
+func snapshotImportObject(args *snapshotImportRequest) (string, string, error) {
+	source := args.File
+	if source == "" {
+		if args.Key == "" {
+			return "", "", &core.CliError{
+				Err:  fmt.Errorf("a file or a key is required"),
+				Hint: "Give the path of a local QCOW file or the object key of a QCOW file of the bucket with key=<key>",
+			}
+		}
+		source = args.Key
+	}
+
+	key := args.Key
+	if key == "" {
+		key = filepath.Base(source)
+	}
+	name := args.Name
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(filepath.ToSlash(source)), path.Ext(source))
+	}
+	return key, name, nil
+}
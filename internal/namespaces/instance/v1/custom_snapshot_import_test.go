@@ -0,0 +1,35 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_snapshotImportObject(t *testing.T) {
+	t.Run("Local file", func(t *testing.T) {
+		key, name, err := snapshotImportObject(&snapshotImportRequest{File: "images/disk.qcow2"})
+		require.NoError(t, err)
+		assert.Equal(t, "disk.qcow2", key)
+		assert.Equal(t, "disk", name)
+	})
+
+	t.Run("Object of the bucket", func(t *testing.T) {
+		key, name, err := snapshotImportObject(&snapshotImportRequest{Key: "exports/disk.qcow2"})
+		require.NoError(t, err)
+		assert.Equal(t, "exports/disk.qcow2", key)
+		assert.Equal(t, "disk", name)
+	})
+
+	t.Run("Custom name", func(t *testing.T) {
+		_, name, err := snapshotImportObject(&snapshotImportRequest{Key: "disk.qcow2", Name: "restored"})
+		require.NoError(t, err)
+		assert.Equal(t, "restored", name)
+	})
+
+	t.Run("No file nor key", func(t *testing.T) {
+		_, _, err := snapshotImportObject(&snapshotImportRequest{})
+		assert.ErrorContains(t, err, "a file or a key is required")
+	})
+}
@@ -0,0 +1,310 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	volumeIssueUnattached     = "unattached"
+	volumeIssueServerDeleted  = "server deleted"
+	volumeIssueServerArchived = "server archived"
+
+	volumeActionDetach          = "detach"
+	volumeActionDelete          = "delete"
+	volumeActionDetachAndDelete = "detach and delete"
+)
+
+// volumeActionsDone are the actions displayed once they succeeded
+var volumeActionsDone = map[string]string{
+	volumeActionDetach:          "detached",
+	volumeActionDelete:          "deleted",
+	volumeActionDetachAndDelete: "detached and deleted",
+}
+
+type volumeListAttachmentsRequest struct {
+	Zone      scw.Zone
+	ProjectID *string
+	Orphaned  bool
+	Action    string
+	Yes       bool
+}
+
+// volumeAttachment is a volume with the server it is attached to.
+// Issue is set when the volume is billed without being used by a running server.
+type volumeAttachment struct {
+	VolumeID    string                    `json:"volume_id"`
+	VolumeName  string                    `json:"volume_name"`
+	VolumeType  instance.VolumeVolumeType `json:"volume_type"`
+	Size        scw.Size                  `json:"size"`
+	ServerID    string                    `json:"server_id"`
+	ServerName  string                    `json:"server_name"`
+	ServerState instance.ServerState      `json:"server_state"`
+	RootVolume  bool                      `json:"root_volume"`
+	Issue       string                    `json:"issue"`
+	Action      string                    `json:"action"`
+}
+
+func volumeListAttachmentsCommand() *core.Command {
+	return &core.Command{
+		Short: `List the volumes with the servers they are attached to`,
+		Long: `List the volumes of a zone with the servers they are attached to, merging the volumes and the servers lists, and flag the volumes billed without being used:
+  - unattached: the volume is not attached to any server.
+  - server deleted: the volume is attached to a server that does not exist anymore.
+  - server archived: the volume is attached to a stopped server, whose volumes are still billed.
+
+With action=detach, flagged volumes are detached from archived servers, except their root volume.
+With action=delete, they are also deleted along with unattached volumes and volumes of deleted servers.
+Actions must be confirmed, unless yes=true is set.`,
+		Namespace: "instance",
+		Resource:  "volume",
+		Verb:      "list-attachments",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(volumeListAttachmentsRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			{
+				Name:  "orphaned",
+				Short: `Only list the volumes flagged with an issue`,
+			},
+			{
+				Name:       "action",
+				Short:      `Action to run on the flagged volumes`,
+				EnumValues: []string{volumeActionDetach, volumeActionDelete},
+			},
+			{
+				Name:  "yes",
+				Short: `Run the action without asking for confirmation`,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run: volumeListAttachmentsRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Volume ID", FieldName: "VolumeID"},
+				{Label: "Volume Name", FieldName: "VolumeName"},
+				{Label: "Type", FieldName: "VolumeType"},
+				{Label: "Size", FieldName: "Size"},
+				{Label: "Server ID", FieldName: "ServerID"},
+				{Label: "Server Name", FieldName: "ServerName"},
+				{Label: "Server State", FieldName: "ServerState"},
+				{Label: "Issue", FieldName: "Issue"},
+				{Label: "Action", FieldName: "Action"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the volumes with their servers",
+				Raw:   "scw instance volume list-attachments",
+			},
+			{
+				Short: "Delete the volumes that are not used by a running server",
+				Raw:   "scw instance volume list-attachments orphaned=true action=delete",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List volumes",
+				Command: "scw instance volume list",
+			},
+			{
+				Short:   "Detach a volume from its server",
+				Command: "scw instance server detach-volume",
+			},
+		},
+	}
+}
+
+func volumeListAttachmentsRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*volumeListAttachmentsRequest)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	volumes, err := api.ListVolumes(&instance.ListVolumesRequest{
+		Zone:    args.Zone,
+		Project: args.ProjectID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	// Servers of all projects are listed as volumes can be attached to servers of other projects
+	servers, err := api.ListServers(&instance.ListServersRequest{
+		Zone: args.Zone,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := buildVolumeAttachments(volumes.Volumes, servers.Servers)
+	if args.Orphaned {
+		attachments = orphanedVolumeAttachments(attachments)
+	}
+	if args.Action == "" {
+		return attachments, nil
+	}
+
+	planned := planVolumeActions(attachments, args.Action)
+	if len(planned) == 0 {
+		return attachments, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:     fmt.Errorf("the action on the volumes must be confirmed"),
+				Details: formatVolumeActions(planned),
+				Hint:    "Use yes=true to run the action without confirmation",
+			}
+		}
+
+		_, _ = interactive.Println(formatVolumeActions(planned))
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to proceed?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Action canceled"}, nil
+		}
+	}
+
+	failed := 0
+	for _, attachment := range planned {
+		err := runVolumeAction(ctx, api, args.Zone, attachment)
+		if err != nil {
+			attachment.Action = fmt.Sprintf("failed to %s: %s", attachment.Action, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return attachments, fmt.Errorf("%d of %d actions failed", failed, len(planned))
+	}
+
+	return attachments, nil
+}
+
+// buildVolumeAttachments matches the volumes with the servers they are attached to.
+func buildVolumeAttachments(volumes []*instance.Volume, servers []*instance.Server) []*volumeAttachment {
+	serversByID := make(map[string]*instance.Server, len(servers))
+	for _, server := range servers {
+		serversByID[server.ID] = server
+	}
+
+	attachments := make([]*volumeAttachment, 0, len(volumes))
+	for _, volume := range volumes {
+		attachment := &volumeAttachment{
+			VolumeID:   volume.ID,
+			VolumeName: volume.Name,
+			VolumeType: volume.VolumeType,
+			Size:       volume.Size,
+		}
+
+		switch {
+		case volume.Server == nil:
+			attachment.Issue = volumeIssueUnattached
+		case serversByID[volume.Server.ID] == nil:
+			attachment.ServerID = volume.Server.ID
+			attachment.ServerName = volume.Server.Name
+			attachment.Issue = volumeIssueServerDeleted
+		default:
+			server := serversByID[volume.Server.ID]
+			attachment.ServerID = server.ID
+			attachment.ServerName = server.Name
+			attachment.ServerState = server.State
+			if rootVolume, exists := server.Volumes["0"]; exists && rootVolume.ID == volume.ID {
+				attachment.RootVolume = true
+			}
+			if server.State == instance.ServerStateStopped {
+				attachment.Issue = volumeIssueServerArchived
+			}
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	sort.SliceStable(attachments, func(i, j int) bool {
+		if attachments[i].ServerName != attachments[j].ServerName {
+			return attachments[i].ServerName < attachments[j].ServerName
+		}
+		return attachments[i].VolumeName < attachments[j].VolumeName
+	})
+
+	return attachments
+}
+
+func orphanedVolumeAttachments(attachments []*volumeAttachment) []*volumeAttachment {
+	orphaned := []*volumeAttachment(nil)
+	for _, attachment := range attachments {
+		if attachment.Issue != "" {
+			orphaned = append(orphaned, attachment)
+		}
+	}
+
+	return orphaned
+}
+
+// planVolumeActions sets the action to run on the flagged volumes and returns them.
+// Root volumes are never detached from archived servers, as the servers could not boot anymore.
+func planVolumeActions(attachments []*volumeAttachment, action string) []*volumeAttachment {
+	planned := []*volumeAttachment(nil)
+	for _, attachment := range attachments {
+		switch {
+		case attachment.Issue == volumeIssueServerArchived && !attachment.RootVolume && action == volumeActionDelete:
+			attachment.Action = volumeActionDetachAndDelete
+		case attachment.Issue == volumeIssueServerArchived && !attachment.RootVolume:
+			attachment.Action = volumeActionDetach
+		case (attachment.Issue == volumeIssueUnattached || attachment.Issue == volumeIssueServerDeleted) && action == volumeActionDelete:
+			attachment.Action = volumeActionDelete
+		default:
+			continue
+		}
+		planned = append(planned, attachment)
+	}
+
+	return planned
+}
+
+func formatVolumeActions(planned []*volumeAttachment) string {
+	lines := []string{"The following actions will be run:"}
+	for _, attachment := range planned {
+		lines = append(lines, fmt.Sprintf("  - %s volume %s (%s)", attachment.Action, attachment.VolumeID, attachment.VolumeName))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func runVolumeAction(ctx context.Context, api *instance.API, zone scw.Zone, attachment *volumeAttachment) error {
+	if attachment.Action == volumeActionDetach || attachment.Action == volumeActionDetachAndDelete {
+		_, err := api.DetachVolume(&instance.DetachVolumeRequest{
+			Zone:          zone,
+			VolumeID:      attachment.VolumeID,
+			IsBlockVolume: scw.BoolPtr(false),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	if attachment.Action == volumeActionDelete || attachment.Action == volumeActionDetachAndDelete {
+		err := api.DeleteVolume(&instance.DeleteVolumeRequest{
+			Zone:     zone,
+			VolumeID: attachment.VolumeID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	attachment.Action = volumeActionsDone[attachment.Action]
+	return nil
+}
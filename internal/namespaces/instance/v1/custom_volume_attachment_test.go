@@ -0,0 +1,63 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildVolumeAttachments(t *testing.T) {
+	volumes := []*instance.Volume{
+		{ID: "root", Name: "web-root", Server: &instance.ServerSummary{ID: "web", Name: "web"}},
+		{ID: "data", Name: "web-data", Server: &instance.ServerSummary{ID: "web", Name: "web"}},
+		{ID: "archive-root", Name: "archive-root", Server: &instance.ServerSummary{ID: "archive", Name: "archive"}},
+		{ID: "archive-data", Name: "archive-data", Server: &instance.ServerSummary{ID: "archive", Name: "archive"}},
+		{ID: "ghost", Name: "ghost-data", Server: &instance.ServerSummary{ID: "deleted", Name: "deleted"}},
+		{ID: "spare", Name: "spare"},
+	}
+	servers := []*instance.Server{
+		{ID: "web", Name: "web", State: instance.ServerStateRunning, Volumes: map[string]*instance.VolumeServer{
+			"0": {ID: "root"},
+			"1": {ID: "data"},
+		}},
+		{ID: "archive", Name: "archive", State: instance.ServerStateStopped, Volumes: map[string]*instance.VolumeServer{
+			"0": {ID: "archive-root"},
+			"1": {ID: "archive-data"},
+		}},
+	}
+
+	attachments := buildVolumeAttachments(volumes, servers)
+	issues := map[string]string{}
+	for _, attachment := range attachments {
+		issues[attachment.VolumeID] = attachment.Issue
+	}
+	assert.Equal(t, map[string]string{
+		"root":         "",
+		"data":         "",
+		"archive-root": volumeIssueServerArchived,
+		"archive-data": volumeIssueServerArchived,
+		"ghost":        volumeIssueServerDeleted,
+		"spare":        volumeIssueUnattached,
+	}, issues)
+	assert.Equal(t, "spare", attachments[0].VolumeID)
+
+	orphaned := orphanedVolumeAttachments(attachments)
+	assert.Len(t, orphaned, 4)
+
+	planned := planVolumeActions(orphaned, volumeActionDetach)
+	assert.Len(t, planned, 1)
+	assert.Equal(t, "archive-data", planned[0].VolumeID)
+	assert.Equal(t, volumeActionDetach, planned[0].Action)
+
+	planned = planVolumeActions(orphaned, volumeActionDelete)
+	actions := map[string]string{}
+	for _, attachment := range planned {
+		actions[attachment.VolumeID] = attachment.Action
+	}
+	assert.Equal(t, map[string]string{
+		"archive-data": volumeActionDetachAndDelete,
+		"ghost":        volumeActionDelete,
+		"spare":        volumeActionDelete,
+	}, actions)
+}
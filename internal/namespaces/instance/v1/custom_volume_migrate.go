@@ -0,0 +1,619 @@
+package instance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	volumeMigrateTypeBSSD = "b_ssd"
+	volumeMigrateTypeSBS  = "sbs"
+)
+
+type volumeMigrateRequest struct {
+	Zone     scw.Zone
+	VolumeID string
+	Type     string
+	Yes      bool
+}
+
+type volumeResizeRequest struct {
+	Zone     scw.Zone
+	VolumeID string
+	Size     scw.Size
+	Yes      bool
+}
+
+// volumeUpdateResult is the volume resulting from a migration or a resize.
+// OldVolumeID is set when the volume was replaced by a new one, which is then attached in its place.
+type volumeUpdateResult struct {
+	VolumeID    string                    `json:"volume_id"`
+	VolumeType  instance.VolumeVolumeType `json:"volume_type"`
+	Size        scw.Size                  `json:"size"`
+	State       string                    `json:"state"`
+	ServerID    string                    `json:"server_id"`
+	OldVolumeID string                    `json:"old_volume_id"`
+	Steps       []string                  `json:"steps"`
+}
+
+// volumeReplacement describes how a volume is copied to a new volume through a snapshot.
+// Server is the server the volume is attached to, nil when the volume is not attached.
+type volumeReplacement struct {
+	volume     *instance.Volume
+	server     *instance.Server
+	volumeType instance.VolumeVolumeType
+	size       scw.Size
+}
+
+func volumeMigrateCommand() *core.Command {
+	return &core.Command{
+		Short: `Migrate a volume to another type`,
+		Long: `Migrate a volume to another storage type:
+  - type=sbs migrates a b_ssd volume and its snapshots to Block Storage, with the migration API. The migration cannot be undone.
+  - type=b_ssd copies a l_ssd volume to a new b_ssd volume through a snapshot. When the volume is attached, the server must be stopped and the new volume is attached in place of the old one.
+
+The old volume of a copy is kept and must be deleted once the new one is checked. The changes must be confirmed, unless yes=true is set.`,
+		Namespace: "instance",
+		Resource:  "volume",
+		Verb:      "migrate",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(volumeMigrateRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "volume-id",
+				Short:      `ID of the volume to migrate`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:       "type",
+				Short:      `Type of storage to migrate the volume to`,
+				Required:   true,
+				EnumValues: []string{volumeMigrateTypeBSSD, volumeMigrateTypeSBS},
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run:      volumeMigrateRun,
+		WaitFunc: waitForVolumeUpdateFunc(),
+		View:     volumeUpdateView(),
+		Examples: []*core.Example{
+			{
+				Short: "Migrate a volume to Block Storage",
+				Raw:   "scw instance volume migrate 11111111-1111-1111-1111-111111111111 type=sbs --wait",
+			},
+			{
+				Short: "Copy a local volume to a block volume",
+				Raw:   "scw instance volume migrate 11111111-1111-1111-1111-111111111111 type=b_ssd",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Resize a volume",
+				Command: "scw instance volume resize",
+			},
+			{
+				Short:   "Stop a server",
+				Command: "scw instance server stop",
+			},
+		},
+	}
+}
+
+func volumeResizeCommand() *core.Command {
+	return &core.Command{
+		Short: `Resize a volume`,
+		Long: `Increase the size of a volume. Volumes cannot be shrunk.
+b_ssd volumes are resized in place, even when attached to a running server. The partition and the file system must then be extended from the server.
+l_ssd volumes cannot be resized in place, they are copied through a snapshot to a new b_ssd volume of the requested size. When the volume is attached, the server must be stopped and the new volume is attached in place of the old one, which is kept. A copy must be confirmed, unless yes=true is set.`,
+		Namespace: "instance",
+		Resource:  "volume",
+		Verb:      "resize",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(volumeResizeRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "volume-id",
+				Short:      `ID of the volume to resize`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "size",
+				Short:    `New size of the volume`,
+				Required: true,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(((*instance.API)(nil)).Zones()...),
+		},
+		Run:      volumeResizeRun,
+		WaitFunc: waitForVolumeUpdateFunc(),
+		View:     volumeUpdateView(),
+		Examples: []*core.Example{
+			{
+				Short: "Resize a volume to 50GB",
+				Raw:   "scw instance volume resize 11111111-1111-1111-1111-111111111111 size=50GB --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Migrate a volume to another type",
+				Command: "scw instance volume migrate",
+			},
+		},
+	}
+}
+
+func volumeUpdateView() *core.View {
+	return &core.View{
+		Fields: []*core.ViewField{
+			{Label: "Volume ID", FieldName: "VolumeID"},
+			{Label: "Type", FieldName: "VolumeType"},
+			{Label: "Size", FieldName: "Size"},
+			{Label: "State", FieldName: "State"},
+			{Label: "Server ID", FieldName: "ServerID"},
+			{Label: "Old Volume ID", FieldName: "OldVolumeID"},
+		},
+		Sections: []*core.ViewSection{
+			{FieldName: "Steps", Title: "Steps"},
+		},
+	}
+}
+
+func volumeMigrateRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*volumeMigrateRequest)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	volume, server, err := getVolumeWithServer(ctx, api, args.Zone, args.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case args.Type == volumeMigrateTypeSBS && volume.VolumeType == instance.VolumeVolumeTypeBSSD:
+		return migrateVolumeToSBS(ctx, api, volume, args.Yes)
+	case args.Type == volumeMigrateTypeSBS && volume.VolumeType == instance.VolumeVolumeTypeLSSD:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("l_ssd volumes cannot be migrated to Block Storage"),
+			Hint: fmt.Sprintf("Migrate the volume to b_ssd first with scw instance volume migrate %s type=b_ssd", volume.ID),
+		}
+	case args.Type == volumeMigrateTypeBSSD && volume.VolumeType == instance.VolumeVolumeTypeLSSD:
+		return replaceVolume(ctx, api, &volumeReplacement{
+			volume:     volume,
+			server:     server,
+			volumeType: instance.VolumeVolumeTypeBSSD,
+			size:       volume.Size,
+		}, args.Yes)
+	case strings.HasPrefix(string(volume.VolumeType), args.Type):
+		return nil, fmt.Errorf("volume %s is already a %s volume", volume.ID, volume.VolumeType)
+	default:
+		return nil, fmt.Errorf("%s volumes cannot be migrated to %s", volume.VolumeType, args.Type)
+	}
+}
+
+func volumeResizeRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*volumeResizeRequest)
+	api := instance.NewAPI(core.ExtractClient(ctx))
+
+	volume, server, err := getVolumeWithServer(ctx, api, args.Zone, args.VolumeID)
+	if err != nil {
+		return nil, err
+	}
+	if args.Size <= volume.Size {
+		return nil, fmt.Errorf("volume %s cannot be resized from %s to %s, volumes can only grow", volume.ID, humanize.Bytes(uint64(volume.Size)), humanize.Bytes(uint64(args.Size)))
+	}
+
+	switch volume.VolumeType {
+	case instance.VolumeVolumeTypeBSSD:
+		step := fmt.Sprintf("resize volume %s in place to %s", volume.ID, humanize.Bytes(uint64(args.Size)))
+		core.ReportProgressStep(ctx, "resize-volume", core.ProgressStateStarted)
+		resp, err := api.UpdateVolume(&instance.UpdateVolumeRequest{
+			Zone:     volume.Zone,
+			VolumeID: volume.ID,
+			Size:     &args.Size,
+		}, scw.WithContext(ctx))
+		if core.ReportProgressResult(ctx, "resize-volume", err) != nil {
+			return nil, err
+		}
+		result := newVolumeUpdateResult(resp.Volume)
+		result.Steps = []string{step}
+		return result, nil
+	case instance.VolumeVolumeTypeLSSD:
+		return replaceVolume(ctx, api, &volumeReplacement{
+			volume:     volume,
+			server:     server,
+			volumeType: instance.VolumeVolumeTypeBSSD,
+			size:       args.Size,
+		}, args.Yes)
+	case instance.VolumeVolumeTypeSbsVolume:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("volume %s is a Block Storage volume", volume.ID),
+			Hint: "Resize it with scw block volume update",
+		}
+	default:
+		return nil, fmt.Errorf("%s volumes cannot be resized", volume.VolumeType)
+	}
+}
+
+// getVolumeWithServer returns a volume and the server it is attached to, nil when it is not attached.
+func getVolumeWithServer(ctx context.Context, api *instance.API, zone scw.Zone, volumeID string) (*instance.Volume, *instance.Server, error) {
+	volumeResp, err := api.GetVolume(&instance.GetVolumeRequest{
+		Zone:     zone,
+		VolumeID: volumeID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	volume := volumeResp.Volume
+	if volume.Server == nil {
+		return volume, nil, nil
+	}
+
+	serverResp, err := api.GetServer(&instance.GetServerRequest{
+		Zone:     zone,
+		ServerID: volume.Server.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	return volume, serverResp.Server, nil
+}
+
+func newVolumeUpdateResult(volume *instance.Volume) *volumeUpdateResult {
+	result := &volumeUpdateResult{
+		VolumeID:   volume.ID,
+		VolumeType: volume.VolumeType,
+		Size:       volume.Size,
+		State:      volume.State.String(),
+	}
+	if volume.Server != nil {
+		result.ServerID = volume.Server.ID
+	}
+	return result
+}
+
+// confirmVolumeUpdate asks to confirm the steps of a migration or a resize, unless yes is set.
+func confirmVolumeUpdate(ctx context.Context, steps []string, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !interactive.IsInteractive {
+		return false, &core.CliError{
+			Err:     fmt.Errorf("the changes to the volume must be confirmed"),
+			Details: formatVolumeUpdateSteps(steps),
+			Hint:    "Use yes=true to proceed without confirmation",
+		}
+	}
+
+	_, _ = interactive.Println(formatVolumeUpdateSteps(steps))
+	return interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+		Prompt:       "Do you want to proceed?",
+		DefaultValue: false,
+		Ctx:          ctx,
+	})
+}
+
+func formatVolumeUpdateSteps(steps []string) string {
+	lines := []string{"The following steps will be run:"}
+	for i, step := range steps {
+		lines = append(lines, fmt.Sprintf("  %d. %s", i+1, step))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// migrateVolumeToSBS migrates a b_ssd volume and its snapshots to Block Storage with the plan and apply migration API.
+func migrateVolumeToSBS(ctx context.Context, api *instance.API, volume *instance.Volume, yes bool) (interface{}, error) {
+	plan, err := api.PlanBlockMigration(&instance.PlanBlockMigrationRequest{
+		Zone:     volume.Zone,
+		VolumeID: &volume.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	steps := []string{fmt.Sprintf("migrate volume %s to Block Storage", volume.ID)}
+	for _, snapshot := range plan.Snapshots {
+		steps = append(steps, fmt.Sprintf("migrate snapshot %s (%s) to Block Storage", snapshot.Name, snapshot.ID))
+	}
+	confirmed, err := confirmVolumeUpdate(ctx, steps, yes)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return &core.SuccessResult{Message: "Migration canceled"}, nil
+	}
+
+	core.ReportProgressStep(ctx, "migrate-volume", core.ProgressStateStarted)
+	err = api.ApplyBlockMigration(&instance.ApplyBlockMigrationRequest{
+		Zone:          volume.Zone,
+		VolumeID:      &volume.ID,
+		ValidationKey: plan.ValidationKey,
+	}, scw.WithContext(ctx))
+	if core.ReportProgressResult(ctx, "migrate-volume", err) != nil {
+		return nil, err
+	}
+
+	result := newVolumeUpdateResult(volume)
+	result.VolumeType = instance.VolumeVolumeTypeSbsVolume
+	result.State = "migrating"
+	result.Steps = steps
+	return result, nil
+}
+
+// planVolumeReplacement lists the steps copying a volume to a new volume, the server of the volume must be stopped.
+func planVolumeReplacement(replacement *volumeReplacement) ([]string, error) {
+	volume := replacement.volume
+	if server := replacement.server; server != nil && server.State != instance.ServerStateStopped {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("volume %s is attached to server %s which is %s", volume.ID, server.ID, server.State),
+			Hint: fmt.Sprintf("Stop the server first with scw instance server stop %s --wait", server.ID),
+		}
+	}
+
+	steps := []string{
+		fmt.Sprintf("create a snapshot of volume %s", volume.ID),
+		fmt.Sprintf("create a %s volume from the snapshot", replacement.volumeType),
+	}
+	if replacement.size > volume.Size {
+		steps = append(steps, fmt.Sprintf("resize the new volume to %s", humanize.Bytes(uint64(replacement.size))))
+	}
+	if replacement.server != nil {
+		steps = append(steps, fmt.Sprintf("attach the new volume to server %s in place of volume %s", replacement.server.ID, volume.ID))
+	}
+	return append(steps, "delete the snapshot"), nil
+}
+
+// replaceVolume copies a volume to a new volume of another type or size through a snapshot and attaches it in place of the old one.
+// The old volume is kept. If a step fails, the snapshot and the new volume are deleted.
+func replaceVolume(ctx context.Context, api *instance.API, replacement *volumeReplacement, yes bool) (interface{}, error) {
+	steps, err := planVolumeReplacement(replacement)
+	if err != nil {
+		return nil, err
+	}
+	confirmed, err := confirmVolumeUpdate(ctx, steps, yes)
+	if err != nil {
+		return nil, err
+	}
+	if !confirmed {
+		return &core.SuccessResult{Message: "Volume update canceled"}, nil
+	}
+
+	volume := replacement.volume
+	zone := volume.Zone
+	snapshotID := ""
+	newVolume := (*instance.Volume)(nil)
+	attached := false
+	done := []string(nil)
+
+	runStep := func(name string, description string, run func() error) error {
+		core.ReportProgressStep(ctx, name, core.ProgressStateStarted)
+		err := core.ReportProgressResult(ctx, name, run())
+		if err != nil {
+			return err
+		}
+		done = append(done, description)
+		_, _ = interactive.Printf("%s: done\n", description)
+		return nil
+	}
+	fail := func(description string, err error) error {
+		cleanupReport := []string(nil)
+		if newVolume != nil && !attached {
+			cleanupErr := api.DeleteVolume(&instance.DeleteVolumeRequest{
+				Zone:     zone,
+				VolumeID: newVolume.ID,
+			}, scw.WithContext(ctx))
+			cleanupReport = append(cleanupReport, volumeCleanupReport("delete the new volume "+newVolume.ID, cleanupErr))
+		}
+		if snapshotID != "" {
+			cleanupErr := api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+				Zone:       zone,
+				SnapshotID: snapshotID,
+			}, scw.WithContext(ctx))
+			cleanupReport = append(cleanupReport, volumeCleanupReport("delete the snapshot "+snapshotID, cleanupErr))
+		}
+
+		cliErr := &core.CliError{
+			Err: fmt.Errorf("failed to %s: %w", description, err),
+		}
+		if len(cleanupReport) > 0 {
+			cliErr.Details = "Cleanup:\n  " + strings.Join(cleanupReport, "\n  ")
+		}
+		return cliErr
+	}
+
+	err = runStep("create-snapshot", steps[0], func() error {
+		snapshotType := instance.SnapshotVolumeType(volume.VolumeType)
+		if replacement.volumeType != volume.VolumeType {
+			snapshotType = instance.SnapshotVolumeTypeUnified
+		}
+		resp, err := api.CreateSnapshot(&instance.CreateSnapshotRequest{
+			Zone:       zone,
+			Name:       volume.Name + "-copy",
+			VolumeID:   &volume.ID,
+			Project:    &volume.Project,
+			VolumeType: snapshotType,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		snapshotID = resp.Snapshot.ID
+		_, err = api.WaitForSnapshot(&instance.WaitForSnapshotRequest{
+			Zone:          zone,
+			SnapshotID:    snapshotID,
+			Timeout:       core.WaitTimeout(ctx, snapshotActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+		return err
+	})
+	if err != nil {
+		return nil, fail(steps[0], err)
+	}
+
+	err = runStep("create-volume", steps[1], func() error {
+		resp, err := api.CreateVolume(&instance.CreateVolumeRequest{
+			Zone:         zone,
+			Name:         volume.Name,
+			Project:      &volume.Project,
+			Tags:         volume.Tags,
+			VolumeType:   replacement.volumeType,
+			BaseSnapshot: &snapshotID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		newVolume = resp.Volume
+		newVolume, err = waitForVolumeState(ctx, api, zone, newVolume.ID)
+		return err
+	})
+	if err != nil {
+		return nil, fail(steps[1], err)
+	}
+
+	next := 2
+	if replacement.size > volume.Size {
+		err = runStep("resize-volume", steps[next], func() error {
+			_, err := api.UpdateVolume(&instance.UpdateVolumeRequest{
+				Zone:     zone,
+				VolumeID: newVolume.ID,
+				Size:     &replacement.size,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			newVolume, err = waitForVolumeState(ctx, api, zone, newVolume.ID)
+			return err
+		})
+		if err != nil {
+			return nil, fail(steps[next], err)
+		}
+		next++
+	}
+
+	if replacement.server != nil {
+		err = runStep("attach-volume", steps[next], func() error {
+			_, err := api.UpdateServer(&instance.UpdateServerRequest{
+				Zone:     zone,
+				ServerID: replacement.server.ID,
+				Volumes:  replacedServerVolumes(replacement.server, volume.ID, newVolume.ID),
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+			attached = true
+			return nil
+		})
+		if err != nil {
+			return nil, fail(steps[next], err)
+		}
+		next++
+	}
+
+	err = runStep("delete-snapshot", steps[next], func() error {
+		return api.DeleteSnapshot(&instance.DeleteSnapshotRequest{
+			Zone:       zone,
+			SnapshotID: snapshotID,
+		}, scw.WithContext(ctx))
+	})
+	if err != nil {
+		// The volume is replaced, only the snapshot is left behind
+		core.ExtractLogger(ctx).Warningf("failed to delete snapshot %s: %s", snapshotID, err)
+	}
+
+	result := newVolumeUpdateResult(newVolume)
+	if replacement.server != nil {
+		result.ServerID = replacement.server.ID
+	}
+	result.OldVolumeID = volume.ID
+	result.Steps = done
+	return result, nil
+}
+
+// replacedServerVolumes returns the volumes of a server with a volume replaced by another one at the same index.
+// All the volumes of the server must be sent to update one of them.
+func replacedServerVolumes(server *instance.Server, oldVolumeID string, newVolumeID string) *map[string]*instance.VolumeServerTemplate {
+	volumes := make(map[string]*instance.VolumeServerTemplate, len(server.Volumes))
+	for index, volume := range server.Volumes {
+		template := &instance.VolumeServerTemplate{
+			ID:   scw.StringPtr(volume.ID),
+			Name: scw.StringPtr(volume.Name),
+			Boot: scw.BoolPtr(volume.Boot),
+		}
+		if volume.ID == oldVolumeID {
+			template.ID = scw.StringPtr(newVolumeID)
+		}
+		volumes[index] = template
+	}
+	return &volumes
+}
+
+func volumeCleanupReport(description string, err error) string {
+	if err != nil {
+		return fmt.Sprintf("failed to %s: %s", description, err)
+	}
+	return description
+}
+
+func waitForVolumeState(ctx context.Context, api *instance.API, zone scw.Zone, volumeID string) (*instance.Volume, error) {
+	return api.WaitForVolume(&instance.WaitForVolumeRequest{
+		Zone:          zone,
+		VolumeID:      volumeID,
+		Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+}
+
+// waitForVolumeUpdateFunc waits for the resulting volume, migrated volumes are waited for with the Block Storage API.
+func waitForVolumeUpdateFunc() core.WaitFunc {
+	return func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+		result, isResult := respI.(*volumeUpdateResult)
+		if !isResult {
+			return respI, nil
+		}
+
+		zone := scw.Zone("")
+		switch args := argsI.(type) {
+		case *volumeMigrateRequest:
+			zone = args.Zone
+		case *volumeResizeRequest:
+			zone = args.Zone
+		}
+
+		client := core.ExtractClient(ctx)
+		if result.VolumeType == instance.VolumeVolumeTypeSbsVolume {
+			volume, err := block.NewAPI(client).WaitForVolume(&block.WaitForVolumeRequest{
+				Zone:          zone,
+				VolumeID:      result.VolumeID,
+				Timeout:       core.WaitTimeout(ctx, serverActionTimeout),
+				RetryInterval: core.DefaultRetryInterval,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			result.State = volume.Status.String()
+			return result, nil
+		}
+
+		volume, err := waitForVolumeState(ctx, instance.NewAPI(client), zone, result.VolumeID)
+		if err != nil {
+			return nil, err
+		}
+		result.Size = volume.Size
+		result.State = volume.State.String()
+		return result, nil
+	}
+}
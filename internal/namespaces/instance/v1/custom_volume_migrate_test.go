@@ -0,0 +1,68 @@
+package instance
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_planVolumeReplacement(t *testing.T) {
+	volume := &instance.Volume{ID: "data", VolumeType: instance.VolumeVolumeTypeLSSD, Size: 20 * scw.GB}
+
+	t.Run("Unattached volume", func(t *testing.T) {
+		steps, err := planVolumeReplacement(&volumeReplacement{
+			volume:     volume,
+			volumeType: instance.VolumeVolumeTypeBSSD,
+			size:       volume.Size,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"create a snapshot of volume data",
+			"create a b_ssd volume from the snapshot",
+			"delete the snapshot",
+		}, steps)
+	})
+
+	t.Run("Resized volume of a stopped server", func(t *testing.T) {
+		steps, err := planVolumeReplacement(&volumeReplacement{
+			volume:     volume,
+			server:     &instance.Server{ID: "web", State: instance.ServerStateStopped},
+			volumeType: instance.VolumeVolumeTypeBSSD,
+			size:       50 * scw.GB,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"create a snapshot of volume data",
+			"create a b_ssd volume from the snapshot",
+			"resize the new volume to 50 GB",
+			"attach the new volume to server web in place of volume data",
+			"delete the snapshot",
+		}, steps)
+	})
+
+	t.Run("Running server", func(t *testing.T) {
+		_, err := planVolumeReplacement(&volumeReplacement{
+			volume:     volume,
+			server:     &instance.Server{ID: "web", State: instance.ServerStateRunning},
+			volumeType: instance.VolumeVolumeTypeBSSD,
+			size:       volume.Size,
+		})
+		assert.ErrorContains(t, err, "attached to server web which is running")
+	})
+}
+
+func Test_replacedServerVolumes(t *testing.T) {
+	server := &instance.Server{Volumes: map[string]*instance.VolumeServer{
+		"0": {ID: "root", Name: "web-root", Boot: true},
+		"1": {ID: "data", Name: "web-data"},
+	}}
+
+	volumes := *replacedServerVolumes(server, "data", "new-data")
+	assert.Equal(t, map[string]*instance.VolumeServerTemplate{
+		"0": {ID: scw.StringPtr("root"), Name: scw.StringPtr("web-root"), Boot: scw.BoolPtr(true)},
+		"1": {ID: scw.StringPtr("new-data"), Name: scw.StringPtr("web-data"), Boot: scw.BoolPtr(false)},
+	}, volumes)
+}
@@ -1,10 +1,13 @@
 package instance
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -19,6 +22,62 @@ func Test_ListServer(t *testing.T) {
 			core.TestCheckExitCode(0),
 		),
 	}))
+
+	// The secondary columns are hidden and the long names truncated to fit the width given by COLUMNS
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/instance/v1/zones/fr-par-1/servers", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Total-Count", "2")
+		_, _ = w.Write([]byte(`{"servers": [
+			{
+				"id": "088b01da-9ba7-40d2-bc55-eb3170f42185",
+				"name": "scw-production-database-replica-paris",
+				"commercial_type": "PRO2-S",
+				"state": "running",
+				"zone": "fr-par-1",
+				"public_ip": {"address": "51.15.251.251"},
+				"tags": ["production", "database"],
+				"image": {"id": "11111111-1111-1111-1111-111111111111", "name": "Ubuntu 22.04 Jammy Jellyfish"},
+				"creation_date": "2024-01-01T00:00:00Z",
+				"modification_date": "2024-01-02T00:00:00Z",
+				"security_group": {"id": "22222222-2222-2222-2222-222222222222", "name": "Default security group"},
+				"arch": "x86_64"
+			},
+			{
+				"id": "3b2b4a1e-56c7-4f5e-9d46-8f0b5e2c9a10",
+				"name": "scw-cool-franklin",
+				"commercial_type": "DEV1-S",
+				"state": "starting",
+				"zone": "fr-par-1",
+				"tags": [],
+				"image": {"id": "11111111-1111-1111-1111-111111111111", "name": "Ubuntu 22.04 Jammy Jellyfish"},
+				"creation_date": "2024-01-01T00:00:00Z",
+				"modification_date": "2024-01-02T00:00:00Z",
+				"security_group": {"id": "22222222-2222-2222-2222-222222222222", "name": "Default security group"},
+				"arch": "x86_64"
+			}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := scw.NewClient(
+		scw.WithAPIURL(server.URL),
+		scw.WithAuth("SCWXXXXXXXXXXXXXXXXX", "11111111-1111-1111-1111-111111111111"),
+		scw.WithDefaultZone(scw.ZoneFrPar1),
+		scw.WithDefaultProjectID("11111111-1111-1111-1111-111111111111"),
+	)
+	require.NoError(t, err)
+
+	t.Run("Narrow terminal", core.Test(&core.TestConfig{
+		Commands:    GetCommands(),
+		Cmd:         "scw instance server list",
+		OverrideEnv: map[string]string{"COLUMNS": "100"},
+		Client:      client,
+		Check: core.TestCheckCombine(
+			core.TestCheckGolden(),
+			core.TestCheckExitCode(0),
+		),
+	}))
 }
 
 func Test_GetServer(t *testing.T) {
@@ -53,7 +112,7 @@ func Test_CreateVolume(t *testing.T) {
 		Cmd:      "scw instance volume create name=test size=20",
 		Check: core.TestCheckCombine(
 			core.TestCheckGolden(),
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeUsage),
 		),
 	}))
 }
@@ -112,7 +171,7 @@ func Test_ServerUpdate(t *testing.T) {
 		BeforeFunc: createServerBionic("Server"),
 		Cmd:        `scw instance server update {{ .Server.ID }} placement-group-id=11111111-1111-1111-1111-111111111111`,
 		Check: core.TestCheckCombine(
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeNotFound),
 			core.TestCheckGolden(),
 		),
 		AfterFunc: deleteServer("Server"),
@@ -123,7 +182,7 @@ func Test_ServerUpdate(t *testing.T) {
 		BeforeFunc: createServerBionic("Server"),
 		Cmd:        `scw instance server update {{ .Server.ID }} placement-group-id=1111111`,
 		Check: core.TestCheckCombine(
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeUsage),
 			core.TestCheckGolden(),
 		),
 		AfterFunc: deleteServer("Server"),
@@ -8,7 +8,6 @@ import (
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	"github.com/scaleway/scaleway-sdk-go/api/iot/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const (
@@ -31,7 +30,7 @@ func hubCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForHub(&iot.WaitForHubRequest{
 			HubID:         respI.(*iot.Hub).ID,
 			Region:        respI.(*iot.Hub).Region,
-			Timeout:       scw.TimeDurationPtr(hubActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, hubActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
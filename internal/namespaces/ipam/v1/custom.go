@@ -5,5 +5,10 @@ import "github.com/scaleway/scaleway-cli/v2/internal/core"
 func GetCommands() *core.Commands {
 	cmds := GetGeneratedCommands()
 
+	cmds.Merge(core.NewCommands(
+		ipReserveCommand(),
+		ipFindCommand(),
+	))
+
 	return cmds
 }
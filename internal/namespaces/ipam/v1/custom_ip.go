@@ -0,0 +1,195 @@
+package ipam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type ipReserveRequest struct {
+	ProjectID        string
+	PrivateNetworkID string
+	Address          net.IP
+	Tags             []string
+	Region           scw.Region
+}
+
+type ipFindRequest struct {
+	Address      net.IP
+	ResourceName *string
+	MacAddress   *string
+	Region       scw.Region
+}
+
+func ipReserveCommand() *core.Command {
+	return &core.Command{
+		Short:     `Reserve a specific IP in a Private Network`,
+		Long:      `Book a chosen address of a Private Network so that it can later be attached to a resource. The call fails when the address is already booked.`,
+		Namespace: "ipam",
+		Resource:  "ip",
+		Verb:      "reserve",
+		ArgsType:  reflect.TypeOf(ipReserveRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			core.ProjectIDArgSpec(),
+			{
+				Name:     "private-network-id",
+				Short:    `Private Network in which to reserve the IP`,
+				Required: true,
+			},
+			{
+				Name:     "address",
+				Short:    `IP to reserve in the Private Network`,
+				Required: true,
+			},
+			{
+				Name:  "tags.{index}",
+				Short: `Tags for the IP`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: ipReserveRun,
+		Examples: []*core.Example{
+			{
+				Short: "Reserve an IP in a Private Network",
+				Raw:   "scw ipam ip reserve private-network-id=11111111-1111-1111-1111-111111111111 address=10.0.0.50",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Find which resource holds an IP",
+				Command: "scw ipam ip find",
+			},
+		},
+	}
+}
+
+func ipReserveRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*ipReserveRequest)
+
+	api := ipam.NewAPI(core.ExtractClient(ctx))
+	ip, err := api.BookIP(&ipam.BookIPRequest{
+		Region:    args.Region,
+		ProjectID: args.ProjectID,
+		Source: &ipam.Source{
+			PrivateNetworkID: &args.PrivateNetworkID,
+		},
+		Address: &args.Address,
+		Tags:    args.Tags,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		respErr := (*scw.ResponseError)(nil)
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusConflict {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("address %s is already booked in private network %s", args.Address, args.PrivateNetworkID),
+				Hint: fmt.Sprintf("Find the resource holding it with: scw ipam ip find address=%s region=%s", args.Address, args.Region),
+			}
+		}
+		return nil, err
+	}
+
+	return ip, nil
+}
+
+func ipFindCommand() *core.Command {
+	c := &core.Command{
+		Short: `Find the IPs held by a resource`,
+		Long: `Look up the IPs matching an address, a resource name or a MAC address, whatever the product holding them.
+All regions are searched unless a region is given.`,
+		Namespace: "ipam",
+		Resource:  "ip",
+		Verb:      "find",
+		ArgsType:  reflect.TypeOf(ipFindRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "address",
+				Short: `IP to look up`,
+			},
+			{
+				Name:  "resource-name",
+				Short: `Only IPs attached to a resource with this string within their name are returned`,
+			},
+			{
+				Name:  "mac-address",
+				Short: `Only IPs attached to a resource with this MAC address are returned`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw, scw.Region(core.AllLocalities)),
+		},
+		Run: ipFindRun,
+		Examples: []*core.Example{
+			{
+				Short: "Find the IPs of a database",
+				Raw:   "scw ipam ip find resource-name=my-db",
+			},
+			{
+				Short: "Find which resource holds an IP",
+				Raw:   "scw ipam ip find address=10.0.0.50",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List existing IPs",
+				Command: "scw ipam ip list",
+			},
+		},
+	}
+	c.ArgSpecs.GetByName("region").Short = "Region to search"
+	c.ArgSpecs.GetByName("region").Default = core.DefaultValueSetter(core.AllLocalities)
+
+	return c
+}
+
+func ipFindRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*ipFindRequest)
+
+	if args.Address == nil && args.ResourceName == nil && args.MacAddress == nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("nothing to find"),
+			Hint: "Use at least one of address, resource-name or mac-address",
+		}
+	}
+
+	api := ipam.NewAPI(core.ExtractClient(ctx))
+	request := &ipam.ListIPsRequest{
+		Region:       args.Region,
+		ResourceName: args.ResourceName,
+		MacAddress:   args.MacAddress,
+	}
+	opts := []scw.RequestOption{scw.WithAllPages(), scw.WithContext(ctx)}
+	if request.Region == scw.Region(core.AllLocalities) {
+		opts = append(opts, scw.WithRegions(api.Regions()...))
+		request.Region = ""
+	}
+	// The API cannot filter on an address, IPs are filtered once listed.
+	if args.Address != nil {
+		request.IsIPv6 = scw.BoolPtr(args.Address.To4() == nil)
+	}
+
+	resp, err := api.ListIPs(request, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterIPsByAddress(resp.IPs, args.Address), nil
+}
+
+func filterIPsByAddress(ips []*ipam.IP, address net.IP) []*ipam.IP {
+	if address == nil {
+		return ips
+	}
+
+	filtered := []*ipam.IP(nil)
+	for _, ip := range ips {
+		if ip.Address.IP.Equal(address) {
+			filtered = append(filtered, ip)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,25 @@
+package ipam
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterIPsByAddress(t *testing.T) {
+	ips := []*ipam.IP{
+		{ID: "1", Address: scw.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.0.0.50"), Mask: net.CIDRMask(22, 32)}}},
+		{ID: "2", Address: scw.IPNet{IPNet: net.IPNet{IP: net.ParseIP("10.0.0.51"), Mask: net.CIDRMask(22, 32)}}},
+	}
+
+	assert.Equal(t, ips, filterIPsByAddress(ips, nil))
+
+	filtered := filterIPsByAddress(ips, net.ParseIP("10.0.0.50"))
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "1", filtered[0].ID)
+
+	assert.Empty(t, filterIPsByAddress(ips, net.ParseIP("10.0.0.52")))
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func definitionStartBuilder(c *core.Command) *core.Command {
-	c.WaitUsage = "Wait until the job reach a stable state, use job definition timeout"
+	c.WaitUsage = "Wait until the job reach a stable state, use job definition timeout. Exit with the job exit code if it fails"
 	c.WaitFunc = func(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
 		api := jobs.NewAPI(core.ExtractClient(ctx))
 		args := argsI.(*jobs.StartJobDefinitionRequest)
@@ -24,12 +24,22 @@ func definitionStartBuilder(c *core.Command) *core.Command {
 			return nil, fmt.Errorf("failed to fetch job definition for timeout: %w", err)
 		}
 
-		return api.WaitForJobRun(&jobs.WaitForJobRunRequest{
+		jobRun, err := api.WaitForJobRun(&jobs.WaitForJobRunRequest{
 			Region:        args.Region,
 			JobRunID:      resp.ID,
 			Timeout:       jobDefinition.JobTimeout.ToTimeDuration(),
 			RetryInterval: core.DefaultRetryInterval,
 		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = jobRunExitError(jobRun)
+		if err != nil {
+			return nil, err
+		}
+
+		return jobRun, nil
 	}
 
 	return c
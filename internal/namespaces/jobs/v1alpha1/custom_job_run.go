@@ -2,6 +2,7 @@ package jobs
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
@@ -11,8 +12,9 @@ import (
 
 func jobsRunWait() *core.Command {
 	return &core.Command{
-		Short:     `Wait for a job run to reach a stable state`,
-		Long:      `Wait for a job run to reach a stable state. This is similar to using --wait flag.`,
+		Short: `Wait for a job run to reach a stable state`,
+		Long: `Wait for a job run to reach a stable state. This is similar to using --wait flag.
+If the job run does not succeed, the command exits with the exit code of the job run, or with code 7 when it has none between 1 and 255.`,
 		Namespace: "jobs",
 		Resource:  "run",
 		Verb:      "wait",
@@ -32,7 +34,40 @@ func jobsRunWait() *core.Command {
 
 			client := core.ExtractClient(ctx)
 			api := jobs.NewAPI(client)
-			return api.WaitForJobRun(request)
+			jobRun, err := api.WaitForJobRun(request)
+			if err != nil {
+				return nil, err
+			}
+
+			err = jobRunExitError(jobRun)
+			if err != nil {
+				return nil, err
+			}
+
+			return jobRun, nil
 		},
 	}
 }
+
+// jobRunExitError returns an error with the exit code of a terminated job run if it did not succeed.
+// This allows to fail CI pipelines when a job run fails.
+func jobRunExitError(jobRun *jobs.JobRun) error {
+	if jobRun.State != jobs.JobRunStateFailed && jobRun.State != jobs.JobRunStateCanceled {
+		return nil
+	}
+
+	cliErr := &core.CliError{
+		Err:     fmt.Errorf("job run %s is %s", jobRun.ID, jobRun.State),
+		Details: jobRun.ErrorMessage,
+		Code:    core.ExitCodeJobFailed,
+	}
+	if jobRun.ExitCode != nil {
+		cliErr.Err = fmt.Errorf("job run %s is %s with exit code %d", jobRun.ID, jobRun.State, *jobRun.ExitCode)
+		// Exit codes of processes are between 0 and 255, other exit codes keep the job_failed exit code
+		if *jobRun.ExitCode > 0 && *jobRun.ExitCode <= 255 {
+			cliErr.ExitCode = int(*jobRun.ExitCode)
+		}
+	}
+
+	return cliErr
+}
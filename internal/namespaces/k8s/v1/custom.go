@@ -19,9 +19,15 @@ func GetCommands() *core.Commands {
 		k8sKubeconfigGetCommand(),
 		k8sKubeconfigInstallCommand(),
 		k8sKubeconfigUninstallCommand(),
+		k8sCITokenCommand(),
+		k8sCITokenCreateCommand(),
+		k8sCITokenRevokeCommand(),
+		k8sHelmValuesCommand(),
+		k8sHelmValuesGenerateCommand(),
 		k8sClusterWaitCommand(),
 		k8sNodeWaitCommand(),
 		k8sPoolWaitCommand(),
+		k8sClusterEventsCommand(),
 	))
 
 	human.RegisterMarshalerFunc(k8s.Version{}, versionMarshalerFunc)
@@ -34,6 +40,7 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("k8s", "cluster", "list-available-versions").Override(clusterAvailableVersionsListBuilder)
 	cmds.MustFind("k8s", "cluster", "create").Override(clusterCreateBuilder)
 	cmds.MustFind("k8s", "cluster", "get").Override(clusterGetBuilder)
+	cmds.MustFind("k8s", "cluster", "list").Override(clusterListBuilder)
 	cmds.MustFind("k8s", "cluster", "update").Override(clusterUpdateBuilder)
 	cmds.MustFind("k8s", "cluster", "upgrade").Override(clusterUpgradeBuilder)
 	cmds.MustFind("k8s", "cluster", "delete").Override(clusterDeleteBuilder)
@@ -0,0 +1,404 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/ghodss/yaml"
+	api "github.com/kubernetes-client/go-base/config/api"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// ciTokenMinTTL is the shortest expiration accepted by the Kubernetes TokenRequest API.
+	ciTokenMinTTL = 10 * time.Minute
+	ciTokenMaxTTL = 24 * time.Hour
+)
+
+type k8sCITokenCreateRequest struct {
+	ClusterID      string
+	Namespace      string
+	ServiceAccount string
+	Role           string
+	TTL            time.Duration
+	Region         scw.Region
+}
+
+type k8sCITokenRevokeRequest struct {
+	ClusterID      string
+	Namespace      string
+	ServiceAccount string
+	Region         scw.Region
+}
+
+// ciTokenClient calls the API server of a cluster with the credentials of its admin kubeconfig.
+type ciTokenClient struct {
+	httpClient *http.Client
+	server     string
+	token      string
+}
+
+func k8sCITokenCommand() *core.Command {
+	return &core.Command{
+		Short: `Manage short-lived credentials for CI pipelines`,
+		Long: `Manage short-lived credentials for CI pipelines.
+A namespaced ServiceAccount is bound to a role and a token expiring after a short time is minted for it, so that the cluster-admin kubeconfig is kept out of pipelines.`,
+		Namespace: "k8s",
+		Resource:  "ci-token",
+	}
+}
+
+func k8sCITokenCreateCommand() *core.Command {
+	return &core.Command{
+		Short: `Create a short-lived kubeconfig for CI`,
+		Long: `Create a ServiceAccount in a namespace, bind it to a ClusterRole in this namespace and output a minimal kubeconfig holding a token expiring after the given TTL.
+The ServiceAccount and its RoleBinding are reused when they already exist. The TTL must be between 10m and 24h.`,
+		Namespace: "k8s",
+		Resource:  "ci-token",
+		Verb:      "create",
+		ArgsType:  reflect.TypeOf(k8sCITokenCreateRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "cluster-id",
+				Short:      "Cluster ID on which to create the ServiceAccount",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "namespace",
+				Short:   "Namespace of the ServiceAccount, it must already exist",
+				Default: core.DefaultValueSetter("default"),
+			},
+			{
+				Name:    "service-account",
+				Short:   "Name of the ServiceAccount",
+				Default: core.DefaultValueSetter("ci"),
+			},
+			{
+				Name:    "role",
+				Short:   "ClusterRole granted to the ServiceAccount in the namespace",
+				Default: core.DefaultValueSetter("edit"),
+			},
+			{
+				Name:    "ttl",
+				Short:   "Lifetime of the token",
+				Default: core.DefaultValueSetter("1h"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: k8sCITokenCreateRun,
+		Examples: []*core.Example{
+			{
+				Short: "Create a kubeconfig valid for one hour in the default namespace",
+				Raw:   "scw k8s ci-token create 11111111-1111-1111-1111-111111111111 > ci-kubeconfig.yaml",
+			},
+			{
+				Short: "Create a read-only kubeconfig valid for 30 minutes in the staging namespace",
+				Raw:   "scw k8s ci-token create 11111111-1111-1111-1111-111111111111 namespace=staging service-account=ci-reader role=view ttl=30m",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw k8s ci-token revoke",
+				Short:   "Revoke the tokens of a CI ServiceAccount",
+			},
+		},
+	}
+}
+
+func k8sCITokenRevokeCommand() *core.Command {
+	return &core.Command{
+		Short: `Revoke the tokens of a CI ServiceAccount`,
+		Long: `Delete a ServiceAccount and its RoleBinding created by ci-token create.
+Every token minted for the ServiceAccount stops being valid once it is deleted.`,
+		Namespace: "k8s",
+		Resource:  "ci-token",
+		Verb:      "revoke",
+		ArgsType:  reflect.TypeOf(k8sCITokenRevokeRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "cluster-id",
+				Short:      "Cluster ID on which to delete the ServiceAccount",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "namespace",
+				Short:   "Namespace of the ServiceAccount",
+				Default: core.DefaultValueSetter("default"),
+			},
+			{
+				Name:    "service-account",
+				Short:   "Name of the ServiceAccount",
+				Default: core.DefaultValueSetter("ci"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: k8sCITokenRevokeRun,
+		Examples: []*core.Example{
+			{
+				Short: "Revoke the tokens of the default CI ServiceAccount",
+				Raw:   "scw k8s ci-token revoke 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw k8s ci-token create",
+				Short:   "Create a short-lived kubeconfig for CI",
+			},
+		},
+	}
+}
+
+func k8sCITokenCreateRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	request := argsI.(*k8sCITokenCreateRequest)
+
+	if request.TTL < ciTokenMinTTL || request.TTL > ciTokenMaxTTL {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("invalid ttl %s", request.TTL),
+			Hint: fmt.Sprintf("The ttl must be between %s and %s", ciTokenMinTTL, ciTokenMaxTTL),
+		}
+	}
+
+	kubeconfig, err := getAdminKubeconfig(ctx, request.ClusterID, request.Region)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newCITokenClient(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccountPath := fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts", request.Namespace)
+	err = client.do(ctx, http.MethodPost, serviceAccountPath, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ServiceAccount",
+		"metadata": map[string]interface{}{
+			"name":      request.ServiceAccount,
+			"namespace": request.Namespace,
+		},
+	}, nil, http.StatusConflict)
+	if err != nil {
+		return nil, err
+	}
+
+	// The role of a RoleBinding cannot be updated so an existing binding is replaced.
+	roleBindingPath := fmt.Sprintf("/apis/rbac.authorization.k8s.io/v1/namespaces/%s/rolebindings", request.Namespace)
+	err = client.do(ctx, http.MethodDelete, roleBindingPath+"/"+request.ServiceAccount, nil, nil, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	err = client.do(ctx, http.MethodPost, roleBindingPath, map[string]interface{}{
+		"apiVersion": "rbac.authorization.k8s.io/v1",
+		"kind":       "RoleBinding",
+		"metadata": map[string]interface{}{
+			"name":      request.ServiceAccount,
+			"namespace": request.Namespace,
+		},
+		"roleRef": map[string]interface{}{
+			"apiGroup": "rbac.authorization.k8s.io",
+			"kind":     "ClusterRole",
+			"name":     request.Role,
+		},
+		"subjects": []map[string]interface{}{
+			{
+				"kind":      "ServiceAccount",
+				"name":      request.ServiceAccount,
+				"namespace": request.Namespace,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenRequest := struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}{}
+	err = client.do(ctx, http.MethodPost, serviceAccountPath+"/"+request.ServiceAccount+"/token", map[string]interface{}{
+		"apiVersion": "authentication.k8s.io/v1",
+		"kind":       "TokenRequest",
+		"spec": map[string]interface{}{
+			"expirationSeconds": int64(request.TTL.Seconds()),
+		},
+	}, &tokenRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	ciKubeconfig, err := buildCITokenKubeconfig(kubeconfig, request.Namespace, request.ServiceAccount, tokenRequest.Status.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := yaml.Marshal(ciKubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(config), nil
+}
+
+func k8sCITokenRevokeRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	request := argsI.(*k8sCITokenRevokeRequest)
+
+	kubeconfig, err := getAdminKubeconfig(ctx, request.ClusterID, request.Region)
+	if err != nil {
+		return nil, err
+	}
+	client, err := newCITokenClient(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.do(ctx, http.MethodDelete, fmt.Sprintf("/apis/rbac.authorization.k8s.io/v1/namespaces/%s/rolebindings/%s", request.Namespace, request.ServiceAccount), nil, nil, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	err = client.do(ctx, http.MethodDelete, fmt.Sprintf("/api/v1/namespaces/%s/serviceaccounts/%s", request.Namespace, request.ServiceAccount), nil, nil, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("ServiceAccount %s/%s and its tokens have been revoked", request.Namespace, request.ServiceAccount),
+	}, nil
+}
+
+func getAdminKubeconfig(ctx context.Context, clusterID string, region scw.Region) (*api.Config, error) {
+	apiK8s := k8s.NewAPI(core.ExtractClient(ctx))
+	apiKubeconfig, err := apiK8s.GetClusterKubeConfig(&k8s.GetClusterKubeConfigRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var kubeconfig api.Config
+	err = yaml.Unmarshal(apiKubeconfig.GetRaw(), &kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	if len(kubeconfig.Clusters) == 0 || len(kubeconfig.AuthInfos) == 0 {
+		return nil, fmt.Errorf("kubeconfig of cluster %s has no cluster or user", clusterID)
+	}
+
+	return &kubeconfig, nil
+}
+
+func newCITokenClient(kubeconfig *api.Config) (*ciTokenClient, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caData := kubeconfig.Clusters[0].Cluster.CertificateAuthorityData; len(caData) > 0 {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("cannot parse the certificate authority of cluster %s", kubeconfig.Clusters[0].Name)
+		}
+	}
+
+	return &ciTokenClient{
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+		server: kubeconfig.Clusters[0].Cluster.Server,
+		token:  kubeconfig.AuthInfos[0].AuthInfo.Token,
+	}, nil
+}
+
+// do sends a request to the API server and decodes the response in result when not nil.
+// Responses with one of the ignoredStatus are not considered as errors.
+func (c *ciTokenClient) do(ctx context.Context, method string, path string, body interface{}, result interface{}, ignoredStatus ...int) error {
+	var reqBody io.Reader
+	if body != nil {
+		rawBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(rawBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.server+path, reqBody)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range ignoredStatus {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiStatus := struct {
+			Message string `json:"message"`
+		}{}
+		_ = json.NewDecoder(resp.Body).Decode(&apiStatus)
+		return fmt.Errorf("%s %s: %s: %s", method, path, resp.Status, apiStatus.Message)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// buildCITokenKubeconfig returns a kubeconfig with the cluster of the admin kubeconfig and a ServiceAccount token.
+func buildCITokenKubeconfig(kubeconfig *api.Config, namespace string, serviceAccount string, token string) (*api.Config, error) {
+	if token == "" {
+		return nil, fmt.Errorf("no token returned for ServiceAccount %s/%s", namespace, serviceAccount)
+	}
+
+	cluster := kubeconfig.Clusters[0]
+	user := fmt.Sprintf("%s-%s", cluster.Name, serviceAccount)
+
+	return &api.Config{
+		APIVersion: "v1",
+		Kind:       "Config",
+		Clusters: []api.NamedCluster{
+			{
+				Name: cluster.Name,
+				Cluster: api.Cluster{
+					Server:                   cluster.Cluster.Server,
+					CertificateAuthorityData: cluster.Cluster.CertificateAuthorityData,
+				},
+			},
+		},
+		AuthInfos: []api.NamedAuthInfo{
+			{
+				Name:     user,
+				AuthInfo: api.AuthInfo{Token: token},
+			},
+		},
+		Contexts: []api.NamedContext{
+			{
+				Name: user,
+				Context: api.Context{
+					Cluster:   cluster.Name,
+					AuthInfo:  user,
+					Namespace: namespace,
+				},
+			},
+		},
+		CurrentContext: user,
+	}, nil
+}
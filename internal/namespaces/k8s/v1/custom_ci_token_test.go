@@ -0,0 +1,73 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	api "github.com/kubernetes-client/go-base/config/api"
+)
+
+func Test_buildCITokenKubeconfig(t *testing.T) {
+	admin := &api.Config{
+		Clusters: []api.NamedCluster{
+			{
+				Name: "my-cluster",
+				Cluster: api.Cluster{
+					Server:                   "https://11111111-1111-1111-1111-111111111111.api.k8s.fr-par.scw.cloud:6443",
+					CertificateAuthorityData: []byte("ca"),
+				},
+			},
+		},
+		AuthInfos: []api.NamedAuthInfo{
+			{Name: "my-cluster-admin", AuthInfo: api.AuthInfo{Token: "admin-token"}},
+		},
+	}
+
+	config, err := buildCITokenKubeconfig(admin, "staging", "ci", "ci-token")
+	assert.NoError(t, err)
+	assert.Equal(t, admin.Clusters, config.Clusters)
+	assert.Equal(t, []api.NamedAuthInfo{{Name: "my-cluster-ci", AuthInfo: api.AuthInfo{Token: "ci-token"}}}, config.AuthInfos)
+	assert.Equal(t, "my-cluster-ci", config.CurrentContext)
+	assert.Equal(t, "staging", config.Contexts[0].Context.Namespace)
+
+	_, err = buildCITokenKubeconfig(admin, "staging", "ci", "")
+	assert.Error(t, err)
+}
+
+func Test_ciTokenClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer admin-token", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/api/v1/namespaces/default/serviceaccounts":
+			w.WriteHeader(http.StatusConflict)
+		case "/api/v1/namespaces/default/serviceaccounts/ci/token":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": map[string]string{"token": "ci-token"}})
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "forbidden"})
+		}
+	}))
+	defer server.Close()
+
+	client := &ciTokenClient{httpClient: server.Client(), server: server.URL, token: "admin-token"}
+	ctx := context.Background()
+
+	err := client.do(ctx, http.MethodPost, "/api/v1/namespaces/default/serviceaccounts", map[string]string{}, nil, http.StatusConflict)
+	assert.NoError(t, err)
+
+	result := struct {
+		Status struct {
+			Token string `json:"token"`
+		} `json:"status"`
+	}{}
+	err = client.do(ctx, http.MethodPost, "/api/v1/namespaces/default/serviceaccounts/ci/token", map[string]string{}, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, "ci-token", result.Status.Token)
+
+	err = client.do(ctx, http.MethodGet, "/api/v1/namespaces", nil, nil)
+	assert.EqualError(t, err, "GET /api/v1/namespaces: 403 Forbidden: forbidden")
+}
@@ -232,6 +232,66 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 	return c
 }
 
+// clusterListBuilder hides the secondary columns first and truncates long values when the list does not fit in the terminal
+func clusterListBuilder(c *core.Command) *core.Command {
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{
+				FieldName: "ID",
+			},
+			{
+				FieldName: "Name",
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Status",
+			},
+			{
+				FieldName: "Version",
+			},
+			{
+				FieldName: "Region",
+			},
+			{
+				FieldName: "ProjectID",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Tags",
+				Priority:  -2,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Cni",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Description",
+				Priority:  -1,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "ClusterURL",
+				Priority:  -1,
+			},
+			{
+				FieldName: "CreatedAt",
+				Priority:  -2,
+			},
+			{
+				FieldName: "UpdatedAt",
+				Priority:  -2,
+			},
+			{
+				FieldName: "Type",
+				Priority:  -1,
+			},
+		},
+	}
+
+	return c
+}
+
 func clusterGetBuilder(c *core.Command) *core.Command {
 	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
 		res, err := runner(ctx, argsI)
@@ -314,9 +374,47 @@ func clusterGetBuilder(c *core.Command) *core.Command {
 
 func clusterDeleteBuilder(c *core.Command) *core.Command {
 	c.WaitFunc = waitForClusterFunc(clusterActionDelete)
+	c.DeletePreview = clusterDeletePreview
 	return c
 }
 
+// clusterDeletePreview lists the pools deleted with a cluster.
+// Additional resources deleted with with-additional-resources are not listed as they are found by name when the cluster is deleted.
+func clusterDeletePreview(ctx context.Context, argsI interface{}) (*core.DeletePreview, error) {
+	args := argsI.(*k8s.DeleteClusterRequest)
+	api := k8s.NewAPI(core.ExtractClient(ctx))
+
+	cluster, err := api.GetCluster(&k8s.GetClusterRequest{
+		Region:    args.Region,
+		ClusterID: args.ClusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	pools, err := api.ListPools(&k8s.ListPoolsRequest{
+		Region:    args.Region,
+		ClusterID: args.ClusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &core.DeletePreviewResource{
+		Type: "cluster",
+		ID:   cluster.ID,
+		Name: cluster.Name,
+	}
+	for _, pool := range pools.Pools {
+		resource.Dependents = append(resource.Dependents, &core.DeletePreviewResource{
+			Type: "pool",
+			ID:   pool.ID,
+			Name: fmt.Sprintf("%s (%d nodes)", pool.Name, pool.Size),
+		})
+	}
+
+	return &core.DeletePreview{Resources: []*core.DeletePreviewResource{resource}}, nil
+}
+
 func clusterUpgradeBuilder(c *core.Command) *core.Command {
 	c.WaitFunc = waitForClusterFunc(clusterActionUpgrade)
 	return c
@@ -341,7 +439,7 @@ func waitForClusterFunc(action int) core.WaitFunc {
 		cluster, err := k8s.NewAPI(core.ExtractClient(ctx)).WaitForCluster(&k8s.WaitForClusterRequest{
 			Region:        clusterResponse.Region,
 			ClusterID:     clusterResponse.ID,
-			Timeout:       scw.TimeDurationPtr(clusterActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, clusterActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		switch action {
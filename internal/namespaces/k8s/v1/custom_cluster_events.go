@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type k8sClusterEventsRequest struct {
+	ClusterID string
+	Follow    bool
+	Region    scw.Region
+}
+
+func k8sClusterEventsCommand() *core.Command {
+	return &core.Command{
+		Short: `Show the recent activity of a cluster`,
+		Long: `Show the recent activity of a Kubernetes cluster, of its pools and of its nodes: their creation, their last update and their current status.
+With follow, the cluster is polled and new events are printed as they come until the command is interrupted, to see what the platform is doing during long operations such as upgrades.`,
+		Namespace: "k8s",
+		Resource:  "cluster",
+		Verb:      "events",
+		ArgsType:  reflect.TypeOf(k8sClusterEventsRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "cluster-id",
+				Short:      `ID of the cluster`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "follow",
+				Short: `Keep printing new events`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*k8sClusterEventsRequest)
+			api := k8s.NewAPI(core.ExtractClient(ctx))
+
+			return core.RunEvents(ctx, func(ctx context.Context) ([]*core.ResourceEvent, error) {
+				return listClusterEvents(ctx, api, args.Region, args.ClusterID)
+			}, args.Follow)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Show the recent activity of a cluster",
+				Raw:   "scw k8s cluster events 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Follow the activity of a cluster during an upgrade",
+				Raw:   "scw k8s cluster events 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Wait for a cluster to reach a stable state",
+				Command: "scw k8s cluster wait",
+			},
+		},
+	}
+}
+
+func listClusterEvents(ctx context.Context, api *k8s.API, region scw.Region, clusterID string) ([]*core.ResourceEvent, error) {
+	cluster, err := api.GetCluster(&k8s.GetClusterRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	events := core.NewResourceEvents("cluster", cluster.ID, cluster.CreatedAt, cluster.UpdatedAt, cluster.Status.String(), "")
+
+	pools, err := api.ListPools(&k8s.ListPoolsRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools.Pools {
+		events = append(events, core.NewResourceEvents("pool", pool.ID, pool.CreatedAt, pool.UpdatedAt, pool.Status.String(), pool.Name)...)
+	}
+
+	nodes, err := api.ListNodes(&k8s.ListNodesRequest{
+		Region:    region,
+		ClusterID: clusterID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes.Nodes {
+		detail := node.Name
+		if node.ErrorMessage != nil && *node.ErrorMessage != "" {
+			detail += ": " + *node.ErrorMessage
+		}
+		events = append(events, core.NewResourceEvents("node", node.ID, node.CreatedAt, node.UpdatedAt, node.Status.String(), detail)...)
+	}
+
+	return events, nil
+}
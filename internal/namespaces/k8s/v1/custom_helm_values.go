@@ -0,0 +1,286 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"text/template"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	helmValuesIntegrationExternalDNS        = "external-dns"
+	helmValuesIntegrationCertManagerWebhook = "cert-manager-webhook"
+	helmValuesIntegrationCertManagerIssuer  = "cert-manager-issuer"
+	helmValuesIntegrationCSICCM             = "csi-ccm"
+
+	helmValuesAccessKeyPlaceholder = "<SCW_ACCESS_KEY>"
+	helmValuesSecretKeyPlaceholder = "<SCW_SECRET_KEY>"
+)
+
+var helmValuesTemplates = map[string]*template.Template{
+	helmValuesIntegrationExternalDNS: template.Must(template.New(helmValuesIntegrationExternalDNS).Parse(`# helm repo add external-dns https://kubernetes-sigs.github.io/external-dns/
+# helm upgrade --install external-dns external-dns/external-dns --values values.yaml
+provider:
+  name: scaleway
+env:
+  - name: SCW_ACCESS_KEY
+    value: {{ printf "%q" .AccessKey }}
+  - name: SCW_SECRET_KEY
+    value: {{ printf "%q" .SecretKey }}
+  - name: SCW_DEFAULT_ORGANIZATION_ID
+    value: {{ printf "%q" .OrganizationID }}
+{{- if .Domain }}
+domainFilters:
+  - {{ .Domain }}
+{{- end }}
+{{- if .ClusterID }}
+txtOwnerId: {{ .ClusterID }}
+{{- end }}
+`)),
+	helmValuesIntegrationCertManagerWebhook: template.Must(template.New(helmValuesIntegrationCertManagerWebhook).Parse(`# helm repo add scaleway https://helm.scw.cloud/
+# helm upgrade --install scaleway-certmanager-webhook scaleway/scaleway-certmanager-webhook --namespace cert-manager --values values.yaml
+secret:
+  accessKey: {{ printf "%q" .AccessKey }}
+  secretKey: {{ printf "%q" .SecretKey }}
+`)),
+	helmValuesIntegrationCertManagerIssuer: template.Must(template.New(helmValuesIntegrationCertManagerIssuer).Parse(`# kubectl apply --filename cluster-issuer.yaml
+apiVersion: cert-manager.io/v1
+kind: ClusterIssuer
+metadata:
+  name: letsencrypt-scaleway
+spec:
+  acme:
+    email: {{ printf "%q" .Email }}
+    server: https://acme-v02.api.letsencrypt.org/directory
+    privateKeySecretRef:
+      name: letsencrypt-scaleway-account-key
+    solvers:
+      - dns01:
+          webhook:
+            groupName: acme.scaleway.com
+            solverName: scaleway
+{{- if .Domain }}
+        selector:
+          dnsZones:
+            - {{ .Domain }}
+{{- end }}
+`)),
+	helmValuesIntegrationCSICCM: template.Must(template.New(helmValuesIntegrationCSICCM).Parse(`# kubectl apply --filename scaleway-secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: scaleway-secret
+  namespace: kube-system
+type: Opaque
+stringData:
+  SCW_ACCESS_KEY: {{ printf "%q" .AccessKey }}
+  SCW_SECRET_KEY: {{ printf "%q" .SecretKey }}
+  SCW_DEFAULT_PROJECT_ID: {{ printf "%q" .ProjectID }}
+  SCW_DEFAULT_REGION: {{ printf "%q" .Region }}
+  SCW_DEFAULT_ZONE: {{ printf "%q" .Zone }}
+`)),
+}
+
+type k8sHelmValuesGenerateRequest struct {
+	Integration string
+	ClusterID   string
+	SecretID    string
+	Domain      string
+	Email       string
+	Region      scw.Region
+}
+
+// helmValuesData holds the values rendered in the templates of the integrations.
+type helmValuesData struct {
+	ClusterID      string
+	OrganizationID string
+	ProjectID      string
+	Region         scw.Region
+	Zone           scw.Zone
+	AccessKey      string
+	SecretKey      string
+	Domain         string
+	Email          string
+}
+
+// helmValuesCredentials is the expected content of the Secret Manager secret holding the API key of an integration.
+type helmValuesCredentials struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+func k8sHelmValuesCommand() *core.Command {
+	return &core.Command{
+		Short:     `Generate Helm values for Scaleway integrations`,
+		Long:      `Generate ready-to-use Helm values and manifests to run common Scaleway integrations in a Kubernetes cluster.`,
+		Namespace: "k8s",
+		Resource:  "helm-values",
+	}
+}
+
+func k8sHelmValuesGenerateCommand() *core.Command {
+	return &core.Command{
+		Short: `Generate Helm values for a Scaleway integration`,
+		Long: `Generate the Helm values or the manifest of a Scaleway integration, pre-filled with the IDs of the cluster and the API key stored in a Secret Manager secret:
+  - external-dns: values of the external-dns chart using the Scaleway DNS provider.
+  - cert-manager-webhook: values of the Scaleway cert-manager webhook chart.
+  - cert-manager-issuer: ClusterIssuer solving ACME DNS-01 challenges with the Scaleway cert-manager webhook.
+  - csi-ccm: scaleway-secret Secret read by the Scaleway CSI driver and Cloud Controller Manager.
+
+The secret must contain a JSON object with the access_key and secret_key of the API key, its latest enabled version is used.
+Without secret-id, placeholders are written instead of the API key.`,
+		Namespace: "k8s",
+		Resource:  "helm-values",
+		Verb:      "generate",
+		ArgsType:  reflect.TypeOf(k8sHelmValuesGenerateRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "integration",
+				Short:      "Integration to generate the values of",
+				Required:   true,
+				Positional: true,
+				EnumValues: []string{
+					helmValuesIntegrationExternalDNS,
+					helmValuesIntegrationCertManagerWebhook,
+					helmValuesIntegrationCertManagerIssuer,
+					helmValuesIntegrationCSICCM,
+				},
+			},
+			{
+				Name:  "cluster-id",
+				Short: "Cluster ID the integration runs on, its project is used instead of the default one",
+			},
+			{
+				Name:  "secret-id",
+				Short: "ID of the Secret Manager secret holding the access_key and secret_key of the API key used by the integration",
+			},
+			{
+				Name:  "domain",
+				Short: "DNS zone managed by external-dns or solving the challenges of cert-manager",
+			},
+			{
+				Name:  "email",
+				Short: "Email of the ACME account of the cert-manager ClusterIssuer",
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: k8sHelmValuesGenerateRun,
+		Examples: []*core.Example{
+			{
+				Short: "Install external-dns for a domain with the API key of a secret",
+				Raw:   "scw k8s helm-values generate external-dns cluster-id=11111111-1111-1111-1111-111111111111 secret-id=22222222-2222-2222-2222-222222222222 domain=example.com > values.yaml\nhelm upgrade --install external-dns external-dns/external-dns --values values.yaml",
+			},
+			{
+				Short: "Generate the ClusterIssuer of cert-manager",
+				Raw:   "scw k8s helm-values generate cert-manager-issuer email=admin@example.com | kubectl apply -f -",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw secret version create",
+				Short:   "Store an API key in Secret Manager",
+			},
+			{
+				Command: "scw k8s kubeconfig install",
+				Short:   "Install the kubeconfig of a cluster",
+			},
+		},
+	}
+}
+
+func k8sHelmValuesGenerateRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	request := argsI.(*k8sHelmValuesGenerateRequest)
+	client := core.ExtractClient(ctx)
+
+	if request.Integration == helmValuesIntegrationCertManagerIssuer && request.Email == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("an email is required by the ACME account of the ClusterIssuer"),
+			Hint: "Use email=<email> to receive the expiration notices of Let's Encrypt",
+		}
+	}
+
+	data := &helmValuesData{
+		Region:    request.Region,
+		Zone:      scw.Zone(request.Region.String() + "-1"),
+		AccessKey: helmValuesAccessKeyPlaceholder,
+		SecretKey: helmValuesSecretKeyPlaceholder,
+		Domain:    request.Domain,
+		Email:     request.Email,
+	}
+	data.OrganizationID, _ = client.GetDefaultOrganizationID()
+	data.ProjectID, _ = client.GetDefaultProjectID()
+	if zone, exists := client.GetDefaultZone(); exists {
+		if region, err := zone.Region(); err == nil && region == request.Region {
+			data.Zone = zone
+		}
+	}
+
+	if request.ClusterID != "" {
+		cluster, err := k8s.NewAPI(client).GetCluster(&k8s.GetClusterRequest{
+			Region:    request.Region,
+			ClusterID: request.ClusterID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		data.ClusterID = cluster.ID
+		data.OrganizationID = cluster.OrganizationID
+		data.ProjectID = cluster.ProjectID
+	}
+
+	if request.SecretID != "" {
+		credentials, err := accessHelmValuesCredentials(ctx, client, request.Region, request.SecretID)
+		if err != nil {
+			return nil, err
+		}
+		data.AccessKey = credentials.AccessKey
+		data.SecretKey = credentials.SecretKey
+	}
+
+	return renderHelmValues(request.Integration, data)
+}
+
+// accessHelmValuesCredentials reads the API key stored in the latest enabled version of a secret.
+func accessHelmValuesCredentials(ctx context.Context, client *scw.Client, region scw.Region, secretID string) (*helmValuesCredentials, error) {
+	version, err := secret.NewAPI(client).AccessSecretVersion(&secret.AccessSecretVersionRequest{
+		Region:   region,
+		SecretID: secretID,
+		Revision: "latest_enabled",
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := &helmValuesCredentials{}
+	err = json.Unmarshal(version.Data, credentials)
+	if err != nil || credentials.AccessKey == "" || credentials.SecretKey == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("secret %s does not hold an API key", secretID),
+			Hint: `The secret must contain a JSON object such as {"access_key":"SCWXXXXXXXXXXXXXXXXX","secret_key":"11111111-1111-1111-1111-111111111111"}`,
+		}
+	}
+
+	return credentials, nil
+}
+
+func renderHelmValues(integration string, data *helmValuesData) (string, error) {
+	tpl, exists := helmValuesTemplates[integration]
+	if !exists {
+		return "", fmt.Errorf("unknown integration %s", integration)
+	}
+
+	buf := &bytes.Buffer{}
+	err := tpl.Execute(buf, data)
+	if err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
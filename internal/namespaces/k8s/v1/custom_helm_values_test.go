@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/ghodss/yaml"
+)
+
+func Test_renderHelmValues(t *testing.T) {
+	data := &helmValuesData{
+		ClusterID:      "11111111-1111-1111-1111-111111111111",
+		OrganizationID: "22222222-2222-2222-2222-222222222222",
+		ProjectID:      "33333333-3333-3333-3333-333333333333",
+		Region:         "fr-par",
+		Zone:           "fr-par-2",
+		AccessKey:      "SCWXXXXXXXXXXXXXXXXX",
+		SecretKey:      "44444444-4444-4444-4444-444444444444",
+		Domain:         "example.com",
+		Email:          "admin@example.com",
+	}
+
+	t.Run("external-dns", func(t *testing.T) {
+		values, err := renderHelmValues(helmValuesIntegrationExternalDNS, data)
+		assert.NoError(t, err)
+
+		parsed := map[string]interface{}{}
+		assert.NoError(t, yaml.Unmarshal([]byte(values), &parsed))
+		assert.Equal(t, map[string]interface{}{"name": "scaleway"}, parsed["provider"])
+		assert.Equal(t, []interface{}{"example.com"}, parsed["domainFilters"])
+		assert.Equal(t, data.ClusterID, parsed["txtOwnerId"])
+		assert.Contains(t, values, `value: "SCWXXXXXXXXXXXXXXXXX"`)
+	})
+
+	t.Run("cert-manager-issuer without domain", func(t *testing.T) {
+		issuerData := *data
+		issuerData.Domain = ""
+		values, err := renderHelmValues(helmValuesIntegrationCertManagerIssuer, &issuerData)
+		assert.NoError(t, err)
+		assert.NotContains(t, values, "selector")
+
+		parsed := map[string]interface{}{}
+		assert.NoError(t, yaml.Unmarshal([]byte(values), &parsed))
+		assert.Equal(t, "ClusterIssuer", parsed["kind"])
+	})
+
+	t.Run("csi-ccm", func(t *testing.T) {
+		values, err := renderHelmValues(helmValuesIntegrationCSICCM, data)
+		assert.NoError(t, err)
+
+		parsed := struct {
+			StringData map[string]string `json:"stringData"`
+		}{}
+		assert.NoError(t, yaml.Unmarshal([]byte(values), &parsed))
+		assert.Equal(t, map[string]string{
+			"SCW_ACCESS_KEY":         data.AccessKey,
+			"SCW_SECRET_KEY":         data.SecretKey,
+			"SCW_DEFAULT_PROJECT_ID": data.ProjectID,
+			"SCW_DEFAULT_REGION":     "fr-par",
+			"SCW_DEFAULT_ZONE":       "fr-par-2",
+		}, parsed.StringData)
+	})
+
+	t.Run("unknown integration", func(t *testing.T) {
+		_, err := renderHelmValues("unknown", data)
+		assert.Error(t, err)
+	})
+}
@@ -3,7 +3,7 @@ package k8s
 import (
 	"context"
 	"os"
-	"path"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -29,7 +29,7 @@ func getKubeconfigPath(ctx context.Context) (string, error) {
 		if err != nil {
 			return "", err
 		}
-		kubeconfigPath = path.Join(homeDir, kubeLocationDir, "config")
+		kubeconfigPath = filepath.Join(homeDir, kubeLocationDir, "config")
 	}
 
 	return kubeconfigPath, nil
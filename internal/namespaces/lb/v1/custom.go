@@ -33,12 +33,19 @@ func GetCommands() *core.Commands {
 
 	cmds := GetGeneratedCommands()
 
-	cmds.Add(
+	cmds.Merge(core.NewCommands(
 		lbWaitCommand(),
-	)
+		lbBackendHealthCommand(),
+		certificateCreateLetsencryptCommand(),
+		certificateRenewAllCommand(),
+		lbConfigCommand(),
+		lbConfigExportCommand(),
+		lbConfigApplyCommand(),
+	))
 
 	cmds.MustFind("lb", "lb", "create").Override(lbCreateBuilder)
 	cmds.MustFind("lb", "lb", "get").Override(lbGetBuilder)
+	cmds.MustFind("lb", "lb", "list").Override(lbListBuilder)
 	cmds.MustFind("lb", "lb", "migrate").Override(lbMigrateBuilder)
 	cmds.MustFind("lb", "lb", "update").Override(lbUpdateBuilder)
 	cmds.MustFind("lb", "lb", "delete").Override(lbDeleteBuilder)
@@ -0,0 +1,220 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const backendHealthPollInterval = 5 * time.Second
+
+type backendHealthArgs struct {
+	BackendID string
+	Watch     bool
+	Zone      scw.Zone
+}
+
+// backendServerHealth is the health of a backend server seen from an instance of the load balancer.
+type backendServerHealth struct {
+	IP                    string
+	InstanceID            string
+	ServerState           lb.BackendServerStatsServerState
+	ServerStateChangedAt  *time.Time
+	LastHealthCheckStatus lb.BackendServerStatsHealthCheckStatus
+	LastFailureAt         *time.Time
+}
+
+func lbBackendHealthCommand() *core.Command {
+	return &core.Command{
+		Short: `Display the health of the servers of a backend`,
+		Long: `Display the state and the last health check status of each server of a backend, as seen by each instance of the load balancer.
+With watch, the servers are displayed again each time their state or health check status changes, until the command is interrupted.
+The API does not expose the reason of a failed health check, the time of the last failure is the time it was observed by the command.`,
+		Namespace: "lb",
+		Resource:  "backend",
+		Verb:      "health",
+		ArgsType:  reflect.TypeOf(backendHealthArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "backend-id",
+				Short:      `ID of the backend`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "watch",
+				Short: `Keep displaying the changes of health`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3),
+		},
+		Run: lbBackendHealthRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "IP", FieldName: "IP"},
+				{Label: "Instance ID", FieldName: "InstanceID"},
+				{Label: "State", FieldName: "ServerState"},
+				{Label: "State Changed At", FieldName: "ServerStateChangedAt"},
+				{Label: "Health Check", FieldName: "LastHealthCheckStatus"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the health of the servers of a backend",
+				Raw:   "scw lb backend health 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Watch the health of the servers of a backend",
+				Raw:   "scw lb backend health 11111111-1111-1111-1111-111111111111 watch=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Update a health check for a given backend",
+				Command: "scw lb backend update-healthcheck",
+			},
+		},
+	}
+}
+
+func lbBackendHealthRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*backendHealthArgs)
+
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+	stdout := core.ExtractStdout(ctx)
+
+	backend, err := api.GetBackend(&lb.ZonedAPIGetBackendRequest{
+		Zone:      args.Zone,
+		BackendID: args.BackendID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	previous := map[string]*backendServerHealth(nil)
+	for {
+		stats, err := api.ListBackendStats(&lb.ZonedAPIListBackendStatsRequest{
+			Zone:      args.Zone,
+			LBID:      backend.LB.ID,
+			BackendID: &backend.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		if !args.Watch {
+			return newBackendServerHealths(stats.BackendServersStats, nil, time.Now()), nil
+		}
+
+		if previous == nil {
+			_, err = fmt.Fprintln(stdout, terminal.Style(formatBackendHealthHeader(), color.Bold))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		healths := newBackendServerHealths(stats.BackendServersStats, previous, time.Now())
+		current := make(map[string]*backendServerHealth, len(healths))
+		for _, health := range healths {
+			key := backendServerHealthKey(health)
+			current[key] = health
+			if previous != nil && !backendServerHealthChanged(previous[key], health) {
+				continue
+			}
+			_, err = fmt.Fprintln(stdout, formatBackendHealth(health))
+			if err != nil {
+				return nil, err
+			}
+		}
+		previous = current
+
+		select {
+		case <-ctx.Done():
+			return &core.SuccessResult{Empty: true}, nil
+		case <-time.After(backendHealthPollInterval):
+		}
+	}
+}
+
+// newBackendServerHealths converts backend server stats, keeping the last failure observed in the previous healths.
+func newBackendServerHealths(stats []*lb.BackendServerStats, previous map[string]*backendServerHealth, now time.Time) []*backendServerHealth {
+	healths := make([]*backendServerHealth, 0, len(stats))
+	for _, stat := range stats {
+		health := &backendServerHealth{
+			IP:                    stat.IP,
+			InstanceID:            stat.InstanceID,
+			ServerState:           stat.ServerState,
+			ServerStateChangedAt:  stat.ServerStateChangedAt,
+			LastHealthCheckStatus: stat.LastHealthCheckStatus,
+		}
+		if previousHealth, exists := previous[backendServerHealthKey(health)]; exists {
+			health.LastFailureAt = previousHealth.LastFailureAt
+		}
+		if health.LastHealthCheckStatus == lb.BackendServerStatsHealthCheckStatusFailed {
+			failureAt := now
+			health.LastFailureAt = &failureAt
+		}
+		healths = append(healths, health)
+	}
+
+	return healths
+}
+
+func backendServerHealthKey(health *backendServerHealth) string {
+	return health.InstanceID + "/" + health.IP
+}
+
+func backendServerHealthChanged(previous *backendServerHealth, current *backendServerHealth) bool {
+	return previous == nil ||
+		previous.ServerState != current.ServerState ||
+		previous.LastHealthCheckStatus != current.LastHealthCheckStatus ||
+		!timeOrZero(previous.ServerStateChangedAt).Equal(timeOrZero(current.ServerStateChangedAt))
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func formatBackendHealthTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatBackendHealthHeader() string {
+	return fmt.Sprintf("%-39s  %-36s  %-8s  %-25s  %-12s  %s", "IP", "INSTANCE ID", "STATE", "STATE CHANGED AT", "HEALTH CHECK", "LAST FAILURE")
+}
+
+func formatBackendHealth(health *backendServerHealth) string {
+	// The status is padded before being colored so that columns stay aligned.
+	status := fmt.Sprintf("%-12s", health.LastHealthCheckStatus)
+	switch health.LastHealthCheckStatus {
+	case lb.BackendServerStatsHealthCheckStatusPassed:
+		status = terminal.Style(status, color.FgGreen)
+	case lb.BackendServerStatsHealthCheckStatusFailed:
+		status = terminal.Style(status, color.FgRed)
+	case lb.BackendServerStatsHealthCheckStatusCondpass:
+		status = terminal.Style(status, color.FgBlue)
+	default:
+		status = terminal.Style(status, color.Faint)
+	}
+
+	return fmt.Sprintf("%-39s  %-36s  %-8s  %-25s  %s  %s",
+		health.IP,
+		health.InstanceID,
+		health.ServerState,
+		formatBackendHealthTime(health.ServerStateChangedAt),
+		status,
+		formatBackendHealthTime(health.LastFailureAt),
+	)
+}
@@ -0,0 +1,35 @@
+package lb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newBackendServerHealths(t *testing.T) {
+	changedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	failedAt := changedAt.Add(time.Minute)
+	passedAt := failedAt.Add(time.Minute)
+
+	stats := []*lb.BackendServerStats{
+		{InstanceID: "instance", IP: "10.0.0.1", ServerState: lb.BackendServerStatsServerStateRunning, ServerStateChangedAt: &changedAt, LastHealthCheckStatus: lb.BackendServerStatsHealthCheckStatusFailed},
+		{InstanceID: "instance", IP: "10.0.0.2", ServerState: lb.BackendServerStatsServerStateRunning, ServerStateChangedAt: &changedAt, LastHealthCheckStatus: lb.BackendServerStatsHealthCheckStatusPassed},
+	}
+	healths := newBackendServerHealths(stats, nil, failedAt)
+	assert.Equal(t, &failedAt, healths[0].LastFailureAt)
+	assert.Nil(t, healths[1].LastFailureAt)
+
+	previous := map[string]*backendServerHealth{}
+	for _, health := range healths {
+		previous[backendServerHealthKey(health)] = health
+	}
+
+	stats[0].LastHealthCheckStatus = lb.BackendServerStatsHealthCheckStatusPassed
+	recovered := newBackendServerHealths(stats, previous, passedAt)
+	assert.Equal(t, &failedAt, recovered[0].LastFailureAt)
+	assert.True(t, backendServerHealthChanged(previous["instance/10.0.0.1"], recovered[0]))
+	assert.False(t, backendServerHealthChanged(previous["instance/10.0.0.2"], recovered[1]))
+	assert.True(t, backendServerHealthChanged(nil, recovered[1]))
+}
@@ -0,0 +1,469 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var lbZones = []scw.Zone{scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3}
+
+type certificateCreateLetsencryptArgs struct {
+	Zone         scw.Zone
+	LBID         string
+	Name         string
+	Domains      []string
+	SkipDNSCheck bool
+}
+
+type certificateRenewAllArgs struct {
+	Zone         scw.Zone
+	LBID         string
+	RenewBefore  time.Duration
+	DryRun       bool
+	Yes          bool
+	SkipDNSCheck bool
+}
+
+// certificateRenewal is a Let's Encrypt certificate replaced by renew-all.
+type certificateRenewal struct {
+	LBID             string
+	Name             string
+	CommonName       string
+	NotValidAfter    *time.Time
+	CertificateID    string
+	NewCertificateID string
+	Frontends        []string
+}
+
+// lookupHostFunc resolves a domain name to addresses, like net.Resolver.LookupHost.
+type lookupHostFunc func(ctx context.Context, host string) ([]string, error)
+
+func certificateCreateLetsencryptCommand() *core.Command {
+	return &core.Command{
+		Short: `Create a Let's Encrypt certificate for a load balancer`,
+		Long: `Create a Let's Encrypt certificate for the given domains, the first domain is used as the common name.
+Let's Encrypt validates the domains by sending HTTP requests to them, so the command first checks that every domain resolves to an IP of the load balancer.
+The load balancer must also have a frontend listening on port 80 for the validation to succeed.`,
+		Namespace: "lb",
+		Resource:  "certificate",
+		Verb:      "create-letsencrypt",
+		ArgsType:  reflect.TypeOf(certificateCreateLetsencryptArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:     "lb-id",
+				Short:    `Load Balancer ID`,
+				Required: true,
+			},
+			{
+				Name:     "name",
+				Short:    `Name for the certificate`,
+				Required: true,
+				Default:  core.RandomValueGenerator("certificate"),
+			},
+			{
+				Name:     "domains.{index}",
+				Short:    `Domains of the certificate, the first one is the common name`,
+				Required: true,
+			},
+			{
+				Name:  "skip-dns-check",
+				Short: `Create the certificate without checking that the domains resolve to the load balancer`,
+			},
+			core.ZoneArgSpec(lbZones...),
+		},
+		Run:       certificateCreateLetsencryptRun,
+		WaitUsage: "wait until the certificate is ready",
+		WaitFunc: func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+			return waitForLetsencryptCertificate(ctx, respI.(*lb.Certificate))
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Create a certificate for example.com and www.example.com and wait until it is ready",
+				Raw:   "scw lb certificate create-letsencrypt lb-id=11111111-1111-1111-1111-111111111111 domains.0=example.com domains.1=www.example.com --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Renew the Let's Encrypt certificates close to expiration",
+				Command: "scw lb certificate renew-all",
+			},
+		},
+	}
+}
+
+func certificateCreateLetsencryptRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*certificateCreateLetsencryptArgs)
+
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+	loadBalancer, err := api.GetLB(&lb.ZonedAPIGetLBRequest{
+		Zone: args.Zone,
+		LBID: args.LBID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if !args.SkipDNSCheck {
+		err = checkLetsencryptDomains(ctx, args.Domains, loadBalancer, net.DefaultResolver.LookupHost)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	certificate, err := api.CreateCertificate(&lb.ZonedAPICreateCertificateRequest{
+		Zone: args.Zone,
+		LBID: args.LBID,
+		Name: args.Name,
+		Letsencrypt: &lb.CreateCertificateRequestLetsencryptConfig{
+			CommonName:             args.Domains[0],
+			SubjectAlternativeName: args.Domains[1:],
+		},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(loadBalancer.Tags) != 0 && loadBalancer.Tags[0] == kapsuleTag {
+		return warningKapsuleTaggedMessageView(), nil
+	}
+
+	return certificate, nil
+}
+
+// waitForLetsencryptCertificate waits for a certificate and explains why Let's Encrypt could not deliver it.
+func waitForLetsencryptCertificate(ctx context.Context, certificate *lb.Certificate) (*lb.Certificate, error) {
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+	certificate, err := api.WaitForLBCertificate(&lb.ZonedAPIWaitForLBCertificateRequest{
+		CertID:        certificate.ID,
+		Zone:          certificate.LB.Zone,
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	if certificate.Status == lb.CertificateStatusError {
+		details := ""
+		if certificate.StatusDetails != nil {
+			details = *certificate.StatusDetails
+		}
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("let's encrypt could not validate certificate %s", certificate.ID),
+			Details: details,
+			Hint: strings.Join([]string{
+				"Make sure that every domain resolves to the IP of the load balancer and that DNS changes have propagated",
+				"Make sure that the load balancer has a frontend listening on port 80 and that no ACL denies the validation requests",
+				"Let's Encrypt limits the number of certificates issued for a domain, wait before retrying if the rate limit was reached",
+				fmt.Sprintf("Delete the certificate with scw lb certificate delete %s zone=%s before creating it again", certificate.ID, certificate.LB.Zone),
+			}, "\n"),
+		}
+	}
+
+	return certificate, nil
+}
+
+// checkLetsencryptDomains returns an error listing the domains that do not resolve to an IP of the load balancer.
+func checkLetsencryptDomains(ctx context.Context, domains []string, loadBalancer *lb.LB, lookupHost lookupHostFunc) error {
+	lbIPs := map[string]bool{}
+	for _, ip := range loadBalancer.IP {
+		lbIPs[ip.IPAddress] = true
+	}
+
+	problems := []string(nil)
+	for _, domain := range domains {
+		addresses, err := lookupHost(ctx, domain)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s cannot be resolved: %s", domain, err))
+			continue
+		}
+
+		pointsToLB := false
+		for _, address := range addresses {
+			if lbIPs[address] {
+				pointsToLB = true
+			}
+		}
+		if !pointsToLB {
+			problems = append(problems, fmt.Sprintf("%s resolves to %s", domain, strings.Join(addresses, ", ")))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	ips := make([]string, 0, len(loadBalancer.IP))
+	for _, ip := range loadBalancer.IP {
+		ips = append(ips, ip.IPAddress)
+	}
+	hint := "The load balancer has no IP, attach one before creating a Let's Encrypt certificate"
+	if len(ips) > 0 {
+		hint = fmt.Sprintf("Create A records pointing to %s, for instance with scw dns record add <zone> name=<name> type=A data=%s, then retry once DNS changes have propagated or use skip-dns-check=true",
+			strings.Join(ips, ", "), ips[0])
+	}
+
+	return &core.CliError{
+		Err:     fmt.Errorf("some domains do not resolve to the load balancer"),
+		Details: strings.Join(problems, "\n"),
+		Hint:    hint,
+	}
+}
+
+func certificateRenewAllCommand() *core.Command {
+	return &core.Command{
+		Short: `Renew the Let's Encrypt certificates close to expiration`,
+		Long: `Replace the Let's Encrypt certificates that expire soon with new certificates for the same domains.
+Each new certificate is created, waited for, then set on the frontends using the old certificate, and the old certificate is deleted.
+The certificates of all the load balancers of the zone are renewed unless lb-id is given.`,
+		Namespace: "lb",
+		Resource:  "certificate",
+		Verb:      "renew-all",
+		ArgsType:  reflect.TypeOf(certificateRenewAllArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "lb-id",
+				Short: `Only renew the certificates of this load balancer`,
+			},
+			{
+				Name:    "renew-before",
+				Short:   `Renew the certificates expiring within this duration`,
+				Default: core.DefaultValueSetter("30d"),
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the certificates that would be renewed`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			{
+				Name:  "skip-dns-check",
+				Short: `Renew the certificates without checking that the domains resolve to the load balancer`,
+			},
+			core.ZoneArgSpec(lbZones...),
+		},
+		Run: certificateRenewAllRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "LB ID", FieldName: "LBID"},
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Common Name", FieldName: "CommonName"},
+				{Label: "Not Valid After", FieldName: "NotValidAfter"},
+				{Label: "Certificate ID", FieldName: "CertificateID"},
+				{Label: "New Certificate ID", FieldName: "NewCertificateID"},
+				{Label: "Frontends", FieldName: "Frontends"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the certificates expiring within 30 days",
+				Raw:   "scw lb certificate renew-all dry-run=true",
+			},
+			{
+				Short: "Renew the certificates of a load balancer expiring within 2 weeks",
+				Raw:   "scw lb certificate renew-all lb-id=11111111-1111-1111-1111-111111111111 renew-before=14d",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create a Let's Encrypt certificate for a load balancer",
+				Command: "scw lb certificate create-letsencrypt",
+			},
+		},
+	}
+}
+
+func certificateRenewAllRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*certificateRenewAllArgs)
+
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+
+	lbIDs := []string{args.LBID}
+	if args.LBID == "" {
+		lbs, err := api.ListLBs(&lb.ZonedAPIListLBsRequest{
+			Zone: args.Zone,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		lbIDs = lbIDs[:0]
+		for _, loadBalancer := range lbs.LBs {
+			lbIDs = append(lbIDs, loadBalancer.ID)
+		}
+	}
+
+	renewals := []*certificateRenewal(nil)
+	for _, lbID := range lbIDs {
+		certificates, err := api.ListCertificates(&lb.ZonedAPIListCertificatesRequest{
+			Zone: args.Zone,
+			LBID: lbID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		frontends, err := api.ListFrontends(&lb.ZonedAPIListFrontendsRequest{
+			Zone: args.Zone,
+			LBID: lbID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		renewals = append(renewals, certificatesToRenew(lbID, certificates.Certificates, frontends.Frontends, time.Now().Add(args.RenewBefore))...)
+	}
+
+	if args.DryRun || len(renewals) == 0 {
+		return renewals, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("renewing certificates must be confirmed"),
+				Hint: "Review the certificates with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, renewal := range renewals {
+			_, _ = interactive.Printf("%s %s (%s) expires on %s\n", renewal.LBID, renewal.Name, renewal.CommonName, renewal.NotValidAfter.Format(time.RFC3339))
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       fmt.Sprintf("Do you want to renew %d certificate(s)?", len(renewals)),
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Renewal canceled"}, nil
+		}
+	}
+
+	for _, renewal := range renewals {
+		err := renewCertificate(ctx, args, renewal)
+		if err != nil {
+			return nil, fmt.Errorf("cannot renew certificate %s: %w", renewal.CertificateID, err)
+		}
+	}
+
+	return renewals, nil
+}
+
+// certificatesToRenew returns the Let's Encrypt certificates expiring before a date, with the frontends using them.
+func certificatesToRenew(lbID string, certificates []*lb.Certificate, frontends []*lb.Frontend, expiringBefore time.Time) []*certificateRenewal {
+	renewals := []*certificateRenewal(nil)
+	for _, certificate := range certificates {
+		if certificate.Type != lb.CertificateTypeLetsencryt || certificate.Status != lb.CertificateStatusReady {
+			continue
+		}
+		if certificate.NotValidAfter == nil || certificate.NotValidAfter.After(expiringBefore) {
+			continue
+		}
+
+		renewal := &certificateRenewal{
+			LBID:          lbID,
+			Name:          certificate.Name,
+			CommonName:    certificate.CommonName,
+			NotValidAfter: certificate.NotValidAfter,
+			CertificateID: certificate.ID,
+		}
+		for _, frontend := range frontends {
+			for _, id := range frontend.CertificateIDs {
+				if id == certificate.ID {
+					renewal.Frontends = append(renewal.Frontends, frontend.ID)
+				}
+			}
+		}
+		renewals = append(renewals, renewal)
+	}
+
+	return renewals
+}
+
+// renewCertificate creates a copy of a certificate, moves the frontends to it and deletes the old one.
+func renewCertificate(ctx context.Context, args *certificateRenewAllArgs, renewal *certificateRenewal) error {
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+
+	oldCertificate, err := api.GetCertificate(&lb.ZonedAPIGetCertificateRequest{
+		Zone:          args.Zone,
+		CertificateID: renewal.CertificateID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if !args.SkipDNSCheck {
+		domains := append([]string{oldCertificate.CommonName}, oldCertificate.SubjectAlternativeName...)
+		err = checkLetsencryptDomains(ctx, domains, oldCertificate.LB, net.DefaultResolver.LookupHost)
+		if err != nil {
+			return err
+		}
+	}
+
+	newCertificate, err := api.CreateCertificate(&lb.ZonedAPICreateCertificateRequest{
+		Zone: args.Zone,
+		LBID: renewal.LBID,
+		Name: oldCertificate.Name,
+		Letsencrypt: &lb.CreateCertificateRequestLetsencryptConfig{
+			CommonName:             oldCertificate.CommonName,
+			SubjectAlternativeName: oldCertificate.SubjectAlternativeName,
+		},
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	renewal.NewCertificateID = newCertificate.ID
+
+	_, err = waitForLetsencryptCertificate(ctx, newCertificate)
+	if err != nil {
+		return err
+	}
+
+	for _, frontendID := range renewal.Frontends {
+		frontend, err := api.GetFrontend(&lb.ZonedAPIGetFrontendRequest{
+			Zone:       args.Zone,
+			FrontendID: frontendID,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		certificateIDs := make([]string, 0, len(frontend.CertificateIDs))
+		for _, id := range frontend.CertificateIDs {
+			if id == renewal.CertificateID {
+				id = newCertificate.ID
+			}
+			certificateIDs = append(certificateIDs, id)
+		}
+
+		_, err = api.UpdateFrontend(&lb.ZonedAPIUpdateFrontendRequest{
+			Zone:           args.Zone,
+			FrontendID:     frontend.ID,
+			Name:           frontend.Name,
+			InboundPort:    frontend.InboundPort,
+			BackendID:      frontend.Backend.ID,
+			TimeoutClient:  frontend.TimeoutClient,
+			CertificateIDs: &certificateIDs,
+			EnableHTTP3:    frontend.EnableHTTP3,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+	}
+
+	return api.DeleteCertificate(&lb.ZonedAPIDeleteCertificateRequest{
+		Zone:          args.Zone,
+		CertificateID: renewal.CertificateID,
+	}, scw.WithContext(ctx))
+}
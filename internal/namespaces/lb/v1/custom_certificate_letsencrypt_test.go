@@ -0,0 +1,54 @@
+package lb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkLetsencryptDomains(t *testing.T) {
+	loadBalancer := &lb.LB{IP: []*lb.IP{{IPAddress: "51.15.0.1"}}}
+	lookupHost := func(_ context.Context, host string) ([]string, error) {
+		switch host {
+		case "example.com", "www.example.com":
+			return []string{"51.15.0.1"}, nil
+		case "old.example.com":
+			return []string{"163.172.0.1"}, nil
+		}
+		return nil, fmt.Errorf("no such host")
+	}
+
+	err := checkLetsencryptDomains(context.Background(), []string{"example.com", "www.example.com"}, loadBalancer, lookupHost)
+	assert.NoError(t, err)
+
+	err = checkLetsencryptDomains(context.Background(), []string{"example.com", "old.example.com", "missing.example.com"}, loadBalancer, lookupHost)
+	cliErr := &core.CliError{}
+	require.ErrorAs(t, err, &cliErr)
+	assert.Equal(t, "old.example.com resolves to 163.172.0.1\nmissing.example.com cannot be resolved: no such host", cliErr.Details)
+	assert.Contains(t, cliErr.Hint, "data=51.15.0.1")
+}
+
+func Test_certificatesToRenew(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	soon := now.Add(10 * 24 * time.Hour)
+	later := now.Add(60 * 24 * time.Hour)
+
+	renewals := certificatesToRenew("lb", []*lb.Certificate{
+		{ID: "expiring", Type: lb.CertificateTypeLetsencryt, Status: lb.CertificateStatusReady, NotValidAfter: &soon},
+		{ID: "valid", Type: lb.CertificateTypeLetsencryt, Status: lb.CertificateStatusReady, NotValidAfter: &later},
+		{ID: "custom", Type: lb.CertificateTypeCustom, Status: lb.CertificateStatusReady, NotValidAfter: &soon},
+	}, []*lb.Frontend{
+		{ID: "https", CertificateIDs: []string{"valid", "expiring"}},
+		{ID: "http"},
+	}, now.Add(30*24*time.Hour))
+
+	require.Len(t, renewals, 1)
+	assert.Equal(t, "expiring", renewals[0].CertificateID)
+	assert.Equal(t, []string{"https"}, renewals[0].Frontends)
+}
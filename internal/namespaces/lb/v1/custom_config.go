@@ -0,0 +1,920 @@
+package lb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	lbConfigOperationCreate = "create"
+	lbConfigOperationUpdate = "update"
+	lbConfigOperationDelete = "delete"
+
+	lbConfigResourceCertificate = "certificate"
+	lbConfigResourceBackend     = "backend"
+	lbConfigResourceFrontend    = "frontend"
+	lbConfigResourceACL         = "acl"
+	lbConfigResourceRoute       = "route"
+)
+
+// lbConfig is the declarative configuration of a Load Balancer handled by lb config export and apply.
+// Resources are identified by their name, routes by their match.
+type lbConfig struct {
+	Certificates []*lbConfigCertificate             `json:"certificates"`
+	Backends     []*lb.ZonedAPICreateBackendRequest `json:"backends"`
+	Frontends    []*lbConfigFrontend                `json:"frontends"`
+}
+
+type lbConfigCertificate struct {
+	Name                   string             `json:"name"`
+	Type                   lb.CertificateType `json:"type"`
+	CommonName             string             `json:"common_name"`
+	SubjectAlternativeName []string           `json:"subject_alternative_name,omitempty"`
+}
+
+type lbConfigFrontend struct {
+	Name        string `json:"name"`
+	InboundPort int32  `json:"inbound_port"`
+	Backend     string `json:"backend"`
+	// TimeoutClient is in milliseconds, like the timeouts of backends.
+	TimeoutClient *int64                         `json:"timeout_client,omitempty"`
+	Certificates  []string                       `json:"certificates,omitempty"`
+	EnableHTTP3   bool                           `json:"enable_http3"`
+	ACLs          []*lb.ZonedAPICreateACLRequest `json:"acls,omitempty"`
+	Routes        []*lbConfigRoute               `json:"routes,omitempty"`
+}
+
+type lbConfigRoute struct {
+	Backend string         `json:"backend"`
+	Match   *lb.RouteMatch `json:"match"`
+}
+
+// lbConfigChange is a change needed to reconcile a Load Balancer with a configuration.
+type lbConfigChange struct {
+	Operation string
+	Resource  string
+	Frontend  string
+	Name      string
+}
+
+// lbConfigState is the live configuration of a Load Balancer and the IDs of its resources.
+type lbConfigState struct {
+	config         *lbConfig
+	certificateIDs map[string]string
+	backendIDs     map[string]string
+	frontendIDs    map[string]string
+	aclIDs         map[string]map[string]string
+	routeIDs       map[string]map[string]string
+}
+
+type lbConfigExportRequest struct {
+	Zone scw.Zone
+	LBID string
+}
+
+type lbConfigApplyRequest struct {
+	Zone   scw.Zone
+	LBID   string
+	File   string
+	DryRun bool
+	Yes    bool
+}
+
+func lbConfigCommand() *core.Command {
+	return &core.Command{
+		Short:     `Load balancer configuration management commands`,
+		Long:      `Export the configuration of a load balancer as a YAML document and apply it back, to manage load balancers declaratively.`,
+		Namespace: "lb",
+		Resource:  "config",
+	}
+}
+
+func lbConfigExportCommand() *core.Command {
+	return &core.Command{
+		Short:     `Export the configuration of a load balancer`,
+		Long:      `Export the certificates, backends, frontends, ACLs and routes of a load balancer as a single YAML document that can be applied with scw lb config apply.`,
+		Namespace: "lb",
+		Resource:  "config",
+		Verb:      "export",
+		ArgsType:  reflect.TypeOf(lbConfigExportRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "lb-id",
+				Short:      `ID of the load balancer`,
+				Required:   true,
+				Positional: true,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*lbConfigExportRequest)
+
+			state, err := fetchLBConfigState(ctx, lb.NewZonedAPI(core.ExtractClient(ctx)), args.Zone, args.LBID)
+			if err != nil {
+				return nil, err
+			}
+
+			config, err := yaml.Marshal(state.config)
+			if err != nil {
+				return nil, err
+			}
+
+			return string(config), nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Export the configuration of a load balancer to a file",
+				Raw:   "scw lb config export 11111111-1111-1111-1111-111111111111 > lb.yaml",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply a configuration to a load balancer",
+				Command: "scw lb config apply",
+			},
+		},
+	}
+}
+
+func lbConfigApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a configuration to a load balancer`,
+		Long: `Reconcile a load balancer with a configuration in the format of scw lb config export.
+Certificates, backends, frontends and ACLs are matched by name and routes by their match. Resources missing from the load balancer are created, resources that differ are updated and resources missing from the configuration are deleted.
+The changes are displayed and must be confirmed before being applied.
+Certificates cannot be updated and custom certificates must be created with scw lb certificate create before being referenced.`,
+		Namespace: "lb",
+		Resource:  "config",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(lbConfigApplyRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "lb-id",
+				Short:      `ID of the load balancer`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "file",
+				Short:       `YAML or JSON configuration to apply`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the changes`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3),
+		},
+		Run: lbConfigApplyRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "Resource", FieldName: "Resource"},
+				{Label: "Frontend", FieldName: "Frontend"},
+				{Label: "Name", FieldName: "Name"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a configuration",
+				Raw:   "scw lb config apply 11111111-1111-1111-1111-111111111111 file=@lb.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a configuration",
+				Raw:   "scw lb config apply 11111111-1111-1111-1111-111111111111 file=@lb.yaml",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Export the configuration of a load balancer",
+				Command: "scw lb config export",
+			},
+		},
+	}
+}
+
+func lbConfigApplyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*lbConfigApplyRequest)
+
+	desired := &lbConfig{}
+	err := yaml.Unmarshal([]byte(args.File), desired)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the configuration: %s", err)
+	}
+
+	api := lb.NewZonedAPI(core.ExtractClient(ctx))
+	state, err := fetchLBConfigState(ctx, api, args.Zone, args.LBID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := planLBConfigChanges(state.config, desired)
+	if err != nil {
+		return nil, err
+	}
+	if args.DryRun {
+		return changes, nil
+	}
+	if len(changes) == 0 {
+		return &core.SuccessResult{Message: "Load balancer is already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying a configuration must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range changes {
+			name := change.Name
+			if change.Frontend != "" {
+				name = change.Frontend + "/" + name
+			}
+			_, _ = interactive.Printf("%s %s %s\n", change.Operation, change.Resource, name)
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	err = applyLBConfigChanges(ctx, api, args.Zone, args.LBID, state, desired, changes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("%d changes applied to load balancer %s", len(changes), args.LBID),
+	}, nil
+}
+
+func fetchLBConfigState(ctx context.Context, api *lb.ZonedAPI, zone scw.Zone, lbID string) (*lbConfigState, error) {
+	certificates, err := api.ListCertificates(&lb.ZonedAPIListCertificatesRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	backends, err := api.ListBackends(&lb.ZonedAPIListBackendsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	frontends, err := api.ListFrontends(&lb.ZonedAPIListFrontendsRequest{
+		Zone: zone,
+		LBID: lbID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	acls := map[string][]*lb.ACL{}
+	routes := map[string][]*lb.Route{}
+	for _, frontend := range frontends.Frontends {
+		frontendACLs, err := api.ListACLs(&lb.ZonedAPIListACLsRequest{
+			Zone:       zone,
+			FrontendID: frontend.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		acls[frontend.ID] = frontendACLs.ACLs
+
+		frontendRoutes, err := api.ListRoutes(&lb.ZonedAPIListRoutesRequest{
+			Zone:       zone,
+			FrontendID: &frontend.ID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		routes[frontend.ID] = frontendRoutes.Routes
+	}
+
+	return newLBConfigState(certificates.Certificates, backends.Backends, frontends.Frontends, acls, routes), nil
+}
+
+// newLBConfigState builds the configuration of a Load Balancer from its resources, ACLs and routes are indexed by frontend ID.
+func newLBConfigState(certificates []*lb.Certificate, backends []*lb.Backend, frontends []*lb.Frontend, acls map[string][]*lb.ACL, routes map[string][]*lb.Route) *lbConfigState {
+	state := &lbConfigState{
+		config:         &lbConfig{},
+		certificateIDs: map[string]string{},
+		backendIDs:     map[string]string{},
+		frontendIDs:    map[string]string{},
+		aclIDs:         map[string]map[string]string{},
+		routeIDs:       map[string]map[string]string{},
+	}
+
+	certificateNames := map[string]string{}
+	for _, certificate := range certificates {
+		certificateNames[certificate.ID] = certificate.Name
+		state.certificateIDs[certificate.Name] = certificate.ID
+		state.config.Certificates = append(state.config.Certificates, &lbConfigCertificate{
+			Name:                   certificate.Name,
+			Type:                   certificate.Type,
+			CommonName:             certificate.CommonName,
+			SubjectAlternativeName: certificate.SubjectAlternativeName,
+		})
+	}
+
+	backendNames := map[string]string{}
+	for _, backend := range backends {
+		backendNames[backend.ID] = backend.Name
+		state.backendIDs[backend.Name] = backend.ID
+		state.config.Backends = append(state.config.Backends, &lb.ZonedAPICreateBackendRequest{
+			Name:                     backend.Name,
+			ForwardProtocol:          backend.ForwardProtocol,
+			ForwardPort:              backend.ForwardPort,
+			ForwardPortAlgorithm:     backend.ForwardPortAlgorithm,
+			StickySessions:           backend.StickySessions,
+			StickySessionsCookieName: backend.StickySessionsCookieName,
+			HealthCheck:              backend.HealthCheck,
+			ServerIP:                 backend.Pool,
+			TimeoutServer:            backend.TimeoutServer,
+			TimeoutConnect:           backend.TimeoutConnect,
+			TimeoutTunnel:            backend.TimeoutTunnel,
+			OnMarkedDownAction:       backend.OnMarkedDownAction,
+			ProxyProtocol:            backend.ProxyProtocol,
+			FailoverHost:             backend.FailoverHost,
+			SslBridging:              backend.SslBridging,
+			IgnoreSslServerVerify:    backend.IgnoreSslServerVerify,
+			RedispatchAttemptCount:   backend.RedispatchAttemptCount,
+			MaxRetries:               backend.MaxRetries,
+			MaxConnections:           backend.MaxConnections,
+			TimeoutQueue:             backend.TimeoutQueue,
+		})
+	}
+
+	for _, frontend := range frontends {
+		state.frontendIDs[frontend.Name] = frontend.ID
+		state.aclIDs[frontend.Name] = map[string]string{}
+		state.routeIDs[frontend.Name] = map[string]string{}
+
+		configFrontend := &lbConfigFrontend{
+			Name:        frontend.Name,
+			InboundPort: frontend.InboundPort,
+			EnableHTTP3: frontend.EnableHTTP3,
+		}
+		if frontend.Backend != nil {
+			configFrontend.Backend = frontend.Backend.Name
+		}
+		if frontend.TimeoutClient != nil {
+			timeoutClient := frontend.TimeoutClient.Milliseconds()
+			configFrontend.TimeoutClient = &timeoutClient
+		}
+		for _, certificateID := range frontend.CertificateIDs {
+			configFrontend.Certificates = append(configFrontend.Certificates, certificateNames[certificateID])
+		}
+
+		for _, acl := range acls[frontend.ID] {
+			state.aclIDs[frontend.Name][acl.Name] = acl.ID
+			configFrontend.ACLs = append(configFrontend.ACLs, &lb.ZonedAPICreateACLRequest{
+				Name:        acl.Name,
+				Action:      acl.Action,
+				Match:       acl.Match,
+				Index:       acl.Index,
+				Description: acl.Description,
+			})
+		}
+
+		for _, route := range routes[frontend.ID] {
+			state.routeIDs[frontend.Name][lbConfigRouteKey(route.Match)] = route.ID
+			configFrontend.Routes = append(configFrontend.Routes, &lbConfigRoute{
+				Backend: backendNames[route.BackendID],
+				Match:   route.Match,
+			})
+		}
+
+		state.config.Frontends = append(state.config.Frontends, configFrontend)
+	}
+
+	return state
+}
+
+// lbConfigRouteKey identifies a route among the routes of a frontend.
+func lbConfigRouteKey(match *lb.RouteMatch) string {
+	switch {
+	case match == nil:
+		return ""
+	case match.Sni != nil:
+		return "sni=" + *match.Sni
+	case match.HostHeader != nil:
+		return "host-header=" + *match.HostHeader
+	}
+	return ""
+}
+
+// lbConfigEqual compares two resources of a configuration using their JSON representation.
+func lbConfigEqual(a, b interface{}) bool {
+	rawA, errA := json.Marshal(a)
+	rawB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(rawA, rawB)
+}
+
+// validateLBConfig checks that names are unique and that references between resources are valid.
+func validateLBConfig(config *lbConfig) error {
+	certificates := map[string]bool{}
+	for _, certificate := range config.Certificates {
+		if certificates[certificate.Name] {
+			return fmt.Errorf("certificate %s is defined more than once", certificate.Name)
+		}
+		certificates[certificate.Name] = true
+	}
+
+	backends := map[string]bool{}
+	for _, backend := range config.Backends {
+		if backends[backend.Name] {
+			return fmt.Errorf("backend %s is defined more than once", backend.Name)
+		}
+		backends[backend.Name] = true
+	}
+
+	frontends := map[string]bool{}
+	for _, frontend := range config.Frontends {
+		if frontends[frontend.Name] {
+			return fmt.Errorf("frontend %s is defined more than once", frontend.Name)
+		}
+		frontends[frontend.Name] = true
+
+		if !backends[frontend.Backend] {
+			return fmt.Errorf("frontend %s uses unknown backend %q", frontend.Name, frontend.Backend)
+		}
+		for _, certificate := range frontend.Certificates {
+			if !certificates[certificate] {
+				return fmt.Errorf("frontend %s uses unknown certificate %q", frontend.Name, certificate)
+			}
+		}
+
+		acls := map[string]bool{}
+		for _, acl := range frontend.ACLs {
+			if acls[acl.Name] {
+				return fmt.Errorf("acl %s of frontend %s is defined more than once", acl.Name, frontend.Name)
+			}
+			acls[acl.Name] = true
+		}
+
+		routes := map[string]bool{}
+		for _, route := range frontend.Routes {
+			key := lbConfigRouteKey(route.Match)
+			if key == "" {
+				return fmt.Errorf("a route of frontend %s has no match", frontend.Name)
+			}
+			if routes[key] {
+				return fmt.Errorf("route %s of frontend %s is defined more than once", key, frontend.Name)
+			}
+			routes[key] = true
+			if !backends[route.Backend] {
+				return fmt.Errorf("route %s of frontend %s uses unknown backend %q", key, frontend.Name, route.Backend)
+			}
+		}
+	}
+
+	return nil
+}
+
+// planLBConfigChanges returns the ordered changes needed to reconcile the current configuration with the desired one.
+// Resources are created and updated before being deleted so that references are never broken.
+func planLBConfigChanges(current, desired *lbConfig) ([]*lbConfigChange, error) {
+	err := validateLBConfig(desired)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*lbConfigChange(nil)
+	addChange := func(operation, resource, frontend, name string) {
+		changes = append(changes, &lbConfigChange{Operation: operation, Resource: resource, Frontend: frontend, Name: name})
+	}
+
+	currentCertificates := map[string]*lbConfigCertificate{}
+	for _, certificate := range current.Certificates {
+		currentCertificates[certificate.Name] = certificate
+	}
+	for _, certificate := range desired.Certificates {
+		currentCertificate, exists := currentCertificates[certificate.Name]
+		switch {
+		case !exists && certificate.Type == lb.CertificateTypeCustom:
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("custom certificate %s does not exist", certificate.Name),
+				Hint: "Create it with scw lb certificate create custom-certificate-chain=@/path/to/chain.pem",
+			}
+		case !exists:
+			addChange(lbConfigOperationCreate, lbConfigResourceCertificate, "", certificate.Name)
+		case !lbConfigEqual(currentCertificate, certificate):
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("certificate %s cannot be updated", certificate.Name),
+				Hint: "Use a new name to replace the certificate",
+			}
+		}
+	}
+
+	currentBackends := map[string]*lb.ZonedAPICreateBackendRequest{}
+	for _, backend := range current.Backends {
+		currentBackends[backend.Name] = backend
+	}
+	for _, backend := range desired.Backends {
+		currentBackend, exists := currentBackends[backend.Name]
+		switch {
+		case !exists:
+			addChange(lbConfigOperationCreate, lbConfigResourceBackend, "", backend.Name)
+		case !lbConfigEqual(currentBackend, backend):
+			addChange(lbConfigOperationUpdate, lbConfigResourceBackend, "", backend.Name)
+		}
+	}
+
+	currentFrontends := map[string]*lbConfigFrontend{}
+	for _, frontend := range current.Frontends {
+		currentFrontends[frontend.Name] = frontend
+	}
+	desiredFrontends := map[string]*lbConfigFrontend{}
+	for _, frontend := range desired.Frontends {
+		desiredFrontends[frontend.Name] = frontend
+
+		currentFrontend, exists := currentFrontends[frontend.Name]
+		if !exists {
+			currentFrontend = &lbConfigFrontend{}
+			addChange(lbConfigOperationCreate, lbConfigResourceFrontend, "", frontend.Name)
+		} else if !lbConfigEqual(lbConfigFrontendSettings(currentFrontend), lbConfigFrontendSettings(frontend)) {
+			addChange(lbConfigOperationUpdate, lbConfigResourceFrontend, "", frontend.Name)
+		}
+
+		currentACLs := map[string]*lb.ZonedAPICreateACLRequest{}
+		for _, acl := range currentFrontend.ACLs {
+			currentACLs[acl.Name] = acl
+		}
+		for _, acl := range frontend.ACLs {
+			currentACL, exists := currentACLs[acl.Name]
+			switch {
+			case !exists:
+				addChange(lbConfigOperationCreate, lbConfigResourceACL, frontend.Name, acl.Name)
+			case !lbConfigEqual(currentACL, acl):
+				addChange(lbConfigOperationUpdate, lbConfigResourceACL, frontend.Name, acl.Name)
+			}
+		}
+
+		currentRoutes := map[string]*lbConfigRoute{}
+		for _, route := range currentFrontend.Routes {
+			currentRoutes[lbConfigRouteKey(route.Match)] = route
+		}
+		for _, route := range frontend.Routes {
+			key := lbConfigRouteKey(route.Match)
+			currentRoute, exists := currentRoutes[key]
+			switch {
+			case !exists:
+				addChange(lbConfigOperationCreate, lbConfigResourceRoute, frontend.Name, key)
+			case currentRoute.Backend != route.Backend:
+				addChange(lbConfigOperationUpdate, lbConfigResourceRoute, frontend.Name, key)
+			}
+		}
+	}
+
+	// ACLs and routes of deleted frontends are deleted with them.
+	for _, frontend := range current.Frontends {
+		desiredFrontend, exists := desiredFrontends[frontend.Name]
+		if !exists {
+			continue
+		}
+
+		desiredACLs := map[string]bool{}
+		for _, acl := range desiredFrontend.ACLs {
+			desiredACLs[acl.Name] = true
+		}
+		for _, acl := range frontend.ACLs {
+			if !desiredACLs[acl.Name] {
+				addChange(lbConfigOperationDelete, lbConfigResourceACL, frontend.Name, acl.Name)
+			}
+		}
+
+		desiredRoutes := map[string]bool{}
+		for _, route := range desiredFrontend.Routes {
+			desiredRoutes[lbConfigRouteKey(route.Match)] = true
+		}
+		for _, route := range frontend.Routes {
+			if key := lbConfigRouteKey(route.Match); !desiredRoutes[key] {
+				addChange(lbConfigOperationDelete, lbConfigResourceRoute, frontend.Name, key)
+			}
+		}
+	}
+
+	for _, frontend := range current.Frontends {
+		if _, exists := desiredFrontends[frontend.Name]; !exists {
+			addChange(lbConfigOperationDelete, lbConfigResourceFrontend, "", frontend.Name)
+		}
+	}
+
+	desiredBackends := map[string]bool{}
+	for _, backend := range desired.Backends {
+		desiredBackends[backend.Name] = true
+	}
+	for _, backend := range current.Backends {
+		if !desiredBackends[backend.Name] {
+			addChange(lbConfigOperationDelete, lbConfigResourceBackend, "", backend.Name)
+		}
+	}
+
+	desiredCertificates := map[string]bool{}
+	for _, certificate := range desired.Certificates {
+		desiredCertificates[certificate.Name] = true
+	}
+	for _, certificate := range current.Certificates {
+		if !desiredCertificates[certificate.Name] {
+			addChange(lbConfigOperationDelete, lbConfigResourceCertificate, "", certificate.Name)
+		}
+	}
+
+	return changes, nil
+}
+
+// lbConfigFrontendSettings returns a frontend without its ACLs and routes, which are reconciled separately.
+func lbConfigFrontendSettings(frontend *lbConfigFrontend) *lbConfigFrontend {
+	settings := *frontend
+	settings.ACLs = nil
+	settings.Routes = nil
+	return &settings
+}
+
+func applyLBConfigChanges(ctx context.Context, api *lb.ZonedAPI, zone scw.Zone, lbID string, state *lbConfigState, desired *lbConfig, changes []*lbConfigChange) error {
+	certificates := map[string]*lbConfigCertificate{}
+	for _, certificate := range desired.Certificates {
+		certificates[certificate.Name] = certificate
+	}
+	backends := map[string]*lb.ZonedAPICreateBackendRequest{}
+	for _, backend := range desired.Backends {
+		backends[backend.Name] = backend
+	}
+	frontends := map[string]*lbConfigFrontend{}
+	acls := map[string]map[string]*lb.ZonedAPICreateACLRequest{}
+	routes := map[string]map[string]*lbConfigRoute{}
+	for _, frontend := range desired.Frontends {
+		frontends[frontend.Name] = frontend
+		acls[frontend.Name] = map[string]*lb.ZonedAPICreateACLRequest{}
+		for _, acl := range frontend.ACLs {
+			acls[frontend.Name][acl.Name] = acl
+		}
+		routes[frontend.Name] = map[string]*lbConfigRoute{}
+		for _, route := range frontend.Routes {
+			routes[frontend.Name][lbConfigRouteKey(route.Match)] = route
+		}
+	}
+
+	for _, change := range changes {
+		err := applyLBConfigChange(ctx, api, zone, lbID, state, change, certificates, backends, frontends, acls, routes)
+		if err != nil {
+			return fmt.Errorf("cannot %s %s %s: %w", change.Operation, change.Resource, change.Name, err)
+		}
+		_, _ = interactive.Printf("%s %s %s done\n", change.Operation, change.Resource, change.Name)
+	}
+
+	return nil
+}
+
+func applyLBConfigChange(
+	ctx context.Context,
+	api *lb.ZonedAPI,
+	zone scw.Zone,
+	lbID string,
+	state *lbConfigState,
+	change *lbConfigChange,
+	certificates map[string]*lbConfigCertificate,
+	backends map[string]*lb.ZonedAPICreateBackendRequest,
+	frontends map[string]*lbConfigFrontend,
+	acls map[string]map[string]*lb.ZonedAPICreateACLRequest,
+	routes map[string]map[string]*lbConfigRoute,
+) error {
+	switch change.Resource + "/" + change.Operation {
+	case lbConfigResourceCertificate + "/" + lbConfigOperationCreate:
+		certificate := certificates[change.Name]
+		created, err := api.CreateCertificate(&lb.ZonedAPICreateCertificateRequest{
+			Zone: zone,
+			LBID: lbID,
+			Name: certificate.Name,
+			Letsencrypt: &lb.CreateCertificateRequestLetsencryptConfig{
+				CommonName:             certificate.CommonName,
+				SubjectAlternativeName: certificate.SubjectAlternativeName,
+			},
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		state.certificateIDs[change.Name] = created.ID
+
+	case lbConfigResourceCertificate + "/" + lbConfigOperationDelete:
+		return api.DeleteCertificate(&lb.ZonedAPIDeleteCertificateRequest{
+			Zone:          zone,
+			CertificateID: state.certificateIDs[change.Name],
+		}, scw.WithContext(ctx))
+
+	case lbConfigResourceBackend + "/" + lbConfigOperationCreate:
+		request := *backends[change.Name]
+		request.Zone = zone
+		request.LBID = lbID
+		created, err := api.CreateBackend(&request, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		state.backendIDs[change.Name] = created.ID
+
+	case lbConfigResourceBackend + "/" + lbConfigOperationUpdate:
+		backend := backends[change.Name]
+		backendID := state.backendIDs[change.Name]
+		_, err := api.UpdateBackend(&lb.ZonedAPIUpdateBackendRequest{
+			Zone:                     zone,
+			BackendID:                backendID,
+			Name:                     backend.Name,
+			ForwardProtocol:          backend.ForwardProtocol,
+			ForwardPort:              backend.ForwardPort,
+			ForwardPortAlgorithm:     backend.ForwardPortAlgorithm,
+			StickySessions:           backend.StickySessions,
+			StickySessionsCookieName: backend.StickySessionsCookieName,
+			TimeoutServer:            backend.TimeoutServer,
+			TimeoutConnect:           backend.TimeoutConnect,
+			TimeoutTunnel:            backend.TimeoutTunnel,
+			OnMarkedDownAction:       backend.OnMarkedDownAction,
+			ProxyProtocol:            backend.ProxyProtocol,
+			FailoverHost:             backend.FailoverHost,
+			SslBridging:              backend.SslBridging,
+			IgnoreSslServerVerify:    backend.IgnoreSslServerVerify,
+			RedispatchAttemptCount:   backend.RedispatchAttemptCount,
+			MaxRetries:               backend.MaxRetries,
+			MaxConnections:           backend.MaxConnections,
+			TimeoutQueue:             backend.TimeoutQueue,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		if backend.HealthCheck != nil {
+			_, err = api.UpdateHealthCheck(&lb.ZonedAPIUpdateHealthCheckRequest{
+				Zone:                zone,
+				BackendID:           backendID,
+				Port:                backend.HealthCheck.Port,
+				CheckDelay:          backend.HealthCheck.CheckDelay,
+				CheckTimeout:        backend.HealthCheck.CheckTimeout,
+				CheckMaxRetries:     backend.HealthCheck.CheckMaxRetries,
+				CheckSendProxy:      backend.HealthCheck.CheckSendProxy,
+				TCPConfig:           backend.HealthCheck.TCPConfig,
+				MysqlConfig:         backend.HealthCheck.MysqlConfig,
+				PgsqlConfig:         backend.HealthCheck.PgsqlConfig,
+				LdapConfig:          backend.HealthCheck.LdapConfig,
+				RedisConfig:         backend.HealthCheck.RedisConfig,
+				HTTPConfig:          backend.HealthCheck.HTTPConfig,
+				HTTPSConfig:         backend.HealthCheck.HTTPSConfig,
+				TransientCheckDelay: backend.HealthCheck.TransientCheckDelay,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = api.SetBackendServers(&lb.ZonedAPISetBackendServersRequest{
+			Zone:      zone,
+			BackendID: backendID,
+			ServerIP:  backend.ServerIP,
+		}, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceBackend + "/" + lbConfigOperationDelete:
+		return api.DeleteBackend(&lb.ZonedAPIDeleteBackendRequest{
+			Zone:      zone,
+			BackendID: state.backendIDs[change.Name],
+		}, scw.WithContext(ctx))
+
+	case lbConfigResourceFrontend + "/" + lbConfigOperationCreate:
+		frontend := frontends[change.Name]
+		certificateIDs := lbConfigCertificateIDs(state, frontend)
+		created, err := api.CreateFrontend(&lb.ZonedAPICreateFrontendRequest{
+			Zone:           zone,
+			LBID:           lbID,
+			Name:           frontend.Name,
+			InboundPort:    frontend.InboundPort,
+			BackendID:      state.backendIDs[frontend.Backend],
+			TimeoutClient:  lbConfigTimeout(frontend.TimeoutClient),
+			CertificateIDs: &certificateIDs,
+			EnableHTTP3:    frontend.EnableHTTP3,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		state.frontendIDs[change.Name] = created.ID
+		state.aclIDs[change.Name] = map[string]string{}
+		state.routeIDs[change.Name] = map[string]string{}
+
+	case lbConfigResourceFrontend + "/" + lbConfigOperationUpdate:
+		frontend := frontends[change.Name]
+		certificateIDs := lbConfigCertificateIDs(state, frontend)
+		_, err := api.UpdateFrontend(&lb.ZonedAPIUpdateFrontendRequest{
+			Zone:           zone,
+			FrontendID:     state.frontendIDs[change.Name],
+			Name:           frontend.Name,
+			InboundPort:    frontend.InboundPort,
+			BackendID:      state.backendIDs[frontend.Backend],
+			TimeoutClient:  lbConfigTimeout(frontend.TimeoutClient),
+			CertificateIDs: &certificateIDs,
+			EnableHTTP3:    frontend.EnableHTTP3,
+		}, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceFrontend + "/" + lbConfigOperationDelete:
+		return api.DeleteFrontend(&lb.ZonedAPIDeleteFrontendRequest{
+			Zone:       zone,
+			FrontendID: state.frontendIDs[change.Name],
+		}, scw.WithContext(ctx))
+
+	case lbConfigResourceACL + "/" + lbConfigOperationCreate:
+		request := *acls[change.Frontend][change.Name]
+		request.Zone = zone
+		request.FrontendID = state.frontendIDs[change.Frontend]
+		_, err := api.CreateACL(&request, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceACL + "/" + lbConfigOperationUpdate:
+		acl := acls[change.Frontend][change.Name]
+		_, err := api.UpdateACL(&lb.ZonedAPIUpdateACLRequest{
+			Zone:        zone,
+			ACLID:       state.aclIDs[change.Frontend][change.Name],
+			Name:        acl.Name,
+			Action:      acl.Action,
+			Match:       acl.Match,
+			Index:       acl.Index,
+			Description: &acl.Description,
+		}, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceACL + "/" + lbConfigOperationDelete:
+		return api.DeleteACL(&lb.ZonedAPIDeleteACLRequest{
+			Zone:  zone,
+			ACLID: state.aclIDs[change.Frontend][change.Name],
+		}, scw.WithContext(ctx))
+
+	case lbConfigResourceRoute + "/" + lbConfigOperationCreate:
+		route := routes[change.Frontend][change.Name]
+		_, err := api.CreateRoute(&lb.ZonedAPICreateRouteRequest{
+			Zone:       zone,
+			FrontendID: state.frontendIDs[change.Frontend],
+			BackendID:  state.backendIDs[route.Backend],
+			Match:      route.Match,
+		}, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceRoute + "/" + lbConfigOperationUpdate:
+		route := routes[change.Frontend][change.Name]
+		_, err := api.UpdateRoute(&lb.ZonedAPIUpdateRouteRequest{
+			Zone:      zone,
+			RouteID:   state.routeIDs[change.Frontend][change.Name],
+			BackendID: state.backendIDs[route.Backend],
+			Match:     route.Match,
+		}, scw.WithContext(ctx))
+		return err
+
+	case lbConfigResourceRoute + "/" + lbConfigOperationDelete:
+		return api.DeleteRoute(&lb.ZonedAPIDeleteRouteRequest{
+			Zone:    zone,
+			RouteID: state.routeIDs[change.Frontend][change.Name],
+		}, scw.WithContext(ctx))
+	}
+
+	return nil
+}
+
+func lbConfigCertificateIDs(state *lbConfigState, frontend *lbConfigFrontend) []string {
+	certificateIDs := []string{}
+	for _, certificate := range frontend.Certificates {
+		certificateIDs = append(certificateIDs, state.certificateIDs[certificate])
+	}
+	return certificateIDs
+}
+
+func lbConfigTimeout(milliseconds *int64) *time.Duration {
+	if milliseconds == nil {
+		return nil
+	}
+	timeout := time.Duration(*milliseconds) * time.Millisecond
+	return &timeout
+}
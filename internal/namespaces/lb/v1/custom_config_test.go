@@ -0,0 +1,93 @@
+package lb
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_newLBConfigState(t *testing.T) {
+	backend := &lb.Backend{ID: "backend-id", Name: "web", ForwardPort: 80, Pool: []string{"10.0.0.1"}}
+	state := newLBConfigState(
+		[]*lb.Certificate{{ID: "certificate-id", Name: "cert", Type: lb.CertificateTypeLetsencryt, CommonName: "example.com"}},
+		[]*lb.Backend{backend},
+		[]*lb.Frontend{{ID: "frontend-id", Name: "https", InboundPort: 443, Backend: backend, CertificateIDs: []string{"certificate-id"}}},
+		map[string][]*lb.ACL{"frontend-id": {{ID: "acl-id", Name: "deny-all", Index: 1}}},
+		map[string][]*lb.Route{"frontend-id": {{ID: "route-id", BackendID: "backend-id", Match: &lb.RouteMatch{HostHeader: scw.StringPtr("example.com")}}}},
+	)
+
+	require.Len(t, state.config.Frontends, 1)
+	frontend := state.config.Frontends[0]
+	assert.Equal(t, "web", frontend.Backend)
+	assert.Equal(t, []string{"cert"}, frontend.Certificates)
+	assert.Equal(t, "web", frontend.Routes[0].Backend)
+	assert.Equal(t, []string{"10.0.0.1"}, state.config.Backends[0].ServerIP)
+	assert.Equal(t, "acl-id", state.aclIDs["https"]["deny-all"])
+	assert.Equal(t, "route-id", state.routeIDs["https"]["host-header=example.com"])
+}
+
+func Test_planLBConfigChanges(t *testing.T) {
+	current := &lbConfig{
+		Backends: []*lb.ZonedAPICreateBackendRequest{
+			{Name: "web", ForwardPort: 80},
+			{Name: "legacy", ForwardPort: 8080},
+		},
+		Frontends: []*lbConfigFrontend{
+			{
+				Name:        "http",
+				InboundPort: 80,
+				Backend:     "legacy",
+				ACLs:        []*lb.ZonedAPICreateACLRequest{{Name: "deny-all", Index: 1}},
+			},
+		},
+	}
+	desired := &lbConfig{
+		Certificates: []*lbConfigCertificate{{Name: "cert", Type: lb.CertificateTypeLetsencryt, CommonName: "example.com"}},
+		Backends: []*lb.ZonedAPICreateBackendRequest{
+			{Name: "web", ForwardPort: 8000},
+		},
+		Frontends: []*lbConfigFrontend{
+			{
+				Name:        "http",
+				InboundPort: 80,
+				Backend:     "web",
+				Routes:      []*lbConfigRoute{{Backend: "web", Match: &lb.RouteMatch{Sni: scw.StringPtr("example.com")}}},
+			},
+			{Name: "https", InboundPort: 443, Backend: "web", Certificates: []string{"cert"}},
+		},
+	}
+
+	changes, err := planLBConfigChanges(current, desired)
+	require.NoError(t, err)
+
+	actual := []string(nil)
+	for _, change := range changes {
+		actual = append(actual, change.Operation+" "+change.Resource+" "+change.Frontend+"/"+change.Name)
+	}
+	assert.Equal(t, []string{
+		"create certificate /cert",
+		"update backend /web",
+		"update frontend /http",
+		"create route http/sni=example.com",
+		"create frontend /https",
+		"delete acl http/deny-all",
+		"delete backend /legacy",
+	}, actual)
+
+	t.Run("unknown backend", func(t *testing.T) {
+		_, err := planLBConfigChanges(current, &lbConfig{
+			Frontends: []*lbConfigFrontend{{Name: "http", Backend: "api"}},
+		})
+		assert.EqualError(t, err, `frontend http uses unknown backend "api"`)
+	})
+
+	t.Run("missing custom certificate", func(t *testing.T) {
+		_, err := planLBConfigChanges(current, &lbConfig{
+			Certificates: []*lbConfigCertificate{{Name: "custom", Type: lb.CertificateTypeCustom}},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -148,6 +148,89 @@ func lbDeleteBuilder(c *core.Command) *core.Command {
 	return c
 }
 
+// lbListBuilder hides the secondary columns first and truncates long values when the list does not fit in the terminal
+func lbListBuilder(c *core.Command) *core.Command {
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{
+				FieldName: "ID",
+			},
+			{
+				FieldName: "Name",
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Description",
+				Priority:  -1,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "Status",
+			},
+			{
+				FieldName: "Instances",
+				Priority:  -1,
+			},
+			{
+				FieldName: "OrganizationID",
+				Priority:  -1,
+			},
+			{
+				FieldName: "ProjectID",
+				Priority:  -1,
+			},
+			{
+				FieldName: "IP",
+			},
+			{
+				FieldName: "Tags",
+				Priority:  -2,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "FrontendCount",
+				Priority:  -1,
+			},
+			{
+				FieldName: "BackendCount",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Type",
+			},
+			{
+				FieldName: "SslCompatibilityLevel",
+				Priority:  -1,
+			},
+			{
+				FieldName: "CreatedAt",
+				Priority:  -2,
+			},
+			{
+				FieldName: "UpdatedAt",
+				Priority:  -2,
+			},
+			{
+				FieldName: "PrivateNetworkCount",
+				Priority:  -1,
+			},
+			{
+				FieldName: "RouteCount",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Region",
+				Priority:  -1,
+			},
+			{
+				FieldName: "Zone",
+			},
+		},
+	}
+
+	return c
+}
+
 func lbGetStatsBuilder(c *core.Command) *core.Command {
 	c.View = &core.View{
 		Sections: []*core.ViewSection{
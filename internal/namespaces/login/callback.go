@@ -0,0 +1,95 @@
+package login
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+const callbackPage = `<!DOCTYPE html>
+<html>
+<head><title>Scaleway CLI</title></head>
+<body><p>%s</p></body>
+</html>
+`
+
+// webCallback is a local HTTP server receiving the session token once the user logged in the browser.
+// The state is a random value given to the console and sent back with the token, other requests are rejected.
+type webCallback struct {
+	listener net.Listener
+	server   *http.Server
+	state    string
+	tokens   chan string
+}
+
+func newWebCallback() (*webCallback, error) {
+	state := make([]byte, 16)
+	_, err := rand.Read(state)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only listen on the loopback interface, the token must not be reachable from the network
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the login callback server: %w", err)
+	}
+
+	c := &webCallback{
+		listener: listener,
+		state:    hex.EncodeToString(state),
+		tokens:   make(chan string, 1),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", c.handle)
+	c.server = &http.Server{Handler: mux} //nolint:gosec
+	go func() {
+		_ = c.server.Serve(listener)
+	}()
+
+	return c, nil
+}
+
+// Port returns the port the callback server listens on.
+func (c *webCallback) Port() int {
+	return c.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (c *webCallback) handle(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if r.URL.Query().Get("state") != c.state || token == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprintf(w, callbackPage, "Invalid login request, run scw login again.")
+		return
+	}
+
+	select {
+	case c.tokens <- token:
+		_, _ = fmt.Fprintf(w, callbackPage, "You are logged in the Scaleway CLI, you can close this window.")
+	default:
+		w.WriteHeader(http.StatusConflict)
+		_, _ = fmt.Fprintf(w, callbackPage, "The Scaleway CLI already received a token.")
+	}
+}
+
+// Wait returns the token sent to the callback, or an error when ctx is done first.
+func (c *webCallback) Wait(ctx context.Context) (string, error) {
+	select {
+	case token := <-c.tokens:
+		return token, nil
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("timed out waiting for the login in the browser")
+		}
+		return "", ctx.Err()
+	}
+}
+
+// Close stops the callback server.
+func (c *webCallback) Close() error {
+	return c.server.Close()
+}
@@ -0,0 +1,349 @@
+package login
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-cli/v2/internal/keyring"
+	accountv3 "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// loginURL is the console page authenticating the user, it sends the session token to the callback server of the CLI.
+const loginURL = "https://account.scaleway.com/authenticate"
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(loginCommand())
+}
+
+type loginArgs struct {
+	ProjectID string
+	Keyring   bool
+	Refresh   bool
+	Timeout   time.Duration
+}
+
+func loginCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"config"},
+		Short:  `Login to Scaleway in the browser`,
+		Long: `Login to Scaleway in the browser and store a new API key in the profile, without copying keys from the console.
+
+The browser is opened on the Scaleway console. Once logged in, the console sends a session token to the CLI, which uses it to create an API key of the user whose default project is the chosen project.
+The API key, the organization and the project are stored in the active profile, use -p to store them in another profile.
+
+With refresh, the API key of a profile whose key expired or was revoked is replaced without confirmation by a new one with the same default project. The secret key stays in the keyring if it was stored there. The old API key is not deleted.`,
+		Namespace:            "login",
+		AllowAnonymousClient: true,
+		ShowSensitiveResult:  true,
+		ArgsType:             reflect.TypeOf(loginArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:         "project-id",
+				Short:        "Default project of the API key, prompted when not given",
+				ValidateFunc: core.ValidateProjectID(),
+			},
+			{
+				Name:  "keyring",
+				Short: "Store the secret key in the keyring of the OS instead of the config file",
+			},
+			{
+				Name:  "refresh",
+				Short: "Replace the API key of the profile, keeping its default project and keyring storage",
+			},
+			{
+				Name:    "timeout",
+				Short:   "Time to wait for the login in the browser",
+				Default: core.DefaultValueSetter("5m"),
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Login and store the API key in the profile 'prod'",
+				Raw:   "scw -p prod login",
+			},
+			{
+				Short: "Renew the expired API key of the profile 'prod'",
+				Raw:   "scw -p prod login refresh=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Initialize the config with existing keys",
+				Command: "scw init",
+			},
+			{
+				Short:   "Check the config",
+				Command: "scw config doctor",
+			},
+		},
+		Run: loginRun,
+	}
+}
+
+func loginRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*loginArgs)
+	profileName := core.ExtractProfileName(ctx)
+	configPath := core.ExtractConfigPath(ctx)
+
+	if !interactive.IsInteractive {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("scw login requires an interactive terminal"),
+			Hint: "Use scw init with the keys of an API key",
+		}
+	}
+
+	config, err := scw.LoadConfigFromPath(configPath)
+	if err != nil {
+		if _, isNotFoundError := err.(*scw.ConfigFileNotFoundError); !isNotFoundError {
+			return nil, err
+		}
+		config = &scw.Config{}
+	}
+	profile := &config.Profile
+	if profileName != scw.DefaultProfileName {
+		if config.Profiles == nil {
+			config.Profiles = map[string]*scw.Profile{}
+		}
+		if _, exists := config.Profiles[profileName]; !exists {
+			config.Profiles[profileName] = &scw.Profile{}
+		}
+		profile = config.Profiles[profileName]
+	}
+	oldAccessKey := ""
+	switch {
+	case args.Refresh && profile.AccessKey == nil:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("the profile %s has no API key to refresh", profileName),
+			Hint: "Login without refresh to add an API key to the profile",
+		}
+	case args.Refresh:
+		oldAccessKey = *profile.AccessKey
+		if args.ProjectID == "" && profile.DefaultProjectID != nil {
+			args.ProjectID = *profile.DefaultProjectID
+		}
+		if keyring.IsSecretKeyRef(profile.SecretKey) {
+			args.Keyring = true
+		}
+	case profile.AccessKey != nil:
+		override, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Ctx:    ctx,
+			Prompt: fmt.Sprintf("The profile %s already has an API key, do you want to replace it?", profileName),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !override {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("login canceled"),
+				Hint: "Use -p to login in another profile",
+			}
+		}
+	}
+
+	callback, err := newWebCallback()
+	if err != nil {
+		return nil, err
+	}
+	defer callback.Close()
+
+	authenticateURL := loginPageURL(callback.Port(), callback.state)
+	_, _ = interactive.Printf("Opening the browser to login, if it does not open, go to:\n%s\n", authenticateURL)
+	if err := openBrowser(authenticateURL); err != nil {
+		core.ExtractLogger(ctx).Debugf("failed to open the browser: %s", err)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, args.Timeout)
+	defer cancel()
+	token, err := callback.Wait(waitCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, organizationID, err := createLoginAPIKey(ctx, token, args.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.AccessKey = &apiKey.AccessKey
+	profile.SecretKey = apiKey.SecretKey
+	profile.DefaultOrganizationID = &organizationID
+	profile.DefaultProjectID = &apiKey.DefaultProjectID
+	if profile.DefaultRegion == nil {
+		profile.DefaultRegion = scw.StringPtr(scw.RegionFrPar.String())
+	}
+	if profile.DefaultZone == nil {
+		profile.DefaultZone = scw.StringPtr(scw.ZoneFrPar1.String())
+	}
+	if args.Keyring {
+		err := keyring.Set(apiKey.AccessKey, *apiKey.SecretKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store the secret key in the keyring: %w", err)
+		}
+		profile.SecretKey = scw.StringPtr(keyring.SecretKeyRef)
+	}
+
+	err = config.SaveTo(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldAccessKey != "" {
+		if args.Keyring {
+			err := keyring.Delete(oldAccessKey)
+			if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+				core.ExtractLogger(ctx).Warningf("failed to delete the secret key of %s from the keyring: %s", oldAccessKey, err)
+			}
+		}
+		return &core.SuccessResult{
+			Message: fmt.Sprintf("the API key %s replaces %s in the profile %s, delete the old one with: scw iam api-key delete %s", apiKey.AccessKey, oldAccessKey, profileName, oldAccessKey),
+		}, nil
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("logged in, the API key %s is stored in the profile %s", apiKey.AccessKey, profileName),
+	}, nil
+}
+
+// createLoginAPIKey creates an API key of the user authenticated by the session token, it returns the API key and the organization of the user.
+func createLoginAPIKey(ctx context.Context, token string, projectID string) (*iam.APIKey, string, error) {
+	jwtID, err := jwtTokenID(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	client, err := scw.NewClient(
+		scw.WithJWT(token),
+		scw.WithHTTPClient(core.ExtractHTTPClient(ctx)),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	iamAPI := iam.NewAPI(client)
+
+	jwt, err := iamAPI.GetJWT(&iam.GetJWTRequest{Jti: jwtID}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+	user, err := iamAPI.GetUser(&iam.GetUserRequest{UserID: jwt.AudienceID}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if projectID == "" {
+		projectID, err = promptProjectID(ctx, client, user.OrganizationID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	hostname, _ := os.Hostname()
+	apiKey, err := iamAPI.CreateAPIKey(&iam.CreateAPIKeyRequest{
+		UserID:           &user.ID,
+		DefaultProjectID: &projectID,
+		Description:      fmt.Sprintf("Created by scw login on %s", hostname),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create the API key: %w", err)
+	}
+
+	return apiKey, user.OrganizationID, nil
+}
+
+// promptProjectID prompts the default project of the API key among the projects of the organization.
+func promptProjectID(ctx context.Context, client *scw.Client, organizationID string) (string, error) {
+	res, err := accountv3.NewProjectAPI(client).ListProjects(&accountv3.ProjectAPIListProjectsRequest{
+		OrganizationID: organizationID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to list projects: %w", err)
+	}
+	if len(res.Projects) == 0 {
+		// The default project of an organization has the ID of the organization
+		return organizationID, nil
+	}
+
+	defaultIndex := 0
+	projects := make([]string, len(res.Projects))
+	for i, project := range res.Projects {
+		if project.ID == organizationID {
+			defaultIndex = i
+		}
+		projects[i] = fmt.Sprintf("%s (%s)", project.Name, project.ID)
+	}
+
+	prompt := interactive.ListPrompt{
+		Prompt:       "Choose the default project of the API key",
+		Choices:      projects,
+		DefaultIndex: defaultIndex,
+	}
+	index, err := prompt.Execute(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return res.Projects[index].ID, nil
+}
+
+func loginPageURL(port int, state string) string {
+	params := url.Values{}
+	params.Set("port", strconv.Itoa(port))
+	params.Set("state", state)
+
+	return loginURL + "?" + params.Encode()
+}
+
+// jwtTokenID returns the jti claim of a JWT, the token is not verified as it is only sent to the API.
+func jwtTokenID(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid session token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid session token: %w", err)
+	}
+
+	claims := struct {
+		Jti string `json:"jti"`
+	}{}
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return "", fmt.Errorf("invalid session token: %w", err)
+	}
+	if claims.Jti == "" {
+		return "", fmt.Errorf("invalid session token: missing jti claim")
+	}
+
+	return claims.Jti, nil
+}
+
+func openBrowser(url string) error {
+	var openCmd *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		openCmd = exec.Command("xdg-open", url)
+	case "windows":
+		openCmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	case "darwin":
+		openCmd = exec.Command("open", url)
+	default:
+		return fmt.Errorf("unsupported platform")
+	}
+
+	return openCmd.Start()
+}
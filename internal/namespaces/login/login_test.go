@@ -0,0 +1,51 @@
+package login
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_webCallback(t *testing.T) {
+	callback, err := newWebCallback()
+	require.NoError(t, err)
+	defer callback.Close()
+
+	baseURL := fmt.Sprintf("http://127.0.0.1:%d/callback", callback.Port())
+
+	resp, err := http.Get(baseURL + "?state=wrong&token=session-token")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(baseURL + "?state=" + callback.state + "&token=session-token")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	token, err := callback.Wait(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "session-token", token)
+
+	_, err = callback.Wait(ctx)
+	assert.Error(t, err)
+}
+
+func Test_jwtTokenID(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"jti":"11111111-1111-1111-1111-111111111111"}`))
+
+	jwtID, err := jwtTokenID("header." + payload + ".signature")
+	require.NoError(t, err)
+	assert.Equal(t, "11111111-1111-1111-1111-111111111111", jwtID)
+
+	_, err = jwtTokenID("not-a-jwt")
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package maintenance
+
+import (
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		maintenanceRoot(),
+		maintenanceCalendarCommand(),
+	)
+}
+
+func maintenanceRoot() *core.Command {
+	return &core.Command{
+		Short:     `Scheduled maintenances of managed products`,
+		Namespace: "maintenance",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
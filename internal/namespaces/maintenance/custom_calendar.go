@@ -0,0 +1,262 @@
+package maintenance
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	documentdb "github.com/scaleway/scaleway-sdk-go/api/documentdb/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// maintenanceEvent is an upcoming maintenance of a resource, StartsAt is nil when the maintenance is not scheduled yet.
+type maintenanceEvent struct {
+	StartsAt     *time.Time
+	StopsAt      *time.Time
+	Product      string
+	ResourceID   string
+	ResourceName string
+	Location     string
+	Reason       string
+}
+
+type maintenanceCalendarArgs struct {
+	Within    time.Duration
+	ProjectID *string
+}
+
+func maintenanceCalendarCommand() *core.Command {
+	return &core.Command{
+		Short: `List the upcoming maintenances of the project`,
+		Long: `List the upcoming maintenances of the resources of a project across all regions and zones, ordered by start date:
+- pending maintenances of Database Instances (rdb) and Document Database Instances (document-db)
+- planned maintenances of Instances, maintenances without a date are listed last
+- next auto upgrade window of Kubernetes clusters with auto upgrade enabled
+
+Redis™ clusters and Load Balancers do not expose their maintenances through the API and are not listed.`,
+		Namespace: "maintenance",
+		Resource:  "calendar",
+		ArgsType:  reflect.TypeOf(maintenanceCalendarArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:    "within",
+				Short:   `Only list the maintenances starting within this duration`,
+				Default: core.DefaultValueSetter("30d"),
+			},
+			core.ProjectIDArgSpec(),
+		},
+		Run: maintenanceCalendarRun,
+		Examples: []*core.Example{
+			{
+				Short: "List the maintenances of the next 7 days",
+				Raw:   "scw maintenance calendar within=7d",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Get a Database Instance and its maintenances",
+				Command: "scw rdb instance get",
+			},
+		},
+	}
+}
+
+func maintenanceCalendarRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*maintenanceCalendarArgs)
+	client := core.ExtractClient(ctx)
+
+	projectID := args.ProjectID
+	if projectID == nil {
+		if defaultProjectID, exists := client.GetDefaultProjectID(); exists {
+			projectID = &defaultProjectID
+		}
+	}
+
+	now := time.Now()
+	events := []*maintenanceEvent(nil)
+
+	rdbAPI := rdb.NewAPI(client)
+	for _, region := range rdbAPI.Regions() {
+		resp, err := rdbAPI.ListInstances(&rdb.ListInstancesRequest{
+			Region:    region,
+			ProjectID: projectID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, dbInstance := range resp.Instances {
+			events = append(events, rdbMaintenanceEvents(dbInstance)...)
+		}
+	}
+
+	documentdbAPI := documentdb.NewAPI(client)
+	for _, region := range documentdbAPI.Regions() {
+		resp, err := documentdbAPI.ListInstances(&documentdb.ListInstancesRequest{
+			Region:    region,
+			ProjectID: projectID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, dbInstance := range resp.Instances {
+			events = append(events, documentdbMaintenanceEvents(dbInstance)...)
+		}
+	}
+
+	instanceAPI := instance.NewAPI(client)
+	for _, zone := range instanceAPI.Zones() {
+		resp, err := instanceAPI.ListServers(&instance.ListServersRequest{
+			Zone:    zone,
+			Project: projectID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, server := range resp.Servers {
+			events = append(events, serverMaintenanceEvents(server)...)
+		}
+	}
+
+	k8sAPI := k8s.NewAPI(client)
+	for _, region := range k8sAPI.Regions() {
+		resp, err := k8sAPI.ListClusters(&k8s.ListClustersRequest{
+			Region:    region,
+			ProjectID: projectID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		for _, cluster := range resp.Clusters {
+			if event := clusterMaintenanceEvent(cluster, now); event != nil {
+				events = append(events, event)
+			}
+		}
+	}
+
+	return sortMaintenanceEvents(filterMaintenanceEvents(events, now.Add(args.Within))), nil
+}
+
+func rdbMaintenanceEvents(dbInstance *rdb.Instance) []*maintenanceEvent {
+	events := []*maintenanceEvent(nil)
+	for _, maintenance := range dbInstance.Maintenances {
+		if maintenance.Status != rdb.MaintenanceStatusPending {
+			continue
+		}
+		events = append(events, &maintenanceEvent{
+			StartsAt:     maintenance.StartsAt,
+			StopsAt:      maintenance.StopsAt,
+			Product:      "rdb",
+			ResourceID:   dbInstance.ID,
+			ResourceName: dbInstance.Name,
+			Location:     dbInstance.Region.String(),
+			Reason:       maintenance.Reason,
+		})
+	}
+
+	return events
+}
+
+func documentdbMaintenanceEvents(dbInstance *documentdb.Instance) []*maintenanceEvent {
+	events := []*maintenanceEvent(nil)
+	for _, maintenance := range dbInstance.Maintenances {
+		if maintenance.Status != documentdb.MaintenanceStatusPending {
+			continue
+		}
+		events = append(events, &maintenanceEvent{
+			StartsAt:     maintenance.StartsAt,
+			StopsAt:      maintenance.StopsAt,
+			Product:      "document-db",
+			ResourceID:   dbInstance.ID,
+			ResourceName: dbInstance.Name,
+			Location:     dbInstance.Region.String(),
+			Reason:       maintenance.Reason,
+		})
+	}
+
+	return events
+}
+
+func serverMaintenanceEvents(server *instance.Server) []*maintenanceEvent {
+	events := []*maintenanceEvent(nil)
+	for _, maintenance := range server.Maintenances {
+		events = append(events, &maintenanceEvent{
+			StartsAt:     maintenance.StartDate,
+			Product:      "instance",
+			ResourceID:   server.ID,
+			ResourceName: server.Name,
+			Location:     server.Zone.String(),
+			Reason:       maintenance.Reason,
+		})
+	}
+
+	return events
+}
+
+// clusterMaintenanceEvent returns the next auto upgrade window of a cluster, nil when auto upgrade is disabled.
+func clusterMaintenanceEvent(cluster *k8s.Cluster, now time.Time) *maintenanceEvent {
+	if cluster.AutoUpgrade == nil || !cluster.AutoUpgrade.Enabled || cluster.AutoUpgrade.MaintenanceWindow == nil {
+		return nil
+	}
+
+	startsAt := nextMaintenanceWindow(cluster.AutoUpgrade.MaintenanceWindow, now)
+	return &maintenanceEvent{
+		StartsAt:     &startsAt,
+		Product:      "k8s",
+		ResourceID:   cluster.ID,
+		ResourceName: cluster.Name,
+		Location:     cluster.Region.String(),
+		Reason:       "auto upgrade window",
+	}
+}
+
+// nextMaintenanceWindow returns the next start of a weekly maintenance window, windows are defined in UTC.
+func nextMaintenanceWindow(window *k8s.MaintenanceWindow, now time.Time) time.Time {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), now.Day(), int(window.StartHour), 0, 0, 0, time.UTC)
+
+	for day := 0; day < 8; day++ {
+		candidate := start.AddDate(0, 0, day)
+		if candidate.Before(now) {
+			continue
+		}
+		if window.Day == k8s.MaintenanceWindowDayOfTheWeekAny || window.Day.String() == strings.ToLower(candidate.Weekday().String()) {
+			return candidate
+		}
+	}
+
+	return start
+}
+
+// filterMaintenanceEvents removes the maintenances starting after until, maintenances without a date are kept.
+func filterMaintenanceEvents(events []*maintenanceEvent, until time.Time) []*maintenanceEvent {
+	filtered := []*maintenanceEvent(nil)
+	for _, event := range events {
+		if event.StartsAt == nil || !event.StartsAt.After(until) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+// sortMaintenanceEvents sorts maintenances by start date, maintenances without a date last.
+func sortMaintenanceEvents(events []*maintenanceEvent) []*maintenanceEvent {
+	sort.SliceStable(events, func(i, j int) bool {
+		switch {
+		case events[i].StartsAt == nil:
+			return false
+		case events[j].StartsAt == nil:
+			return true
+		default:
+			return events[i].StartsAt.Before(*events[j].StartsAt)
+		}
+	})
+
+	return events
+}
@@ -0,0 +1,65 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_nextMaintenanceWindow(t *testing.T) {
+	// 2024-01-03 is a Wednesday
+	now := time.Date(2024, 1, 3, 10, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), nextMaintenanceWindow(&k8s.MaintenanceWindow{
+		StartHour: 12,
+		Day:       k8s.MaintenanceWindowDayOfTheWeekAny,
+	}, now))
+	assert.Equal(t, time.Date(2024, 1, 4, 3, 0, 0, 0, time.UTC), nextMaintenanceWindow(&k8s.MaintenanceWindow{
+		StartHour: 3,
+		Day:       k8s.MaintenanceWindowDayOfTheWeekAny,
+	}, now))
+	assert.Equal(t, time.Date(2024, 1, 10, 3, 0, 0, 0, time.UTC), nextMaintenanceWindow(&k8s.MaintenanceWindow{
+		StartHour: 3,
+		Day:       k8s.MaintenanceWindowDayOfTheWeekWednesday,
+	}, now))
+	assert.Equal(t, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), nextMaintenanceWindow(&k8s.MaintenanceWindow{
+		StartHour: 0,
+		Day:       k8s.MaintenanceWindowDayOfTheWeekSunday,
+	}, now))
+}
+
+func Test_maintenanceEvents(t *testing.T) {
+	now := time.Date(2024, 1, 3, 10, 30, 0, 0, time.UTC)
+
+	events := rdbMaintenanceEvents(&rdb.Instance{
+		ID:     "db",
+		Region: scw.RegionFrPar,
+		Maintenances: []*rdb.Maintenance{
+			{StartsAt: scw.TimePtr(now.Add(48 * time.Hour)), Status: rdb.MaintenanceStatusPending, Reason: "minor upgrade"},
+			{StartsAt: scw.TimePtr(now.Add(-48 * time.Hour)), Status: rdb.MaintenanceStatusDone},
+			{StartsAt: scw.TimePtr(now.Add(60 * 24 * time.Hour)), Status: rdb.MaintenanceStatusPending},
+		},
+	})
+	assert.Len(t, events, 2)
+
+	events = append(events, &maintenanceEvent{ResourceID: "server"})
+	events = append(events, clusterMaintenanceEvent(&k8s.Cluster{
+		ID: "cluster",
+		AutoUpgrade: &k8s.ClusterAutoUpgrade{
+			Enabled:           true,
+			MaintenanceWindow: &k8s.MaintenanceWindow{StartHour: 12, Day: k8s.MaintenanceWindowDayOfTheWeekAny},
+		},
+	}, now))
+	assert.Nil(t, clusterMaintenanceEvent(&k8s.Cluster{AutoUpgrade: &k8s.ClusterAutoUpgrade{}}, now))
+
+	events = sortMaintenanceEvents(filterMaintenanceEvents(events, now.Add(30*24*time.Hour)))
+	ids := []string(nil)
+	for _, event := range events {
+		ids = append(ids, event.ResourceID)
+	}
+	assert.Equal(t, []string{"cluster", "db", "server"}, ids)
+}
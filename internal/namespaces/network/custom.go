@@ -0,0 +1,24 @@
+package network
+
+import (
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		networkRoot(),
+		networkLatencyCommand(),
+	)
+}
+
+func networkRoot() *core.Command {
+	return &core.Command{
+		Groups:    []string{"utility"},
+		Short:     `Network diagnostic commands`,
+		Namespace: "network",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
@@ -0,0 +1,202 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// latencyEndpoint is a public endpoint hosted in a region.
+type latencyEndpoint struct {
+	Region  string
+	Service string
+	Host    string
+}
+
+// latencyResult is the TCP connection time to an endpoint, in milliseconds.
+type latencyResult struct {
+	Region   string
+	Service  string
+	Endpoint string
+	MinMs    float64
+	AvgMs    float64
+	MaxMs    float64
+	Error    string
+}
+
+type networkLatencyArgs struct {
+	Region  []scw.Region
+	Count   uint32
+	Timeout time.Duration
+}
+
+func networkLatencyCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Measure the latency to Scaleway regions`,
+		Long: `Measure the time needed to open a TCP connection from this machine to public endpoints of each Scaleway region and to the API, sorted from the closest region.
+The zones of a region are in the same metropolitan area and do not expose public endpoints of their own, the latency of a region applies to all its zones.`,
+		Namespace:            "network",
+		Resource:             "latency",
+		ArgsType:             reflect.TypeOf(networkLatencyArgs{}),
+		AllowAnonymousClient: true,
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "region.{index}",
+				Short:      `Regions to measure, all regions by default`,
+				EnumValues: regionsEnumValues(),
+			},
+			{
+				Name:    "count",
+				Short:   `Number of connections opened to each endpoint`,
+				Default: core.DefaultValueSetter("3"),
+			},
+			{
+				Name:    "timeout",
+				Short:   `Maximum time to open a connection`,
+				Default: core.DefaultValueSetter("2s"),
+			},
+		},
+		Run: networkLatencyRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Region", FieldName: "Region"},
+				{Label: "Service", FieldName: "Service"},
+				{Label: "Endpoint", FieldName: "Endpoint"},
+				{Label: "Min (ms)", FieldName: "MinMs"},
+				{Label: "Avg (ms)", FieldName: "AvgMs"},
+				{Label: "Max (ms)", FieldName: "MaxMs"},
+				{Label: "Error", FieldName: "Error"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Measure the latency to all regions",
+				Raw:   "scw network latency",
+			},
+			{
+				Short: "Measure the latency to Paris and Amsterdam with more connections",
+				Raw:   "scw network latency region.0=fr-par region.1=nl-ams count=10",
+			},
+		},
+	}
+}
+
+func regionsEnumValues() []string {
+	regions := make([]string, 0, len(scw.AllRegions))
+	for _, region := range scw.AllRegions {
+		regions = append(regions, region.String())
+	}
+	return regions
+}
+
+// latencyEndpoints returns the endpoints to measure for the given regions, the API is always measured.
+func latencyEndpoints(regions []scw.Region) []*latencyEndpoint {
+	if len(regions) == 0 {
+		regions = scw.AllRegions
+	}
+
+	endpoints := []*latencyEndpoint{
+		{Region: "global", Service: "API", Host: "api.scaleway.com"},
+	}
+	for _, region := range regions {
+		endpoints = append(endpoints,
+			&latencyEndpoint{Region: region.String(), Service: "Object Storage", Host: fmt.Sprintf("s3.%s.scw.cloud", region)},
+			&latencyEndpoint{Region: region.String(), Service: "Container Registry", Host: fmt.Sprintf("rg.%s.scw.cloud", region)},
+		)
+	}
+
+	return endpoints
+}
+
+func networkLatencyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*networkLatencyArgs)
+	if args.Count == 0 {
+		return nil, fmt.Errorf("count must be greater than 0")
+	}
+
+	endpoints := latencyEndpoints(args.Region)
+	results := make([]*latencyResult, len(endpoints))
+
+	wg := sync.WaitGroup{}
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *latencyEndpoint) {
+			defer wg.Done()
+			results[i] = measureLatency(ctx, endpoint, args.Count, args.Timeout)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	sortLatencyResults(results)
+
+	return results, nil
+}
+
+// measureLatency opens count TCP connections to the HTTPS port of an endpoint, one after the other.
+func measureLatency(ctx context.Context, endpoint *latencyEndpoint, count uint32, timeout time.Duration) *latencyResult {
+	result := &latencyResult{
+		Region:   endpoint.Region,
+		Service:  endpoint.Service,
+		Endpoint: endpoint.Host,
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	durations := make([]time.Duration, 0, count)
+	for i := uint32(0); i < count; i++ {
+		start := time.Now()
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(endpoint.Host, "443"))
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		durations = append(durations, time.Since(start))
+		_ = conn.Close()
+	}
+
+	result.MinMs, result.AvgMs, result.MaxMs = latencyStats(durations)
+
+	return result
+}
+
+// latencyStats returns the minimum, average and maximum of durations in milliseconds, rounded to a tenth.
+func latencyStats(durations []time.Duration) (float64, float64, float64) {
+	if len(durations) == 0 {
+		return 0, 0, 0
+	}
+
+	minDuration, maxDuration, total := durations[0], durations[0], time.Duration(0)
+	for _, d := range durations {
+		if d < minDuration {
+			minDuration = d
+		}
+		if d > maxDuration {
+			maxDuration = d
+		}
+		total += d
+	}
+
+	return durationToMs(minDuration), durationToMs(total / time.Duration(len(durations))), durationToMs(maxDuration)
+}
+
+func durationToMs(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*10) / 10
+}
+
+// sortLatencyResults sorts results by ascending average latency, failed measures last.
+func sortLatencyResults(results []*latencyResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if (results[i].Error == "") != (results[j].Error == "") {
+			return results[i].Error == ""
+		}
+		return results[i].AvgMs < results[j].AvgMs
+	})
+}
@@ -0,0 +1,42 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_latencyEndpoints(t *testing.T) {
+	endpoints := latencyEndpoints([]scw.Region{scw.RegionNlAms})
+	assert.Len(t, endpoints, 3)
+	assert.Equal(t, "api.scaleway.com", endpoints[0].Host)
+	assert.Equal(t, "s3.nl-ams.scw.cloud", endpoints[1].Host)
+	assert.Equal(t, "rg.nl-ams.scw.cloud", endpoints[2].Host)
+
+	assert.Len(t, latencyEndpoints(nil), 1+2*len(scw.AllRegions))
+}
+
+func Test_latencyStats(t *testing.T) {
+	minMs, avgMs, maxMs := latencyStats([]time.Duration{
+		12340 * time.Microsecond,
+		10 * time.Millisecond,
+		20 * time.Millisecond,
+	})
+	assert.Equal(t, 10.0, minMs)
+	assert.Equal(t, 14.1, avgMs)
+	assert.Equal(t, 20.0, maxMs)
+}
+
+func Test_sortLatencyResults(t *testing.T) {
+	results := []*latencyResult{
+		{Region: "fr-par", AvgMs: 20},
+		{Region: "pl-waw", Error: "timeout"},
+		{Region: "nl-ams", AvgMs: 10},
+	}
+	sortLatencyResults(results)
+	assert.Equal(t, "nl-ams", results[0].Region)
+	assert.Equal(t, "fr-par", results[1].Region)
+	assert.Equal(t, "pl-waw", results[2].Region)
+}
@@ -10,6 +10,10 @@ func GetCommands() *core.Commands {
 		objectConfig(),
 		configGetCommand(),
 		configInstallCommand(),
+		objectBucket(),
+		bucketWebsiteEnableCommand(),
+		bucketWebsiteDeployCommand(),
+		bucketUploadCommand(),
 	)
 }
 
@@ -0,0 +1,139 @@
+package object
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"reflect"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type bucketUploadArgs struct {
+	BucketName  string
+	File        string
+	Key         string
+	ContentType string
+	PartSize    string
+	Concurrency int
+	Region      scw.Region
+}
+
+type bucketUploadResult struct {
+	*upload.Result
+	Bucket string `json:"bucket"`
+	Key    string `json:"key"`
+}
+
+func bucketUploadCommand() *core.Command {
+	return &core.Command{
+		Namespace: "object",
+		Resource:  "bucket",
+		Verb:      "upload",
+		Short:     "Upload a large file to a bucket",
+		Long: `Upload a file to a bucket with a multipart upload: the file is uploaded in parts, in parallel, and the failed parts are retried.
+When the upload is interrupted, running the same command again resumes it: the parts already uploaded are checked against their checksum and skipped.`,
+		ArgsType: reflect.TypeOf(bucketUploadArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "bucket-name",
+				Short:      "Name of the bucket",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:     "file",
+				Short:    "Path of the file to upload",
+				Required: true,
+			},
+			{
+				Name:  "key",
+				Short: "Key of the uploaded object, default to the name of the file",
+			},
+			{
+				Name:  "content-type",
+				Short: "Content type of the uploaded object, guessed from the file extension by default",
+			},
+			{
+				Name:    "part-size",
+				Short:   "Size of the uploaded parts, at least 5MiB",
+				Default: core.DefaultValueSetter("16MB"),
+			},
+			{
+				Name:    "concurrency",
+				Short:   "Number of parts uploaded in parallel",
+				Default: core.DefaultValueSetter("4"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Upload a disk image to a bucket",
+				Raw:   "scw object bucket upload my-bucket file=./disk.qcow2 key=images/disk.qcow2",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Import a QCOW file as an Instance snapshot",
+				Command: "scw instance snapshot import",
+			},
+		},
+		Run: bucketUploadRun,
+	}
+}
+
+func bucketUploadRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*bucketUploadArgs)
+
+	// scw.Size arguments only support GB, part sizes are usually given in MB.
+	partSize, err := humanize.ParseBytes(args.PartSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid part size %s: %w", args.PartSize, err)
+	}
+	if partSize < s3MinPartSize {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("part size %s is too small", args.PartSize),
+			Hint: "The parts of a multipart upload must be at least 5MiB",
+		}
+	}
+
+	key := args.Key
+	if key == "" {
+		key = filepath.Base(args.File)
+	}
+	contentType := args.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(args.File))
+	}
+
+	headers := http.Header{}
+	if contentType != "" {
+		headers.Set("Content-Type", contentType)
+	}
+	target, err := newS3MultipartTarget(ctx, args.Region, args.BucketName, key, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	config := core.UploadConfig(ctx, "upload")
+	config.PartSize = int64(partSize)
+	config.Concurrency = args.Concurrency
+	result, err := upload.File(ctx, args.File, target, config)
+	if err != nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("failed to upload %s: %w", args.File, err),
+			Hint: "Run the same command again to resume the upload",
+		}
+	}
+
+	return &bucketUploadResult{
+		Result: result,
+		Bucket: args.BucketName,
+		Key:    key,
+	}, nil
+}
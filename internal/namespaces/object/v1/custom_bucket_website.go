@@ -0,0 +1,317 @@
+package object
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type bucketWebsiteEnableArgs struct {
+	BucketName    string
+	IndexDocument string
+	ErrorDocument string
+	Region        scw.Region
+}
+
+type bucketWebsiteDeployArgs struct {
+	BucketName             string
+	Src                    string
+	Prefix                 string
+	IndexDocument          string
+	ErrorDocument          string
+	CacheControl           string
+	HTMLCacheControl       string
+	EdgeServicesPipelineID string
+	Region                 scw.Region
+}
+
+type bucketWebsiteDeployResult struct {
+	URL                string `json:"url"`
+	UploadedFiles      int    `json:"uploaded_files"`
+	EdgeServicesPurged bool   `json:"edge_services_purged"`
+}
+
+func objectBucket() *core.Command {
+	return &core.Command{
+		Short:     `Manage buckets`,
+		Long:      `Bucket management commands.`,
+		Namespace: "object",
+		Resource:  `bucket`,
+	}
+}
+
+func bucketWebsiteArgSpecs() core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:       "bucket-name",
+			Short:      "Name of the bucket",
+			Required:   true,
+			Positional: true,
+		},
+		{
+			Name:    "index-document",
+			Short:   "Suffix of the document served for directory requests",
+			Default: core.DefaultValueSetter("index.html"),
+		},
+		{
+			Name:  "error-document",
+			Short: "Key of the document served on errors",
+		},
+	}
+}
+
+func bucketWebsiteEnableCommand() *core.Command {
+	argSpecs := bucketWebsiteArgSpecs()
+	argSpecs = append(argSpecs, core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw))
+
+	return &core.Command{
+		Namespace: "object",
+		Resource:  "bucket",
+		Verb:      "website-enable",
+		Short:     "Enable website hosting on a bucket",
+		Long:      "Enable website hosting on a bucket and print the website URL.",
+		ArgsType:  reflect.TypeOf(bucketWebsiteEnableArgs{}),
+		ArgSpecs:  argSpecs,
+		Examples: []*core.Example{
+			{
+				Short: "Enable website hosting with a custom error page",
+				Raw:   "scw object bucket website-enable my-bucket error-document=404.html",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Deploy a static website to a bucket",
+				Command: "scw object bucket website-deploy",
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*bucketWebsiteEnableArgs)
+
+			client, err := newS3Client(ctx, args.Region)
+			if err != nil {
+				return nil, err
+			}
+
+			err = client.putBucketWebsite(ctx, args.BucketName, args.IndexDocument, args.ErrorDocument)
+			if err != nil {
+				return nil, err
+			}
+
+			return &core.SuccessResult{
+				Message: "Website hosting enabled on " + s3WebsiteEndpoint(args.BucketName, args.Region),
+			}, nil
+		},
+	}
+}
+
+func bucketWebsiteDeployCommand() *core.Command {
+	argSpecs := bucketWebsiteArgSpecs()
+	argSpecs = append(argSpecs,
+		&core.ArgSpec{
+			Name:     "src",
+			Short:    "Path of the directory to upload, usually the output directory of the site build",
+			Required: true,
+		},
+		&core.ArgSpec{
+			Name:  "prefix",
+			Short: "Prefix of the uploaded object keys",
+		},
+		&core.ArgSpec{
+			Name:    "cache-control",
+			Short:   "Cache-Control header of the uploaded assets",
+			Default: core.DefaultValueSetter("public, max-age=31536000"),
+		},
+		&core.ArgSpec{
+			Name:    "html-cache-control",
+			Short:   "Cache-Control header of the uploaded HTML documents",
+			Default: core.DefaultValueSetter("no-cache"),
+		},
+		&core.ArgSpec{
+			Name:  "edge-services-pipeline-id",
+			Short: "ID of the Edge Services pipeline in front of the bucket, its cache is purged after the upload",
+		},
+		core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+	)
+
+	return &core.Command{
+		Namespace: "object",
+		Resource:  "bucket",
+		Verb:      "website-deploy",
+		Short:     "Deploy a static website to a bucket",
+		Long: `Enable website hosting on a bucket and upload a local directory to it.
+Files are uploaded publicly readable with a content type guessed from their extension.
+HTML documents and other assets get their own Cache-Control header so that new deployments are served right away.`,
+		ArgsType: reflect.TypeOf(bucketWebsiteDeployArgs{}),
+		ArgSpecs: argSpecs,
+		Examples: []*core.Example{
+			{
+				Short: "Deploy the build directory of a site",
+				Raw:   "scw object bucket website-deploy my-bucket src=./dist",
+			},
+			{
+				Short: "Deploy a site and purge the cache of its Edge Services pipeline",
+				Raw:   "scw object bucket website-deploy my-bucket src=./dist edge-services-pipeline-id=11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Enable website hosting on a bucket",
+				Command: "scw object bucket website-enable",
+			},
+		},
+		Run: bucketWebsiteDeployRun,
+	}
+}
+
+func bucketWebsiteDeployRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*bucketWebsiteDeployArgs)
+
+	files, err := listWebsiteFiles(args.Src)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newS3Client(ctx, args.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	err = client.putBucketWebsite(ctx, args.BucketName, args.IndexDocument, args.ErrorDocument)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := core.ExtractLogger(ctx)
+	for _, file := range files {
+		filePath := filepath.Join(args.Src, filepath.FromSlash(file))
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		contentType := websiteFileContentType(file, content)
+		cacheControl := args.CacheControl
+		if strings.HasPrefix(contentType, "text/html") {
+			cacheControl = args.HTMLCacheControl
+		}
+
+		key := path.Join(args.Prefix, file)
+		logger.Debugf("uploading %s (%s, %s)\n", key, contentType, cacheControl)
+		if len(content) > int(upload.DefaultPartSize) {
+			err = uploadLargeWebsiteFile(ctx, args, filePath, key, contentType, cacheControl)
+		} else {
+			err = client.putObject(ctx, args.BucketName, key, content, contentType, cacheControl)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", file, err)
+		}
+	}
+
+	result := &bucketWebsiteDeployResult{
+		URL:           s3WebsiteEndpoint(args.BucketName, args.Region),
+		UploadedFiles: len(files),
+	}
+	if args.Prefix != "" {
+		result.URL += "/" + strings.Trim(args.Prefix, "/") + "/"
+	}
+
+	if args.EdgeServicesPipelineID != "" {
+		err = purgeEdgeServicesPipeline(ctx, args.EdgeServicesPipelineID)
+		if err != nil {
+			return nil, fmt.Errorf("files were uploaded but the edge services cache could not be purged: %w", err)
+		}
+		result.EdgeServicesPurged = true
+	}
+
+	return result, nil
+}
+
+// uploadLargeWebsiteFile uploads a file with a multipart upload, which is resumed if the deployment is run again after an interruption.
+func uploadLargeWebsiteFile(ctx context.Context, args *bucketWebsiteDeployArgs, filePath string, key string, contentType string, cacheControl string) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	if cacheControl != "" {
+		headers.Set("Cache-Control", cacheControl)
+	}
+	headers.Set("X-Amz-Acl", "public-read")
+
+	target, err := newS3MultipartTarget(ctx, args.Region, args.BucketName, key, headers)
+	if err != nil {
+		return err
+	}
+	_, err = upload.File(ctx, filePath, target, core.UploadConfig(ctx, "upload "+key))
+	return err
+}
+
+// listWebsiteFiles returns the slash separated paths of the regular files of a directory, relative to it.
+func listWebsiteFiles(src string) ([]string, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", src)
+	}
+
+	files := []string(nil)
+	err = filepath.WalkDir(src, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(src, filePath)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relPath))
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// websiteFileContentType guesses the content type of a file from its extension, then from its content.
+func websiteFileContentType(file string, content []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(file)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(content)
+}
+
+// purgeEdgeServicesPipeline purges the whole cache of an Edge Services pipeline.
+func purgeEdgeServicesPipeline(ctx context.Context, pipelineID string) error {
+	scwReq := &scw.ScalewayRequest{
+		Method: "POST",
+		Path:   "/edge-services/v1alpha1/purge-requests",
+	}
+
+	err := scwReq.SetBody(map[string]interface{}{
+		"pipeline_id": pipelineID,
+		"all":         true,
+	})
+	if err != nil {
+		return err
+	}
+
+	var resp json.RawMessage
+	return core.ExtractClient(ctx).Do(scwReq, &resp, scw.WithContext(ctx))
+}
@@ -0,0 +1,207 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// s3Client is a minimal S3 client signing its requests with AWS signature version 4.
+// It only covers the few bucket operations needed by the object commands.
+type s3Client struct {
+	httpClient *http.Client
+	accessKey  string
+	secretKey  string
+	region     scw.Region
+}
+
+func newS3Client(ctx context.Context, region scw.Region) (*s3Client, error) {
+	config, err := newS3Config(ctx, region, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &s3Client{
+		httpClient: core.ExtractHTTPClient(ctx),
+		accessKey:  config.AccessKey,
+		secretKey:  config.SecretKey,
+		region:     region,
+	}, nil
+}
+
+func s3BucketEndpoint(bucket string, region scw.Region) string {
+	return fmt.Sprintf("https://%s.s3.%s.scw.cloud", bucket, region)
+}
+
+func s3WebsiteEndpoint(bucket string, region scw.Region) string {
+	return fmt.Sprintf("https://%s.s3-website.%s.scw.cloud", bucket, region)
+}
+
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+func (e *s3Error) Error() string {
+	return fmt.Sprintf("s3 error %s: %s", e.Code, e.Message)
+}
+
+func (c *s3Client) do(ctx context.Context, method string, bucket string, key string, query string, headers http.Header, body []byte) error {
+	_, _, err := c.send(ctx, method, bucket, key, query, headers, body)
+	return err
+}
+
+// send sends a request and returns the headers and the body of its response.
+func (c *s3Client) send(ctx context.Context, method string, bucket string, key string, query string, headers http.Header, body []byte) (http.Header, []byte, error) {
+	u := s3BucketEndpoint(bucket, c.region) + "/" + s3EscapePath(key)
+	if query != "" {
+		u += "?" + query
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	for name, values := range headers {
+		req.Header[name] = values
+	}
+
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	signS3Request(req, c.accessKey, c.secretKey, c.region.String(), time.Now())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode >= 300 {
+		s3Err := &s3Error{}
+		if xml.Unmarshal(respBody, s3Err) != nil || s3Err.Code == "" {
+			return nil, nil, fmt.Errorf("s3 request failed with status %s", resp.Status)
+		}
+		return nil, nil, s3Err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp.Header, respBody, nil
+}
+
+func (c *s3Client) putObject(ctx context.Context, bucket string, key string, body []byte, contentType string, cacheControl string) error {
+	headers := http.Header{}
+	headers.Set("Content-Type", contentType)
+	if cacheControl != "" {
+		headers.Set("Cache-Control", cacheControl)
+	}
+	headers.Set("X-Amz-Acl", "public-read")
+
+	return c.do(ctx, http.MethodPut, bucket, key, "", headers, body)
+}
+
+func (c *s3Client) putBucketWebsite(ctx context.Context, bucket string, indexDocument string, errorDocument string) error {
+	type document struct {
+		Key    string `xml:"Key,omitempty"`
+		Suffix string `xml:"Suffix,omitempty"`
+	}
+	config := struct {
+		XMLName       xml.Name  `xml:"WebsiteConfiguration"`
+		IndexDocument document  `xml:"IndexDocument"`
+		ErrorDocument *document `xml:"ErrorDocument,omitempty"`
+	}{
+		IndexDocument: document{Suffix: indexDocument},
+	}
+	if errorDocument != "" {
+		config.ErrorDocument = &document{Key: errorDocument}
+	}
+
+	body, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	bodyMD5 := md5.Sum(body) //nolint:gosec
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml")
+	headers.Set("Content-Md5", base64.StdEncoding.EncodeToString(bodyMD5[:]))
+
+	return c.do(ctx, http.MethodPut, bucket, "", "website=", headers, body)
+}
+
+// s3EscapePath escapes an object key, keeping its slashes.
+func s3EscapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "+", "%2B")
+	}
+	return strings.Join(segments, "/")
+}
+
+// signS3Request adds the Authorization header of AWS signature version 4 to a request.
+// All headers of the request are signed, the X-Amz-Content-Sha256 header must already be set.
+func signS3Request(req *http.Request, accessKey string, secretKey string, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+
+	canonicalHeaders := strings.Builder{}
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+
+	hmacSHA256 := func(key []byte, data string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(data))
+		return h.Sum(nil)
+	}
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s", accessKey, scope, signedHeaders, signature))
+}
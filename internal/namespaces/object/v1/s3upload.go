@@ -0,0 +1,128 @@
+package object
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// s3MinPartSize is the minimum size of the parts of a multipart upload, except the last one.
+const s3MinPartSize = 5 * 1024 * 1024
+
+// s3MultipartTarget uploads a file to an object with a multipart upload.
+type s3MultipartTarget struct {
+	client *s3Client
+	bucket string
+	key    string
+	// headers are sent when the upload is started, such as Content-Type
+	headers http.Header
+}
+
+// NewUploadTarget returns the target of the upload of a file to an object of a bucket,
+// to be used with the upload package.
+func NewUploadTarget(ctx context.Context, region scw.Region, bucket string, key string) (upload.Target, error) {
+	return newS3MultipartTarget(ctx, region, bucket, key, http.Header{})
+}
+
+func newS3MultipartTarget(ctx context.Context, region scw.Region, bucket string, key string, headers http.Header) (*s3MultipartTarget, error) {
+	client, err := newS3Client(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	return &s3MultipartTarget{
+		client:  client,
+		bucket:  bucket,
+		key:     key,
+		headers: headers,
+	}, nil
+}
+
+func (t *s3MultipartTarget) ID() string {
+	return fmt.Sprintf("s3://%s/%s?region=%s", t.bucket, t.key, t.client.region)
+}
+
+func (t *s3MultipartTarget) Start(ctx context.Context) (string, error) {
+	_, body, err := t.client.send(ctx, http.MethodPost, t.bucket, t.key, "uploads=", t.headers.Clone(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	result := struct {
+		UploadID string `xml:"UploadId"`
+	}{}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("invalid response to the creation of the multipart upload: %w", err)
+	}
+	if result.UploadID == "" {
+		return "", fmt.Errorf("invalid response to the creation of the multipart upload: missing upload ID")
+	}
+	return result.UploadID, nil
+}
+
+func (t *s3MultipartTarget) UploadPart(ctx context.Context, uploadID string, part *upload.Part, body io.ReadSeeker) (string, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	// The part is signed with the SHA-256 of its content, the checksum is checked by the server.
+	contentHash := sha256.Sum256(content)
+	if hex.EncodeToString(contentHash[:]) != part.Checksum {
+		return "", fmt.Errorf("part %d changed during the upload", part.Number)
+	}
+
+	query := url.Values{
+		"partNumber": {strconv.Itoa(part.Number)},
+		"uploadId":   {uploadID},
+	}
+	headers, _, err := t.client.send(ctx, http.MethodPut, t.bucket, t.key, query.Encode(), http.Header{}, content)
+	if err != nil {
+		return "", err
+	}
+	return headers.Get("ETag"), nil
+}
+
+func (t *s3MultipartTarget) Complete(ctx context.Context, uploadID string, parts []*upload.Part) error {
+	type completedPart struct {
+		PartNumber int    `xml:"PartNumber"`
+		ETag       string `xml:"ETag"`
+	}
+	completeUpload := struct {
+		XMLName xml.Name         `xml:"CompleteMultipartUpload"`
+		Parts   []*completedPart `xml:"Part"`
+	}{}
+	for _, part := range parts {
+		completeUpload.Parts = append(completeUpload.Parts, &completedPart{
+			PartNumber: part.Number,
+			ETag:       part.ETag,
+		})
+	}
+
+	body, err := xml.Marshal(completeUpload)
+	if err != nil {
+		return err
+	}
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml")
+
+	query := url.Values{"uploadId": {uploadID}}
+	_, respBody, err := t.client.send(ctx, http.MethodPost, t.bucket, t.key, query.Encode(), headers, body)
+	if err != nil {
+		return err
+	}
+
+	// The completion can fail after a 200 OK response, the error is then in the body.
+	s3Err := &s3Error{}
+	if xml.Unmarshal(respBody, s3Err) == nil && s3Err.Code != "" {
+		return s3Err
+	}
+	return nil
+}
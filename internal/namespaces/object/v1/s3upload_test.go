@@ -0,0 +1,104 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3Multipart emulates the multipart upload requests of an S3 bucket.
+type fakeS3Multipart struct {
+	mu      sync.Mutex
+	headers http.Header
+	parts   map[int][]byte
+	object  []byte
+}
+
+func (f *fakeS3Multipart) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	query := req.URL.Query()
+	respBody := ""
+	header := http.Header{}
+
+	switch {
+	case req.Method == http.MethodPost && query.Has("uploads"):
+		f.headers = req.Header.Clone()
+		f.parts = map[int][]byte{}
+		respBody = "<InitiateMultipartUploadResult><UploadId>upload-1</UploadId></InitiateMultipartUploadResult>"
+	case req.Method == http.MethodPut && query.Get("uploadId") == "upload-1":
+		partNumber, _ := strconv.Atoi(query.Get("partNumber"))
+		f.parts[partNumber] = body
+		header.Set("ETag", `"etag-`+query.Get("partNumber")+`"`)
+	case req.Method == http.MethodPost && query.Get("uploadId") == "upload-1":
+		completeUpload := struct {
+			Parts []struct {
+				PartNumber int
+				ETag       string
+			} `xml:"Part"`
+		}{}
+		if err := xml.Unmarshal(body, &completeUpload); err != nil {
+			return nil, err
+		}
+		for _, part := range completeUpload.Parts {
+			if part.ETag != `"etag-`+strconv.Itoa(part.PartNumber)+`"` {
+				respBody = "<Error><Code>InvalidPart</Code><Message>invalid etag</Message></Error>"
+			}
+			f.object = append(f.object, f.parts[part.PartNumber]...)
+		}
+	default:
+		return &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request", Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Status:     "200 OK",
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(respBody)),
+	}, nil
+}
+
+func Test_s3MultipartTarget(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	path := filepath.Join(t.TempDir(), "disk.qcow2")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+
+	fakeS3 := &fakeS3Multipart{}
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/octet-stream")
+	target := &s3MultipartTarget{
+		client: &s3Client{
+			httpClient: &http.Client{Transport: fakeS3},
+			accessKey:  "SCWXXXXXXXXXXXXXXXXX",
+			secretKey:  "11111111-1111-1111-1111-111111111111",
+			region:     scw.RegionFrPar,
+		},
+		bucket:  "my-bucket",
+		key:     "images/disk.qcow2",
+		headers: headers,
+	}
+
+	result, err := upload.File(context.Background(), path, target, &upload.Config{PartSize: 3000})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.Parts)
+	assert.Equal(t, content, fakeS3.object)
+	assert.Equal(t, "application/octet-stream", fakeS3.headers.Get("Content-Type"))
+	assert.Equal(t, "s3://my-bucket/images/disk.qcow2?region=fr-par", target.ID())
+}
@@ -0,0 +1,57 @@
+package plugin
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		pluginRoot(),
+		pluginListCommand(),
+	)
+}
+
+func pluginRoot() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Plugins management commands`,
+		Long: `Plugins are executables named scw-<name> in the PATH, run as scw <name> when <name> is not a command of the CLI.
+The arguments following the name are passed to the plugin, along with the profile and the credentials of the CLI in the SCW_* environment variables.`,
+		Namespace: "plugin",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
+
+type pluginListRequest struct{}
+
+func pluginListCommand() *core.Command {
+	return &core.Command{
+		Groups:               []string{"utility"},
+		Short:                `List the plugins found in the PATH`,
+		Long:                 `List the executables named scw-<name> found in the PATH, when several have the same name the first one of the PATH is run.`,
+		Namespace:            "plugin",
+		Resource:             "list",
+		ArgsType:             reflect.TypeOf(pluginListRequest{}),
+		ArgSpecs:             core.ArgSpecs{},
+		AllowAnonymousClient: true,
+		Run: func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return core.ListPlugins(ctx), nil
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Path", FieldName: "Path"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Run the plugin scw-hello found in the PATH",
+				Raw:   "scw hello world",
+			},
+		},
+	}
+}
@@ -16,6 +16,7 @@ func GetCommands() *core.Commands {
 	human.RegisterMarshalerFunc(rdbACLCustomResult{}, rdbACLCustomResultMarshalerFunc)
 	human.RegisterMarshalerFunc(core.MultiResults{}, rdbACLCustomMultiResultMarshalerFunc)
 	human.RegisterMarshalerFunc(rdb.DatabaseBackup{}, backupExportDisplayBuilder)
+	human.RegisterMarshalerFunc(privilegeMatrix{}, privilegeMatrixMarshalerFunc)
 
 	human.RegisterMarshalerFunc(rdb.InstanceStatus(""), human.EnumMarshalFunc(instanceStatusMarshalSpecs))
 	human.RegisterMarshalerFunc(rdb.DatabaseBackupStatus(""), human.EnumMarshalFunc(backupStatusMarshalSpecs))
@@ -26,12 +27,24 @@ func GetCommands() *core.Commands {
 	cmds.Merge(core.NewCommands(
 		instanceWaitCommand(),
 		instanceConnectCommand(),
+		instanceCheckStorageCommand(),
+		instanceFailoverTestCommand(),
+		instanceEventsCommand(),
+		instanceMetricsCommand(),
+		instanceSlowQueriesCommand(),
 		backupWaitCommand(),
 		backupDownloadCommand(),
 		engineSettingsCommand(),
 		aclEditCommand(),
+		aclMatrixCommand(),
+		privilegeApplyCommand(),
 		userGetURLCommand(),
+		userListConnectionsCommand(),
+		userKillConnectionCommand(),
 		databaseGetURLCommand(),
+		logDownloadCommand(),
+		logTailCommand(),
+		readReplicaPromoteCommand(),
 	))
 	cmds.MustFind("rdb", "acl", "add").Override(aclAddBuilder)
 	cmds.MustFind("rdb", "acl", "delete").Override(aclDeleteBuilder)
@@ -47,8 +60,14 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("rdb", "instance", "upgrade").Override(instanceUpgradeBuilder)
 	cmds.MustFind("rdb", "instance", "update").Override(instanceUpdateBuilder)
 	cmds.MustFind("rdb", "instance", "get").Override(instanceGetBuilder)
+	cmds.MustFind("rdb", "instance", "list").Override(instanceListBuilder)
 	cmds.MustFind("rdb", "instance", "delete").Override(instanceDeleteBuilder)
 
+	cmds.MustFind("rdb", "read-replica", "create").Override(readReplicaCreateBuilder)
+	cmds.MustFind("rdb", "read-replica", "create-endpoint").Override(readReplicaCreateEndpointBuilder)
+
+	cmds.MustFind("rdb", "database", "list").Override(databaseListBuilder)
+
 	cmds.MustFind("rdb", "engine", "list").Override(engineListBuilder)
 
 	cmds.MustFind("rdb", "user", "list").Override(userListBuilder)
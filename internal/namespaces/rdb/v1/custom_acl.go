@@ -129,7 +129,7 @@ func aclAddBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
@@ -232,7 +232,7 @@ func aclDeleteBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
@@ -271,7 +271,7 @@ func aclSetBuilder(c *core.Command) *core.Command {
 		_, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    args.InstanceID,
 			Region:        args.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
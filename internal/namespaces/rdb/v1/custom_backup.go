@@ -79,13 +79,12 @@ func backupWaitCommand() *core.Command {
 }
 
 func backupCreateBuilder(c *core.Command) *core.Command {
-	timeout := backupActionTimeout
 	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
 		api := rdb.NewAPI(core.ExtractClient(ctx))
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
+			Timeout:          core.WaitTimeout(ctx, backupActionTimeout),
 			RetryInterval:    core.DefaultRetryInterval,
 		})
 	}
@@ -94,13 +93,12 @@ func backupCreateBuilder(c *core.Command) *core.Command {
 }
 
 func backupExportBuilder(c *core.Command) *core.Command {
-	timeout := backupActionTimeout
 	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
 		api := rdb.NewAPI(core.ExtractClient(ctx))
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
+			Timeout:          core.WaitTimeout(ctx, backupActionTimeout),
 			RetryInterval:    core.DefaultRetryInterval,
 		})
 	}
@@ -109,13 +107,12 @@ func backupExportBuilder(c *core.Command) *core.Command {
 }
 
 func backupRestoreBuilder(c *core.Command) *core.Command {
-	timeout := backupActionTimeout
 	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
 		api := rdb.NewAPI(core.ExtractClient(ctx))
 		return api.WaitForDatabaseBackup(&rdb.WaitForDatabaseBackupRequest{
 			DatabaseBackupID: respI.(*rdb.DatabaseBackup).ID,
 			Region:           respI.(*rdb.DatabaseBackup).Region,
-			Timeout:          &timeout,
+			Timeout:          core.WaitTimeout(ctx, backupActionTimeout),
 			RetryInterval:    core.DefaultRetryInterval,
 		})
 	}
@@ -378,7 +375,7 @@ func backupDownloadCommand() *core.Command {
 			backupRequest := &rdb.WaitForDatabaseBackupRequest{
 				DatabaseBackupID: args.BackupID,
 				Region:           args.Region,
-				Timeout:          scw.TimeDurationPtr(backupActionTimeout),
+				Timeout:          core.WaitTimeout(ctx, backupActionTimeout),
 				RetryInterval:    core.DefaultRetryInterval,
 			}
 			backup, err := api.WaitForDatabaseBackup(backupRequest)
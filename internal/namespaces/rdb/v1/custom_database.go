@@ -0,0 +1,87 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type customDatabase struct {
+	Name    string   `json:"name"`
+	Owner   string   `json:"owner"`
+	Managed bool     `json:"managed"`
+	Size    scw.Size `json:"size"`
+	Share   string   `json:"share"`
+}
+
+// databaseListBuilder adds the share of each database in the storage used by all the databases of the instance
+func databaseListBuilder(c *core.Command) *core.Command {
+	c.Examples = append(c.Examples, &core.Example{
+		Short: "List the databases of an instance from the biggest to the smallest",
+		Raw:   "scw rdb database list instance-id=11111111-1111-1111-1111-111111111111 --sort-by=size:desc",
+	})
+
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{
+				Label:     "Name",
+				FieldName: "Name",
+			},
+			{
+				Label:     "Owner",
+				FieldName: "Owner",
+			},
+			{
+				Label:     "Managed",
+				FieldName: "Managed",
+			},
+			{
+				Label:     "Size",
+				FieldName: "Size",
+			},
+			{
+				Label:     "Share",
+				FieldName: "Share",
+			},
+		},
+	}
+
+	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+		resI, err := runner(ctx, argsI)
+		if err != nil {
+			return nil, err
+		}
+
+		return newCustomDatabases(resI.([]*rdb.Database)), nil
+	}
+
+	return c
+}
+
+func newCustomDatabases(databases []*rdb.Database) []*customDatabase {
+	total := scw.Size(0)
+	for _, database := range databases {
+		total += database.Size
+	}
+
+	res := []*customDatabase(nil)
+	for _, database := range databases {
+		share := "-"
+		if total > 0 {
+			share = fmt.Sprintf("%.1f%%", float64(database.Size)*100/float64(total))
+		}
+
+		res = append(res, &customDatabase{
+			Name:    database.Name,
+			Owner:   database.Owner,
+			Managed: database.Managed,
+			Size:    database.Size,
+			Share:   share,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,24 @@
+package rdb
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newCustomDatabases(t *testing.T) {
+	databases := newCustomDatabases([]*rdb.Database{
+		{Name: "app", Owner: "app", Size: 3 * scw.GB},
+		{Name: "rdb", Owner: "_rdb_superadmin", Managed: true, Size: 1 * scw.GB},
+	})
+
+	assert.Equal(t, "75.0%", databases[0].Share)
+	assert.Equal(t, "app", databases[0].Owner)
+	assert.Equal(t, "25.0%", databases[1].Share)
+	assert.True(t, databases[1].Managed)
+
+	empty := newCustomDatabases([]*rdb.Database{{Name: "app"}})
+	assert.Equal(t, "-", empty[0].Share)
+}
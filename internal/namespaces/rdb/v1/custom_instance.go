@@ -7,9 +7,10 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
-	"path"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -157,7 +158,7 @@ func instanceCloneBuilder(c *core.Command) *core.Command {
 		return api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdb.Instance).ID,
 			Region:        respI.(*rdb.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -228,8 +229,8 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 
 	type rdbCreateInstanceRequestCustom struct {
 		*rdb.CreateInstanceRequest
-		InitEndpoints    []*rdbEndpointSpecCustom `json:"init-endpoints"`
-		GeneratePassword bool
+		passwordgenerator.Args
+		InitEndpoints []*rdbEndpointSpecCustom `json:"init-endpoints"`
 	}
 
 	c.ArgSpecs.AddBefore("init-endpoints.{index}.private-network.private-network-id", &core.ArgSpec{
@@ -244,14 +245,9 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 		Required: false,
 		Default:  core.DefaultValueSetter("false"),
 	})
-	c.ArgSpecs.AddBefore("password", &core.ArgSpec{
-		Name:       "generate-password",
-		Short:      `Will generate a 21 character-length password that contains a mix of upper/lower case letters, numbers and special symbols`,
-		Required:   false,
-		Deprecated: false,
-		Positional: false,
-		Default:    core.DefaultValueSetter("true"),
-	})
+	for _, argSpec := range passwordgenerator.ArgSpecs() {
+		c.ArgSpecs.AddBefore("password", argSpec)
+	}
 	c.ArgSpecs.GetByName("password").Required = false
 	c.ArgSpecs.GetByName("node-type").Default = core.DefaultValueSetter("DB-DEV-S")
 	c.ArgSpecs.GetByName("node-type").AutoCompleteFunc = autoCompleteNodeType
@@ -264,7 +260,7 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 		instance, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    respI.(createInstanceResult).Instance.ID,
 			Region:        respI.(createInstanceResult).Instance.Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
@@ -288,13 +284,12 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 
 		var err error
 		createInstanceRequest.NodeType = strings.ToLower(createInstanceRequest.NodeType)
+		displayedPassword := createInstanceRequest.Password
 		if customRequest.GeneratePassword && customRequest.Password == "" {
-			createInstanceRequest.Password, err = passwordgenerator.GeneratePassword(21, 1, 1, 1, 1)
+			createInstanceRequest.Password, displayedPassword, err = passwordgenerator.Generate(&customRequest.Args)
 			if err != nil {
 				return nil, err
 			}
-			fmt.Printf("Your generated password is %s \n", createInstanceRequest.Password)
-			fmt.Printf("\n")
 		}
 
 		for _, customEndpoint := range customRequest.InitEndpoints {
@@ -324,7 +319,7 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 
 		result := createInstanceResult{
 			Instance: instance,
-			Password: createInstanceRequest.Password,
+			Password: displayedPassword,
 		}
 
 		return result, nil
@@ -340,6 +335,52 @@ func instanceCreateBuilder(c *core.Command) *core.Command {
 	return c
 }
 
+// instanceListBuilder hides the secondary columns first and truncates long values when the list does not fit in the terminal
+func instanceListBuilder(c *core.Command) *core.Command {
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{
+				FieldName: "ID",
+			},
+			{
+				FieldName: "Name",
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "NodeType",
+			},
+			{
+				FieldName: "Status",
+			},
+			{
+				FieldName: "Engine",
+			},
+			{
+				FieldName: "Region",
+			},
+			{
+				FieldName: "Tags",
+				Priority:  -2,
+				MaxWidth:  30,
+			},
+			{
+				FieldName: "IsHaCluster",
+				Priority:  -1,
+			},
+			{
+				FieldName: "BackupSchedule",
+				Priority:  -1,
+			},
+			{
+				FieldName: "CreatedAt",
+				Priority:  -2,
+			},
+		},
+	}
+
+	return c
+}
+
 func instanceGetBuilder(c *core.Command) *core.Command {
 	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
 		res, err := runner(ctx, argsI)
@@ -360,10 +401,12 @@ func instanceGetBuilder(c *core.Command) *core.Command {
 
 		return struct {
 			*rdb.Instance
-			ACLs []*rdb.ACLRule `json:"acls"`
+			ACLs                 []*rdb.ACLRule     `json:"acls"`
+			UpcomingMaintenances []*rdb.Maintenance `json:"upcoming_maintenances,omitempty"`
 		}{
 			instance,
 			acls.Rules,
+			upcomingMaintenances(instance.Maintenances, time.Now()),
 		}, nil
 	}
 
@@ -391,11 +434,35 @@ func instanceGetBuilder(c *core.Command) *core.Command {
 				Title:       "ACLs",
 				HideIfEmpty: true,
 			},
+			{
+				FieldName:   "UpcomingMaintenances",
+				Title:       "Upcoming maintenances",
+				HideIfEmpty: true,
+			},
 		},
 	}
 	return c
 }
 
+// upcomingMaintenances returns the pending maintenances which are not over yet, the next one first.
+func upcomingMaintenances(maintenances []*rdb.Maintenance, now time.Time) []*rdb.Maintenance {
+	upcoming := []*rdb.Maintenance(nil)
+	for _, maintenance := range maintenances {
+		if maintenance.Status != rdb.MaintenanceStatusPending || (maintenance.StopsAt != nil && maintenance.StopsAt.Before(now)) {
+			continue
+		}
+		upcoming = append(upcoming, maintenance)
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		if upcoming[i].StartsAt == nil || upcoming[j].StartsAt == nil {
+			return upcoming[j].StartsAt == nil && upcoming[i].StartsAt != nil
+		}
+		return upcoming[i].StartsAt.Before(*upcoming[j].StartsAt)
+	})
+	return upcoming
+}
+
 func instanceUpgradeBuilder(c *core.Command) *core.Command {
 	c.ArgSpecs.GetByName("node-type").AutoCompleteFunc = autoCompleteNodeType
 
@@ -404,7 +471,7 @@ func instanceUpgradeBuilder(c *core.Command) *core.Command {
 		return api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdb.Instance).ID,
 			Region:        respI.(*rdb.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
@@ -561,7 +628,7 @@ func instanceUpdateBuilder(_ *core.Command) *core.Command {
 			return api.WaitForInstance(&rdb.WaitForInstanceRequest{
 				InstanceID:    respI.(*rdb.Instance).ID,
 				Region:        respI.(*rdb.Instance).Region,
-				Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+				Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 				RetryInterval: core.DefaultRetryInterval,
 			})
 		},
@@ -588,7 +655,7 @@ func instanceDeleteBuilder(c *core.Command) *core.Command {
 		instance, err := api.WaitForInstance(&rdb.WaitForInstanceRequest{
 			InstanceID:    respI.(*rdb.Instance).ID,
 			Region:        respI.(*rdb.Instance).Region,
-			Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
@@ -602,9 +669,51 @@ func instanceDeleteBuilder(c *core.Command) *core.Command {
 		}
 		return instance, nil
 	}
+	c.DeletePreview = instanceDeletePreview
 	return c
 }
 
+// instanceDeletePreview lists the databases and read replicas deleted with an instance.
+func instanceDeletePreview(ctx context.Context, argsI interface{}) (*core.DeletePreview, error) {
+	args := argsI.(*rdb.DeleteInstanceRequest)
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	databases, err := api.ListDatabases(&rdb.ListDatabasesRequest{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	resource := &core.DeletePreviewResource{
+		Type: "instance",
+		ID:   instance.ID,
+		Name: instance.Name,
+	}
+	for _, database := range databases.Databases {
+		resource.Dependents = append(resource.Dependents, &core.DeletePreviewResource{
+			Type: "database",
+			Name: database.Name,
+		})
+	}
+	for _, readReplica := range instance.ReadReplicas {
+		resource.Dependents = append(resource.Dependents, &core.DeletePreviewResource{
+			Type: "read replica",
+			ID:   readReplica.ID,
+		})
+	}
+
+	return &core.DeletePreview{Resources: []*core.DeletePreviewResource{resource}}, nil
+}
+
 func instanceWaitCommand() *core.Command {
 	return &core.Command{
 		Short:     `Wait for an instance to reach a stable state`,
@@ -649,6 +758,7 @@ type instanceConnectArgs struct {
 	Username       string
 	Database       *string
 	CliDB          *string
+	PrintCommand   bool
 }
 
 type engineFamily string
@@ -665,25 +775,34 @@ mysql supports loading your password from a file to avoid typing them manually.
 Learn more at: https://dev.mysql.com/doc/refman/8.0/en/option-files.html`
 )
 
-func passwordFileExist(ctx context.Context, family engineFamily) bool {
-	passwordFilePath := ""
+// passwordFilePath returns the path of the file from which the database client reads passwords.
+// On Windows, clients read it in the application data directory instead of the home directory.
+func passwordFilePath(ctx context.Context, family engineFamily) string {
 	switch family {
 	case PostgreSQL:
-		switch runtime.GOOS {
-		case "windows":
-			passwordFilePath = path.Join(core.ExtractUserHomeDir(ctx), core.ExtractEnv(ctx, "APPDATA"), "postgresql", "pgpass.conf")
-		default:
-			passwordFilePath = path.Join(core.ExtractUserHomeDir(ctx), ".pgpass")
+		if pgPassFile := core.ExtractEnv(ctx, "PGPASSFILE"); pgPassFile != "" {
+			return pgPassFile
+		}
+		if runtime.GOOS == "windows" {
+			return filepath.Join(core.ExtractEnv(ctx, "APPDATA"), "postgresql", "pgpass.conf")
 		}
+		return filepath.Join(core.ExtractUserHomeDir(ctx), ".pgpass")
 	case MySQL:
-		passwordFilePath = path.Join(core.ExtractUserHomeDir(ctx), ".my.cnf")
+		if runtime.GOOS == "windows" {
+			return filepath.Join(core.ExtractEnv(ctx, "APPDATA"), "MySQL", ".mylogin.cnf")
+		}
+		return filepath.Join(core.ExtractUserHomeDir(ctx), ".my.cnf")
 	default:
-		return false
+		return ""
 	}
-	if passwordFilePath == "" {
+}
+
+func passwordFileExist(ctx context.Context, family engineFamily) bool {
+	filePath := passwordFilePath(ctx, family)
+	if filePath == "" {
 		return false
 	}
-	_, err := os.Stat(passwordFilePath)
+	_, err := os.Stat(filePath)
 	return err == nil
 }
 
@@ -731,6 +850,39 @@ func getPrivateEndpoint(endpoints []*rdb.Endpoint) (*rdb.Endpoint, error) {
 	return nil, fmt.Errorf(errorMessagePrivateEndpointNotFound)
 }
 
+// getConnectEndpoint returns the engine family of an instance and the endpoint to use to connect to it.
+func getConnectEndpoint(ctx context.Context, region scw.Region, instanceID string, privateNetwork bool) (engineFamily, *rdb.Endpoint, error) {
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	})
+	if err != nil {
+		return Unknown, nil, err
+	}
+
+	family, err := detectEngineFamily(instance)
+	if err != nil {
+		return Unknown, nil, err
+	}
+
+	if len(instance.Endpoints) == 0 {
+		return Unknown, nil, fmt.Errorf(errorMessageEndpointNotFound)
+	}
+
+	var endpoint *rdb.Endpoint
+	if privateNetwork {
+		endpoint, err = getPrivateEndpoint(instance.Endpoints)
+	} else {
+		endpoint, err = getPublicEndpoint(instance.Endpoints)
+	}
+	if err != nil {
+		return Unknown, nil, err
+	}
+
+	return family, endpoint, nil
+}
+
 func createConnectCommandLineArgs(endpoint *rdb.Endpoint, family engineFamily, args *instanceConnectArgs) ([]string, error) {
 	database := "rdb"
 	if args.Database != nil {
@@ -806,47 +958,25 @@ func instanceConnectCommand() *core.Command {
 				Name:  "cli-db",
 				Short: "Command line tool to use, default to psql/mysql",
 			},
+			core.PrintCommandArgSpec(),
 			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms),
 		},
 		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
 			args := argsI.(*instanceConnectArgs)
 
-			client := core.ExtractClient(ctx)
-			api := rdb.NewAPI(client)
-			instance, err := api.GetInstance(&rdb.GetInstanceRequest{
-				Region:     args.Region,
-				InstanceID: args.InstanceID,
-			})
+			engineFamily, endpoint, err := getConnectEndpoint(ctx, args.Region, args.InstanceID, args.PrivateNetwork)
 			if err != nil {
 				return nil, err
 			}
 
-			engineFamily, err := detectEngineFamily(instance)
+			cmdArgs, err := createConnectCommandLineArgs(endpoint, engineFamily, args)
 			if err != nil {
 				return nil, err
 			}
 
-			if len(instance.Endpoints) == 0 {
-				return nil, fmt.Errorf(errorMessageEndpointNotFound)
-			}
-
-			var endpoint *rdb.Endpoint
-			switch {
-			case args.PrivateNetwork:
-				endpoint, err = getPrivateEndpoint(instance.Endpoints)
-				if err != nil {
-					return nil, err
-				}
-			default:
-				endpoint, err = getPublicEndpoint(instance.Endpoints)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			cmdArgs, err := createConnectCommandLineArgs(endpoint, engineFamily, args)
-			if err != nil {
-				return nil, err
+			cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...) //nolint:gosec
+			if args.PrintCommand {
+				return core.RawResult(core.FormatCommandLine(ctx, cmd) + "\n"), nil
 			}
 
 			if !passwordFileExist(ctx, engineFamily) {
@@ -854,7 +984,6 @@ func instanceConnectCommand() *core.Command {
 			}
 
 			// Run command
-			cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...) //nolint:gosec
 			//cmd.Stdin = os.Stdin
 			core.ExtractLogger(ctx).Debugf("executing: %s\n", cmd.Args)
 			exitCode, err := core.ExecCmd(ctx, cmd)
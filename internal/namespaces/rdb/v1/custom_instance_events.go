@@ -0,0 +1,118 @@
+package rdb
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// instanceEventsPageSize is the number of latest backups and snapshots included in the events of an instance.
+const instanceEventsPageSize = 10
+
+type instanceEventsArgs struct {
+	InstanceID string
+	Follow     bool
+	Region     scw.Region
+}
+
+func instanceEventsCommand() *core.Command {
+	return &core.Command{
+		Short: `Show the recent activity of a Database Instance`,
+		Long: `Show the recent activity of a Database Instance: its creation and current status, and the creation and last update of its latest backups, snapshots and log exports.
+With follow, the instance is polled and new events are printed as they come until the command is interrupted, to see what the platform is doing during long operations.`,
+		Namespace: "rdb",
+		Resource:  "instance",
+		Verb:      "events",
+		ArgsType:  reflect.TypeOf(instanceEventsArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "follow",
+				Short: `Keep printing new events`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*instanceEventsArgs)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+
+			return core.RunEvents(ctx, func(ctx context.Context) ([]*core.ResourceEvent, error) {
+				return listInstanceEvents(ctx, api, args.Region, args.InstanceID)
+			}, args.Follow)
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Show the recent activity of a Database Instance",
+				Raw:   "scw rdb instance events 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Follow the activity of a Database Instance during an upgrade",
+				Raw:   "scw rdb instance events 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Wait for an instance to reach a stable state",
+				Command: "scw rdb instance wait",
+			},
+		},
+	}
+}
+
+func listInstanceEvents(ctx context.Context, api *rdb.API, region scw.Region, instanceID string) ([]*core.ResourceEvent, error) {
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	events := core.NewResourceEvents("instance", instance.ID, instance.CreatedAt, nil, instance.Status.String(), "")
+
+	backups, err := api.ListDatabaseBackups(&rdb.ListDatabaseBackupsRequest{
+		Region:     region,
+		InstanceID: &instanceID,
+		OrderBy:    rdb.ListDatabaseBackupsRequestOrderByCreatedAtDesc,
+		PageSize:   scw.Uint32Ptr(instanceEventsPageSize),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups.DatabaseBackups {
+		events = append(events, core.NewResourceEvents("backup", backup.ID, backup.CreatedAt, backup.UpdatedAt, backup.Status.String(), backup.Name)...)
+	}
+
+	snapshots, err := api.ListSnapshots(&rdb.ListSnapshotsRequest{
+		Region:     region,
+		InstanceID: &instanceID,
+		OrderBy:    rdb.ListSnapshotsRequestOrderByCreatedAtDesc,
+		PageSize:   scw.Uint32Ptr(instanceEventsPageSize),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots.Snapshots {
+		events = append(events, core.NewResourceEvents("snapshot", snapshot.ID, snapshot.CreatedAt, snapshot.UpdatedAt, snapshot.Status.String(), snapshot.Name)...)
+	}
+
+	logs, err := api.ListInstanceLogs(&rdb.ListInstanceLogsRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs.InstanceLogs {
+		events = append(events, core.NewResourceEvents("log", log.ID, log.CreatedAt, nil, log.Status.String(), log.NodeName)...)
+	}
+
+	return events, nil
+}
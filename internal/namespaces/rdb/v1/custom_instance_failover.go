@@ -0,0 +1,315 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// failoverTestFailedExitCode is returned when the failover did not behave as expected,
+	// other errors are returned with the default exit code 1.
+	failoverTestFailedExitCode = core.ExitCodeCheckFailed
+	failoverProbeInterval      = time.Second
+)
+
+type instanceFailoverTestArgs struct {
+	InstanceID    string
+	EndpointID    string
+	DryRun        bool
+	MaxDowntime   time.Duration
+	ProbeInterval time.Duration
+	Timeout       time.Duration
+	Region        scw.Region
+}
+
+type instanceFailoverTestResult struct {
+	InstanceID        string        `json:"instance_id"`
+	Endpoint          string        `json:"endpoint"`
+	DryRun            bool          `json:"dry_run"`
+	Probes            int           `json:"probes"`
+	FailedProbes      int           `json:"failed_probes"`
+	Unavailability    *scw.Duration `json:"unavailability"`
+	EndpointUnchanged bool          `json:"endpoint_unchanged"`
+	ResolvedIPs       []string      `json:"resolved_ips"`
+}
+
+// failoverProbe records the reachability of an endpoint probed at regular intervals.
+type failoverProbe struct {
+	probes       int
+	failedProbes int
+	// downSince is the time of the first failed probe of the current unavailability window
+	downSince time.Time
+	// longestDowntime is the longest window between a failed probe and the next successful one
+	longestDowntime time.Duration
+}
+
+func (p *failoverProbe) record(at time.Time, reachable bool) {
+	p.probes++
+	switch {
+	case !reachable:
+		p.failedProbes++
+		if p.downSince.IsZero() {
+			p.downSince = at
+		}
+	case !p.downSince.IsZero():
+		if downtime := at.Sub(p.downSince); downtime > p.longestDowntime {
+			p.longestDowntime = downtime
+		}
+		p.downSince = time.Time{}
+	}
+}
+
+// reachable returns true when the last probe succeeded.
+func (p *failoverProbe) reachable() bool {
+	return p.probes > 0 && p.downSince.IsZero()
+}
+
+func instanceFailoverTestCommand() *core.Command {
+	return &core.Command{
+		Short: `Test the failover of a High-Availability instance`,
+		Long: `Test the failover of a High-Availability Database Instance and measure how long its endpoint is unavailable, to be used in disaster recovery exercises.
+The API has no failover action, the instance is restarted which switches the main node to the standby node. The endpoint is probed with TCP connections during the failover, until it is reachable again once the instance is ready.
+The test fails with exit code 6 when the endpoint changed, when its hostname does not resolve, when it is not reachable after the failover or when it was unavailable for longer than max-downtime.
+With dry-run, the instance is not restarted: only the High-Availability, the endpoint and its DNS resolution are checked.
+Private Network endpoints can only be probed from a server of the Private Network.`,
+		Namespace: "rdb",
+		Resource:  "instance",
+		Verb:      "failover-test",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(instanceFailoverTestArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "endpoint-id",
+				Short: `UUID of the endpoint to probe, the public endpoint is used by default`,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Check the instance and its endpoint without restarting it`,
+			},
+			{
+				Name:  "max-downtime",
+				Short: `Unavailability of the endpoint above which the test fails`,
+			},
+			{
+				Name:    "probe-interval",
+				Short:   `Interval between two probes of the endpoint`,
+				Default: core.DefaultValueSetter(failoverProbeInterval.String()),
+			},
+			core.WaitTimeoutArgSpec(instanceActionTimeout),
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: instanceFailoverTestRun,
+		Examples: []*core.Example{
+			{
+				Short: "Check that an instance is ready for a failover test",
+				Raw:   "scw rdb instance failover-test 11111111-1111-1111-1111-111111111111 dry-run=true",
+			},
+			{
+				Short: "Test the failover of an instance, which fails if the endpoint is unavailable for more than 30 seconds",
+				Raw:   "scw rdb instance failover-test 11111111-1111-1111-1111-111111111111 max-downtime=30s",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb instance upgrade",
+				Short:   "Upgrade a Database Instance",
+			},
+			{
+				Command: "scw rdb instance restart",
+				Short:   "Restart Database Instance",
+			},
+		},
+	}
+}
+
+func instanceFailoverTestRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*instanceFailoverTestArgs)
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !instance.IsHaCluster {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("instance %s is not a High-Availability cluster", instance.ID),
+			Hint: fmt.Sprintf("Enable High-Availability with: scw rdb instance upgrade %s enable-ha=true region=%s", instance.ID, args.Region),
+		}
+	}
+	if instance.Status != rdb.InstanceStatusReady {
+		return nil, fmt.Errorf("instance %s is %s, it must be ready to test a failover", instance.ID, instance.Status)
+	}
+
+	endpoint, err := failoverTestEndpoint(instance, args.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	address := endpointAddress(endpoint)
+
+	result := &instanceFailoverTestResult{
+		InstanceID: instance.ID,
+		Endpoint:   address,
+		DryRun:     args.DryRun,
+	}
+	issues := []string(nil)
+
+	if endpoint.Hostname != nil {
+		result.ResolvedIPs, err = net.DefaultResolver.LookupHost(ctx, *endpoint.Hostname)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve the hostname of endpoint %s: %w", address, err)
+		}
+	}
+	err = dialEndpoint(ctx, address, args.ProbeInterval)
+	if err != nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("endpoint %s is not reachable before the failover: %w", address, err),
+			Hint: "Private Network endpoints can only be probed from a server of the Private Network, use endpoint-id to probe another endpoint",
+		}
+	}
+	if args.DryRun {
+		result.EndpointUnchanged = true
+		return result, nil
+	}
+
+	core.ReportProgressStep(ctx, "failover", core.ProgressStateStarted)
+	deadline := time.Now().Add(args.Timeout)
+	ready := make(chan struct{})
+	probeResult := make(chan *failoverProbe)
+	go func() {
+		probeResult <- probeEndpoint(ctx, address, args.ProbeInterval, ready, deadline)
+	}()
+
+	_, err = api.RestartInstance(&rdb.RestartInstanceRequest{
+		Region:     args.Region,
+		InstanceID: instance.ID,
+	}, scw.WithContext(ctx))
+	if err == nil {
+		instance, err = api.WaitForInstance(&rdb.WaitForInstanceRequest{
+			Region:        args.Region,
+			InstanceID:    instance.ID,
+			Timeout:       scw.TimeDurationPtr(args.Timeout),
+			RetryInterval: core.DefaultRetryInterval,
+		}, scw.WithContext(ctx))
+	}
+	close(ready)
+	probe := <-probeResult
+	if core.ReportProgressResult(ctx, "failover", err) != nil {
+		return nil, err
+	}
+
+	result.Probes = probe.probes
+	result.FailedProbes = probe.failedProbes
+	result.Unavailability = scw.NewDurationFromTimeDuration(probe.longestDowntime)
+	if !probe.reachable() {
+		issues = append(issues, fmt.Sprintf("endpoint %s is not reachable after the failover", address))
+	}
+	if args.MaxDowntime > 0 && probe.longestDowntime > args.MaxDowntime {
+		issues = append(issues, fmt.Sprintf("endpoint %s was unavailable for %s, more than %s", address, probe.longestDowntime, args.MaxDowntime))
+	}
+
+	switchedEndpoint, err := failoverTestEndpoint(instance, endpoint.ID)
+	switch {
+	case err != nil:
+		issues = append(issues, err.Error())
+	case endpointAddress(switchedEndpoint) != address:
+		issues = append(issues, fmt.Sprintf("endpoint changed from %s to %s", address, endpointAddress(switchedEndpoint)))
+	default:
+		result.EndpointUnchanged = true
+	}
+	if endpoint.Hostname != nil {
+		resolvedIPs, err := net.DefaultResolver.LookupHost(ctx, *endpoint.Hostname)
+		if err != nil {
+			issues = append(issues, fmt.Sprintf("hostname %s does not resolve after the failover: %s", *endpoint.Hostname, err))
+		}
+		result.ResolvedIPs = resolvedIPs
+	}
+
+	if len(issues) > 0 {
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("failover test of instance %s failed", instance.ID),
+			Details: fmt.Sprintf("%s\nThe endpoint was unavailable for %s (%d failed probes out of %d)", strings.Join(issues, "\n"), probe.longestDowntime, probe.failedProbes, probe.probes),
+			Code:    failoverTestFailedExitCode,
+		}
+	}
+
+	return result, nil
+}
+
+// failoverTestEndpoint returns the endpoint of the instance with the given ID, or its public endpoint when ID is empty.
+func failoverTestEndpoint(instance *rdb.Instance, endpointID string) (*rdb.Endpoint, error) {
+	for _, endpoint := range instance.Endpoints {
+		if endpoint.ID == endpointID || (endpointID == "" && endpoint.LoadBalancer != nil) {
+			return endpoint, nil
+		}
+	}
+	if endpointID == "" && len(instance.Endpoints) > 0 {
+		return instance.Endpoints[0], nil
+	}
+	if endpointID == "" {
+		return nil, fmt.Errorf("instance %s has no endpoint", instance.ID)
+	}
+
+	return nil, fmt.Errorf("endpoint %s not found on instance %s", endpointID, instance.ID)
+}
+
+func endpointAddress(endpoint *rdb.Endpoint) string {
+	host := ""
+	switch {
+	case endpoint.Hostname != nil:
+		host = *endpoint.Hostname
+	case endpoint.IP != nil:
+		host = endpoint.IP.String()
+	}
+
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(endpoint.Port), 10))
+}
+
+func dialEndpoint(ctx context.Context, address string, timeout time.Duration) error {
+	dialer := &net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// probeEndpoint probes the endpoint until it is reachable once ready is closed, or until the deadline.
+func probeEndpoint(ctx context.Context, address string, interval time.Duration, ready <-chan struct{}, deadline time.Time) *failoverProbe {
+	probe := &failoverProbe{}
+	isReady := false
+	for {
+		start := time.Now()
+		probe.record(start, dialEndpoint(ctx, address, interval) == nil)
+		if (isReady && probe.reachable()) || time.Now().After(deadline) {
+			return probe
+		}
+
+		select {
+		case <-ctx.Done():
+			return probe
+		case <-ready:
+			isReady = true
+			ready = nil
+		case <-time.After(interval - time.Since(start)):
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package rdb
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_failoverProbe(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	probe := &failoverProbe{}
+	for i, reachable := range []bool{true, false, false, true, false, false, false, false, true} {
+		probe.record(start.Add(time.Duration(i)*time.Second), reachable)
+	}
+
+	assert.Equal(t, 9, probe.probes)
+	assert.Equal(t, 6, probe.failedProbes)
+	assert.Equal(t, 4*time.Second, probe.longestDowntime)
+	assert.True(t, probe.reachable())
+
+	probe.record(start.Add(10*time.Second), false)
+	assert.False(t, probe.reachable())
+}
+
+func Test_failoverTestEndpoint(t *testing.T) {
+	ip := net.ParseIP("51.15.0.1")
+	instance := &rdb.Instance{
+		ID: "11111111-1111-1111-1111-111111111111",
+		Endpoints: []*rdb.Endpoint{
+			{ID: "private", IP: &ip, Port: 5432, PrivateNetwork: &rdb.EndpointPrivateNetworkDetails{}},
+			{ID: "public", Hostname: scw.StringPtr("rdb.example.com"), Port: 1234, LoadBalancer: &rdb.EndpointLoadBalancerDetails{}},
+		},
+	}
+
+	endpoint, err := failoverTestEndpoint(instance, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "rdb.example.com:1234", endpointAddress(endpoint))
+
+	endpoint, err = failoverTestEndpoint(instance, "private")
+	assert.NoError(t, err)
+	assert.Equal(t, "51.15.0.1:5432", endpointAddress(endpoint))
+
+	_, err = failoverTestEndpoint(instance, "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const instanceMetricsSparklineWidth = 40
+
+// instanceMetricKeywords are the words identifying the time series of each metric.
+var instanceMetricKeywords = map[string][]string{
+	"cpu":         {"cpu"},
+	"connections": {"connection"},
+	"disk":        {"disk"},
+}
+
+type instanceMetricsArgs struct {
+	InstanceID string
+	Metric     string
+	Since      time.Duration
+	Region     scw.Region
+}
+
+// instanceMetricSummary summarizes a time series of a node of an instance, the points are only displayed in JSON.
+type instanceMetricSummary struct {
+	Name   string                 `json:"name"`
+	Node   string                 `json:"node"`
+	Min    float64                `json:"min"`
+	Avg    float64                `json:"avg"`
+	Max    float64                `json:"max"`
+	Last   float64                `json:"last"`
+	Trend  string                 `json:"-"`
+	Points []*scw.TimeSeriesPoint `json:"points"`
+}
+
+func instanceMetricsCommand() *core.Command {
+	return &core.Command{
+		Short: `Summarize the metrics of a Database Instance`,
+		Long: `Display the CPU, connections and disk metrics of a Database Instance over a period, with the minimum, average, maximum and last value of each node and a sparkline of their trend.
+With -o json, the points of the metrics are returned as well.`,
+		Namespace: "rdb",
+		Resource:  "instance",
+		Verb:      "metrics",
+		ArgsType:  reflect.TypeOf(instanceMetricsArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:       "metric",
+				Short:      `Only display this metric`,
+				EnumValues: []string{"cpu", "connections", "disk"},
+			},
+			{
+				Name:    "since",
+				Short:   `Period of the metrics, until now`,
+				Default: core.DefaultValueSetter("1h"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*instanceMetricsArgs)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+
+			endDate := time.Now()
+			startDate := endDate.Add(-args.Since)
+			metrics, err := api.GetInstanceMetrics(&rdb.GetInstanceMetricsRequest{
+				Region:     args.Region,
+				InstanceID: args.InstanceID,
+				StartDate:  &startDate,
+				EndDate:    &endDate,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			summaries := summarizeInstanceMetrics(metrics.Timeseries, args.Metric)
+			if len(summaries) == 0 {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("no metrics measured on instance %s during the last %s", args.InstanceID, args.Since),
+					Hint: "Check that the instance is ready with: scw rdb instance get " + args.InstanceID,
+				}
+			}
+			return summaries, nil
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Name", FieldName: "Name"},
+				{Label: "Node", FieldName: "Node"},
+				{Label: "Min", FieldName: "Min"},
+				{Label: "Avg", FieldName: "Avg"},
+				{Label: "Max", FieldName: "Max"},
+				{Label: "Last", FieldName: "Last"},
+				{Label: "Trend", FieldName: "Trend"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Summarize the metrics of a Database Instance during the last hour",
+				Raw:   "scw rdb instance metrics 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Display the connections to a Database Instance during the last day",
+				Raw:   "scw rdb instance metrics 11111111-1111-1111-1111-111111111111 metric=connections since=24h",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb instance slow-queries",
+				Short:   "Report the slowest queries of a Database Instance",
+			},
+			{
+				Command: "scw rdb instance get-metrics",
+				Short:   "Get Database Instance metrics",
+			},
+		},
+	}
+}
+
+// summarizeInstanceMetrics summarizes the time series of a metric, or of all the metrics when metric is empty, sorted by name and node.
+func summarizeInstanceMetrics(timeseries []*scw.TimeSeries, metric string) []*instanceMetricSummary {
+	summaries := []*instanceMetricSummary(nil)
+	for _, series := range timeseries {
+		if len(series.Points) == 0 || (metric != "" && !instanceMetricMatches(series.Name, metric)) {
+			continue
+		}
+
+		points := append([]*scw.TimeSeriesPoint(nil), series.Points...)
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].Timestamp.Before(points[j].Timestamp)
+		})
+
+		node := series.Metadata["node"]
+		if node == "" {
+			node = "main"
+		}
+		values := make([]float64, 0, len(points))
+		summary := &instanceMetricSummary{
+			Name:   series.Name,
+			Node:   node,
+			Min:    float64(points[0].Value),
+			Max:    float64(points[0].Value),
+			Last:   float64(points[len(points)-1].Value),
+			Points: points,
+		}
+		for _, point := range points {
+			value := float64(point.Value)
+			values = append(values, value)
+			summary.Avg += value / float64(len(points))
+			if value < summary.Min {
+				summary.Min = value
+			}
+			if value > summary.Max {
+				summary.Max = value
+			}
+		}
+		summary.Avg = math.Round(summary.Avg*100) / 100
+		summary.Trend = human.Sparkline(values, instanceMetricsSparklineWidth)
+		summaries = append(summaries, summary)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Name != summaries[j].Name {
+			return summaries[i].Name < summaries[j].Name
+		}
+		return summaries[i].Node < summaries[j].Node
+	})
+	return summaries
+}
+
+func instanceMetricMatches(seriesName string, metric string) bool {
+	for _, keyword := range instanceMetricKeywords[metric] {
+		if strings.Contains(strings.ToLower(seriesName), keyword) {
+			return true
+		}
+	}
+	return false
+}
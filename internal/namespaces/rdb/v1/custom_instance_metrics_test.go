@@ -0,0 +1,48 @@
+package rdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_summarizeInstanceMetrics(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeseries := []*scw.TimeSeries{
+		{
+			Name:     diskUsageMetricName,
+			Metadata: map[string]string{"node": "replica"},
+			Points:   []*scw.TimeSeriesPoint{{Timestamp: now, Value: 50}},
+		},
+		{
+			Name: "cpu_usage_percent",
+			Points: []*scw.TimeSeriesPoint{
+				{Timestamp: now, Value: 40},
+				{Timestamp: now.Add(-2 * time.Minute), Value: 10},
+				{Timestamp: now.Add(-time.Minute), Value: 20},
+			},
+		},
+		{
+			Name: "total_connections",
+		},
+	}
+
+	summaries := summarizeInstanceMetrics(timeseries, "cpu")
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "main", summaries[0].Node)
+	assert.Equal(t, 10.0, summaries[0].Min)
+	assert.Equal(t, 23.33, summaries[0].Avg)
+	assert.Equal(t, 40.0, summaries[0].Max)
+	assert.Equal(t, 40.0, summaries[0].Last)
+	assert.Equal(t, "▁▃█", summaries[0].Trend)
+
+	summaries = summarizeInstanceMetrics(timeseries, "")
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, "cpu_usage_percent", summaries[0].Name)
+	assert.Equal(t, diskUsageMetricName, summaries[1].Name)
+	assert.Equal(t, "replica", summaries[1].Node)
+
+	assert.Empty(t, summarizeInstanceMetrics(timeseries, "connections"))
+}
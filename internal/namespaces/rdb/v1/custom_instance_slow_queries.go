@@ -0,0 +1,222 @@
+package rdb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var (
+	// postgresSlowQueryRegexp matches the statements logged by PostgreSQL when they last longer than log_min_duration_statement.
+	postgresSlowQueryRegexp = regexp.MustCompile(`duration: ([0-9.]+) ms\s+(?:statement|(?:execute|parse|bind) [^:]*): (.+)$`)
+	// mysqlQueryTimeRegexp matches the header of a query in the MySQL slow query log, the query follows on the next lines.
+	mysqlQueryTimeRegexp = regexp.MustCompile(`^# Query_time: ([0-9.]+)`)
+
+	queryStringLiteralRegexp = regexp.MustCompile(`'(?:[^']|'')*'`)
+	queryNumberLiteralRegexp = regexp.MustCompile(`\b[0-9]+(?:\.[0-9]+)?\b`)
+	queryParameterRegexp     = regexp.MustCompile(`\$[0-9]+`)
+	querySpacesRegexp        = regexp.MustCompile(`\s+`)
+)
+
+type instanceSlowQueriesArgs struct {
+	InstanceID string
+	Since      time.Duration
+	Limit      uint32
+	Region     scw.Region
+}
+
+// slowQuery aggregates the executions of a query whose literals have been replaced by ?.
+type slowQuery struct {
+	Query   string  `json:"query"`
+	Calls   uint32  `json:"calls"`
+	TotalMs float64 `json:"total_ms"`
+	MeanMs  float64 `json:"mean_ms"`
+	MaxMs   float64 `json:"max_ms"`
+}
+
+func instanceSlowQueriesCommand() *core.Command {
+	return &core.Command{
+		Short: `Report the slowest queries of a Database Instance`,
+		Long: `Prepare and download the logs of a Database Instance over a period, then report the queries which spent the most time, grouped by query with their literals replaced by ?.
+Only the queries logged by the engine are reported: set log_min_duration_statement on PostgreSQL or slow_query_log and long_query_time on MySQL with scw rdb setting set.`,
+		Namespace: "rdb",
+		Resource:  "instance",
+		Verb:      "slow-queries",
+		ArgsType:  reflect.TypeOf(instanceSlowQueriesArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "since",
+				Short:   `Period of the logs to analyze, until now`,
+				Default: core.DefaultValueSetter("24h"),
+			},
+			{
+				Name:    "limit",
+				Short:   `Number of queries to report`,
+				Default: core.DefaultValueSetter("10"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*instanceSlowQueriesArgs)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+
+			endDate := time.Now()
+			instanceLogs, err := prepareInstanceLogs(ctx, api, args.Region, args.InstanceID, endDate.Add(-args.Since), endDate)
+			if err != nil {
+				return nil, err
+			}
+
+			queries := map[string]*slowQuery{}
+			for _, instanceLog := range instanceLogs {
+				content, err := downloadInstanceLog(ctx, instanceLog)
+				if err != nil {
+					return nil, err
+				}
+				err = parseSlowQueries(content, queries)
+				content.Close()
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			if len(queries) == 0 {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("no slow query logged on instance %s during the last %s", args.InstanceID, args.Since),
+					Hint: "Check the logging settings of the engine with: scw rdb instance get " + args.InstanceID,
+				}
+			}
+			return topSlowQueries(queries, int(args.Limit)), nil
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Total (ms)", FieldName: "TotalMs"},
+				{Label: "Calls", FieldName: "Calls"},
+				{Label: "Mean (ms)", FieldName: "MeanMs"},
+				{Label: "Max (ms)", FieldName: "MaxMs"},
+				{Label: "Query", FieldName: "Query"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Report the 10 slowest queries of the last day",
+				Raw:   "scw rdb instance slow-queries 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Report the 3 slowest queries of the last hour",
+				Raw:   "scw rdb instance slow-queries 11111111-1111-1111-1111-111111111111 since=1h limit=3",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb instance metrics",
+				Short:   "Summarize the metrics of a Database Instance",
+			},
+			{
+				Command: "scw rdb log prepare",
+				Short:   "Prepare logs of a Database Instance",
+			},
+		},
+	}
+}
+
+// parseSlowQueries adds the slow queries found in PostgreSQL or MySQL logs to queries.
+func parseSlowQueries(r io.Reader, queries map[string]*slowQuery) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	mysqlDuration := -1.0
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := postgresSlowQueryRegexp.FindStringSubmatch(line); match != nil {
+			duration, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return err
+			}
+			addSlowQuery(queries, match[2], duration)
+			continue
+		}
+
+		if match := mysqlQueryTimeRegexp.FindStringSubmatch(line); match != nil {
+			seconds, err := strconv.ParseFloat(match[1], 64)
+			if err != nil {
+				return err
+			}
+			mysqlDuration = seconds * 1000
+			continue
+		}
+
+		// The query of a MySQL slow log entry is the first line which is not a comment or a session statement.
+		trimmed := strings.TrimSpace(line)
+		if mysqlDuration < 0 || trimmed == "" || strings.HasPrefix(trimmed, "#") ||
+			strings.HasPrefix(trimmed, "SET timestamp=") || strings.HasPrefix(strings.ToLower(trimmed), "use ") {
+			continue
+		}
+		addSlowQuery(queries, trimmed, mysqlDuration)
+		mysqlDuration = -1
+	}
+	return scanner.Err()
+}
+
+func addSlowQuery(queries map[string]*slowQuery, query string, durationMs float64) {
+	query = normalizeQuery(query)
+	slow, exists := queries[query]
+	if !exists {
+		slow = &slowQuery{Query: query}
+		queries[query] = slow
+	}
+	slow.Calls++
+	slow.TotalMs += durationMs
+	if durationMs > slow.MaxMs {
+		slow.MaxMs = durationMs
+	}
+}
+
+// normalizeQuery replaces the literals and parameters of a query by ? so that its executions can be grouped together.
+func normalizeQuery(query string) string {
+	query = queryStringLiteralRegexp.ReplaceAllString(query, "?")
+	query = queryParameterRegexp.ReplaceAllString(query, "?")
+	query = queryNumberLiteralRegexp.ReplaceAllString(query, "?")
+	query = querySpacesRegexp.ReplaceAllString(query, " ")
+	return strings.TrimSuffix(strings.TrimSpace(query), ";")
+}
+
+// topSlowQueries returns the limit queries which spent the most time, with their durations rounded to the microsecond.
+func topSlowQueries(queries map[string]*slowQuery, limit int) []*slowQuery {
+	top := make([]*slowQuery, 0, len(queries))
+	for _, query := range queries {
+		query.MeanMs = math.Round(query.TotalMs/float64(query.Calls)*1000) / 1000
+		query.TotalMs = math.Round(query.TotalMs*1000) / 1000
+		query.MaxMs = math.Round(query.MaxMs*1000) / 1000
+		top = append(top, query)
+	}
+
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].TotalMs != top[j].TotalMs {
+			return top[i].TotalMs > top[j].TotalMs
+		}
+		return top[i].Query < top[j].Query
+	})
+	if limit > 0 && len(top) > limit {
+		top = top[:limit]
+	}
+	return top
+}
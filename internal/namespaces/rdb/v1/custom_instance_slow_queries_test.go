@@ -0,0 +1,36 @@
+package rdb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseSlowQueries(t *testing.T) {
+	queries := map[string]*slowQuery{}
+
+	postgresLogs := `2024-01-01 12:00:00 UTC [42]: LOG:  duration: 1500.500 ms  statement: SELECT * FROM orders WHERE customer_id = 12
+2024-01-01 12:00:01 UTC [42]: LOG:  connection received: host=10.0.0.1
+2024-01-01 12:00:02 UTC [43]: LOG:  duration: 500.250 ms  statement: SELECT *   FROM orders WHERE customer_id = 7;
+2024-01-01 12:00:03 UTC [44]: LOG:  duration: 800 ms  execute <unnamed>: UPDATE users SET name = $1 WHERE email = 'john@example.com'
+`
+	assert.NoError(t, parseSlowQueries(strings.NewReader(postgresLogs), queries))
+
+	mysqlLogs := `# Time: 2024-01-01T12:00:00.000000Z
+# User@Host: app[app] @  [10.0.0.1]  Id:    42
+# Query_time: 2.000000  Lock_time: 0.000100 Rows_sent: 1  Rows_examined: 100000
+use shop;
+SET timestamp=1704110400;
+SELECT COUNT(*) FROM orders WHERE status = 'pending';
+`
+	assert.NoError(t, parseSlowQueries(strings.NewReader(mysqlLogs), queries))
+
+	assert.Equal(t, []*slowQuery{
+		{Query: "SELECT * FROM orders WHERE customer_id = ?", Calls: 2, TotalMs: 2000.75, MeanMs: 1000.375, MaxMs: 1500.5},
+		{Query: "SELECT COUNT(*) FROM orders WHERE status = ?", Calls: 1, TotalMs: 2000, MeanMs: 2000, MaxMs: 2000},
+		{Query: "UPDATE users SET name = ? WHERE email = ?", Calls: 1, TotalMs: 800, MeanMs: 800, MaxMs: 800},
+	}, topSlowQueries(queries, 0))
+
+	assert.Len(t, topSlowQueries(queries, 2), 2)
+}
@@ -0,0 +1,223 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	diskUsageMetricName = "disk_usage_percent"
+	// storageThresholdExceededExitCode is returned when the disk usage is above the threshold,
+	// other errors are returned with the default exit code 1.
+	storageThresholdExceededExitCode = core.ExitCodeCheckFailed
+	storageCheckMetricsWindow        = time.Hour
+)
+
+type instanceCheckStorageArgs struct {
+	InstanceID string
+	Threshold  float32
+	ExtendBy   *scw.Size
+	Region     scw.Region
+}
+
+// instanceStorageUsage is the last disk usage measured on a node of an instance.
+type instanceStorageUsage struct {
+	Node         string    `json:"node"`
+	UsagePercent float32   `json:"usage_percent"`
+	MeasuredAt   time.Time `json:"measured_at"`
+}
+
+type instanceStorageCheck struct {
+	InstanceID string                  `json:"instance_id"`
+	VolumeType rdb.VolumeType          `json:"volume_type"`
+	VolumeSize scw.Size                `json:"volume_size"`
+	Threshold  float32                 `json:"threshold"`
+	Usages     []*instanceStorageUsage `json:"usages"`
+	ExtendedTo *scw.Size               `json:"extended_to,omitempty"`
+}
+
+func instanceCheckStorageCommand() *core.Command {
+	return &core.Command{
+		Short: `Check the disk usage of an instance`,
+		Long: `Check the last disk usage measured on the nodes of an instance and exit with code 6 when it is above the threshold, to be used by cron-based monitoring.
+The API does not extend volumes automatically. With extend-by, the volume is extended when the threshold is exceeded, which is only possible for Block volumes.`,
+		Namespace: "rdb",
+		Resource:  "instance",
+		Verb:      "check-storage",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(instanceCheckStorageArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "threshold",
+				Short:   `Disk usage percentage above which the check fails`,
+				Default: core.DefaultValueSetter("80"),
+			},
+			{
+				Name:  "extend-by",
+				Short: `Size to add to the volume when the threshold is exceeded`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: instanceCheckStorageRun,
+		Examples: []*core.Example{
+			{
+				Short: "Check that the disk usage of an instance is below 80%",
+				Raw:   "scw rdb instance check-storage 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Extend the volume by 10GB when the disk usage is above 90%",
+				Raw:   "scw rdb instance check-storage 11111111-1111-1111-1111-111111111111 threshold=90 extend-by=10GB",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb instance get-metrics",
+				Short:   "Get Database Instance metrics",
+			},
+			{
+				Command: "scw rdb instance upgrade",
+				Short:   "Upgrade a Database Instance",
+			},
+		},
+	}
+}
+
+func instanceCheckStorageRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*instanceCheckStorageArgs)
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+
+	instance, err := api.GetInstance(&rdb.GetInstanceRequest{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	endDate := time.Now()
+	startDate := endDate.Add(-storageCheckMetricsWindow)
+	metrics, err := api.GetInstanceMetrics(&rdb.GetInstanceMetricsRequest{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+		StartDate:  &startDate,
+		EndDate:    &endDate,
+		MetricName: scw.StringPtr(diskUsageMetricName),
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	check := &instanceStorageCheck{
+		InstanceID: instance.ID,
+		Threshold:  args.Threshold,
+		Usages:     lastStorageUsages(metrics.Timeseries),
+	}
+	if instance.Volume != nil {
+		check.VolumeType = instance.Volume.Type
+		check.VolumeSize = instance.Volume.Size
+	}
+	if len(check.Usages) == 0 {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no disk usage measured on instance %s during the last %s", instance.ID, storageCheckMetricsWindow),
+			Hint: "Check that the instance is ready with: scw rdb instance get " + instance.ID,
+		}
+	}
+
+	exceeded := storageUsagesAbove(check.Usages, args.Threshold)
+	if len(exceeded) == 0 {
+		return check, nil
+	}
+
+	if args.ExtendBy != nil && check.VolumeType != rdb.VolumeTypeLssd {
+		newSize := check.VolumeSize + *args.ExtendBy
+		_, err := api.UpgradeInstance(&rdb.UpgradeInstanceRequest{
+			Region:     args.Region,
+			InstanceID: instance.ID,
+			VolumeSize: scw.Uint64Ptr(uint64(newSize)),
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		check.ExtendedTo = &newSize
+		return check, nil
+	}
+
+	hint := fmt.Sprintf("Extend the volume with: scw rdb instance upgrade %s volume-size=%d region=%s", instance.ID, uint64(check.VolumeSize+10*scw.GB), args.Region)
+	if check.VolumeType == rdb.VolumeTypeLssd {
+		hint = fmt.Sprintf("Local volumes cannot be extended, upgrade the node type or migrate to a Block volume with: scw rdb instance upgrade %s volume-type=bssd region=%s", instance.ID, args.Region)
+	}
+
+	return nil, &core.CliError{
+		Err:     fmt.Errorf("disk usage of instance %s is above %g%%", instance.ID, args.Threshold),
+		Details: formatStorageUsages(exceeded),
+		Hint:    hint,
+		Code:    storageThresholdExceededExitCode,
+	}
+}
+
+// lastStorageUsages returns the last point of each disk usage time series, one per node of the instance.
+func lastStorageUsages(timeseries []*scw.TimeSeries) []*instanceStorageUsage {
+	usages := []*instanceStorageUsage(nil)
+	for _, series := range timeseries {
+		if series.Name != diskUsageMetricName || len(series.Points) == 0 {
+			continue
+		}
+
+		last := series.Points[0]
+		for _, point := range series.Points[1:] {
+			if point.Timestamp.After(last.Timestamp) {
+				last = point
+			}
+		}
+
+		node := series.Metadata["node"]
+		if node == "" {
+			node = "main"
+		}
+		usages = append(usages, &instanceStorageUsage{
+			Node:         node,
+			UsagePercent: last.Value,
+			MeasuredAt:   last.Timestamp,
+		})
+	}
+
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Node < usages[j].Node
+	})
+
+	return usages
+}
+
+func storageUsagesAbove(usages []*instanceStorageUsage, threshold float32) []*instanceStorageUsage {
+	exceeded := []*instanceStorageUsage(nil)
+	for _, usage := range usages {
+		if usage.UsagePercent > threshold {
+			exceeded = append(exceeded, usage)
+		}
+	}
+
+	return exceeded
+}
+
+func formatStorageUsages(usages []*instanceStorageUsage) string {
+	lines := make([]string, 0, len(usages))
+	for _, usage := range usages {
+		lines = append(lines, fmt.Sprintf("Disk usage of node %s is %.1f%% (measured at %s)", usage.Node, usage.UsagePercent, usage.MeasuredAt.Format(time.RFC3339)))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,44 @@
+package rdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_lastStorageUsages(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	usages := lastStorageUsages([]*scw.TimeSeries{
+		{
+			Name:     diskUsageMetricName,
+			Metadata: map[string]string{"node": "replica"},
+			Points: []*scw.TimeSeriesPoint{
+				{Timestamp: now, Value: 91},
+				{Timestamp: now.Add(-time.Minute), Value: 60},
+			},
+		},
+		{
+			Name: diskUsageMetricName,
+			Points: []*scw.TimeSeriesPoint{
+				{Timestamp: now.Add(-time.Minute), Value: 90},
+				{Timestamp: now, Value: 42.5},
+			},
+		},
+		{
+			Name:   diskUsageMetricName,
+			Points: nil,
+		},
+	})
+
+	assert.Equal(t, []*instanceStorageUsage{
+		{Node: "main", UsagePercent: 42.5, MeasuredAt: now},
+		{Node: "replica", UsagePercent: 91, MeasuredAt: now},
+	}, usages)
+
+	exceeded := storageUsagesAbove(usages, 80)
+	assert.Len(t, exceeded, 1)
+	assert.Equal(t, "replica", exceeded[0].Node)
+	assert.Empty(t, storageUsagesAbove(usages, 91))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alecthomas/assert"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
@@ -347,3 +348,25 @@ func checkEndpoints(ctx *core.CheckFuncCtx, t *testing.T, expected []string) {
 		}
 	}
 }
+
+func Test_upcomingMaintenances(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		date := now.Add(d)
+		return &date
+	}
+
+	maintenances := []*rdb.Maintenance{
+		{Reason: "next week", StartsAt: at(7 * 24 * time.Hour), StopsAt: at(7*24*time.Hour + time.Hour), Status: rdb.MaintenanceStatusPending},
+		{Reason: "done", StartsAt: at(-48 * time.Hour), StopsAt: at(-47 * time.Hour), Status: rdb.MaintenanceStatusDone},
+		{Reason: "ongoing", StartsAt: at(-time.Hour), StopsAt: at(time.Hour), Status: rdb.MaintenanceStatusPending},
+		{Reason: "missed", StartsAt: at(-3 * time.Hour), StopsAt: at(-2 * time.Hour), Status: rdb.MaintenanceStatusPending},
+		{Reason: "canceled", StartsAt: at(time.Hour), StopsAt: at(2 * time.Hour), Status: rdb.MaintenanceStatusCanceled},
+	}
+
+	upcoming := upcomingMaintenances(maintenances, now)
+	assert.Equal(t, 2, len(upcoming))
+	assert.Equal(t, "ongoing", upcoming[0].Reason)
+	assert.Equal(t, "next week", upcoming[1].Reason)
+	assert.Equal(t, 0, len(upcomingMaintenances(nil, now)))
+}
@@ -1,7 +1,13 @@
 package rdb
 
 import (
+	"bufio"
+	"compress/gzip"
 	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
@@ -23,21 +29,76 @@ func logPrepareBuilder(c *core.Command) *core.Command {
 	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
 		getResp := respI.(*rdb.PrepareInstanceLogsResponse)
 		api := rdb.NewAPI(core.ExtractClient(ctx))
-		readyLogs := make([]*rdb.InstanceLog, len(getResp.InstanceLogs))
-		for i := range getResp.InstanceLogs {
-			logs, err := api.WaitForInstanceLog(&rdb.WaitForInstanceLogRequest{
-				InstanceLogID: getResp.InstanceLogs[i].ID,
-				Region:        getResp.InstanceLogs[i].Region,
-				Timeout:       scw.TimeDurationPtr(instanceActionTimeout),
-				RetryInterval: core.DefaultRetryInterval,
-			})
-			if err != nil {
-				return nil, err
-			}
-			readyLogs[i] = logs
+		readyLogs, err := waitForInstanceLogs(ctx, api, getResp.InstanceLogs)
+		if err != nil {
+			return nil, err
 		}
 		respI.(*rdb.PrepareInstanceLogsResponse).InstanceLogs = readyLogs
 		return respI.(*rdb.PrepareInstanceLogsResponse), nil
 	}
 	return c
 }
+
+// prepareInstanceLogs prepares the logs of the nodes of an instance between two dates and waits for them to be ready to download.
+func prepareInstanceLogs(ctx context.Context, api *rdb.API, region scw.Region, instanceID string, startDate, endDate time.Time) ([]*rdb.InstanceLog, error) {
+	resp, err := api.PrepareInstanceLogs(&rdb.PrepareInstanceLogsRequest{
+		Region:     region,
+		InstanceID: instanceID,
+		StartDate:  &startDate,
+		EndDate:    &endDate,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return waitForInstanceLogs(ctx, api, resp.InstanceLogs)
+}
+
+func waitForInstanceLogs(ctx context.Context, api *rdb.API, instanceLogs []*rdb.InstanceLog) ([]*rdb.InstanceLog, error) {
+	readyLogs := make([]*rdb.InstanceLog, len(instanceLogs))
+	for i := range instanceLogs {
+		logs, err := api.WaitForInstanceLog(&rdb.WaitForInstanceLogRequest{
+			InstanceLogID: instanceLogs[i].ID,
+			Region:        instanceLogs[i].Region,
+			Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
+			RetryInterval: core.DefaultRetryInterval,
+		})
+		if err != nil {
+			return nil, err
+		}
+		readyLogs[i] = logs
+	}
+	return readyLogs, nil
+}
+
+// downloadInstanceLog opens the content of a ready instance log, the log files may be compressed with gzip.
+func downloadInstanceLog(ctx context.Context, instanceLog *rdb.InstanceLog) (io.ReadCloser, error) {
+	if instanceLog.DownloadURL == nil {
+		return nil, fmt.Errorf("instance log %s has no download URL, its status is %s", instanceLog.ID, instanceLog.Status)
+	}
+
+	res, err := core.ExtractHTTPClient(ctx).Get(*instanceLog.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("could not download instance log %s: %s", instanceLog.ID, res.Status)
+	}
+
+	body := bufio.NewReader(res.Body)
+	magic, err := body.Peek(2)
+	if err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return &readCloser{Reader: body, Closer: res.Body}, nil
+	}
+	content, err := gzip.NewReader(body)
+	if err != nil {
+		res.Body.Close()
+		return nil, err
+	}
+	return &readCloser{Reader: content, Closer: res.Body}, nil
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
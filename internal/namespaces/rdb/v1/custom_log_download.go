@@ -0,0 +1,138 @@
+package rdb
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type logDownloadArgs struct {
+	InstanceID string
+	Since      time.Duration
+	Output     string
+	Region     scw.Region
+}
+
+type logDownloadResult struct {
+	NodeName string   `json:"node_name"`
+	FileName string   `json:"file_name"`
+	Size     scw.Size `json:"size"`
+}
+
+func logDownloadCommand() *core.Command {
+	return &core.Command{
+		Short: `Download the logs of a Database Instance`,
+		Long: `Prepare the logs of a Database Instance over a period, wait for them to be ready and download them to a directory, one file per node.
+Compressed logs are decompressed while downloading.`,
+		Namespace: "rdb",
+		Resource:  "log",
+		Verb:      "download",
+		ArgsType:  reflect.TypeOf(logDownloadArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "since",
+				Short:   `Period of the logs to download, until now`,
+				Default: core.DefaultValueSetter("1h"),
+			},
+			{
+				Name:    "output",
+				Short:   `Directory to write the log files to`,
+				Default: core.DefaultValueSetter("."),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*logDownloadArgs)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+
+			endDate := time.Now()
+			instanceLogs, err := prepareInstanceLogs(ctx, api, args.Region, args.InstanceID, endDate.Add(-args.Since), endDate)
+			if err != nil {
+				return nil, err
+			}
+
+			err = os.MkdirAll(args.Output, 0o755)
+			if err != nil {
+				return nil, err
+			}
+
+			results := []*logDownloadResult(nil)
+			for _, instanceLog := range instanceLogs {
+				result, err := downloadInstanceLogToFile(ctx, instanceLog, args.Output)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, result)
+			}
+			return results, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Download the logs of the last hour to the current directory",
+				Raw:   "scw rdb log download 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Download the logs of the last day to a directory",
+				Raw:   "scw rdb log download 11111111-1111-1111-1111-111111111111 since=24h output=./logs",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb log tail",
+				Short:   "Print the latest logs of a Database Instance",
+			},
+			{
+				Command: "scw rdb log list",
+				Short:   "List available logs of a Database Instance",
+			},
+		},
+	}
+}
+
+func downloadInstanceLogToFile(ctx context.Context, instanceLog *rdb.InstanceLog, directory string) (*logDownloadResult, error) {
+	content, err := downloadInstanceLog(ctx, instanceLog)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	fileName, err := getDefaultFileName(*instanceLog.DownloadURL)
+	if err != nil {
+		return nil, err
+	}
+	fileName = strings.TrimSuffix(fileName, ".gz")
+	if fileName == "" {
+		fileName = instanceLog.ID + ".log"
+	}
+	fileName = filepath.Join(directory, fileName)
+
+	out, err := os.Create(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	size, err := io.Copy(out, content)
+	if err != nil {
+		return nil, err
+	}
+	return &logDownloadResult{
+		NodeName: instanceLog.NodeName,
+		FileName: fileName,
+		Size:     scw.Size(size),
+	}, nil
+}
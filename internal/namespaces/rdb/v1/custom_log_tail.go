@@ -0,0 +1,190 @@
+package rdb
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// logTailPollInterval is long because each poll prepares new logs on the instance.
+	logTailPollInterval = 30 * time.Second
+	// logTailOverlap is the period prepared again at each poll so that the lines written while the previous logs were prepared are not missed.
+	logTailOverlap = time.Minute
+)
+
+type logTailArgs struct {
+	InstanceID string
+	Since      time.Duration
+	Tail       uint32
+	Follow     bool
+	Region     scw.Region
+}
+
+// instanceLogLine is a line of the logs of a node of an instance.
+type instanceLogLine struct {
+	Node string
+	Text string
+}
+
+func logTailCommand() *core.Command {
+	return &core.Command{
+		Short: `Print the latest logs of a Database Instance`,
+		Long: `Prepare and download the logs of a Database Instance over a period and print their last lines, errors in red and warnings in yellow.
+With follow, new logs are prepared every 30 seconds and their new lines are printed until the command is interrupted.`,
+		Namespace: "rdb",
+		Resource:  "log",
+		Verb:      "tail",
+		ArgsType:  reflect.TypeOf(logTailArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "since",
+				Short:   `Period of the logs to print, until now`,
+				Default: core.DefaultValueSetter("10m"),
+			},
+			{
+				Name:    "tail",
+				Short:   `Number of latest lines to print`,
+				Default: core.DefaultValueSetter("100"),
+			},
+			{
+				Name:  "follow",
+				Short: `Keep printing new lines`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: logTailRun,
+		Examples: []*core.Example{
+			{
+				Short: "Print the last lines of the logs of a Database Instance",
+				Raw:   "scw rdb log tail 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Follow the logs of a Database Instance",
+				Raw:   "scw rdb log tail 11111111-1111-1111-1111-111111111111 follow=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb log download",
+				Short:   "Download the logs of a Database Instance",
+			},
+		},
+	}
+}
+
+func logTailRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*logTailArgs)
+
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+	stdout := core.ExtractStdout(ctx)
+
+	endDate := time.Now()
+	startDate := endDate.Add(-args.Since)
+	printedLines := map[instanceLogLine]bool(nil)
+	for {
+		instanceLogs, err := prepareInstanceLogs(ctx, api, args.Region, args.InstanceID, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := readInstanceLogLines(ctx, instanceLogs)
+		if err != nil {
+			return nil, err
+		}
+
+		firstPoll := printedLines == nil
+		lines, printedLines = newInstanceLogLines(lines, printedLines)
+		if firstPoll && args.Tail > 0 && len(lines) > int(args.Tail) {
+			lines = lines[len(lines)-int(args.Tail):]
+		}
+		for _, line := range lines {
+			_, err := fmt.Fprintln(stdout, formatInstanceLogLine(line, len(instanceLogs) > 1))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !args.Follow {
+			return &core.SuccessResult{Empty: true}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &core.SuccessResult{Empty: true}, nil
+		case <-time.After(logTailPollInterval):
+		}
+
+		// Following polls only need the logs since the previous one, the overlapping lines are not printed again
+		if endDate.Add(-logTailOverlap).After(startDate) {
+			startDate = endDate.Add(-logTailOverlap)
+		}
+		endDate = time.Now()
+	}
+}
+
+func readInstanceLogLines(ctx context.Context, instanceLogs []*rdb.InstanceLog) ([]instanceLogLine, error) {
+	lines := []instanceLogLine(nil)
+	for _, instanceLog := range instanceLogs {
+		content, err := downloadInstanceLog(ctx, instanceLog)
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(content)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if scanner.Text() != "" {
+				lines = append(lines, instanceLogLine{Node: instanceLog.NodeName, Text: scanner.Text()})
+			}
+		}
+		content.Close()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// newInstanceLogLines returns the lines that have not been printed yet and the set of the given lines.
+func newInstanceLogLines(lines []instanceLogLine, printedLines map[instanceLogLine]bool) ([]instanceLogLine, map[instanceLogLine]bool) {
+	newLines := []instanceLogLine(nil)
+	seen := make(map[instanceLogLine]bool, len(lines))
+	for _, line := range lines {
+		seen[line] = true
+		if !printedLines[line] {
+			newLines = append(newLines, line)
+		}
+	}
+
+	return newLines, seen
+}
+
+func formatInstanceLogLine(line instanceLogLine, withNode bool) string {
+	text := line.Text
+	switch {
+	case strings.Contains(text, "ERROR") || strings.Contains(text, "FATAL") || strings.Contains(text, "PANIC"):
+		text = terminal.Style(text, color.FgRed)
+	case strings.Contains(text, "WARNING") || strings.Contains(text, "[Warning]"):
+		text = terminal.Style(text, color.FgYellow)
+	}
+
+	if !withNode {
+		return text
+	}
+	return terminal.Style(line.Node, color.Faint) + " " + text
+}
@@ -0,0 +1,25 @@
+package rdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newInstanceLogLines(t *testing.T) {
+	lines := []instanceLogLine{
+		{Node: "node-0", Text: "2024-01-01 12:00:00 UTC [42]: LOG:  checkpoint starting"},
+		{Node: "node-0", Text: "2024-01-01 12:00:30 UTC [42]: LOG:  checkpoint complete"},
+	}
+	newLines, printedLines := newInstanceLogLines(lines, nil)
+	assert.Equal(t, lines, newLines)
+
+	// The next poll overlaps the previous one
+	lines = []instanceLogLine{
+		{Node: "node-0", Text: "2024-01-01 12:00:30 UTC [42]: LOG:  checkpoint complete"},
+		{Node: "node-1", Text: "2024-01-01 12:00:30 UTC [42]: LOG:  checkpoint complete"},
+		{Node: "node-0", Text: "2024-01-01 12:01:00 UTC [43]: ERROR:  relation \"users\" does not exist"},
+	}
+	newLines, _ = newInstanceLogLines(lines, printedLines)
+	assert.Equal(t, lines[1:], newLines)
+}
@@ -0,0 +1,405 @@
+package rdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-cli/v2/internal/tabwriter"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var privilegePermissions = []rdb.Permission{
+	rdb.PermissionReadonly,
+	rdb.PermissionReadwrite,
+	rdb.PermissionAll,
+	rdb.PermissionCustom,
+	rdb.PermissionNone,
+}
+
+// privilegeMatrix is the permission of each user of an instance on each of its databases.
+type privilegeMatrix struct {
+	Databases []string               `json:"databases"`
+	Users     []*privilegeMatrixUser `json:"users"`
+}
+
+type privilegeMatrixUser struct {
+	Name    string `json:"name"`
+	IsAdmin bool   `json:"is_admin"`
+	// Permissions are indexed by database name.
+	Permissions map[string]rdb.Permission `json:"permissions"`
+}
+
+// privilegeApplySpec is the declarative list of the permissions of users on databases.
+type privilegeApplySpec struct {
+	Privileges []*rdb.Privilege `json:"privileges"`
+}
+
+// privilegeChange is a permission to change to reconcile the privileges of an instance with a file.
+type privilegeChange struct {
+	UserName          string         `json:"user_name"`
+	DatabaseName      string         `json:"database_name"`
+	CurrentPermission rdb.Permission `json:"current_permission"`
+	DesiredPermission rdb.Permission `json:"desired_permission"`
+}
+
+type aclMatrixArgs struct {
+	InstanceID string
+	Region     scw.Region
+}
+
+type privilegeApplyArgs struct {
+	InstanceID string
+	File       string
+	DryRun     bool
+	Yes        bool
+	Region     scw.Region
+}
+
+func aclMatrixCommand() *core.Command {
+	return &core.Command{
+		Short: `Display the permissions of the users on the databases`,
+		Long: `Display a table of the permissions of each user of a Database Instance on each of its databases.
+Admin users can manage all the databases whatever their permissions.`,
+		Namespace: "rdb",
+		Resource:  "acl",
+		Verb:      "matrix",
+		ArgsType:  reflect.TypeOf(aclMatrixArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "instance-id",
+				Short:      `UUID of the Database Instance`,
+				Required:   true,
+				Positional: true,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*aclMatrixArgs)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+
+			users, databases, privileges, err := listInstancePrivileges(ctx, api, args.Region, args.InstanceID)
+			if err != nil {
+				return nil, err
+			}
+			return buildPrivilegeMatrix(users, databases, privileges), nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the permissions of the users of a Database Instance",
+				Raw:   "scw rdb acl matrix 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb privilege apply",
+				Short:   "Apply a list of privileges to a Database Instance",
+			},
+			{
+				Command: "scw rdb privilege list",
+				Short:   "List user privileges for a database",
+			},
+		},
+	}
+}
+
+func privilegeApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a list of privileges to a Database Instance`,
+		Long: `Set the permissions of users on databases listed in a YAML or JSON file, with a single command.
+Only the permissions of the listed users and databases are changed, use the none permission to revoke one. The permissions which change are displayed and must be confirmed before being applied.`,
+		Namespace: "rdb",
+		Resource:  "privilege",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(privilegeApplyArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:     "instance-id",
+				Short:    `UUID of the Database Instance`,
+				Required: true,
+			},
+			{
+				Name:        "file",
+				Short:       `YAML or JSON file with the list of privileges, each with a user_name, a database_name and a permission`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the changes`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: privilegeApplyRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "User", FieldName: "UserName"},
+				{Label: "Database", FieldName: "DatabaseName"},
+				{Label: "Current Permission", FieldName: "CurrentPermission"},
+				{Label: "Desired Permission", FieldName: "DesiredPermission"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a list of privileges",
+				Raw:   "scw rdb privilege apply instance-id=11111111-1111-1111-1111-111111111111 file=@grants.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a list of privileges without confirmation",
+				Raw:   "scw rdb privilege apply instance-id=11111111-1111-1111-1111-111111111111 file=@grants.yaml yes=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb acl matrix",
+				Short:   "Display the permissions of the users on the databases",
+			},
+			{
+				Command: "scw rdb privilege set",
+				Short:   "Set user privileges for a database",
+			},
+		},
+	}
+}
+
+func privilegeApplyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*privilegeApplyArgs)
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+
+	spec := &privilegeApplySpec{}
+	if err := yaml.Unmarshal([]byte(args.File), spec); err != nil {
+		return nil, fmt.Errorf("cannot parse the privileges: %s", err)
+	}
+
+	users, databases, privileges, err := listInstancePrivileges(ctx, api, args.Region, args.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePrivilegeApplySpec(spec, users, databases); err != nil {
+		return nil, err
+	}
+
+	changes := planPrivileges(privileges, spec.Privileges)
+	if args.DryRun {
+		return changes, nil
+	}
+	if len(changes) == 0 {
+		return &core.SuccessResult{Message: "Privileges are already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying changes must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range changes {
+			_, _ = interactive.Println(privilegeChangeDiff(change))
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	for _, change := range changes {
+		_, err := api.SetPrivilege(&rdb.SetPrivilegeRequest{
+			Region:       args.Region,
+			InstanceID:   args.InstanceID,
+			DatabaseName: change.DatabaseName,
+			UserName:     change.UserName,
+			Permission:   change.DesiredPermission,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, fmt.Errorf("cannot set the permission of user %s on database %s: %w", change.UserName, change.DatabaseName, err)
+		}
+	}
+	return changes, nil
+}
+
+func listInstancePrivileges(ctx context.Context, api *rdb.API, region scw.Region, instanceID string) ([]*rdb.User, []*rdb.Database, []*rdb.Privilege, error) {
+	users, err := api.ListUsers(&rdb.ListUsersRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	databases, err := api.ListDatabases(&rdb.ListDatabasesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	privileges, err := api.ListPrivileges(&rdb.ListPrivilegesRequest{
+		Region:     region,
+		InstanceID: instanceID,
+	}, scw.WithContext(ctx), scw.WithAllPages())
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return users.Users, databases.Databases, privileges.Privileges, nil
+}
+
+// buildPrivilegeMatrix returns the permission of each user on each database, none when the user has no privilege on it.
+func buildPrivilegeMatrix(users []*rdb.User, databases []*rdb.Database, privileges []*rdb.Privilege) privilegeMatrix {
+	matrix := privilegeMatrix{}
+	for _, database := range databases {
+		matrix.Databases = append(matrix.Databases, database.Name)
+	}
+	sort.Strings(matrix.Databases)
+
+	usersByName := map[string]*privilegeMatrixUser{}
+	for _, user := range users {
+		matrixUser := &privilegeMatrixUser{
+			Name:        user.Name,
+			IsAdmin:     user.IsAdmin,
+			Permissions: map[string]rdb.Permission{},
+		}
+		for _, database := range matrix.Databases {
+			matrixUser.Permissions[database] = rdb.PermissionNone
+		}
+		usersByName[user.Name] = matrixUser
+		matrix.Users = append(matrix.Users, matrixUser)
+	}
+	sort.Slice(matrix.Users, func(i, j int) bool {
+		return matrix.Users[i].Name < matrix.Users[j].Name
+	})
+
+	for _, privilege := range privileges {
+		user, exists := usersByName[privilege.UserName]
+		if !exists {
+			continue
+		}
+		if _, exists := user.Permissions[privilege.DatabaseName]; exists {
+			user.Permissions[privilege.DatabaseName] = privilege.Permission
+		}
+	}
+
+	return matrix
+}
+
+func privilegeMatrixMarshalerFunc(i interface{}, _ *human.MarshalOpt) (string, error) {
+	matrix := i.(privilegeMatrix)
+	if len(matrix.Users) == 0 {
+		return "No users", nil
+	}
+
+	buffer := bytes.Buffer{}
+	w := tabwriter.NewWriter(&buffer, 5, 1, 2, ' ', tabwriter.ANSIGraphicsRendition)
+	fmt.Fprintln(w, strings.Join(append([]string{"USER", "ADMIN"}, matrix.Databases...), "\t"))
+	for _, user := range matrix.Users {
+		row := []string{user.Name, fmt.Sprint(user.IsAdmin)}
+		for _, database := range matrix.Databases {
+			permission := user.Permissions[database]
+			if permission == rdb.PermissionNone {
+				row = append(row, terminal.Style(permission.String(), color.Faint))
+			} else {
+				row = append(row, permission.String())
+			}
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	w.Flush()
+	return strings.TrimSpace(buffer.String()), nil
+}
+
+func validatePrivilegeApplySpec(spec *privilegeApplySpec, users []*rdb.User, databases []*rdb.Database) error {
+	userNames := map[string]bool{}
+	for _, user := range users {
+		userNames[user.Name] = true
+	}
+	databaseNames := map[string]bool{}
+	for _, database := range databases {
+		databaseNames[database.Name] = true
+	}
+	permissions := map[rdb.Permission]bool{}
+	for _, permission := range privilegePermissions {
+		permissions[permission] = true
+	}
+
+	privileges := map[[2]string]bool{}
+	for i, privilege := range spec.Privileges {
+		switch {
+		case privilege == nil || privilege.UserName == "" || privilege.DatabaseName == "":
+			return fmt.Errorf("privilege %d must have a user_name and a database_name", i+1)
+		case !userNames[privilege.UserName]:
+			return fmt.Errorf("user %s does not exist", privilege.UserName)
+		case !databaseNames[privilege.DatabaseName]:
+			return fmt.Errorf("database %s does not exist", privilege.DatabaseName)
+		case !permissions[privilege.Permission]:
+			return fmt.Errorf("permission of user %s on database %s must be one of %s", privilege.UserName, privilege.DatabaseName, privilegePermissions)
+		}
+
+		key := [2]string{privilege.UserName, privilege.DatabaseName}
+		if privileges[key] {
+			return fmt.Errorf("permission of user %s on database %s is defined twice", privilege.UserName, privilege.DatabaseName)
+		}
+		privileges[key] = true
+	}
+	return nil
+}
+
+// planPrivileges returns the permissions which differ from the desired ones, sorted by user and database.
+func planPrivileges(current []*rdb.Privilege, desired []*rdb.Privilege) []*privilegeChange {
+	currentPermissions := map[[2]string]rdb.Permission{}
+	for _, privilege := range current {
+		currentPermissions[[2]string{privilege.UserName, privilege.DatabaseName}] = privilege.Permission
+	}
+
+	changes := []*privilegeChange(nil)
+	for _, privilege := range desired {
+		currentPermission, exists := currentPermissions[[2]string{privilege.UserName, privilege.DatabaseName}]
+		if !exists {
+			currentPermission = rdb.PermissionNone
+		}
+		if currentPermission == privilege.Permission {
+			continue
+		}
+		changes = append(changes, &privilegeChange{
+			UserName:          privilege.UserName,
+			DatabaseName:      privilege.DatabaseName,
+			CurrentPermission: currentPermission,
+			DesiredPermission: privilege.Permission,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].UserName != changes[j].UserName {
+			return changes[i].UserName < changes[j].UserName
+		}
+		return changes[i].DatabaseName < changes[j].DatabaseName
+	})
+	return changes
+}
+
+func privilegeChangeDiff(change *privilegeChange) string {
+	return fmt.Sprintf("~ %s on %s: %s -> %s", change.UserName, change.DatabaseName, change.CurrentPermission, change.DesiredPermission)
+}
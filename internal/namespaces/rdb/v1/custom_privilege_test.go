@@ -0,0 +1,67 @@
+package rdb
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildPrivilegeMatrix(t *testing.T) {
+	matrix := buildPrivilegeMatrix(
+		[]*rdb.User{{Name: "reporting"}, {Name: "admin", IsAdmin: true}},
+		[]*rdb.Database{{Name: "shop"}, {Name: "analytics"}},
+		[]*rdb.Privilege{
+			{UserName: "reporting", DatabaseName: "analytics", Permission: rdb.PermissionReadonly},
+			{UserName: "admin", DatabaseName: "shop", Permission: rdb.PermissionAll},
+			{UserName: "deleted", DatabaseName: "shop", Permission: rdb.PermissionAll},
+		},
+	)
+
+	assert.Equal(t, []string{"analytics", "shop"}, matrix.Databases)
+	assert.Equal(t, []*privilegeMatrixUser{
+		{Name: "admin", IsAdmin: true, Permissions: map[string]rdb.Permission{"analytics": rdb.PermissionNone, "shop": rdb.PermissionAll}},
+		{Name: "reporting", Permissions: map[string]rdb.Permission{"analytics": rdb.PermissionReadonly, "shop": rdb.PermissionNone}},
+	}, matrix.Users)
+}
+
+func Test_planPrivileges(t *testing.T) {
+	users := []*rdb.User{{Name: "app"}, {Name: "reporting"}}
+	databases := []*rdb.Database{{Name: "shop"}, {Name: "analytics"}}
+
+	spec := &privilegeApplySpec{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`privileges:
+- user_name: reporting
+  database_name: shop
+  permission: readonly
+- user_name: app
+  database_name: shop
+  permission: readwrite
+- user_name: app
+  database_name: analytics
+  permission: none
+`), spec))
+	assert.NoError(t, validatePrivilegeApplySpec(spec, users, databases))
+
+	changes := planPrivileges([]*rdb.Privilege{
+		{UserName: "app", DatabaseName: "shop", Permission: rdb.PermissionAll},
+		{UserName: "app", DatabaseName: "analytics", Permission: rdb.PermissionNone},
+	}, spec.Privileges)
+	assert.Equal(t, []*privilegeChange{
+		{UserName: "app", DatabaseName: "shop", CurrentPermission: rdb.PermissionAll, DesiredPermission: rdb.PermissionReadwrite},
+		{UserName: "reporting", DatabaseName: "shop", CurrentPermission: rdb.PermissionNone, DesiredPermission: rdb.PermissionReadonly},
+	}, changes)
+	assert.Equal(t, "~ app on shop: all -> readwrite", privilegeChangeDiff(changes[0]))
+
+	for _, invalidSpec := range []string{
+		"privileges:\n- user_name: unknown\n  database_name: shop\n  permission: all\n",
+		"privileges:\n- user_name: app\n  database_name: unknown\n  permission: all\n",
+		"privileges:\n- user_name: app\n  database_name: shop\n  permission: owner\n",
+		"privileges:\n- user_name: app\n  database_name: shop\n  permission: all\n- user_name: app\n  database_name: shop\n  permission: none\n",
+	} {
+		spec := &privilegeApplySpec{}
+		assert.NoError(t, yaml.Unmarshal([]byte(invalidSpec), spec))
+		assert.Error(t, validatePrivilegeApplySpec(spec, users, databases), invalidSpec)
+	}
+}
@@ -0,0 +1,198 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type rdbReadReplicaEndpointSpecPrivateNetworkCustom struct {
+	*rdb.ReadReplicaEndpointSpecPrivateNetwork
+	EnableIpam bool `json:"enable-ipam"`
+}
+
+type rdbReadReplicaEndpointSpecCustom struct {
+	PrivateNetwork *rdbReadReplicaEndpointSpecPrivateNetworkCustom `json:"private-network"`
+	DirectAccess   bool                                            `json:"direct-access"`
+}
+
+func addReadReplicaEndpointArgSpecs(c *core.Command) {
+	c.ArgSpecs.AddBefore("endpoint-spec.{index}.private-network.private-network-id", &core.ArgSpec{
+		Name:     "endpoint-spec.{index}.direct-access",
+		Short:    "Will configure a public direct access endpoint if true",
+		Required: false,
+		Default:  core.DefaultValueSetter("false"),
+	})
+	c.ArgSpecs.AddBefore("endpoint-spec.{index}.private-network.private-network-id", &core.ArgSpec{
+		Name:     "endpoint-spec.{index}.private-network.enable-ipam",
+		Short:    "Will configure your Private Network endpoint with Scaleway IPAM service if true",
+		Required: false,
+		Default:  core.DefaultValueSetter("false"),
+	})
+}
+
+func readReplicaCreateBuilder(c *core.Command) *core.Command {
+	type rdbCreateReadReplicaRequestCustom struct {
+		*rdb.CreateReadReplicaRequest
+		EndpointSpec []*rdbReadReplicaEndpointSpecCustom `json:"endpoint-spec"`
+	}
+
+	addReadReplicaEndpointArgSpecs(c)
+	c.ArgsType = reflect.TypeOf(rdbCreateReadReplicaRequestCustom{})
+
+	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+		api := rdb.NewAPI(core.ExtractClient(ctx))
+
+		customRequest := argsI.(*rdbCreateReadReplicaRequestCustom)
+		createReadReplicaRequest := customRequest.CreateReadReplicaRequest
+
+		endpointSpecs, err := readReplicaEndpointSpecs(customRequest.EndpointSpec)
+		if err != nil {
+			return nil, err
+		}
+		createReadReplicaRequest.EndpointSpec = endpointSpecs
+
+		return api.CreateReadReplica(createReadReplicaRequest, scw.WithContext(ctx))
+	}
+	c.WaitFunc = readReplicaWaitFunc
+	c.Examples = []*core.Example{
+		{
+			Short: "Create a Read Replica with a public endpoint",
+			Raw:   "scw rdb read-replica create 11111111-1111-1111-1111-111111111111 endpoint-spec.0.direct-access=true",
+		},
+		{
+			Short: "Create a Read Replica attached to a Private Network with IPAM",
+			Raw:   "scw rdb read-replica create 11111111-1111-1111-1111-111111111111 endpoint-spec.0.private-network.private-network-id=22222222-2222-2222-2222-222222222222 endpoint-spec.0.private-network.enable-ipam=true --wait",
+		},
+		{
+			Short: "Create a Read Replica attached to a Private Network with a static IP",
+			Raw:   "scw rdb read-replica create 11111111-1111-1111-1111-111111111111 endpoint-spec.0.private-network.private-network-id=22222222-2222-2222-2222-222222222222 endpoint-spec.0.private-network.service-ip=192.168.1.10/24",
+		},
+	}
+
+	return c
+}
+
+func readReplicaCreateEndpointBuilder(c *core.Command) *core.Command {
+	type rdbCreateReadReplicaEndpointRequestCustom struct {
+		*rdb.CreateReadReplicaEndpointRequest
+		EndpointSpec []*rdbReadReplicaEndpointSpecCustom `json:"endpoint-spec"`
+	}
+
+	addReadReplicaEndpointArgSpecs(c)
+	c.ArgsType = reflect.TypeOf(rdbCreateReadReplicaEndpointRequestCustom{})
+
+	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+		api := rdb.NewAPI(core.ExtractClient(ctx))
+
+		customRequest := argsI.(*rdbCreateReadReplicaEndpointRequestCustom)
+		createEndpointRequest := customRequest.CreateReadReplicaEndpointRequest
+
+		endpointSpecs, err := readReplicaEndpointSpecs(customRequest.EndpointSpec)
+		if err != nil {
+			return nil, err
+		}
+		createEndpointRequest.EndpointSpec = endpointSpecs
+
+		return api.CreateReadReplicaEndpoint(createEndpointRequest, scw.WithContext(ctx))
+	}
+	c.WaitFunc = readReplicaWaitFunc
+
+	return c
+}
+
+func readReplicaPromoteCommand() *core.Command {
+	return &core.Command{
+		Short: `Promote a Read Replica to a Database Instance`,
+		Long: `Promote a Read Replica to a standalone Database Instance with its own endpoints.
+The replication stops and the Read Replica cannot be attached to its former Database Instance anymore.`,
+		Namespace: "rdb",
+		Resource:  "read-replica",
+		Verb:      "promote",
+		ArgsType:  reflect.TypeOf(rdb.PromoteReadReplicaRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "read-replica-id",
+				Short:      `UUID of the Read Replica`,
+				Required:   true,
+				Positional: true,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			request := argsI.(*rdb.PromoteReadReplicaRequest)
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+			return api.PromoteReadReplica(request, scw.WithContext(ctx))
+		},
+		WaitFunc: func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+			api := rdb.NewAPI(core.ExtractClient(ctx))
+			return api.WaitForInstance(&rdb.WaitForInstanceRequest{
+				InstanceID:    respI.(*rdb.Instance).ID,
+				Region:        respI.(*rdb.Instance).Region,
+				Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
+				RetryInterval: core.DefaultRetryInterval,
+			})
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Promote a Read Replica and wait for the new Database Instance to be ready",
+				Raw:   "scw rdb read-replica promote 11111111-1111-1111-1111-111111111111 --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw rdb read-replica create",
+				Short:   "Create a Read Replica",
+			},
+		},
+	}
+}
+
+func readReplicaWaitFunc(ctx context.Context, _, respI interface{}) (interface{}, error) {
+	api := rdb.NewAPI(core.ExtractClient(ctx))
+	return api.WaitForReadReplica(&rdb.WaitForReadReplicaRequest{
+		ReadReplicaID: respI.(*rdb.ReadReplica).ID,
+		Region:        respI.(*rdb.ReadReplica).Region,
+		Timeout:       core.WaitTimeout(ctx, instanceActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	})
+}
+
+// readReplicaEndpointSpecs converts the endpoints given as arguments, a Private Network endpoint needs either a static service IP or IPAM.
+func readReplicaEndpointSpecs(customSpecs []*rdbReadReplicaEndpointSpecCustom) ([]*rdb.ReadReplicaEndpointSpec, error) {
+	endpointSpecs := []*rdb.ReadReplicaEndpointSpec(nil)
+	for i, customSpec := range customSpecs {
+		switch {
+		case customSpec.DirectAccess:
+			endpointSpecs = append(endpointSpecs, &rdb.ReadReplicaEndpointSpec{
+				DirectAccess: &rdb.ReadReplicaEndpointSpecDirectAccess{},
+			})
+		case customSpec.PrivateNetwork != nil && customSpec.PrivateNetwork.ReadReplicaEndpointSpecPrivateNetwork != nil:
+			privateNetwork := customSpec.PrivateNetwork
+			if privateNetwork.EnableIpam == (privateNetwork.ServiceIP != nil) {
+				return nil, &core.CliError{
+					Err:  fmt.Errorf("endpoint %d must either have a service IP or use IPAM", i),
+					Hint: fmt.Sprintf("Use endpoint-spec.%d.private-network.service-ip=<ip-cidr> or endpoint-spec.%d.private-network.enable-ipam=true", i, i),
+				}
+			}
+
+			ipamConfig := &rdb.ReadReplicaEndpointSpecPrivateNetworkIpamConfig{}
+			if !privateNetwork.EnableIpam {
+				ipamConfig = nil
+			}
+			endpointSpecs = append(endpointSpecs, &rdb.ReadReplicaEndpointSpec{
+				PrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{
+					PrivateNetworkID: privateNetwork.PrivateNetworkID,
+					ServiceIP:        privateNetwork.ServiceIP,
+					IpamConfig:       ipamConfig,
+				},
+			})
+		}
+	}
+
+	return endpointSpecs, nil
+}
@@ -0,0 +1,44 @@
+package rdb
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readReplicaEndpointSpecs(t *testing.T) {
+	serviceIP := &scw.IPNet{IPNet: net.IPNet{IP: net.IPv4(192, 168, 1, 10), Mask: net.CIDRMask(24, 32)}}
+
+	specs, err := readReplicaEndpointSpecs([]*rdbReadReplicaEndpointSpecCustom{
+		{DirectAccess: true},
+		{PrivateNetwork: &rdbReadReplicaEndpointSpecPrivateNetworkCustom{
+			ReadReplicaEndpointSpecPrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{PrivateNetworkID: "pn-1"},
+			EnableIpam:                            true,
+		}},
+		{PrivateNetwork: &rdbReadReplicaEndpointSpecPrivateNetworkCustom{
+			ReadReplicaEndpointSpecPrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{PrivateNetworkID: "pn-2", ServiceIP: serviceIP},
+		}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*rdb.ReadReplicaEndpointSpec{
+		{DirectAccess: &rdb.ReadReplicaEndpointSpecDirectAccess{}},
+		{PrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{
+			PrivateNetworkID: "pn-1",
+			IpamConfig:       &rdb.ReadReplicaEndpointSpecPrivateNetworkIpamConfig{},
+		}},
+		{PrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{
+			PrivateNetworkID: "pn-2",
+			ServiceIP:        serviceIP,
+		}},
+	}, specs)
+
+	_, err = readReplicaEndpointSpecs([]*rdbReadReplicaEndpointSpecCustom{
+		{PrivateNetwork: &rdbReadReplicaEndpointSpecPrivateNetworkCustom{
+			ReadReplicaEndpointSpecPrivateNetwork: &rdb.ReadReplicaEndpointSpecPrivateNetwork{PrivateNetworkID: "pn-1"},
+		}},
+	})
+	assert.Error(t, err)
+}
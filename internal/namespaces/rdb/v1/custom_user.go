@@ -2,7 +2,6 @@ package rdb
 
 import (
 	"context"
-	"fmt"
 	"reflect"
 
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
@@ -90,7 +89,7 @@ func userListBuilder(c *core.Command) *core.Command {
 func userCreateBuilder(c *core.Command) *core.Command {
 	type rdbCreateUserRequestCustom struct {
 		*rdb.CreateUserRequest
-		GeneratePassword bool
+		passwordgenerator.Args
 	}
 
 	type rdbCreateUserResponseCustom struct {
@@ -98,14 +97,9 @@ func userCreateBuilder(c *core.Command) *core.Command {
 		Password string `json:"password"`
 	}
 
-	c.ArgSpecs.AddBefore("password", &core.ArgSpec{
-		Name:       "generate-password",
-		Short:      `Will generate a 21 character-length password that contains a mix of upper/lower case letters, numbers and special symbols`,
-		Required:   false,
-		Deprecated: false,
-		Positional: false,
-		Default:    core.DefaultValueSetter("true"),
-	})
+	for _, argSpec := range passwordgenerator.ArgSpecs() {
+		c.ArgSpecs.AddBefore("password", argSpec)
+	}
 	c.ArgsType = reflect.TypeOf(rdbCreateUserRequestCustom{})
 
 	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
@@ -116,13 +110,12 @@ func userCreateBuilder(c *core.Command) *core.Command {
 		createUserRequest := customRequest.CreateUserRequest
 
 		var err error
+		displayedPassword := createUserRequest.Password
 		if customRequest.GeneratePassword && customRequest.Password == "" {
-			createUserRequest.Password, err = passwordgenerator.GeneratePassword(21, 1, 1, 1, 1)
+			createUserRequest.Password, displayedPassword, err = passwordgenerator.Generate(&customRequest.Args)
 			if err != nil {
 				return nil, err
 			}
-			fmt.Printf("Your generated password is %s \n", createUserRequest.Password)
-			fmt.Printf("\n")
 		}
 
 		user, err := api.CreateUser(createUserRequest)
@@ -132,7 +125,7 @@ func userCreateBuilder(c *core.Command) *core.Command {
 
 		result := rdbCreateUserResponseCustom{
 			User:     user,
-			Password: createUserRequest.Password,
+			Password: displayedPassword,
 		}
 
 		return result, nil
@@ -144,7 +137,7 @@ func userCreateBuilder(c *core.Command) *core.Command {
 func userUpdateBuilder(c *core.Command) *core.Command {
 	type rdbUpdateUserRequestCustom struct {
 		*rdb.UpdateUserRequest
-		GeneratePassword bool
+		passwordgenerator.Args
 	}
 
 	type rdbUpdateUserResponseCustom struct {
@@ -152,14 +145,9 @@ func userUpdateBuilder(c *core.Command) *core.Command {
 		Password string `json:"password"`
 	}
 
-	c.ArgSpecs.AddBefore("password", &core.ArgSpec{
-		Name:       "generate-password",
-		Short:      `Will generate a 21 character-length password that contains a mix of upper/lower case letters, numbers and special symbols`,
-		Required:   false,
-		Deprecated: false,
-		Positional: false,
-		Default:    core.DefaultValueSetter("true"),
-	})
+	for _, argSpec := range passwordgenerator.ArgSpecs() {
+		c.ArgSpecs.AddBefore("password", argSpec)
+	}
 	c.ArgsType = reflect.TypeOf(rdbUpdateUserRequestCustom{})
 
 	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
@@ -171,14 +159,15 @@ func userUpdateBuilder(c *core.Command) *core.Command {
 		updateUserRequest := customRequest.UpdateUserRequest
 
 		var err error
+		displayedPassword := ""
 		if customRequest.GeneratePassword && customRequest.Password == nil {
 			updateUserRequest.Password = new(string)
-			*updateUserRequest.Password, err = passwordgenerator.GeneratePassword(21, 1, 1, 1, 1)
+			*updateUserRequest.Password, displayedPassword, err = passwordgenerator.Generate(&customRequest.Args)
 			if err != nil {
 				return nil, err
 			}
-			fmt.Printf("Your generated password is %v \n", *updateUserRequest.Password)
-			fmt.Printf("\n")
+		} else if updateUserRequest.Password != nil {
+			displayedPassword = *updateUserRequest.Password
 		}
 
 		user, err := api.UpdateUser(updateUserRequest)
@@ -188,7 +177,7 @@ func userUpdateBuilder(c *core.Command) *core.Command {
 
 		result := rdbUpdateUserResponseCustom{
 			User:     user,
-			Password: *updateUserRequest.Password,
+			Password: displayedPassword,
 		}
 
 		return result, nil
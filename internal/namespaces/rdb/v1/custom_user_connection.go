@@ -0,0 +1,299 @@
+package rdb
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// Sessions are not exposed by the management API, they are queried with the engine CLI through the connect machinery.
+const (
+	postgreSQLListConnectionsQuery = `SELECT pid, usename, datname, COALESCE(client_addr::text, ''), COALESCE(state, ''), COALESCE(EXTRACT(EPOCH FROM now() - query_start)::int, 0), regexp_replace(query, '\s+', ' ', 'g') FROM pg_stat_activity WHERE backend_type = 'client backend' AND pid <> pg_backend_pid()`
+	mySQLListConnectionsQuery      = `SELECT id, user, IFNULL(db, ''), host, command, time, IFNULL(REPLACE(REPLACE(REPLACE(info, '\n', ' '), '\r', ' '), '\t', ' '), '') FROM information_schema.processlist WHERE id <> CONNECTION_ID()`
+)
+
+type userConnectionArgs struct {
+	Region         scw.Region
+	PrivateNetwork bool
+	InstanceID     string
+	Username       string
+	Database       *string
+	CliDB          *string
+}
+
+type userListConnectionsArgs struct {
+	userConnectionArgs
+	Name string
+}
+
+type userKillConnectionArgs struct {
+	userConnectionArgs
+	ConnectionID uint64
+	QueryOnly    bool
+}
+
+type userConnection struct {
+	ID            string        `json:"id"`
+	User          string        `json:"user"`
+	Database      string        `json:"database"`
+	ClientAddress string        `json:"client_address"`
+	State         string        `json:"state"`
+	Duration      time.Duration `json:"duration"`
+	Query         string        `json:"query"`
+}
+
+func userConnectionArgSpecs() core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:       "instance-id",
+			Short:      `UUID of the Database Instance`,
+			Required:   true,
+			Positional: true,
+		},
+		{
+			Name:     "username",
+			Short:    "Name of the admin user to connect with to the database",
+			Required: true,
+		},
+		{
+			Name:    "database",
+			Short:   "Name of the database",
+			Default: core.DefaultValueSetter("rdb"),
+		},
+		{
+			Name:    "private-network",
+			Short:   `Connect by the private network endpoint attached.`,
+			Default: core.DefaultValueSetter("false"),
+		},
+		{
+			Name:  "cli-db",
+			Short: "Command line tool to use, default to psql/mysql",
+		},
+	}
+}
+
+func userListConnectionsCommand() *core.Command {
+	argSpecs := userConnectionArgSpecs()
+	argSpecs = append(argSpecs,
+		&core.ArgSpec{
+			Name:  "name",
+			Short: "Only list the sessions of this user",
+		},
+		core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms),
+	)
+
+	return &core.Command{
+		Namespace: "rdb",
+		Resource:  "user",
+		Verb:      "list-connections",
+		Short:     "List the sessions opened on a Database Instance",
+		Long: `List the sessions currently opened on a Database Instance with their running query.
+Sessions are queried with the locally installed CLI (psql or mysql), connected with the given admin user.`,
+		ArgsType: reflect.TypeOf(userListConnectionsArgs{}),
+		ArgSpecs: argSpecs,
+		Run:      userListConnectionsRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "ID", FieldName: "ID"},
+				{Label: "User", FieldName: "User"},
+				{Label: "Database", FieldName: "Database"},
+				{Label: "Client Address", FieldName: "ClientAddress"},
+				{Label: "State", FieldName: "State"},
+				{Label: "Duration", FieldName: "Duration"},
+				{Label: "Query", FieldName: "Query"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the sessions of a given user",
+				Raw:   "scw rdb user list-connections 11111111-1111-1111-1111-111111111111 username=admin name=app",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Kill a session",
+				Command: "scw rdb user kill-connection",
+			},
+		},
+	}
+}
+
+func userKillConnectionCommand() *core.Command {
+	argSpecs := userConnectionArgSpecs()
+	argSpecs = append(argSpecs,
+		&core.ArgSpec{
+			Name:     "connection-id",
+			Short:    "ID of the session to kill, as returned by list-connections",
+			Required: true,
+		},
+		&core.ArgSpec{
+			Name:  "query-only",
+			Short: "Only cancel the running query and keep the session opened",
+		},
+		core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms),
+	)
+
+	return &core.Command{
+		Namespace: "rdb",
+		Resource:  "user",
+		Verb:      "kill-connection",
+		Short:     "Kill a session opened on a Database Instance",
+		Long: `Terminate a session opened on a Database Instance, or only cancel its running query.
+The session is killed with the locally installed CLI (psql or mysql), connected with the given admin user.`,
+		ArgsType: reflect.TypeOf(userKillConnectionArgs{}),
+		ArgSpecs: argSpecs,
+		Run:      userKillConnectionRun,
+		Examples: []*core.Example{
+			{
+				Short: "Cancel the running query of a session",
+				Raw:   "scw rdb user kill-connection 11111111-1111-1111-1111-111111111111 username=admin connection-id=4242 query-only=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List sessions",
+				Command: "scw rdb user list-connections",
+			},
+		},
+	}
+}
+
+func userListConnectionsRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*userListConnectionsArgs)
+
+	family, output, err := runUserConnectionQuery(ctx, &args.userConnectionArgs, func(family engineFamily) string {
+		if family == MySQL {
+			return mySQLListConnectionsQuery
+		}
+		return postgreSQLListConnectionsQuery
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	connections, err := parseUserConnections(family, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Name == "" {
+		return connections, nil
+	}
+
+	filtered := []*userConnection(nil)
+	for _, connection := range connections {
+		if connection.User == args.Name {
+			filtered = append(filtered, connection)
+		}
+	}
+
+	return filtered, nil
+}
+
+func userKillConnectionRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*userKillConnectionArgs)
+
+	_, _, err := runUserConnectionQuery(ctx, &args.userConnectionArgs, func(family engineFamily) string {
+		return killConnectionQuery(family, args.ConnectionID, args.QueryOnly)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if args.QueryOnly {
+		return &core.SuccessResult{Message: fmt.Sprintf("Query of session %d has been cancelled", args.ConnectionID)}, nil
+	}
+
+	return &core.SuccessResult{Message: fmt.Sprintf("Session %d has been killed", args.ConnectionID)}, nil
+}
+
+// runUserConnectionQuery runs a SQL query on an instance with the engine CLI and returns its raw output.
+func runUserConnectionQuery(ctx context.Context, args *userConnectionArgs, query func(family engineFamily) string) (engineFamily, string, error) {
+	family, endpoint, err := getConnectEndpoint(ctx, args.Region, args.InstanceID, args.PrivateNetwork)
+	if err != nil {
+		return Unknown, "", err
+	}
+
+	cmdArgs, err := createConnectCommandLineArgs(endpoint, family, &instanceConnectArgs{
+		Region:     args.Region,
+		InstanceID: args.InstanceID,
+		Username:   args.Username,
+		Database:   args.Database,
+		CliDB:      args.CliDB,
+	})
+	if err != nil {
+		return Unknown, "", err
+	}
+
+	switch family {
+	case PostgreSQL:
+		cmdArgs = append(cmdArgs, "--no-align", "--tuples-only", "--field-separator", "\t", "--command", query(family))
+	case MySQL:
+		cmdArgs = append(cmdArgs, "--batch", "--skip-column-names", "--execute", query(family))
+	}
+
+	cmd := exec.Command(cmdArgs[0], cmdArgs[1:]...) //nolint:gosec
+	core.ExtractLogger(ctx).Debugf("executing: %s\n", cmd.Args)
+	output, exitCode, err := core.ExecCmdOutput(ctx, cmd)
+	if err != nil {
+		return Unknown, "", err
+	}
+	if exitCode != 0 {
+		return Unknown, "", &core.CliError{Empty: true, Code: exitCode}
+	}
+
+	return family, string(output), nil
+}
+
+func killConnectionQuery(family engineFamily, connectionID uint64, queryOnly bool) string {
+	switch {
+	case family == MySQL && queryOnly:
+		return fmt.Sprintf("KILL QUERY %d", connectionID)
+	case family == MySQL:
+		return fmt.Sprintf("KILL %d", connectionID)
+	case queryOnly:
+		return fmt.Sprintf("SELECT pg_cancel_backend(%d)", connectionID)
+	default:
+		return fmt.Sprintf("SELECT pg_terminate_backend(%d)", connectionID)
+	}
+}
+
+// parseUserConnections parses the tab separated output of the list connections queries.
+func parseUserConnections(family engineFamily, output string) ([]*userConnection, error) {
+	connections := []*userConnection(nil)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, "\t", 7)
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("unexpected %s output: %q", family, line)
+		}
+
+		seconds, err := strconv.ParseInt(fields[5], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected %s session duration: %w", family, err)
+		}
+
+		connections = append(connections, &userConnection{
+			ID:            fields[0],
+			User:          fields[1],
+			Database:      fields[2],
+			ClientAddress: fields[3],
+			State:         fields[4],
+			Duration:      time.Duration(seconds) * time.Second,
+			Query:         fields[6],
+		})
+	}
+
+	return connections, nil
+}
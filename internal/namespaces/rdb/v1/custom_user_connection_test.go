@@ -0,0 +1,51 @@
+package rdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseUserConnections(t *testing.T) {
+	t.Run("PostgreSQL", func(t *testing.T) {
+		output := "4242\tapp\trdb\t10.0.0.1\tactive\t90\tSELECT pg_sleep(3600)\n4243\tadmin\trdb\t\tidle\t0\t\n"
+		connections, err := parseUserConnections(PostgreSQL, output)
+		assert.NoError(t, err)
+		assert.Equal(t, []*userConnection{
+			{
+				ID:            "4242",
+				User:          "app",
+				Database:      "rdb",
+				ClientAddress: "10.0.0.1",
+				State:         "active",
+				Duration:      90 * time.Second,
+				Query:         "SELECT pg_sleep(3600)",
+			},
+			{
+				ID:       "4243",
+				User:     "admin",
+				Database: "rdb",
+				State:    "idle",
+			},
+		}, connections)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		connections, err := parseUserConnections(MySQL, "\n")
+		assert.NoError(t, err)
+		assert.Empty(t, connections)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := parseUserConnections(MySQL, "12\troot")
+		assert.Error(t, err)
+	})
+}
+
+func Test_killConnectionQuery(t *testing.T) {
+	assert.Equal(t, "SELECT pg_terminate_backend(12)", killConnectionQuery(PostgreSQL, 12, false))
+	assert.Equal(t, "SELECT pg_cancel_backend(12)", killConnectionQuery(PostgreSQL, 12, true))
+	assert.Equal(t, "KILL 12", killConnectionQuery(MySQL, 12, false))
+	assert.Equal(t, "KILL QUERY 12", killConnectionQuery(MySQL, 12, true))
+}
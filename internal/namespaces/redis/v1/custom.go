@@ -11,11 +11,18 @@ func GetCommands() *core.Commands {
 
 	human.RegisterMarshalerFunc(redis.Cluster{}, redisClusterGetMarshalerFunc)
 	human.RegisterMarshalerFunc(redis.Cluster{}.Endpoints, redisEndpointsClusterGetMarshalerFunc)
+	human.RegisterMarshalerFunc(createClusterResult{}, createClusterResultMarshalerFunc)
 
-	cmds.Merge(core.NewCommands(clusterWaitCommand()))
+	cmds.Merge(core.NewCommands(
+		clusterWaitCommand(),
+		clusterExportCommand(),
+		aclApplyCommand(),
+		settingApplyCommand(),
+	))
 	cmds.MustFind("redis", "cluster", "create").Override(clusterCreateBuilder)
 	cmds.MustFind("redis", "cluster", "delete").Override(clusterDeleteBuilder)
 	cmds.MustFind("redis", "acl", "add").Override(ACLAddListBuilder)
+	cmds.MustFind("redis", "cluster", "metrics").Override(clusterMetricsBuilder)
 
 	return cmds
 }
@@ -0,0 +1,382 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	applyOperationAdd    = "add"
+	applyOperationUpdate = "update"
+	applyOperationDelete = "delete"
+)
+
+// applyChange is a change needed to reconcile the ACL rules or the settings of a cluster with a file.
+// Name is the IP range of an ACL rule or the name of a setting, and values are descriptions or setting values.
+type applyChange struct {
+	Operation    string `json:"operation"`
+	Name         string `json:"name"`
+	CurrentValue string `json:"current_value,omitempty"`
+	DesiredValue string `json:"desired_value,omitempty"`
+}
+
+type applyRequest struct {
+	Zone      scw.Zone
+	ClusterID string
+	File      string
+	DryRun    bool
+	Yes       bool
+}
+
+// aclApplySpec is the declarative list of the ACL rules of a cluster.
+type aclApplySpec struct {
+	ACLRules []*redis.ACLRuleSpec `json:"acl_rules"`
+}
+
+// settingApplySpec is the declarative list of the advanced settings of a cluster.
+// Values may be written as YAML numbers or booleans, they are sent as strings.
+type settingApplySpec struct {
+	Settings []*struct {
+		Name  string          `json:"name"`
+		Value json.RawMessage `json:"value"`
+	} `json:"settings"`
+}
+
+func applyArgSpecs(fileShort string) core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:     "cluster-id",
+			Short:    `UUID of the Database Instance`,
+			Required: true,
+		},
+		{
+			Name:        "file",
+			Short:       fileShort,
+			Required:    true,
+			CanLoadFile: true,
+		},
+		{
+			Name:  "dry-run",
+			Short: `Only display the changes`,
+		},
+		{
+			Name:  "yes",
+			Short: `Do not ask for confirmation`,
+		},
+		core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZonePlWaw1, scw.ZonePlWaw2),
+	}
+}
+
+var applyView = &core.View{
+	Fields: []*core.ViewField{
+		{Label: "Operation", FieldName: "Operation"},
+		{Label: "Name", FieldName: "Name"},
+		{Label: "Current Value", FieldName: "CurrentValue"},
+		{Label: "Desired Value", FieldName: "DesiredValue"},
+	},
+}
+
+func aclApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a list of ACL rules to a cluster`,
+		Long: `Replace all the ACL rules of a Redis™ Database Instance with the rules of a YAML or JSON file, in a single request.
+Rules are matched by IP range. The rules added, deleted and whose description changes are displayed and must be confirmed before being applied.`,
+		Namespace: "redis",
+		Resource:  "acl",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(applyRequest{}),
+		ArgSpecs:  applyArgSpecs(`YAML or JSON file with the list of acl_rules of the cluster`),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*applyRequest)
+
+			spec := &aclApplySpec{}
+			if err := yaml.Unmarshal([]byte(args.File), spec); err != nil {
+				return nil, fmt.Errorf("cannot parse the ACL rules: %s", err)
+			}
+			if err := validateACLApplySpec(spec); err != nil {
+				return nil, err
+			}
+
+			return applyClusterChanges(ctx, args, func(cluster *redis.Cluster) []*applyChange {
+				return planACLRules(cluster.ACLRules, spec.ACLRules)
+			}, func(api *redis.API) error {
+				_, err := api.SetACLRules(&redis.SetACLRulesRequest{
+					Zone:      args.Zone,
+					ClusterID: args.ClusterID,
+					ACLRules:  spec.ACLRules,
+				}, scw.WithContext(ctx))
+				return err
+			})
+		},
+		WaitFunc: applyWaitFunc,
+		View:     applyView,
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a list of ACL rules",
+				Raw:   "scw redis acl apply cluster-id=11111111-1111-1111-1111-111111111111 file=@acls.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a list of ACL rules without confirmation",
+				Raw:   "scw redis acl apply cluster-id=11111111-1111-1111-1111-111111111111 file=@acls.yaml yes=true",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Set ACL rules for a cluster",
+				Command: "scw redis acl set",
+			},
+		},
+	}
+}
+
+func settingApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a list of advanced settings to a cluster`,
+		Long: `Replace all the advanced settings of a Redis™ Database Instance with the settings of a YAML or JSON file, in a single request.
+Settings missing from the file are restored to their default value. The settings added, deleted and whose value changes are displayed and must be confirmed before being applied.`,
+		Namespace: "redis",
+		Resource:  "setting",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(applyRequest{}),
+		ArgSpecs:  applyArgSpecs(`YAML or JSON file with the list of settings of the cluster`),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*applyRequest)
+
+			spec := &settingApplySpec{}
+			if err := yaml.Unmarshal([]byte(args.File), spec); err != nil {
+				return nil, fmt.Errorf("cannot parse the settings: %s", err)
+			}
+			settings, err := settingApplySpecSettings(spec)
+			if err != nil {
+				return nil, err
+			}
+
+			return applyClusterChanges(ctx, args, func(cluster *redis.Cluster) []*applyChange {
+				return planSettings(cluster.ClusterSettings, settings)
+			}, func(api *redis.API) error {
+				_, err := api.SetClusterSettings(&redis.SetClusterSettingsRequest{
+					Zone:      args.Zone,
+					ClusterID: args.ClusterID,
+					Settings:  settings,
+				}, scw.WithContext(ctx))
+				return err
+			})
+		},
+		WaitFunc: applyWaitFunc,
+		View:     applyView,
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a list of settings",
+				Raw:   "scw redis setting apply cluster-id=11111111-1111-1111-1111-111111111111 file=@settings.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a list of settings and wait for the cluster to be ready",
+				Raw:   "scw redis setting apply cluster-id=11111111-1111-1111-1111-111111111111 file=@settings.yaml --wait",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Set advanced settings",
+				Command: "scw redis setting set",
+			},
+		},
+	}
+}
+
+// applyClusterChanges plans the changes of a cluster, confirms them unless yes is set and applies them.
+// The changes are returned, so that they are displayed once applied.
+func applyClusterChanges(ctx context.Context, args *applyRequest, plan func(*redis.Cluster) []*applyChange, apply func(*redis.API) error) (interface{}, error) {
+	api := redis.NewAPI(core.ExtractClient(ctx))
+	cluster, err := api.GetCluster(&redis.GetClusterRequest{
+		Zone:      args.Zone,
+		ClusterID: args.ClusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := plan(cluster)
+	if args.DryRun {
+		return changes, nil
+	}
+	if len(changes) == 0 {
+		return &core.SuccessResult{Message: "Cluster is already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying changes must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range changes {
+			_, _ = interactive.Println(applyChangeDiff(change))
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	if err := apply(api); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func applyWaitFunc(ctx context.Context, argsI, respI interface{}) (interface{}, error) {
+	args := argsI.(*applyRequest)
+	if args.DryRun {
+		return respI, nil
+	}
+	_, err := redis.NewAPI(core.ExtractClient(ctx)).WaitForCluster(&redis.WaitForClusterRequest{
+		Zone:          args.Zone,
+		ClusterID:     args.ClusterID,
+		Timeout:       core.WaitTimeout(ctx, redisActionTimeout),
+		RetryInterval: core.DefaultRetryInterval,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return respI, nil
+}
+
+func validateACLApplySpec(spec *aclApplySpec) error {
+	ipRanges := map[string]bool{}
+	for i, rule := range spec.ACLRules {
+		if rule == nil || rule.IPCidr.IP == nil {
+			return fmt.Errorf("ACL rule %d must have an ip_cidr", i+1)
+		}
+		key := ipNetKey(rule.IPCidr)
+		if ipRanges[key] {
+			return fmt.Errorf("ACL rule %s is defined twice", key)
+		}
+		ipRanges[key] = true
+	}
+	return nil
+}
+
+// planACLRules compares the ACL rules of a cluster with the desired rules, matched by IP range.
+func planACLRules(current []*redis.ACLRule, desired []*redis.ACLRuleSpec) []*applyChange {
+	currentDescriptions := map[string]string{}
+	for _, rule := range current {
+		if rule.IPCidr == nil {
+			continue
+		}
+		description := ""
+		if rule.Description != nil {
+			description = *rule.Description
+		}
+		currentDescriptions[ipNetKey(*rule.IPCidr)] = description
+	}
+	desiredDescriptions := map[string]string{}
+	for _, rule := range desired {
+		desiredDescriptions[ipNetKey(rule.IPCidr)] = rule.Description
+	}
+
+	return planChanges(currentDescriptions, desiredDescriptions)
+}
+
+// planSettings compares the settings of a cluster with the desired settings, matched by name.
+func planSettings(current []*redis.ClusterSetting, desired []*redis.ClusterSetting) []*applyChange {
+	currentValues := map[string]string{}
+	for _, setting := range current {
+		currentValues[setting.Name] = setting.Value
+	}
+	desiredValues := map[string]string{}
+	for _, setting := range desired {
+		desiredValues[setting.Name] = setting.Value
+	}
+
+	return planChanges(currentValues, desiredValues)
+}
+
+// planChanges returns the changes turning current values into desired values, sorted by name.
+func planChanges(current map[string]string, desired map[string]string) []*applyChange {
+	changes := []*applyChange{}
+	for name, desiredValue := range desired {
+		currentValue, exists := current[name]
+		switch {
+		case !exists:
+			changes = append(changes, &applyChange{Operation: applyOperationAdd, Name: name, DesiredValue: desiredValue})
+		case currentValue != desiredValue:
+			changes = append(changes, &applyChange{Operation: applyOperationUpdate, Name: name, CurrentValue: currentValue, DesiredValue: desiredValue})
+		}
+	}
+	for name, currentValue := range current {
+		if _, exists := desired[name]; !exists {
+			changes = append(changes, &applyChange{Operation: applyOperationDelete, Name: name, CurrentValue: currentValue})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// applyChangeDiff formats a change as a line of a diff, such as "~ maxclients: 1000 -> 2000".
+func applyChangeDiff(change *applyChange) string {
+	switch change.Operation {
+	case applyOperationAdd:
+		return fmt.Sprintf("+ %s: %s", change.Name, change.DesiredValue)
+	case applyOperationDelete:
+		return fmt.Sprintf("- %s: %s", change.Name, change.CurrentValue)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", change.Name, change.CurrentValue, change.DesiredValue)
+	}
+}
+
+// settingApplySpecSettings converts the settings of a file, whose values are strings, numbers or booleans.
+func settingApplySpecSettings(spec *settingApplySpec) ([]*redis.ClusterSetting, error) {
+	settings := make([]*redis.ClusterSetting, 0, len(spec.Settings))
+	names := map[string]bool{}
+	for i, setting := range spec.Settings {
+		if setting == nil || setting.Name == "" {
+			return nil, fmt.Errorf("setting %d must have a name", i+1)
+		}
+		if names[setting.Name] {
+			return nil, fmt.Errorf("setting %s is defined twice", setting.Name)
+		}
+		names[setting.Name] = true
+
+		var value interface{}
+		_ = json.Unmarshal(setting.Value, &value)
+		switch typedValue := value.(type) {
+		case string:
+			settings = append(settings, &redis.ClusterSetting{Name: setting.Name, Value: typedValue})
+		case float64, bool:
+			settings = append(settings, &redis.ClusterSetting{Name: setting.Name, Value: string(setting.Value)})
+		default:
+			return nil, fmt.Errorf("setting %s must have a string, number or boolean value", setting.Name)
+		}
+	}
+	return settings, nil
+}
+
+// ipNetKey identifies an IP range by its network, "10.0.0.1/24" and "10.0.0.0/24" are the same range.
+func ipNetKey(ipNet scw.IPNet) string {
+	network := ipNet.IPNet
+	if network.IP != nil {
+		network.IP = network.IP.Mask(network.Mask)
+	}
+	return network.String()
+}
@@ -0,0 +1,77 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_planACLRules(t *testing.T) {
+	spec := &aclApplySpec{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`acl_rules:
+- ip_cidr: 10.0.0.1/24
+  description: office
+- ip_cidr: 192.168.1.1
+  description: bastion
+`), spec))
+	assert.NoError(t, validateACLApplySpec(spec))
+
+	officeIPs := scw.IPNet{}
+	assert.NoError(t, officeIPs.UnmarshalJSON([]byte(`"10.0.0.0/24"`)))
+	anyIPs := scw.IPNet{}
+	assert.NoError(t, anyIPs.UnmarshalJSON([]byte(`"0.0.0.0/0"`)))
+
+	changes := planACLRules([]*redis.ACLRule{
+		{ID: "1", IPCidr: &officeIPs, Description: scw.StringPtr("old office")},
+		{ID: "2", IPCidr: &anyIPs, Description: scw.StringPtr("Allow everything")},
+	}, spec.ACLRules)
+
+	diff := []string(nil)
+	for _, change := range changes {
+		diff = append(diff, applyChangeDiff(change))
+	}
+	assert.Equal(t, []string{
+		"- 0.0.0.0/0: Allow everything",
+		"~ 10.0.0.0/24: old office -> office",
+		"+ 192.168.1.1/32: bastion",
+	}, diff)
+
+	assert.Error(t, validateACLApplySpec(&aclApplySpec{ACLRules: []*redis.ACLRuleSpec{{IPCidr: officeIPs}, {IPCidr: officeIPs}}}))
+}
+
+func Test_planSettings(t *testing.T) {
+	spec := &settingApplySpec{}
+	assert.NoError(t, yaml.Unmarshal([]byte(`settings:
+- name: maxclients
+  value: 2000
+- name: lazyfree-lazy-eviction
+  value: "yes"
+- name: tcp-keepalive
+  value: 300
+`), spec))
+	settings, err := settingApplySpecSettings(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, []*redis.ClusterSetting{
+		{Name: "maxclients", Value: "2000"},
+		{Name: "lazyfree-lazy-eviction", Value: "yes"},
+		{Name: "tcp-keepalive", Value: "300"},
+	}, settings)
+
+	changes := planSettings([]*redis.ClusterSetting{
+		{Name: "maxclients", Value: "1000"},
+		{Name: "tcp-keepalive", Value: "300"},
+		{Name: "timeout", Value: "60"},
+	}, settings)
+	assert.Equal(t, []*applyChange{
+		{Operation: applyOperationAdd, Name: "lazyfree-lazy-eviction", DesiredValue: "yes"},
+		{Operation: applyOperationUpdate, Name: "maxclients", CurrentValue: "1000", DesiredValue: "2000"},
+		{Operation: applyOperationDelete, Name: "timeout", CurrentValue: "60"},
+	}, changes)
+
+	assert.NoError(t, yaml.Unmarshal([]byte("settings:\n- name: maxclients\n  value: [1]\n"), spec))
+	_, err = settingApplySpecSettings(spec)
+	assert.Error(t, err)
+}
@@ -6,16 +6,40 @@ import (
 	"log"
 	"net/http"
 	"reflect"
+	"strings"
 	"time"
 
+	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-cli/v2/internal/passwordgenerator"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
 	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
 	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const redisActionTimeout = 15 * time.Minute
 
+// createClusterResult is the result of a cluster creation with a generated password.
+type createClusterResult struct {
+	*redis.Cluster
+	Password string `json:"password"`
+}
+
+func createClusterResultMarshalerFunc(i interface{}, opt *human.MarshalOpt) (string, error) {
+	clusterResult := i.(createClusterResult)
+
+	clusterStr, err := human.Marshal(clusterResult.Cluster, opt)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{
+		clusterStr,
+		terminal.Style("Password: ", color.Bold) + "\n" + clusterResult.Password,
+	}, "\n\n"), nil
+}
+
 func clusterCreateBuilder(c *core.Command) *core.Command {
 	type redisEndpointSpecPrivateNetworkSpecCustom struct {
 		*redis.EndpointSpecPrivateNetworkSpec
@@ -28,6 +52,7 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 
 	type redisCreateClusterRequestCustom struct {
 		*redis.CreateClusterRequest
+		passwordgenerator.Args
 		Endpoints []*redisEndpointSpecCustom `json:"endpoints"`
 	}
 
@@ -37,20 +62,37 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 		Required: false,
 		Default:  core.DefaultValueSetter("false"),
 	})
+	for _, argSpec := range passwordgenerator.ArgSpecs() {
+		c.ArgSpecs.AddBefore("password", argSpec)
+	}
+	c.ArgSpecs.GetByName("password").Required = false
 
 	c.ArgsType = reflect.TypeOf(redisCreateClusterRequestCustom{})
 
 	c.WaitFunc = func(ctx context.Context, _, respI interface{}) (interface{}, error) {
+		// The result holds the password when it has been generated
+		clusterResult, withPassword := respI.(createClusterResult)
+		cluster, _ := respI.(*redis.Cluster)
+		if withPassword {
+			cluster = clusterResult.Cluster
+		}
+
 		api := redis.NewAPI(core.ExtractClient(ctx))
 		cluster, err := api.WaitForCluster(&redis.WaitForClusterRequest{
-			ClusterID:     respI.(*redis.Cluster).ID,
-			Zone:          respI.(*redis.Cluster).Zone,
-			Timeout:       scw.TimeDurationPtr(redisActionTimeout),
+			ClusterID:     cluster.ID,
+			Zone:          cluster.Zone,
+			Timeout:       core.WaitTimeout(ctx, redisActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
 			return nil, err
 		}
+		if withPassword {
+			return createClusterResult{
+				Cluster:  cluster,
+				Password: clusterResult.Password,
+			}, nil
+		}
 		return cluster, nil
 	}
 
@@ -61,6 +103,15 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 		customRequest := argsI.(*redisCreateClusterRequestCustom)
 		createClusterRequest := customRequest.CreateClusterRequest
 
+		var err error
+		displayedPassword := ""
+		if customRequest.GeneratePassword && createClusterRequest.Password == "" {
+			createClusterRequest.Password, displayedPassword, err = passwordgenerator.Generate(&customRequest.Args)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		for _, customEndpoint := range customRequest.Endpoints {
 			if customEndpoint.PrivateNetwork == nil {
 				continue
@@ -82,8 +133,18 @@ func clusterCreateBuilder(c *core.Command) *core.Command {
 		if err != nil {
 			return nil, err
 		}
+		if displayedPassword != "" {
+			return createClusterResult{
+				Cluster:  cluster,
+				Password: displayedPassword,
+			}, nil
+		}
 		return cluster, nil
 	}
+	c.Examples = append(c.Examples, &core.Example{
+		Short: "Create a Redis™ Database Instance with a generated password copied to the clipboard",
+		Raw:   "scw redis cluster create name=my-cluster version=7.0.5 node-type=RED1-micro user-name=admin password-charset=url-safe copy=true",
+	})
 
 	return c
 }
@@ -94,7 +155,7 @@ func clusterDeleteBuilder(c *core.Command) *core.Command {
 		cluster, err := api.WaitForCluster(&redis.WaitForClusterRequest{
 			ClusterID:     respI.(*redis.Cluster).ID,
 			Zone:          respI.(*redis.Cluster).Zone,
-			Timeout:       scw.TimeDurationPtr(redisActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, redisActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 		if err != nil {
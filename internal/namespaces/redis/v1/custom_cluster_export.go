@@ -0,0 +1,311 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	object "github.com/scaleway/scaleway-cli/v2/internal/namespaces/object/v1"
+	"github.com/scaleway/scaleway-cli/v2/internal/upload"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const redisDialTimeout = 30 * time.Second
+
+type clusterExportRequest struct {
+	Zone      scw.Zone
+	ClusterID string
+	UserName  string
+	Password  string
+	File      string
+	Bucket    string
+	Key       string
+}
+
+type clusterExportResult struct {
+	ClusterID string   `json:"cluster_id"`
+	File      string   `json:"file,omitempty"`
+	Bucket    string   `json:"bucket,omitempty"`
+	Key       string   `json:"key,omitempty"`
+	Size      scw.Size `json:"size"`
+	// Checksum is the CRC-64 ending the RDB file, empty when the server does not checksum its snapshots
+	Checksum         string `json:"checksum"`
+	ChecksumVerified bool   `json:"checksum_verified"`
+	SHA256           string `json:"sha256"`
+}
+
+func clusterExportCommand() *core.Command {
+	return &core.Command{
+		Short: `Export the data of a cluster to an RDB file`,
+		Long: `Export a snapshot of the data of a Redis™ Database Instance to an RDB file, stored locally or uploaded to a bucket of the region of the cluster.
+The snapshot is retrieved with the replication protocol: the cluster saves it and sends it as it would to a new replica, the command waits until it is received.
+The checksum ending the RDB file is verified, and the SHA-256 of the file is returned to check its copies.
+The cluster must be reachable from a public endpoint, and cluster mode is not supported.`,
+		Namespace: "redis",
+		Resource:  "cluster",
+		Verb:      "export",
+		ArgsType:  reflect.TypeOf(clusterExportRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "cluster-id",
+				Short:      "UUID of the cluster to export",
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "user-name",
+				Short: "Name of the user authenticating to the cluster, default to the user created with the cluster",
+			},
+			{
+				Name:     "password",
+				Short:    "Password of the user",
+				Required: true,
+			},
+			{
+				Name:  "file",
+				Short: "Path of the RDB file, default to <cluster-name>-<date>.rdb when no bucket is given",
+			},
+			{
+				Name:  "bucket",
+				Short: "Bucket the RDB file is uploaded to, it must be in the region of the cluster",
+			},
+			{
+				Name:  "key",
+				Short: "Object key of the uploaded RDB file, default to the name of the file",
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZonePlWaw1, scw.ZonePlWaw2),
+		},
+		Run: clusterExportRun,
+		Examples: []*core.Example{
+			{
+				Short: "Export the data of a cluster to a local file",
+				Raw:   "scw redis cluster export 11111111-1111-1111-1111-111111111111 password=P@sSw0Rd file=./backup.rdb",
+			},
+			{
+				Short: "Export the data of a cluster to a bucket",
+				Raw:   "scw redis cluster export 11111111-1111-1111-1111-111111111111 password=P@sSw0Rd bucket=my-backups key=redis/backup.rdb",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Get the endpoints of a cluster",
+				Command: "scw redis cluster get",
+			},
+		},
+	}
+}
+
+func clusterExportRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*clusterExportRequest)
+	api := redis.NewAPI(core.ExtractClient(ctx))
+
+	cluster, err := api.GetCluster(&redis.GetClusterRequest{
+		Zone:      args.Zone,
+		ClusterID: args.ClusterID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Status != redis.ClusterStatusReady {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("cluster %s is %s", cluster.ID, cluster.Status),
+			Hint: "Wait for the cluster to be ready with: scw redis cluster wait " + cluster.ID,
+		}
+	}
+	// Clusters of 3 nodes or more are sharded, a snapshot would only contain the data of a shard.
+	if cluster.ClusterSize > 2 {
+		return nil, fmt.Errorf("cluster %s runs in cluster mode, its data cannot be exported", cluster.ID)
+	}
+
+	userName := args.UserName
+	if userName == "" {
+		userName = cluster.UserName
+	}
+
+	exportName := fmt.Sprintf("%s-%s.rdb", cluster.Name, time.Now().Format("20060102-150405"))
+	path := args.File
+	removeFile := false
+	switch {
+	case path == "" && args.Bucket != "":
+		tmpFile, err := os.CreateTemp("", "redis-*.rdb")
+		if err != nil {
+			return nil, err
+		}
+		tmpFile.Close()
+		path = tmpFile.Name()
+		removeFile = true
+		defer os.Remove(path)
+	case path == "":
+		path = exportName
+	}
+
+	result, err := exportCluster(ctx, api, cluster, userName, args.Password, path)
+	if err != nil {
+		return nil, err
+	}
+	if !removeFile {
+		result.File = path
+	}
+
+	if args.Bucket != "" {
+		key := args.Key
+		switch {
+		case key == "" && removeFile:
+			key = exportName
+		case key == "":
+			key = filepath.Base(path)
+		}
+		region, err := cluster.Zone.Region()
+		if err != nil {
+			return nil, err
+		}
+		target, err := object.NewUploadTarget(ctx, region, args.Bucket, key)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := upload.File(ctx, path, target, core.UploadConfig(ctx, "upload")); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", path, err)
+		}
+		result.Bucket = args.Bucket
+		result.Key = key
+	}
+
+	return result, nil
+}
+
+// exportCluster retrieves a snapshot of a cluster to a file and verifies it.
+func exportCluster(ctx context.Context, api *redis.API, cluster *redis.Cluster, userName string, password string, path string) (*clusterExportResult, error) {
+	conn, err := dialCluster(ctx, api, cluster)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	verifier := &rdbVerifier{}
+	sha := sha256.New()
+	size, err := syncRDB(ctx, conn, userName, password, io.MultiWriter(file, verifier, sha), func(received int64, total int64) {
+		percent := float64(received) * 100 / float64(total)
+		core.ReportProgress(ctx, &core.ProgressEvent{
+			Step:    "download",
+			State:   core.ProgressStateRunning,
+			Percent: &percent,
+			Message: fmt.Sprintf("%s / %s", humanize.Bytes(uint64(received)), humanize.Bytes(uint64(total))),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export cluster %s: %w", cluster.ID, err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+
+	checksum, verified, err := verifier.verify()
+	if err != nil {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("the export of cluster %s is corrupted: %w", cluster.ID, err),
+			Hint: "Run the export again",
+		}
+	}
+
+	return &clusterExportResult{
+		ClusterID:        cluster.ID,
+		Size:             scw.Size(size),
+		Checksum:         checksum,
+		ChecksumVerified: verified,
+		SHA256:           hex.EncodeToString(sha.Sum(nil)),
+	}, nil
+}
+
+// dialCluster connects to the public endpoint of a cluster, with TLS when it is enabled.
+func dialCluster(ctx context.Context, api *redis.API, cluster *redis.Cluster) (net.Conn, error) {
+	address := ""
+	for _, endpoint := range cluster.Endpoints {
+		if endpoint.PublicNetwork != nil && len(endpoint.IPs) > 0 {
+			address = net.JoinHostPort(endpoint.IPs[0].String(), strconv.Itoa(int(endpoint.Port)))
+			break
+		}
+	}
+	if address == "" {
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("cluster %s has no public endpoint", cluster.ID),
+			Hint: "The data of a cluster can only be exported from a public endpoint, the endpoints of the cluster are listed by: scw redis cluster get " + cluster.ID,
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: redisDialTimeout}
+	if !cluster.TLSEnabled {
+		return dialer.DialContext(ctx, "tcp", address)
+	}
+
+	certificate, err := api.GetClusterCertificate(&redis.GetClusterCertificateRequest{
+		Zone:      cluster.Zone,
+		ClusterID: cluster.ID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(certificate.Content)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("invalid certificate of cluster %s", cluster.ID)
+	}
+
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			// Endpoints are IPs which are not in the certificate, the certificate chain is verified
+			// against the certificate of the cluster instead.
+			InsecureSkipVerify: true, //nolint:gosec
+			VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+				return verifyClusterCertificate(roots, rawCerts)
+			},
+		},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", address)
+}
+
+func verifyClusterCertificate(roots *x509.CertPool, rawCerts [][]byte) error {
+	if len(rawCerts) == 0 {
+		return fmt.Errorf("the cluster sent no certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
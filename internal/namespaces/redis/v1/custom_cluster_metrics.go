@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/human"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const metricsSparklineWidth = 40
+
+// clusterMetricKeywords are the words identifying the time series of each metric, the names of the series depend on the engine version.
+var clusterMetricKeywords = map[string][]string{
+	"cpu":         {"cpu"},
+	"memory":      {"mem"},
+	"connections": {"conn", "client"},
+}
+
+type clusterMetricsRequest struct {
+	Zone      scw.Zone
+	ClusterID string
+	Metric    string
+	Since     time.Duration
+}
+
+// clusterMetricSummary summarizes a time series of a node of a cluster, the points are only displayed in JSON.
+type clusterMetricSummary struct {
+	Name   string                 `json:"name"`
+	Node   string                 `json:"node"`
+	Min    float64                `json:"min"`
+	Avg    float64                `json:"avg"`
+	Max    float64                `json:"max"`
+	Last   float64                `json:"last"`
+	Trend  string                 `json:"-"`
+	Points []*scw.TimeSeriesPoint `json:"points"`
+}
+
+func clusterMetricsBuilder(c *core.Command) *core.Command {
+	c.Long = `Display the CPU, memory and connections metrics of a Redis™ Database Instance over a period, with the minimum, average, maximum and last value of each node and a sparkline of their trend.
+With -o json, the points of the metrics are returned as well.`
+	c.ArgsType = reflect.TypeOf(clusterMetricsRequest{})
+	c.ArgSpecs = core.ArgSpecs{
+		{
+			Name:       "cluster-id",
+			Short:      `UUID of the cluster`,
+			Required:   true,
+			Positional: true,
+		},
+		{
+			Name:       "metric",
+			Short:      `Only display this metric`,
+			EnumValues: []string{"cpu", "memory", "connections"},
+		},
+		{
+			Name:    "since",
+			Short:   `Period of the metrics, until now`,
+			Default: core.DefaultValueSetter("1h"),
+		},
+		core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZonePlWaw1, scw.ZonePlWaw2),
+	}
+	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+		args := argsI.(*clusterMetricsRequest)
+		api := redis.NewAPI(core.ExtractClient(ctx))
+
+		endAt := time.Now()
+		startAt := endAt.Add(-args.Since)
+		metrics, err := api.GetClusterMetrics(&redis.GetClusterMetricsRequest{
+			Zone:      args.Zone,
+			ClusterID: args.ClusterID,
+			StartAt:   &startAt,
+			EndAt:     &endAt,
+		}, scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+
+		summaries := summarizeClusterMetrics(metrics.Timeseries, args.Metric)
+		if len(summaries) == 0 {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("no metrics measured on cluster %s during the last %s", args.ClusterID, args.Since),
+				Hint: "Check that the cluster is ready with: scw redis cluster get " + args.ClusterID,
+			}
+		}
+		return summaries, nil
+	}
+	c.View = &core.View{
+		Fields: []*core.ViewField{
+			{Label: "Name", FieldName: "Name"},
+			{Label: "Node", FieldName: "Node"},
+			{Label: "Min", FieldName: "Min"},
+			{Label: "Avg", FieldName: "Avg"},
+			{Label: "Max", FieldName: "Max"},
+			{Label: "Last", FieldName: "Last"},
+			{Label: "Trend", FieldName: "Trend"},
+		},
+	}
+	c.Examples = []*core.Example{
+		{
+			Short: "Display the CPU usage of a cluster during the last 6 hours",
+			Raw:   "scw redis cluster metrics 11111111-1111-1111-1111-111111111111 metric=cpu since=6h",
+		},
+	}
+	return c
+}
+
+// summarizeClusterMetrics summarizes the time series of a metric, or of all the metrics when metric is empty, sorted by name and node.
+func summarizeClusterMetrics(timeseries []*scw.TimeSeries, metric string) []*clusterMetricSummary {
+	summaries := []*clusterMetricSummary(nil)
+	for _, series := range timeseries {
+		if len(series.Points) == 0 || (metric != "" && !clusterMetricMatches(series.Name, metric)) {
+			continue
+		}
+
+		points := append([]*scw.TimeSeriesPoint(nil), series.Points...)
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].Timestamp.Before(points[j].Timestamp)
+		})
+
+		values := make([]float64, 0, len(points))
+		summary := &clusterMetricSummary{
+			Name:   series.Name,
+			Node:   series.Metadata["node"],
+			Min:    float64(points[0].Value),
+			Max:    float64(points[0].Value),
+			Last:   float64(points[len(points)-1].Value),
+			Points: points,
+		}
+		for _, point := range points {
+			value := float64(point.Value)
+			values = append(values, value)
+			summary.Avg += value / float64(len(points))
+			if value < summary.Min {
+				summary.Min = value
+			}
+			if value > summary.Max {
+				summary.Max = value
+			}
+		}
+		summary.Avg = math.Round(summary.Avg*100) / 100
+		summary.Trend = human.Sparkline(values, metricsSparklineWidth)
+		summaries = append(summaries, summary)
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].Name != summaries[j].Name {
+			return summaries[i].Name < summaries[j].Name
+		}
+		return summaries[i].Node < summaries[j].Node
+	})
+	return summaries
+}
+
+func clusterMetricMatches(seriesName string, metric string) bool {
+	for _, keyword := range clusterMetricKeywords[metric] {
+		if strings.Contains(strings.ToLower(seriesName), keyword) {
+			return true
+		}
+	}
+	return false
+}
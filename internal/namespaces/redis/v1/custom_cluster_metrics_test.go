@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alecthomas/assert"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+func Test_summarizeClusterMetrics(t *testing.T) {
+	now := time.Now()
+	timeseries := []*scw.TimeSeries{
+		{
+			Name:     "mem_usage_percent",
+			Metadata: map[string]string{"node": "node-1"},
+			Points:   []*scw.TimeSeriesPoint{{Timestamp: now, Value: 50}},
+		},
+		{
+			Name:     "cpu_usage_percent",
+			Metadata: map[string]string{"node": "node-1"},
+			Points: []*scw.TimeSeriesPoint{
+				{Timestamp: now, Value: 40},
+				{Timestamp: now.Add(-2 * time.Minute), Value: 10},
+				{Timestamp: now.Add(-time.Minute), Value: 20},
+			},
+		},
+		{
+			Name:     "cpu_usage_percent",
+			Metadata: map[string]string{"node": "node-0"},
+		},
+	}
+
+	summaries := summarizeClusterMetrics(timeseries, "cpu")
+	assert.Equal(t, 1, len(summaries))
+	assert.Equal(t, "node-1", summaries[0].Node)
+	assert.Equal(t, 10.0, summaries[0].Min)
+	assert.Equal(t, 23.33, summaries[0].Avg)
+	assert.Equal(t, 40.0, summaries[0].Max)
+	assert.Equal(t, 40.0, summaries[0].Last)
+	assert.Equal(t, "▁▃█", summaries[0].Trend)
+
+	summaries = summarizeClusterMetrics(timeseries, "")
+	assert.Equal(t, 2, len(summaries))
+	assert.Equal(t, "cpu_usage_percent", summaries[0].Name)
+	assert.Equal(t, "mem_usage_percent", summaries[1].Name)
+}
@@ -214,7 +214,7 @@ func Test_EndpointsEdgeCases(t *testing.T) {
 			"endpoints.0.private-network.service-ips.0=%s",
 			"private-endpoint-both", metaNamePNA, serviceIPsA),
 		Check: core.TestCheckCombine(
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeUsage),
 			func(t *testing.T, ctx *core.CheckFuncCtx) {
 				assert.Contains(t, ctx.Err.Error(), expectedError)
 			},
@@ -239,7 +239,7 @@ func Test_EndpointsEdgeCases(t *testing.T) {
 			"endpoints.0.private-network.id={{ .%s.ID }}",
 			"private-endpoint-both", metaNamePNA),
 		Check: core.TestCheckCombine(
-			core.TestCheckExitCode(1),
+			core.TestCheckExitCode(core.ExitCodeUsage),
 			func(t *testing.T, ctx *core.CheckFuncCtx) {
 				assert.Contains(t, ctx.Err.Error(), expectedError)
 			},
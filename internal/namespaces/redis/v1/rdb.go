@@ -0,0 +1,174 @@
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc64"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// rdbCRC64Table is the table of the CRC-64/Jones checksum ending RDB files.
+var rdbCRC64Table = crc64.MakeTable(0x95ac9329ac4bc9b5)
+
+// rdbCRC64 updates the checksum of an RDB file. Unlike hash/crc64, Redis neither inverts the initial nor the final value.
+func rdbCRC64(crc uint64, p []byte) uint64 {
+	return ^crc64.Update(^crc, rdbCRC64Table, p)
+}
+
+// syncRDB retrieves a snapshot of a Redis server with the replication protocol, as a replica would do on its first sync,
+// and writes it to w. progress is called with the number of bytes received and the size of the snapshot.
+func syncRDB(ctx context.Context, conn net.Conn, user string, password string, w io.Writer, progress func(received int64, total int64)) (int64, error) {
+	// Closing the connection unblocks the reads when the context is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	reader := bufio.NewReader(conn)
+	if password != "" {
+		if err := writeRESPCommand(conn, "AUTH", user, password); err != nil {
+			return 0, err
+		}
+		reply, err := readRESPLine(reader)
+		if err != nil {
+			return 0, err
+		}
+		if reply != "+OK" {
+			return 0, fmt.Errorf("authentication failed: %s", strings.TrimPrefix(reply, "-"))
+		}
+	}
+
+	if err := writeRESPCommand(conn, "SYNC"); err != nil {
+		return 0, err
+	}
+
+	// The server sends newlines to keep the connection alive while it saves the snapshot.
+	reply := ""
+	for reply == "" {
+		line, err := readRESPLine(reader)
+		if err != nil {
+			return 0, ctxErr(ctx, err)
+		}
+		reply = line
+	}
+	if strings.HasPrefix(reply, "-") {
+		return 0, fmt.Errorf("the server refused to send a snapshot: %s", reply[1:])
+	}
+	if !strings.HasPrefix(reply, "$") || strings.HasPrefix(reply, "$EOF:") {
+		return 0, fmt.Errorf("unsupported reply to SYNC: %q", reply)
+	}
+	size, err := strconv.ParseInt(reply[1:], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid snapshot size %q: %w", reply[1:], err)
+	}
+
+	received := int64(0)
+	buffer := make([]byte, 32*1024)
+	for received < size {
+		chunk := buffer
+		if remaining := size - received; remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		n, err := reader.Read(chunk)
+		if n > 0 {
+			if _, err := w.Write(chunk[:n]); err != nil {
+				return received, err
+			}
+			received += int64(n)
+			if progress != nil {
+				progress(received, size)
+			}
+		}
+		if err != nil {
+			return received, fmt.Errorf("snapshot truncated after %d of %d bytes: %w", received, size, ctxErr(ctx, err))
+		}
+	}
+	return received, nil
+}
+
+func writeRESPCommand(w io.Writer, args ...string) error {
+	buffer := &bytes.Buffer{}
+	fmt.Fprintf(buffer, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(buffer, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+func readRESPLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// ctxErr returns the error of the context when it is done, as it is the reason why the connection failed.
+func ctxErr(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// rdbVerifier checks an RDB file written to it: its header and the checksum of its 8 last bytes.
+type rdbVerifier struct {
+	header []byte
+	crc    uint64
+	// tail holds the last 8 bytes written, which are the checksum once the file is complete
+	tail []byte
+}
+
+func (v *rdbVerifier) Write(p []byte) (int, error) {
+	if missing := 9 - len(v.header); missing > 0 {
+		if missing > len(p) {
+			missing = len(p)
+		}
+		v.header = append(v.header, p[:missing]...)
+	}
+
+	data := append(v.tail, p...)
+	if len(data) > 8 {
+		v.crc = rdbCRC64(v.crc, data[:len(data)-8])
+		data = data[len(data)-8:]
+	}
+	v.tail = append([]byte(nil), data...)
+	return len(p), nil
+}
+
+// verify returns the checksum of the file and whether it was verified,
+// files saved with rdbchecksum disabled have no checksum.
+func (v *rdbVerifier) verify() (string, bool, error) {
+	if !bytes.HasPrefix(v.header, []byte("REDIS")) || len(v.header) < 9 {
+		return "", false, fmt.Errorf("the snapshot is not an RDB file")
+	}
+	version, err := strconv.Atoi(string(v.header[5:9]))
+	if err != nil {
+		return "", false, fmt.Errorf("the snapshot is not an RDB file: invalid version %q", v.header[5:9])
+	}
+	// Checksums were introduced with version 5.
+	if version < 5 || len(v.tail) < 8 {
+		return "", false, nil
+	}
+
+	expected := binary.LittleEndian.Uint64(v.tail)
+	if expected == 0 {
+		return "", false, nil
+	}
+	if expected != v.crc {
+		return "", false, fmt.Errorf("checksum mismatch: the snapshot ends with %016x but its content has %016x", expected, v.crc)
+	}
+	return fmt.Sprintf("%016x", expected), true, nil
+}
@@ -0,0 +1,119 @@
+package redis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func Test_rdbCRC64(t *testing.T) {
+	assert.Equal(t, uint64(0xe9c6d914c4b8d9ca), rdbCRC64(0, []byte("123456789")))
+	assert.Equal(t, uint64(0xe9c6d914c4b8d9ca), rdbCRC64(rdbCRC64(0, []byte("1234")), []byte("56789")))
+}
+
+// testRDB returns an RDB file with a valid checksum.
+func testRDB() []byte {
+	content := append([]byte("REDIS0009"), 0xfa, 0x03, 'k', 'e', 'y', 0xff)
+	checksum := make([]byte, 8)
+	binary.LittleEndian.PutUint64(checksum, rdbCRC64(0, content))
+	return append(content, checksum...)
+}
+
+func Test_rdbVerifier(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		verifier := &rdbVerifier{}
+		for _, b := range testRDB() {
+			_, _ = verifier.Write([]byte{b})
+		}
+		checksum, verified, err := verifier.verify()
+		assert.NoError(t, err)
+		assert.True(t, verified)
+		assert.Equal(t, fmt.Sprintf("%016x", rdbCRC64(0, testRDB()[:len(testRDB())-8])), checksum)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		rdb := testRDB()
+		rdb[10] = 'x'
+		verifier := &rdbVerifier{}
+		_, _ = verifier.Write(rdb)
+		_, _, err := verifier.verify()
+		assert.Error(t, err)
+	})
+
+	t.Run("Without checksum", func(t *testing.T) {
+		rdb := testRDB()
+		copy(rdb[len(rdb)-8:], make([]byte, 8))
+		verifier := &rdbVerifier{}
+		_, _ = verifier.Write(rdb)
+		_, verified, err := verifier.verify()
+		assert.NoError(t, err)
+		assert.False(t, verified)
+	})
+
+	t.Run("Not an RDB file", func(t *testing.T) {
+		verifier := &rdbVerifier{}
+		_, _ = verifier.Write([]byte("-ERR not a snapshot"))
+		_, _, err := verifier.verify()
+		assert.Error(t, err)
+	})
+}
+
+func Test_syncRDB(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+
+	commands := make(chan string, 2)
+	go func() {
+		defer server.Close()
+		reader := bufio.NewReader(server)
+		for _, reply := range []string{"+OK\r\n", "\n\n$" + fmt.Sprint(len(testRDB())) + "\r\n" + string(testRDB())} {
+			command, err := readTestRESPCommand(reader)
+			if err != nil {
+				return
+			}
+			commands <- command
+			_, _ = server.Write([]byte(reply))
+		}
+	}()
+
+	output := &bytes.Buffer{}
+	progress := int64(0)
+	size, err := syncRDB(context.Background(), client, "admin", "P@sSw0Rd", output, func(received int64, total int64) {
+		progress = received * 100 / total
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(testRDB())), size)
+	assert.Equal(t, testRDB(), output.Bytes())
+	assert.Equal(t, int64(100), progress)
+	assert.Equal(t, "AUTH admin P@sSw0Rd", <-commands)
+	assert.Equal(t, "SYNC", <-commands)
+}
+
+// readTestRESPCommand reads a command sent to the server and returns its arguments separated by spaces.
+func readTestRESPCommand(reader *bufio.Reader) (string, error) {
+	count := 0
+	if _, err := fmt.Fscanf(reader, "*%d\r\n", &count); err != nil {
+		return "", err
+	}
+	args := []string(nil)
+	for i := 0; i < count; i++ {
+		length := 0
+		if _, err := fmt.Fscanf(reader, "$%d\r\n", &length); err != nil {
+			return "", err
+		}
+		arg := make([]byte, length+2)
+		if _, err := io.ReadFull(reader, arg); err != nil {
+			return "", err
+		}
+		args = append(args, string(arg[:length]))
+	}
+	return strings.Join(args, " "), nil
+}
@@ -22,6 +22,7 @@ func GetCommands() *core.Commands {
 		registryDockerHelperListCommand(),
 		registryDockerHelperStoreCommand(),
 		registryInstallDockerHelperCommand(),
+		registryTagPruneCommand(),
 	))
 
 	cmds.MustFind("registry", "tag", "get").Override(tagGetBuilder)
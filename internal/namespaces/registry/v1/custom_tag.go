@@ -3,11 +3,15 @@ package registry
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 //
@@ -124,3 +128,216 @@ func tagListBuilder(c *core.Command) *core.Command {
 
 	return c
 }
+
+type registryTagPruneArgs struct {
+	Namespace string
+	Image     string
+	Keep      uint32
+	OlderThan time.Duration
+	DryRun    bool
+	Region    scw.Region
+}
+
+func registryTagPruneCommand() *core.Command {
+	return &core.Command{
+		Short: `Delete old tags of an image`,
+		Long: `Delete the tags of an image according to retention rules.
+The most recent tags (by creation date) are always kept, the remaining ones are deleted if they are older than the given duration.
+Use dry-run to list the tags that would be deleted.`,
+		Namespace: "registry",
+		Resource:  "tag",
+		Verb:      "prune",
+		ArgsType:  reflect.TypeOf(registryTagPruneArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:     "namespace",
+				Short:    "Name of the namespace containing the image",
+				Required: true,
+			},
+			{
+				Name:     "image",
+				Short:    "Name of the image to prune",
+				Required: true,
+			},
+			{
+				Name:    "keep",
+				Short:   "Number of most recent tags to keep",
+				Default: core.DefaultValueSetter("10"),
+			},
+			{
+				Name:  "older-than",
+				Short: "Only delete tags created before this duration (e.g. 12h, 30d)",
+			},
+			{
+				Name:  "dry-run",
+				Short: "List the tags that would be deleted without deleting them",
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: registryTagPruneRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{
+					Label:     "ID",
+					FieldName: "ID",
+				},
+				{
+					Label:     "Full Name",
+					FieldName: "FullName",
+				},
+				{
+					Label:     "Status",
+					FieldName: "Status",
+				},
+				{
+					Label:     "Created At",
+					FieldName: "CreatedAt",
+				},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the tags that would be deleted, keeping the 10 most recent ones",
+				Raw:   "scw registry tag prune namespace=my-namespace image=my-image dry-run=true",
+			},
+			{
+				Short: "Delete tags older than 30 days, keeping at least the 5 most recent ones",
+				Raw:   "scw registry tag prune namespace=my-namespace image=my-image keep=5 older-than=30d",
+			},
+		},
+	}
+}
+
+func registryTagPruneRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*registryTagPruneArgs)
+
+	client := core.ExtractClient(ctx)
+	api := registry.NewAPI(client)
+
+	namespaces, err := api.ListNamespaces(&registry.ListNamespacesRequest{
+		Region: args.Region,
+		Name:   &args.Namespace,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	namespace, err := findNamespaceByName(namespaces.Namespaces, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := api.ListImages(&registry.ListImagesRequest{
+		Region:      args.Region,
+		NamespaceID: &namespace.ID,
+		Name:        &args.Image,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	image, err := findImageByName(images.Images, args.Image, args.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := api.ListTags(&registry.ListTagsRequest{
+		Region:  args.Region,
+		ImageID: image.ID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	res := []customTag(nil)
+	for _, tag := range selectTagsToPrune(tags.Tags, int(args.Keep), args.OlderThan, time.Now()) {
+		if !args.DryRun {
+			tag, err = api.DeleteTag(&registry.DeleteTagRequest{
+				Region: args.Region,
+				TagID:  tag.ID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, customTag{
+			Tag:      *tag,
+			FullName: fmt.Sprintf("%s/%s:%s", namespace.Endpoint, image.Name, tag.Name),
+		})
+	}
+
+	return res, nil
+}
+
+// findNamespaceByName returns the only namespace named name, as the name filter of the API also matches partial names.
+func findNamespaceByName(namespaces []*registry.Namespace, name string) (*registry.Namespace, error) {
+	found := []*registry.Namespace(nil)
+	for _, namespace := range namespaces {
+		if namespace.Name == name {
+			found = append(found, namespace)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no namespace found with name %q", name),
+			Code: core.ExitCodeNotFound,
+		}
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%d namespaces found with name %q", len(found), name)
+	}
+}
+
+// findImageByName returns the only image named name in a namespace, as the name filter of the API also matches partial names.
+func findImageByName(images []*registry.Image, name string, namespaceName string) (*registry.Image, error) {
+	found := []*registry.Image(nil)
+	for _, image := range images {
+		if image.Name == name {
+			found = append(found, image)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, &core.CliError{
+			Err:  fmt.Errorf("no image found with name %q in namespace %q", name, namespaceName),
+			Code: core.ExitCodeNotFound,
+		}
+	case 1:
+		return found[0], nil
+	default:
+		return nil, fmt.Errorf("%d images found with name %q in namespace %q", len(found), name, namespaceName)
+	}
+}
+
+// selectTagsToPrune returns the tags that do not match the retention rules.
+// The keep most recent tags are always retained, other tags are selected if they were created before now - olderThan.
+func selectTagsToPrune(tags []*registry.Tag, keep int, olderThan time.Duration, now time.Time) []*registry.Tag {
+	sorted := make([]*registry.Tag, len(tags))
+	copy(sorted, tags)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return tagCreationDate(sorted[i]).After(tagCreationDate(sorted[j]))
+	})
+
+	if keep >= len(sorted) {
+		return nil
+	}
+
+	limit := now.Add(-olderThan)
+	toPrune := []*registry.Tag(nil)
+	for _, tag := range sorted[keep:] {
+		if olderThan == 0 || tagCreationDate(tag).Before(limit) {
+			toPrune = append(toPrune, tag)
+		}
+	}
+
+	return toPrune
+}
+
+func tagCreationDate(tag *registry.Tag) time.Time {
+	if tag.CreatedAt == nil {
+		return time.Time{}
+	}
+	return *tag.CreatedAt
+}
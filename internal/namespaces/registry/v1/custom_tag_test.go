@@ -0,0 +1,82 @@
+package registry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_selectTagsToPrune(t *testing.T) {
+	now := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	tags := []*registry.Tag{
+		{Name: "v1", CreatedAt: scw.TimePtr(now.Add(-90 * 24 * time.Hour))},
+		{Name: "v4", CreatedAt: scw.TimePtr(now.Add(-1 * time.Hour))},
+		{Name: "v2", CreatedAt: scw.TimePtr(now.Add(-60 * 24 * time.Hour))},
+		{Name: "v3", CreatedAt: scw.TimePtr(now.Add(-10 * 24 * time.Hour))},
+	}
+
+	tagNames := func(tags []*registry.Tag) []string {
+		names := []string(nil)
+		for _, tag := range tags {
+			names = append(names, tag.Name)
+		}
+		return names
+	}
+
+	t.Run("Keep only", func(t *testing.T) {
+		assert.Equal(t, []string{"v2", "v1"}, tagNames(selectTagsToPrune(tags, 2, 0, now)))
+	})
+
+	t.Run("Keep more than available", func(t *testing.T) {
+		assert.Empty(t, selectTagsToPrune(tags, 10, 0, now))
+	})
+
+	t.Run("Keep and older than", func(t *testing.T) {
+		assert.Equal(t, []string{"v1"}, tagNames(selectTagsToPrune(tags, 1, 70*24*time.Hour, now)))
+	})
+
+	t.Run("Older than only", func(t *testing.T) {
+		assert.Equal(t, []string{"v2", "v1"}, tagNames(selectTagsToPrune(tags, 0, 30*24*time.Hour, now)))
+	})
+}
+
+func Test_findImageByName(t *testing.T) {
+	images := []*registry.Image{
+		{ID: "1", Name: "app-old"},
+		{ID: "2", Name: "app"},
+	}
+
+	t.Run("Exact match", func(t *testing.T) {
+		image, err := findImageByName(images, "app", "ns")
+		require.NoError(t, err)
+		assert.Equal(t, "2", image.ID)
+	})
+
+	t.Run("Partial match only", func(t *testing.T) {
+		_, err := findImageByName(images[:1], "app", "ns")
+		assert.Error(t, err)
+	})
+
+	t.Run("Several matches", func(t *testing.T) {
+		_, err := findImageByName(append(images, &registry.Image{ID: "3", Name: "app"}), "app", "ns")
+		assert.Error(t, err)
+	})
+}
+
+func Test_findNamespaceByName(t *testing.T) {
+	namespaces := []*registry.Namespace{
+		{ID: "1", Name: "prod-old"},
+		{ID: "2", Name: "prod"},
+	}
+
+	namespace, err := findNamespaceByName(namespaces, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "2", namespace.ID)
+
+	_, err = findNamespaceByName(namespaces[:1], "prod")
+	assert.Error(t, err)
+}
@@ -0,0 +1,24 @@
+package resource
+
+import (
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		resourceRoot(),
+		resourceGetCommand(),
+	)
+}
+
+func resourceRoot() *core.Command {
+	return &core.Command{
+		Groups:    []string{"utility"},
+		Short:     `Resource lookup commands`,
+		Namespace: "resource",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
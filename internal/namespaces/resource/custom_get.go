@@ -0,0 +1,192 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/scaleway/scaleway-sdk-go/validation"
+)
+
+// maxConcurrentProbes is the number of lookups run at the same time.
+const maxConcurrentProbes = 16
+
+type resourceGetRequest struct {
+	ID string
+}
+
+type resourceGetResult struct {
+	Product  string      `json:"product"`
+	Resource string      `json:"resource"`
+	Locality string      `json:"locality,omitempty"`
+	Command  string      `json:"command"`
+	Details  interface{} `json:"details"`
+}
+
+func resourceGetCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Find a resource from its ID`,
+		Long: `Find the product and the type of a resource from its ID only, and print its details.
+The resource is looked up in parallel in the APIs of the products and in all their zones or regions, resources the current credentials cannot read are not found.
+The command to get the resource with its own product is printed, to act on it afterwards.`,
+		Namespace: "resource",
+		Resource:  "get",
+		ArgsType:  reflect.TypeOf(resourceGetRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "id",
+				Short:      `ID of the resource`,
+				Required:   true,
+				Positional: true,
+			},
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*resourceGetRequest)
+			return resourceGet(ctx, core.ExtractClient(ctx), resourceProbes(), args.ID)
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Product", FieldName: "Product"},
+				{Label: "Resource", FieldName: "Resource"},
+				{Label: "Locality", FieldName: "Locality"},
+				{Label: "Command", FieldName: "Command"},
+			},
+			Sections: []*core.ViewSection{
+				{FieldName: "Details", Title: "Details"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Find the resource with a given ID",
+				Raw:   "scw resource get 11111111-1111-1111-1111-111111111111",
+			},
+		},
+	}
+}
+
+// resourceGet runs the probes in all their localities and returns the resources found.
+// A single resource is returned as is, several resources as a list.
+func resourceGet(ctx context.Context, client *scw.Client, probes []*resourceProbe, id string) (interface{}, error) {
+	if !validation.IsUUID(id) {
+		return nil, fmt.Errorf("%s is not a valid ID, resources are identified by UUIDs", id)
+	}
+
+	type lookup struct {
+		probe    *resourceProbe
+		locality string
+	}
+	lookups := []*lookup(nil)
+	for _, probe := range probes {
+		switch {
+		case len(probe.Zones) > 0:
+			for _, zone := range probe.Zones {
+				lookups = append(lookups, &lookup{probe: probe, locality: zone.String()})
+			}
+		case len(probe.Regions) > 0:
+			for _, region := range probe.Regions {
+				lookups = append(lookups, &lookup{probe: probe, locality: region.String()})
+			}
+		default:
+			lookups = append(lookups, &lookup{probe: probe})
+		}
+	}
+
+	mu := sync.Mutex{}
+	results := []*resourceGetResult(nil)
+	failures := []string(nil)
+	semaphore := make(chan struct{}, maxConcurrentProbes)
+	wg := sync.WaitGroup{}
+	for _, l := range lookups {
+		wg.Add(1)
+		go func(l *lookup) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			details, err := l.probe.Get(ctx, client, l.locality, id)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				results = append(results, newResourceGetResult(l.probe, l.locality, id, details))
+			case !isLookupMiss(err):
+				failures = append(failures, fmt.Sprintf("%s %s: %s", resourceLabel(l.probe), l.locality, err))
+			}
+		}(l)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Command < results[j].Command
+	})
+	sort.Strings(failures)
+
+	switch len(results) {
+	case 0:
+		err := &core.CliError{
+			Err:  fmt.Errorf("no resource found with ID %s", id),
+			Hint: "The resource may have been deleted, or belong to an organization or a product the current credentials cannot read",
+		}
+		if len(failures) > 0 {
+			err.Details = "Some lookups failed:\n" + strings.Join(failures, "\n")
+		}
+		return nil, err
+	case 1:
+		return results[0], nil
+	default:
+		return results, nil
+	}
+}
+
+func newResourceGetResult(probe *resourceProbe, locality string, id string, details interface{}) *resourceGetResult {
+	command := fmt.Sprintf("scw %s %s get %s", probe.Namespace, probe.Resource, id)
+	switch {
+	case len(probe.Zones) > 0:
+		command += " zone=" + locality
+	case len(probe.Regions) > 0:
+		command += " region=" + locality
+	}
+
+	return &resourceGetResult{
+		Product:  probe.Namespace,
+		Resource: probe.Resource,
+		Locality: locality,
+		Command:  command,
+		Details:  details,
+	}
+}
+
+func resourceLabel(probe *resourceProbe) string {
+	return probe.Namespace + " " + probe.Resource
+}
+
+// isLookupMiss returns true when an error means the resource is not the one looked up:
+// it does not exist, it cannot be read or the ID is not valid for this API.
+func isLookupMiss(err error) bool {
+	if errors.As(err, new(*scw.ResourceNotFoundError)) ||
+		errors.As(err, new(*scw.PermissionsDeniedError)) ||
+		errors.As(err, new(*scw.InvalidArgumentsError)) {
+		return true
+	}
+	responseError := &scw.ResponseError{}
+	if errors.As(err, &responseError) {
+		switch responseError.StatusCode {
+		case http.StatusNotFound, http.StatusForbidden, http.StatusBadRequest:
+			return true
+		}
+	}
+	return false
+}
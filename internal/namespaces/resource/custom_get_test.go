@@ -0,0 +1,83 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testResourceID = "11111111-1111-1111-1111-111111111111"
+
+func testProbe(namespace string, resource string, found scw.Zone, err error) *resourceProbe {
+	return zonedProbe(namespace, resource, []scw.Zone{scw.ZoneFrPar1, scw.ZoneNlAms1}, func(_ context.Context, _ *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+		if zone == found {
+			return map[string]string{"id": id}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return nil, &scw.ResourceNotFoundError{Resource: resource, ResourceID: id}
+	})
+}
+
+func Test_resourceGet(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Single match", func(t *testing.T) {
+		result, err := resourceGet(ctx, nil, []*resourceProbe{
+			testProbe("instance", "server", "", nil),
+			testProbe("baremetal", "server", scw.ZoneNlAms1, &scw.PermissionsDeniedError{}),
+		}, testResourceID)
+		require.NoError(t, err)
+		assert.Equal(t, &resourceGetResult{
+			Product:  "baremetal",
+			Resource: "server",
+			Locality: "nl-ams-1",
+			Command:  "scw baremetal server get " + testResourceID + " zone=nl-ams-1",
+			Details:  map[string]string{"id": testResourceID},
+		}, result)
+	})
+
+	t.Run("Global resource", func(t *testing.T) {
+		result, err := resourceGet(ctx, nil, []*resourceProbe{
+			globalProbe("iam", "user", func(_ context.Context, _ *scw.Client, id string) (interface{}, error) {
+				return id, nil
+			}),
+		}, testResourceID)
+		require.NoError(t, err)
+		assert.Equal(t, "scw iam user get "+testResourceID, result.(*resourceGetResult).Command)
+	})
+
+	t.Run("Several matches", func(t *testing.T) {
+		result, err := resourceGet(ctx, nil, []*resourceProbe{
+			testProbe("instance", "volume", scw.ZoneFrPar1, nil),
+			testProbe("block", "volume", scw.ZoneFrPar1, nil),
+		}, testResourceID)
+		require.NoError(t, err)
+		results := result.([]*resourceGetResult)
+		require.Len(t, results, 2)
+		assert.Equal(t, "block", results[0].Product)
+		assert.Equal(t, "instance", results[1].Product)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := resourceGet(ctx, nil, []*resourceProbe{
+			testProbe("instance", "server", "", nil),
+			testProbe("redis", "cluster", "", errors.New("service unavailable")),
+		}, testResourceID)
+		cliErr := &core.CliError{}
+		require.ErrorAs(t, err, &cliErr)
+		assert.Equal(t, "no resource found with ID "+testResourceID, cliErr.Err.Error())
+		assert.Equal(t, "Some lookups failed:\nredis cluster fr-par-1: service unavailable\nredis cluster nl-ams-1: service unavailable", cliErr.Details)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		_, err := resourceGet(ctx, nil, nil, "my-server")
+		assert.EqualError(t, err, "my-server is not a valid ID, resources are identified by UUIDs")
+	})
+}
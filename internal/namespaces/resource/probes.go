@@ -0,0 +1,244 @@
+package resource
+
+import (
+	"context"
+
+	account "github.com/scaleway/scaleway-sdk-go/api/account/v3"
+	applesilicon "github.com/scaleway/scaleway-sdk-go/api/applesilicon/v1alpha1"
+	baremetal "github.com/scaleway/scaleway-sdk-go/api/baremetal/v1"
+	block "github.com/scaleway/scaleway-sdk-go/api/block/v1alpha1"
+	container "github.com/scaleway/scaleway-sdk-go/api/container/v1beta1"
+	documentdb "github.com/scaleway/scaleway-sdk-go/api/documentdb/v1beta1"
+	flexibleip "github.com/scaleway/scaleway-sdk-go/api/flexibleip/v1alpha1"
+	function "github.com/scaleway/scaleway-sdk-go/api/function/v1beta1"
+	iam "github.com/scaleway/scaleway-sdk-go/api/iam/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/api/instance/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/iot/v1"
+	ipam "github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	jobs "github.com/scaleway/scaleway-sdk-go/api/jobs/v1alpha1"
+	k8s "github.com/scaleway/scaleway-sdk-go/api/k8s/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/lb/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/rdb/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/redis/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/registry/v1"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	serverless_sqldb "github.com/scaleway/scaleway-sdk-go/api/serverless_sqldb/v1alpha1"
+	tem "github.com/scaleway/scaleway-sdk-go/api/tem/v1alpha1"
+	vpc "github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+// resourceProbe gets a resource of a product from its ID, in each of its localities.
+type resourceProbe struct {
+	// Namespace and Resource are the ones of the scw command getting the resource
+	Namespace string
+	Resource  string
+	// Zones or Regions are the localities the resource is looked up in, both are empty for global resources
+	Zones   []scw.Zone
+	Regions []scw.Region
+	// Get gets the resource in a locality, which is empty for global resources
+	Get func(ctx context.Context, client *scw.Client, locality string, id string) (interface{}, error)
+}
+
+func zonedProbe(namespace string, resource string, zones []scw.Zone, get func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error)) *resourceProbe {
+	return &resourceProbe{
+		Namespace: namespace,
+		Resource:  resource,
+		Zones:     zones,
+		Get: func(ctx context.Context, client *scw.Client, locality string, id string) (interface{}, error) {
+			return get(ctx, client, scw.Zone(locality), id)
+		},
+	}
+}
+
+func regionalProbe(namespace string, resource string, regions []scw.Region, get func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error)) *resourceProbe {
+	return &resourceProbe{
+		Namespace: namespace,
+		Resource:  resource,
+		Regions:   regions,
+		Get: func(ctx context.Context, client *scw.Client, locality string, id string) (interface{}, error) {
+			return get(ctx, client, scw.Region(locality), id)
+		},
+	}
+}
+
+func globalProbe(namespace string, resource string, get func(ctx context.Context, client *scw.Client, id string) (interface{}, error)) *resourceProbe {
+	return &resourceProbe{
+		Namespace: namespace,
+		Resource:  resource,
+		Get: func(ctx context.Context, client *scw.Client, _ string, id string) (interface{}, error) {
+			return get(ctx, client, id)
+		},
+	}
+}
+
+// resourceProbes returns the probes of the resources that can be looked up by ID.
+func resourceProbes() []*resourceProbe {
+	instanceZones := (*instance.API)(nil).Zones()
+	k8sRegions := (*k8s.API)(nil).Regions()
+	rdbRegions := (*rdb.API)(nil).Regions()
+
+	return []*resourceProbe{
+		zonedProbe("instance", "server", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetServer(&instance.GetServerRequest{Zone: zone, ServerID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Server, nil
+		}),
+		zonedProbe("instance", "volume", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetVolume(&instance.GetVolumeRequest{Zone: zone, VolumeID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Volume, nil
+		}),
+		zonedProbe("instance", "snapshot", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetSnapshot(&instance.GetSnapshotRequest{Zone: zone, SnapshotID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Snapshot, nil
+		}),
+		zonedProbe("instance", "image", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetImage(&instance.GetImageRequest{Zone: zone, ImageID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Image, nil
+		}),
+		zonedProbe("instance", "ip", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetIP(&instance.GetIPRequest{Zone: zone, IP: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.IP, nil
+		}),
+		zonedProbe("instance", "security-group", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetSecurityGroup(&instance.GetSecurityGroupRequest{Zone: zone, SecurityGroupID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.SecurityGroup, nil
+		}),
+		zonedProbe("instance", "placement-group", instanceZones, func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			resp, err := instance.NewAPI(client).GetPlacementGroup(&instance.GetPlacementGroupRequest{Zone: zone, PlacementGroupID: id}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+			return resp.PlacementGroup, nil
+		}),
+		zonedProbe("block", "volume", (*block.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return block.NewAPI(client).GetVolume(&block.GetVolumeRequest{Zone: zone, VolumeID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("block", "snapshot", (*block.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return block.NewAPI(client).GetSnapshot(&block.GetSnapshotRequest{Zone: zone, SnapshotID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("baremetal", "server", (*baremetal.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return baremetal.NewAPI(client).GetServer(&baremetal.GetServerRequest{Zone: zone, ServerID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("apple-silicon", "server", (*applesilicon.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return applesilicon.NewAPI(client).GetServer(&applesilicon.GetServerRequest{Zone: zone, ServerID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("lb", "lb", (*lb.ZonedAPI)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return lb.NewZonedAPI(client).GetLB(&lb.ZonedAPIGetLBRequest{Zone: zone, LBID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("vpc-gw", "gateway", (*vpcgw.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return vpcgw.NewAPI(client).GetGateway(&vpcgw.GetGatewayRequest{Zone: zone, GatewayID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("fip", "ip", (*flexibleip.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return flexibleip.NewAPI(client).GetFlexibleIP(&flexibleip.GetFlexibleIPRequest{Zone: zone, FipID: id}, scw.WithContext(ctx))
+		}),
+		zonedProbe("redis", "cluster", (*redis.API)(nil).Zones(), func(ctx context.Context, client *scw.Client, zone scw.Zone, id string) (interface{}, error) {
+			return redis.NewAPI(client).GetCluster(&redis.GetClusterRequest{Zone: zone, ClusterID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("k8s", "cluster", k8sRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return k8s.NewAPI(client).GetCluster(&k8s.GetClusterRequest{Region: region, ClusterID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("k8s", "pool", k8sRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return k8s.NewAPI(client).GetPool(&k8s.GetPoolRequest{Region: region, PoolID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("k8s", "node", k8sRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return k8s.NewAPI(client).GetNode(&k8s.GetNodeRequest{Region: region, NodeID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("rdb", "instance", rdbRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return rdb.NewAPI(client).GetInstance(&rdb.GetInstanceRequest{Region: region, InstanceID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("rdb", "backup", rdbRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return rdb.NewAPI(client).GetDatabaseBackup(&rdb.GetDatabaseBackupRequest{Region: region, DatabaseBackupID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("rdb", "snapshot", rdbRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return rdb.NewAPI(client).GetSnapshot(&rdb.GetSnapshotRequest{Region: region, SnapshotID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("rdb", "read-replica", rdbRegions, func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return rdb.NewAPI(client).GetReadReplica(&rdb.GetReadReplicaRequest{Region: region, ReadReplicaID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("document-db", "instance", (*documentdb.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return documentdb.NewAPI(client).GetInstance(&documentdb.GetInstanceRequest{Region: region, InstanceID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("sdb-sql", "database", (*serverless_sqldb.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return serverless_sqldb.NewAPI(client).GetDatabase(&serverless_sqldb.GetDatabaseRequest{Region: region, DatabaseID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("vpc", "vpc", (*vpc.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return vpc.NewAPI(client).GetVPC(&vpc.GetVPCRequest{Region: region, VpcID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("vpc", "private-network", (*vpc.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return vpc.NewAPI(client).GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{Region: region, PrivateNetworkID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("ipam", "ip", (*ipam.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return ipam.NewAPI(client).GetIP(&ipam.GetIPRequest{Region: region, IPID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("registry", "namespace", (*registry.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return registry.NewAPI(client).GetNamespace(&registry.GetNamespaceRequest{Region: region, NamespaceID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("registry", "image", (*registry.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return registry.NewAPI(client).GetImage(&registry.GetImageRequest{Region: region, ImageID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("registry", "tag", (*registry.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return registry.NewAPI(client).GetTag(&registry.GetTagRequest{Region: region, TagID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("function", "namespace", (*function.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return function.NewAPI(client).GetNamespace(&function.GetNamespaceRequest{Region: region, NamespaceID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("function", "function", (*function.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return function.NewAPI(client).GetFunction(&function.GetFunctionRequest{Region: region, FunctionID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("container", "namespace", (*container.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return container.NewAPI(client).GetNamespace(&container.GetNamespaceRequest{Region: region, NamespaceID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("container", "container", (*container.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return container.NewAPI(client).GetContainer(&container.GetContainerRequest{Region: region, ContainerID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("jobs", "definition", (*jobs.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return jobs.NewAPI(client).GetJobDefinition(&jobs.GetJobDefinitionRequest{Region: region, JobDefinitionID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("secret", "secret", (*secret.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return secret.NewAPI(client).GetSecret(&secret.GetSecretRequest{Region: region, SecretID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("tem", "domain", (*tem.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return tem.NewAPI(client).GetDomain(&tem.GetDomainRequest{Region: region, DomainID: id}, scw.WithContext(ctx))
+		}),
+		regionalProbe("iot", "hub", (*iot.API)(nil).Regions(), func(ctx context.Context, client *scw.Client, region scw.Region, id string) (interface{}, error) {
+			return iot.NewAPI(client).GetHub(&iot.GetHubRequest{Region: region, HubID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("account", "project", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return account.NewProjectAPI(client).GetProject(&account.ProjectAPIGetProjectRequest{ProjectID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("iam", "user", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return iam.NewAPI(client).GetUser(&iam.GetUserRequest{UserID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("iam", "application", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return iam.NewAPI(client).GetApplication(&iam.GetApplicationRequest{ApplicationID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("iam", "group", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return iam.NewAPI(client).GetGroup(&iam.GetGroupRequest{GroupID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("iam", "policy", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return iam.NewAPI(client).GetPolicy(&iam.GetPolicyRequest{PolicyID: id}, scw.WithContext(ctx))
+		}),
+		globalProbe("iam", "ssh-key", func(ctx context.Context, client *scw.Client, id string) (interface{}, error) {
+			return iam.NewAPI(client).GetSSHKey(&iam.GetSSHKeyRequest{SSHKeyID: id}, scw.WithContext(ctx))
+		}),
+	}
+}
@@ -8,15 +8,11 @@ func GetCommands() *core.Commands {
 	cmds := GetGeneratedCommands()
 
 	cmds.MustFind("secret", "version", "create").Override(dataCreateVersion)
-	return cmds
-}
+	cmds.MustFind("secret", "version", "access").Override(secretVersionAccessBuilder)
 
-func dataCreateVersion(c *core.Command) *core.Command {
-	*c.ArgSpecs.GetByName("data") = core.ArgSpec{
-		Name:        "data",
-		Short:       "Content of the secret version. Base64 is handled by the SDK",
-		Required:    true,
-		CanLoadFile: true,
-	}
-	return c
+	cmds.Merge(core.NewCommands(
+		secretEnvCommand(),
+	))
+
+	return cmds
 }
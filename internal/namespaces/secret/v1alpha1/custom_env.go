@@ -0,0 +1,149 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+var envVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type secretEnvArgs struct {
+	SecretID string
+	Revision string
+	Region   scw.Region
+}
+
+func secretEnvCommand() *core.Command {
+	return &core.Command{
+		Short: `Export the key/value payload of a secret as shell variables`,
+		Long: `Print the key/value payload of a secret version as shell export lines.
+The payload can either be a JSON object or a dotenv file with KEY=VALUE lines.`,
+		Namespace: "secret",
+		Resource:  "env",
+		ArgsType:  reflect.TypeOf(secretEnvArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "secret-id",
+				Short:      `ID of the secret`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "revision",
+				Short:   `Version number, latest or latest_enabled`,
+				Default: core.DefaultValueSetter("latest_enabled"),
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*secretEnvArgs)
+
+			api := secret.NewAPI(core.ExtractClient(ctx))
+			resp, err := api.AccessSecretVersion(&secret.AccessSecretVersionRequest{
+				Region:   args.Region,
+				SecretID: args.SecretID,
+				Revision: args.Revision,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			variables, err := parseSecretEnv(resp.Data)
+			if err != nil {
+				return nil, err
+			}
+
+			return core.RawResult(formatShellExports(variables)), nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Load the variables of a secret in the current shell",
+				Raw:   `eval "$(scw secret env 11111111-1111-1111-1111-111111111111)"`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create a version from a dotenv file",
+				Command: "scw secret version create data=@prod.env",
+			},
+		},
+	}
+}
+
+// parseSecretEnv parses a JSON object or dotenv payload into variables.
+func parseSecretEnv(data []byte) (map[string]string, error) {
+	variables := map[string]string{}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		object := map[string]interface{}{}
+		err := json.Unmarshal(trimmed, &object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid json payload: %w", err)
+		}
+
+		for key, value := range object {
+			if str, isString := value.(string); isString {
+				variables[key] = str
+				continue
+			}
+			raw, err := json.Marshal(value)
+			if err != nil {
+				return nil, err
+			}
+			variables[key] = string(raw)
+		}
+	} else {
+		for i, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			line = strings.TrimPrefix(line, "export ")
+
+			key, value, found := strings.Cut(line, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid line %d: expected KEY=VALUE", i+1)
+			}
+			key = strings.TrimSpace(key)
+			value = strings.TrimSpace(value)
+			if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+				value = value[1 : len(value)-1]
+			}
+			variables[key] = value
+		}
+	}
+
+	for key := range variables {
+		if !envVariableNameRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable name %q", key)
+		}
+	}
+
+	return variables, nil
+}
+
+// formatShellExports returns export lines sorted by variable name, values are single quoted.
+func formatShellExports(variables map[string]string) string {
+	keys := make([]string, 0, len(variables))
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	buf := strings.Builder{}
+	for _, key := range keys {
+		buf.WriteString(fmt.Sprintf("export %s='%s'\n", key, strings.ReplaceAll(variables[key], "'", `'\''`)))
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,34 @@
+package secret
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSecretEnv(t *testing.T) {
+	t.Run("Dotenv", func(t *testing.T) {
+		variables, err := parseSecretEnv([]byte("# comment\nexport FOO=bar\nQUOTED=\"hello world\"\n\nEMPTY=\n"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"FOO": "bar", "QUOTED": "hello world", "EMPTY": ""}, variables)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		variables, err := parseSecretEnv([]byte(`{"FOO": "bar", "PORT": 8080}`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"FOO": "bar", "PORT": "8080"}, variables)
+	})
+
+	t.Run("Invalid name", func(t *testing.T) {
+		_, err := parseSecretEnv([]byte("MY-VAR=1"))
+		assert.EqualError(t, err, `invalid variable name "MY-VAR"`)
+	})
+}
+
+func Test_formatShellExports(t *testing.T) {
+	assert.Equal(t,
+		"export A='1'\nexport B='it'\\''s'\n",
+		formatShellExports(map[string]string{"B": "it's", "A": "1"}),
+	)
+}
@@ -0,0 +1,123 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	secret "github.com/scaleway/scaleway-sdk-go/api/secret/v1alpha1"
+)
+
+func dataCreateVersion(c *core.Command) *core.Command {
+	*c.ArgSpecs.GetByName("data") = core.ArgSpec{
+		Name:        "data",
+		Short:       "Content of the secret version. Base64 is handled by the SDK. Use - to read it from stdin",
+		Required:    true,
+		CanLoadFile: true,
+	}
+
+	c.Examples = append(c.Examples,
+		&core.Example{
+			Short: "Create a version from a file",
+			Raw:   "scw secret version create secret-id=11111111-1111-1111-1111-111111111111 data=@prod.env",
+		},
+		&core.Example{
+			Short: "Create a version from stdin",
+			Raw:   "cat prod.env | scw secret version create secret-id=11111111-1111-1111-1111-111111111111 data=-",
+		},
+	)
+
+	c.Interceptor = func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+		args := argsI.(*secret.CreateSecretVersionRequest)
+
+		if string(args.Data) == "-" {
+			data, err := io.ReadAll(core.ExtractStdin(ctx))
+			if err != nil {
+				return nil, fmt.Errorf("could not read data from stdin: %w", err)
+			}
+			args.Data = data
+		}
+
+		return runner(ctx, args)
+	}
+
+	return c
+}
+
+func secretVersionAccessBuilder(c *core.Command) *core.Command {
+	type customAccessSecretVersionRequest struct {
+		*secret.AccessSecretVersionRequest
+		Raw        bool
+		OutputFile string
+	}
+
+	c.ArgSpecs.AddBefore("region", &core.ArgSpec{
+		Name:  "raw",
+		Short: "Print only the data of the version",
+	})
+	c.ArgSpecs.AddBefore("region", &core.ArgSpec{
+		Name:  "output-file",
+		Short: "Write the data of the version to this file, only readable by the current user",
+	})
+	c.ArgsType = reflect.TypeOf(customAccessSecretVersionRequest{})
+
+	c.Examples = append(c.Examples,
+		&core.Example{
+			Short: "Print the data of the latest version",
+			Raw:   "scw secret version access secret-id=11111111-1111-1111-1111-111111111111 revision=latest raw=true",
+		},
+		&core.Example{
+			Short: "Write the data of the latest enabled version to a file",
+			Raw:   "scw secret version access secret-id=11111111-1111-1111-1111-111111111111 revision=latest_enabled output-file=prod.env",
+		},
+	)
+
+	c.Run = func(ctx context.Context, argsI interface{}) (interface{}, error) {
+		args := argsI.(*customAccessSecretVersionRequest)
+
+		api := secret.NewAPI(core.ExtractClient(ctx))
+		resp, err := api.AccessSecretVersion(args.AccessSecretVersionRequest)
+		if err != nil {
+			return nil, err
+		}
+
+		if args.OutputFile != "" {
+			err = writeSecretFile(args.OutputFile, resp.Data)
+			if err != nil {
+				return nil, err
+			}
+
+			return &core.SuccessResult{
+				Message: fmt.Sprintf("Revision %d of secret %s written to %s", resp.Revision, resp.SecretID, args.OutputFile),
+			}, nil
+		}
+
+		if args.Raw {
+			return core.RawResult(resp.Data), nil
+		}
+
+		return resp, nil
+	}
+
+	return c
+}
+
+// writeSecretFile writes data to a file only readable by the current user, even if the file already exists.
+func writeSecretFile(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	err = f.Chmod(0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+	return err
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"reflect"
 
-	"github.com/scaleway/scaleway-cli/v2/internal/args"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 )
 
@@ -14,15 +13,50 @@ func GetCommands() *core.Commands {
 	)
 }
 
+// shellArgs are parsed by core.RunShell, they are declared here for the usage and the documentation.
+type shellArgs struct {
+	Profile   string
+	ProjectID string
+	Zone      string
+	Region    string
+}
+
 func shellCommand() *core.Command {
 	return &core.Command{
-		Groups:               []string{"utility"},
-		Short:                "Start shell mode",
+		Groups: []string{"utility"},
+		Short:  "Start shell mode",
+		Long: `Start an interactive shell where commands are typed without the scw prefix, with completion and a history shared between sessions.
+The profile, project, zone and region given to the shell are used by default by all the commands of the session.`,
 		Namespace:            "shell",
 		AllowAnonymousClient: false,
-		ArgsType:             reflect.TypeOf(args.RawArgs{}),
+		ArgsType:             reflect.TypeOf(shellArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:  "profile",
+				Short: "Config profile to use in the shell",
+			},
+			{
+				Name:         "project-id",
+				Short:        "Project ID used by default in the shell",
+				ValidateFunc: core.ValidateProjectID(),
+			},
+			{
+				Name:  "zone",
+				Short: "Zone used by default in the shell, the region of the zone is used by default too",
+			},
+			{
+				Name:  "region",
+				Short: "Region used by default in the shell",
+			},
+		},
 		Run: func(_ context.Context, _ interface{}) (interface{}, error) {
 			return nil, nil
 		},
+		Examples: []*core.Example{
+			{
+				Short: "Start a shell working in a project in Amsterdam",
+				Raw:   "scw shell project-id=11111111-1111-1111-1111-111111111111 zone=nl-ams-1",
+			},
+		},
 	}
 }
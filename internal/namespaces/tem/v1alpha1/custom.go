@@ -14,5 +14,10 @@ func GetCommands() *core.Commands {
 
 	cmds.MustFind("tem", "domain", "get").Override(domainGetBuilder)
 
+	cmds.Merge(core.NewCommands(
+		domainVerifyCommand(),
+		emailSendCommand(),
+	))
+
 	return cmds
 }
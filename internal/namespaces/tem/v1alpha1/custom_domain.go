@@ -1,10 +1,21 @@
 package tem
 
 import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
 	"github.com/fatih/color"
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	tem "github.com/scaleway/scaleway-sdk-go/api/tem/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	domainActionTimeout = 5 * time.Minute
 )
 
 var (
@@ -18,6 +29,12 @@ var (
 	}
 )
 
+type domainVerifyArgs struct {
+	DomainID string
+	Region   scw.Region
+	Timeout  time.Duration
+}
+
 func domainGetBuilder(c *core.Command) *core.Command {
 	c.View = &core.View{
 		Sections: []*core.ViewSection{
@@ -30,3 +47,104 @@ func domainGetBuilder(c *core.Command) *core.Command {
 
 	return c
 }
+
+func domainVerifyCommand() *core.Command {
+	return &core.Command{
+		Short: `Check the DNS configuration of a domain and wait for the result`,
+		Long: `Trigger a DNS check of a domain, wait for it to complete and display the SPF, DKIM and DMARC records status.
+The command fails with the records to add to the DNS zone if the domain could not be checked.`,
+		Namespace: "tem",
+		Resource:  "domain",
+		Verb:      "verify",
+		ArgsType:  reflect.TypeOf(domainVerifyArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "domain-id",
+				Short:      `ID of the domain to verify`,
+				Required:   true,
+				Positional: true,
+			},
+			core.RegionArgSpec(scw.RegionFrPar),
+			core.WaitTimeoutArgSpec(domainActionTimeout),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*domainVerifyArgs)
+			api := tem.NewAPI(core.ExtractClient(ctx))
+
+			_, err := api.CheckDomain(&tem.CheckDomainRequest{
+				Region:   args.Region,
+				DomainID: args.DomainID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			domain, err := api.WaitForDomain(&tem.WaitForDomainRequest{
+				Region:        args.Region,
+				DomainID:      args.DomainID,
+				Timeout:       scw.TimeDurationPtr(args.Timeout),
+				RetryInterval: core.DefaultRetryInterval,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			lastStatus, err := api.GetDomainLastStatus(&tem.GetDomainLastStatusRequest{
+				Region:   args.Region,
+				DomainID: args.DomainID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			if domain.Status != tem.DomainStatusChecked {
+				return nil, &core.CliError{
+					Err:     fmt.Errorf("domain %s is %s", domain.Name, domain.Status),
+					Details: domainLastStatusErrors(lastStatus),
+					Hint:    domainDNSRecordsHint(domain),
+				}
+			}
+
+			return lastStatus, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Verify a domain after updating its DNS zone",
+				Raw:   "scw tem domain verify 11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Send an email with file attachments",
+				Command: "scw tem email send",
+			},
+		},
+	}
+}
+
+// domainLastStatusErrors returns the errors of the SPF, DKIM and DMARC records, one per line.
+func domainLastStatusErrors(lastStatus *tem.DomainLastStatus) string {
+	lines := []string(nil)
+	if lastStatus.SpfRecord != nil && lastStatus.SpfRecord.Error != nil {
+		lines = append(lines, fmt.Sprintf("SPF record is %s: %s", lastStatus.SpfRecord.Status, *lastStatus.SpfRecord.Error))
+	}
+	if lastStatus.DkimRecord != nil && lastStatus.DkimRecord.Error != nil {
+		lines = append(lines, fmt.Sprintf("DKIM record is %s: %s", lastStatus.DkimRecord.Status, *lastStatus.DkimRecord.Error))
+	}
+	if lastStatus.DmarcRecord != nil && lastStatus.DmarcRecord.Error != nil {
+		lines = append(lines, fmt.Sprintf("DMARC record is %s: %s", lastStatus.DmarcRecord.Status, *lastStatus.DmarcRecord.Error))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// domainDNSRecordsHint returns the TXT records expected in the DNS zone of a domain.
+func domainDNSRecordsHint(domain *tem.Domain) string {
+	return fmt.Sprintf(`Make sure the following TXT records are set in the DNS zone of %s:
+  %s  "v=spf1 %s -all"
+  %s._domainkey.%s  "%s"`,
+		domain.Name,
+		domain.Name, domain.SpfConfig,
+		domain.ProjectID, domain.Name, domain.DkimConfig,
+	)
+}
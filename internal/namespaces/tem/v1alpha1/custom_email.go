@@ -1,9 +1,21 @@
 package tem
 
 import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
 	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	tem "github.com/scaleway/scaleway-sdk-go/api/tem/v1alpha1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 var (
@@ -15,3 +27,173 @@ var (
 		tem.EmailStatusNew:      &human.EnumMarshalSpec{Attribute: color.FgBlue, Value: "new"},
 	}
 )
+
+type emailSendArgs struct {
+	From        string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Subject     string
+	Body        string
+	Attachments []string
+	ProjectID   string
+	Region      scw.Region
+}
+
+func emailSendCommand() *core.Command {
+	return &core.Command{
+		Short: `Send an email with file attachments`,
+		Long: `Send an email from a checked domain.
+Addresses can be given as a bare email or with a displayed name, see the examples.
+The body is sent as HTML when it looks like an HTML document, as text otherwise.`,
+		Namespace: "tem",
+		Resource:  "email",
+		Verb:      "send",
+		ArgsType:  reflect.TypeOf(emailSendArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:     "from",
+				Short:    `Address of the sender`,
+				Required: true,
+			},
+			{
+				Name:     "to.{index}",
+				Short:    `Address of a recipient`,
+				Required: true,
+			},
+			{
+				Name:  "cc.{index}",
+				Short: `Address of a carbon copy recipient`,
+			},
+			{
+				Name:  "bcc.{index}",
+				Short: `Address of a blind carbon copy recipient`,
+			},
+			{
+				Name:     "subject",
+				Short:    `Subject of the email, at least 6 characters`,
+				Required: true,
+			},
+			{
+				Name:        "body",
+				Short:       `Text or HTML content of the email`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "attachments.{index}",
+				Short: `Path of a file to attach, optionally prefixed with @`,
+			},
+			core.ProjectIDArgSpec(),
+			core.RegionArgSpec(scw.RegionFrPar),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*emailSendArgs)
+
+			request, err := buildEmailRequest(args)
+			if err != nil {
+				return nil, err
+			}
+
+			resp, err := tem.NewAPI(core.ExtractClient(ctx)).CreateEmail(request, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return resp.Emails, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Send an HTML email with an attachment",
+				Raw:   "scw tem email send from=alerts@example.com to.0=ops@example.com subject=\"Backup report\" body=@report.html attachments.0=@report.pdf",
+			},
+			{
+				Short: "Send a text email from a script",
+				Raw:   "scw tem email send from=\"Alerts <alerts@example.com>\" to.0=ops@example.com subject=\"Disk almost full\" body=\"$(df -h)\"",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Verify the DNS configuration of a domain",
+				Command: "scw tem domain verify",
+			},
+		},
+	}
+}
+
+func buildEmailRequest(args *emailSendArgs) (*tem.CreateEmailRequest, error) {
+	request := &tem.CreateEmailRequest{
+		Region:    args.Region,
+		Subject:   args.Subject,
+		ProjectID: args.ProjectID,
+	}
+
+	from, err := parseEmailAddresses([]string{args.From})
+	if err != nil {
+		return nil, err
+	}
+	request.From = from[0]
+
+	request.To, err = parseEmailAddresses(args.To)
+	if err != nil {
+		return nil, err
+	}
+	request.Cc, err = parseEmailAddresses(args.Cc)
+	if err != nil {
+		return nil, err
+	}
+	request.Bcc, err = parseEmailAddresses(args.Bcc)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(http.DetectContentType([]byte(args.Body)), "text/html") {
+		request.HTML = args.Body
+	} else {
+		request.Text = args.Body
+	}
+
+	for _, attachment := range args.Attachments {
+		attachmentPath := strings.TrimPrefix(attachment, "@")
+		content, err := os.ReadFile(attachmentPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read attachment: %w", err)
+		}
+
+		contentType := mime.TypeByExtension(filepath.Ext(attachmentPath))
+		if contentType == "" {
+			contentType = http.DetectContentType(content)
+		}
+		// Parameters such as charset are not accepted by the API
+		contentType, _, _ = strings.Cut(contentType, ";")
+
+		request.Attachments = append(request.Attachments, &tem.CreateEmailRequestAttachment{
+			Name:    filepath.Base(attachmentPath),
+			Type:    contentType,
+			Content: content,
+		})
+	}
+
+	return request, nil
+}
+
+// parseEmailAddresses parses addresses like "jane@example.com" or "Jane <jane@example.com>".
+func parseEmailAddresses(rawAddresses []string) ([]*tem.CreateEmailRequestAddress, error) {
+	addresses := []*tem.CreateEmailRequestAddress(nil)
+	for _, rawAddress := range rawAddresses {
+		address, err := mail.ParseAddress(rawAddress)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", rawAddress, err)
+		}
+
+		requestAddress := &tem.CreateEmailRequestAddress{
+			Email: address.Address,
+		}
+		if address.Name != "" {
+			requestAddress.Name = scw.StringPtr(address.Name)
+		}
+		addresses = append(addresses, requestAddress)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,55 @@
+package tem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_buildEmailRequest(t *testing.T) {
+	attachmentPath := filepath.Join(t.TempDir(), "report.pdf")
+	require.NoError(t, os.WriteFile(attachmentPath, []byte("%PDF-1.4"), 0o600))
+
+	request, err := buildEmailRequest(&emailSendArgs{
+		From:        "Alerts <alerts@example.com>",
+		To:          []string{"ops@example.com"},
+		Subject:     "Backup report",
+		Body:        "<html><body>Done</body></html>",
+		Attachments: []string{"@" + attachmentPath},
+		Region:      scw.RegionFrPar,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "alerts@example.com", request.From.Email)
+	assert.Equal(t, scw.StringPtr("Alerts"), request.From.Name)
+	require.Len(t, request.To, 1)
+	assert.Equal(t, "ops@example.com", request.To[0].Email)
+	assert.Nil(t, request.To[0].Name)
+	assert.Equal(t, "<html><body>Done</body></html>", request.HTML)
+	assert.Empty(t, request.Text)
+	require.Len(t, request.Attachments, 1)
+	assert.Equal(t, "report.pdf", request.Attachments[0].Name)
+	assert.Equal(t, "application/pdf", request.Attachments[0].Type)
+	assert.Equal(t, []byte("%PDF-1.4"), request.Attachments[0].Content)
+}
+
+func Test_buildEmailRequestText(t *testing.T) {
+	request, err := buildEmailRequest(&emailSendArgs{
+		From:    "alerts@example.com",
+		To:      []string{"ops@example.com"},
+		Subject: "Disk almost full",
+		Body:    "/dev/sda1 is 95% full",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/dev/sda1 is 95% full", request.Text)
+	assert.Empty(t, request.HTML)
+
+	_, err = buildEmailRequest(&emailSendArgs{
+		From: "not an address",
+	})
+	assert.Error(t, err)
+}
@@ -10,5 +10,13 @@ func GetCommands() *core.Commands {
 	cmds.Remove("vpc", "post")
 	cmds.MustFind("vpc", "private-network", "get").Override(privateNetworkGetBuilder)
 
+	cmds.Merge(core.NewCommands(
+		dnsRoot(),
+		dnsListCommand(),
+		dnsCreateCommand(),
+		dnsDeleteCommand(),
+		vpcShowCommand(),
+	))
+
 	return cmds
 }
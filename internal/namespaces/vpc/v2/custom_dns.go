@@ -0,0 +1,278 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type dnsRecordType string
+
+const (
+	// dnsRecordTypeInternal is the record of a resource automatically registered in the Private Network zone
+	dnsRecordTypeInternal = dnsRecordType("internal")
+	// dnsRecordTypeReverse is a custom reverse record set on an IP
+	dnsRecordTypeReverse = dnsRecordType("reverse")
+)
+
+type privateNetworkDNSRecord struct {
+	Hostname     string            `json:"hostname"`
+	Type         dnsRecordType     `json:"type"`
+	Address      string            `json:"address"`
+	IPID         string            `json:"ip_id"`
+	ResourceType ipam.ResourceType `json:"resource_type"`
+	ResourceID   string            `json:"resource_id"`
+}
+
+type dnsListArgs struct {
+	PrivateNetworkID string
+	Region           scw.Region
+}
+
+type dnsRecordArgs struct {
+	IPID     string
+	Hostname string
+	Address  net.IP
+	Region   scw.Region
+}
+
+func dnsRoot() *core.Command {
+	return &core.Command{
+		Short: `Private DNS management commands`,
+		Long: `Resources attached to a Private Network are resolved from the Private Network with {resource-name}.{private-network-name}.internal.
+Custom reverse records can also be set on the IPs of a Private Network.`,
+		Namespace: "vpc",
+		Resource:  "dns",
+	}
+}
+
+func dnsListCommand() *core.Command {
+	return &core.Command{
+		Short:     `List the DNS records of a Private Network`,
+		Long:      `List the internal hostnames of the resources attached to a Private Network and the custom reverse records of its IPs.`,
+		Namespace: "vpc",
+		Resource:  "dns",
+		Verb:      "list",
+		ArgsType:  reflect.TypeOf(dnsListArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "private-network-id",
+				Short:      `Private Network ID`,
+				Required:   true,
+				Positional: true,
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*dnsListArgs)
+			client := core.ExtractClient(ctx)
+
+			pn, err := vpc.NewAPI(client).GetPrivateNetwork(&vpc.GetPrivateNetworkRequest{
+				Region:           args.Region,
+				PrivateNetworkID: args.PrivateNetworkID,
+			}, scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			ips, err := ipam.NewAPI(client).ListIPs(&ipam.ListIPsRequest{
+				Region:           args.Region,
+				PrivateNetworkID: &pn.ID,
+			}, scw.WithAllPages(), scw.WithContext(ctx))
+			if err != nil {
+				return nil, err
+			}
+
+			return privateNetworkDNSRecords(pn.Name, ips.IPs), nil
+		},
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Hostname", FieldName: "Hostname"},
+				{Label: "Type", FieldName: "Type"},
+				{Label: "Address", FieldName: "Address"},
+				{Label: "IP ID", FieldName: "IPID"},
+				{Label: "Resource Type", FieldName: "ResourceType"},
+				{Label: "Resource ID", FieldName: "ResourceID"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "List the DNS records of a Private Network",
+				Raw:   "scw vpc dns list 11111111-1111-1111-1111-111111111111",
+			},
+		},
+	}
+}
+
+func dnsRecordArgSpecs() core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:       "ip-id",
+			Short:      `ID of the IPAM IP to set the record on`,
+			Required:   true,
+			Positional: true,
+		},
+		{
+			Name:     "hostname",
+			Short:    `Hostname of the record`,
+			Required: true,
+		},
+		{
+			Name:  "address",
+			Short: `Address of the record, default to the IP address`,
+		},
+		core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+	}
+}
+
+func dnsCreateCommand() *core.Command {
+	return &core.Command{
+		Short:     `Create a reverse DNS record on an IP`,
+		Long:      `Add a custom reverse DNS record on an IP of a Private Network. Existing records of the IP are kept.`,
+		Namespace: "vpc",
+		Resource:  "dns",
+		Verb:      "create",
+		ArgsType:  reflect.TypeOf(dnsRecordArgs{}),
+		ArgSpecs:  dnsRecordArgSpecs(),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*dnsRecordArgs)
+
+			return updateIPReverses(ctx, args, func(ip *ipam.IP, reverses []*ipam.Reverse) ([]*ipam.Reverse, error) {
+				address := args.Address
+				if address == nil {
+					address = ip.Address.IP
+				}
+
+				return append(reverses, &ipam.Reverse{
+					Hostname: args.Hostname,
+					Address:  &address,
+				}), nil
+			})
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Create a reverse record on an IP",
+				Raw:   "scw vpc dns create 11111111-1111-1111-1111-111111111111 hostname=db.example.internal",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List IPs managed by IPAM",
+				Command: "scw ipam ip list",
+			},
+		},
+	}
+}
+
+func dnsDeleteCommand() *core.Command {
+	return &core.Command{
+		Short:     `Delete a reverse DNS record of an IP`,
+		Long:      `Delete the custom reverse DNS records of an IP matching the given hostname, and address if given.`,
+		Namespace: "vpc",
+		Resource:  "dns",
+		Verb:      "delete",
+		ArgsType:  reflect.TypeOf(dnsRecordArgs{}),
+		ArgSpecs:  dnsRecordArgSpecs(),
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*dnsRecordArgs)
+
+			return updateIPReverses(ctx, args, func(_ *ipam.IP, reverses []*ipam.Reverse) ([]*ipam.Reverse, error) {
+				kept := []*ipam.Reverse{}
+				for _, reverse := range reverses {
+					if reverse.Hostname == args.Hostname && (args.Address == nil || reverse.Address == nil || reverse.Address.Equal(args.Address)) {
+						continue
+					}
+					kept = append(kept, reverse)
+				}
+				if len(kept) == len(reverses) {
+					return nil, fmt.Errorf("no record %s found on ip %s", args.Hostname, args.IPID)
+				}
+
+				return kept, nil
+			})
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Delete a reverse record of an IP",
+				Raw:   "scw vpc dns delete 11111111-1111-1111-1111-111111111111 hostname=db.example.internal",
+			},
+		},
+	}
+}
+
+func updateIPReverses(ctx context.Context, args *dnsRecordArgs, update func(ip *ipam.IP, reverses []*ipam.Reverse) ([]*ipam.Reverse, error)) (interface{}, error) {
+	api := ipam.NewAPI(core.ExtractClient(ctx))
+
+	ip, err := api.GetIP(&ipam.GetIPRequest{
+		Region: args.Region,
+		IPID:   args.IPID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	reverses, err := update(ip, ip.Reverses)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.UpdateIP(&ipam.UpdateIPRequest{
+		Region:   args.Region,
+		IPID:     args.IPID,
+		Reverses: reverses,
+	}, scw.WithContext(ctx))
+}
+
+// privateNetworkDNSRecords returns the records of the IPs of a Private Network, sorted by hostname.
+func privateNetworkDNSRecords(pnName string, ips []*ipam.IP) []*privateNetworkDNSRecord {
+	records := []*privateNetworkDNSRecord(nil)
+	for _, ip := range ips {
+		address := ip.Address.IP.String()
+		resourceType := ipam.ResourceType("")
+		resourceID := ""
+		if ip.Resource != nil {
+			resourceType = ip.Resource.Type
+			resourceID = ip.Resource.ID
+
+			if ip.Resource.Name != nil && *ip.Resource.Name != "" {
+				records = append(records, &privateNetworkDNSRecord{
+					Hostname:     strings.ToLower(fmt.Sprintf("%s.%s.internal", *ip.Resource.Name, pnName)),
+					Type:         dnsRecordTypeInternal,
+					Address:      address,
+					IPID:         ip.ID,
+					ResourceType: resourceType,
+					ResourceID:   resourceID,
+				})
+			}
+		}
+
+		for _, reverse := range ip.Reverses {
+			reverseAddress := address
+			if reverse.Address != nil {
+				reverseAddress = reverse.Address.String()
+			}
+			records = append(records, &privateNetworkDNSRecord{
+				Hostname:     reverse.Hostname,
+				Type:         dnsRecordTypeReverse,
+				Address:      reverseAddress,
+				IPID:         ip.ID,
+				ResourceType: resourceType,
+				ResourceID:   resourceID,
+			})
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Hostname < records[j].Hostname
+	})
+
+	return records
+}
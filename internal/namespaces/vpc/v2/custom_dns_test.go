@@ -0,0 +1,51 @@
+package vpc
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/ipam/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_privateNetworkDNSRecords(t *testing.T) {
+	reverseAddress := net.ParseIP("172.16.0.3")
+	ips := []*ipam.IP{
+		{
+			ID:      "ip-1",
+			Address: scw.IPNet{IPNet: net.IPNet{IP: net.ParseIP("172.16.0.2"), Mask: net.CIDRMask(22, 32)}},
+			Resource: &ipam.Resource{
+				Type: ipam.ResourceTypeInstancePrivateNic,
+				ID:   "nic-1",
+				Name: scw.StringPtr("Web"),
+			},
+		},
+		{
+			ID:      "ip-2",
+			Address: scw.IPNet{IPNet: net.IPNet{IP: net.ParseIP("172.16.0.3"), Mask: net.CIDRMask(22, 32)}},
+			Reverses: []*ipam.Reverse{
+				{Hostname: "db.example.internal", Address: &reverseAddress},
+			},
+		},
+	}
+
+	records := privateNetworkDNSRecords("my-pn", ips)
+
+	assert.Equal(t, []*privateNetworkDNSRecord{
+		{
+			Hostname: "db.example.internal",
+			Type:     dnsRecordTypeReverse,
+			Address:  "172.16.0.3",
+			IPID:     "ip-2",
+		},
+		{
+			Hostname:     "web.my-pn.internal",
+			Type:         dnsRecordTypeInternal,
+			Address:      "172.16.0.2",
+			IPID:         "ip-1",
+			ResourceType: ipam.ResourceTypeInstancePrivateNic,
+			ResourceID:   "nic-1",
+		},
+	}, records)
+}
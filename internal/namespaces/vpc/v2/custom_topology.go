@@ -0,0 +1,276 @@
+package vpc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-sdk-go/api/vpc/v2"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+type vpcTopologyFormat string
+
+const (
+	vpcTopologyFormatTree = vpcTopologyFormat("tree")
+	vpcTopologyFormatDot  = vpcTopologyFormat("dot")
+)
+
+type vpcShowArgs struct {
+	VpcID  string
+	Format vpcTopologyFormat
+	Region scw.Region
+}
+
+type vpcTopology struct {
+	ID              string                    `json:"id"`
+	Name            string                    `json:"name"`
+	Region          scw.Region                `json:"region"`
+	PrivateNetworks []*privateNetworkTopology `json:"private_networks"`
+}
+
+type privateNetworkTopology struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Subnets   []string               `json:"subnets"`
+	Resources []*vpcTopologyResource `json:"resources"`
+}
+
+type vpcTopologyResource struct {
+	Type  string `json:"type"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+func vpcShowCommand() *core.Command {
+	return &core.Command{
+		Short: `Show the network topology of a VPC`,
+		Long: `Show the Private Networks of a VPC and the resources attached to them: Instances, Elastic Metal servers, Kubernetes clusters, Load Balancers, Database Instances, Redis™ clusters and Public Gateways.
+The topology is displayed as a tree, or as a Graphviz DOT document with format=dot.`,
+		Namespace: "vpc",
+		Resource:  "show",
+		ArgsType:  reflect.TypeOf(vpcShowArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "vpc-id",
+				Short:      `VPC ID`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:       "format",
+				Short:      `Format of the topology`,
+				Default:    core.DefaultValueSetter(string(vpcTopologyFormatTree)),
+				EnumValues: []string{string(vpcTopologyFormatTree), string(vpcTopologyFormatDot)},
+			},
+			core.RegionArgSpec(scw.RegionFrPar, scw.RegionNlAms, scw.RegionPlWaw),
+		},
+		Run: func(ctx context.Context, argsI interface{}) (interface{}, error) {
+			args := argsI.(*vpcShowArgs)
+
+			topology, err := getVPCTopology(core.ExtractClient(ctx), args.Region, args.VpcID)
+			if err != nil {
+				return nil, err
+			}
+
+			if args.Format == vpcTopologyFormatDot {
+				return formatVPCTopologyDot(topology), nil
+			}
+
+			return topology, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Show the topology of a VPC",
+				Raw:   "scw vpc show 11111111-1111-1111-1111-111111111111",
+			},
+			{
+				Short: "Render the topology of a VPC with Graphviz",
+				Raw:   "scw vpc show 11111111-1111-1111-1111-111111111111 format=dot | dot -Tsvg > vpc.svg",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Command: "scw vpc private-network get",
+				Short:   "Get a Private Network and the resources attached to it",
+			},
+		},
+	}
+}
+
+func getVPCTopology(client *scw.Client, region scw.Region, vpcID string) (*vpcTopology, error) {
+	api := vpc.NewAPI(client)
+
+	v, err := api.GetVPC(&vpc.GetVPCRequest{
+		Region: region,
+		VpcID:  vpcID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pns, err := api.ListPrivateNetworks(&vpc.ListPrivateNetworksRequest{
+		Region: region,
+		VpcID:  &v.ID,
+	}, scw.WithAllPages())
+	if err != nil {
+		return nil, err
+	}
+
+	topology := &vpcTopology{
+		ID:     v.ID,
+		Name:   v.Name,
+		Region: v.Region,
+	}
+	for _, pn := range pns.PrivateNetworks {
+		pnTopology, err := getPrivateNetworkTopology(client, pn)
+		if err != nil {
+			return nil, err
+		}
+		topology.PrivateNetworks = append(topology.PrivateNetworks, pnTopology)
+	}
+
+	return topology, nil
+}
+
+func getPrivateNetworkTopology(client *scw.Client, pn *vpc.PrivateNetwork) (*privateNetworkTopology, error) {
+	topology := &privateNetworkTopology{
+		ID:        pn.ID,
+		Name:      pn.Name,
+		Resources: []*vpcTopologyResource{},
+	}
+	for _, subnet := range pn.Subnets {
+		topology.Subnets = append(topology.Subnets, subnet.Subnet.String())
+	}
+	addResource := func(resourceType string, id string, name string, state fmt.Stringer) {
+		topology.Resources = append(topology.Resources, &vpcTopologyResource{
+			Type:  resourceType,
+			ID:    id,
+			Name:  name,
+			State: state.String(),
+		})
+	}
+
+	instanceServers, err := listCustomInstanceServers(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range instanceServers {
+		addResource("Instance", server.ID, server.Name, server.State)
+	}
+
+	baremetalServers, err := listCustomBaremetalServers(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, server := range baremetalServers {
+		addResource("Elastic Metal", server.ID, server.Name, server.State)
+	}
+
+	k8sClusters, err := listCustomK8sClusters(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range k8sClusters {
+		addResource("Kubernetes", cluster.ID, cluster.Name, cluster.State)
+	}
+
+	lbs, err := listCustomLBs(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, lb := range lbs {
+		addResource("Load Balancer", lb.ID, lb.Name, lb.State)
+	}
+
+	rdbInstances, err := listCustomRdbs(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range rdbInstances {
+		addResource("Database", db.ID, db.Name, db.State)
+	}
+
+	redisClusters, err := listCustomRedisClusters(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range redisClusters {
+		addResource("Redis", cluster.ID, cluster.Name, cluster.State)
+	}
+
+	gateways, err := listCustomGateways(client, pn)
+	if err != nil {
+		return nil, err
+	}
+	for _, gateway := range gateways {
+		addResource("Public Gateway", gateway.ID, gateway.Name, gateway.State)
+	}
+
+	return topology, nil
+}
+
+// MarshalHuman displays the topology as a tree.
+func (t *vpcTopology) MarshalHuman() (string, error) {
+	return formatVPCTopologyTree(t), nil
+}
+
+func formatVPCTopologyTree(topology *vpcTopology) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "VPC %s (%s) %s\n", topology.Name, topology.ID, topology.Region)
+
+	for i, pn := range topology.PrivateNetworks {
+		branch, indent := "├── ", "│   "
+		if i == len(topology.PrivateNetworks)-1 {
+			branch, indent = "└── ", "    "
+		}
+		fmt.Fprintf(&b, "%sPrivate Network %s (%s)", branch, pn.Name, pn.ID)
+		if len(pn.Subnets) > 0 {
+			fmt.Fprintf(&b, " %s", strings.Join(pn.Subnets, ", "))
+		}
+		b.WriteString("\n")
+
+		for j, resource := range pn.Resources {
+			resourceBranch := "├── "
+			if j == len(pn.Resources)-1 {
+				resourceBranch = "└── "
+			}
+			fmt.Fprintf(&b, "%s%s%s %s (%s) %s\n", indent, resourceBranch, resource.Type, resource.Name, resource.ID, resource.State)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatVPCTopologyDot returns a Graphviz DOT document of the topology.
+// A resource attached to several Private Networks is a single node linked to each of them.
+func formatVPCTopologyDot(topology *vpcTopology) string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "graph %q {\n", "vpc-"+topology.Name)
+	b.WriteString("  node [fontname=\"Helvetica\"];\n")
+	fmt.Fprintf(&b, "  %q [label=%q, shape=box3d];\n", topology.ID, "VPC\n"+topology.Name)
+
+	declared := map[string]bool{}
+	for _, pn := range topology.PrivateNetworks {
+		label := "Private Network\n" + pn.Name
+		if len(pn.Subnets) > 0 {
+			label += "\n" + strings.Join(pn.Subnets, "\n")
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, shape=ellipse];\n", pn.ID, label)
+		fmt.Fprintf(&b, "  %q -- %q;\n", topology.ID, pn.ID)
+
+		for _, resource := range pn.Resources {
+			if !declared[resource.ID] {
+				declared[resource.ID] = true
+				fmt.Fprintf(&b, "  %q [label=%q, shape=box];\n", resource.ID, resource.Type+"\n"+resource.Name)
+			}
+			fmt.Fprintf(&b, "  %q -- %q;\n", pn.ID, resource.ID)
+		}
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
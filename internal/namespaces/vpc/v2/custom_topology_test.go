@@ -0,0 +1,55 @@
+package vpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_formatVPCTopology(t *testing.T) {
+	topology := &vpcTopology{
+		ID:     "vpc-1",
+		Name:   "prod",
+		Region: "fr-par",
+		PrivateNetworks: []*privateNetworkTopology{
+			{
+				ID:      "pn-1",
+				Name:    "front",
+				Subnets: []string{"172.16.0.0/22"},
+				Resources: []*vpcTopologyResource{
+					{Type: "Instance", ID: "srv-1", Name: "web", State: "running"},
+					{Type: "Public Gateway", ID: "gw-1", Name: "gw", State: "running"},
+				},
+			},
+			{
+				ID:      "pn-2",
+				Name:    "back",
+				Subnets: []string{"172.16.4.0/22"},
+				Resources: []*vpcTopologyResource{
+					{Type: "Instance", ID: "srv-1", Name: "web", State: "running"},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, `VPC prod (vpc-1) fr-par
+├── Private Network front (pn-1) 172.16.0.0/22
+│   ├── Instance web (srv-1) running
+│   └── Public Gateway gw (gw-1) running
+└── Private Network back (pn-2) 172.16.4.0/22
+    └── Instance web (srv-1) running`, formatVPCTopologyTree(topology))
+
+	assert.Equal(t, `graph "vpc-prod" {
+  node [fontname="Helvetica"];
+  "vpc-1" [label="VPC\nprod", shape=box3d];
+  "pn-1" [label="Private Network\nfront\n172.16.0.0/22", shape=ellipse];
+  "vpc-1" -- "pn-1";
+  "srv-1" [label="Instance\nweb", shape=box];
+  "pn-1" -- "srv-1";
+  "gw-1" [label="Public Gateway\ngw", shape=box];
+  "pn-1" -- "gw-1";
+  "pn-2" [label="Private Network\nback\n172.16.4.0/22", shape=ellipse];
+  "vpc-1" -- "pn-2";
+  "pn-2" -- "srv-1";
+}`, formatVPCTopologyDot(topology))
+}
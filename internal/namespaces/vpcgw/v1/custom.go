@@ -15,6 +15,12 @@ func GetCommands() *core.Commands {
 	cmds.MustFind("vpc-gw", "gateway-type", "list").Override(vpcgwGatewayTypeListBuilder)
 	cmds.MustFind("vpc-gw", "gateway", "create").Override(gatewayCreateBuilder)
 	cmds.MustFind("vpc-gw", "gateway-network", "create").Override(gatewayNetworkCreateBuilder)
+	cmds.MustFind("vpc-gw", "dhcp-entry", "list").Override(dhcpEntryListBuilder)
+
+	cmds.Merge(core.NewCommands(
+		patRuleApplyCommand(),
+		gatewayConnectionsCommand(),
+	))
 
 	return cmds
 }
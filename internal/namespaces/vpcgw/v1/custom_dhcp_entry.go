@@ -0,0 +1,145 @@
+package vpcgw
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/terminal"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+)
+
+const dhcpEntryWatchInterval = 5 * time.Second
+
+const (
+	dhcpEntryEventAdded   = "added"
+	dhcpEntryEventUpdated = "updated"
+	dhcpEntryEventRemoved = "removed"
+)
+
+// dhcpEntryEvent is a change of a DHCP entry observed while watching.
+type dhcpEntryEvent struct {
+	Event string
+	Entry *vpcgw.DHCPEntry
+}
+
+func dhcpEntryListBuilder(c *core.Command) *core.Command {
+	type customListDHCPEntriesRequest struct {
+		*vpcgw.ListDHCPEntriesRequest
+		Watch bool
+	}
+
+	c.ArgsType = reflect.TypeOf(customListDHCPEntriesRequest{})
+	c.ArgSpecs.AddBefore("zone", &core.ArgSpec{
+		Name:  "watch",
+		Short: `Keep displaying the DHCP entries added, updated or removed`,
+	})
+	c.Examples = append(c.Examples, &core.Example{
+		Short: "Watch the DHCP leases of a gateway network",
+		Raw:   "scw vpc-gw dhcp-entry list gateway-network-id=11111111-1111-1111-1111-111111111111 type=lease watch=true",
+	})
+
+	c.AddInterceptors(func(ctx context.Context, argsI interface{}, runner core.CommandRunner) (interface{}, error) {
+		args := argsI.(*customListDHCPEntriesRequest)
+
+		request := args.ListDHCPEntriesRequest
+		if request == nil {
+			request = &vpcgw.ListDHCPEntriesRequest{}
+		}
+		if !args.Watch {
+			return runner(ctx, request)
+		}
+
+		stdout := core.ExtractStdout(ctx)
+		_, err := fmt.Fprintln(stdout, terminal.Style(formatDHCPEntryEventHeader(), color.Bold))
+		if err != nil {
+			return nil, err
+		}
+
+		previous := map[string]*vpcgw.DHCPEntry{}
+		for {
+			res, err := runner(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+
+			current := map[string]*vpcgw.DHCPEntry{}
+			for _, entry := range res.([]*vpcgw.DHCPEntry) {
+				current[entry.ID] = entry
+			}
+			for _, event := range dhcpEntryEvents(previous, current) {
+				_, err = fmt.Fprintln(stdout, formatDHCPEntryEvent(event, time.Now()))
+				if err != nil {
+					return nil, err
+				}
+			}
+			previous = current
+
+			select {
+			case <-ctx.Done():
+				return &core.SuccessResult{Empty: true}, nil
+			case <-time.After(dhcpEntryWatchInterval):
+			}
+		}
+	})
+
+	return c
+}
+
+// dhcpEntryEvents returns the entries added, updated and removed between two listings, sorted by IP address.
+func dhcpEntryEvents(previous map[string]*vpcgw.DHCPEntry, current map[string]*vpcgw.DHCPEntry) []*dhcpEntryEvent {
+	events := []*dhcpEntryEvent(nil)
+	for id, entry := range current {
+		previousEntry, exists := previous[id]
+		switch {
+		case !exists:
+			events = append(events, &dhcpEntryEvent{Event: dhcpEntryEventAdded, Entry: entry})
+		case !previousEntry.IPAddress.Equal(entry.IPAddress) ||
+			previousEntry.MacAddress != entry.MacAddress ||
+			previousEntry.Hostname != entry.Hostname ||
+			previousEntry.Type != entry.Type:
+			events = append(events, &dhcpEntryEvent{Event: dhcpEntryEventUpdated, Entry: entry})
+		}
+	}
+	for id, entry := range previous {
+		if _, exists := current[id]; !exists {
+			events = append(events, &dhcpEntryEvent{Event: dhcpEntryEventRemoved, Entry: entry})
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Entry.IPAddress.String() < events[j].Entry.IPAddress.String()
+	})
+
+	return events
+}
+
+func formatDHCPEntryEventHeader() string {
+	return fmt.Sprintf("%-20s  %-7s  %-11s  %-15s  %-17s  %s", "TIME", "EVENT", "TYPE", "IP ADDRESS", "MAC ADDRESS", "HOSTNAME")
+}
+
+func formatDHCPEntryEvent(event *dhcpEntryEvent, now time.Time) string {
+	// The event is padded before being colored so that columns stay aligned.
+	eventName := fmt.Sprintf("%-7s", event.Event)
+	switch event.Event {
+	case dhcpEntryEventAdded:
+		eventName = terminal.Style(eventName, color.FgGreen)
+	case dhcpEntryEventRemoved:
+		eventName = terminal.Style(eventName, color.FgRed)
+	default:
+		eventName = terminal.Style(eventName, color.FgBlue)
+	}
+
+	return fmt.Sprintf("%-20s  %s  %-11s  %-15s  %-17s  %s",
+		now.UTC().Format(time.RFC3339),
+		eventName,
+		event.Entry.Type,
+		event.Entry.IPAddress,
+		event.Entry.MacAddress,
+		event.Entry.Hostname,
+	)
+}
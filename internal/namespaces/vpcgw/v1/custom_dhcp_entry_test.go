@@ -0,0 +1,30 @@
+package vpcgw
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dhcpEntryEvents(t *testing.T) {
+	previous := map[string]*vpcgw.DHCPEntry{
+		"kept":    {ID: "kept", IPAddress: net.ParseIP("192.168.1.2"), MacAddress: "02:00:00:00:00:02", Type: vpcgw.DHCPEntryTypeLease},
+		"renamed": {ID: "renamed", IPAddress: net.ParseIP("192.168.1.3"), Hostname: "old", Type: vpcgw.DHCPEntryTypeLease},
+		"expired": {ID: "expired", IPAddress: net.ParseIP("192.168.1.4"), Type: vpcgw.DHCPEntryTypeLease},
+	}
+	current := map[string]*vpcgw.DHCPEntry{
+		"kept":    previous["kept"],
+		"renamed": {ID: "renamed", IPAddress: net.ParseIP("192.168.1.3"), Hostname: "new", Type: vpcgw.DHCPEntryTypeLease},
+		"new":     {ID: "new", IPAddress: net.ParseIP("192.168.1.5"), Type: vpcgw.DHCPEntryTypeLease},
+	}
+
+	events := dhcpEntryEvents(previous, current)
+	summary := []string(nil)
+	for _, event := range events {
+		summary = append(summary, event.Entry.ID+" "+event.Event)
+	}
+	assert.Equal(t, []string{"renamed updated", "expired removed", "new added"}, summary)
+	assert.Empty(t, dhcpEntryEvents(current, current))
+}
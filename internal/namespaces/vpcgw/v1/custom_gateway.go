@@ -8,7 +8,6 @@ import (
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 const (
@@ -36,7 +35,7 @@ func gatewayCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForGateway(&vpcgw.WaitForGatewayRequest{
 			GatewayID:     getResp.ID,
 			Zone:          getResp.Zone,
-			Timeout:       scw.TimeDurationPtr(gatewayActionTimeout),
+			Timeout:       core.WaitTimeout(ctx, gatewayActionTimeout),
 			RetryInterval: core.DefaultRetryInterval,
 		})
 	}
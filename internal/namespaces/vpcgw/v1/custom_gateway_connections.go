@@ -0,0 +1,350 @@
+package vpcgw
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	cockpit "github.com/scaleway/scaleway-sdk-go/api/cockpit/v1beta1"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	gatewayCheckOK      = "ok"
+	gatewayCheckWarning = "warning"
+)
+
+// smtpPorts are the ports blocked by a gateway unless SMTP is enabled.
+var smtpPorts = map[uint32]bool{25: true, 465: true, 587: true}
+
+type gatewayConnectionsRequest struct {
+	GatewayID       string
+	DestinationIP   net.IP
+	DestinationPort uint32
+	Since           time.Duration
+	Limit           uint32
+	Token           string
+	Zone            scw.Zone
+}
+
+type gatewayConnectionsResult struct {
+	GatewayID string
+	Name      string
+	Status    vpcgw.GatewayStatus
+	PublicIP  net.IP
+	Checks    []*gatewayCheck
+	PatRules  []*vpcgw.PATRule
+	FlowLogs  []*gatewayFlowLog
+}
+
+// gatewayCheck is the result of a check of a gateway configuration which can prevent connections.
+type gatewayCheck struct {
+	Check   string
+	Status  string
+	Details string
+}
+
+type gatewayFlowLog struct {
+	Time time.Time
+	Line string
+}
+
+// lokiQueryResponse is the response of a Loki query_range request returning log streams.
+type lokiQueryResponse struct {
+	Data struct {
+		Result []struct {
+			Values [][2]string `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func gatewayConnectionsCommand() *core.Command {
+	return &core.Command{
+		Short: `Debug the connections going through a gateway`,
+		Long: `Check the configuration of a Public Gateway which can prevent the resources of its Private Networks from reaching the internet, and display its recent flow logs.
+
+Checks cover the gateway status and public IP, the masquerade (NAT) of each attached Private Network and, with a destination port, the SMTP blocking and the PAT rules using this port.
+The gateway API does not expose NAT sessions, flow logs are read from the Cockpit of the gateway project when a token is given, among the logs labelled with the gateway ID. They can be filtered by destination IP and port.`,
+		Namespace: "vpc-gw",
+		Resource:  "gateway",
+		Verb:      "connections",
+		Groups:    []string{"workflow"},
+		ArgsType:  reflect.TypeOf(gatewayConnectionsRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "gateway-id",
+				Short:      `ID of the gateway to debug`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:  "destination-ip",
+				Short: `Only display the flow logs to this IP`,
+			},
+			{
+				Name:  "destination-port",
+				Short: `Check the rules of this port and only display the flow logs to this port`,
+			},
+			{
+				Name:    "since",
+				Short:   `Age of the oldest flow logs to display`,
+				Default: core.DefaultValueSetter("15m"),
+			},
+			{
+				Name:    "limit",
+				Short:   `Maximum number of flow logs to display`,
+				Default: core.DefaultValueSetter("100"),
+			},
+			{
+				Name:  "token",
+				Short: `Secret key of a Cockpit token with the query_logs scope, flow logs are not displayed without it`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3),
+		},
+		Run: gatewayConnectionsRun,
+		View: &core.View{
+			Sections: []*core.ViewSection{
+				{Title: "Checks", FieldName: "Checks"},
+				{Title: "PAT Rules", FieldName: "PatRules", HideIfEmpty: true},
+				{Title: "Flow Logs", FieldName: "FlowLogs", HideIfEmpty: true},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Check why the resources behind a gateway cannot send emails",
+				Raw:   "scw vpc-gw gateway connections 11111111-1111-1111-1111-111111111111 destination-port=25",
+			},
+			{
+				Short: "Display the flow logs of the last hour to an IP",
+				Raw:   "scw vpc-gw gateway connections 11111111-1111-1111-1111-111111111111 destination-ip=1.1.1.1 since=1h token=11111111-1111-1111-1111-111111111111",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Create a token with the query_logs scope",
+				Command: "scw cockpit token create scopes.query-logs=true",
+			},
+			{
+				Short:   "Enable the masquerade of a gateway network",
+				Command: "scw vpc-gw gateway-network update enable-masquerade=true",
+			},
+		},
+	}
+}
+
+func gatewayConnectionsRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*gatewayConnectionsRequest)
+
+	client := core.ExtractClient(ctx)
+	api := vpcgw.NewAPI(client)
+
+	gateway, err := api.GetGateway(&vpcgw.GetGatewayRequest{
+		Zone:      args.Zone,
+		GatewayID: args.GatewayID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &gatewayConnectionsResult{
+		GatewayID: gateway.ID,
+		Name:      gateway.Name,
+		Status:    gateway.Status,
+		Checks:    gatewayChecks(gateway, args.DestinationPort),
+	}
+	if gateway.IP != nil {
+		result.PublicIP = gateway.IP.Address
+	}
+
+	if args.DestinationPort != 0 {
+		patRules, err := api.ListPATRules(&vpcgw.ListPATRulesRequest{
+			Zone:      args.Zone,
+			GatewayID: &args.GatewayID,
+		}, scw.WithAllPages(), scw.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		result.PatRules = filterPATRulesByPort(patRules.PatRules, args.DestinationPort)
+	}
+
+	if args.Token == "" {
+		return result, nil
+	}
+
+	projectCockpit, err := cockpit.NewAPI(client).GetCockpit(&cockpit.GetCockpitRequest{
+		ProjectID: gateway.ProjectID,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if projectCockpit.Endpoints == nil || projectCockpit.Endpoints.LogsURL == "" {
+		return nil, fmt.Errorf("cockpit of project %s has no logs endpoint", gateway.ProjectID)
+	}
+
+	end := time.Now()
+	result.FlowLogs, err = queryGatewayFlowLogs(ctx, core.ExtractHTTPClient(ctx), projectCockpit.Endpoints.LogsURL, args.Token,
+		gatewayFlowLogsQuery(gateway.ID, args.DestinationIP, args.DestinationPort), end.Add(-args.Since), end, args.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// gatewayChecks returns the checks of the gateway configuration, the SMTP check is only done for SMTP destination ports.
+func gatewayChecks(gateway *vpcgw.Gateway, destinationPort uint32) []*gatewayCheck {
+	checks := []*gatewayCheck(nil)
+
+	status := &gatewayCheck{Check: "Gateway status", Status: gatewayCheckOK, Details: gateway.Status.String()}
+	if gateway.Status != vpcgw.GatewayStatusRunning {
+		status.Status = gatewayCheckWarning
+		status.Details = fmt.Sprintf("gateway is %s, it only forwards traffic when running", gateway.Status)
+	}
+	checks = append(checks, status)
+
+	publicIP := &gatewayCheck{Check: "Public IP", Status: gatewayCheckOK}
+	if gateway.IP == nil {
+		publicIP.Status = gatewayCheckWarning
+		publicIP.Details = "gateway has no public IP, resources cannot reach the internet through it"
+	} else {
+		publicIP.Details = gateway.IP.Address.String()
+	}
+	checks = append(checks, publicIP)
+
+	if len(gateway.GatewayNetworks) == 0 {
+		checks = append(checks, &gatewayCheck{
+			Check:   "Private Networks",
+			Status:  gatewayCheckWarning,
+			Details: "gateway is not attached to any Private Network",
+		})
+	}
+	for _, gatewayNetwork := range gateway.GatewayNetworks {
+		check := &gatewayCheck{
+			Check:   "Masquerade on " + gatewayNetwork.PrivateNetworkID,
+			Status:  gatewayCheckOK,
+			Details: "enabled",
+		}
+		switch {
+		case !gatewayNetwork.EnableMasquerade:
+			check.Status = gatewayCheckWarning
+			check.Details = "disabled, resources of this Private Network cannot reach the internet through the gateway"
+		case gatewayNetwork.Status != vpcgw.GatewayNetworkStatusReady:
+			check.Status = gatewayCheckWarning
+			check.Details = fmt.Sprintf("enabled but gateway network is %s", gatewayNetwork.Status)
+		}
+		checks = append(checks, check)
+	}
+
+	if smtpPorts[destinationPort] {
+		check := &gatewayCheck{Check: "SMTP", Status: gatewayCheckOK, Details: "enabled"}
+		if !gateway.SMTPEnabled {
+			check.Status = gatewayCheckWarning
+			check.Details = fmt.Sprintf("port %d is blocked, enable SMTP with: scw vpc-gw gateway update %s enable-smtp=true zone=%s", destinationPort, gateway.ID, gateway.Zone)
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// filterPATRulesByPort returns the PAT rules using port as public or private port.
+func filterPATRulesByPort(rules []*vpcgw.PATRule, port uint32) []*vpcgw.PATRule {
+	filtered := []*vpcgw.PATRule(nil)
+	for _, rule := range rules {
+		if rule.PublicPort == port || rule.PrivatePort == port {
+			filtered = append(filtered, rule)
+		}
+	}
+
+	return filtered
+}
+
+// gatewayFlowLogsQuery returns the LogQL query selecting the logs of a gateway,
+// IP and port are matched as whole words as flow logs have no fixed format.
+func gatewayFlowLogsQuery(gatewayID string, destinationIP net.IP, destinationPort uint32) string {
+	query := fmt.Sprintf(`{resource_id=%q}`, gatewayID)
+	if destinationIP != nil {
+		query += fmt.Sprintf(` |~ %q`, `(^|[^0-9.])`+regexp.QuoteMeta(destinationIP.String())+`([^0-9.]|$)`)
+	}
+	if destinationPort != 0 {
+		query += fmt.Sprintf(` |~ %q`, `(^|[^0-9])`+strconv.FormatUint(uint64(destinationPort), 10)+`([^0-9]|$)`)
+	}
+
+	return query
+}
+
+// queryGatewayFlowLogs runs a Loki query on a Cockpit logs endpoint and returns the latest logs, oldest first.
+func queryGatewayFlowLogs(ctx context.Context, httpClient *http.Client, logsURL string, token string, query string, start time.Time, end time.Time, limit uint32) ([]*gatewayFlowLog, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.UnixNano(), 10))
+	params.Set("end", strconv.FormatInt(end.UnixNano(), 10))
+	params.Set("limit", strconv.FormatUint(uint64(limit), 10))
+	params.Set("direction", "backward")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(logsURL, "/")+"/loki/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Token", token)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &core.CliError{
+			Err:     fmt.Errorf("failed to query flow logs: %s", resp.Status),
+			Details: strings.TrimSpace(string(respBody)),
+			Hint:    "Make sure the token has the query_logs scope",
+		}
+	}
+
+	queryResp := &lokiQueryResponse{}
+	err = json.NewDecoder(resp.Body).Decode(queryResp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse flow logs: %w", err)
+	}
+
+	logs := []*gatewayFlowLog(nil)
+	for _, stream := range queryResp.Data.Result {
+		for _, value := range stream.Values {
+			timestamp, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid flow log timestamp %q", value[0])
+			}
+			logs = append(logs, &gatewayFlowLog{
+				Time: time.Unix(0, timestamp),
+				Line: strings.TrimSuffix(value[1], "\n"),
+			})
+		}
+	}
+
+	// Streams are returned separately, the latest logs of all streams are kept.
+	sort.SliceStable(logs, func(i, j int) bool {
+		return logs[i].Time.After(logs[j].Time)
+	})
+	if limit > 0 && len(logs) > int(limit) {
+		logs = logs[:limit]
+	}
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+
+	return logs, nil
+}
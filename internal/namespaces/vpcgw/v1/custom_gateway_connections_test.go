@@ -0,0 +1,70 @@
+package vpcgw
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_gatewayChecks(t *testing.T) {
+	gateway := &vpcgw.Gateway{
+		ID:     "11111111-1111-1111-1111-111111111111",
+		Status: vpcgw.GatewayStatusRunning,
+		IP:     &vpcgw.IP{Address: net.ParseIP("51.15.0.1")},
+		GatewayNetworks: []*vpcgw.GatewayNetwork{
+			{PrivateNetworkID: "pn-1", EnableMasquerade: true, Status: vpcgw.GatewayNetworkStatusReady},
+			{PrivateNetworkID: "pn-2", EnableMasquerade: false, Status: vpcgw.GatewayNetworkStatusReady},
+		},
+	}
+
+	statuses := map[string]string{}
+	for _, check := range gatewayChecks(gateway, 25) {
+		statuses[check.Check] = check.Status
+	}
+	assert.Equal(t, map[string]string{
+		"Gateway status":     gatewayCheckOK,
+		"Public IP":          gatewayCheckOK,
+		"Masquerade on pn-1": gatewayCheckOK,
+		"Masquerade on pn-2": gatewayCheckWarning,
+		"SMTP":               gatewayCheckWarning,
+	}, statuses)
+
+	assert.Len(t, gatewayChecks(gateway, 443), 4)
+}
+
+func Test_gatewayFlowLogsQuery(t *testing.T) {
+	query := gatewayFlowLogsQuery("11111111-1111-1111-1111-111111111111", net.ParseIP("1.1.1.1"), 53)
+	assert.Equal(t, `{resource_id="11111111-1111-1111-1111-111111111111"} |~ "(^|[^0-9.])1\\.1\\.1\\.1([^0-9.]|$)" |~ "(^|[^0-9])53([^0-9]|$)"`, query)
+
+	port := regexp.MustCompile(`(^|[^0-9])53([^0-9]|$)`)
+	assert.True(t, port.MatchString("dst=1.1.1.1 dport=53"))
+	assert.False(t, port.MatchString("dst=1.1.1.1 dport=5353"))
+}
+
+func Test_queryGatewayFlowLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/loki/api/v1/query_range", r.URL.Path)
+		assert.Equal(t, "token", r.Header.Get("X-Token"))
+		assert.Equal(t, `{resource_id="gw"}`, r.URL.Query().Get("query"))
+		_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[
+{"stream":{"resource_id":"gw"},"values":[["3000000000","third\n"],["1000000000","first"]]},
+{"stream":{"resource_id":"gw","level":"info"},"values":[["2000000000","second"]]}
+]}}`))
+	}))
+	defer server.Close()
+
+	logs, err := queryGatewayFlowLogs(context.Background(), server.Client(), server.URL+"/", "token", `{resource_id="gw"}`, time.Unix(0, 0), time.Unix(10, 0), 2)
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+	assert.Equal(t, "second", logs[0].Line)
+	assert.Equal(t, "third", logs[1].Line)
+	assert.Equal(t, time.Unix(3, 0), logs[1].Time)
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/scaleway/scaleway-cli/v2/internal/core"
 	"github.com/scaleway/scaleway-cli/v2/internal/human"
 	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
-	"github.com/scaleway/scaleway-sdk-go/scw"
 )
 
 var (
@@ -29,7 +28,7 @@ func gatewayNetworkCreateBuilder(c *core.Command) *core.Command {
 		return api.WaitForGatewayNetwork(&vpcgw.WaitForGatewayNetworkRequest{
 			GatewayNetworkID: getResp.ID,
 			Zone:             getResp.Zone,
-			Timeout:          scw.TimeDurationPtr(gatewayActionTimeout),
+			Timeout:          core.WaitTimeout(ctx, gatewayActionTimeout),
 			RetryInterval:    core.DefaultRetryInterval,
 		})
 	}
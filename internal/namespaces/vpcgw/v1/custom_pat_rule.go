@@ -0,0 +1,251 @@
+package vpcgw
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/scaleway/scaleway-cli/v2/internal/interactive"
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	patRuleOperationCreate = "create"
+	patRuleOperationUpdate = "update"
+	patRuleOperationDelete = "delete"
+)
+
+type patRuleApplyRequest struct {
+	GatewayID string
+	File      string
+	DryRun    bool
+	Yes       bool
+	Zone      scw.Zone
+}
+
+// patRulesFile is the list of PAT rules of a gateway, the protocol of a rule is both when omitted.
+type patRulesFile struct {
+	PatRules []*vpcgw.SetPATRulesRequestRule `json:"pat_rules"`
+}
+
+// patRuleChange is a change needed to reconcile the PAT rules of a gateway with a file.
+type patRuleChange struct {
+	Operation   string
+	PublicPort  uint32
+	Protocol    vpcgw.PATRuleProtocol
+	PrivateIP   net.IP
+	PrivatePort uint32
+}
+
+func patRuleApplyCommand() *core.Command {
+	return &core.Command{
+		Short: `Apply a list of PAT rules from a file`,
+		Long: `Reconcile the PAT rules of a Public Gateway with a YAML or JSON file listing them under pat_rules.
+Rules are identified by their public port and protocol: missing rules are created, rules with another private IP or port are updated and rules absent from the file are deleted.
+The changes are applied at once with the set PAT rules API, they can be reviewed with dry-run=true.`,
+		Namespace: "vpc-gw",
+		Resource:  "pat-rule",
+		Verb:      "apply",
+		ArgsType:  reflect.TypeOf(patRuleApplyRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "gateway-id",
+				Short:      `ID of the gateway on which to apply the PAT rules`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:        "file",
+				Short:       `YAML or JSON file listing the PAT rules`,
+				Required:    true,
+				CanLoadFile: true,
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only display the changes`,
+			},
+			{
+				Name:  "yes",
+				Short: `Do not ask for confirmation`,
+			},
+			core.ZoneArgSpec(scw.ZoneFrPar1, scw.ZoneFrPar2, scw.ZoneNlAms1, scw.ZoneNlAms2, scw.ZoneNlAms3, scw.ZonePlWaw1, scw.ZonePlWaw2, scw.ZonePlWaw3),
+		},
+		Run: patRuleApplyRun,
+		View: &core.View{
+			Fields: []*core.ViewField{
+				{Label: "Operation", FieldName: "Operation"},
+				{Label: "Public Port", FieldName: "PublicPort"},
+				{Label: "Protocol", FieldName: "Protocol"},
+				{Label: "Private IP", FieldName: "PrivateIP"},
+				{Label: "Private Port", FieldName: "PrivatePort"},
+			},
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Display the changes needed to apply a list of PAT rules",
+				Raw:   "scw vpc-gw pat-rule apply 11111111-1111-1111-1111-111111111111 file=@rules.yaml dry-run=true",
+			},
+			{
+				Short: "Apply a list of PAT rules",
+				Raw:   "scw vpc-gw pat-rule apply 11111111-1111-1111-1111-111111111111 file=@rules.yaml",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "List PAT rules",
+				Command: "scw vpc-gw pat-rule list",
+			},
+		},
+	}
+}
+
+func patRuleApplyRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*patRuleApplyRequest)
+
+	desired, err := parsePATRulesFile([]byte(args.File))
+	if err != nil {
+		return nil, err
+	}
+
+	api := vpcgw.NewAPI(core.ExtractClient(ctx))
+	current, err := api.ListPATRules(&vpcgw.ListPATRulesRequest{
+		Zone:      args.Zone,
+		GatewayID: &args.GatewayID,
+	}, scw.WithAllPages(), scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	changes := planPATRuleChanges(current.PatRules, desired)
+	if args.DryRun {
+		return changes, nil
+	}
+	if len(changes) == 0 {
+		return &core.SuccessResult{Message: "PAT rules are already up to date"}, nil
+	}
+
+	if !args.Yes {
+		if !interactive.IsInteractive {
+			return nil, &core.CliError{
+				Err:  fmt.Errorf("applying PAT rules must be confirmed"),
+				Hint: "Review the changes with dry-run=true then use yes=true to confirm",
+			}
+		}
+
+		for _, change := range changes {
+			_, _ = interactive.Printf("%s %d/%s -> %s:%d\n", change.Operation, change.PublicPort, change.Protocol, change.PrivateIP, change.PrivatePort)
+		}
+		confirmed, err := interactive.PromptBoolWithConfig(&interactive.PromptBoolConfig{
+			Prompt:       "Do you want to apply these changes?",
+			DefaultValue: false,
+			Ctx:          ctx,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !confirmed {
+			return &core.SuccessResult{Message: "Changes canceled"}, nil
+		}
+	}
+
+	_, err = api.SetPATRules(&vpcgw.SetPATRulesRequest{
+		Zone:      args.Zone,
+		GatewayID: args.GatewayID,
+		PatRules:  desired,
+	}, scw.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &core.SuccessResult{
+		Message: fmt.Sprintf("%d changes applied to the PAT rules of gateway %s", len(changes), args.GatewayID),
+	}, nil
+}
+
+func parsePATRulesFile(content []byte) ([]*vpcgw.SetPATRulesRequestRule, error) {
+	file := &patRulesFile{}
+	err := yaml.Unmarshal(content, file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse the PAT rules: %s", err)
+	}
+
+	seen := map[string]bool{}
+	for i, rule := range file.PatRules {
+		if rule.Protocol == "" {
+			rule.Protocol = vpcgw.PATRuleProtocolBoth
+		}
+		switch {
+		case rule.PublicPort == 0 || rule.PrivatePort == 0:
+			return nil, fmt.Errorf("PAT rule %d: public_port and private_port are required", i)
+		case rule.PrivateIP == nil:
+			return nil, fmt.Errorf("PAT rule %d: private_ip is required", i)
+		}
+
+		key := patRuleKey(rule.PublicPort, rule.Protocol)
+		if seen[key] {
+			return nil, fmt.Errorf("PAT rule %d: public port %s is defined twice", i, key)
+		}
+		seen[key] = true
+	}
+
+	return file.PatRules, nil
+}
+
+// planPATRuleChanges returns the changes needed to go from the current PAT rules to the desired ones, sorted by public port.
+func planPATRuleChanges(current []*vpcgw.PATRule, desired []*vpcgw.SetPATRulesRequestRule) []*patRuleChange {
+	currentRules := make(map[string]*vpcgw.PATRule, len(current))
+	for _, rule := range current {
+		currentRules[patRuleKey(rule.PublicPort, rule.Protocol)] = rule
+	}
+
+	changes := []*patRuleChange(nil)
+	for _, rule := range desired {
+		key := patRuleKey(rule.PublicPort, rule.Protocol)
+		currentRule, exists := currentRules[key]
+		delete(currentRules, key)
+
+		change := &patRuleChange{
+			PublicPort:  rule.PublicPort,
+			Protocol:    rule.Protocol,
+			PrivateIP:   rule.PrivateIP,
+			PrivatePort: rule.PrivatePort,
+		}
+		switch {
+		case !exists:
+			change.Operation = patRuleOperationCreate
+		case !currentRule.PrivateIP.Equal(rule.PrivateIP) || currentRule.PrivatePort != rule.PrivatePort:
+			change.Operation = patRuleOperationUpdate
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	for _, rule := range currentRules {
+		changes = append(changes, &patRuleChange{
+			Operation:   patRuleOperationDelete,
+			PublicPort:  rule.PublicPort,
+			Protocol:    rule.Protocol,
+			PrivateIP:   rule.PrivateIP,
+			PrivatePort: rule.PrivatePort,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].PublicPort != changes[j].PublicPort {
+			return changes[i].PublicPort < changes[j].PublicPort
+		}
+		return changes[i].Protocol < changes[j].Protocol
+	})
+
+	return changes
+}
+
+func patRuleKey(publicPort uint32, protocol vpcgw.PATRuleProtocol) string {
+	return fmt.Sprintf("%d/%s", publicPort, protocol)
+}
@@ -0,0 +1,59 @@
+package vpcgw
+
+import (
+	"net"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/api/vpcgw/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_planPATRuleChanges(t *testing.T) {
+	desired, err := parsePATRulesFile([]byte(`
+pat_rules:
+  - public_port: 2222
+    private_ip: 192.168.1.10
+    private_port: 22
+    protocol: tcp
+  - public_port: 8080
+    private_ip: 192.168.1.11
+    private_port: 80
+  - public_port: 5353
+    private_ip: 192.168.1.12
+    private_port: 53
+    protocol: udp
+`))
+	require.NoError(t, err)
+	assert.Equal(t, vpcgw.PATRuleProtocolBoth, desired[1].Protocol)
+
+	current := []*vpcgw.PATRule{
+		{PublicPort: 2222, PrivateIP: net.ParseIP("192.168.1.10"), PrivatePort: 22, Protocol: vpcgw.PATRuleProtocolTCP},
+		{PublicPort: 8080, PrivateIP: net.ParseIP("192.168.1.20"), PrivatePort: 80, Protocol: vpcgw.PATRuleProtocolBoth},
+		{PublicPort: 3389, PrivateIP: net.ParseIP("192.168.1.30"), PrivatePort: 3389, Protocol: vpcgw.PATRuleProtocolTCP},
+	}
+
+	changes := planPATRuleChanges(current, desired)
+	operations := []string(nil)
+	for _, change := range changes {
+		operations = append(operations, patRuleKey(change.PublicPort, change.Protocol)+" "+change.Operation)
+	}
+	assert.Equal(t, []string{
+		"3389/tcp delete",
+		"5353/udp create",
+		"8080/both update",
+	}, operations)
+	assert.Equal(t, "192.168.1.11", changes[2].PrivateIP.String())
+}
+
+func Test_parsePATRulesFile(t *testing.T) {
+	_, err := parsePATRulesFile([]byte("pat_rules:\n  - public_port: 22\n    private_port: 22\n"))
+	assert.ErrorContains(t, err, "private_ip is required")
+
+	_, err = parsePATRulesFile([]byte(`
+pat_rules:
+  - {public_port: 22, private_ip: 10.0.0.1, private_port: 22}
+  - {public_port: 22, private_ip: 10.0.0.2, private_port: 22, protocol: both}
+`))
+	assert.ErrorContains(t, err, "22/both is defined twice")
+}
@@ -0,0 +1,24 @@
+package watcher
+
+import (
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		watcherRoot(),
+		watcherDaemonCommand(),
+	)
+}
+
+func watcherRoot() *core.Command {
+	return &core.Command{
+		Groups:    []string{"utility"},
+		Short:     `Declarative reconciliation commands`,
+		Namespace: "watcher",
+		ArgsType:  reflect.TypeOf(struct{}{}),
+		ArgSpecs:  core.ArgSpecs{},
+	}
+}
@@ -0,0 +1,327 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+const (
+	watcherEventReconciled = "reconciled"
+	watcherEventFailed     = "failed"
+	watcherEventSkipped    = "skipped"
+	watcherEventInvalid    = "invalid"
+)
+
+type watcherDaemonRequest struct {
+	Dir      string
+	Interval time.Duration
+	DryRun   bool
+	Once     bool
+}
+
+// watcherSpec is a command of the CLI run at each reconciliation, read from a file of the specs directory.
+type watcherSpec struct {
+	// Name is the name of the file of the spec
+	Name    string   `json:"-"`
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	// err is set when the file of the spec cannot be read
+	err error
+}
+
+// watcherEvent is logged as a JSON line for each spec at each reconciliation.
+type watcherEvent struct {
+	Time     time.Time       `json:"time"`
+	Spec     string          `json:"spec"`
+	Command  string          `json:"command,omitempty"`
+	Event    string          `json:"event"`
+	Duration string          `json:"duration,omitempty"`
+	ExitCode int             `json:"exit_code,omitempty"`
+	Error    string          `json:"error,omitempty"`
+	Result   json.RawMessage `json:"result,omitempty"`
+}
+
+// watcherRunFunc runs the CLI with the given arguments in a directory and returns its standard output and its exit code.
+type watcherRunFunc func(ctx context.Context, dir string, cmdArgs []string) ([]byte, int, error)
+
+// watcherDaemon reconciles the specs of a directory with the commands of the CLI.
+type watcherDaemon struct {
+	commands *core.Commands
+	// globalFlags are given to the CLI before the arguments of each spec
+	globalFlags []string
+	run         watcherRunFunc
+	logEvent    func(event *watcherEvent) error
+}
+
+func watcherDaemonCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  `Reconcile a directory of declarative specs on an interval`,
+		Long: `Run the commands described by a directory of spec files on an interval, to keep resources in line with declarative configurations like a lightweight GitOps agent.
+Each YAML or JSON file of the directory is a spec holding a command of the CLI, like lb config apply, iam policy apply or vpc-gw pat-rule apply, and its arguments. Files loaded with @ are relative to the directory and must be kept in a subdirectory, as all the YAML and JSON files of the directory are specs.
+The directory is read again at each reconciliation, so that changes to the specs are applied without restarting the daemon. Confirmations are skipped and the outcome of each spec is logged as a JSON line on the standard output.
+With dry-run=true, the changes are only reported and the specs of commands without dry-run are skipped.`,
+		Namespace: "watcher",
+		Resource:  "daemon",
+		ArgsType:  reflect.TypeOf(watcherDaemonRequest{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "dir",
+				Short:      `Directory of the spec files`,
+				Required:   true,
+				Positional: true,
+			},
+			{
+				Name:    "interval",
+				Short:   `Time between two reconciliations`,
+				Default: core.DefaultValueSetter("5m"),
+			},
+			{
+				Name:  "dry-run",
+				Short: `Only report the changes needed to reconcile the specs`,
+			},
+			{
+				Name:  "once",
+				Short: `Reconcile the specs once and exit, failing when a spec fails`,
+			},
+		},
+		Run: watcherDaemonRun,
+		Examples: []*core.Example{
+			{
+				Short: "Reconcile the specs of a directory every 5 minutes",
+				Raw:   "scw watcher daemon ./specs",
+			},
+			{
+				Short: "Check once that the specs of a directory are reconciled",
+				Raw:   "scw watcher daemon ./specs once=true dry-run=true",
+			},
+			{
+				Short: "Spec applying a load balancer configuration",
+				Raw: `command: lb config apply
+args: [11111111-1111-1111-1111-111111111111, file=@config/lb.yaml]`,
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Apply a configuration to a load balancer",
+				Command: "scw lb config apply",
+			},
+			{
+				Short:   "Apply IAM configuration files to an organization",
+				Command: "scw iam policy apply",
+			},
+			{
+				Short:   "Apply a list of PAT rules from a file",
+				Command: "scw vpc-gw pat-rule apply",
+			},
+		},
+	}
+}
+
+func watcherDaemonRun(ctx context.Context, argsI interface{}) (interface{}, error) {
+	args := argsI.(*watcherDaemonRequest)
+	if args.Interval <= 0 && !args.Once {
+		return nil, fmt.Errorf("interval must be greater than 0")
+	}
+
+	dir, err := filepath.Abs(args.Dir)
+	if err != nil {
+		return nil, err
+	}
+	args.Dir = dir
+
+	globalFlags := []string{"-o", "json"}
+	if profile := core.ExtractProfileFlag(ctx); profile != "" {
+		globalFlags = append(globalFlags, "-p", profile)
+	}
+	if configPath := core.ExtractConfigPathFlag(ctx); configPath != "" {
+		configPath, err = filepath.Abs(configPath)
+		if err != nil {
+			return nil, err
+		}
+		globalFlags = append(globalFlags, "-c", configPath)
+	}
+
+	encoder := json.NewEncoder(core.ExtractStdout(ctx))
+	daemon := &watcherDaemon{
+		commands:    core.ExtractCommands(ctx),
+		globalFlags: globalFlags,
+		run:         runCLI,
+		logEvent: func(event *watcherEvent) error {
+			return encoder.Encode(event)
+		},
+	}
+
+	for {
+		failures, err := daemon.reconcile(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		if args.Once {
+			if failures > 0 {
+				return nil, fmt.Errorf("%d specs failed to reconcile", failures)
+			}
+			return &core.SuccessResult{Empty: true}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return &core.SuccessResult{Empty: true}, nil
+		case <-time.After(args.Interval):
+		}
+	}
+}
+
+// runCLI runs the current binary of the CLI.
+func runCLI(ctx context.Context, dir string, cmdArgs []string) ([]byte, int, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		binary = core.ExtractBinaryName(ctx)
+	}
+	cmd := exec.CommandContext(ctx, binary, cmdArgs...) //nolint:gosec
+	cmd.Dir = dir
+	return core.ExecCmdOutput(ctx, cmd)
+}
+
+// reconcile runs the specs of the directory one after the other and logs an event for each of them.
+// It returns the number of specs that are invalid or failed.
+func (d *watcherDaemon) reconcile(ctx context.Context, args *watcherDaemonRequest) (int, error) {
+	specs, err := loadWatcherSpecs(args.Dir)
+	if err != nil {
+		return 1, d.logEvent(&watcherEvent{
+			Time:  time.Now(),
+			Spec:  args.Dir,
+			Event: watcherEventInvalid,
+			Error: err.Error(),
+		})
+	}
+
+	failures := 0
+	for _, spec := range specs {
+		if ctx.Err() != nil {
+			break
+		}
+		event := d.runSpec(ctx, args, spec)
+		if event.Event == watcherEventFailed || event.Event == watcherEventInvalid {
+			failures++
+		}
+		err := d.logEvent(event)
+		if err != nil {
+			return failures, err
+		}
+	}
+	return failures, nil
+}
+
+func (d *watcherDaemon) runSpec(ctx context.Context, args *watcherDaemonRequest, spec *watcherSpec) *watcherEvent {
+	event := &watcherEvent{
+		Time:    time.Now(),
+		Spec:    spec.Name,
+		Command: spec.Command,
+	}
+
+	cmdArgs, err := d.commandArgs(spec, args.DryRun)
+	if err != nil {
+		event.Event = watcherEventInvalid
+		event.Error = err.Error()
+		return event
+	}
+	if cmdArgs == nil {
+		event.Event = watcherEventSkipped
+		event.Error = "command has no dry-run argument"
+		return event
+	}
+
+	output, exitCode, err := d.run(ctx, args.Dir, cmdArgs)
+	event.Duration = time.Since(event.Time).Round(time.Millisecond).String()
+	event.ExitCode = exitCode
+	if json.Valid(output) {
+		event.Result = output
+	}
+	switch {
+	case err != nil:
+		event.Event = watcherEventFailed
+		event.Error = err.Error()
+	case exitCode != 0:
+		event.Event = watcherEventFailed
+		event.Error = fmt.Sprintf("command exited with code %d", exitCode)
+	default:
+		event.Event = watcherEventReconciled
+	}
+	return event
+}
+
+// commandArgs returns the arguments of the CLI running a spec, with confirmations skipped.
+// It returns nil when dry-run is requested and the command of the spec cannot only report its changes.
+func (d *watcherDaemon) commandArgs(spec *watcherSpec, dryRun bool) ([]string, error) {
+	if spec.err != nil {
+		return nil, spec.err
+	}
+	if spec.Command == "" {
+		return nil, fmt.Errorf("command is required")
+	}
+
+	path := strings.Fields(spec.Command)
+	cmd := d.commands.Find(path...)
+	if cmd == nil || cmd.Run == nil {
+		return nil, fmt.Errorf("unknown command %q", spec.Command)
+	}
+	if cmd.Namespace == "watcher" {
+		return nil, fmt.Errorf("watcher commands cannot be run by a spec")
+	}
+
+	cmdArgs := append(append(append([]string(nil), d.globalFlags...), path...), spec.Args...)
+	if dryRun {
+		if cmd.ArgSpecs.GetByName("dry-run") == nil {
+			return nil, nil
+		}
+		cmdArgs = append(cmdArgs, "dry-run=true")
+	}
+	if cmd.ArgSpecs.GetByName("yes") != nil {
+		cmdArgs = append(cmdArgs, "yes=true")
+	}
+	return cmdArgs, nil
+}
+
+// loadWatcherSpecs reads the YAML and JSON spec files of a directory in alphabetical order.
+// A file that cannot be read is returned as a spec with an error, so that the other specs are still reconciled.
+func loadWatcherSpecs(dir string) ([]*watcherSpec, error) {
+	files := []string(nil)
+	for _, pattern := range []string{"*.yaml", "*.yml", "*.json"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YAML or JSON file found in %s", dir)
+	}
+
+	specs := make([]*watcherSpec, 0, len(files))
+	for _, file := range files {
+		spec := &watcherSpec{}
+		content, err := os.ReadFile(file)
+		if err == nil {
+			err = yaml.Unmarshal(content, spec)
+		}
+		if err != nil {
+			spec.err = fmt.Errorf("cannot parse %s: %s", filepath.Base(file), err)
+		}
+		spec.Name = filepath.Base(file)
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
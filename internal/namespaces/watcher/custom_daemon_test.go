@@ -0,0 +1,161 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testApplyRequest struct {
+	File   string
+	DryRun bool
+	Yes    bool
+}
+
+func testCommands() *core.Commands {
+	return core.NewCommands(
+		&core.Command{
+			Namespace: "lb",
+			Resource:  "config",
+			Verb:      "apply",
+			ArgsType:  reflect.TypeOf(testApplyRequest{}),
+			ArgSpecs: core.ArgSpecs{
+				{Name: "file"},
+				{Name: "dry-run"},
+				{Name: "yes"},
+			},
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) { return nil, nil },
+		},
+		&core.Command{
+			Namespace: "iam",
+			Resource:  "policy",
+			Verb:      "apply",
+			ArgsType:  reflect.TypeOf(testApplyRequest{}),
+			ArgSpecs: core.ArgSpecs{
+				{Name: "yes"},
+			},
+			Run: func(_ context.Context, _ interface{}) (interface{}, error) { return nil, nil },
+		},
+		watcherDaemonCommand(),
+	)
+}
+
+func writeSpecs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	return dir
+}
+
+func Test_loadWatcherSpecs(t *testing.T) {
+	t.Run("Specs in alphabetical order", func(t *testing.T) {
+		dir := writeSpecs(t, map[string]string{
+			"b.yaml":    "command: iam policy apply\nargs: [./iam]\n",
+			"a.json":    `{"command": "lb config apply", "args": ["11111111-1111-1111-1111-111111111111", "file=@config/lb.yaml"]}`,
+			"notes.txt": "not a spec",
+			"c.yml":     "command: [",
+		})
+
+		specs, err := loadWatcherSpecs(dir)
+		require.NoError(t, err)
+		require.Len(t, specs, 3)
+		assert.Equal(t, &watcherSpec{Name: "a.json", Command: "lb config apply", Args: []string{"11111111-1111-1111-1111-111111111111", "file=@config/lb.yaml"}}, specs[0])
+		assert.Equal(t, &watcherSpec{Name: "b.yaml", Command: "iam policy apply", Args: []string{"./iam"}}, specs[1])
+		assert.Equal(t, "c.yml", specs[2].Name)
+		assert.Error(t, specs[2].err)
+	})
+
+	t.Run("Empty directory", func(t *testing.T) {
+		_, err := loadWatcherSpecs(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func Test_watcherDaemonCommandArgs(t *testing.T) {
+	daemon := &watcherDaemon{
+		commands:    testCommands(),
+		globalFlags: []string{"-o", "json"},
+	}
+
+	t.Run("Confirmation skipped", func(t *testing.T) {
+		cmdArgs, err := daemon.commandArgs(&watcherSpec{Command: "lb config apply", Args: []string{"lb-id", "file=@lb.yaml"}}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-o", "json", "lb", "config", "apply", "lb-id", "file=@lb.yaml", "yes=true"}, cmdArgs)
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		cmdArgs, err := daemon.commandArgs(&watcherSpec{Command: "lb config apply"}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-o", "json", "lb", "config", "apply", "dry-run=true", "yes=true"}, cmdArgs)
+	})
+
+	t.Run("Dry run not supported", func(t *testing.T) {
+		cmdArgs, err := daemon.commandArgs(&watcherSpec{Command: "iam policy apply"}, true)
+		require.NoError(t, err)
+		assert.Nil(t, cmdArgs)
+	})
+
+	t.Run("Invalid specs", func(t *testing.T) {
+		for _, spec := range []*watcherSpec{
+			{},
+			{Command: "lb config import"},
+			{Command: "watcher daemon"},
+			{Command: "lb config apply", err: errors.New("cannot parse")},
+		} {
+			_, err := daemon.commandArgs(spec, false)
+			assert.Error(t, err, spec.Command)
+		}
+	})
+}
+
+func Test_watcherDaemonReconcile(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"1-lb.yaml":     "command: lb config apply\nargs: [lb-id]\n",
+		"2-iam.yaml":    "command: iam policy apply\n",
+		"3-broken.yaml": "command: unknown\n",
+	})
+
+	events := []*watcherEvent(nil)
+	runs := [][]string(nil)
+	daemon := &watcherDaemon{
+		commands: testCommands(),
+		run: func(_ context.Context, runDir string, cmdArgs []string) ([]byte, int, error) {
+			assert.Equal(t, dir, runDir)
+			runs = append(runs, cmdArgs)
+			if cmdArgs[0] == "iam" {
+				return []byte("not json"), 1, nil
+			}
+			return []byte(`{"message": "Load balancer is already up to date"}`), 0, nil
+		},
+		logEvent: func(event *watcherEvent) error {
+			events = append(events, event)
+			return nil
+		},
+	}
+
+	failures, err := daemon.reconcile(context.Background(), &watcherDaemonRequest{Dir: dir})
+	require.NoError(t, err)
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, [][]string{
+		{"lb", "config", "apply", "lb-id", "yes=true"},
+		{"iam", "policy", "apply", "yes=true"},
+	}, runs)
+
+	require.Len(t, events, 3)
+	assert.Equal(t, watcherEventReconciled, events[0].Event)
+	assert.JSONEq(t, `{"message": "Load balancer is already up to date"}`, string(events[0].Result))
+	assert.Equal(t, watcherEventFailed, events[1].Event)
+	assert.Equal(t, 1, events[1].ExitCode)
+	assert.Nil(t, events[1].Result)
+	assert.Equal(t, watcherEventInvalid, events[2].Event)
+	assert.Equal(t, "3-broken.yaml", events[2].Spec)
+}
@@ -0,0 +1,59 @@
+package wizard
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+func GetCommands() *core.Commands {
+	return core.NewCommands(
+		wizardCommand(),
+	)
+}
+
+// wizardArgs are prompted by core.Bootstrap, they are declared here for the usage and the documentation.
+type wizardArgs struct {
+	Command string
+}
+
+func wizardCommand() *core.Command {
+	return &core.Command{
+		Groups: []string{"utility"},
+		Short:  "Build a command interactively",
+		Long: `Prompt the arguments of a command one by one, then print and run the resulting command line.
+Required arguments are prompted first, optional arguments are prompted when asked for. Values are validated as on the command line and the choices of enums are listed.
+Arguments given after the command are kept and not prompted.`,
+		Namespace:            "wizard",
+		AllowAnonymousClient: true,
+		ArgsType:             reflect.TypeOf(wizardArgs{}),
+		ArgSpecs: core.ArgSpecs{
+			{
+				Name:       "command",
+				Short:      "Path of the command to build, such as instance server create",
+				Required:   true,
+				Positional: true,
+			},
+		},
+		Run: func(_ context.Context, _ interface{}) (interface{}, error) {
+			return nil, nil
+		},
+		Examples: []*core.Example{
+			{
+				Short: "Build a command creating a server",
+				Raw:   "scw wizard instance server create",
+			},
+			{
+				Short: "Build a command creating a server in Amsterdam",
+				Raw:   "scw wizard instance server create zone=nl-ams-1",
+			},
+		},
+		SeeAlsos: []*core.SeeAlso{
+			{
+				Short:   "Start shell mode",
+				Command: "scw shell",
+			},
+		},
+	}
+}
@@ -0,0 +1,76 @@
+package passwordgenerator
+
+import (
+	"fmt"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/clipboard"
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
+)
+
+const (
+	// MaskedPassword replaces a generated password copied to the clipboard in the result of a command.
+	MaskedPassword = core.SensitiveMask
+
+	defaultPasswordLength = 21
+	minPasswordLength     = 8
+)
+
+// Args are the arguments of the commands which can generate a password, they are embedded in the arguments of the command.
+type Args struct {
+	GeneratePassword bool
+	PasswordLength   uint32
+	PasswordCharset  string
+	Copy             bool
+}
+
+// ArgSpecs returns the specs of Args, to add before the password argument of a command.
+func ArgSpecs() core.ArgSpecs {
+	return core.ArgSpecs{
+		{
+			Name:    "generate-password",
+			Short:   `Will generate a password that contains a mix of upper/lower case letters, numbers and special symbols`,
+			Default: core.DefaultValueSetter("true"),
+		},
+		{
+			Name:    "password-length",
+			Short:   `Length of the generated password`,
+			Default: core.DefaultValueSetter(fmt.Sprint(defaultPasswordLength)),
+		},
+		{
+			Name:       "password-charset",
+			Short:      `Characters of the generated password, url-safe only uses symbols which do not need to be escaped in connection strings`,
+			Default:    core.DefaultValueSetter(CharsetAll),
+			EnumValues: Charsets,
+		},
+		{
+			Name:  "copy",
+			Short: `Copy the generated password to the clipboard instead of displaying it`,
+		},
+	}
+}
+
+// Generate generates a password and prints it, or copies it to the clipboard with Copy.
+// The password to display in the result of the command is returned as well, it is masked when the password is copied.
+func Generate(args *Args) (password string, displayedPassword string, err error) {
+	if args.PasswordLength < minPasswordLength {
+		return "", "", fmt.Errorf("password-length must be at least %d", minPasswordLength)
+	}
+
+	password, err = GeneratePasswordWithCharset(int(args.PasswordLength), args.PasswordCharset)
+	if err != nil {
+		return "", "", err
+	}
+
+	if !args.Copy {
+		fmt.Printf("Your generated password is %s \n", password)
+		fmt.Printf("\n")
+		return password, password, nil
+	}
+
+	if err := clipboard.Write(password); err != nil {
+		return "", "", err
+	}
+	fmt.Printf("Your generated password has been copied to the clipboard\n")
+	fmt.Printf("\n")
+	return password, MaskedPassword, nil
+}
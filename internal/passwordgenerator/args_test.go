@@ -0,0 +1,34 @@
+package passwordgenerator
+
+import (
+	"testing"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/clipboard"
+)
+
+func TestGenerate(t *testing.T) {
+	password, displayedPassword, err := Generate(&Args{PasswordLength: 16, PasswordCharset: CharsetAll})
+	if err != nil {
+		t.Fatalf("Error generating password: %v", err)
+	}
+	if len(password) != 16 || displayedPassword != password {
+		t.Fatalf("Generated password %q is not displayed as is", password)
+	}
+
+	clipboard.MockInit()
+	password, displayedPassword, err = Generate(&Args{PasswordLength: 16, PasswordCharset: CharsetURLSafe, Copy: true})
+	if err != nil {
+		t.Fatalf("Error generating password: %v", err)
+	}
+	if displayedPassword != MaskedPassword {
+		t.Fatalf("Copied password is displayed as %q", displayedPassword)
+	}
+	if clipboard.MockContent() != password {
+		t.Fatalf("Generated password has not been copied to the clipboard")
+	}
+
+	_, _, err = Generate(&Args{PasswordLength: 4})
+	if err == nil {
+		t.Fatalf("Expected an error for a too short password")
+	}
+}
@@ -3,6 +3,7 @@ package passwordgenerator
 import (
 	crypto "crypto/rand"
 	"errors"
+	"fmt"
 	"math/big"
 	"strings"
 )
@@ -12,10 +13,36 @@ const (
 	lowerLetters   = "abcdedfghijklmnopqrstuvwxyz"
 	upperLetters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
 	specialSymbols = "!$%^&*()_+{}:@[];'#<>?,./|\\\\-=?"
-	allSet         = lowerLetters + upperLetters + specialSymbols + numbers
+	// urlSafeSymbols are the symbols which do not need to be escaped in URLs, such as connection strings.
+	urlSafeSymbols = "-_.~"
 )
 
+// Charsets of the generated passwords.
+const (
+	CharsetAll     = "all"
+	CharsetURLSafe = "url-safe"
+)
+
+// Charsets lists the charsets supported by GeneratePasswordWithCharset.
+var Charsets = []string{CharsetAll, CharsetURLSafe}
+
 func GeneratePassword(length, minNumbers, minLower, minUpper, minSymbol int) (string, error) {
+	return generatePassword(specialSymbols, length, minNumbers, minLower, minUpper, minSymbol)
+}
+
+// GeneratePasswordWithCharset generates a password of length characters with at least a number, a lower case letter, an upper case letter and a symbol of charset.
+func GeneratePasswordWithCharset(length int, charset string) (string, error) {
+	switch charset {
+	case CharsetAll, "":
+		return generatePassword(specialSymbols, length, 1, 1, 1, 1)
+	case CharsetURLSafe:
+		return generatePassword(urlSafeSymbols, length, 1, 1, 1, 1)
+	default:
+		return "", fmt.Errorf("unknown password charset %s, must be one of %s", charset, strings.Join(Charsets, ", "))
+	}
+}
+
+func generatePassword(symbols string, length, minNumbers, minLower, minUpper, minSymbol int) (string, error) {
 	if length < (minNumbers + minLower + minUpper + minSymbol) {
 		return "", errors.New("length is less than the sum of minNumbers, minLower, minUpper, and minSymbol")
 	}
@@ -47,13 +74,14 @@ func GeneratePassword(length, minNumbers, minLower, minUpper, minSymbol int) (st
 	}
 
 	for i := 0; i < minSymbol; i++ {
-		random, err := randInt(len(specialSymbols))
+		random, err := randInt(len(symbols))
 		if err != nil {
 			return "", err
 		}
-		password.WriteString(string(specialSymbols[random]))
+		password.WriteString(string(symbols[random]))
 	}
 
+	allSet := lowerLetters + upperLetters + symbols + numbers
 	remainingLength := length - minNumbers - minLower - minUpper - minSymbol
 	for i := 0; i < remainingLength; i++ {
 		random, err := randInt(len(allSet))
@@ -57,3 +57,24 @@ func isPassword(s string) bool {
 	}
 	return hasNumber && hasUpperCase && hasLowercase && hasSymbol && hasLength >= minLength
 }
+
+func TestPasswordWithCharset(t *testing.T) {
+	password, err := GeneratePasswordWithCharset(32, CharsetURLSafe)
+	if err != nil {
+		t.Fatalf("Error generating password: %v", err)
+	}
+	if len(password) != 32 {
+		t.Fatalf("Generated password has %d characters instead of 32", len(password))
+	}
+	for _, value := range password {
+		isAlphanumeric := (value >= '0' && value <= '9') || (value >= 'A' && value <= 'Z') || (value >= 'a' && value <= 'z')
+		if !isAlphanumeric && !strings.ContainsRune(urlSafeSymbols, value) {
+			t.Fatalf("Generated password contains %q which is not url-safe", value)
+		}
+	}
+
+	_, err = GeneratePasswordWithCharset(21, "unknown")
+	if err == nil {
+		t.Fatalf("Expected an error for an unknown charset")
+	}
+}
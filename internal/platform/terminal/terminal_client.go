@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/scaleway/scaleway-cli/v2/internal/keyring"
 	"github.com/scaleway/scaleway-cli/v2/internal/platform"
 	"github.com/scaleway/scaleway-sdk-go/logger"
 	"github.com/scaleway/scaleway-sdk-go/scw"
@@ -40,6 +41,16 @@ func (p *Platform) CreateClient(httpClient *http.Client, configPath string, prof
 			return nil, err
 		}
 
+		// The secret key is read from the keyring only when it is not overridden by the environment
+		if profile.SecretKey == nil {
+			err = keyring.ResolveSecretKey(activeProfile)
+			if err != nil {
+				return nil, err
+			}
+		} else if keyring.IsSecretKeyRef(activeProfile.SecretKey) {
+			activeProfile.SecretKey = profile.SecretKey
+		}
+
 		// Creates a client from the active profile
 		// It will trigger a validation step on its configuration to catch errors if any
 		opts := []scw.ClientOption{
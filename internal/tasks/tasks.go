@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/signal"
 	"reflect"
+
+	"github.com/scaleway/scaleway-cli/v2/internal/core"
 )
 
 type TaskFunc[T any, U any] func(t *Task, args T) (nextArgs U, err error)
@@ -135,6 +137,7 @@ func (ts *Tasks) Execute(ctx context.Context, data interface{}) (interface{}, er
 		loggerEntry := logger.AddEntry(task.Name)
 		task.Logs = loggerEntry.Logs
 		loggerEntry.Start()
+		ts.reportProgress(ctx, task, i, core.ProgressStateStarted, nil)
 
 		// Add context and reset cleanup functions, allows to execute multiple times
 		task.Ctx = cancelableCtx
@@ -143,6 +146,7 @@ func (ts *Tasks) Execute(ctx context.Context, data interface{}) (interface{}, er
 		data, err = task.taskFunction(task, data)
 		if err != nil {
 			loggerEntry.Complete(err)
+			ts.reportProgress(ctx, task, i, core.ProgressStateFailed, err)
 			ts.Cleanup(ctx, logger, i)
 
 			return nil, fmt.Errorf("task %d %q failed: %w", i+1, task.Name, err)
@@ -151,13 +155,29 @@ func (ts *Tasks) Execute(ctx context.Context, data interface{}) (interface{}, er
 		select {
 		case <-ctx.Done():
 			loggerEntry.Complete(context.Canceled)
+			ts.reportProgress(ctx, task, i, core.ProgressStateFailed, context.Canceled)
 			ts.Cleanup(ctx, logger, i)
 			return nil, fmt.Errorf("task %d %q failed: context canceled", i+1, task.Name)
 		default:
 		}
 
 		loggerEntry.Complete(nil)
+		ts.reportProgress(ctx, task, i+1, core.ProgressStateSucceeded, nil)
 	}
 
 	return data, nil
 }
+
+// reportProgress reports the state of a task with the percentage of tasks done, it is printed with --progress=json.
+func (ts *Tasks) reportProgress(ctx context.Context, task *Task, done int, state core.ProgressState, err error) {
+	percent := float64(done) * 100 / float64(len(ts.tasks))
+	event := &core.ProgressEvent{
+		Step:    task.Name,
+		State:   state,
+		Percent: &percent,
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+	core.ReportProgress(ctx, event)
+}
@@ -0,0 +1,55 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// PutTarget uploads a file with a single PUT request to a URL, such as a presigned upload URL.
+// The file must be uploaded in a single part, with a negative Config.PartSize:
+// a failed request is retried but an interrupted upload starts over.
+type PutTarget struct {
+	HTTPClient *http.Client
+	URL        string
+	Header     http.Header
+}
+
+func (t *PutTarget) ID() string {
+	return t.URL
+}
+
+func (t *PutTarget) Start(_ context.Context) (string, error) {
+	return "", nil
+}
+
+func (t *PutTarget) UploadPart(ctx context.Context, _ string, part *Part, body io.ReadSeeker) (string, error) {
+	if part.Number != 1 || part.Offset != 0 {
+		return "", fmt.Errorf("cannot upload part %d, the file must be uploaded in a single part", part.Number)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, t.URL, body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = part.Size
+	for name, values := range t.Header {
+		req.Header[name] = values
+	}
+
+	resp, err := t.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("upload failed with status %s", resp.Status)
+	}
+	return resp.Header.Get("ETag"), nil
+}
+
+func (t *PutTarget) Complete(_ context.Context, _ string, _ []*Part) error {
+	return nil
+}
@@ -0,0 +1,376 @@
+// Package upload uploads large files in parts, in parallel, retrying failed parts.
+// The uploaded parts are recorded in a manifest on disk so that an interrupted upload is resumed
+// instead of starting over.
+package upload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultPartSize      = int64(16 * 1024 * 1024)
+	DefaultConcurrency   = 4
+	DefaultMaxAttempts   = 3
+	DefaultRetryInterval = time.Second
+)
+
+// Part is a part of a file, uploaded or to upload.
+type Part struct {
+	Number int   `json:"number"`
+	Offset int64 `json:"offset"`
+	Size   int64 `json:"size"`
+	// Checksum is the hex encoded SHA-256 of the part
+	Checksum string `json:"checksum"`
+	// ETag is returned by the target once the part is uploaded
+	ETag string `json:"etag"`
+}
+
+// Target is the destination of an upload.
+type Target interface {
+	// ID identifies the destination, an upload is only resumed for the same file and the same ID.
+	ID() string
+	// Start starts a new upload and returns its ID.
+	Start(ctx context.Context) (string, error)
+	// UploadPart uploads a part and returns its ETag. Its Checksum is set.
+	UploadPart(ctx context.Context, uploadID string, part *Part, body io.ReadSeeker) (string, error)
+	// Complete completes an upload from its parts, sorted by number.
+	Complete(ctx context.Context, uploadID string, parts []*Part) error
+}
+
+// Config is the configuration of an upload, zero values are replaced by the default ones.
+type Config struct {
+	// PartSize is the size of the parts, the file is uploaded in a single part when negative
+	PartSize    int64
+	Concurrency int
+	// MaxAttempts is the number of attempts to upload a part before the upload fails
+	MaxAttempts int
+	// RetryInterval is the delay before the first retry of a part, doubled at each retry
+	RetryInterval time.Duration
+	// ManifestDir is the directory of the resume manifests, uploads cannot be resumed when it is empty
+	ManifestDir string
+	// Progress is called each time a part is uploaded
+	Progress func(uploaded int64, total int64)
+}
+
+// Result is the summary of a completed upload.
+type Result struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	UploadID string `json:"upload_id"`
+	Parts    int    `json:"parts"`
+	// ResumedParts is the number of parts uploaded by a previous interrupted upload
+	ResumedParts int `json:"resumed_parts"`
+}
+
+// manifest records the parts of an upload uploaded so far.
+type manifest struct {
+	Path     string    `json:"path"`
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"mod_time"`
+	TargetID string    `json:"target_id"`
+	PartSize int64     `json:"part_size"`
+	UploadID string    `json:"upload_id"`
+	Parts    []*Part   `json:"parts"`
+}
+
+// File uploads a file to a target.
+// When a manifest of an interrupted upload of the same file to the same target exists,
+// the parts already uploaded are checked against their checksum and skipped.
+func File(ctx context.Context, path string, target Target, config *Config) (*Result, error) {
+	config = withDefaults(config)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	partSize := config.PartSize
+	if partSize < 0 || partSize > stat.Size() {
+		partSize = stat.Size()
+	}
+
+	manifestPath := ""
+	if config.ManifestDir != "" {
+		manifestPath = filepath.Join(config.ManifestDir, manifestFileName(path, target.ID()))
+	}
+
+	m := readManifest(manifestPath)
+	if m == nil || !m.matches(path, stat, target.ID(), partSize) || !partsUnchanged(file, m.Parts) {
+		uploadID, err := target.Start(ctx)
+		if err != nil {
+			return nil, err
+		}
+		m = &manifest{
+			Path:     path,
+			Size:     stat.Size(),
+			ModTime:  stat.ModTime(),
+			TargetID: target.ID(),
+			PartSize: partSize,
+			UploadID: uploadID,
+		}
+		if err := m.write(manifestPath); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &Result{
+		Path:         path,
+		Size:         stat.Size(),
+		UploadID:     m.UploadID,
+		ResumedParts: len(m.Parts),
+	}
+
+	uploaded := map[int]bool{}
+	uploadedSize := int64(0)
+	for _, part := range m.Parts {
+		uploaded[part.Number] = true
+		uploadedSize += part.Size
+	}
+	remainingParts := []*Part(nil)
+	for _, part := range splitParts(stat.Size(), partSize) {
+		if !uploaded[part.Number] {
+			remainingParts = append(remainingParts, part)
+		}
+	}
+	result.Parts = len(m.Parts) + len(remainingParts)
+
+	u := &uploader{
+		file:         file,
+		target:       target,
+		config:       config,
+		manifest:     m,
+		manifestPath: manifestPath,
+		uploadedSize: uploadedSize,
+	}
+	if err := u.uploadParts(ctx, remainingParts); err != nil {
+		return nil, err
+	}
+
+	parts := append([]*Part(nil), m.Parts...)
+	sort.Slice(parts, func(i, j int) bool {
+		return parts[i].Number < parts[j].Number
+	})
+	if err := target.Complete(ctx, m.UploadID, parts); err != nil {
+		return nil, err
+	}
+
+	if manifestPath != "" {
+		if err := os.Remove(manifestPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func withDefaults(config *Config) *Config {
+	c := Config{}
+	if config != nil {
+		c = *config
+	}
+	if c.PartSize == 0 {
+		c.PartSize = DefaultPartSize
+	}
+	if c.Concurrency <= 0 {
+		c.Concurrency = DefaultConcurrency
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultMaxAttempts
+	}
+	if c.RetryInterval == 0 {
+		c.RetryInterval = DefaultRetryInterval
+	}
+	return &c
+}
+
+// splitParts returns the parts of a file, numbered from 1. An empty file has a single empty part.
+func splitParts(size int64, partSize int64) []*Part {
+	if size == 0 || partSize <= 0 {
+		return []*Part{{Number: 1, Size: size}}
+	}
+
+	parts := []*Part(nil)
+	for offset := int64(0); offset < size; offset += partSize {
+		partLength := partSize
+		if offset+partLength > size {
+			partLength = size - offset
+		}
+		parts = append(parts, &Part{
+			Number: len(parts) + 1,
+			Offset: offset,
+			Size:   partLength,
+		})
+	}
+	return parts
+}
+
+type uploader struct {
+	file   *os.File
+	target Target
+	config *Config
+
+	mu           sync.Mutex
+	manifest     *manifest
+	manifestPath string
+	uploadedSize int64
+}
+
+// uploadParts uploads parts with config.Concurrency workers, the first error stops the upload.
+func (u *uploader) uploadParts(ctx context.Context, parts []*Part) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partsChan := make(chan *Part)
+	errs := make(chan error, len(parts))
+	wg := sync.WaitGroup{}
+	for i := 0; i < u.config.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for part := range partsChan {
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := u.uploadPart(ctx, part); err != nil {
+					errs <- err
+					cancel()
+				}
+			}
+		}()
+	}
+
+	for _, part := range parts {
+		select {
+		case partsChan <- part:
+		case <-ctx.Done():
+		}
+	}
+	close(partsChan)
+	wg.Wait()
+	close(errs)
+
+	if err, hasErr := <-errs; hasErr {
+		return err
+	}
+	return ctx.Err()
+}
+
+// uploadPart uploads a part, retrying with an exponential backoff, and records it in the manifest.
+func (u *uploader) uploadPart(ctx context.Context, part *Part) error {
+	checksum, err := checksumPart(u.file, part)
+	if err != nil {
+		return err
+	}
+	part.Checksum = checksum
+
+	interval := u.config.RetryInterval
+	for attempt := 1; ; attempt++ {
+		part.ETag, err = u.target.UploadPart(ctx, u.manifest.UploadID, part, io.NewSectionReader(u.file, part.Offset, part.Size))
+		if err == nil {
+			break
+		}
+		if attempt >= u.config.MaxAttempts || ctx.Err() != nil {
+			return fmt.Errorf("failed to upload part %d after %d attempts: %w", part.Number, attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.manifest.Parts = append(u.manifest.Parts, part)
+	u.uploadedSize += part.Size
+	if u.config.Progress != nil {
+		u.config.Progress(u.uploadedSize, u.manifest.Size)
+	}
+	return u.manifest.write(u.manifestPath)
+}
+
+func checksumPart(file io.ReaderAt, part *Part) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(file, part.Offset, part.Size)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// partsUnchanged returns true when the parts of the file still match their checksum.
+func partsUnchanged(file io.ReaderAt, parts []*Part) bool {
+	for _, part := range parts {
+		checksum, err := checksumPart(file, part)
+		if err != nil || checksum != part.Checksum {
+			return false
+		}
+	}
+	return true
+}
+
+// manifestFileName returns the name of the manifest of the upload of a file to a target.
+func manifestFileName(path string, targetID string) string {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		absPath = path
+	}
+	h := sha256.Sum256([]byte(absPath + "\n" + targetID))
+	return hex.EncodeToString(h[:16]) + ".json"
+}
+
+// readManifest returns the manifest stored at path, nil when there is none or when it cannot be read.
+func readManifest(path string) *manifest {
+	if path == "" {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	m := &manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil
+	}
+	return m
+}
+
+func (m *manifest) matches(path string, stat os.FileInfo, targetID string, partSize int64) bool {
+	return m.Path == path &&
+		m.Size == stat.Size() &&
+		m.ModTime.Equal(stat.ModTime()) &&
+		m.TargetID == targetID &&
+		m.PartSize == partSize &&
+		m.UploadID != ""
+}
+
+func (m *manifest) write(path string) error {
+	if path == "" {
+		return nil
+	}
+	content, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
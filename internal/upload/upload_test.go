@@ -0,0 +1,179 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTarget assembles the uploaded parts in memory.
+type memoryTarget struct {
+	mu       sync.Mutex
+	uploads  int
+	parts    map[int][]byte
+	attempts map[int]int
+	// failures is the number of times the upload of a part fails before succeeding
+	failures map[int]int
+	content  []byte
+}
+
+func newMemoryTarget() *memoryTarget {
+	return &memoryTarget{
+		parts:    map[int][]byte{},
+		attempts: map[int]int{},
+		failures: map[int]int{},
+	}
+}
+
+func (t *memoryTarget) ID() string {
+	return "memory"
+}
+
+func (t *memoryTarget) Start(_ context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.uploads++
+	t.parts = map[int][]byte{}
+	return fmt.Sprintf("upload-%d", t.uploads), nil
+}
+
+func (t *memoryTarget) UploadPart(_ context.Context, _ string, part *Part, body io.ReadSeeker) (string, error) {
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.attempts[part.Number]++
+	if t.failures[part.Number] > 0 {
+		t.failures[part.Number]--
+		return "", errors.New("connection reset by peer")
+	}
+	t.parts[part.Number] = content
+	return fmt.Sprintf("etag-%d", part.Number), nil
+}
+
+func (t *memoryTarget) Complete(_ context.Context, _ string, parts []*Part) error {
+	t.content = nil
+	for i, part := range parts {
+		if part.Number != i+1 {
+			return fmt.Errorf("unexpected part %d at index %d", part.Number, i)
+		}
+		t.content = append(t.content, t.parts[part.Number]...)
+	}
+	return nil
+}
+
+func writeTestFile(t *testing.T, size int) (string, []byte) {
+	t.Helper()
+	content := bytes.Repeat([]byte("0123456789"), size/10+1)[:size]
+	path := filepath.Join(t.TempDir(), "file.bin")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path, content
+}
+
+func Test_File(t *testing.T) {
+	path, content := writeTestFile(t, 105)
+	target := newMemoryTarget()
+	target.failures[2] = 1
+
+	progress := []int64(nil)
+	result, err := File(context.Background(), path, target, &Config{
+		PartSize:      10,
+		Concurrency:   3,
+		RetryInterval: -1,
+		Progress: func(uploaded int64, total int64) {
+			assert.Equal(t, int64(105), total)
+			progress = append(progress, uploaded)
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 11, result.Parts)
+	assert.Equal(t, 0, result.ResumedParts)
+	assert.Equal(t, content, target.content)
+	assert.Equal(t, 2, target.attempts[2], "failed parts must be retried")
+	assert.Len(t, progress, 11)
+	assert.Equal(t, int64(105), progress[len(progress)-1])
+}
+
+func Test_File_Resume(t *testing.T) {
+	path, content := writeTestFile(t, 100)
+	manifestDir := t.TempDir()
+	target := newMemoryTarget()
+	target.failures[5] = 1
+
+	config := &Config{
+		PartSize:      10,
+		Concurrency:   1,
+		MaxAttempts:   1,
+		RetryInterval: -1,
+		ManifestDir:   manifestDir,
+	}
+	_, err := File(context.Background(), path, target, config)
+	require.Error(t, err)
+	manifests, _ := os.ReadDir(manifestDir)
+	require.Len(t, manifests, 1, "the manifest of an interrupted upload must be kept")
+
+	result, err := File(context.Background(), path, target, config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, target.uploads, "the interrupted upload must be resumed")
+	assert.Equal(t, 4, result.ResumedParts)
+	assert.Equal(t, 1, target.attempts[1], "uploaded parts must not be uploaded again")
+	assert.Equal(t, content, target.content)
+	manifests, _ = os.ReadDir(manifestDir)
+	assert.Empty(t, manifests, "the manifest of a completed upload must be removed")
+}
+
+func Test_File_ResumeModifiedFile(t *testing.T) {
+	path, _ := writeTestFile(t, 100)
+	manifestDir := t.TempDir()
+	target := newMemoryTarget()
+	target.failures[5] = 1
+
+	config := &Config{
+		PartSize:      10,
+		Concurrency:   1,
+		MaxAttempts:   1,
+		RetryInterval: -1,
+		ManifestDir:   manifestDir,
+	}
+	_, err := File(context.Background(), path, target, config)
+	require.Error(t, err)
+
+	newContent := bytes.Repeat([]byte("a"), 100)
+	require.NoError(t, os.WriteFile(path, newContent, 0o600))
+
+	result, err := File(context.Background(), path, target, config)
+	require.NoError(t, err)
+	assert.Equal(t, 2, target.uploads, "a modified file must be uploaded again")
+	assert.Equal(t, 0, result.ResumedParts)
+	assert.Equal(t, newContent, target.content)
+}
+
+func Test_File_SinglePart(t *testing.T) {
+	path, content := writeTestFile(t, 100)
+	target := newMemoryTarget()
+
+	result, err := File(context.Background(), path, target, &Config{PartSize: -1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Parts)
+	assert.Equal(t, content, target.content)
+}
+
+func Test_splitParts(t *testing.T) {
+	parts := splitParts(25, 10)
+	require.Len(t, parts, 3)
+	assert.Equal(t, &Part{Number: 3, Offset: 20, Size: 5}, parts[2])
+
+	assert.Equal(t, []*Part{{Number: 1, Size: 0}}, splitParts(0, 10))
+}
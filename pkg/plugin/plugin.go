@@ -0,0 +1,60 @@
+// Package plugin helps writing plugins of the Scaleway CLI.
+//
+// Any executable named scw-<name> in the PATH is run by the CLI as scw <name>, with the arguments following the name.
+// The CLI passes the profile and the credentials to the plugin in the environment, with the SCW_* variables read by the SDK:
+// clients created with NewClient use the same profile as the CLI command.
+package plugin
+
+import (
+	"errors"
+	"os"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+)
+
+const (
+	// ExecutablePrefix is the prefix of the executables of plugins.
+	ExecutablePrefix = "scw-"
+
+	// NameEnv is set to the name of the plugin when it is run by the CLI.
+	NameEnv = "SCW_PLUGIN_NAME"
+	// CLIVersionEnv is set to the version of the CLI running the plugin.
+	CLIVersionEnv = "SCW_CLI_VERSION"
+)
+
+// IsRunByCLI returns whether the plugin is run by the CLI, as opposed to run directly.
+func IsRunByCLI() bool {
+	return os.Getenv(NameEnv) != ""
+}
+
+// Name returns the name of the plugin as run by the CLI, scw-<name> is run for scw <name>.
+func Name() string {
+	return os.Getenv(NameEnv)
+}
+
+// CLIVersion returns the version of the CLI running the plugin.
+func CLIVersion() string {
+	return os.Getenv(CLIVersionEnv)
+}
+
+// NewClient creates an SDK client using the profile and the credentials of the CLI.
+// The active profile of the config file is loaded first, then overridden by the environment set by the CLI.
+func NewClient(opts ...scw.ClientOption) (*scw.Client, error) {
+	profile := &scw.Profile{}
+	config, err := scw.LoadConfig()
+	// The config file is optional as the CLI passes the credentials in the environment
+	if err == nil {
+		activeProfile, err := config.GetActiveProfile()
+		if err != nil {
+			return nil, err
+		}
+		profile = activeProfile
+	} else if notFoundErr := (*scw.ConfigFileNotFoundError)(nil); !errors.As(err, &notFoundErr) {
+		return nil, err
+	}
+
+	return scw.NewClient(append([]scw.ClientOption{
+		scw.WithProfile(profile),
+		scw.WithEnv(),
+	}, opts...)...)
+}
@@ -0,0 +1,28 @@
+package plugin
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/scaleway/scaleway-sdk-go/scw"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Setenv(scw.ScwConfigPathEnv, filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv(NameEnv, "hello")
+	t.Setenv(scw.ScwAccessKeyEnv, "SCWXXXXXXXXXXXXXXXXX")
+	t.Setenv(scw.ScwSecretKeyEnv, "11111111-1111-1111-1111-111111111111")
+	t.Setenv(scw.ScwDefaultZoneEnv, "nl-ams-1")
+
+	assert.True(t, IsRunByCLI())
+	assert.Equal(t, "hello", Name())
+
+	client, err := NewClient()
+	require.NoError(t, err)
+	accessKey, _ := client.GetAccessKey()
+	assert.Equal(t, "SCWXXXXXXXXXXXXXXXXX", accessKey)
+	zone, _ := client.GetDefaultZone()
+	assert.Equal(t, scw.ZoneNlAms1, zone)
+}